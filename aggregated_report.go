@@ -0,0 +1,25 @@
+package ginkgo
+
+import (
+	"github.com/onsi/ginkgo/internal/global"
+	"github.com/onsi/ginkgo/types"
+)
+
+//CurrentAggregatedReport returns the report of the suite run so far: the specs completed by all the parallel nodes, and how
+//many of them passed, failed, are pending or were skipped.  Specs can adapt to how the run is going, e.g. skip
+//expensive diagnostics once many specs have failed:
+//
+//	if CurrentAggregatedReport().Failed > 10 {
+//		Skip("too many failures to gather diagnostics")
+//	}
+//
+//The report lags behind the other nodes by the time it takes them to report their specs.  When running serially it
+//covers the specs of the process.  Fetching the report fails the running spec if the server run by the Ginkgo CLI
+//cannot be reached.
+func CurrentAggregatedReport() types.AggregatedReport {
+	report, err := global.Suite.AggregatedReport()
+	if err != nil {
+		Fail(err.Error(), 1)
+	}
+	return report
+}
@@ -15,6 +15,9 @@ package config
 
 import (
 	"flag"
+	"os"
+	"sort"
+	"strings"
 	"time"
 
 	"fmt"
@@ -26,15 +29,48 @@ type GinkgoConfigType struct {
 	RandomSeed         int64
 	RandomizeAllSpecs  bool
 	RegexScansFilePath bool
+	SeedMathRand       bool
 	FocusStrings       []string
 	SkipStrings        []string
+	SkipFile           string
+	Features           []string
 	SkipMeasurements   bool
 	FailOnPending      bool
-	FailFast           bool
-	FlakeAttempts      int
-	EmitSpecProgress   bool
-	DryRun             bool
-	DebugParallel      bool
+	StrictPending      bool
+	FailOnEmpty        bool
+	FailOnSeverity     string
+
+	SlowContainerThreshold float64
+	FailOnSlowContainers   bool
+
+	DetectGoroutineFailures bool
+	NodeTimeout             float64
+	FailOnCleanupPanics     bool
+	RestoreEnvironment      bool
+	CheckInvariants         bool
+	Hermetic                bool
+	ArtifactsDir            string
+
+	RuntimeBudgetsFile   string
+	FailOnRuntimeBudgets bool
+
+	CodeOwnersFile string
+
+	FailFast              bool
+	FlakeAttempts         int
+	RetryOnPatterns       []string
+	Concurrency           int
+	EmitSpecProgress      bool
+	DryRun                bool
+	CleanupOnly           bool
+	DebugParallel         bool
+	ParallelDeterministic bool
+	TimingStoreFile       string
+	TimingStoreURL        string
+	SuiteLabels           []string
+	SuiteMetadata         map[string]string
+	UpdateSnapshots       bool
+	CustomFlags           map[string]string
 
 	ParallelNode  int
 	ParallelTotal int
@@ -44,16 +80,83 @@ type GinkgoConfigType struct {
 
 var GinkgoConfig = GinkgoConfigType{}
 
+//Copy returns a copy of the configuration that shares none of its slices and maps with it
+func (config GinkgoConfigType) Copy() GinkgoConfigType {
+	config.FocusStrings = copyStrings(config.FocusStrings)
+	config.SkipStrings = copyStrings(config.SkipStrings)
+	config.RetryOnPatterns = copyStrings(config.RetryOnPatterns)
+	config.Features = copyStrings(config.Features)
+	config.SuiteLabels = copyStrings(config.SuiteLabels)
+	config.SuiteMetadata = copyStringMap(config.SuiteMetadata)
+	config.CustomFlags = copyStringMap(config.CustomFlags)
+	return config
+}
+
+func copyStrings(values []string) []string {
+	if values == nil {
+		return nil
+	}
+	return append([]string{}, values...)
+}
+
+func copyStringMap(m map[string]string) map[string]string {
+	if m == nil {
+		return nil
+	}
+	result := make(map[string]string, len(m))
+	for key, value := range m {
+		result[key] = value
+	}
+	return result
+}
+
 type DefaultReporterConfigType struct {
 	NoColor           bool
 	SlowSpecThreshold float64
 	NoisyPendings     bool
 	NoisySkippings    bool
 	Succinct          bool
+	Quiet             bool
 	Verbose           bool
+	Follow            bool
 	FullTrace         bool
+	FullStackTraces   bool
+	StackTraceDepth   int
+	StackTraceFilter  string
 	ReportPassed      bool
+	GroupFailures     bool
+	Columns           int
+	NoWrap            bool
+	Glyphs            string
+	OutputLimit       int
+	StripANSI         bool
+	BinaryOutput      string
+	LateOutput        string
+	RedactPatterns    []string
+	RerunCommands     string
+	RerunScriptFile   string
 	ReportFile        string
+
+	JSONReportFile            string
+	HistoryDir                string
+	BaselineReportFile        string
+	BaselineDiffFile          string
+	BaselineDurationThreshold float64
+
+	MetricsAddress        string
+	MetricsPushgatewayURL string
+	MetricsJob            string
+
+	ProgressAddress string
+
+	NotifyWebhookURL   string
+	NotifyTemplateFile string
+	NotifyArtifactsURL string
+
+	AllureResultsDir   string
+	SonarReportFile    string
+	XUnitV2ReportFile  string
+	CucumberReportFile string
 }
 
 var DefaultReporterConfig = DefaultReporterConfigType{}
@@ -74,22 +177,55 @@ func Flags(flagSet *flag.FlagSet, prefix string, includeParallelFlags bool) {
 	prefix = processPrefix(prefix)
 	flagSet.Int64Var(&(GinkgoConfig.RandomSeed), prefix+"seed", time.Now().Unix(), "The seed used to randomize the spec suite.")
 	flagSet.BoolVar(&(GinkgoConfig.RandomizeAllSpecs), prefix+"randomizeAllSpecs", false, "If set, ginkgo will randomize all specs together.  By default, ginkgo only randomizes the top level Describe, Context and When groups.")
+	flagSet.BoolVar(&(GinkgoConfig.SeedMathRand), prefix+"seedMathRand", false, "If set, ginkgo will seed math/rand before each spec with a seed derived from -seed and the spec's text, so that specs relying on math/rand can be reproduced.")
 	flagSet.BoolVar(&(GinkgoConfig.SkipMeasurements), prefix+"skipMeasurements", false, "If set, ginkgo will skip any measurement specs.")
 	flagSet.BoolVar(&(GinkgoConfig.FailOnPending), prefix+"failOnPending", false, "If set, ginkgo will mark the test suite as failed if any specs are pending.")
+	flagSet.BoolVar(&(GinkgoConfig.StrictPending), prefix+"strictPending", false, "If set, Its declared without a body, such as It(\"does something later\"), fail instead of being pending.")
+	flagSet.BoolVar(&(GinkgoConfig.FailOnEmpty), prefix+"failOnEmpty", false, "If set, ginkgo will mark the test suite as failed if no spec is left to run once the focus, skip and label filters are applied.")
+	flagSet.StringVar(&(GinkgoConfig.FailOnSeverity), prefix+"failOnSeverity", "", "If set to critical, high, medium or low, the failures of specs of a lower severity are reported but do not fail the test suite.  Failures of specs without a severity always fail it.")
+	flagSet.Float64Var(&(GinkgoConfig.SlowContainerThreshold), prefix+"slowContainerThreshold", 1.0, "(in seconds) Containers whose body takes longer than this threshold to build the spec tree are flagged with a warning, as their slow work runs even for the specs that are filtered out.  0 disables the check.")
+	flagSet.BoolVar(&(GinkgoConfig.FailOnSlowContainers), prefix+"failOnSlowContainers", false, "If set, ginkgo will mark the test suite as failed if a container is flagged by -slowContainerThreshold.")
+	flagSet.BoolVar(&(GinkgoConfig.DetectGoroutineFailures), prefix+"detectGoroutineFailures", false, "If set, ginkgo will turn failures raised from goroutines started by specs that do not defer GinkgoRecover() into spec failures, stopping the goroutine, instead of letting them crash the test binary.")
+	flagSet.Float64Var(&(GinkgoConfig.NodeTimeout), prefix+"nodeTimeout", 0, "(in seconds) If set, any node (BeforeSuite, BeforeEach, It, AfterEach...) running longer than this is timed out, with the stacks of all goroutines in its failure, so that a hung node does not stall the whole suite.")
+	flagSet.BoolVar(&(GinkgoConfig.FailOnCleanupPanics), prefix+"failOnCleanupPanics", false, "If set, cleanups registered with DeferCleanup that panic fail their spec.  Otherwise their panics are only reported in the output and timeline of the spec.")
+	flagSet.BoolVar(&(GinkgoConfig.RestoreEnvironment), prefix+"restoreEnvironment", false, "If set, the environment variables are restored after each spec, and the changes a spec did not make with GinkgoSetenv are reported as leaks.  As the environment is shared by the whole process, specs then never run concurrently with -concurrency.")
+	flagSet.BoolVar(&(GinkgoConfig.CheckInvariants), prefix+"checkInvariants", false, "If set, the suite invariants registered with RegisterSuiteInvariant are checked after every spec, and the first spec to violate each of them fails.  Specs then never run concurrently with -concurrency.")
+	flagSet.BoolVar(&(GinkgoConfig.Hermetic), prefix+"hermetic", false, "If set, specs that open network connections, or open files for writing outside the temporary directory and -artifactsDir, fail with the list of what they did, unless it is allowed by HermeticAllow.  Only enforced on Linux, by watching the file descriptors of the process: specs then never run concurrently with -concurrency.")
+	flagSet.StringVar(&(GinkgoConfig.ArtifactsDir), prefix+"artifactsDir", "", "If set, the directory the specs write their artifacts (logs, screenshots...) to.  -hermetic lets specs write files under it.")
+	flagSet.StringVar(&(GinkgoConfig.RuntimeBudgetsFile), prefix+"runtimeBudgets", "", "If set, ginkgo will check the run time of the specs against the budgets of their labels in this file, one label<=duration (e.g. unit<=100ms) per line.  Specs over budget are flagged with a warning and counted in the suite summary.")
+	flagSet.BoolVar(&(GinkgoConfig.FailOnRuntimeBudgets), prefix+"failOnRuntimeBudgets", false, "If set, specs that run longer than the budget of their label in -runtimeBudgets fail instead of being flagged with a warning.")
 	flagSet.BoolVar(&(GinkgoConfig.FailFast), prefix+"failFast", false, "If set, ginkgo will stop running a test suite after a failure occurs.")
 
 	flagSet.BoolVar(&(GinkgoConfig.DryRun), prefix+"dryRun", false, "If set, ginkgo will walk the test hierarchy without actually running anything.  Best paired with -v.")
+	flagSet.BoolVar(&(GinkgoConfig.CleanupOnly), prefix+"cleanupOnly", false, "If set, ginkgo will skip the WarmUp, BeforeSuite, BeforeEach, JustBeforeEach and It bodies, and only run the JustAfterEach, AfterEach and AfterSuite nodes of the specs, to clean up the external resources left behind by an interrupted or crashed run.")
 
 	flagSet.Var(flagFunc(flagFocus), prefix+"focus", "If set, ginkgo will only run specs that match this regular expression. Can be specified multiple times, values are ORed.")
 	flagSet.Var(flagFunc(flagSkip), prefix+"skip", "If set, ginkgo will only run specs that do not match this regular expression. Can be specified multiple times, values are ORed.")
 
+	flagSet.StringVar(&(GinkgoConfig.SkipFile), prefix+"skipFile", "", "If set, ginkgo will skip the specs matching the entries of this skip list, one regular expression per line optionally followed by '; expires=YYYY-MM-DD' and '; reason=...'.  Expired entries no longer skip specs and produce a warning.")
+
+	flagSet.Var(flagFunc(flagFeatures), prefix+"features", "A comma-separated list of the features (e.g. gpu,ipv6) the environment the suite runs against provides.  Specs decorated with RequiresFeature that require another feature are skipped. Can be specified multiple times.")
+
+	flagSet.StringVar(&(GinkgoConfig.CodeOwnersFile), prefix+"codeOwners", "", "If set, ginkgo will read the owners of the specs that are not decorated with Owner from this CODEOWNERS file, by the file the spec is in.")
+
 	flagSet.BoolVar(&(GinkgoConfig.RegexScansFilePath), prefix+"regexScansFilePath", false, "If set, ginkgo regex matching also will look at the file path (code location).")
 
+	flagSet.IntVar(&(GinkgoConfig.Concurrency), prefix+"concurrency", 1, "EXPERIMENTAL: run up to this many specs concurrently, on goroutines, within each process.  Specs decorated with Serial run on their own.")
 	flagSet.IntVar(&(GinkgoConfig.FlakeAttempts), prefix+"flakeAttempts", 1, "Make up to this many attempts to run each spec. Please note that if any of the attempts succeed, the suite will not be failed. But any failures will still be recorded.")
+	flagSet.Var(flagFunc(flagRetryOn), prefix+"retryOn", "If set, -flakeAttempts only retries the specs whose failure message matches this regular expression (e.g. 'connection refused|status 503'), so that retries do not hide genuine failures.  Specs decorated with RetryOn use their own patterns instead. Can be specified multiple times, values are ORed.")
 
 	flagSet.BoolVar(&(GinkgoConfig.EmitSpecProgress), prefix+"progress", false, "If set, ginkgo will emit progress information as each spec runs to the GinkgoWriter.")
 
 	flagSet.BoolVar(&(GinkgoConfig.DebugParallel), prefix+"debug", false, "If set, ginkgo will emit node output to files when running in parallel.")
+	flagSet.BoolVar(&(GinkgoConfig.ParallelDeterministic), prefix+"parallelDeterministic", false, "If set, parallel nodes stop stealing work from each other: given the same seed and number of nodes, each node runs exactly the same specs in the same order, so that failures that only happen in parallel can be reproduced.")
+
+	flagSet.StringVar(&(GinkgoConfig.TimingStoreFile), prefix+"timingStore", "", "If set, ginkgo will read historical spec run times from this JSON file and record the run times of this run in it.")
+	flagSet.StringVar(&(GinkgoConfig.TimingStoreURL), prefix+"timingStoreURL", "", "If set, ginkgo will fetch historical spec run times from this HTTP endpoint and post the report of this run to it.  Takes precedence over -timingStore.")
+
+	flagSet.Var(flagFunc(flagSuiteLabel), prefix+"suiteLabel", "If set, ginkgo will add this label to the suite's labels in the reports of the suite run. Can be specified multiple times.")
+	flagSet.Var(flagFunc(flagSuiteMetadata), prefix+"suiteMetadata", "A key=value pair (e.g. gitSHA=abc123) that ginkgo will add to the suite's metadata in the reports of the suite run. Can be specified multiple times.")
+
+	flagSet.BoolVar(&(GinkgoConfig.UpdateSnapshots), prefix+"updateSnapshots", false, "If set, MatchSnapshot will rewrite the snapshots under testdata/__snapshots__ with the data it is given instead of comparing them.")
 
 	if includeParallelFlags {
 		flagSet.IntVar(&(GinkgoConfig.ParallelNode), prefix+"parallel.node", 1, "This worker node's (one-indexed) node number.  For running specs in parallel.")
@@ -103,10 +239,42 @@ func Flags(flagSet *flag.FlagSet, prefix string, includeParallelFlags bool) {
 	flagSet.BoolVar(&(DefaultReporterConfig.NoisyPendings), prefix+"noisyPendings", true, "If set, default reporter will shout about pending tests.")
 	flagSet.BoolVar(&(DefaultReporterConfig.NoisySkippings), prefix+"noisySkippings", true, "If set, default reporter will shout about skipping tests.")
 	flagSet.BoolVar(&(DefaultReporterConfig.Verbose), prefix+"v", false, "If set, default reporter print out all specs as they begin.")
+	flagSet.BoolVar(&(DefaultReporterConfig.Follow), prefix+"follow", false, "If set, what the running spec writes to GinkgoWriter is streamed to the console as it is written rather than only shown when the spec fails.  Reports still capture it.  Pair it with -focus to follow one spec.")
 	flagSet.BoolVar(&(DefaultReporterConfig.Succinct), prefix+"succinct", false, "If set, default reporter prints out a very succinct report")
+	flagSet.BoolVar(&(DefaultReporterConfig.Quiet), prefix+"quiet", false, "If set, default reporter prints nothing but the failures, as they happen, and the summary of the suite, e.g. for massive suites.  It takes precedence over -v and -succinct.  Reports are still complete.")
 	flagSet.BoolVar(&(DefaultReporterConfig.FullTrace), prefix+"trace", false, "If set, default reporter prints out the full stack trace when a failure occurs")
+	flagSet.BoolVar(&(DefaultReporterConfig.FullStackTraces), prefix+"fullStackTraces", false, "If set, stack traces keep the frames from Ginkgo, the testing package and the Go runtime rather than trimming them, on the console and in reports")
+	flagSet.IntVar(&(DefaultReporterConfig.StackTraceDepth), prefix+"stackTraceDepth", 0, "If set, stack traces keep at most this many frames, on the console and in reports")
+	flagSet.StringVar(&(DefaultReporterConfig.StackTraceFilter), prefix+"stackTraceFilter", "", "A comma-separated list of path fragments (e.g. vendor/).  Stack traces hide the frames whose source file contains one of them, on the console and in reports")
 	flagSet.BoolVar(&(DefaultReporterConfig.ReportPassed), prefix+"reportPassed", false, "If set, default reporter prints out captured output of passed tests.")
+	flagSet.BoolVar(&(DefaultReporterConfig.GroupFailures), prefix+"groupFailures", false, "If set, default reporter summarizes every failure clustered with the others that share its fingerprint, along with the fingerprint, from the most frequent failure on.")
+	flagSet.IntVar(&(DefaultReporterConfig.Columns), prefix+"columns", 0, "The width, in columns, the default reporter wraps failure messages and progress output at.  By default, it is the width of the terminal, or $COLUMNS, and output that does not go to a terminal is not wrapped.")
+	flagSet.BoolVar(&(DefaultReporterConfig.NoWrap), prefix+"noWrap", false, "If set, the default reporter never wraps its output, e.g. when piping it into a log system that folds long lines itself.  It takes precedence over -columns.")
+	flagSet.StringVar(&(DefaultReporterConfig.Glyphs), prefix+"glyphs", os.Getenv("GINKGO_GLYPHS"), "The glyphs the default reporter marks the specs with: ascii for ASCII-only glyphs, for terminals and logs that mangle unicode, and/or comma-separated state=glyph pairs (e.g. passed=✓,failed=✗) for the states passed, failed, panicked, timedOut, pending and skipped.  Defaults to $GINKGO_GLYPHS.")
+	flagSet.IntVar(&(DefaultReporterConfig.OutputLimit), prefix+"outputLimit", 0, "(in bytes) If set, the output each spec writes to GinkgoWriter is capped to this many bytes: its beginning and its end are kept, and an \"output truncated (N bytes dropped)\" marker replaces the rest, on the console and in reports.")
+	flagSet.BoolVar(&(DefaultReporterConfig.StripANSI), prefix+"stripANSI", false, "If set, ANSI escape codes (colors, cursor moves) are stripped from the output captured for reports and failures.  Output streamed live to the console with -v keeps them.")
+	flagSet.StringVar(&(DefaultReporterConfig.BinaryOutput), prefix+"binaryOutput", "", "If set to hex, binary garbage in the output captured for reports and failures (invalid UTF-8, control characters) is written as \\xNN escapes.  If set to elide, it is replaced with a note of its length.  Either keeps JSON and XML reports valid.")
+	flagSet.StringVar(&(DefaultReporterConfig.LateOutput), prefix+"lateOutput", "off", "What to do with the output goroutines started by a spec write to GinkgoWriter after the spec ended, which would otherwise bleed into the output of later specs: leave it alone (off), tag it with the spec (tag) or drop it (drop).  When tagged or dropped, a warning with the stack of the late writer is printed.  Telling late output apart takes running each spec on a goroutine of its own, and looking up the goroutine that started each writer.")
+	flagSet.Var(flagFunc(flagRedact), prefix+"redact", "If set, what this regular expression matches in the output captured for reports and failures (e.g. tokens or passwords) is replaced with [REDACTED]. Can be specified multiple times.")
+	flagSet.StringVar(&(DefaultReporterConfig.RerunCommands), prefix+"rerunCommands", "ginkgo", "How the commands rerunning each failed spec, with the seed of the run, are printed once the suite ends: as ginkgo commands (ginkgo), as go test commands (go), or not at all (none).  JSON reports include them either way.")
+	flagSet.StringVar(&(DefaultReporterConfig.RerunScriptFile), prefix+"rerunScript", "", "If set, ginkgo will write a shell script rerunning exactly the specs that failed, with the seed of the run, to this file.")
 	flagSet.StringVar(&(DefaultReporterConfig.ReportFile), prefix+"reportFile", "", "Override the default reporter output file path.")
+	flagSet.StringVar(&(DefaultReporterConfig.JSONReportFile), prefix+"jsonReport", "", "If set, ginkgo will write a JSON report of the suite run to this file.")
+	flagSet.StringVar(&(DefaultReporterConfig.HistoryDir), prefix+"historyDir", "", "If set, ginkgo will store the JSON report of the suite run in this directory (e.g. .ginkgo-history, which ginkgo history reads by default) to keep a history of the runs.")
+	flagSet.StringVar(&(DefaultReporterConfig.BaselineReportFile), prefix+"baselineReport", "", "If set, ginkgo will compare the suite run against this previously generated JSON report and summarize regressions, fixes and duration changes.")
+	flagSet.StringVar(&(DefaultReporterConfig.BaselineDiffFile), prefix+"baselineDiffFile", "", "If set along with -baselineReport, ginkgo will write the comparison against the baseline to this file as JSON.")
+	flagSet.Float64Var(&(DefaultReporterConfig.BaselineDurationThreshold), prefix+"baselineDurationThreshold", 1.0, "(in seconds) Specs whose run time changed by more than this threshold relative to the baseline report are flagged.")
+	flagSet.StringVar(&(DefaultReporterConfig.MetricsAddress), prefix+"metricsAddress", "", "If set, ginkgo will serve Prometheus metrics about the suite run on this address (e.g. :9090) under /metrics while the suite runs.")
+	flagSet.StringVar(&(DefaultReporterConfig.MetricsPushgatewayURL), prefix+"metricsPushgateway", "", "If set, ginkgo will push Prometheus metrics about the suite run to the Pushgateway at this URL once the suite ends.")
+	flagSet.StringVar(&(DefaultReporterConfig.MetricsJob), prefix+"metricsJob", "ginkgo", "The job name used when pushing metrics to the Pushgateway.")
+	flagSet.StringVar(&(DefaultReporterConfig.ProgressAddress), prefix+"progressAddress", "", "If set, ginkgo will serve the progress of the suite run as JSON on this address (e.g. :8080) under /progress, /specs and /report while the suite runs.")
+	flagSet.StringVar(&(DefaultReporterConfig.NotifyWebhookURL), prefix+"notifyWebhook", "", "If set, ginkgo will post a summary of the suite run, including failures, to this webhook (e.g. a Slack incoming webhook) once the suite ends.")
+	flagSet.StringVar(&(DefaultReporterConfig.NotifyTemplateFile), prefix+"notifyTemplate", "", "If set, the payload posted to -notifyWebhook is rendered from this Go text/template file instead of the default Slack message.")
+	flagSet.StringVar(&(DefaultReporterConfig.AllureResultsDir), prefix+"allureResultsDir", "", "If set, ginkgo will write the results of the suite run to this directory in the Allure 2 format.")
+	flagSet.StringVar(&(DefaultReporterConfig.SonarReportFile), prefix+"sonarReport", "", "If set, ginkgo will write a SonarQube Generic Test Execution report of the suite run to this file.")
+	flagSet.StringVar(&(DefaultReporterConfig.XUnitV2ReportFile), prefix+"xunitV2Report", "", "If set, ginkgo will write an xUnit.net v2 XML report of the suite run to this file.")
+	flagSet.StringVar(&(DefaultReporterConfig.CucumberReportFile), prefix+"cucumberReport", "", "If set, ginkgo will write a Cucumber JSON report of the suite run, including the Given/WhenStep/Then steps of the specs, to this file.")
+	flagSet.StringVar(&(DefaultReporterConfig.NotifyArtifactsURL), prefix+"notifyArtifactsURL", "", "If set, the notification posted to -notifyWebhook links to this URL.  {spec} is replaced by the text of each failed spec.")
 
 }
 
@@ -122,6 +290,10 @@ func BuildFlagArgs(prefix string, ginkgo GinkgoConfigType, reporter DefaultRepor
 		result = append(result, fmt.Sprintf("--%srandomizeAllSpecs", prefix))
 	}
 
+	if ginkgo.SeedMathRand {
+		result = append(result, fmt.Sprintf("--%sseedMathRand", prefix))
+	}
+
 	if ginkgo.SkipMeasurements {
 		result = append(result, fmt.Sprintf("--%sskipMeasurements", prefix))
 	}
@@ -130,6 +302,60 @@ func BuildFlagArgs(prefix string, ginkgo GinkgoConfigType, reporter DefaultRepor
 		result = append(result, fmt.Sprintf("--%sfailOnPending", prefix))
 	}
 
+	if ginkgo.StrictPending {
+		result = append(result, fmt.Sprintf("--%sstrictPending", prefix))
+	}
+
+	if ginkgo.FailOnEmpty {
+		result = append(result, fmt.Sprintf("--%sfailOnEmpty", prefix))
+	}
+
+	if ginkgo.FailOnSeverity != "" {
+		result = append(result, fmt.Sprintf("--%sfailOnSeverity=%s", prefix, ginkgo.FailOnSeverity))
+	}
+
+	result = append(result, fmt.Sprintf("--%sslowContainerThreshold=%.5f", prefix, ginkgo.SlowContainerThreshold))
+
+	if ginkgo.FailOnSlowContainers {
+		result = append(result, fmt.Sprintf("--%sfailOnSlowContainers", prefix))
+	}
+
+	if ginkgo.DetectGoroutineFailures {
+		result = append(result, fmt.Sprintf("--%sdetectGoroutineFailures", prefix))
+	}
+
+	if ginkgo.FailOnCleanupPanics {
+		result = append(result, fmt.Sprintf("--%sfailOnCleanupPanics", prefix))
+	}
+
+	if ginkgo.RestoreEnvironment {
+		result = append(result, fmt.Sprintf("--%srestoreEnvironment", prefix))
+	}
+
+	if ginkgo.CheckInvariants {
+		result = append(result, fmt.Sprintf("--%scheckInvariants", prefix))
+	}
+
+	if ginkgo.Hermetic {
+		result = append(result, fmt.Sprintf("--%shermetic", prefix))
+	}
+
+	if ginkgo.ArtifactsDir != "" {
+		result = append(result, fmt.Sprintf("--%sartifactsDir=%s", prefix, ginkgo.ArtifactsDir))
+	}
+
+	if ginkgo.RuntimeBudgetsFile != "" {
+		result = append(result, fmt.Sprintf("--%sruntimeBudgets=%s", prefix, ginkgo.RuntimeBudgetsFile))
+	}
+
+	if ginkgo.FailOnRuntimeBudgets {
+		result = append(result, fmt.Sprintf("--%sfailOnRuntimeBudgets", prefix))
+	}
+
+	if ginkgo.NodeTimeout > 0 {
+		result = append(result, fmt.Sprintf("--%snodeTimeout=%.5f", prefix, ginkgo.NodeTimeout))
+	}
+
 	if ginkgo.FailFast {
 		result = append(result, fmt.Sprintf("--%sfailFast", prefix))
 	}
@@ -138,6 +364,10 @@ func BuildFlagArgs(prefix string, ginkgo GinkgoConfigType, reporter DefaultRepor
 		result = append(result, fmt.Sprintf("--%sdryRun", prefix))
 	}
 
+	if ginkgo.CleanupOnly {
+		result = append(result, fmt.Sprintf("--%scleanupOnly", prefix))
+	}
+
 	for _, s := range ginkgo.FocusStrings {
 		result = append(result, fmt.Sprintf("--%sfocus=%s", prefix, s))
 	}
@@ -146,10 +376,30 @@ func BuildFlagArgs(prefix string, ginkgo GinkgoConfigType, reporter DefaultRepor
 		result = append(result, fmt.Sprintf("--%sskip=%s", prefix, s))
 	}
 
+	if ginkgo.SkipFile != "" {
+		result = append(result, fmt.Sprintf("--%sskipFile=%s", prefix, ginkgo.SkipFile))
+	}
+
+	if len(ginkgo.Features) > 0 {
+		result = append(result, fmt.Sprintf("--%sfeatures=%s", prefix, strings.Join(ginkgo.Features, ",")))
+	}
+
+	if ginkgo.CodeOwnersFile != "" {
+		result = append(result, fmt.Sprintf("--%scodeOwners=%s", prefix, ginkgo.CodeOwnersFile))
+	}
+
 	if ginkgo.FlakeAttempts > 1 {
 		result = append(result, fmt.Sprintf("--%sflakeAttempts=%d", prefix, ginkgo.FlakeAttempts))
 	}
 
+	for _, pattern := range ginkgo.RetryOnPatterns {
+		result = append(result, fmt.Sprintf("--%sretryOn=%s", prefix, pattern))
+	}
+
+	if ginkgo.Concurrency > 1 {
+		result = append(result, fmt.Sprintf("--%sconcurrency=%d", prefix, ginkgo.Concurrency))
+	}
+
 	if ginkgo.EmitSpecProgress {
 		result = append(result, fmt.Sprintf("--%sprogress", prefix))
 	}
@@ -158,6 +408,35 @@ func BuildFlagArgs(prefix string, ginkgo GinkgoConfigType, reporter DefaultRepor
 		result = append(result, fmt.Sprintf("--%sdebug", prefix))
 	}
 
+	if ginkgo.ParallelDeterministic {
+		result = append(result, fmt.Sprintf("--%sparallelDeterministic", prefix))
+	}
+
+	if ginkgo.TimingStoreFile != "" {
+		result = append(result, fmt.Sprintf("--%stimingStore=%s", prefix, ginkgo.TimingStoreFile))
+	}
+
+	if ginkgo.TimingStoreURL != "" {
+		result = append(result, fmt.Sprintf("--%stimingStoreURL=%s", prefix, ginkgo.TimingStoreURL))
+	}
+
+	for _, label := range ginkgo.SuiteLabels {
+		result = append(result, fmt.Sprintf("--%ssuiteLabel=%s", prefix, label))
+	}
+
+	metadataKeys := []string{}
+	for key := range ginkgo.SuiteMetadata {
+		metadataKeys = append(metadataKeys, key)
+	}
+	sort.Strings(metadataKeys)
+	for _, key := range metadataKeys {
+		result = append(result, fmt.Sprintf("--%ssuiteMetadata=%s=%s", prefix, key, ginkgo.SuiteMetadata[key]))
+	}
+
+	if ginkgo.UpdateSnapshots {
+		result = append(result, fmt.Sprintf("--%supdateSnapshots", prefix))
+	}
+
 	if ginkgo.ParallelNode != 0 {
 		result = append(result, fmt.Sprintf("--%sparallel.node=%d", prefix, ginkgo.ParallelNode))
 	}
@@ -198,22 +477,150 @@ func BuildFlagArgs(prefix string, ginkgo GinkgoConfigType, reporter DefaultRepor
 		result = append(result, fmt.Sprintf("--%sv", prefix))
 	}
 
+	if reporter.Follow {
+		result = append(result, fmt.Sprintf("--%sfollow", prefix))
+	}
+
 	if reporter.Succinct {
 		result = append(result, fmt.Sprintf("--%ssuccinct", prefix))
 	}
 
+	if reporter.Quiet {
+		result = append(result, fmt.Sprintf("--%squiet", prefix))
+	}
+
 	if reporter.FullTrace {
 		result = append(result, fmt.Sprintf("--%strace", prefix))
 	}
 
+	if reporter.FullStackTraces {
+		result = append(result, fmt.Sprintf("--%sfullStackTraces", prefix))
+	}
+
+	if reporter.StackTraceDepth > 0 {
+		result = append(result, fmt.Sprintf("--%sstackTraceDepth=%d", prefix, reporter.StackTraceDepth))
+	}
+
+	if reporter.StackTraceFilter != "" {
+		result = append(result, fmt.Sprintf("--%sstackTraceFilter=%s", prefix, reporter.StackTraceFilter))
+	}
+
 	if reporter.ReportPassed {
 		result = append(result, fmt.Sprintf("--%sreportPassed", prefix))
 	}
 
+	if reporter.GroupFailures {
+		result = append(result, fmt.Sprintf("--%sgroupFailures", prefix))
+	}
+
+	if reporter.Columns > 0 {
+		result = append(result, fmt.Sprintf("--%scolumns=%d", prefix, reporter.Columns))
+	}
+
+	if reporter.NoWrap {
+		result = append(result, fmt.Sprintf("--%snoWrap", prefix))
+	}
+
+	if reporter.Glyphs != "" {
+		result = append(result, fmt.Sprintf("--%sglyphs=%s", prefix, reporter.Glyphs))
+	}
+
+	if reporter.OutputLimit > 0 {
+		result = append(result, fmt.Sprintf("--%soutputLimit=%d", prefix, reporter.OutputLimit))
+	}
+
+	if reporter.StripANSI {
+		result = append(result, fmt.Sprintf("--%sstripANSI", prefix))
+	}
+
+	if reporter.BinaryOutput != "" {
+		result = append(result, fmt.Sprintf("--%sbinaryOutput=%s", prefix, reporter.BinaryOutput))
+	}
+
+	if reporter.LateOutput != "" && reporter.LateOutput != "off" {
+		result = append(result, fmt.Sprintf("--%slateOutput=%s", prefix, reporter.LateOutput))
+	}
+
+	for _, pattern := range reporter.RedactPatterns {
+		result = append(result, fmt.Sprintf("--%sredact=%s", prefix, pattern))
+	}
+
+	if reporter.RerunCommands != "" && reporter.RerunCommands != "ginkgo" {
+		result = append(result, fmt.Sprintf("--%srerunCommands=%s", prefix, reporter.RerunCommands))
+	}
+
+	if reporter.RerunScriptFile != "" {
+		result = append(result, fmt.Sprintf("--%srerunScript=%s", prefix, reporter.RerunScriptFile))
+	}
+
 	if reporter.ReportFile != "" {
 		result = append(result, fmt.Sprintf("--%sreportFile=%s", prefix, reporter.ReportFile))
 	}
 
+	if reporter.JSONReportFile != "" {
+		result = append(result, fmt.Sprintf("--%sjsonReport=%s", prefix, reporter.JSONReportFile))
+	}
+
+	if reporter.HistoryDir != "" {
+		result = append(result, fmt.Sprintf("--%shistoryDir=%s", prefix, reporter.HistoryDir))
+	}
+
+	if reporter.BaselineReportFile != "" {
+		result = append(result, fmt.Sprintf("--%sbaselineReport=%s", prefix, reporter.BaselineReportFile))
+	}
+
+	if reporter.BaselineDiffFile != "" {
+		result = append(result, fmt.Sprintf("--%sbaselineDiffFile=%s", prefix, reporter.BaselineDiffFile))
+	}
+
+	if reporter.BaselineDurationThreshold > 0 {
+		result = append(result, fmt.Sprintf("--%sbaselineDurationThreshold=%.5f", prefix, reporter.BaselineDurationThreshold))
+	}
+
+	if reporter.MetricsAddress != "" {
+		result = append(result, fmt.Sprintf("--%smetricsAddress=%s", prefix, reporter.MetricsAddress))
+	}
+
+	if reporter.MetricsPushgatewayURL != "" {
+		result = append(result, fmt.Sprintf("--%smetricsPushgateway=%s", prefix, reporter.MetricsPushgatewayURL))
+	}
+
+	if reporter.MetricsJob != "" && reporter.MetricsJob != "ginkgo" {
+		result = append(result, fmt.Sprintf("--%smetricsJob=%s", prefix, reporter.MetricsJob))
+	}
+
+	if reporter.ProgressAddress != "" {
+		result = append(result, fmt.Sprintf("--%sprogressAddress=%s", prefix, reporter.ProgressAddress))
+	}
+
+	if reporter.NotifyWebhookURL != "" {
+		result = append(result, fmt.Sprintf("--%snotifyWebhook=%s", prefix, reporter.NotifyWebhookURL))
+	}
+
+	if reporter.NotifyTemplateFile != "" {
+		result = append(result, fmt.Sprintf("--%snotifyTemplate=%s", prefix, reporter.NotifyTemplateFile))
+	}
+
+	if reporter.NotifyArtifactsURL != "" {
+		result = append(result, fmt.Sprintf("--%snotifyArtifactsURL=%s", prefix, reporter.NotifyArtifactsURL))
+	}
+
+	if reporter.AllureResultsDir != "" {
+		result = append(result, fmt.Sprintf("--%sallureResultsDir=%s", prefix, reporter.AllureResultsDir))
+	}
+
+	if reporter.SonarReportFile != "" {
+		result = append(result, fmt.Sprintf("--%ssonarReport=%s", prefix, reporter.SonarReportFile))
+	}
+
+	if reporter.XUnitV2ReportFile != "" {
+		result = append(result, fmt.Sprintf("--%sxunitV2Report=%s", prefix, reporter.XUnitV2ReportFile))
+	}
+
+	if reporter.CucumberReportFile != "" {
+		result = append(result, fmt.Sprintf("--%scucumberReport=%s", prefix, reporter.CucumberReportFile))
+	}
+
 	return result
 }
 
@@ -230,3 +637,49 @@ func flagSkip(arg string) {
 		GinkgoConfig.SkipStrings = append(GinkgoConfig.SkipStrings, arg)
 	}
 }
+
+// flagFeatures implements the -features flag.
+func flagFeatures(arg string) {
+	for _, feature := range strings.Split(arg, ",") {
+		if feature = strings.TrimSpace(feature); feature != "" {
+			GinkgoConfig.Features = append(GinkgoConfig.Features, feature)
+		}
+	}
+}
+
+// flagRetryOn implements the -retryOn flag.
+func flagRetryOn(arg string) {
+	if arg != "" {
+		GinkgoConfig.RetryOnPatterns = append(GinkgoConfig.RetryOnPatterns, arg)
+	}
+}
+
+// flagRedact implements the -redact flag.
+func flagRedact(arg string) {
+	if arg != "" {
+		DefaultReporterConfig.RedactPatterns = append(DefaultReporterConfig.RedactPatterns, arg)
+	}
+}
+
+// flagSuiteLabel implements the -suiteLabel flag.
+func flagSuiteLabel(arg string) {
+	if arg != "" {
+		GinkgoConfig.SuiteLabels = append(GinkgoConfig.SuiteLabels, arg)
+	}
+}
+
+// flagSuiteMetadata implements the -suiteMetadata flag.
+func flagSuiteMetadata(arg string) {
+	if arg == "" {
+		return
+	}
+	if GinkgoConfig.SuiteMetadata == nil {
+		GinkgoConfig.SuiteMetadata = map[string]string{}
+	}
+	components := strings.SplitN(arg, "=", 2)
+	if len(components) == 1 {
+		GinkgoConfig.SuiteMetadata[components[0]] = ""
+	} else {
+		GinkgoConfig.SuiteMetadata[components[0]] = components[1]
+	}
+}
@@ -0,0 +1,123 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"time"
+)
+
+//CustomFlagSet registers the flags of the suite being run, e.g. a -slowEnvironment flag to lengthen its timeouts, with
+//the flags go test parses.  Its methods mirror those of flag.FlagSet.
+//
+//Flags must be registered before go test parses them: from an init function or a package-level variable declaration of
+//the suite.  Pass them after -- when running the suite with the Ginkgo CLI (e.g. ginkgo -- -slowEnvironment), which
+//forwards them to every parallel node.  -help lists them in a section of their own, and their values are made available
+//to specs through GinkgoConfigType.CustomFlags.
+type CustomFlagSet struct {
+	flagSet *flag.FlagSet
+}
+
+//CustomFlags holds the flags registered by the suite being run
+var CustomFlags = &CustomFlagSet{flagSet: flag.NewFlagSet("custom", flag.ContinueOnError)}
+
+//Var registers a flag with the given name and usage, whose value is held by value
+func (c *CustomFlagSet) Var(value flag.Value, name string, usage string) {
+	c.register(name, func() { c.flagSet.Var(value, name, usage) })
+}
+
+func (c *CustomFlagSet) BoolVar(p *bool, name string, value bool, usage string) {
+	c.register(name, func() { c.flagSet.BoolVar(p, name, value, usage) })
+}
+
+func (c *CustomFlagSet) Bool(name string, value bool, usage string) *bool {
+	p := new(bool)
+	c.BoolVar(p, name, value, usage)
+	return p
+}
+
+func (c *CustomFlagSet) StringVar(p *string, name string, value string, usage string) {
+	c.register(name, func() { c.flagSet.StringVar(p, name, value, usage) })
+}
+
+func (c *CustomFlagSet) String(name string, value string, usage string) *string {
+	p := new(string)
+	c.StringVar(p, name, value, usage)
+	return p
+}
+
+func (c *CustomFlagSet) IntVar(p *int, name string, value int, usage string) {
+	c.register(name, func() { c.flagSet.IntVar(p, name, value, usage) })
+}
+
+func (c *CustomFlagSet) Int(name string, value int, usage string) *int {
+	p := new(int)
+	c.IntVar(p, name, value, usage)
+	return p
+}
+
+func (c *CustomFlagSet) Float64Var(p *float64, name string, value float64, usage string) {
+	c.register(name, func() { c.flagSet.Float64Var(p, name, value, usage) })
+}
+
+func (c *CustomFlagSet) Float64(name string, value float64, usage string) *float64 {
+	p := new(float64)
+	c.Float64Var(p, name, value, usage)
+	return p
+}
+
+func (c *CustomFlagSet) DurationVar(p *time.Duration, name string, value time.Duration, usage string) {
+	c.register(name, func() { c.flagSet.DurationVar(p, name, value, usage) })
+}
+
+func (c *CustomFlagSet) Duration(name string, value time.Duration, usage string) *time.Duration {
+	p := new(time.Duration)
+	c.DurationVar(p, name, value, usage)
+	return p
+}
+
+//register defines a flag on the custom flag set with define, and mirrors it on the flags go test parses
+func (c *CustomFlagSet) register(name string, define func()) {
+	if flag.Parsed() {
+		panic(fmt.Sprintf("The custom flag -%s must be registered before flags are parsed, from an init function or a package-level variable declaration", name))
+	}
+	if flag.CommandLine.Lookup(name) != nil {
+		panic(fmt.Sprintf("The custom flag -%s is already defined", name))
+	}
+	define()
+	f := c.flagSet.Lookup(name)
+	flag.CommandLine.Var(f.Value, f.Name, f.Usage)
+}
+
+//Values returns the values of the custom flags, keyed by name
+func (c *CustomFlagSet) Values() map[string]string {
+	values := map[string]string{}
+	c.flagSet.VisitAll(func(f *flag.Flag) {
+		values[f.Name] = f.Value.String()
+	})
+	return values
+}
+
+//Usage prints the usage of the flags of flagSet to output, listing the custom flags in a section of their own
+func (c *CustomFlagSet) Usage(flagSet *flag.FlagSet, output io.Writer) {
+	builtIn := flag.NewFlagSet(flagSet.Name(), flag.ContinueOnError)
+	flagSet.VisitAll(func(f *flag.Flag) {
+		if c.flagSet.Lookup(f.Name) == nil {
+			builtIn.Var(f.Value, f.Name, f.Usage)
+			builtIn.Lookup(f.Name).DefValue = f.DefValue
+		}
+	})
+
+	fmt.Fprintf(output, "Usage of %s:\n", flagSet.Name())
+	builtIn.SetOutput(output)
+	builtIn.PrintDefaults()
+
+	hasCustomFlags := false
+	c.flagSet.VisitAll(func(*flag.Flag) { hasCustomFlags = true })
+	if !hasCustomFlags {
+		return
+	}
+	fmt.Fprintf(output, "\nCustom flags of the suite:\n")
+	c.flagSet.SetOutput(output)
+	c.flagSet.PrintDefaults()
+}
@@ -0,0 +1,687 @@
+package ginkgo
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/onsi/ginkgo/config"
+	"github.com/onsi/ginkgo/internal/annotations"
+	"github.com/onsi/ginkgo/internal/global"
+	"github.com/onsi/ginkgo/internal/hermetic"
+	"github.com/onsi/ginkgo/internal/suite"
+	"github.com/onsi/ginkgo/types"
+)
+
+//Labels decorate containers and specs.  A spec carries its own labels as well as the labels of all its containers.
+//
+//Labels are made available to reporters through SpecSummary.Labels and to the running spec through CurrentGinkgoTestDescription().
+type Labels []string
+
+//Label decorates a container or a spec with one or more labels.  Pass it after the body:
+//
+//	Describe("the storage layer", func() {
+//		It("survives a restart", func() {
+//			...
+//		}, Label("slow"))
+//	}, Label("integration", "storage"))
+//
+//Labels, owners and severities can also be annotated in the comment lines right above a container or a spec:
+//
+//	// ginkgo:label=slow,storage ginkgo:owner=team-x ginkgo:severity=high
+//	It("survives a restart", func() {
+//		...
+//	})
+//
+//The annotations are read from the source files when the spec tree is built: a test binary running where its sources
+//are not, e.g. one built with go test -c and run on another machine, silently has none of them.  Use decorators for
+//suites that run that way.
+func Label(labels ...string) Labels {
+	return Labels(labels)
+}
+
+//OncePerContainerDecorator is the type of the OncePerContainer decorator
+type OncePerContainerDecorator bool
+
+//OncePerContainer decorates a container so that its expensive setup is shared by its specs rather than repeated around each of them:
+//
+//	Describe("the search index", func() {
+//		BeforeEach(func() {
+//			index = buildIndex()
+//		})
+//
+//		AfterEach(func() {
+//			index.Destroy()
+//		})
+//		...
+//	}, OncePerContainer)
+//
+//The BeforeEach and JustBeforeEach nodes of the container run once, before the first of its specs that runs.  If one of them
+//fails, every spec of the container fails with the same failure.  The AfterEach and JustAfterEach nodes of the container run
+//once too, after the last spec of the run, just before AfterSuite.  Setup nodes of nested containers are not affected.
+//
+//When running in parallel, each parallel node process that runs specs of the container runs its setup and teardown once.
+const OncePerContainer = OncePerContainerDecorator(true)
+
+//SerialDecorator is the type of the Serial decorator
+type SerialDecorator bool
+
+//Serial decorates a container or a spec that must not run concurrently with other specs when running with -concurrency:
+//
+//	Describe("the global configuration", func() {
+//		...
+//	}, Serial)
+//
+//A Serial spec waits for the specs running concurrently to complete, and no other spec starts until it completes.  The
+//specs of a container decorated with OncePerContainer are Serial too, as they share the setup of the container.
+const Serial = SerialDecorator(true)
+
+//IsolateWorkingDirDecorator is the type of the IsolateWorkingDir decorator
+type IsolateWorkingDirDecorator bool
+
+//IsolateWorkingDir decorates a container or a spec whose specs each run in a temporary working directory of their own,
+//for the files they create with relative paths not to leak into the package directory or into other specs:
+//
+//	It("writes the report", func() {
+//		Ω(WriteReport("report.txt")).Should(Succeed())
+//	}, IsolateWorkingDir)
+//
+//The working directory is changed back, and the temporary directory removed, once the spec completes, including its
+//AfterEach nodes.  As the working directory is shared by the whole process, such specs are Serial: they never run
+//concurrently with other specs when running with -concurrency.
+const IsolateWorkingDir = IsolateWorkingDirDecorator(true)
+
+//HermeticAllowDecorator is the type of the HermeticAllow decorator
+type HermeticAllowDecorator []string
+
+//HermeticAllow decorates a container or a spec that may escape its sandbox under -hermetic, for the given hosts and
+//directories only:
+//
+//	It("fetches the fixtures from the local server", func() {
+//		...
+//	}, HermeticAllow("127.0.0.1", "./testdata"))
+//
+//Entries that are absolute paths, or that start with ./ or ../, are directories the specs may write files under.  The
+//others are hosts the specs may connect to or listen on: an IP address, an IP address and a port such as [::1]:8080, a
+//CIDR block such as 10.0.0.0/8, or * for any host.  A spec may do what the HermeticAllow decorators of all its nodes
+//allow.
+func HermeticAllow(entries ...string) HermeticAllowDecorator {
+	return HermeticAllowDecorator(entries)
+}
+
+//DependsOnDecorator is the type of the DependsOn decorator
+type DependsOnDecorator []string
+
+//DependsOn decorates a container or a spec that only makes sense once other specs passed.  Refer to a spec by its full
+//text (the texts of its containers and its own, joined by spaces), or to every spec carrying a label:
+//
+//	It("creates the account", func() {
+//		...
+//	})
+//
+//	It("logs into the account", func() {
+//		...
+//	}, DependsOn("creates the account"))
+//
+//	Describe("the dashboard", func() {
+//		...
+//	}, DependsOn("smoke"))
+//
+//Prerequisites run before the specs that depend on them, even with -randomizeAllSpecs.  A spec is skipped, with the name
+//of the prerequisite that did not pass, when one of its prerequisites fails, is skipped or is pending.  When running in
+//parallel, specs wait for their prerequisites to complete on the other parallel nodes.  The suite fails to start when a
+//reference matches no spec, or when specs depend on each other.
+func DependsOn(refs ...string) DependsOnDecorator {
+	return DependsOnDecorator(refs)
+}
+
+//OwnerDecorator is the type of the Owner decorator
+type OwnerDecorator []string
+
+//Owner decorates a container or a spec with the teams or people to turn to when it fails:
+//
+//	Describe("the billing service", func() {
+//		...
+//	}, Owner("team-billing"))
+//
+//A spec is owned by the owners of its innermost node decorated with Owner.  Specs that are not can be given the owners
+//of the file they are in by a CODEOWNERS file passed to -codeOwners.  Owners are made available to reporters through
+//SpecSummary.Owners, and the failures of the suite are summarized by owner.
+func Owner(owners ...string) OwnerDecorator {
+	return OwnerDecorator(owners)
+}
+
+//SeverityDecorator is the type of the Critical, High, Medium and Low decorators
+type SeverityDecorator types.Severity
+
+//Critical, High, Medium and Low decorate a container or a spec with how much its failure matters:
+//
+//	Describe("checkout", func() {
+//		It("charges the card", func() {
+//			...
+//		})
+//
+//		It("suggests related products", func() {
+//			...
+//		}, Low)
+//	}, Critical)
+//
+//A spec has the severity of its innermost node decorated with one.  With -failOnSeverity=high, the failures of Medium and
+//Low specs are reported but do not fail the suite; the failures of specs without a severity always do.  Severities are
+//made available to reporters through SpecSummary.Severity, and the failures of the suite are broken down by severity.
+const (
+	Critical = SeverityDecorator(types.SeverityCritical)
+	High     = SeverityDecorator(types.SeverityHigh)
+	Medium   = SeverityDecorator(types.SeverityMedium)
+	Low      = SeverityDecorator(types.SeverityLow)
+)
+
+//RequiresFeatureDecorator is the type of the RequiresFeature decorator
+type RequiresFeatureDecorator []string
+
+//RequiresFeature decorates a container or a spec that needs the environment the suite runs against to provide one or
+//more features, so that one suite can target environments that differ:
+//
+//	Describe("the GPU scheduler", func() {
+//		...
+//	}, RequiresFeature("gpu"))
+//
+//Pass the features the environment provides with -features=gpu,ipv6.  A spec requires the features of all its nodes
+//decorated with RequiresFeature, and is skipped, naming the first missing feature, when -features does not enable all of
+//them.  Required features are made available to reporters through SpecSummary.RequiredFeatures.
+func RequiresFeature(features ...string) RequiresFeatureDecorator {
+	return RequiresFeatureDecorator(features)
+}
+
+//RetryOnDecorator is the type of the RetryOn decorator
+type RetryOnDecorator func(message string) bool
+
+//RetryOn decorates a container or a spec so that -flakeAttempts only retries its specs when they fail for a known
+//transient reason.  Pass regular expressions matched against the failure message, as strings or *regexp.Regexp, or
+//predicates on the failure message, of type func(string) bool:
+//
+//	It("lists the buckets", func() {
+//		...
+//	}, RetryOn("connection refused", regexp.MustCompile(`status 5\d\d`)))
+//
+//A spec whose failure matches none of them is not retried, however many attempts are left, so that retries do not mask
+//genuine failures.  A spec follows its innermost node decorated with RetryOn.  Specs that are not decorated with it are
+//retried on the failures matching -retryOn, or on any failure when -retryOn is not set.
+func RetryOn(patterns ...interface{}) RetryOnDecorator {
+	if len(patterns) == 0 {
+		panic("RetryOn expects at least one pattern")
+	}
+	predicates := []func(string) bool{}
+	for _, pattern := range patterns {
+		switch pattern := pattern.(type) {
+		case string:
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				panic(fmt.Sprintf("RetryOn was passed an invalid regular expression %q: %s", pattern, err))
+			}
+			predicates = append(predicates, re.MatchString)
+		case *regexp.Regexp:
+			predicates = append(predicates, pattern.MatchString)
+		case func(string) bool:
+			predicates = append(predicates, pattern)
+		default:
+			panic(fmt.Sprintf("RetryOn expects regular expressions or func(string) bool predicates, got %#v", pattern))
+		}
+	}
+	return func(message string) bool {
+		for _, predicate := range predicates {
+			if predicate(message) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+//PollDecorator is the type of the Poll decorator
+type PollDecorator struct {
+	interval time.Duration
+	timeout  time.Duration
+}
+
+//Poll turns an It or a Specify into a spec that retries its body until it succeeds.  Pass it before the body, which
+//must return an error:
+//
+//	It("serves the new configuration", Poll(time.Second, time.Minute), func() error {
+//		return client.CheckConfiguration(expected)
+//	})
+//
+//The body is called every interval until it returns nil.  The spec fails when the body still returns an error once
+//timeout has elapsed.  Every failed attempt is recorded as a step of the spec, along with the error it returned.
+func Poll(interval time.Duration, timeout time.Duration) PollDecorator {
+	if interval <= 0 || timeout <= 0 {
+		panic(fmt.Sprintf("Poll expects a positive interval and timeout, got %s and %s", interval, timeout))
+	}
+	return PollDecorator{interval: interval, timeout: timeout}
+}
+
+//splitBody splits the arguments of an It or a Specify into its body and its decorators.  The body is nil when the spec
+//is declared without one, e.g. It("does something later").
+func splitBody(args []interface{}) (interface{}, []interface{}) {
+	if len(args) == 0 {
+		return nil, nil
+	}
+	if _, ok := args[0].(PollDecorator); ok {
+		return pollDecoratedBody(args[0], args[1:])
+	}
+	if args[0] == nil {
+		return nil, args[1:]
+	}
+	if reflect.TypeOf(args[0]).Kind() == reflect.Func {
+		return args[0], args[1:]
+	}
+	return nil, args
+}
+
+//pollDecoratedBody returns the body and the decorators of an It or a Specify, moving the Poll decorator, if it is passed
+//in place of the body, back among the decorators
+func pollDecoratedBody(body interface{}, decorators []interface{}) (interface{}, []interface{}) {
+	poll, ok := body.(PollDecorator)
+	if !ok || len(decorators) == 0 {
+		return body, decorators
+	}
+	return decorators[0], append([]interface{}{poll}, decorators[1:]...)
+}
+
+//pollingBody wraps the body of a spec decorated with Poll so that it is retried until it returns nil
+func pollingBody(poll *PollDecorator, body interface{}, nodeType string, codeLocation types.CodeLocation) interface{} {
+	if poll == nil {
+		return body
+	}
+
+	attempt, ok := body.(func() error)
+	if !ok {
+		panic(fmt.Sprintf("%s decorated with Poll expects a func() error body (at %s)", nodeType, codeLocation))
+	}
+
+	return func() {
+		deadline := time.Now().Add(poll.timeout)
+		for attempts := 1; ; attempts++ {
+			err := attempt()
+			if err == nil {
+				return
+			}
+			global.Suite.RecordStep(fmt.Sprintf("Poll attempt %d failed: %s", attempts, err), codeLocation)
+			if time.Now().Add(poll.interval).After(deadline) {
+				global.Failer.Fail(fmt.Sprintf("Timed out after %s polling every %s: %d attempts failed, the last one with:\n%s", poll.timeout, poll.interval, attempts, err), codeLocation)
+				return
+			}
+			time.Sleep(poll.interval)
+		}
+	}
+}
+
+//ReportOrderDecorator is the type of the ReportOrder decorator
+type ReportOrderDecorator int
+
+//ReportOrder decorates a ReportAfterSuite to order it among the others: the ReportAfterSuite nodes run from the lowest
+//order to the highest, and those of the same order in the order they are registered.  The default order is 0.
+//
+//	var _ = ReportAfterSuite("uploads the JUnit report", func(report types.AggregatedReport) {
+//		...
+//	}, ReportOrder(1))
+func ReportOrder(order int) ReportOrderDecorator {
+	return ReportOrderDecorator(order)
+}
+
+//PendingReasonDecorator is the type of the PendingReason decorator
+type PendingReasonDecorator string
+
+//PendingReason records why a pending container or spec is pending:
+//
+//	PIt("survives a restart", PendingReason("blocked on ISSUE-123"))
+//
+//The reason is made available to reporters through SpecSummary.PendingReason, and is printed along with the pending
+//spec when running with -noisyPendings.  Specs in a pending container carry the reason of the container, unless they
+//have a reason of their own.
+func PendingReason(reason string) PendingReasonDecorator {
+	return PendingReasonDecorator(reason)
+}
+
+//ExpectFailureDecorator is the type of the ExpectFailure decorator
+type ExpectFailureDecorator string
+
+//ExpectFailure marks a container or a spec as expected to fail, e.g. the regression spec of a known bug that is not
+//fixed yet:
+//
+//	It("keeps the order of the items", func() {
+//		...
+//	}, ExpectFailure("ISSUE-123: the items are sorted by name"))
+//
+//A spec expected to fail that fails is reported as an expected failure and does not fail the suite.  One that passes
+//fails the suite, so that the decorator is removed once the bug is fixed.  The reason and the expected failure are made
+//available to reporters through SpecSummary.ExpectedFailureReason and SpecSummary.ExpectedFailure.
+func ExpectFailure(reason string) ExpectFailureDecorator {
+	return ExpectFailureDecorator(reason)
+}
+
+//ProcessAffinityDecorator is the type of the ProcessAffinity decorator
+type ProcessAffinityDecorator int
+
+//ProcessAffinity decorates a container so that, when running in parallel, all its specs run on the given parallel process
+//(numbered from 1), e.g. the one holding an expensive per-process fixture:
+//
+//	Describe("the search index", func() {
+//		...
+//	}, ProcessAffinity(1), OncePerContainer)
+//
+//Specs that are not pinned to a process remain freely distributed among the processes.  Each process runs the specs pinned
+//to it before the others.  When there are fewer processes than process, the specs are pinned to process modulo the number of
+//processes instead.  ProcessAffinity has no effect when running in series.
+func ProcessAffinity(process int) ProcessAffinityDecorator {
+	if process < 1 {
+		panic(fmt.Sprintf("ProcessAffinity expects a process number greater than or equal to 1, got %d", process))
+	}
+	return ProcessAffinityDecorator(process)
+}
+
+//SuiteMetadata describes a suite run (e.g. the git SHA, or the name of the environment the suite runs against).
+//Pass it, along with any suite-wide Label, to RunSpecs:
+//
+//	RunSpecs(t, "Books Suite", Label("integration"), SuiteMetadata{"gitSHA": os.Getenv("GIT_SHA")})
+//
+//Suite labels and metadata can also be set with the -suiteLabel and -suiteMetadata=key=value flags, which take
+//precedence.  They are made available to reporters through SuiteSummary.SuiteLabels and SuiteSummary.SuiteMetadata.
+type SuiteMetadata map[string]string
+
+//FailOnEmptyDecorator is the type of the FailOnEmpty option of RunSpecs
+type FailOnEmptyDecorator bool
+
+//FailOnEmpty makes the suite fail when no spec is left to run once the focus, skip and label filters are applied, as
+//it does with the -failOnEmpty flag.  Pass it to RunSpecs:
+//
+//	RunSpecs(t, "Books Suite", FailOnEmpty)
+//
+//A suite that runs nothing usually means that the filters passed to it, e.g. by CI, no longer match any spec.
+const FailOnEmpty = FailOnEmptyDecorator(true)
+
+//suiteConfig applies the suite-wide labels, metadata and options passed to RunSpecs to ginkgoConfig
+func suiteConfig(ginkgoConfig config.GinkgoConfigType, codeLocation types.CodeLocation, args ...interface{}) config.GinkgoConfigType {
+	labels := []string{}
+	metadata := map[string]string{}
+	for _, arg := range args {
+		switch arg := arg.(type) {
+		case Labels:
+			for _, label := range arg {
+				label = strings.TrimSpace(label)
+				if label == "" {
+					panic(fmt.Sprintf("Empty label passed to RunSpecs at %s", codeLocation))
+				}
+				labels = append(labels, label)
+			}
+		case SuiteMetadata:
+			for key, value := range arg {
+				metadata[key] = value
+			}
+		case FailOnEmptyDecorator:
+			ginkgoConfig.FailOnEmpty = ginkgoConfig.FailOnEmpty || bool(arg)
+		default:
+			panic(fmt.Sprintf("Unknown argument %#v passed to RunSpecs at %s", arg, codeLocation))
+		}
+	}
+
+	for _, label := range ginkgoConfig.SuiteLabels {
+		labels = append(labels, label)
+	}
+	for key, value := range ginkgoConfig.SuiteMetadata {
+		metadata[key] = value
+	}
+
+	ginkgoConfig.SuiteLabels = nil
+	if len(labels) > 0 {
+		ginkgoConfig.SuiteLabels = labels
+	}
+	ginkgoConfig.SuiteMetadata = nil
+	if len(metadata) > 0 {
+		ginkgoConfig.SuiteMetadata = metadata
+	}
+	return ginkgoConfig
+}
+
+//decorations holds the decorators passed to a DSL function, once parsed
+type decorations struct {
+	timeout          time.Duration
+	labels           []string
+	oncePerContainer bool
+	poll             *PollDecorator
+	pendingReason    string
+	processAffinity  int
+	serial           bool
+	reportOrder      int
+}
+
+//parseDecorations parses the optional arguments passed to a DSL function.  Timeouts (float64 or int seconds, or a time.Duration)
+//are only accepted by nodes that can time out.  Pending nodes ignore anything they do not understand, including their body.
+func parseDecorations(nodeType string, codeLocation types.CodeLocation, acceptsTimeout bool, pending bool, args ...interface{}) decorations {
+	result := decorations{timeout: global.DefaultTimeout}
+	if isContainerNodeType(nodeType) || isItNodeType(nodeType) {
+		args = append(commentDecorators(nodeType, codeLocation), args...)
+	}
+	for _, arg := range args {
+		switch arg := arg.(type) {
+		case Labels:
+			for _, label := range arg {
+				label = strings.TrimSpace(label)
+				if label == "" {
+					panic(fmt.Sprintf("Empty label passed to %s at %s", nodeType, codeLocation))
+				}
+				result.labels = append(result.labels, label)
+			}
+		case *Fixture:
+			arg.fixture.DeclareAt(codeLocation)
+		case RequiredResources:
+			for _, resource := range arg {
+				if strings.TrimSpace(resource) == "" {
+					panic(fmt.Sprintf("Empty resource passed to %s at %s", nodeType, codeLocation))
+				}
+			}
+			global.Suite.DeclareResources(codeLocation, arg...)
+		case OncePerContainerDecorator:
+			if !isContainerNodeType(nodeType) {
+				panic(fmt.Sprintf("OncePerContainer can only decorate Describe, Context and When containers, not %s (at %s)", nodeType, codeLocation))
+			}
+			result.oncePerContainer = bool(arg)
+		case ProcessAffinityDecorator:
+			if !isContainerNodeType(nodeType) {
+				panic(fmt.Sprintf("ProcessAffinity can only decorate Describe, Context and When containers, not %s (at %s)", nodeType, codeLocation))
+			}
+			result.processAffinity = int(arg)
+		case SerialDecorator:
+			if !isContainerNodeType(nodeType) && !isItNodeType(nodeType) {
+				panic(fmt.Sprintf("Serial can only decorate Describe, Context, When, It and Specify, not %s (at %s)", nodeType, codeLocation))
+			}
+			result.serial = bool(arg)
+		case IsolateWorkingDirDecorator:
+			if !isContainerNodeType(nodeType) && !isItNodeType(nodeType) {
+				panic(fmt.Sprintf("IsolateWorkingDir can only decorate Describe, Context, When, It and Specify, not %s (at %s)", nodeType, codeLocation))
+			}
+			if arg {
+				global.Suite.DeclareIsolatedWorkingDir(codeLocation)
+			}
+		case DependsOnDecorator:
+			if !isContainerNodeType(nodeType) && !isItNodeType(nodeType) {
+				panic(fmt.Sprintf("DependsOn can only decorate Describe, Context, When, It and Specify, not %s (at %s)", nodeType, codeLocation))
+			}
+			for _, ref := range arg {
+				if strings.TrimSpace(ref) == "" {
+					panic(fmt.Sprintf("Empty reference passed to DependsOn at %s", codeLocation))
+				}
+			}
+			global.Suite.DeclareDependencies(codeLocation, arg...)
+		case OwnerDecorator:
+			if !isContainerNodeType(nodeType) && !isItNodeType(nodeType) {
+				panic(fmt.Sprintf("Owner can only decorate Describe, Context, When, It and Specify, not %s (at %s)", nodeType, codeLocation))
+			}
+			for _, owner := range arg {
+				if strings.TrimSpace(owner) == "" {
+					panic(fmt.Sprintf("Empty owner passed to %s at %s", nodeType, codeLocation))
+				}
+			}
+			global.Suite.DeclareOwners(codeLocation, arg...)
+		case SeverityDecorator:
+			if !isContainerNodeType(nodeType) && !isItNodeType(nodeType) {
+				panic(fmt.Sprintf("%s can only decorate Describe, Context, When, It and Specify, not %s (at %s)", severityDecoratorName(arg), nodeType, codeLocation))
+			}
+			global.Suite.DeclareSeverity(codeLocation, types.Severity(arg))
+		case ExpectFailureDecorator:
+			if !isContainerNodeType(nodeType) && !isItNodeType(nodeType) {
+				panic(fmt.Sprintf("ExpectFailure can only decorate Describe, Context, When, It and Specify, not %s (at %s)", nodeType, codeLocation))
+			}
+			if strings.TrimSpace(string(arg)) == "" {
+				panic(fmt.Sprintf("Empty reason passed to ExpectFailure at %s", codeLocation))
+			}
+			global.Suite.DeclareExpectedFailure(codeLocation, string(arg))
+		case HermeticAllowDecorator:
+			if !isContainerNodeType(nodeType) && !isItNodeType(nodeType) {
+				panic(fmt.Sprintf("HermeticAllow can only decorate Describe, Context, When, It and Specify, not %s (at %s)", nodeType, codeLocation))
+			}
+			if _, err := hermetic.ParseAllowlist(arg); err != nil {
+				panic(fmt.Sprintf("Invalid HermeticAllow at %s: %s", codeLocation, err))
+			}
+			global.Suite.DeclareHermeticAllowlist(codeLocation, arg...)
+		case ReportOrderDecorator:
+			if nodeType != "ReportAfterSuite" {
+				panic(fmt.Sprintf("ReportOrder can only decorate ReportAfterSuite, not %s (at %s)", nodeType, codeLocation))
+			}
+			result.reportOrder = int(arg)
+		case RequiresFeatureDecorator:
+			if !isContainerNodeType(nodeType) && !isItNodeType(nodeType) {
+				panic(fmt.Sprintf("RequiresFeature can only decorate Describe, Context, When, It and Specify, not %s (at %s)", nodeType, codeLocation))
+			}
+			for _, feature := range arg {
+				if strings.TrimSpace(feature) == "" {
+					panic(fmt.Sprintf("Empty feature passed to RequiresFeature at %s", codeLocation))
+				}
+			}
+			global.Suite.DeclareRequiredFeatures(codeLocation, arg...)
+		case RetryOnDecorator:
+			if !isContainerNodeType(nodeType) && !isItNodeType(nodeType) {
+				panic(fmt.Sprintf("RetryOn can only decorate Describe, Context, When, It and Specify, not %s (at %s)", nodeType, codeLocation))
+			}
+			global.Suite.DeclareRetryOn(codeLocation, arg)
+		case PendingReasonDecorator:
+			if !isPendingNodeType(nodeType) {
+				panic(fmt.Sprintf("PendingReason can only decorate pending nodes, not %s (at %s)", nodeType, codeLocation))
+			}
+			if strings.TrimSpace(string(arg)) == "" {
+				panic(fmt.Sprintf("Empty pending reason passed to %s at %s", nodeType, codeLocation))
+			}
+			result.pendingReason = string(arg)
+		case PollDecorator:
+			if !isItNodeType(nodeType) && !pending {
+				panic(fmt.Sprintf("Poll can only decorate It and Specify, not %s (at %s)", nodeType, codeLocation))
+			}
+			poll := arg
+			result.poll = &poll
+		case float64:
+			if !acceptsTimeout && !pending {
+				panic(fmt.Sprintf("%s does not accept a timeout (at %s)", nodeType, codeLocation))
+			}
+			result.timeout = time.Duration(arg * float64(time.Second))
+		case int:
+			if !acceptsTimeout && !pending {
+				panic(fmt.Sprintf("%s does not accept a timeout (at %s)", nodeType, codeLocation))
+			}
+			result.timeout = time.Duration(arg) * time.Second
+		case time.Duration:
+			if !acceptsTimeout && !pending {
+				panic(fmt.Sprintf("%s does not accept a timeout (at %s)", nodeType, codeLocation))
+			}
+			result.timeout = arg
+		default:
+			if !pending {
+				panic(fmt.Sprintf("Unknown decorator %#v passed to %s at %s", arg, nodeType, codeLocation))
+			}
+		}
+	}
+	return result
+}
+
+func severityDecoratorName(severity SeverityDecorator) string {
+	name := types.Severity(severity).String()
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+//commentDecorators returns the decorators standing for the ginkgo:label, ginkgo:owner and ginkgo:severity annotations in the
+//comments right above the node at codeLocation:
+//
+//	// ginkgo:label=slow,storage ginkgo:owner=team-x
+//	// ginkgo:severity=high
+//	It("survives a restart", func() {
+//		...
+//	})
+//
+//is decorated as if it were passed Label("slow", "storage"), Owner("team-x") and High.  The annotations are read from the
+//source file of the node when the spec tree is built, so a precompiled suite running away from its sources has none.
+//Other ginkgo:key=value words, e.g. in prose or links, are left alone.
+func commentDecorators(nodeType string, codeLocation types.CodeLocation) []interface{} {
+	decorators := []interface{}{}
+	for _, annotation := range annotations.Lookup(codeLocation) {
+		switch annotation.Key {
+		case "label":
+			decorators = append(decorators, Labels(annotation.Values()))
+		case "owner":
+			decorators = append(decorators, OwnerDecorator(annotation.Values()))
+		case "severity":
+			severity, err := types.ParseSeverity(annotation.Value)
+			if err != nil {
+				panic(fmt.Sprintf("Invalid ginkgo:severity annotation on %s at %s: %s", nodeType, codeLocation, err))
+			}
+			decorators = append(decorators, SeverityDecorator(severity))
+		}
+	}
+	return decorators
+}
+
+func isContainerNodeType(nodeType string) bool {
+	switch strings.TrimLeft(nodeType, "FPX") {
+	case "Describe", "Context", "When":
+		return true
+	}
+	return false
+}
+
+func isPendingNodeType(nodeType string) bool {
+	return strings.HasPrefix(nodeType, "P") || strings.HasPrefix(nodeType, "X")
+}
+
+func isItNodeType(nodeType string) bool {
+	switch strings.TrimLeft(nodeType, "FPX") {
+	case "It", "Specify":
+		return true
+	}
+	return false
+}
+
+func pushItNode(text string, body interface{}, flag types.FlagType, codeLocation types.CodeLocation, d decorations) {
+	if d.serial {
+		global.Suite.PushSerialItNode(text, body, flag, codeLocation, d.timeout, d.labels...)
+		return
+	}
+	global.Suite.PushItNode(text, body, flag, codeLocation, d.timeout, d.labels...)
+}
+
+func pushPendingItNode(text string, codeLocation types.CodeLocation, d decorations) {
+	if d.pendingReason != "" {
+		global.Suite.PushPendingItNode(text, d.pendingReason, codeLocation, d.labels...)
+		return
+	}
+	global.Suite.PushItNode(text, func() {}, types.FlagTypePending, codeLocation, 0, d.labels...)
+}
+
+func pushContainerNode(text string, body func(), flag types.FlagType, codeLocation types.CodeLocation, d decorations) {
+	options := suite.ContainerOptions{
+		OncePerContainer: d.oncePerContainer,
+		PendingReason:    d.pendingReason,
+		ProcessAffinity:  d.processAffinity,
+		Serial:           d.serial,
+	}
+	global.Suite.PushContainerNodeWithOptions(text, body, flag, codeLocation, options, d.labels...)
+}
@@ -0,0 +1,153 @@
+package table
+
+import (
+	"github.com/onsi/ginkgo/internal/codelocation"
+	"github.com/onsi/ginkgo/internal/global"
+	"github.com/onsi/ginkgo/types"
+)
+
+/*
+DescribeClosureTable describes a table-driven test without reflection, for suites compiled with tinygo or for other
+runtimes that do not support reflect.Call.
+
+Instead of handing parameters to a shared function, each entry provides the closure to run.  Build these closures with
+a helper that takes the parameters of the entry, which the compiler type-checks:
+
+	check := func(x int, y int, expected bool) func() {
+		return func() {
+			Ω(x > y).Should(Equal(expected))
+		}
+	}
+
+	DescribeClosureTable("a simple table",
+		Case("x > y", check(1, 0, true)),
+		Case("x == y", check(0, 0, false)),
+		Case("x < y", check(0, 1, false)),
+	)
+
+Like with DescribeTable, each Case is turned into an It within a Describe.  Cases can be focused (with FCase) or marked
+pending (with PCase or XCase), and the entire table with FDescribeClosureTable and PDescribeClosureTable/XDescribeClosureTable.
+
+DescribeTable and the Entry constructors rely on reflection and are left out of builds using the tinygo or the
+ginkgo_noreflect build tag.
+*/
+func DescribeClosureTable(description string, cases ...ClosureEntry) bool {
+	describeClosureTable(description, cases, types.FlagTypeNone)
+	return true
+}
+
+/*
+You can focus a table with `FDescribeClosureTable`.  This is equivalent to `FDescribe`.
+*/
+func FDescribeClosureTable(description string, cases ...ClosureEntry) bool {
+	describeClosureTable(description, cases, types.FlagTypeFocused)
+	return true
+}
+
+/*
+You can mark a table as pending with `PDescribeClosureTable`.  This is equivalent to `PDescribe`.
+*/
+func PDescribeClosureTable(description string, cases ...ClosureEntry) bool {
+	describeClosureTable(description, cases, types.FlagTypePending)
+	return true
+}
+
+/*
+You can mark a table as pending with `XDescribeClosureTable`.  This is equivalent to `XDescribe`.
+*/
+func XDescribeClosureTable(description string, cases ...ClosureEntry) bool {
+	describeClosureTable(description, cases, types.FlagTypePending)
+	return true
+}
+
+func describeClosureTable(description string, cases []ClosureEntry, flag types.FlagType) {
+	global.Suite.PushContainerNode(
+		description,
+		func() {
+			for _, c := range cases {
+				c.generateIt()
+			}
+		},
+		flag,
+		codelocation.New(2),
+	)
+}
+
+/*
+ClosureEntry represents an entry in a table test described with DescribeClosureTable.  You generally use the `Case`
+constructor.
+*/
+type ClosureEntry struct {
+	Description  string
+	Body         func()
+	Pending      bool
+	Focused      bool
+	codeLocation types.CodeLocation
+}
+
+func (c ClosureEntry) generateIt() {
+	if c.Pending {
+		global.Suite.PushItNode(c.Description, func() {}, types.FlagTypePending, c.codeLocation, 0)
+		return
+	}
+
+	if c.Focused {
+		global.Suite.PushItNode(c.Description, c.Body, types.FlagTypeFocused, c.codeLocation, global.DefaultTimeout)
+	} else {
+		global.Suite.PushItNode(c.Description, c.Body, types.FlagTypeNone, c.codeLocation, global.DefaultTimeout)
+	}
+}
+
+/*
+Case constructs a ClosureEntry.
+
+The description becomes the content of the generated Ginkgo `It`, and body is run as its body.
+*/
+func Case(description string, body func()) ClosureEntry {
+	return ClosureEntry{
+		Description:  description,
+		Body:         body,
+		Pending:      false,
+		Focused:      false,
+		codeLocation: codelocation.New(1),
+	}
+}
+
+/*
+You can focus a particular case with FCase.  This is equivalent to FIt.
+*/
+func FCase(description string, body func()) ClosureEntry {
+	return ClosureEntry{
+		Description:  description,
+		Body:         body,
+		Pending:      false,
+		Focused:      true,
+		codeLocation: codelocation.New(1),
+	}
+}
+
+/*
+You can mark a particular case as pending with PCase.  This is equivalent to PIt.
+*/
+func PCase(description string, body func()) ClosureEntry {
+	return ClosureEntry{
+		Description:  description,
+		Body:         body,
+		Pending:      true,
+		Focused:      false,
+		codeLocation: codelocation.New(1),
+	}
+}
+
+/*
+You can mark a particular case as pending with XCase.  This is equivalent to XIt.
+*/
+func XCase(description string, body func()) ClosureEntry {
+	return ClosureEntry{
+		Description:  description,
+		Body:         body,
+		Pending:      true,
+		Focused:      false,
+		codeLocation: codelocation.New(1),
+	}
+}
@@ -0,0 +1,64 @@
+package table_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/extensions/table"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ClosureTable", func() {
+	var ran []string
+
+	check := func(description string, x int, y int, expected bool) func() {
+		return func() {
+			ran = append(ran, description)
+			Ω(x > y).Should(Equal(expected))
+		}
+	}
+
+	DescribeClosureTable("a simple table",
+		Case("x > y", check("x > y", 1, 0, true)),
+		Case("x == y", check("x == y", 0, 0, false)),
+		Case("x < y", check("x < y", 0, 1, false)),
+		PCase("x < y, wrongly", check("x < y, wrongly", 0, 1, true)),
+		XCase("x == y, wrongly", check("x == y, wrongly", 0, 0, true)),
+	)
+
+	It("should have run every case but the pending ones, in order", func() {
+		Ω(ran).Should(Equal([]string{"x > y", "x == y", "x < y"}))
+	})
+
+	type ComplicatedThings struct {
+		Superstructure string
+		Substructure   string
+		Count          int
+	}
+
+	count := func(c ComplicatedThings) func() {
+		return func() {
+			Ω(strings.Count(c.Superstructure, c.Substructure)).Should(BeNumerically("==", c.Count))
+		}
+	}
+
+	DescribeClosureTable("a more complicated table",
+		Case("with no matching substructures", count(ComplicatedThings{
+			Superstructure: "the sixth sheikh's sixth sheep's sick",
+			Substructure:   "emir",
+			Count:          0,
+		})),
+		Case("with many matching substructures", count(ComplicatedThings{
+			Superstructure: "the sixth sheikh's sixth sheep's sick",
+			Substructure:   "si",
+			Count:          3,
+		})),
+	)
+
+	PDescribeClosureTable("a failure",
+		Case("when true", func() {
+			Ω(true).Should(BeFalse())
+		}),
+	)
+})
@@ -1,3 +1,5 @@
+// +build !tinygo,!ginkgo_noreflect
+
 /*
 
 Table provides a simple DSL for Ginkgo-native Table-Driven Tests
@@ -41,6 +43,37 @@ Under the hood, `DescribeTable` simply generates a new Ginkgo `Describe`.  Each
 
 It's important to understand that the `Describe`s and `It`s are generated at evaluation time (i.e. when Ginkgo constructs the tree of tests and before the tests run).
 
+Tables whose function takes a single struct can be made self-documenting by passing each struct directly to Entry,
+without a description:
+
+    type comparison struct {
+        X        int  `table:"x"`
+        Y        int  `table:"y"`
+        Expected bool `table:"expected"`
+    }
+
+    DescribeTable("a simple table",
+        func(c comparison) {
+            Ω(c.X > c.Y).Should(Equal(c.Expected))
+        },
+        Entry(comparison{X: 1, Y: 0, Expected: true}),
+        Entry(comparison{X: 0, Y: 1, Expected: false}),
+    )
+
+The description of such an Entry is the value of the field tagged `table:"description"`, if any.  Otherwise it lists
+the fields tagged `table:"<name>"` as name=value, here "x=1, y=0, expected=true", and failing that, all the fields.
+
+Like an It, the function can accept a SpecContext, or a context.Context, as its first parameter.  Ginkgo supplies the
+SpecContext of the spec, so Entries only list the remaining parameters:
+
+    DescribeTable("fetching pages",
+        func(ctx context.Context, path string, expectedStatus int) {
+            Ω(client.Get(ctx, path)).Should(HaveHTTPStatus(expectedStatus))
+        },
+        Entry("the home page", "/", http.StatusOK),
+        Entry("a missing page", "/missing", http.StatusNotFound),
+    )
+
 Individual Entries can be focused (with FEntry) or marked pending (with PEntry or XEntry).  In addition, the entire table can be focused or marked pending with FDescribeTable and PDescribeTable/XDescribeTable.
 
 A description function can be passed to Entry in place of the description. The function is then fed with the entry parameters to generate the description of the It corresponding to that particular Entry.
@@ -1,9 +1,13 @@
+// +build !tinygo,!ginkgo_noreflect
+
 package table
 
 import (
 	"fmt"
 	"reflect"
+	"strings"
 
+	"github.com/onsi/ginkgo"
 	"github.com/onsi/ginkgo/internal/codelocation"
 	"github.com/onsi/ginkgo/internal/global"
 	"github.com/onsi/ginkgo/types"
@@ -18,16 +22,25 @@ type TableEntry struct {
 	Pending      bool
 	Focused      bool
 	codeLocation types.CodeLocation
+
+	pendingReason string
 }
 
 func (t TableEntry) generateIt(itBody reflect.Value) {
 	var description string
+	parameters := t.Parameters
 	descriptionValue := reflect.ValueOf(t.Description)
 	switch descriptionValue.Kind() {
 	case reflect.String:
 		description = descriptionValue.String()
+	case reflect.Struct:
+		if len(parameters) > 0 {
+			panic(fmt.Sprintf("An entry holding a struct must not have other parameters, got %#v", parameters))
+		}
+		description = describeStruct(descriptionValue)
+		parameters = []interface{}{t.Description}
 	case reflect.Func:
-		values := castParameters(descriptionValue, t.Parameters)
+		values := castParameters(descriptionValue, parameters, 0)
 		res := descriptionValue.Call(values)
 		if len(res) != 1 {
 			panic(fmt.Sprintf("The describe function should return only a value, returned %d", len(res)))
@@ -37,7 +50,12 @@ func (t TableEntry) generateIt(itBody reflect.Value) {
 		}
 		description = res[0].String()
 	default:
-		panic(fmt.Sprintf("Description can either be a string or a function, got %#v", descriptionValue))
+		panic(fmt.Sprintf("Description can either be a string, a function or a struct, got %#v", descriptionValue))
+	}
+
+	if t.Pending && t.pendingReason != "" {
+		global.Suite.PushPendingItNode(description, t.pendingReason, t.codeLocation)
+		return
 	}
 
 	if t.Pending {
@@ -45,9 +63,17 @@ func (t TableEntry) generateIt(itBody reflect.Value) {
 		return
 	}
 
-	values := castParameters(itBody, t.Parameters)
-	body := func() {
-		itBody.Call(values)
+	var body interface{}
+	if takesSpecContext(itBody) {
+		values := castParameters(itBody, parameters, 1)
+		body = func(ctx ginkgo.SpecContext) {
+			itBody.Call(append([]reflect.Value{reflect.ValueOf(ctx)}, values...))
+		}
+	} else {
+		values := castParameters(itBody, parameters, 0)
+		body = func() {
+			itBody.Call(values)
+		}
 	}
 
 	if t.Focused {
@@ -57,12 +83,46 @@ func (t TableEntry) generateIt(itBody reflect.Value) {
 	}
 }
 
-func castParameters(function reflect.Value, parameters []interface{}) []reflect.Value {
+//describeStruct generates the description of an entry holding a struct.  A field tagged `table:"description"` provides
+//it.  Otherwise the fields tagged `table:"<name>"` are listed as name=value, and failing that, all the fields are.
+func describeStruct(value reflect.Value) string {
+	structType := value.Type()
+	labelled := []string{}
+	for i := 0; i < structType.NumField(); i++ {
+		name := structType.Field(i).Tag.Get("table")
+		switch name {
+		case "", "-":
+			continue
+		case "description":
+			return fmt.Sprint(value.Field(i))
+		}
+		labelled = append(labelled, fmt.Sprintf("%s=%v", name, value.Field(i)))
+	}
+	if len(labelled) > 0 {
+		return strings.Join(labelled, ", ")
+	}
+	return fmt.Sprintf("%+v", value)
+}
+
+var specContextType = reflect.TypeOf((*ginkgo.SpecContext)(nil)).Elem()
+
+//takesSpecContext tells whether the first parameter of the function is a context the SpecContext of the spec satisfies,
+//such as a context.Context or a SpecContext.  Ginkgo supplies the SpecContext itself, ahead of the entry parameters.
+func takesSpecContext(function reflect.Value) bool {
+	funcType := function.Type()
+	if funcType.NumIn() == 0 || funcType.In(0).Kind() != reflect.Interface || funcType.In(0).NumMethod() == 0 {
+		return false
+	}
+	return specContextType.Implements(funcType.In(0))
+}
+
+//castParameters turns the parameters of an entry into the arguments of function, starting at its offset-th parameter
+func castParameters(function reflect.Value, parameters []interface{}, offset int) []reflect.Value {
 	res := make([]reflect.Value, len(parameters))
 	funcType := function.Type()
 	for i, param := range parameters {
 		if param == nil {
-			inType := funcType.In(i)
+			inType := funcType.In(i + offset)
 			res[i] = reflect.Zero(inType)
 		} else {
 			res[i] = reflect.ValueOf(param)
@@ -77,6 +137,9 @@ Entry constructs a TableEntry.
 The first argument is a required description (this becomes the content of the generated Ginkgo `It`).
 Subsequent parameters are saved off and sent to the callback passed in to `DescribeTable`.
 
+Alternatively, an Entry can hold a single struct value in place of its description and parameters.  The struct is sent
+to the callback and the description is generated from it (see DescribeTable).
+
 Each Entry ends up generating an individual Ginkgo It.
 */
 func Entry(description interface{}, parameters ...interface{}) TableEntry {
@@ -104,14 +167,20 @@ func FEntry(description interface{}, parameters ...interface{}) TableEntry {
 
 /*
 You can mark a particular entry as pending with PEntry.  This is equivalent to PIt.
+
+Like PIt, PEntry accepts a PendingReason among its parameters to record why the entry is pending:
+
+	PEntry("x > y", 1, 0, true, PendingReason("blocked on ISSUE-123")),
 */
 func PEntry(description interface{}, parameters ...interface{}) TableEntry {
+	parameters, pendingReason := extractPendingReason(parameters)
 	return TableEntry{
-		Description:  description,
-		Parameters:   parameters,
-		Pending:      true,
-		Focused:      false,
-		codeLocation: codelocation.New(1),
+		Description:   description,
+		Parameters:    parameters,
+		Pending:       true,
+		Focused:       false,
+		codeLocation:  codelocation.New(1),
+		pendingReason: pendingReason,
 	}
 }
 
@@ -119,11 +188,27 @@ func PEntry(description interface{}, parameters ...interface{}) TableEntry {
 You can mark a particular entry as pending with XEntry.  This is equivalent to XIt.
 */
 func XEntry(description interface{}, parameters ...interface{}) TableEntry {
+	parameters, pendingReason := extractPendingReason(parameters)
 	return TableEntry{
-		Description:  description,
-		Parameters:   parameters,
-		Pending:      true,
-		Focused:      false,
-		codeLocation: codelocation.New(1),
+		Description:   description,
+		Parameters:    parameters,
+		Pending:       true,
+		Focused:       false,
+		codeLocation:  codelocation.New(1),
+		pendingReason: pendingReason,
+	}
+}
+
+//extractPendingReason removes the PendingReason, if any, from the parameters of a pending entry
+func extractPendingReason(parameters []interface{}) ([]interface{}, string) {
+	reason := ""
+	remaining := []interface{}{}
+	for _, parameter := range parameters {
+		if pendingReason, ok := parameter.(ginkgo.PendingReasonDecorator); ok {
+			reason = string(pendingReason)
+			continue
+		}
+		remaining = append(remaining, parameter)
 	}
+	return remaining, reason
 }
@@ -1,6 +1,9 @@
+// +build !tinygo,!ginkgo_noreflect
+
 package table_test
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -62,6 +65,67 @@ var _ = Describe("Table", func() {
 		},
 		Entry("nil", nil),
 	)
+
+	DescribeTable("a table taking a context.Context",
+		func(ctx context.Context, x int, err error) {
+			Ω(ctx).ShouldNot(BeNil())
+			Ω(ctx.Err()).ShouldNot(HaveOccurred())
+			Ω(x).Should(Equal(1))
+			Ω(err).Should(BeNil())
+		},
+		Entry("gets the SpecContext ahead of the entry parameters", 1, nil),
+	)
+
+	DescribeTable("a table taking a SpecContext",
+		func(ctx SpecContext, x int) {
+			Ω(ctx.AttachProgressReporter(func() string { return "" })).ShouldNot(BeNil())
+			Ω(x).Should(Equal(1))
+		},
+		Entry("gets the SpecContext ahead of the entry parameters", 1),
+	)
+})
+
+var _ = Describe("TableWithStructEntries", func() {
+	type described struct {
+		Name  string `table:"description"`
+		Value int
+	}
+
+	DescribeTable("a table with a description field",
+		func(c described) {
+			Ω(CurrentGinkgoTestDescription().TestText).Should(Equal(c.Name))
+			Ω(c.Value).Should(Equal(1))
+		},
+		Entry(described{Name: "one", Value: 1}),
+	)
+
+	type labelled struct {
+		X        int  `table:"x"`
+		Y        int  `table:"y"`
+		Expected bool `table:"expected"`
+		ignored  string
+	}
+
+	DescribeTable("a table with labelled fields",
+		func(c labelled) {
+			Ω(CurrentGinkgoTestDescription().TestText).Should(Equal(fmt.Sprintf("x=%d, y=%d, expected=%t", c.X, c.Y, c.Expected)))
+			Ω(c.X > c.Y).Should(Equal(c.Expected))
+		},
+		Entry(labelled{X: 1, Y: 0, Expected: true, ignored: "ignored"}),
+		Entry(labelled{X: 0, Y: 1, Expected: false}),
+	)
+
+	type plain struct {
+		X int
+		Y int
+	}
+
+	DescribeTable("a table without tags",
+		func(c plain) {
+			Ω(CurrentGinkgoTestDescription().TestText).Should(Equal("{X:1 Y:2}"))
+		},
+		Entry(plain{X: 1, Y: 2}),
+	)
 })
 
 var _ = Describe("TableWithParametricDescription", func() {
@@ -0,0 +1,94 @@
+/*
+
+Failer is the API through which assertion libraries report to Ginkgo.  It is what Gomega relies on, through Fail, so that
+an alternative matcher library or assertion DSL integrates with Ginkgo exactly as Gomega does:
+
+	func ExpectPositive(n int) {
+		if n <= 0 {
+			failer.Fail(fmt.Sprintf("expected %d to be positive", n), 1)
+		}
+	}
+
+Each function applies to the node (It, BeforeEach, ...) that runs on the calling goroutine, or that started it.  Only the
+first of Fail, Skip, Abort and Panic a node calls sets its outcome: the later ones are ignored until Drain.  The callerSkip
+they accept, 0 by default, picks the location the outcome is reported at, as for ginkgo.Fail: 1 points at the caller of
+the function calling them.  GinkgoHelper applies as well.
+
+Fail, Skip, Abort and Panic stop the node by panicking with ginkgo.GINKGO_PANIC, which Ginkgo rescues.  On a goroutine the
+node starts, the panic must be rescued by deferring GinkgoRecover, unless the suite runs with -detectGoroutineFailures.
+
+*/
+
+package failer
+
+import (
+	"runtime"
+
+	"github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/internal/codelocation"
+	"github.com/onsi/ginkgo/internal/global"
+	"github.com/onsi/ginkgo/types"
+)
+
+//Fail fails the running node with message, and stops it
+func Fail(message string, callerSkip ...int) {
+	location := codelocation.New(skip(callerSkip) + 1)
+	if global.Failer.FailOutsideNode(message, location) {
+		runtime.Goexit()
+	}
+	global.Failer.Fail(message, location)
+	panic(ginkgo.GINKGO_PANIC)
+}
+
+//Skip skips the running spec with message, and stops the node
+func Skip(message string, callerSkip ...int) {
+	global.Failer.Skip(message, codelocation.New(skip(callerSkip)+1))
+	panic(ginkgo.GINKGO_PANIC)
+}
+
+//Abort fails the running node with message, as Fail does, and aborts the suite: the specs left to run are skipped, on
+//every parallel node, and the suite fails with an "abort" special failure reason.  The specs already running on other
+//parallel nodes, or concurrently with -concurrency, complete.
+func Abort(message string, callerSkip ...int) {
+	global.Failer.Abort(message, codelocation.New(skip(callerSkip)+1))
+	panic(ginkgo.GINKGO_PANIC)
+}
+
+//Panic records that the running node panicked with forwardedPanic, as if the panic had escaped the node, and stops it.
+//It is meant for libraries that recover panics to report them, e.g. out of code under test they ran.
+func Panic(forwardedPanic interface{}, callerSkip ...int) {
+	global.Failer.Panic(codelocation.New(skip(callerSkip)+1), forwardedPanic)
+	panic(ginkgo.GINKGO_PANIC)
+}
+
+//Drain takes back the outcome the running node has recorded so far, returning it and leaving the node as passed.  It
+//does not stop the node.  Libraries that retry assertions, or gather several failures, drain the outcome of each attempt
+//before deciding on the outcome of the node:
+//
+//	func attempt(assertion func()) (failure types.SpecFailure, state types.SpecState) {
+//		defer func() {
+//			if e := recover(); e != nil && e != ginkgo.GINKGO_PANIC {
+//				panic(e)
+//			}
+//			failure, state = failer.Drain()
+//		}()
+//		assertion()
+//		return
+//	}
+//
+//The state is types.SpecStatePassed, and the failure is zero, if there was no outcome.
+func Drain() (types.SpecFailure, types.SpecState) {
+	return global.Failer.Drain(types.SpecComponentTypeInvalid, 0, types.CodeLocation{})
+}
+
+//State returns the outcome the running node has recorded so far, without taking it back
+func State() types.SpecState {
+	return global.Failer.State()
+}
+
+func skip(callerSkip []int) int {
+	if len(callerSkip) > 0 {
+		return callerSkip[0]
+	}
+	return 0
+}
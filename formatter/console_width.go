@@ -0,0 +1,30 @@
+package formatter
+
+import (
+	"os"
+	"strconv"
+)
+
+//ConsoleWidth returns the width, in columns, console output wraps at: columns when it is set, otherwise the width of the
+//terminal stdout is attached to, otherwise $COLUMNS.  It returns 0, which leaves output unwrapped, with noWrap or when
+//stdout is not a terminal, e.g. when it is piped into a log system that folds long lines itself, whatever $COLUMNS says.
+//On platforms where that cannot be told, stdout is taken for a terminal.
+func ConsoleWidth(columns int, noWrap bool) uint {
+	if noWrap {
+		return 0
+	}
+	if columns > 0 {
+		return uint(columns)
+	}
+	width, isTerminal := terminalWidth(os.Stdout.Fd())
+	if !isTerminal {
+		return 0
+	}
+	if width > 0 {
+		return width
+	}
+	if width, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && width > 0 {
+		return uint(width)
+	}
+	return 0
+}
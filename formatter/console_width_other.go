@@ -0,0 +1,9 @@
+// +build !freebsd,!openbsd,!netbsd,!dragonfly,!darwin,!linux
+
+package formatter
+
+//terminalWidth cannot tell the width of the terminal on this platform, nor whether fd is attached to one: it assumes it
+//is, so that ConsoleWidth falls back to $COLUMNS
+func terminalWidth(fd uintptr) (uint, bool) {
+	return 0, true
+}
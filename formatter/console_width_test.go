@@ -0,0 +1,66 @@
+package formatter_test
+
+import (
+	"os"
+	"runtime"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/formatter"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ConsoleWidth", func() {
+	var (
+		stdout        *os.File
+		reader        *os.File
+		writer        *os.File
+		columns       string
+		columnsWasSet bool
+	)
+
+	BeforeEach(func() {
+		columns, columnsWasSet = os.LookupEnv("COLUMNS")
+		os.Setenv("COLUMNS", "120")
+
+		//a pipe stands for stdout being piped into a log system
+		var err error
+		reader, writer, err = os.Pipe()
+		Ω(err).ShouldNot(HaveOccurred())
+		stdout = os.Stdout
+		os.Stdout = writer
+	})
+
+	AfterEach(func() {
+		os.Stdout = stdout
+		writer.Close()
+		reader.Close()
+
+		if columnsWasSet {
+			os.Setenv("COLUMNS", columns)
+		} else {
+			os.Unsetenv("COLUMNS")
+		}
+	})
+
+	It("should return the columns asked for", func() {
+		Ω(formatter.ConsoleWidth(100, false)).Should(Equal(uint(100)))
+	})
+
+	It("should return 0 with noWrap, even when columns are asked for", func() {
+		Ω(formatter.ConsoleWidth(100, true)).Should(BeZero())
+	})
+
+	Context("when stdout is not a terminal", func() {
+		BeforeEach(func() {
+			switch runtime.GOOS {
+			case "freebsd", "openbsd", "netbsd", "dragonfly", "darwin", "linux":
+			default:
+				Skip("whether stdout is a terminal cannot be told on " + runtime.GOOS)
+			}
+		})
+
+		It("should return 0, whatever $COLUMNS says", func() {
+			Ω(formatter.ConsoleWidth(0, false)).Should(BeZero())
+		})
+	})
+})
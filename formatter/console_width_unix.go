@@ -0,0 +1,20 @@
+// +build freebsd openbsd netbsd dragonfly darwin linux
+
+package formatter
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+//terminalWidth returns the width of the terminal fd is attached to, and whether it is attached to one at all
+func terminalWidth(fd uintptr) (uint, bool) {
+	var size struct {
+		rows, columns, xPixels, yPixels uint16
+	}
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, uintptr(syscall.TIOCGWINSZ), uintptr(unsafe.Pointer(&size)))
+	if errno != 0 {
+		return 0, false
+	}
+	return uint(size.columns), true
+}
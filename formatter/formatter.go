@@ -6,6 +6,7 @@ import (
 	"strings"
 )
 
+//COLS is the width, in columns, of a terminal whose width cannot be detected
 const COLS = 80
 
 type ColorMode uint8
@@ -92,34 +93,7 @@ func (f Formatter) Fiw(indentation uint, maxWidth uint, format string, args ...i
 	lines := strings.Split(out, "\n")
 
 	if maxWidth != 0 {
-		outLines := []string{}
-
-		maxWidth = maxWidth - indentation*2
-		for _, line := range lines {
-			if f.length(line) <= maxWidth {
-				outLines = append(outLines, line)
-				continue
-			}
-			outWords := []string{}
-			length := uint(0)
-			words := strings.Split(line, " ")
-			for _, word := range words {
-				wordLength := f.length(word)
-				if length+wordLength <= maxWidth {
-					length += wordLength
-					outWords = append(outWords, word)
-					continue
-				}
-				outLines = append(outLines, strings.Join(outWords, " "))
-				outWords = []string{word}
-				length = wordLength
-			}
-			if len(outWords) > 0 {
-				outLines = append(outLines, strings.Join(outWords, " "))
-			}
-		}
-
-		lines = outLines
+		lines = f.wrap(lines, maxWidth-indentation*2)
 	}
 
 	if indentation == 0 {
@@ -136,6 +110,45 @@ func (f Formatter) Fiw(indentation uint, maxWidth uint, format string, args ...i
 	return strings.Join(lines, "\n")
 }
 
+//Wrap wraps the lines of text longer than width at spaces, keeping the indentation of each line on the lines it wraps
+//onto.  Words longer than width are left whole on lines of their own.  A width of 0 leaves text as is.
+func Wrap(text string, width uint) string {
+	if width == 0 {
+		return text
+	}
+	return strings.Join(SingletonFormatter.wrap(strings.Split(text, "\n"), width), "\n")
+}
+
+func (f Formatter) wrap(lines []string, width uint) []string {
+	outLines := []string{}
+	for _, line := range lines {
+		if f.length(line) <= width {
+			outLines = append(outLines, line)
+			continue
+		}
+		words := strings.Fields(line)
+		padding := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		outWords := []string{}
+		length := f.length(padding)
+		for _, word := range words {
+			wordLength := f.length(word)
+			if len(outWords) == 0 || length+1+wordLength <= width {
+				if len(outWords) > 0 {
+					length++
+				}
+				length += wordLength
+				outWords = append(outWords, word)
+				continue
+			}
+			outLines = append(outLines, padding+strings.Join(outWords, " "))
+			outWords = []string{word}
+			length = f.length(padding) + wordLength
+		}
+		outLines = append(outLines, padding+strings.Join(outWords, " "))
+	}
+	return outLines
+}
+
 func (f Formatter) length(styled string) uint {
 	n := uint(0)
 	inStyle := false
@@ -0,0 +1,68 @@
+package ginkgo
+
+import (
+	"fmt"
+
+	"github.com/onsi/ginkgo/config"
+	"github.com/onsi/ginkgo/internal/codelocation"
+	"github.com/onsi/ginkgo/internal/global"
+	"github.com/onsi/ginkgo/types"
+)
+
+//Given, WhenStep and Then announce the steps of a spec written in the Gherkin style, as By does:
+//
+//	It("withdraws cash", func() {
+//		Given("an account with $100", func() {
+//			account = NewAccount(100)
+//		})
+//		WhenStep("the customer withdraws $20", func() {
+//			account.Withdraw(20)
+//		})
+//		Then("the balance is $80", func() {
+//			Ω(account.Balance()).Should(Equal(80))
+//		})
+//	})
+//
+//The keyword of each step is recorded in the SpecSummary handed to reporters, along with the state and run time of the
+//step when it is handed a callback.  WhenStep is named so as not to clash with the When container; it is reported with
+//the When keyword.  -cucumberReport writes the steps to a Cucumber JSON report.
+func Given(text string, callbacks ...func()) {
+	gherkinStep("Given", text, codelocation.New(1), callbacks)
+}
+
+//WhenStep announces a When step, see Given
+func WhenStep(text string, callbacks ...func()) {
+	gherkinStep("When", text, codelocation.New(1), callbacks)
+}
+
+//Then announces a Then step, see Given
+func Then(text string, callbacks ...func()) {
+	gherkinStep("Then", text, codelocation.New(1), callbacks)
+}
+
+func gherkinStep(keyword string, text string, codeLocation types.CodeLocation, callbacks []func()) {
+	if len(callbacks) > 1 {
+		panic("just one callback per " + keyword + ", please")
+	}
+	preamble := "\x1b[1mSTEP\x1b[0m"
+	if config.DefaultReporterConfig.NoColor {
+		preamble = "STEP"
+	}
+	fmt.Fprintln(GinkgoWriter, preamble+": "+keyword+" "+text)
+	complete := global.Suite.RecordGherkinStep(keyword, text, codeLocation)
+	if len(callbacks) == 0 {
+		return
+	}
+
+	completed := false
+	defer func() {
+		state := global.Failer.State()
+		if !completed && state == types.SpecStatePassed {
+			//the callback panicked without failing through Fail
+			state = types.SpecStatePanicked
+		}
+		complete(state)
+	}()
+	callbacks[0]()
+	completed = true
+}
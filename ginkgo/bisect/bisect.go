@@ -0,0 +1,78 @@
+/*
+Package bisect narrows down the specs an order-dependent failure depends on, by delta debugging over the specs that run
+before the failing one.
+*/
+package bisect
+
+//Reproduces runs the failing spec after the given candidates, in their order, and tells whether it failed
+type Reproduces func(candidates []int) (bool, error)
+
+//Minimize returns a minimal subset of candidates that still makes the failing spec fail: running the failing spec after
+//any one element less of the subset lets it pass.  candidates must reproduce the failure, and running the failing spec
+//on its own must not.  The subsets passed to reproduces keep the order of candidates.
+func Minimize(candidates []int, reproduces Reproduces) ([]int, error) {
+	current := append([]int{}, candidates...)
+	n := 2
+	for len(current) >= 2 {
+		chunks := split(current, n)
+
+		reduced := false
+		for _, chunk := range chunks {
+			fails, err := reproduces(chunk)
+			if err != nil {
+				return nil, err
+			}
+			if fails {
+				current, n, reduced = chunk, 2, true
+				break
+			}
+		}
+
+		if !reduced && n > 2 {
+			for i := range chunks {
+				complement := complementOf(chunks, i)
+				fails, err := reproduces(complement)
+				if err != nil {
+					return nil, err
+				}
+				if fails {
+					current, n, reduced = complement, n-1, true
+					break
+				}
+			}
+		}
+
+		if !reduced {
+			if n >= len(current) {
+				break
+			}
+			n = n * 2
+			if n > len(current) {
+				n = len(current)
+			}
+		}
+	}
+	return current, nil
+}
+
+//split splits candidates into n chunks of nearly equal sizes, keeping their order
+func split(candidates []int, n int) [][]int {
+	chunks := [][]int{}
+	start := 0
+	for i := 0; i < n; i++ {
+		end := start + (len(candidates)-start)/(n-i)
+		chunks = append(chunks, candidates[start:end])
+		start = end
+	}
+	return chunks
+}
+
+func complementOf(chunks [][]int, skip int) []int {
+	complement := []int{}
+	for i, chunk := range chunks {
+		if i != skip {
+			complement = append(complement, chunk...)
+		}
+	}
+	return complement
+}
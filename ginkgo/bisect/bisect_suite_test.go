@@ -0,0 +1,13 @@
+package bisect_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestBisect(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Bisect Suite")
+}
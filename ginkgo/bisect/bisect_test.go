@@ -0,0 +1,76 @@
+package bisect_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/onsi/ginkgo/ginkgo/bisect"
+)
+
+//failsWhenRunAfter reproduces the failure when every one of culprits is among the candidates, and records the candidates
+//of each run
+func failsWhenRunAfter(runs *[][]int, culprits ...int) bisect.Reproduces {
+	return func(candidates []int) (bool, error) {
+		*runs = append(*runs, candidates)
+		for _, culprit := range culprits {
+			found := false
+			for _, candidate := range candidates {
+				found = found || candidate == culprit
+			}
+			if !found {
+				return false, nil
+			}
+		}
+		return true, nil
+	}
+}
+
+var _ = Describe("Minimize", func() {
+	var candidates []int
+	var runs [][]int
+
+	BeforeEach(func() {
+		candidates = []int{}
+		for i := 0; i < 20; i++ {
+			candidates = append(candidates, i)
+		}
+		runs = [][]int{}
+	})
+
+	It("finds a single culprit", func() {
+		Ω(bisect.Minimize(candidates, failsWhenRunAfter(&runs, 13))).Should(Equal([]int{13}))
+	})
+
+	It("finds several culprits that only make the spec fail together", func() {
+		Ω(bisect.Minimize(candidates, failsWhenRunAfter(&runs, 2, 17))).Should(Equal([]int{2, 17}))
+		Ω(bisect.Minimize(candidates, failsWhenRunAfter(&runs, 4, 5, 6))).Should(Equal([]int{4, 5, 6}))
+	})
+
+	It("keeps the order of the candidates in each run", func() {
+		bisect.Minimize(candidates, failsWhenRunAfter(&runs, 2, 17))
+		for _, run := range runs {
+			for i := 1; i < len(run); i++ {
+				Ω(run[i]).Should(BeNumerically(">", run[i-1]))
+			}
+		}
+	})
+
+	It("needs far fewer runs than trying each candidate", func() {
+		bisect.Minimize(candidates, failsWhenRunAfter(&runs, 13))
+		Ω(len(runs)).Should(BeNumerically("<", len(candidates)))
+	})
+
+	It("returns a single candidate as is", func() {
+		Ω(bisect.Minimize([]int{7}, failsWhenRunAfter(&runs, 7))).Should(Equal([]int{7}))
+		Ω(runs).Should(BeEmpty())
+	})
+
+	It("stops at the first error", func() {
+		_, err := bisect.Minimize(candidates, func([]int) (bool, error) {
+			return false, errors.New("boom")
+		})
+		Ω(err).Should(MatchError("boom"))
+	})
+})
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/onsi/ginkgo/config"
+	"github.com/onsi/ginkgo/ginkgo/bisect"
+	"github.com/onsi/ginkgo/ginkgo/testsuite"
+	"github.com/onsi/ginkgo/reporters"
+	"github.com/onsi/ginkgo/types"
+)
+
+func BuildBisectCommand() *Command {
+	var failingSpec string
+	commandFlags := NewBuildCommandFlags(flag.NewFlagSet("bisect", flag.ExitOnError))
+	config.Flags(commandFlags.FlagSet, "", false)
+	commandFlags.FlagSet.StringVar(&failingSpec, "failingSpec", "", "The spec that fails depending on the specs that run before it: either its full text, or the file:line of its It (a path suffix such as foo_test.go:42 will do).")
+	return &Command{
+		Name:         "bisect",
+		FlagSet:      commandFlags.FlagSet,
+		UsageCommand: "ginkgo bisect -seed=SEED -failingSpec=SPEC <FLAGS> <PACKAGE>",
+		Usage: []string{
+			"Find the earlier specs that make -failingSpec fail when the specs of the passed in <PACKAGE> (or the package in the current directory if left blank) run in the order of -seed.",
+			"Reruns the failing spec after shrinking subsets of the specs that run before it, and prints the smallest set of them that still makes it fail, each paired with the failing spec.",
+			"Pass the same -seed, -randomizeAllSpecs, -focus and -skip flags as the run that failed.",
+			"Accepts the following flags:",
+		},
+		Command: func(args []string, additionalArgs []string) {
+			bisectSuite(args, commandFlags, failingSpec, additionalArgs)
+		},
+	}
+}
+
+//bisector reruns the failing spec after subsets of the specs that run before it
+type bisector struct {
+	suite          testsuite.TestSuite
+	binary         string
+	additionalArgs []string
+	dir            string
+
+	description string
+	specs       []*types.SpecSummary
+	target      *types.SpecSummary
+	runs        int
+}
+
+func bisectSuite(args []string, commandFlags *RunWatchAndBuildCommandFlags, failingSpec string, additionalArgs []string) {
+	if failingSpec == "" {
+		complainAndQuit("ginkgo bisect needs the -failingSpec to bisect")
+	}
+	suites, _ := findSuites(args, false, commandFlags.SkipPackage, true)
+	if len(suites) != 1 {
+		complainAndQuit(fmt.Sprintf("ginkgo bisect needs exactly one test suite, found %d", len(suites)))
+	}
+	suite := suites[0]
+
+	dir, err := ioutil.TempDir("", "ginkgo-bisect")
+	if err != nil {
+		complainAndQuit(fmt.Sprintf("Failed to create a temporary directory: %s", err.Error()))
+	}
+	defer os.RemoveAll(dir)
+
+	binary, err := compileSuite(suite, commandFlags, dir)
+	if err != nil {
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+	ginkgoConfig := config.GinkgoConfig
+	ginkgoConfig.DryRun = true
+	report, output, err := runCompiledSuite(suite, binary, ginkgoConfig, additionalArgs, filepath.Join(dir, "order.json"))
+	if err != nil {
+		fmt.Printf("Failed to walk the specs of %s:\n%s\n", suite.PackageName, output)
+		os.Exit(1)
+	}
+
+	b := &bisector{suite: suite, binary: binary, additionalArgs: additionalArgs, dir: dir, description: report.SuiteDescription}
+	for _, summary := range report.SpecSummaries {
+		if summary.State == types.SpecStateSkipped || summary.State == types.SpecStatePending {
+			continue
+		}
+		if matchesFailingSpec(summary, failingSpec) {
+			if b.target != nil {
+				complainAndQuit(fmt.Sprintf("-failingSpec=%s matches several specs, pass the file:line of its It", failingSpec))
+			}
+			b.target = summary
+		} else if b.target == nil {
+			b.specs = append(b.specs, summary)
+		}
+	}
+	if b.target == nil {
+		complainAndQuit(fmt.Sprintf("-failingSpec=%s matches none of the specs that run with the passed in flags", failingSpec))
+	}
+
+	fmt.Printf("Bisecting the %d spec(s) that run before %q with seed %d\n", len(b.specs), reporters.SpecFullText(b.target), config.GinkgoConfig.RandomSeed)
+	candidates := make([]int, len(b.specs))
+	for i := range candidates {
+		candidates[i] = i
+	}
+	culprits, err := b.bisect(candidates)
+	if err != nil {
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+
+	fmt.Printf("\n%d spec(s) make %q fail when they run before it:\n", len(culprits), reporters.SpecFullText(b.target))
+	for _, i := range culprits {
+		fmt.Printf("  %s  ->  %s\n", reporters.SpecFullText(b.specs[i]), reporters.SpecFullText(b.target))
+		fmt.Printf("    %s  ->  %s\n", specLocation(b.specs[i]), specLocation(b.target))
+	}
+	fmt.Printf("Found in %d run(s)\n", b.runs)
+}
+
+//bisect checks that the failing spec fails after all the candidates but not on its own, then minimizes the candidates
+func (b *bisector) bisect(candidates []int) ([]int, error) {
+	fails, err := b.reproduces(candidates)
+	if err != nil {
+		return nil, err
+	}
+	if !fails {
+		return nil, fmt.Errorf("%q passes when it runs after the %d spec(s) before it: rerun with the -seed and flags of the run that failed", reporters.SpecFullText(b.target), len(candidates))
+	}
+	fails, err = b.reproduces([]int{})
+	if err != nil {
+		return nil, err
+	}
+	if fails {
+		return nil, fmt.Errorf("%q fails on its own: the specs that run before it have nothing to do with its failure", reporters.SpecFullText(b.target))
+	}
+	return bisect.Minimize(candidates, b.reproduces)
+}
+
+//reproduces runs the specs at the candidate indices, then the failing spec, and tells whether it failed
+func (b *bisector) reproduces(candidates []int) (bool, error) {
+	b.runs++
+	patterns := []string{reporters.FocusPattern(b.description, b.target)}
+	for _, i := range candidates {
+		patterns = append(patterns, reporters.FocusPattern(b.description, b.specs[i]))
+	}
+
+	ginkgoConfig := config.GinkgoConfig
+	//the suite keeps the specs it is focused on in the order of the seed
+	ginkgoConfig.FocusStrings = []string{strings.Join(patterns, "|")}
+	ginkgoConfig.RegexScansFilePath = false
+	ginkgoConfig.FailFast = false
+
+	report, output, err := runCompiledSuite(b.suite, b.binary, ginkgoConfig, b.additionalArgs, filepath.Join(b.dir, fmt.Sprintf("run-%d.json", b.runs)))
+	if err != nil {
+		return false, fmt.Errorf("Failed to run the specs of %s:\n%s", b.suite.PackageName, output)
+	}
+	for _, summary := range report.SpecSummaries {
+		if sameSpec(summary, b.target) && summary.State != types.SpecStateSkipped {
+			fails := summary.HasFailureState()
+			outcome := "passes"
+			if fails {
+				outcome = "fails"
+			}
+			fmt.Printf("  after %d spec(s): %s\n", len(candidates), outcome)
+			return fails, nil
+		}
+	}
+	return false, fmt.Errorf("%q did not run:\n%s", reporters.SpecFullText(b.target), output)
+}
+
+func matchesFailingSpec(summary *types.SpecSummary, failingSpec string) bool {
+	return reporters.SpecFullText(summary) == failingSpec || strings.HasSuffix(specLocation(summary).String(), failingSpec)
+}
+
+func sameSpec(a, b *types.SpecSummary) bool {
+	return strings.Join(a.ComponentTexts, " ") == strings.Join(b.ComponentTexts, " ") && specLocation(a).String() == specLocation(b).String()
+}
+
+func specLocation(summary *types.SpecSummary) types.CodeLocation {
+	return summary.ComponentCodeLocations[len(summary.ComponentCodeLocations)-1]
+}
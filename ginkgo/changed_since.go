@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/onsi/ginkgo/ginkgo/testsuite"
+	"github.com/onsi/ginkgo/ginkgo/watch"
+)
+
+//maxChangedSinceDepth bounds how deep -changedSince looks in the dependency tree of a suite
+const maxChangedSinceDepth = 1000
+
+//changedSelection describes the suites affected by the Go files changed since a git ref
+type changedSelection struct {
+	//Suites are the suites affected by the changes, in the order they were found
+	Suites []testsuite.TestSuite
+	//FocusFiles maps the path of the affected suites whose only changes are to some of their _test.go files to those files
+	FocusFiles map[string][]string
+	//Unaffected lists the paths of the suites that no change affects
+	Unaffected []string
+}
+
+//selectChangedSuites selects the suites affected by changedFiles, the absolute paths of the changed Go files.  A suite is
+//affected when one of the Go files of its package, or of a package it depends on, changed.  Precompiled suites are always
+//selected.
+func selectChangedSuites(suites []testsuite.TestSuite, changedFiles []string) changedSelection {
+	changedDirs := map[string][]string{}
+	for _, file := range changedFiles {
+		dir := filepath.Dir(file)
+		changedDirs[dir] = append(changedDirs[dir], file)
+	}
+
+	selection := changedSelection{FocusFiles: map[string][]string{}}
+	for _, suite := range suites {
+		if suite.Precompiled {
+			selection.Suites = append(selection.Suites, suite)
+			continue
+		}
+
+		dir, err := filepath.Abs(suite.Path)
+		if err != nil {
+			selection.Suites = append(selection.Suites, suite)
+			continue
+		}
+
+		dependencyChanged := false
+		deps, err := watch.NewDependencies(suite.Path, maxChangedSinceDepth)
+		if err != nil {
+			dependencyChanged = true
+		}
+		for dep := range deps.Dependencies() {
+			if dep != dir && len(changedDirs[dep]) > 0 {
+				dependencyChanged = true
+				break
+			}
+		}
+
+		ownChanges := changedDirs[dir]
+		switch {
+		case dependencyChanged || (len(ownChanges) > 0 && !onlyTestFiles(ownChanges)):
+			selection.Suites = append(selection.Suites, suite)
+		case len(ownChanges) > 0:
+			selection.Suites = append(selection.Suites, suite)
+			selection.FocusFiles[suite.Path] = ownChanges
+		default:
+			selection.Unaffected = append(selection.Unaffected, suite.Path)
+		}
+	}
+	return selection
+}
+
+func onlyTestFiles(files []string) bool {
+	for _, file := range files {
+		if !strings.HasSuffix(file, "_test.go") {
+			return false
+		}
+	}
+	return true
+}
+
+//focusOnFilesArgs returns the arguments that focus a suite on the specs defined in files
+func focusOnFilesArgs(files []string) []string {
+	patterns := []string{}
+	for _, file := range files {
+		patterns = append(patterns, regexp.QuoteMeta(file)+"$")
+	}
+	return []string{"--ginkgo.regexScansFilePath", "--ginkgo.focus=" + strings.Join(patterns, "|")}
+}
+
+//changedGoFiles returns the absolute paths of the Go files that differ from ref, including uncommitted and untracked files
+func changedGoFiles(ref string) ([]string, error) {
+	root, err := gitOutput("rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, err
+	}
+	diff, err := gitOutput("diff", "--name-only", ref, "--")
+	if err != nil {
+		return nil, err
+	}
+	untracked, err := gitOutput("ls-files", "--others", "--exclude-standard", "--full-name")
+	if err != nil {
+		return nil, err
+	}
+
+	files := []string{}
+	for _, file := range strings.Split(diff+"\n"+untracked, "\n") {
+		file = strings.TrimSpace(file)
+		if strings.HasSuffix(file, ".go") {
+			files = append(files, filepath.Join(root, filepath.FromSlash(file)))
+		}
+	}
+	return files, nil
+}
+
+func gitOutput(args ...string) (string, error) {
+	output, err := exec.Command("git", args...).Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return "", fmt.Errorf("git %s failed: %s", strings.Join(args, " "), strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", fmt.Errorf("git %s failed: %s", strings.Join(args, " "), err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
@@ -1,7 +1,6 @@
 package convert
 
 import (
-	"fmt"
 	"go/ast"
 	"strings"
 	"unicode"
@@ -65,20 +64,26 @@ func blockStatementFromDescribe(desc *ast.CallExpr) *ast.BlockStmt {
 	return funcLit.Body
 }
 
-/* convenience function for creating an It("TestNameHere")
- * with all the body of the test function inside the anonymous
- * func passed to It()
+/*
+ * Convenience function to return the name of the *testing.T param
+ * of the func literal passed to a t.Run subtest
+ */
+func namedTestingTArgOfSubtest(subtest *ast.CallExpr) string {
+	return subtest.Args[1].(*ast.FuncLit).Type.Params.List[0].Names[0].Name
+}
+
+/*
+ * convenience function for creating a Describe(name, func() { ... }) or an
+ * It(name, func() { ... }) statement holding the passed in statements
  */
-func createItStatementForTestFunc(testFunc *ast.FuncDecl) *ast.ExprStmt {
-	blockStatement := &ast.BlockStmt{List: testFunc.Body.List}
+func createNodeStatement(node string, name ast.Expr, statements []ast.Stmt) *ast.ExprStmt {
+	blockStatement := &ast.BlockStmt{List: statements}
 	fieldList := &ast.FieldList{}
 	funcType := &ast.FuncType{Params: fieldList}
 	funcLit := &ast.FuncLit{Type: funcType, Body: blockStatement}
 
-	testName := rewriteTestName(testFunc.Name.Name)
-	basicLit := &ast.BasicLit{Kind: 9, Value: fmt.Sprintf("\"%s\"", testName)}
-	itBlockIdent := &ast.Ident{Name: "It"}
-	callExpr := &ast.CallExpr{Fun: itBlockIdent, Args: []ast.Expr{basicLit, funcLit}}
+	nodeIdent := &ast.Ident{Name: node}
+	callExpr := &ast.CallExpr{Fun: nodeIdent, Args: []ast.Expr{name, funcLit}}
 	return &ast.ExprStmt{X: callExpr}
 }
 
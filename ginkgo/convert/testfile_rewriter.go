@@ -67,7 +67,9 @@ func rewriteTestsInFile(pathToFile string) {
 /*
  * Given a test func named TestDoesSomethingNeat, rewrites it as
  * It("does something neat", func() { __test_body_here__ }) and adds it
- * to the Describe's list of statements
+ * to the Describe's list of statements.
+ * A test func that only runs subtests is rewritten as a nested Describe instead,
+ * see createStatementForTestFunc
  */
 func rewriteTestFuncAsItStatement(testFunc *ast.FuncDecl, rootNode *ast.File, describe *ast.CallExpr) {
 	var funcIndex int = -1
@@ -83,7 +85,7 @@ func rewriteTestFuncAsItStatement(testFunc *ast.FuncDecl, rootNode *ast.File, de
 	}
 
 	var block *ast.BlockStmt = blockStatementFromDescribe(describe)
-	block.List = append(block.List, createItStatementForTestFunc(testFunc))
+	block.List = append(block.List, createStatementForTestFunc(testFunc))
 	replaceTestingTsWithGinkgoT(block, namedTestingTArg(testFunc))
 
 	// remove the old test func from the root node's declarations
@@ -160,3 +162,86 @@ func replaceTestingTsInArgsLists(callExpr *ast.CallExpr, testingT string) {
 		}
 	}
 }
+
+/*
+ * Given a test func, returns the Ginkgo statement it is rewritten as:
+ * - when the func only runs subtests through t.Run, a Describe holding
+ *   a node for each subtest, themselves rewritten the same way
+ * - otherwise an It, in which any subtest is rewritten as a By step
+ */
+func createStatementForTestFunc(testFunc *ast.FuncDecl) ast.Stmt {
+	testName := &ast.BasicLit{Kind: token.STRING, Value: fmt.Sprintf("\"%s\"", rewriteTestName(testFunc.Name.Name))}
+	return createStatementForTest(testName, testFunc.Body, namedTestingTArg(testFunc))
+}
+
+func createStatementForTest(name ast.Expr, body *ast.BlockStmt, testingT string) ast.Stmt {
+	if subtests := findSubtests(body, testingT); len(subtests) > 0 {
+		statements := []ast.Stmt{}
+		for _, subtest := range subtests {
+			statements = append(statements, createStatementForTest(subtest.Args[0], subtest.Args[1].(*ast.FuncLit).Body, namedTestingTArgOfSubtest(subtest)))
+		}
+		return createNodeStatement("Describe", name, statements)
+	}
+
+	rewriteSubtestsAsSteps(body, testingT)
+	replaceTestingTsWithGinkgoT(body, testingT)
+	return createNodeStatement("It", name, body.List)
+}
+
+/*
+ * Returns the t.Run(name, func(t *testing.T) { ... }) calls the statements
+ * of a test consist of, or nothing if they do anything else
+ */
+func findSubtests(body *ast.BlockStmt, testingT string) []*ast.CallExpr {
+	subtests := []*ast.CallExpr{}
+	for _, statement := range body.List {
+		exprStmt, ok := statement.(*ast.ExprStmt)
+		if !ok {
+			return nil
+		}
+		callExpr, ok := exprStmt.X.(*ast.CallExpr)
+		if !ok || !isSubtest(callExpr, testingT) {
+			return nil
+		}
+		subtests = append(subtests, callExpr)
+	}
+	return subtests
+}
+
+/*
+ * rewrites the t.Run(name, func(t *testing.T) { ... }) statements found in a
+ * test as By(name, func() { ... }) steps
+ */
+func rewriteSubtestsAsSteps(body *ast.BlockStmt, testingT string) {
+	ast.Inspect(body, func(node ast.Node) bool {
+		exprStmt, ok := node.(*ast.ExprStmt)
+		if !ok {
+			return true
+		}
+		callExpr, ok := exprStmt.X.(*ast.CallExpr)
+		if !ok || !isSubtest(callExpr, testingT) {
+			return true
+		}
+
+		funcLit := callExpr.Args[1].(*ast.FuncLit)
+		subtestT := namedTestingTArgOfSubtest(callExpr)
+		rewriteSubtestsAsSteps(funcLit.Body, subtestT)
+		replaceTestingTsWithGinkgoT(funcLit.Body, subtestT)
+		funcLit.Type.Params = &ast.FieldList{}
+		callExpr.Fun = &ast.Ident{Name: "By"}
+		return false
+	})
+}
+
+func isSubtest(callExpr *ast.CallExpr, testingT string) bool {
+	selectorExpr, ok := callExpr.Fun.(*ast.SelectorExpr)
+	if !ok || selectorExpr.Sel.Name != "Run" || len(callExpr.Args) != 2 {
+		return false
+	}
+	ident, ok := selectorExpr.X.(*ast.Ident)
+	if !ok || ident.Name != testingT {
+		return false
+	}
+	funcLit, ok := callExpr.Args[1].(*ast.FuncLit)
+	return ok && len(funcLit.Type.Params.List) == 1 && len(funcLit.Type.Params.List[0].Names) == 1
+}
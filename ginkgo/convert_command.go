@@ -17,6 +17,7 @@ func BuildConvertCommand() *Command {
 		UsageCommand: "ginkgo convert /path/to/package",
 		Usage: []string{
 			"Convert the package at the passed in path from an XUnit-style test to a Ginkgo-style test",
+			"Tests that only run subtests through t.Run become Describes nesting them, other subtests become By steps.",
 		},
 		Command: convertPackage,
 	}
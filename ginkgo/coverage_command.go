@@ -0,0 +1,144 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/onsi/ginkgo/config"
+	"github.com/onsi/ginkgo/ginkgo/speccoverage"
+	"github.com/onsi/ginkgo/ginkgo/testsuite"
+	"github.com/onsi/ginkgo/reporters"
+	"github.com/onsi/ginkgo/types"
+)
+
+//DefaultSpecCoverageFile is where ginkgo coverage writes the report of a suite, relative to the suite
+const DefaultSpecCoverageFile = "spec_coverage.json"
+
+func BuildCoverageCommand() *Command {
+	var specCoverageFile string
+	commandFlags := NewBuildCommandFlags(flag.NewFlagSet("coverage", flag.ExitOnError))
+	config.Flags(commandFlags.FlagSet, "", false)
+	commandFlags.FlagSet.StringVar(&specCoverageFile, "specCoverageFile", DefaultSpecCoverageFile, "Where to write the JSON report of each suite, along with the functions each spec covered, relative to the suite.")
+	return &Command{
+		Name:         "coverage",
+		FlagSet:      commandFlags.FlagSet,
+		UsageCommand: "ginkgo coverage <FLAGS> <PACKAGES>",
+		Usage: []string{
+			"EXPERIMENTAL: Attribute code coverage to the specs of the passed in <PACKAGES> (or the package in the current directory if left blank).",
+			"Builds each suite with -cover and runs each spec that would run with the passed in flags in a process of its own, as coverage counters cannot be read while a test binary runs.",
+			"Writes the JSON report of each suite to -specCoverageFile, with the functions each spec covered in the CoveredFunctions of its summary.  What BeforeSuite and AfterSuite cover is covered by every spec.",
+			"Pass -coverpkg to cover the packages the suite tests besides its own.",
+			"Accepts the following flags:",
+		},
+		Command: func(args []string, additionalArgs []string) {
+			coverSuites(args, commandFlags, specCoverageFile, additionalArgs)
+		},
+	}
+}
+
+func coverSuites(args []string, commandFlags *RunWatchAndBuildCommandFlags, specCoverageFile string, additionalArgs []string) {
+	suites, _ := findSuites(args, commandFlags.Recurse, commandFlags.SkipPackage, true)
+	if len(suites) == 0 {
+		complainAndQuit("Found no test suites")
+	}
+	*commandFlags.GoOpts["cover"].(*bool) = true
+
+	dir, err := ioutil.TempDir("", "ginkgo-coverage")
+	if err != nil {
+		complainAndQuit(fmt.Sprintf("Failed to create a temporary directory: %s", err.Error()))
+	}
+	defer os.RemoveAll(dir)
+
+	passed := true
+	for _, suite := range suites {
+		report, err := coverSuite(suite, commandFlags, additionalArgs, dir)
+		if err != nil {
+			fmt.Println(err.Error())
+			passed = false
+			continue
+		}
+
+		reportFile := specCoverageFile
+		if !filepath.IsAbs(reportFile) {
+			reportFile = filepath.Join(suiteDir(suite), reportFile)
+		}
+		if err := reporters.WriteJSONReport(reportFile, report); err != nil {
+			fmt.Printf("Failed to write the coverage report of %s: %s\n", suite.PackageName, err.Error())
+			passed = false
+			continue
+		}
+		fmt.Printf("Wrote the coverage of each spec of %s to %s\n", suite.PackageName, reportFile)
+	}
+
+	if !passed {
+		os.Exit(1)
+	}
+}
+
+//coverSuite runs each spec of suite that would run on its own, and returns the report of the suite with the functions
+//each spec covered
+func coverSuite(suite testsuite.TestSuite, commandFlags *RunWatchAndBuildCommandFlags, additionalArgs []string, dir string) (reporters.JSONReport, error) {
+	binary, err := compileSuite(suite, commandFlags, dir)
+	if err != nil {
+		return reporters.JSONReport{}, err
+	}
+	ginkgoConfig := config.GinkgoConfig
+	ginkgoConfig.DryRun = true
+	report, output, err := runCompiledSuite(suite, binary, ginkgoConfig, additionalArgs, filepath.Join(dir, suite.PackageName+".json"))
+	if err != nil {
+		return report, fmt.Errorf("Failed to walk the specs of %s:\n%s", suite.PackageName, output)
+	}
+
+	toRun := 0
+	for _, summary := range report.SpecSummaries {
+		if summary.State != types.SpecStateSkipped && summary.State != types.SpecStatePending {
+			toRun++
+		}
+	}
+	fmt.Printf("Covering the %d spec(s) of %s, one at a time\n", toRun, suite.PackageName)
+
+	ginkgoConfig = config.GinkgoConfig
+	ginkgoConfig.RegexScansFilePath = false
+	ginkgoConfig.FailFast = false
+	ran := 0
+	for i, summary := range report.SpecSummaries {
+		if summary.State == types.SpecStateSkipped || summary.State == types.SpecStatePending {
+			continue
+		}
+		ran++
+
+		coverDir := filepath.Join(dir, fmt.Sprintf("%s-%d", suite.PackageName, i))
+		if err := os.MkdirAll(coverDir, os.ModePerm); err != nil {
+			return report, err
+		}
+		ginkgoConfig.FocusStrings = []string{reporters.FocusPattern(report.SuiteDescription, summary)}
+		specReport, output, err := runCompiledSuite(suite, binary, ginkgoConfig, append(additionalArgs, "-test.gocoverdir="+coverDir), filepath.Join(coverDir, "report.json"))
+		if err != nil {
+			return report, fmt.Errorf("Failed to run %q:\n%s", reporters.SpecFullText(summary), output)
+		}
+		for _, specSummary := range specReport.SpecSummaries {
+			if sameSpec(specSummary, summary) && specSummary.State != types.SpecStateSkipped {
+				report.SpecSummaries[i] = specSummary
+			}
+		}
+
+		functions, err := speccoverage.ReadCoveredFunctions(coverDir)
+		if err != nil {
+			return report, err
+		}
+		report.SpecSummaries[i].CoveredFunctions = functions
+		fmt.Printf("  [%d/%d] %s: %d function(s)\n", ran, toRun, reporters.SpecFullText(summary), len(functions))
+	}
+	return report, nil
+}
+
+//suiteDir returns the directory of suite, or the directory of its test binary if it is precompiled
+func suiteDir(suite testsuite.TestSuite) string {
+	if suite.Precompiled {
+		return filepath.Dir(suite.Path)
+	}
+	return suite.Path
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"html"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/onsi/ginkgo/ginkgo/outline"
+	"github.com/onsi/ginkgo/ginkgo/testsuite"
+)
+
+func BuildDocsCommand() *Command {
+	var format, output, skipPackage string
+	var recurse bool
+	flagSet := flag.NewFlagSet("docs", flag.ExitOnError)
+	flagSet.StringVar(&format, "format", "markdown", "Format of the document. Accepted: 'markdown', 'html'")
+	flagSet.StringVar(&output, "output", "", "Write the document to this file rather than to stdout")
+	flagSet.BoolVar(&recurse, "r", false, "Find and document test suites under the current directory recursively.")
+	flagSet.StringVar(&skipPackage, "skipPackage", "", "A comma-separated list of package names to be skipped.  If any part of the package's path matches, that package is ignored.")
+	return &Command{
+		Name:         "docs",
+		FlagSet:      flagSet,
+		UsageCommand: "ginkgo docs <FLAGS> <PACKAGES>",
+		Usage: []string{
+			"Document the specs of the passed in <PACKAGES> (or the package in the current directory if left blank) without running them.",
+			"Containers become headings, specs become bullet points and labels become tags.",
+			"Accepts the following flags:",
+		},
+		Command: func(args []string, additionalArgs []string) {
+			documentSuites(args, format, output, recurse, skipPackage)
+		},
+	}
+}
+
+func documentSuites(args []string, format string, output string, recurse bool, skipPackage string) {
+	if format != "markdown" && format != "html" {
+		complainAndQuit(fmt.Sprintf("format %s not accepted", format))
+	}
+
+	suites, _ := findSuites(args, recurse, skipPackage, false)
+	if len(suites) == 0 {
+		complainAndQuit("Found no test suites")
+	}
+
+	var b strings.Builder
+	for _, suite := range suites {
+		b.WriteString(documentSuite(suite, format))
+	}
+
+	document := b.String()
+	if format == "html" {
+		document = fmt.Sprintf("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>Specs</title>\n</head>\n<body>\n%s</body>\n</html>\n", document)
+	}
+
+	if output == "" {
+		fmt.Print(document)
+		return
+	}
+	err := ioutil.WriteFile(output, []byte(document), 0666)
+	if err != nil {
+		complainAndQuit(fmt.Sprintf("Failed to write the document: %s", err.Error()))
+	}
+}
+
+//documentSuite documents the test files of the suite, in alphabetical order, under a heading naming its package
+func documentSuite(suite testsuite.TestSuite, format string) string {
+	files, err := filepath.Glob(filepath.Join(suite.Path, "*_test.go"))
+	if err != nil {
+		complainAndQuit(fmt.Sprintf("Failed to list the test files of %s: %s", suite.Path, err.Error()))
+	}
+	sort.Strings(files)
+
+	var b strings.Builder
+	if format == "html" {
+		b.WriteString(fmt.Sprintf("<h1>%s</h1>\n", html.EscapeString(suite.PackageName)))
+	} else {
+		b.WriteString(fmt.Sprintf("# %s\n\n", suite.PackageName))
+	}
+	for _, file := range files {
+		fset := token.NewFileSet()
+		parsedSrc, err := parser.ParseFile(fset, file, nil, parser.ParseComments)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Skipping %s: %s\n", file, err.Error())
+			continue
+		}
+		o, err := outline.FromASTFile(fset, parsedSrc)
+		if err != nil {
+			//the file does not use Ginkgo
+			continue
+		}
+		if format == "html" {
+			b.WriteString(o.HTML(2))
+		} else {
+			b.WriteString(o.Markdown(2))
+		}
+	}
+	return b.String()
+}
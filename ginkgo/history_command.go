@@ -0,0 +1,114 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/onsi/ginkgo/reporters"
+)
+
+func BuildHistoryCommand() *Command {
+	var historyDir, skipPackage string
+	var lastRuns int
+	var durationThreshold float64
+	var recurse bool
+	flagSet := flag.NewFlagSet("history", flag.ExitOnError)
+	flagSet.StringVar(&historyDir, "historyDir", ".ginkgo-history", "The directory the suites stored their run history in with -historyDir.  Relative paths are resolved against each package.")
+	flagSet.IntVar(&lastRuns, "n", 10, "The number of most recent runs of each suite to show.")
+	flagSet.Float64Var(&durationThreshold, "durationThreshold", 1.0, "(in seconds) Specs that ran slower in the latest run than on average over the previous runs by more than this threshold are flagged.")
+	flagSet.BoolVar(&recurse, "r", false, "Find and show the history of test suites under the current directory recursively.")
+	flagSet.StringVar(&skipPackage, "skipPackage", "", "A comma-separated list of package names to be skipped.  If any part of the package's path matches, that package is ignored.")
+	return &Command{
+		Name:         "history",
+		FlagSet:      flagSet,
+		UsageCommand: "ginkgo history <FLAGS> <PACKAGES>",
+		Usage: []string{
+			"Show the trend of the passed in <PACKAGES> (or the package in the current directory if left blank) over their last runs, as stored by running them with -historyDir.",
+			"For each suite, lists the pass rate of each run, the specs that became flaky and the specs whose duration regressed in the latest run.",
+			"Accepts the following flags:",
+		},
+		Command: func(args []string, additionalArgs []string) {
+			showHistory(args, historyDir, lastRuns, time.Duration(durationThreshold*float64(time.Second)), recurse, skipPackage)
+		},
+	}
+}
+
+func showHistory(args []string, historyDir string, lastRuns int, durationThreshold time.Duration, recurse bool, skipPackage string) {
+	suites, _ := findSuites(args, recurse, skipPackage, false)
+	if len(suites) == 0 {
+		complainAndQuit("Found no test suites")
+	}
+
+	dirs := []string{}
+	seen := map[string]bool{}
+	for _, suite := range suites {
+		dir := historyDir
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(suite.Path, dir)
+		}
+		if !seen[dir] {
+			seen[dir] = true
+			dirs = append(dirs, dir)
+		}
+	}
+
+	reports := []reporters.JSONReport{}
+	for _, dir := range dirs {
+		dirReports, err := reporters.ReadHistory(dir)
+		if err != nil {
+			complainAndQuit(fmt.Sprintf("Failed to read the run history in %s: %s", dir, err.Error()))
+		}
+		reports = append(reports, dirReports...)
+	}
+	if len(reports) == 0 {
+		complainAndQuit(fmt.Sprintf("Found no run history in %s, run the suites with -historyDir=%s to record it", historyDir, historyDir))
+	}
+
+	for _, history := range reporters.AnalyzeHistory(reports, lastRuns, durationThreshold) {
+		fmt.Println(renderHistory(history, durationThreshold))
+	}
+}
+
+//renderHistory lists the runs of a suite, oldest first, followed by its newly flaky specs and duration regressions
+func renderHistory(history reporters.SuiteHistory, durationThreshold time.Duration) string {
+	s := fmt.Sprintf("%s (last %d run(s))\n", history.SuiteDescription, len(history.Runs))
+	for _, run := range history.Runs {
+		outcome := "PASS"
+		if !run.SuiteSucceeded {
+			outcome = "FAIL"
+		}
+		s += fmt.Sprintf("  %s  %s  %3.0f%% of %d spec(s) passed", run.StartTime.Local().Format("2006-01-02 15:04:05"), outcome, run.PassRate()*100, run.Passed+run.Failed)
+		if run.Flaked > 0 {
+			s += fmt.Sprintf(", %d flaked", run.Flaked)
+		}
+		s += fmt.Sprintf(" in %s\n", run.RunTime.Round(time.Millisecond))
+	}
+
+	if len(history.Runs) > 1 {
+		first, last := history.Runs[0].PassRate(), history.Runs[len(history.Runs)-1].PassRate()
+		trend := "steady"
+		if last > first {
+			trend = "improving"
+		} else if last < first {
+			trend = "declining"
+		}
+		s += fmt.Sprintf("  Pass rate %s: %.0f%% -> %.0f%%\n", trend, first*100, last*100)
+	}
+
+	if len(history.NewlyFlakySpecs) > 0 {
+		s += "  Newly flaky specs:\n"
+		for _, spec := range history.NewlyFlakySpecs {
+			s += fmt.Sprintf("    %s\n", spec)
+		}
+	}
+
+	if len(history.DurationRegressions) > 0 {
+		s += fmt.Sprintf("  Specs more than %s slower than on average:\n", durationThreshold)
+		for _, regression := range history.DurationRegressions {
+			s += fmt.Sprintf("    %s: %s -> %s\n      %s\n", regression.Spec, regression.PreviousRunTime.Round(time.Millisecond), regression.RunTime.Round(time.Millisecond), regression.CodeLocation)
+		}
+	}
+	return s
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/onsi/ginkgo/ginkgo/speccoverage"
+	"github.com/onsi/ginkgo/ginkgo/testsuite"
+	"github.com/onsi/ginkgo/reporters"
+)
+
+//impactedChanges describes the changes of a git diff, for -impactedBy
+type impactedChanges struct {
+	//Functions are the changed functions, as file:function, see speccoverage.ChangedFunctions
+	Functions map[string]bool
+	//TestFiles are the absolute paths of the changed _test.go files
+	TestFiles []string
+	//Unattributed lists the changed Go files some changes of which cannot be attributed to functions, including new files
+	Unattributed []string
+}
+
+//impactedSelection describes the specs impacted by the changes of a git diff
+type impactedSelection struct {
+	//Suites are the suites with impacted specs, or without coverage data, in the order they were found
+	Suites []testsuite.TestSuite
+	//FocusArgs maps the path of the suites with coverage data to the arguments that focus them on their impacted specs
+	FocusArgs map[string][]string
+	//Unaffected lists the paths of the suites no spec of which is impacted
+	Unaffected []string
+	//WithoutData lists the paths of the suites without coverage data, which run every spec
+	WithoutData []string
+}
+
+//selectImpactedSuites returns the suites with specs impacted by -impactedBy and records the arguments that focus them in
+//focusArgs, exiting when no spec is impacted
+func (r *SpecRunner) selectImpactedSuites(suites []testsuite.TestSuite, focusArgs map[string][]string) []testsuite.TestSuite {
+	changes, err := diffChanges(r.commandFlags.ImpactedBy)
+	if err != nil {
+		complainAndQuit(err.Error())
+	}
+	if len(changes.Unattributed) > 0 {
+		fmt.Println("Will run every spec, as some changes to these files cannot be attributed to functions:")
+		for _, file := range changes.Unattributed {
+			fmt.Println("  " + file)
+		}
+		return suites
+	}
+
+	selection := selectImpactedSpecs(suites, changes, r.commandFlags.SpecCoverageFile)
+	if len(selection.WithoutData) > 0 {
+		fmt.Println("Will run every spec of suites without coverage data (see ginkgo coverage):")
+		for _, path := range selection.WithoutData {
+			fmt.Println("  " + path)
+		}
+	}
+	if len(selection.Unaffected) > 0 {
+		fmt.Printf("Will skip suites with no spec impacted by %s:\n", r.commandFlags.ImpactedBy)
+		for _, path := range selection.Unaffected {
+			fmt.Println("  " + path)
+		}
+	}
+	if len(selection.Suites) == 0 {
+		fmt.Println("No specs impacted by changes!  Exiting...")
+		os.Exit(0)
+	}
+	for path, args := range selection.FocusArgs {
+		focusArgs[path] = args
+	}
+	return selection.Suites
+}
+
+//diffChanges returns the changes of the Go files git diff tells for diff, e.g. origin/main or HEAD~1..HEAD, along with
+//the untracked Go files
+func diffChanges(diff string) (impactedChanges, error) {
+	changes := impactedChanges{Functions: map[string]bool{}}
+	root, err := gitOutput("rev-parse", "--show-toplevel")
+	if err != nil {
+		return changes, err
+	}
+	output, err := gitOutput("diff", "--unified=0", "--no-color", "--no-ext-diff", diff, "--")
+	if err != nil {
+		return changes, err
+	}
+	untracked, err := gitOutput("ls-files", "--others", "--exclude-standard", "--full-name")
+	if err != nil {
+		return changes, err
+	}
+
+	importPaths := map[string]string{}
+	importPathOf := func(dir string) (string, bool) {
+		if importPath, ok := importPaths[dir]; ok {
+			return importPath, importPath != ""
+		}
+		cmd := exec.Command("go", "list", "-f", "{{.ImportPath}}", ".")
+		cmd.Dir = dir
+		output, err := cmd.Output()
+		importPaths[dir] = ""
+		if err == nil {
+			importPaths[dir] = strings.TrimSpace(string(output))
+		}
+		return importPaths[dir], importPaths[dir] != ""
+	}
+
+	hunks := speccoverage.ParseDiff(output)
+	for _, file := range strings.Split(untracked, "\n") {
+		file = strings.TrimSpace(file)
+		if strings.HasSuffix(file, ".go") {
+			hunks[file] = nil
+		}
+	}
+	for file, fileHunks := range hunks {
+		if !strings.HasSuffix(file, ".go") {
+			continue
+		}
+		path := filepath.Join(root, filepath.FromSlash(file))
+		if strings.HasSuffix(file, "_test.go") {
+			changes.TestFiles = append(changes.TestFiles, path)
+			continue
+		}
+
+		src, err := ioutil.ReadFile(path)
+		if err != nil || fileHunks == nil {
+			changes.Unattributed = append(changes.Unattributed, path)
+			continue
+		}
+		functions, ok := speccoverage.ChangedFunctions(path, src, fileHunks)
+		importPath, found := importPathOf(filepath.Dir(path))
+		if !ok || !found {
+			changes.Unattributed = append(changes.Unattributed, path)
+			continue
+		}
+		for _, function := range functions {
+			changes.Functions[importPath+"/"+filepath.Base(path)+":"+function] = true
+		}
+	}
+	return changes, nil
+}
+
+//selectImpactedSpecs selects the specs of suites that changes impact: the specs that covered a changed function, as
+//recorded by ginkgo coverage in specCoverageFile, and the specs defined in the changed test files.  Suites without
+//coverage data run every spec.
+func selectImpactedSpecs(suites []testsuite.TestSuite, changes impactedChanges, specCoverageFile string) impactedSelection {
+	selection := impactedSelection{FocusArgs: map[string][]string{}}
+	for _, suite := range suites {
+		reportFile := specCoverageFile
+		if !filepath.IsAbs(reportFile) {
+			reportFile = filepath.Join(suiteDir(suite), reportFile)
+		}
+		report, err := reporters.ReadJSONReport(reportFile)
+		if err != nil {
+			selection.Suites = append(selection.Suites, suite)
+			selection.WithoutData = append(selection.WithoutData, suite.Path)
+			continue
+		}
+
+		patterns := []string{}
+		dir, _ := filepath.Abs(suiteDir(suite))
+		for _, file := range changes.TestFiles {
+			if filepath.Dir(file) == dir {
+				patterns = append(patterns, regexp.QuoteMeta(file)+"$")
+			}
+		}
+		for _, summary := range report.SpecSummaries {
+			for _, function := range summary.CoveredFunctions {
+				if changes.Functions[function] {
+					//the suite matches -focus against its description, the texts of the spec and its file
+					text := report.SuiteDescription + " " + strings.Join(summary.ComponentTexts, " ") + " "
+					patterns = append(patterns, "^"+regexp.QuoteMeta(text)+".*"+regexp.QuoteMeta(filepath.Base(specLocation(summary).FileName))+"$")
+					break
+				}
+			}
+		}
+
+		if len(patterns) == 0 {
+			selection.Unaffected = append(selection.Unaffected, suite.Path)
+			continue
+		}
+		selection.Suites = append(selection.Suites, suite)
+		selection.FocusArgs[suite.Path] = []string{"--ginkgo.regexScansFilePath", "--ginkgo.focus=" + strings.Join(patterns, "|")}
+	}
+	return selection
+}
@@ -1,10 +1,7 @@
 package interrupthandler
 
 import (
-	"os"
-	"os/signal"
 	"sync"
-	"syscall"
 )
 
 type InterruptHandler struct {
@@ -31,22 +28,3 @@ func (h *InterruptHandler) WasInterrupted() bool {
 
 	return h.interruptCount > 0
 }
-
-func (h *InterruptHandler) handleInterrupt() {
-	c := make(chan os.Signal, 1)
-	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
-
-	<-c
-	signal.Stop(c)
-
-	h.lock.Lock()
-	h.interruptCount++
-	if h.interruptCount == 1 {
-		close(h.C)
-	} else if h.interruptCount > 5 {
-		os.Exit(1)
-	}
-	h.lock.Unlock()
-
-	go h.handleInterrupt()
-}
@@ -0,0 +1,12 @@
+// +build js wasip1
+
+package interrupthandler
+
+//handleInterrupt does nothing on js/wasm and wasip1, which deliver no signals: the handler is never interrupted
+func (h *InterruptHandler) handleInterrupt() {
+	//noop
+}
+
+func SwallowSigQuit() {
+	//noop
+}
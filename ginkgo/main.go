@@ -116,6 +116,18 @@ To print an outline of Ginkgo specs and containers in a file:
 
 	gingko outline <filename>
 
+To document the specs of a package as Markdown (or HTML, with -format=html), containers as headings, specs as bullet points and labels as tags:
+
+	ginkgo docs <path-to-package>
+
+To explain which specs would run with the given focus and skip flags, and which rule selected or left out each of them:
+
+	ginkgo why -focus=<REGEXP> -skip=<REGEXP> <path-to-package>
+
+To show the pass rate trend, newly flaky specs and duration regressions over the last runs recorded with -historyDir=.ginkgo-history:
+
+	ginkgo history -n=10 <path-to-package>
+
 To print out Ginkgo's version:
 
 	ginkgo version
@@ -178,6 +190,11 @@ func init() {
 	Commands = append(Commands, BuildVersionCommand())
 	Commands = append(Commands, BuildHelpCommand())
 	Commands = append(Commands, BuildOutlineCommand())
+	Commands = append(Commands, BuildDocsCommand())
+	Commands = append(Commands, BuildWhyCommand())
+	Commands = append(Commands, BuildHistoryCommand())
+	Commands = append(Commands, BuildBisectCommand())
+	Commands = append(Commands, BuildCoverageCommand())
 }
 
 func main() {
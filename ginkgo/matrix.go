@@ -0,0 +1,213 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/onsi/ginkgo/config"
+	"github.com/onsi/ginkgo/ginkgo/testsuite"
+	"github.com/onsi/ginkgo/reporters"
+	"gopkg.in/yaml.v2"
+)
+
+//matrixEntry is an entry of the YAML file passed to -envMatrix:
+//
+//	- name: postgres
+//	  env:
+//	    DB: postgres
+//	  flags: ["--ginkgo.focus=storage"]
+//	- env:
+//	    DB: sqlite
+//
+//The suites run once per entry, with its environment variables set and its flags passed through to the test processes,
+//as if they followed --.  The name is optional.
+type matrixEntry struct {
+	Name  string            `yaml:"name"`
+	Env   map[string]string `yaml:"env"`
+	Flags []string          `yaml:"flags"`
+}
+
+func readEnvMatrix(path string) ([]matrixEntry, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	entries := []matrixEntry{}
+	if err := yaml.UnmarshalStrict(data, &entries); err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("%s has no entries", path)
+	}
+	for i := range entries {
+		if entries[i].Name == "" {
+			entries[i].Name = entries[i].describe()
+		}
+	}
+	return entries, nil
+}
+
+//values returns the environment variables of the entry, along with its flags under the "flags" key
+func (entry matrixEntry) values() map[string]string {
+	values := map[string]string{}
+	for key, value := range entry.Env {
+		values[key] = value
+	}
+	if len(entry.Flags) > 0 {
+		values["flags"] = strings.Join(entry.Flags, " ")
+	}
+	return values
+}
+
+func (entry matrixEntry) describe() string {
+	values := entry.values()
+	keys := []string{}
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	description := []string{}
+	for _, key := range keys {
+		description = append(description, key+"="+values[key])
+	}
+	if len(description) == 0 {
+		return "(no values)"
+	}
+	return strings.Join(description, " ")
+}
+
+//setEnv sets the environment variables of the entry, and returns the function restoring their previous values
+func (entry matrixEntry) setEnv() func() {
+	previous := map[string]*string{}
+	for key, value := range entry.Env {
+		if old, ok := os.LookupEnv(key); ok {
+			previous[key] = &old
+		} else {
+			previous[key] = nil
+		}
+		os.Setenv(key, value)
+	}
+	return func() {
+		for key, old := range previous {
+			if old == nil {
+				os.Unsetenv(key)
+			} else {
+				os.Setenv(key, *old)
+			}
+		}
+	}
+}
+
+//runMatrix runs the suites once per entry of the -envMatrix file, collecting the JSON report of each suite in its
+//package directory, then summarizes the runs and exits
+func (r *SpecRunner) runMatrix(suites []testsuite.TestSuite, focusArgs map[string][]string, additionalArgs []string) {
+	entries, err := readEnvMatrix(r.commandFlags.EnvMatrix)
+	if err != nil {
+		complainAndQuit(fmt.Sprintf("Failed to read -envMatrix: %s", err.Error()))
+	}
+	if config.DefaultReporterConfig.JSONReportFile != "" {
+		complainAndQuit("-jsonReport can't be combined with -envMatrix, use -matrixReport to write the merged report of the runs")
+	}
+	if r.commandFlags.UntilItFails {
+		complainAndQuit("-untilItFails can't be combined with -envMatrix")
+	}
+
+	t := time.Now()
+	numSuites := 0
+	runs := []reporters.MatrixRun{}
+	for i, entry := range entries {
+		fmt.Printf("\nMatrix entry %d/%d: %s\n", i+1, len(entries), entry.Name)
+
+		reportFile := fmt.Sprintf(".ginkgo-matrix-%d.json", i)
+		config.DefaultReporterConfig.JSONReportFile = reportFile
+		restoreEnv := entry.setEnv()
+
+		runners := r.buildRunners(suites, focusArgs, append(append([]string{}, additionalArgs...), entry.Flags...))
+		runResult, n := r.suiteRunner.RunSuites(r.randomizeOrder(runners), r.commandFlags.NumCompilers, r.commandFlags.KeepGoing, nil)
+		for _, runner := range runners {
+			runner.CleanUp()
+		}
+
+		restoreEnv()
+		config.DefaultReporterConfig.JSONReportFile = ""
+		numSuites += n
+
+		run := reporters.MatrixRun{Name: entry.Name, Values: entry.values(), Succeeded: runResult.Passed}
+		for _, suite := range suites {
+			path := filepath.Join(suite.Path, reportFile)
+			report, err := reporters.ReadJSONReport(path)
+			if err != nil {
+				//the suite did not run, or is not a Ginkgo suite
+				continue
+			}
+			os.Remove(path)
+			run.Reports = append(run.Reports, report)
+		}
+		runs = append(runs, run)
+
+		if r.interruptHandler.WasInterrupted() || (!runResult.Passed && !r.commandFlags.KeepGoing) {
+			break
+		}
+	}
+
+	report := reporters.NewMatrixReport(runs)
+	if len(runs) < len(entries) {
+		report.SuiteSucceeded = false
+	}
+	fmt.Print(renderMatrixReport(report, len(entries)))
+
+	if r.commandFlags.MatrixReport != "" {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err == nil {
+			err = ioutil.WriteFile(r.commandFlags.MatrixReport, data, 0666)
+		}
+		if err != nil {
+			fmt.Printf("Failed to write the matrix report: %s\n", err.Error())
+		}
+	}
+
+	fmt.Printf("\nGinkgo ran %d %s in %s\n", numSuites, pluralizedWord("suite", "suites", numSuites), time.Since(t))
+	if report.SuiteSucceeded {
+		fmt.Printf("Test Suite Passed\n")
+		os.Exit(0)
+	}
+	fmt.Printf("Test Suite Failed\n")
+	os.Exit(1)
+}
+
+//renderMatrixReport lists the outcome of each run of the matrix, followed by the breakdown of the results by matrix value
+func renderMatrixReport(report reporters.MatrixReport, numEntries int) string {
+	s := fmt.Sprintf("\nMatrix: %d of %d run(s)\n", len(report.Runs), numEntries)
+	for _, run := range report.Runs {
+		outcome := "PASS"
+		if !run.Succeeded {
+			outcome = "FAIL"
+		}
+		passed, failed := 0, 0
+		for _, jsonReport := range run.Reports {
+			if jsonReport.SuiteSummary != nil {
+				passed += jsonReport.SuiteSummary.NumberOfPassedSpecs
+				failed += jsonReport.SuiteSummary.NumberOfFailedSpecs
+			}
+		}
+		s += fmt.Sprintf("  %s  %s: %d passed, %d failed\n", outcome, run.Name, passed, failed)
+	}
+
+	for _, dimension := range report.Dimensions {
+		s += fmt.Sprintf("By %s:\n", dimension.Name)
+		for _, value := range dimension.Values {
+			name := value.Value
+			if name == "" {
+				name = "(unset)"
+			}
+			s += fmt.Sprintf("  %s: %d of %d run(s) passed, %d passed | %d failed | %d pending | %d skipped\n", name, value.Runs-value.FailedRuns, value.Runs, value.Passed, value.Failed, value.Pending, value.Skipped)
+		}
+	}
+	return s
+}
@@ -0,0 +1,26 @@
+package example_test
+
+import (
+	. "github.com/onsi/ginkgo"
+)
+
+// ginkgo:label=storage ginkgo:owner=team-storage
+var _ = Describe("AnnotationsFixture", func() {
+	// survives restarts of the database
+	// ginkgo:label=slow,restart
+	// ginkgo:severity=high
+	It("annotated", func() {
+
+	}, Label("fast"))
+
+	// not an annotation
+
+	It("unannotated", func() {
+
+	})
+
+	// ginkgo:label=ignored
+	BeforeEach(func() {
+
+	})
+})
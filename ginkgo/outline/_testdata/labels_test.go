@@ -0,0 +1,31 @@
+package example_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+)
+
+var _ = Describe("LabelsFixture", func() {
+	It("unlabelled", func() {
+
+	})
+
+	It("labelled", func() {
+
+	}, Label("fast"))
+
+	Context("labelled", func() {
+		It("labelled", func() {
+
+		}, Label("slow", "storage"))
+
+		PIt("pending", func() {
+
+		})
+	}, Label("integration"))
+
+	DescribeTable("table",
+		func() {},
+		Entry("entry"),
+	)
+}, Label("docs"))
@@ -0,0 +1,131 @@
+package outline
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+const (
+	// maxHeadingLevel is the deepest heading level available in Markdown and
+	// HTML. Deeper containers share it.
+	maxHeadingLevel = 6
+)
+
+// documentWriter renders the parts of a document in a given format
+type documentWriter interface {
+	heading(b *strings.Builder, level int, n *ginkgoNode)
+	specs(b *strings.Builder, specs []*ginkgoNode)
+}
+
+// Markdown returns the outline as a Markdown document: containers are
+// headings, starting at the given level, and specs are bullet points under the
+// heading of their container. Labels are rendered as code spans.
+func (o *outline) Markdown(level int) string {
+	return o.document(level, markdownWriter{})
+}
+
+// HTML returns the outline as an HTML fragment: containers are headings,
+// starting at the given level, and specs are list items under the heading of
+// their container. Labels are rendered as spans of the "label" class.
+func (o *outline) HTML(level int) string {
+	return o.document(level, htmlWriter{})
+}
+
+// document renders the specs of every container before its nested containers,
+// so that they stay under the heading of their container.
+func (o *outline) document(level int, w documentWriter) string {
+	var b strings.Builder
+	var render func(nodes []*ginkgoNode, level int)
+	render = func(nodes []*ginkgoNode, level int) {
+		specs := []*ginkgoNode{}
+		for _, n := range nodes {
+			if n.Spec {
+				specs = append(specs, n)
+			}
+		}
+		if len(specs) > 0 {
+			w.specs(&b, specs)
+		}
+		for _, n := range nodes {
+			if isContainer(n) {
+				w.heading(&b, level, n)
+				render(n.Nodes, level+1)
+			}
+		}
+	}
+	render(o.Nodes, level)
+	return b.String()
+}
+
+// isContainer tells whether the node is a Ginkgo container, as opposed to a
+// spec, a setup node or a `By` step.
+func isContainer(n *ginkgoNode) bool {
+	if n.Spec {
+		return false
+	}
+	name := strings.TrimLeft(n.Name, "FPX")
+	switch name {
+	case "Context", "Describe", "When", "DescribeTable", "DescribeClosureTable":
+		return true
+	default:
+		return false
+	}
+}
+
+func headingLevel(level int) int {
+	if level > maxHeadingLevel {
+		return maxHeadingLevel
+	}
+	return level
+}
+
+type markdownWriter struct{}
+
+func (markdownWriter) heading(b *strings.Builder, level int, n *ginkgoNode) {
+	b.WriteString(fmt.Sprintf("%s %s%s\n\n", strings.Repeat("#", headingLevel(level)), n.Text, markdownTags(n)))
+}
+
+func (markdownWriter) specs(b *strings.Builder, specs []*ginkgoNode) {
+	for _, n := range specs {
+		b.WriteString(fmt.Sprintf("- %s%s\n", n.Text, markdownTags(n)))
+	}
+	b.WriteString("\n")
+}
+
+func markdownTags(n *ginkgoNode) string {
+	var b strings.Builder
+	for _, label := range n.Labels {
+		b.WriteString(fmt.Sprintf(" `%s`", label))
+	}
+	if n.Pending {
+		b.WriteString(" _(pending)_")
+	}
+	return b.String()
+}
+
+type htmlWriter struct{}
+
+func (htmlWriter) heading(b *strings.Builder, level int, n *ginkgoNode) {
+	level = headingLevel(level)
+	b.WriteString(fmt.Sprintf("<h%d>%s%s</h%d>\n", level, html.EscapeString(n.Text), htmlTags(n), level))
+}
+
+func (htmlWriter) specs(b *strings.Builder, specs []*ginkgoNode) {
+	b.WriteString("<ul>\n")
+	for _, n := range specs {
+		b.WriteString(fmt.Sprintf("<li>%s%s</li>\n", html.EscapeString(n.Text), htmlTags(n)))
+	}
+	b.WriteString("</ul>\n")
+}
+
+func htmlTags(n *ginkgoNode) string {
+	var b strings.Builder
+	for _, label := range n.Labels {
+		b.WriteString(fmt.Sprintf(` <span class="label">%s</span>`, html.EscapeString(label)))
+	}
+	if n.Pending {
+		b.WriteString(` <em class="pending">(pending)</em>`)
+	}
+	return b.String()
+}
@@ -4,6 +4,9 @@ import (
 	"go/ast"
 	"go/token"
 	"strconv"
+	"strings"
+
+	"github.com/onsi/ginkgo/internal/annotations"
 )
 
 const (
@@ -28,6 +31,18 @@ type ginkgoMetadata struct {
 	Spec    bool `json:"spec"`
 	Focused bool `json:"focused"`
 	Pending bool `json:"pending"`
+
+	// Labels are the labels passed to specs and containers through `Label`,
+	// or through `ginkgo:label` annotations in the comments above them
+	Labels []string `json:"labels,omitempty"`
+
+	// Owners are the owners given to specs and containers by `ginkgo:owner`
+	// annotations in the comments above them
+	Owners []string `json:"owners,omitempty"`
+
+	// Severity is the severity given to specs and containers by a
+	// `ginkgo:severity` annotation in the comments above them
+	Severity string `json:"severity,omitempty"`
 }
 
 // ginkgoNode is used to construct the outline as a tree
@@ -141,12 +156,13 @@ func ginkgoNodeFromCallExpr(fset *token.FileSet, ce *ast.CallExpr, ginkgoPackage
 	n.Name = identName
 	n.Start, n.End = absoluteOffsetsForNode(fset, ce)
 	n.Nodes = make([]*ginkgoNode, 0)
+	n.Labels = labelsFromCallExpr(ce, ginkgoPackageName)
 	switch identName {
 	case "It", "Measure", "Specify":
 		n.Spec = true
 		n.Text = textOrAltFromCallExpr(ce, undefinedTextAlt)
 		return &n, ginkgoPackageName != nil && *ginkgoPackageName == packageName
-	case "Entry":
+	case "Entry", "Case":
 		n.Spec = true
 		n.Text = textOrAltFromCallExpr(ce, undefinedTextAlt)
 		return &n, tablePackageName != nil && *tablePackageName == packageName
@@ -155,7 +171,7 @@ func ginkgoNodeFromCallExpr(fset *token.FileSet, ce *ast.CallExpr, ginkgoPackage
 		n.Focused = true
 		n.Text = textOrAltFromCallExpr(ce, undefinedTextAlt)
 		return &n, ginkgoPackageName != nil && *ginkgoPackageName == packageName
-	case "FEntry":
+	case "FEntry", "FCase":
 		n.Spec = true
 		n.Focused = true
 		n.Text = textOrAltFromCallExpr(ce, undefinedTextAlt)
@@ -165,7 +181,7 @@ func ginkgoNodeFromCallExpr(fset *token.FileSet, ce *ast.CallExpr, ginkgoPackage
 		n.Pending = true
 		n.Text = textOrAltFromCallExpr(ce, undefinedTextAlt)
 		return &n, ginkgoPackageName != nil && *ginkgoPackageName == packageName
-	case "PEntry", "XEntry":
+	case "PEntry", "XEntry", "PCase", "XCase":
 		n.Spec = true
 		n.Pending = true
 		n.Text = textOrAltFromCallExpr(ce, undefinedTextAlt)
@@ -173,14 +189,14 @@ func ginkgoNodeFromCallExpr(fset *token.FileSet, ce *ast.CallExpr, ginkgoPackage
 	case "Context", "Describe", "When":
 		n.Text = textOrAltFromCallExpr(ce, undefinedTextAlt)
 		return &n, ginkgoPackageName != nil && *ginkgoPackageName == packageName
-	case "DescribeTable":
+	case "DescribeTable", "DescribeClosureTable":
 		n.Text = textOrAltFromCallExpr(ce, undefinedTextAlt)
 		return &n, tablePackageName != nil && *tablePackageName == packageName
 	case "FContext", "FDescribe", "FWhen":
 		n.Focused = true
 		n.Text = textOrAltFromCallExpr(ce, undefinedTextAlt)
 		return &n, ginkgoPackageName != nil && *ginkgoPackageName == packageName
-	case "FDescribeTable":
+	case "FDescribeTable", "FDescribeClosureTable":
 		n.Focused = true
 		n.Text = textOrAltFromCallExpr(ce, undefinedTextAlt)
 		return &n, tablePackageName != nil && *tablePackageName == packageName
@@ -188,7 +204,7 @@ func ginkgoNodeFromCallExpr(fset *token.FileSet, ce *ast.CallExpr, ginkgoPackage
 		n.Pending = true
 		n.Text = textOrAltFromCallExpr(ce, undefinedTextAlt)
 		return &n, ginkgoPackageName != nil && *ginkgoPackageName == packageName
-	case "PDescribeTable", "XDescribeTable":
+	case "PDescribeTable", "XDescribeTable", "PDescribeClosureTable", "XDescribeClosureTable":
 		n.Pending = true
 		n.Text = textOrAltFromCallExpr(ce, undefinedTextAlt)
 		return &n, tablePackageName != nil && *tablePackageName == packageName
@@ -208,6 +224,32 @@ func ginkgoNodeFromCallExpr(fset *token.FileSet, ce *ast.CallExpr, ginkgoPackage
 	}
 }
 
+// applyCommentAnnotations merges the `ginkgo:label`, `ginkgo:owner` and
+// `ginkgo:severity` annotations of the comment right above a spec or container
+// into its metadata, as the ginkgo package does when building the spec tree.
+func applyCommentAnnotations(n *ginkgoNode, comment *ast.CommentGroup) {
+	if comment == nil {
+		return
+	}
+	switch strings.TrimLeft(n.Name, "FPX") {
+	case "Describe", "Context", "When", "It", "Specify":
+	default:
+		return
+	}
+	var labels []string
+	for _, annotation := range annotations.Parse(comment.Text()) {
+		switch annotation.Key {
+		case "label":
+			labels = append(labels, annotation.Values()...)
+		case "owner":
+			n.Owners = append(n.Owners, annotation.Values()...)
+		case "severity":
+			n.Severity = annotation.Value
+		}
+	}
+	n.Labels = append(labels, n.Labels...)
+}
+
 // textOrAltFromCallExpr tries to derive the "text" of a Ginkgo spec or
 // container. If it cannot derive it, it returns the alt text.
 func textOrAltFromCallExpr(ce *ast.CallExpr, alt string) string {
@@ -241,3 +283,34 @@ func textFromCallExpr(ce *ast.CallExpr) (string, bool) {
 		return text.Value, true
 	}
 }
+
+// labelsFromCallExpr derives the labels passed to a Ginkgo spec or container
+// through `Label` calls. Labels that are not literals are left out.
+func labelsFromCallExpr(ce *ast.CallExpr, ginkgoPackageName *string) []string {
+	if ginkgoPackageName == nil {
+		return nil
+	}
+	var labels []string
+	for _, arg := range ce.Args {
+		labelCall, ok := arg.(*ast.CallExpr)
+		if !ok {
+			continue
+		}
+		packageName, identName, ok := packageAndIdentNamesFromCallExpr(labelCall)
+		if !ok || identName != "Label" || packageName != *ginkgoPackageName {
+			continue
+		}
+		for _, labelArg := range labelCall.Args {
+			lit, ok := labelArg.(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING {
+				continue
+			}
+			label, err := strconv.Unquote(lit.Value)
+			if err != nil {
+				continue
+			}
+			labels = append(labels, label)
+		}
+	}
+	return labels
+}
@@ -18,7 +18,9 @@ const (
 	tableImportPath = "github.com/onsi/ginkgo/extensions/table"
 )
 
-// FromASTFile returns an outline for a Ginkgo test source file
+// FromASTFile returns an outline for a Ginkgo test source file. Parse the file
+// with parser.ParseComments for the outline to include the metadata annotated
+// in comments (see `ginkgo:label`).
 func FromASTFile(fset *token.FileSet, src *ast.File) (*outline, error) {
 	ginkgoPackageName := packageNameForImport(src, ginkgoImportPath)
 	tablePackageName := packageNameForImport(src, tableImportPath)
@@ -26,6 +28,12 @@ func FromASTFile(fset *token.FileSet, src *ast.File) (*outline, error) {
 		return nil, fmt.Errorf("file does not import %q or %q", ginkgoImportPath, tableImportPath)
 	}
 
+	// the comments that end on each line, to find those right above specs and containers
+	commentsByEndLine := map[int]*ast.CommentGroup{}
+	for _, comment := range src.Comments {
+		commentsByEndLine[fset.Position(comment.End()).Line] = comment
+	}
+
 	root := ginkgoNode{}
 	stack := []*ginkgoNode{&root}
 	ispr := inspector.New([]*ast.File{src})
@@ -43,6 +51,7 @@ func FromASTFile(fset *token.FileSet, src *ast.File) (*outline, error) {
 				// Node is not a Ginkgo spec or container, continue
 				return true
 			}
+			applyCommentAnnotations(gn, commentsByEndLine[fset.Position(ce.Pos()).Line-1])
 			parent := stack[len(stack)-1]
 			parent.Nodes = append(parent.Nodes, gn)
 			stack = append(stack, gn)
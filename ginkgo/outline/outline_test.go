@@ -19,7 +19,7 @@ import (
 var _ = DescribeTable("Validate outline from file with",
 	func(srcFilename, jsonOutlineFilename, csvOutlineFilename string) {
 		fset := token.NewFileSet()
-		astFile, err := parser.ParseFile(fset, filepath.Join("_testdata", srcFilename), nil, 0)
+		astFile, err := parser.ParseFile(fset, filepath.Join("_testdata", srcFilename), nil, parser.ParseComments)
 		Expect(err).To(BeNil(), "error parsing source: %s", err)
 
 		if err != nil {
@@ -61,6 +61,8 @@ var _ = DescribeTable("Validate outline from file with",
 	Entry("mixed focused containers and specs", "mixed_test.go", "mixed_test.go.json", "mixed_test.go.csv"),
 	Entry("specs used to verify position", "position_test.go", "position_test.go.json", "position_test.go.csv"),
 	Entry("suite setup", "suite_test.go", "suite_test.go.json", "suite_test.go.csv"),
+	Entry("labelled containers and specs", "labels_test.go", "labels_test.go.json", "labels_test.go.csv"),
+	Entry("containers and specs annotated in comments", "annotations_test.go", "annotations_test.go.json", "annotations_test.go.csv"),
 )
 
 var _ = Describe("Validate position", func() {
@@ -93,3 +95,44 @@ var _ = Describe("Validate position", func() {
 
 	})
 })
+
+var _ = Describe("Document", func() {
+	var o *outline
+
+	BeforeEach(func() {
+		fset := token.NewFileSet()
+		astFile, err := parser.ParseFile(fset, filepath.Join("_testdata", "labels_test.go"), nil, 0)
+		Expect(err).To(BeNil(), "error parsing source: %s", err)
+
+		o, err = FromASTFile(fset, astFile)
+		Expect(err).To(BeNil(), "error creating outline: %s", err)
+	})
+
+	It("should render containers as Markdown headings, specs as bullet points and labels as tags", func() {
+		Expect(o.Markdown(2)).To(Equal("## LabelsFixture `docs`\n\n" +
+			"- unlabelled\n" +
+			"- labelled `fast`\n\n" +
+			"### labelled `integration`\n\n" +
+			"- labelled `slow` `storage`\n" +
+			"- pending _(pending)_\n\n" +
+			"### table\n\n" +
+			"- entry\n\n"))
+	})
+
+	It("should render containers as HTML headings, specs as list items and labels as tags", func() {
+		Expect(o.HTML(2)).To(Equal("<h2>LabelsFixture <span class=\"label\">docs</span></h2>\n" +
+			"<ul>\n" +
+			"<li>unlabelled</li>\n" +
+			"<li>labelled <span class=\"label\">fast</span></li>\n" +
+			"</ul>\n" +
+			"<h3>labelled <span class=\"label\">integration</span></h3>\n" +
+			"<ul>\n" +
+			"<li>labelled <span class=\"label\">slow</span> <span class=\"label\">storage</span></li>\n" +
+			"<li>pending <em class=\"pending\">(pending)</em></li>\n" +
+			"</ul>\n" +
+			"<h3>table</h3>\n" +
+			"<ul>\n" +
+			"<li>entry</li>\n" +
+			"</ul>\n"))
+	})
+})
@@ -61,7 +61,7 @@ func outlineFile(args []string, format string) {
 
 	fset := token.NewFileSet()
 
-	parsedSrc, err := parser.ParseFile(fset, filename, src, 0)
+	parsedSrc, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
 	if err != nil {
 		println(fmt.Sprintf("error parsing source: %s", err))
 		os.Exit(1)
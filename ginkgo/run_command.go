@@ -16,6 +16,7 @@ import (
 	"github.com/onsi/ginkgo/config"
 	"github.com/onsi/ginkgo/ginkgo/interrupthandler"
 	"github.com/onsi/ginkgo/ginkgo/testrunner"
+	"github.com/onsi/ginkgo/ginkgo/testsuite"
 	colorable "github.com/onsi/ginkgo/reporters/stenographer/support/go-colorable"
 	"github.com/onsi/ginkgo/types"
 )
@@ -94,14 +95,42 @@ func (r *SpecRunner) RunSpecs(args []string, additionalArgs []string) {
 		complainAndQuit("Found no test suites")
 	}
 
+	focusArgs := map[string][]string{}
+	if r.commandFlags.ChangedSince != "" {
+		changedFiles, err := changedGoFiles(r.commandFlags.ChangedSince)
+		if err != nil {
+			complainAndQuit(err.Error())
+		}
+		selection := selectChangedSuites(suites, changedFiles)
+		if len(selection.Unaffected) > 0 {
+			fmt.Printf("Will skip suites unaffected by changes since %s:\n", r.commandFlags.ChangedSince)
+			for _, unaffected := range selection.Unaffected {
+				fmt.Println("  " + unaffected)
+			}
+		}
+		if len(selection.Suites) == 0 {
+			fmt.Println("No test suites affected by changes!  Exiting...")
+			os.Exit(0)
+		}
+		suites = selection.Suites
+		for path, files := range selection.FocusFiles {
+			focusArgs[path] = focusOnFilesArgs(files)
+		}
+	}
+
+	if r.commandFlags.ImpactedBy != "" {
+		suites = r.selectImpactedSuites(suites, focusArgs)
+	}
+
 	r.ComputeSuccinctMode(len(suites))
 
+	if r.commandFlags.EnvMatrix != "" {
+		r.runMatrix(suites, focusArgs, additionalArgs)
+	}
+
 	t := time.Now()
 
-	runners := []*testrunner.TestRunner{}
-	for _, suite := range suites {
-		runners = append(runners, testrunner.New(suite, r.commandFlags.NumCPU, r.commandFlags.ParallelStream, r.commandFlags.Timeout, r.commandFlags.GoOpts, additionalArgs))
-	}
+	runners := r.buildRunners(suites, focusArgs, additionalArgs)
 
 	numSuites := 0
 	runResult := testrunner.PassingRunResult()
@@ -165,6 +194,20 @@ func (r *SpecRunner) RunSpecs(args []string, additionalArgs []string) {
 	}
 }
 
+func (r *SpecRunner) buildRunners(suites []testsuite.TestSuite, focusArgs map[string][]string, additionalArgs []string) []*testrunner.TestRunner {
+	runners := []*testrunner.TestRunner{}
+	for _, suite := range suites {
+		suiteArgs := additionalArgs
+		if args, ok := focusArgs[suite.Path]; ok && !r.hasFocus() {
+			suiteArgs = append(append([]string{}, args...), additionalArgs...)
+		}
+		runner := testrunner.New(suite, r.commandFlags.NumCPU, r.commandFlags.ParallelStream, r.commandFlags.Timeout, r.commandFlags.GoOpts, suiteArgs)
+		runner.SetSpecLeaseTimeout(r.commandFlags.SpecLeaseTimeout)
+		runners = append(runners, runner)
+	}
+	return runners
+}
+
 // Moves all generated profiles to specified directory
 func (r *SpecRunner) moveCoverprofiles(runners []*testrunner.TestRunner) {
 	for _, runner := range runners {
@@ -259,6 +302,10 @@ func (r *SpecRunner) ComputeSuccinctMode(numSuites int) {
 	}
 }
 
+func (r *SpecRunner) hasFocus() bool {
+	return len(config.GinkgoConfig.FocusStrings) > 0
+}
+
 func (r *SpecRunner) UpdateSeed() {
 	if !r.commandFlags.wasSet("seed") {
 		config.GinkgoConfig.RandomSeed = time.Now().Unix()
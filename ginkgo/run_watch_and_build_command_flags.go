@@ -15,18 +15,24 @@ type RunWatchAndBuildCommandFlags struct {
 	GoOpts      map[string]interface{}
 
 	//for run and watch commands
-	NumCPU         int
-	NumCompilers   int
-	ParallelStream bool
-	Notify         bool
-	AfterSuiteHook string
-	AutoNodes      bool
-	Timeout        time.Duration
+	NumCPU           int
+	NumCompilers     int
+	ParallelStream   bool
+	Notify           bool
+	AfterSuiteHook   string
+	AutoNodes        bool
+	Timeout          time.Duration
+	SpecLeaseTimeout time.Duration
 
 	//only for run command
-	KeepGoing       bool
-	UntilItFails    bool
-	RandomizeSuites bool
+	KeepGoing        bool
+	UntilItFails     bool
+	RandomizeSuites  bool
+	ChangedSince     string
+	ImpactedBy       string
+	SpecCoverageFile string
+	EnvMatrix        string
+	MatrixReport     string
 
 	//only for watch command
 	Depth       int
@@ -154,12 +160,18 @@ func (c *RunWatchAndBuildCommandFlags) flags(mode int) {
 		}
 		c.FlagSet.StringVar(&(c.AfterSuiteHook), "afterSuiteHook", "", "Run a command when a suite test run completes")
 		c.FlagSet.DurationVar(&(c.Timeout), "timeout", 24*time.Hour, "Suite fails if it does not complete within the specified timeout")
+		c.FlagSet.DurationVar(&(c.SpecLeaseTimeout), "specLeaseTimeout", 0, "If set, a spec claimed by a parallel node that neither completes it nor checks in within this duration, e.g. because the node crashed, is handed to another node.  The first attempt is reported as abandoned.")
 	}
 
 	if mode == runMode {
 		c.FlagSet.BoolVar(&(c.KeepGoing), "keepGoing", false, "When true, failures from earlier test suites do not prevent later test suites from running")
 		c.FlagSet.BoolVar(&(c.UntilItFails), "untilItFails", false, "When true, Ginkgo will keep rerunning tests until a failure occurs")
 		c.FlagSet.BoolVar(&(c.RandomizeSuites), "randomizeSuites", false, "When true, Ginkgo will randomize the order in which test suites run")
+		c.FlagSet.StringVar(&(c.ChangedSince), "changedSince", "", "If set, Ginkgo only runs the test suites affected by the Go files changed since this git ref (e.g. origin/main).  Suites whose only changes are to their own test files are focused on the specs defined in those files.")
+		c.FlagSet.StringVar(&(c.ImpactedBy), "impactedBy", "", "If set, Ginkgo only runs the specs impacted by the changes git diff tells for this argument (e.g. origin/main or HEAD~1..HEAD): the specs whose coverage recorded by ginkgo coverage includes a changed function, and the specs defined in the changed test files.  Ginkgo runs every spec of the suites without coverage data, and every spec when some changes cannot be attributed to functions.")
+		c.FlagSet.StringVar(&(c.SpecCoverageFile), "specCoverageFile", DefaultSpecCoverageFile, "The per-spec coverage file, written by ginkgo coverage, that -impactedBy reads in each suite's directory.")
+		c.FlagSet.StringVar(&(c.EnvMatrix), "envMatrix", "", "If set, Ginkgo runs the test suites once for each entry of this YAML file, with the environment variables and pass-through flags of the entry, and breaks the results down by matrix value.")
+		c.FlagSet.StringVar(&(c.MatrixReport), "matrixReport", "", "If set along with -envMatrix, Ginkgo writes the merged JSON report of the runs of the matrix to this file.")
 	}
 
 	if mode == watchMode {
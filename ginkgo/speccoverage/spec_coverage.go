@@ -0,0 +1,191 @@
+/*
+Package speccoverage attributes code coverage to specs, for ginkgo coverage.
+
+The coverage counters of a test binary can only be read once it exits: the runtime/coverage API cannot snapshot them
+while the specs of a test binary run.  Each spec therefore runs in a process of its own, writing its counters to a
+directory of its own with -test.gocoverdir, and go tool covdata func tells the functions it covered.
+
+The functions a diff changes are told apart the same way, as file:function, so that ginkgo run -impactedBy can select
+the specs that covered them.
+*/
+package speccoverage
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//CoveredFunctions returns the functions output, the output of go tool covdata func, tells were covered, as
+//file:function sorted, e.g. github.com/foo/bar/bar.go:*Bar.Baz
+func CoveredFunctions(output []byte) []string {
+	functions := []string{}
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 3 || fields[0] == "total" {
+			continue
+		}
+		if strings.TrimSuffix(fields[2], "%") == "0.0" {
+			continue
+		}
+		//the location is file:line:
+		location := strings.TrimSuffix(fields[0], ":")
+		if i := strings.LastIndex(location, ":"); i >= 0 {
+			location = location[:i]
+		}
+		functions = append(functions, location+":"+fields[1])
+	}
+	sort.Strings(functions)
+	return functions
+}
+
+//ReadCoveredFunctions runs go tool covdata func over the coverage data of dir, as written with -test.gocoverdir, and
+//returns the functions it tells were covered
+func ReadCoveredFunctions(dir string) ([]string, error) {
+	output, err := exec.Command("go", "tool", "covdata", "func", "-i="+dir).CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("go tool covdata failed: %s\n%s", err, output)
+	}
+	return CoveredFunctions(output), nil
+}
+
+//Hunk is a change a diff makes to a file: the lines from Start to End of the new version of the file, or the lines
+//deleted from between Start and End when Deletion is set
+type Hunk struct {
+	Start    int
+	End      int
+	Deletion bool
+}
+
+var hunkHeader = regexp.MustCompile(`^@@ -\d+(?:,\d+)? \+(\d+)(?:,(\d+))? @@`)
+
+//ParseDiff returns the hunks of diff, the output of git diff --unified=0, by the path of the file they change relative
+//to the root of the repository.  A deleted file gets a single deletion hunk.
+func ParseDiff(diff string) map[string][]Hunk {
+	hunks := map[string][]Hunk{}
+	oldFile, file := "", ""
+	for _, line := range strings.Split(diff, "\n") {
+		switch {
+		case strings.HasPrefix(line, "--- "):
+			oldFile = strings.TrimPrefix(strings.TrimPrefix(line, "--- "), "a/")
+		case strings.HasPrefix(line, "+++ "):
+			file = strings.TrimPrefix(line, "+++ ")
+			if file == "/dev/null" {
+				file = oldFile
+				hunks[file] = append(hunks[file], Hunk{Start: 0, End: 1, Deletion: true})
+				file = ""
+			} else {
+				file = strings.TrimPrefix(file, "b/")
+			}
+		case file != "":
+			match := hunkHeader.FindStringSubmatch(line)
+			if match == nil {
+				continue
+			}
+			start, _ := strconv.Atoi(match[1])
+			count := 1
+			if match[2] != "" {
+				count, _ = strconv.Atoi(match[2])
+			}
+			if count == 0 {
+				hunks[file] = append(hunks[file], Hunk{Start: start, End: start + 1, Deletion: true})
+			} else {
+				hunks[file] = append(hunks[file], Hunk{Start: start, End: start + count - 1})
+			}
+		}
+	}
+	return hunks
+}
+
+//funcSpan is the lines a function spans, from its doc comment to its closing brace
+type funcSpan struct {
+	name       string
+	start, end int
+}
+
+//ChangedFunctions returns the functions of src, the new version of the Go file filename, that hunks change, named as
+//go tool covdata func names them.  It returns false when some hunk changes lines outside of any function, such as
+//package level declarations, or deletes lines from between functions: what such changes impact cannot be told.
+func ChangedFunctions(filename string, src []byte, hunks []Hunk) ([]string, bool) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
+	if err != nil {
+		return nil, false
+	}
+
+	spans := []funcSpan{}
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+		start := funcDecl.Pos()
+		if funcDecl.Doc != nil {
+			start = funcDecl.Doc.Pos()
+		}
+		spans = append(spans, funcSpan{name: funcName(funcDecl), start: fset.Position(start).Line, end: fset.Position(funcDecl.End()).Line})
+	}
+	spanAt := func(line int) *funcSpan {
+		for i := range spans {
+			if spans[i].start <= line && line <= spans[i].end {
+				return &spans[i]
+			}
+		}
+		return nil
+	}
+
+	changed := map[string]bool{}
+	for _, hunk := range hunks {
+		if hunk.Deletion {
+			before, after := spanAt(hunk.Start), spanAt(hunk.End)
+			if before == nil || before != after {
+				return nil, false
+			}
+			changed[before.name] = true
+			continue
+		}
+		for line := hunk.Start; line <= hunk.End; line++ {
+			span := spanAt(line)
+			if span == nil {
+				return nil, false
+			}
+			changed[span.name] = true
+		}
+	}
+
+	functions := []string{}
+	for name := range changed {
+		functions = append(functions, name)
+	}
+	sort.Strings(functions)
+	return functions, true
+}
+
+//funcName names funcDecl as go tool covdata func does: F, T.M or *T.M
+func funcName(funcDecl *ast.FuncDecl) string {
+	if funcDecl.Recv == nil || len(funcDecl.Recv.List) == 0 {
+		return funcDecl.Name.Name
+	}
+	receiver := funcDecl.Recv.List[0].Type
+	pointer := ""
+	if star, ok := receiver.(*ast.StarExpr); ok {
+		pointer, receiver = "*", star.X
+	}
+	//drop the type parameter of generic receivers
+	if index, ok := receiver.(*ast.IndexExpr); ok {
+		receiver = index.X
+	}
+	if ident, ok := receiver.(*ast.Ident); ok {
+		return pointer + ident.Name + "." + funcDecl.Name.Name
+	}
+	return funcDecl.Name.Name
+}
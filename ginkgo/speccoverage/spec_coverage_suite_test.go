@@ -0,0 +1,13 @@
+package speccoverage_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestSpecCoverage(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "SpecCoverage Suite")
+}
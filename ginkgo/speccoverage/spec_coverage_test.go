@@ -0,0 +1,111 @@
+package speccoverage_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/ginkgo/speccoverage"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CoveredFunctions", func() {
+	It("should list the functions with some coverage, as file:function", func() {
+		output := []byte("github.com/foo/bar/bar.go:5:\t*Bar.Baz\t\t100.0%\n" +
+			"github.com/foo/bar/bar.go:9:\tunused\t\t0.0%\n" +
+			"github.com/foo/bar/baz.go:12:\tNewBaz\t\t33.3%\n" +
+			"total\t\t\t(statements)\t57.1%\n")
+
+		Ω(CoveredFunctions(output)).Should(Equal([]string{
+			"github.com/foo/bar/bar.go:*Bar.Baz",
+			"github.com/foo/bar/baz.go:NewBaz",
+		}))
+	})
+
+	It("should list nothing when there is no coverage data", func() {
+		Ω(CoveredFunctions([]byte("warning: no applicable files found in input directories\n"))).Should(BeEmpty())
+	})
+})
+
+var _ = Describe("ParseDiff", func() {
+	It("should return the hunks of each changed file", func() {
+		diff := `diff --git a/pkg/a.go b/pkg/a.go
+index 1111111..2222222 100644
+--- a/pkg/a.go
++++ b/pkg/a.go
+@@ -3 +3 @@ func A() int {
+-	return 1
++	return 2
+@@ -10,2 +10,0 @@ func B() int {
+-	x := 1
+-	_ = x
+@@ -20,0 +19,3 @@ func C() {
++	a()
++	b()
++	c()
+diff --git a/pkg/gone.go b/pkg/gone.go
+deleted file mode 100644
+--- a/pkg/gone.go
++++ /dev/null
+@@ -1,3 +0,0 @@
+-package pkg
+-
+-func Gone() {}
+`
+		Ω(ParseDiff(diff)).Should(Equal(map[string][]Hunk{
+			"pkg/a.go": {
+				{Start: 3, End: 3},
+				{Start: 10, End: 11, Deletion: true},
+				{Start: 19, End: 21},
+			},
+			"pkg/gone.go": {
+				{Start: 0, End: 1, Deletion: true},
+			},
+		}))
+	})
+})
+
+var _ = Describe("ChangedFunctions", func() {
+	src := []byte(`package pkg
+
+var answer = 42
+
+//A answers
+func A() int {
+	return answer
+}
+
+type T struct{}
+
+func (t *T) M() int {
+	x := 1
+	return x
+}
+
+func (t T) N() int {
+	return 2
+}
+`)
+
+	It("should name the functions the hunks change as go tool covdata func does", func() {
+		functions, ok := ChangedFunctions("pkg.go", src, []Hunk{{Start: 5, End: 5}, {Start: 13, End: 14}, {Start: 18, End: 18}})
+		Ω(ok).Should(BeTrue())
+		Ω(functions).Should(Equal([]string{"*T.M", "A", "T.N"}))
+	})
+
+	It("should attribute the lines deleted from within a function to the function", func() {
+		functions, ok := ChangedFunctions("pkg.go", src, []Hunk{{Start: 13, End: 14, Deletion: true}})
+		Ω(ok).Should(BeTrue())
+		Ω(functions).Should(Equal([]string{"*T.M"}))
+	})
+
+	It("should not attribute the changes outside of functions", func() {
+		_, ok := ChangedFunctions("pkg.go", src, []Hunk{{Start: 3, End: 3}})
+		Ω(ok).Should(BeFalse())
+
+		_, ok = ChangedFunctions("pkg.go", src, []Hunk{{Start: 8, End: 9, Deletion: true}})
+		Ω(ok).Should(BeFalse())
+	})
+
+	It("should not attribute the changes to files it cannot parse", func() {
+		_, ok := ChangedFunctions("pkg.go", []byte("package"), []Hunk{{Start: 1, End: 1}})
+		Ω(ok).Should(BeFalse())
+	})
+})
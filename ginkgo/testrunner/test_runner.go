@@ -2,6 +2,7 @@ package testrunner
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -14,8 +15,10 @@ import (
 	"time"
 
 	"github.com/onsi/ginkgo/config"
+	"github.com/onsi/ginkgo/formatter"
 	"github.com/onsi/ginkgo/ginkgo/testsuite"
 	"github.com/onsi/ginkgo/internal/remote"
+	"github.com/onsi/ginkgo/reporters"
 	"github.com/onsi/ginkgo/reporters/stenographer"
 	colorable "github.com/onsi/ginkgo/reporters/stenographer/support/go-colorable"
 	"github.com/onsi/ginkgo/types"
@@ -27,12 +30,13 @@ type TestRunner struct {
 	compiled              bool
 	compilationTargetPath string
 
-	numCPU         int
-	parallelStream bool
-	timeout        time.Duration
-	goOpts         map[string]interface{}
-	additionalArgs []string
-	stderr         *bytes.Buffer
+	numCPU           int
+	parallelStream   bool
+	timeout          time.Duration
+	specLeaseTimeout time.Duration
+	goOpts           map[string]interface{}
+	additionalArgs   []string
+	stderr           *bytes.Buffer
 
 	CoverageFile string
 }
@@ -55,6 +59,11 @@ func New(suite testsuite.TestSuite, numCPU int, parallelStream bool, timeout tim
 	return runner
 }
 
+//SetSpecLeaseTimeout makes the parallel nodes lease the specs they claim, see remote.Server.SetSpecLeaseTimeout
+func (t *TestRunner) SetSpecLeaseTimeout(timeout time.Duration) {
+	t.specLeaseTimeout = timeout
+}
+
 func (t *TestRunner) Compile() error {
 	return t.CompileTo(t.compilationTargetPath)
 }
@@ -248,7 +257,7 @@ func (t *TestRunner) CleanUp() {
 }
 
 func (t *TestRunner) runSerialGinkgoSuite() RunResult {
-	ginkgoArgs := config.BuildFlagArgs("ginkgo", config.GinkgoConfig, config.DefaultReporterConfig)
+	ginkgoArgs := config.BuildFlagArgs("ginkgo", config.GinkgoConfig, nodeReporterConfig())
 	return t.run(t.cmd(ginkgoArgs, os.Stdout, 1), nil)
 }
 
@@ -264,6 +273,7 @@ func (t *TestRunner) runAndStreamParallelGinkgoSuite() RunResult {
 	if err != nil {
 		panic("Failed to start parallel spec server")
 	}
+	server.SetSpecLeaseTimeout(t.specLeaseTimeout)
 
 	server.Start()
 	defer server.Close()
@@ -273,7 +283,7 @@ func (t *TestRunner) runAndStreamParallelGinkgoSuite() RunResult {
 		config.GinkgoConfig.ParallelTotal = t.numCPU
 		config.GinkgoConfig.SyncHost = server.Address()
 
-		ginkgoArgs := config.BuildFlagArgs("ginkgo", config.GinkgoConfig, config.DefaultReporterConfig)
+		ginkgoArgs := config.BuildFlagArgs("ginkgo", config.GinkgoConfig, nodeReporterConfig())
 
 		writers[cpu] = newLogWriter(os.Stdout, cpu+1)
 
@@ -314,24 +324,39 @@ func (t *TestRunner) runParallelGinkgoSuite() RunResult {
 	writers := make([]*logWriter, t.numCPU)
 	reports := make([]*bytes.Buffer, t.numCPU)
 
-	stenographer := stenographer.New(!config.DefaultReporterConfig.NoColor, config.GinkgoConfig.FlakeAttempts > 1, colorable.NewColorableStdout())
-	aggregator := remote.NewAggregator(t.numCPU, result, config.DefaultReporterConfig, stenographer)
+	width := formatter.ConsoleWidth(config.DefaultReporterConfig.Columns, config.DefaultReporterConfig.NoWrap)
+	//the test processes reject invalid glyphs
+	glyphs, _ := stenographer.ParseGlyphs(config.DefaultReporterConfig.Glyphs)
+	stenographer := stenographer.NewWithGlyphs(!config.DefaultReporterConfig.NoColor, config.GinkgoConfig.FlakeAttempts > 1, width, glyphs, colorable.NewColorableStdout())
+	reportFileReporters := reporters.NewReportFileReporters(config.GinkgoConfig, config.DefaultReporterConfig, t.Suite.Path)
+	aggregator := remote.NewAggregator(t.numCPU, result, config.DefaultReporterConfig, stenographer, reportFileReporters...)
 
 	server, err := remote.NewServer(t.numCPU)
 	if err != nil {
 		panic("Failed to start parallel spec server")
 	}
+	server.SetSpecLeaseTimeout(t.specLeaseTimeout)
 	server.RegisterReporters(aggregator)
 	server.Start()
 	defer server.Close()
 
+	timingSnapshot := t.snapshotTimingStore()
+	if timingSnapshot != "" {
+		defer os.Remove(timingSnapshot)
+	}
+
 	for cpu := 0; cpu < t.numCPU; cpu++ {
 		config.GinkgoConfig.ParallelNode = cpu + 1
 		config.GinkgoConfig.ParallelTotal = t.numCPU
 		config.GinkgoConfig.SyncHost = server.Address()
 		config.GinkgoConfig.StreamHost = server.Address()
 
-		ginkgoArgs := config.BuildFlagArgs("ginkgo", config.GinkgoConfig, config.DefaultReporterConfig)
+		nodeConfig := config.GinkgoConfig
+		if nodeConfig.TimingStoreURL != "" {
+			nodeConfig.TimingStoreURL = ""
+			nodeConfig.TimingStoreFile = timingSnapshot
+		}
+		ginkgoArgs := config.BuildFlagArgs("ginkgo", nodeConfig, nodeReporterConfig())
 
 		reports[cpu] = &bytes.Buffer{}
 		writers[cpu] = newLogWriter(reports[cpu], cpu+1)
@@ -389,6 +414,45 @@ func (t *TestRunner) runParallelGinkgoSuite() RunResult {
 	return res
 }
 
+//snapshotTimingStore fetches the timings served by -timingStoreURL once and stores them in a temporary file.
+//The parallel nodes schedule their specs off of this snapshot: they must all agree on the order of the specs, which
+//they could not guarantee if each of them queried the endpoint.
+func (t *TestRunner) snapshotTimingStore() string {
+	if config.GinkgoConfig.TimingStoreURL == "" {
+		return ""
+	}
+
+	timings, err := reporters.NewHTTPTimingStore(config.GinkgoConfig.TimingStoreURL).Timings()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to fetch spec run times from %s:\n\t%s\n", config.GinkgoConfig.TimingStoreURL, err.Error())
+		timings = reporters.Timings{}
+	}
+	data, err := json.Marshal(timings)
+	if err != nil {
+		return ""
+	}
+
+	f, err := ioutil.TempFile("", "ginkgo-timings")
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+	_, err = f.Write(data)
+	if err != nil {
+		os.Remove(f.Name())
+		return ""
+	}
+	return f.Name()
+}
+
+//nodeReporterConfig is the reporter configuration passed to the test processes.  Their output is piped through the CLI,
+//so that they cannot tell the width of the terminal: the CLI detects it for them.
+func nodeReporterConfig() config.DefaultReporterConfigType {
+	reporterConfig := config.DefaultReporterConfig
+	reporterConfig.Columns = int(formatter.ConsoleWidth(reporterConfig.Columns, reporterConfig.NoWrap))
+	return reporterConfig
+}
+
 const CoverProfileSuffix = ".coverprofile"
 
 func (t *TestRunner) cmd(ginkgoArgs []string, stream io.Writer, node int) *exec.Cmd {
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/onsi/ginkgo/config"
+	"github.com/onsi/ginkgo/ginkgo/testrunner"
+	"github.com/onsi/ginkgo/ginkgo/testsuite"
+	"github.com/onsi/ginkgo/reporters"
+	"github.com/onsi/ginkgo/types"
+)
+
+func BuildWhyCommand() *Command {
+	commandFlags := NewBuildCommandFlags(flag.NewFlagSet("why", flag.ExitOnError))
+	config.Flags(commandFlags.FlagSet, "", false)
+	return &Command{
+		Name:         "why",
+		FlagSet:      commandFlags.FlagSet,
+		UsageCommand: "ginkgo why <FLAGS> <PACKAGES>",
+		Usage: []string{
+			"Explain which specs of the passed in <PACKAGES> (or the package in the current directory if left blank) would run with the passed in flags, without running them.",
+			"Each spec is listed with the rule that selected it or left it out: -focus, -skip, -skipFile, -skipMeasurements, programmatic focus or pending.",
+			"Accepts the following flags:",
+		},
+		Command: func(args []string, additionalArgs []string) {
+			explainSuites(args, commandFlags, additionalArgs)
+		},
+	}
+}
+
+func explainSuites(args []string, commandFlags *RunWatchAndBuildCommandFlags, additionalArgs []string) {
+	suites, _ := findSuites(args, commandFlags.Recurse, commandFlags.SkipPackage, true)
+	if len(suites) == 0 {
+		complainAndQuit("Found no test suites")
+	}
+
+	dir, err := ioutil.TempDir("", "ginkgo-why")
+	if err != nil {
+		complainAndQuit(fmt.Sprintf("Failed to create a temporary directory: %s", err.Error()))
+	}
+	defer os.RemoveAll(dir)
+
+	passed := true
+	for _, suite := range suites {
+		report, err := dryRunSuite(suite, commandFlags, additionalArgs, dir)
+		if err != nil {
+			fmt.Println(err.Error())
+			passed = false
+			continue
+		}
+		fmt.Println(explainReport(suite, report))
+	}
+	fmt.Println("Specs that would run can still skip themselves by calling Skip, which only running them tells.")
+
+	if !passed {
+		os.Exit(1)
+	}
+}
+
+//dryRunSuite compiles suite and walks its specs with -dryRun, returning the JSON report of the walk
+func dryRunSuite(suite testsuite.TestSuite, commandFlags *RunWatchAndBuildCommandFlags, additionalArgs []string, dir string) (reporters.JSONReport, error) {
+	binary, err := compileSuite(suite, commandFlags, dir)
+	if err != nil {
+		return reporters.JSONReport{}, err
+	}
+
+	ginkgoConfig := config.GinkgoConfig
+	ginkgoConfig.DryRun = true
+	//a dry run fails when specs are programmatically focused, what matters is the report it leaves behind
+	report, output, err := runCompiledSuite(suite, binary, ginkgoConfig, additionalArgs, filepath.Join(dir, suite.PackageName+".json"))
+	if err != nil {
+		return report, fmt.Errorf("Failed to walk the specs of %s:\n%s", suite.PackageName, output)
+	}
+	return report, nil
+}
+
+//compileSuite compiles suite into dir, unless it is precompiled, and returns the path of its test binary
+func compileSuite(suite testsuite.TestSuite, commandFlags *RunWatchAndBuildCommandFlags, dir string) (string, error) {
+	if suite.Precompiled {
+		return suite.Path, nil
+	}
+	binary := filepath.Join(dir, suite.PackageName+".test")
+	runner := testrunner.New(suite, 1, false, 0, commandFlags.GoOpts, nil)
+	if err := runner.CompileTo(binary); err != nil {
+		return "", err
+	}
+	return binary, nil
+}
+
+//runCompiledSuite runs the test binary of suite with ginkgoConfig, writing its JSON report to reportFile, and returns
+//the report along with the output of the run.  The run failing is not an error, only its report missing is.
+func runCompiledSuite(suite testsuite.TestSuite, binary string, ginkgoConfig config.GinkgoConfigType, additionalArgs []string, reportFile string) (reporters.JSONReport, []byte, error) {
+	os.Remove(reportFile)
+	reporterConfig := config.DefaultReporterConfig
+	reporterConfig.JSONReportFile = reportFile
+
+	cmd := exec.Command(binary, append(config.BuildFlagArgs("ginkgo", ginkgoConfig, reporterConfig), additionalArgs...)...)
+	cmd.Dir = suite.Path
+	if suite.Precompiled {
+		cmd.Dir = filepath.Dir(suite.Path)
+	}
+	output, _ := cmd.CombinedOutput()
+
+	report, err := reporters.ReadJSONReport(reportFile)
+	return report, output, err
+}
+
+//explainReport lists each spec of the report with whether it would run and the rule that decided it
+func explainReport(suite testsuite.TestSuite, report reporters.JSONReport) string {
+	s := fmt.Sprintf("%s\n", suite.PackageName)
+	for _, summary := range report.SpecSummaries {
+		verdict := "would run"
+		switch summary.State {
+		case types.SpecStateSkipped:
+			verdict = "skipped"
+		case types.SpecStatePending:
+			verdict = "pending"
+		}
+		reason := summary.FilterReason
+		if reason == "" {
+			reason = "no focus or skip rule applies to it"
+		}
+		location := summary.ComponentCodeLocations[len(summary.ComponentCodeLocations)-1]
+		s += fmt.Sprintf("  %-9s  %s\n             %s\n             %s\n", verdict, reporters.SpecFullText(summary), location, reason)
+	}
+	return s
+}
@@ -12,16 +12,20 @@ Ginkgo is MIT-Licensed
 package ginkgo
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"reflect"
+	"runtime"
 	"strings"
 	"time"
 
 	"github.com/onsi/ginkgo/config"
+	"github.com/onsi/ginkgo/formatter"
+	"github.com/onsi/ginkgo/internal/clock"
 	"github.com/onsi/ginkgo/internal/codelocation"
 	"github.com/onsi/ginkgo/internal/global"
 	"github.com/onsi/ginkgo/internal/remote"
@@ -51,6 +55,9 @@ at the top of the goroutine that caused this panic.
 
 func init() {
 	config.Flags(flag.CommandLine, "ginkgo", true)
+	flag.Usage = func() {
+		config.CustomFlags.Usage(flag.CommandLine, flag.CommandLine.Output())
+	}
 	GinkgoWriter = writer.New(os.Stdout)
 }
 
@@ -79,6 +86,66 @@ func GinkgoParallelNode() int {
 	return config.GinkgoConfig.ParallelNode
 }
 
+//Flags returns the flag set to register the flags of the suite with, instead of using the flag package directly.
+//Register them from an init function or a package-level variable declaration:
+//
+//	var slowEnvironment = Flags().Bool("slowEnvironment", false, "If set, specs wait longer for the system under test.")
+//
+//Pass them after -- when running the suite with the Ginkgo CLI (e.g. ginkgo -- -slowEnvironment).  See
+//config.CustomFlagSet.
+func Flags() *config.CustomFlagSet {
+	return config.CustomFlags
+}
+
+//GinkgoConfiguration returns copies of the configuration of the running suite and of the configuration of its
+//reporters, so that specs can adapt to how the suite is run:
+//
+//	ginkgoConfig, _ := GinkgoConfiguration()
+//	if ginkgoConfig.CustomFlags["slowEnvironment"] == "true" {
+//		SetDefaultEventuallyTimeout(time.Minute)
+//	}
+//
+//The configuration includes the suite-wide labels and metadata passed to RunSpecs, and the values of the flags
+//registered with Flags.  Changing the copies has no effect on the suite.
+func GinkgoConfiguration() (config.GinkgoConfigType, config.DefaultReporterConfigType) {
+	ginkgoConfig, running := global.Suite.Config()
+	if !running {
+		ginkgoConfig = config.GinkgoConfig
+	}
+	ginkgoConfig = ginkgoConfig.Copy()
+	ginkgoConfig.CustomFlags = config.CustomFlags.Values()
+	return ginkgoConfig, config.DefaultReporterConfig
+}
+
+//GinkgoParallelPort returns a free TCP port that is not handed to any other parallel node.  Use it instead of
+//computing ports from GinkgoParallelNode():
+//
+//	BeforeEach(func() {
+//		server = startServer(GinkgoParallelPort())
+//	})
+//
+//Each call returns a different port.  The port is free when it is handed out: start listening on it right away.
+func GinkgoParallelPort() int {
+	port, err := global.Suite.AllocatePort()
+	if err != nil {
+		Fail(err.Error(), 1)
+	}
+	return port
+}
+
+//GinkgoUniqueNamespace returns a name starting with prefix that is not handed to any other parallel node, nor by any
+//other call to GinkgoUniqueNamespace.  The name also differs between suite runs, so that suites running at the same time
+//against the same environment (a database server, a Kubernetes cluster...) do not conflict:
+//
+//	namespace := GinkgoUniqueNamespace("books") // e.g. books-3f9a0c12-1
+func GinkgoUniqueNamespace(prefix string) string {
+	name, err := global.Suite.UniqueName(prefix)
+	if err != nil {
+		Fail(err.Error(), 1)
+	}
+	return name
+}
+
 //Some matcher libraries or legacy codebases require a *testing.T
 //GinkgoT implements an interface analogous to *testing.T and can be used if
 //the library in question accepts *testing.T through an interface
@@ -139,6 +206,23 @@ type Reporter reporters.Reporter
 //to tell Ginkgo that your async test is done.
 type Done chan<- interface{}
 
+//Specs that accept a SpecContext are given one that is cancelled when the node returns:
+//
+//	It("waits for the cluster", func(ctx SpecContext) {
+//		detach := ctx.AttachProgressReporter(func() string {
+//			return fmt.Sprintf("%d/%d nodes ready", cluster.Ready(), cluster.Size())
+//		})
+//		defer detach()
+//		cluster.WaitUntilReady(ctx)
+//	})
+//
+//AttachProgressReporter registers a function whose status line is included in the progress report Ginkgo
+//emits for the running spec when it is interrupted.  It returns a function that detaches the reporter.
+type SpecContext interface {
+	context.Context
+	AttachProgressReporter(func() string) func()
+}
+
 //GinkgoTestDescription represents the information about the current running test returned by CurrentGinkgoTestDescription
 //	FullTestText: a concatenation of ComponentTexts and the TestText
 //	ComponentTexts: a list of all texts for the Describes & Contexts leading up to the current test
@@ -157,6 +241,8 @@ type GinkgoTestDescription struct {
 	FileName   string
 	LineNumber int
 
+	Labels []string
+
 	Failed   bool
 	Duration time.Duration
 }
@@ -177,6 +263,7 @@ func CurrentGinkgoTestDescription() GinkgoTestDescription {
 		IsMeasurement:  summary.IsMeasurement,
 		FileName:       subjectCodeLocation.FileName,
 		LineNumber:     subjectCodeLocation.LineNumber,
+		Labels:         summary.Labels,
 		Failed:         summary.HasFailureState(),
 		Duration:       summary.RunTime,
 	}
@@ -201,42 +288,80 @@ type Benchmarker interface {
 //RunSpecs is the entry point for the Ginkgo test runner.
 //You must call this within a Golang testing TestX(t *testing.T) function.
 //
+//RunSpecs optionally accepts suite-wide Labels and SuiteMetadata, which are passed on to the reporters, and the
+//FailOnEmpty option.
+//
 //To bootstrap a test suite you can use the Ginkgo CLI:
 //
 //	ginkgo bootstrap
-func RunSpecs(t GinkgoTestingT, description string) bool {
+func RunSpecs(t GinkgoTestingT, description string, args ...interface{}) bool {
+	ginkgoConfig := suiteConfig(config.GinkgoConfig, codelocation.New(1), args...)
 	specReporters := []Reporter{buildDefaultReporter()}
 	if config.DefaultReporterConfig.ReportFile != "" {
 		reportFile := config.DefaultReporterConfig.ReportFile
 		specReporters[0] = reporters.NewJUnitReporter(reportFile)
 		specReporters = append(specReporters, buildDefaultReporter())
 	}
-	return runSpecsWithCustomReporters(t, description, specReporters)
+	return runSpecsWithCustomReporters(t, description, specReporters, ginkgoConfig)
 }
 
 //To run your tests with Ginkgo's default reporter and your custom reporter(s), replace
-//RunSpecs() with this method.
+//RunSpecs() with this method.  The reporters share the reporter configuration of the flags, unless they are given their
+//own with reporters.WithReporterConfig.
 func RunSpecsWithDefaultAndCustomReporters(t GinkgoTestingT, description string, specReporters []Reporter) bool {
 	deprecationTracker.TrackDeprecation(types.Deprecations.CustomReporter())
 	specReporters = append(specReporters, buildDefaultReporter())
-	return runSpecsWithCustomReporters(t, description, specReporters)
+	return runSpecsWithCustomReporters(t, description, specReporters, suiteConfig(config.GinkgoConfig, codelocation.New(1)))
 }
 
 //To run your tests with your custom reporter(s) (and *not* Ginkgo's default reporter), replace
 //RunSpecs() with this method.  Note that parallel tests will not work correctly without the default reporter
 func RunSpecsWithCustomReporters(t GinkgoTestingT, description string, specReporters []Reporter) bool {
 	deprecationTracker.TrackDeprecation(types.Deprecations.CustomReporter())
-	return runSpecsWithCustomReporters(t, description, specReporters)
+	return runSpecsWithCustomReporters(t, description, specReporters, suiteConfig(config.GinkgoConfig, codelocation.New(1)))
 }
 
-func runSpecsWithCustomReporters(t GinkgoTestingT, description string, specReporters []Reporter) bool {
+func runSpecsWithCustomReporters(t GinkgoTestingT, description string, specReporters []Reporter, ginkgoConfig config.GinkgoConfigType) bool {
+	if err := writer.ValidateBinaryOutput(config.DefaultReporterConfig.BinaryOutput); err != nil {
+		panic(fmt.Sprintf("Invalid -binaryOutput: %s", err))
+	}
+	redactors := []func(string) string{}
+	for _, pattern := range config.DefaultReporterConfig.RedactPatterns {
+		redactor, err := writer.RegexpRedactor(pattern)
+		if err != nil {
+			panic(fmt.Sprintf("Invalid -redact pattern: %s", err))
+		}
+		redactors = append(redactors, redactor)
+	}
+	if lateOutput := config.DefaultReporterConfig.LateOutput; lateOutput != "" && lateOutput != "off" && lateOutput != "tag" && lateOutput != "drop" {
+		panic(fmt.Sprintf("Invalid -lateOutput: %q, expected off, tag or drop", lateOutput))
+	}
+	if _, err := stenographer.ParseGlyphs(config.DefaultReporterConfig.Glyphs); err != nil {
+		panic(fmt.Sprintf("Invalid -glyphs: %s", err))
+	}
+	if rerunCommands := config.DefaultReporterConfig.RerunCommands; rerunCommands != "" && rerunCommands != "ginkgo" && rerunCommands != "go" && rerunCommands != "none" {
+		panic(fmt.Sprintf("Invalid -rerunCommands: %q, expected ginkgo, go or none", rerunCommands))
+	}
+	if failOnSeverity := ginkgoConfig.FailOnSeverity; failOnSeverity != "" {
+		if _, err := types.ParseSeverity(failOnSeverity); err != nil {
+			panic(fmt.Sprintf("Invalid -failOnSeverity: %s", err))
+		}
+	}
 	writer := GinkgoWriter.(*writer.Writer)
-	writer.SetStream(config.DefaultReporterConfig.Verbose)
+	writer.SetStream(config.DefaultReporterConfig.Verbose || config.DefaultReporterConfig.Follow)
+	writer.SetLimit(config.DefaultReporterConfig.OutputLimit)
+	writer.SetSanitization(config.DefaultReporterConfig.StripANSI, config.DefaultReporterConfig.BinaryOutput)
+	writer.SetTrackSessions(config.DefaultReporterConfig.LateOutput == "tag" || config.DefaultReporterConfig.LateOutput == "drop")
+	writer.SetDropLateOutput(config.DefaultReporterConfig.LateOutput == "drop")
+	for _, redactor := range redactors {
+		writer.AddRedactor(redactor)
+	}
 	reporters := make([]reporters.Reporter, len(specReporters))
 	for i, reporter := range specReporters {
 		reporters[i] = reporter
 	}
-	passed, hasFocusedTests := global.Suite.Run(t, description, reporters, writer, config.GinkgoConfig)
+	reporters = append(reporters, buildReportFileReporters()...)
+	passed, hasFocusedTests := global.Suite.Run(t, description, reporters, writer, ginkgoConfig)
 
 	if deprecationTracker.DidTrackDeprecations() {
 		fmt.Fprintln(colorable.NewColorableStderr(), deprecationTracker.DeprecationsReport())
@@ -249,10 +374,22 @@ func runSpecsWithCustomReporters(t GinkgoTestingT, description string, specRepor
 	return passed
 }
 
+//InterruptSuite lets programs that run a suite themselves, such as custom runners or services running validation
+//suites, shut the running suite down the way Ctrl-C does: the running spec is reported, AfterSuite runs and the reports
+//are written, with cause recorded among the special failure reasons of the suite.  RunSpecs then returns false instead
+//of exiting the process.
+//
+//InterruptSuite returns false if no suite is running.
+func InterruptSuite(cause types.InterruptCause, message string) bool {
+	return global.Suite.Interrupt(cause, message)
+}
+
 func buildDefaultReporter() Reporter {
 	remoteReportingServer := config.GinkgoConfig.StreamHost
 	if remoteReportingServer == "" {
-		stenographer := stenographer.New(!config.DefaultReporterConfig.NoColor, config.GinkgoConfig.FlakeAttempts > 1, colorable.NewColorableStdout())
+		width := formatter.ConsoleWidth(config.DefaultReporterConfig.Columns, config.DefaultReporterConfig.NoWrap)
+		glyphs, _ := stenographer.ParseGlyphs(config.DefaultReporterConfig.Glyphs)
+		stenographer := stenographer.NewWithGlyphs(!config.DefaultReporterConfig.NoColor, config.GinkgoConfig.FlakeAttempts > 1, width, glyphs, colorable.NewColorableStdout())
 		return reporters.NewDefaultReporter(config.DefaultReporterConfig, stenographer)
 	} else {
 		debugFile := ""
@@ -263,6 +400,15 @@ func buildDefaultReporter() Reporter {
 	}
 }
 
+//When running in parallel, the Ginkgo CLI attaches the report file reporters to its aggregator
+//so that they see the entire suite.  The individual nodes must not write the reports themselves.
+func buildReportFileReporters() []reporters.Reporter {
+	if config.GinkgoConfig.StreamHost != "" {
+		return nil
+	}
+	return reporters.NewReportFileReporters(config.GinkgoConfig, config.DefaultReporterConfig, "")
+}
+
 //Skip notifies Ginkgo that the current spec was skipped.
 func Skip(message string, callerSkip ...int) {
 	skip := 0
@@ -281,10 +427,27 @@ func Fail(message string, callerSkip ...int) {
 		skip = callerSkip[0]
 	}
 
-	global.Failer.Fail(message, codelocation.New(skip+1))
+	location := codelocation.New(skip + 1)
+	if global.Failer.FailOutsideNode(message, location) {
+		runtime.Goexit()
+	}
+	global.Failer.Fail(message, location)
 	panic(GINKGO_PANIC)
 }
 
+//GinkgoHelper marks the function calling it as a helper, as testing.T.Helper does: failures raised within it, through
+//Fail or Gomega, are reported at the location of its caller rather than within the helper.
+//
+//	func expectValidUser(user User) {
+//		GinkgoHelper()
+//		Ω(user.Name).ShouldNot(BeEmpty())
+//	}
+//
+//Helpers may call other helpers: failures are reported at the first caller that is not one.
+func GinkgoHelper() {
+	codelocation.MarkAsHelper(1)
+}
+
 //GinkgoRecover should be deferred at the top of any spawned goroutine that (may) call `Fail`
 //Since Gomega assertions call fail, you should throw a `defer GinkgoRecover()` at the top of any goroutine that
 //calls out to Gomega
@@ -295,6 +458,9 @@ func Fail(message string, callerSkip ...int) {
 //
 //Unfortunately, if a panic originates on a goroutine *launched* from one of these nodes there's no
 //way for Ginkgo to rescue the panic.  To do this, you must remember to `defer GinkgoRecover()` at the top of such a goroutine.
+//
+//Run with -detectGoroutineFailures to find the goroutines that forget to: rather than crashing the test binary, their
+//failures fail the running spec with the stack of the goroutine, and the goroutine is stopped.
 func GinkgoRecover() {
 	e := recover()
 	if e != nil {
@@ -302,32 +468,67 @@ func GinkgoRecover() {
 	}
 }
 
+//GinkgoRecoverAndReport can be deferred at the top of a spawned goroutine instead of GinkgoRecover.  Rather than failing
+//the node that is running, the failure or panic of the goroutine is recorded as an additional failure of the spec that
+//is running when it occurs: the spec carries on and fails once it has run, reporting the failure of the goroutine
+//alongside its own.  This keeps goroutines that outlive their spec from failing the node of a spec that has nothing
+//to do with them.
+//
+//Outside of a spec, GinkgoRecoverAndReport behaves like GinkgoRecover.
+func GinkgoRecoverAndReport() {
+	e := recover()
+	failure, ok := global.Failer.RetractGoroutineFailure()
+	if !ok {
+		if e == nil || e == GINKGO_PANIC {
+			//nothing failed, or the failure was already reported by the node it failed
+			return
+		}
+		failure = types.SpecFailure{
+			Message:        "Test Panicked",
+			Location:       codelocation.New(1),
+			ForwardedPanic: fmt.Sprintf("%v", e),
+		}
+	}
+	if global.Suite.RecordAdditionalFailure(failure) {
+		return
+	}
+	if failure.ForwardedPanic != "" {
+		global.Failer.Panic(failure.Location, e)
+	} else {
+		global.Failer.Fail(failure.Message, failure.Location)
+	}
+}
+
 //Describe blocks allow you to organize your specs.  A Describe block can contain any number of
 //BeforeEach, AfterEach, JustBeforeEach, It, and Measurement blocks.
 //
 //In addition you can nest Describe, Context and When blocks.  Describe, Context and When blocks are functionally
 //equivalent.  The difference is purely semantic -- you typically Describe the behavior of an object
 //or method and, within that Describe, outline a number of Contexts and Whens.
-func Describe(text string, body func()) bool {
-	global.Suite.PushContainerNode(text, body, types.FlagTypeNone, codelocation.New(1))
+func Describe(text string, body func(), decorators ...interface{}) bool {
+	d := parseDecorations("Describe", codelocation.New(1), false, false, decorators...)
+	pushContainerNode(text, body, types.FlagTypeNone, codelocation.New(1), d)
 	return true
 }
 
 //You can focus the tests within a describe block using FDescribe
-func FDescribe(text string, body func()) bool {
-	global.Suite.PushContainerNode(text, body, types.FlagTypeFocused, codelocation.New(1))
+func FDescribe(text string, body func(), decorators ...interface{}) bool {
+	d := parseDecorations("FDescribe", codelocation.New(1), false, false, decorators...)
+	pushContainerNode(text, body, types.FlagTypeFocused, codelocation.New(1), d)
 	return true
 }
 
 //You can mark the tests within a describe block as pending using PDescribe
-func PDescribe(text string, body func()) bool {
-	global.Suite.PushContainerNode(text, body, types.FlagTypePending, codelocation.New(1))
+func PDescribe(text string, body func(), decorators ...interface{}) bool {
+	d := parseDecorations("PDescribe", codelocation.New(1), false, false, decorators...)
+	pushContainerNode(text, body, types.FlagTypePending, codelocation.New(1), d)
 	return true
 }
 
 //You can mark the tests within a describe block as pending using XDescribe
-func XDescribe(text string, body func()) bool {
-	global.Suite.PushContainerNode(text, body, types.FlagTypePending, codelocation.New(1))
+func XDescribe(text string, body func(), decorators ...interface{}) bool {
+	d := parseDecorations("XDescribe", codelocation.New(1), false, false, decorators...)
+	pushContainerNode(text, body, types.FlagTypePending, codelocation.New(1), d)
 	return true
 }
 
@@ -337,26 +538,30 @@ func XDescribe(text string, body func()) bool {
 //In addition you can nest Describe, Context and When blocks.  Describe, Context and When blocks are functionally
 //equivalent.  The difference is purely semantic -- you typical Describe the behavior of an object
 //or method and, within that Describe, outline a number of Contexts and Whens.
-func Context(text string, body func()) bool {
-	global.Suite.PushContainerNode(text, body, types.FlagTypeNone, codelocation.New(1))
+func Context(text string, body func(), decorators ...interface{}) bool {
+	d := parseDecorations("Context", codelocation.New(1), false, false, decorators...)
+	pushContainerNode(text, body, types.FlagTypeNone, codelocation.New(1), d)
 	return true
 }
 
 //You can focus the tests within a describe block using FContext
-func FContext(text string, body func()) bool {
-	global.Suite.PushContainerNode(text, body, types.FlagTypeFocused, codelocation.New(1))
+func FContext(text string, body func(), decorators ...interface{}) bool {
+	d := parseDecorations("FContext", codelocation.New(1), false, false, decorators...)
+	pushContainerNode(text, body, types.FlagTypeFocused, codelocation.New(1), d)
 	return true
 }
 
 //You can mark the tests within a describe block as pending using PContext
-func PContext(text string, body func()) bool {
-	global.Suite.PushContainerNode(text, body, types.FlagTypePending, codelocation.New(1))
+func PContext(text string, body func(), decorators ...interface{}) bool {
+	d := parseDecorations("PContext", codelocation.New(1), false, false, decorators...)
+	pushContainerNode(text, body, types.FlagTypePending, codelocation.New(1), d)
 	return true
 }
 
 //You can mark the tests within a describe block as pending using XContext
-func XContext(text string, body func()) bool {
-	global.Suite.PushContainerNode(text, body, types.FlagTypePending, codelocation.New(1))
+func XContext(text string, body func(), decorators ...interface{}) bool {
+	d := parseDecorations("XContext", codelocation.New(1), false, false, decorators...)
+	pushContainerNode(text, body, types.FlagTypePending, codelocation.New(1), d)
 	return true
 }
 
@@ -366,26 +571,30 @@ func XContext(text string, body func()) bool {
 //In addition you can nest Describe, Context and When blocks.  Describe, Context and When blocks are functionally
 //equivalent.  The difference is purely semantic -- you typical Describe the behavior of an object
 //or method and, within that Describe, outline a number of Contexts and Whens.
-func When(text string, body func()) bool {
-	global.Suite.PushContainerNode("when "+text, body, types.FlagTypeNone, codelocation.New(1))
+func When(text string, body func(), decorators ...interface{}) bool {
+	d := parseDecorations("When", codelocation.New(1), false, false, decorators...)
+	pushContainerNode("when "+text, body, types.FlagTypeNone, codelocation.New(1), d)
 	return true
 }
 
 //You can focus the tests within a describe block using FWhen
-func FWhen(text string, body func()) bool {
-	global.Suite.PushContainerNode("when "+text, body, types.FlagTypeFocused, codelocation.New(1))
+func FWhen(text string, body func(), decorators ...interface{}) bool {
+	d := parseDecorations("FWhen", codelocation.New(1), false, false, decorators...)
+	pushContainerNode("when "+text, body, types.FlagTypeFocused, codelocation.New(1), d)
 	return true
 }
 
 //You can mark the tests within a describe block as pending using PWhen
-func PWhen(text string, body func()) bool {
-	global.Suite.PushContainerNode("when "+text, body, types.FlagTypePending, codelocation.New(1))
+func PWhen(text string, body func(), decorators ...interface{}) bool {
+	d := parseDecorations("PWhen", codelocation.New(1), false, false, decorators...)
+	pushContainerNode("when "+text, body, types.FlagTypePending, codelocation.New(1), d)
 	return true
 }
 
 //You can mark the tests within a describe block as pending using XWhen
-func XWhen(text string, body func()) bool {
-	global.Suite.PushContainerNode("when "+text, body, types.FlagTypePending, codelocation.New(1))
+func XWhen(text string, body func(), decorators ...interface{}) bool {
+	d := parseDecorations("XWhen", codelocation.New(1), false, false, decorators...)
+	pushContainerNode("when "+text, body, types.FlagTypePending, codelocation.New(1), d)
 	return true
 }
 
@@ -394,56 +603,103 @@ func XWhen(text string, body func()) bool {
 //
 //Ginkgo will normally run It blocks synchronously.  To perform asynchronous tests, pass a
 //function that accepts a Done channel.  When you do this, you can also provide an optional timeout.
-func It(text string, body interface{}, timeout ...float64) bool {
+//
+//It blocks can also accept a SpecContext, which is cancelled when the It block returns.
+//
+//Synchronous It blocks may return an error: a non-nil error fails the spec with the error's message at the
+//location of the It block, sparing a trailing Expect(err).NotTo(HaveOccurred()).
+//
+//It blocks, like Describe, Context and When blocks, accept decorators such as Label after their body.
+//The Poll decorator, which retries the body until it succeeds, goes before the body.
+//
+//It blocks declared without a body, such as It("does something later"), are placeholders: they are pending, unless
+//-strictPending is set, in which case they fail.  Either way, the summary of the suite lists them.
+func It(text string, args ...interface{}) bool {
+	body, decorators := splitBody(args)
+	if body == nil {
+		d := parseDecorations("It", codelocation.New(1), true, false, decorators...)
+		global.Suite.PushBodylessItNode(text, types.FlagTypeNone, codelocation.New(1), d.labels...)
+		return true
+	}
 	validateBodyFunc(body, codelocation.New(1))
-	global.Suite.PushItNode(text, body, types.FlagTypeNone, codelocation.New(1), parseTimeout(timeout...))
+	d := parseDecorations("It", codelocation.New(1), true, false, decorators...)
+	body = pollingBody(d.poll, body, "It", codelocation.New(1))
+	pushItNode(text, body, types.FlagTypeNone, codelocation.New(1), d)
 	return true
 }
 
 //You can focus individual Its using FIt
-func FIt(text string, body interface{}, timeout ...float64) bool {
+func FIt(text string, args ...interface{}) bool {
+	body, decorators := splitBody(args)
+	if body == nil {
+		d := parseDecorations("FIt", codelocation.New(1), true, false, decorators...)
+		global.Suite.PushBodylessItNode(text, types.FlagTypeFocused, codelocation.New(1), d.labels...)
+		return true
+	}
 	validateBodyFunc(body, codelocation.New(1))
-	global.Suite.PushItNode(text, body, types.FlagTypeFocused, codelocation.New(1), parseTimeout(timeout...))
+	d := parseDecorations("FIt", codelocation.New(1), true, false, decorators...)
+	body = pollingBody(d.poll, body, "FIt", codelocation.New(1))
+	pushItNode(text, body, types.FlagTypeFocused, codelocation.New(1), d)
 	return true
 }
 
-//You can mark Its as pending using PIt
-func PIt(text string, _ ...interface{}) bool {
-	global.Suite.PushItNode(text, func() {}, types.FlagTypePending, codelocation.New(1), 0)
+//You can mark Its as pending using PIt.  Decorate them with PendingReason to record why they are pending.
+func PIt(text string, args ...interface{}) bool {
+	d := parseDecorations("PIt", codelocation.New(1), true, true, args...)
+	pushPendingItNode(text, codelocation.New(1), d)
 	return true
 }
 
 //You can mark Its as pending using XIt
-func XIt(text string, _ ...interface{}) bool {
-	global.Suite.PushItNode(text, func() {}, types.FlagTypePending, codelocation.New(1), 0)
+func XIt(text string, args ...interface{}) bool {
+	d := parseDecorations("XIt", codelocation.New(1), true, true, args...)
+	pushPendingItNode(text, codelocation.New(1), d)
 	return true
 }
 
 //Specify blocks are aliases for It blocks and allow for more natural wording in situations
 //which "It" does not fit into a natural sentence flow. All the same protocols apply for Specify blocks
 //which apply to It blocks.
-func Specify(text string, body interface{}, timeout ...float64) bool {
+func Specify(text string, args ...interface{}) bool {
+	body, decorators := splitBody(args)
+	if body == nil {
+		d := parseDecorations("Specify", codelocation.New(1), true, false, decorators...)
+		global.Suite.PushBodylessItNode(text, types.FlagTypeNone, codelocation.New(1), d.labels...)
+		return true
+	}
 	validateBodyFunc(body, codelocation.New(1))
-	global.Suite.PushItNode(text, body, types.FlagTypeNone, codelocation.New(1), parseTimeout(timeout...))
+	d := parseDecorations("Specify", codelocation.New(1), true, false, decorators...)
+	body = pollingBody(d.poll, body, "Specify", codelocation.New(1))
+	pushItNode(text, body, types.FlagTypeNone, codelocation.New(1), d)
 	return true
 }
 
 //You can focus individual Specifys using FSpecify
-func FSpecify(text string, body interface{}, timeout ...float64) bool {
+func FSpecify(text string, args ...interface{}) bool {
+	body, decorators := splitBody(args)
+	if body == nil {
+		d := parseDecorations("FSpecify", codelocation.New(1), true, false, decorators...)
+		global.Suite.PushBodylessItNode(text, types.FlagTypeFocused, codelocation.New(1), d.labels...)
+		return true
+	}
 	validateBodyFunc(body, codelocation.New(1))
-	global.Suite.PushItNode(text, body, types.FlagTypeFocused, codelocation.New(1), parseTimeout(timeout...))
+	d := parseDecorations("FSpecify", codelocation.New(1), true, false, decorators...)
+	body = pollingBody(d.poll, body, "FSpecify", codelocation.New(1))
+	pushItNode(text, body, types.FlagTypeFocused, codelocation.New(1), d)
 	return true
 }
 
 //You can mark Specifys as pending using PSpecify
-func PSpecify(text string, is ...interface{}) bool {
-	global.Suite.PushItNode(text, func() {}, types.FlagTypePending, codelocation.New(1), 0)
+func PSpecify(text string, args ...interface{}) bool {
+	d := parseDecorations("PSpecify", codelocation.New(1), true, true, args...)
+	pushPendingItNode(text, codelocation.New(1), d)
 	return true
 }
 
 //You can mark Specifys as pending using XSpecify
-func XSpecify(text string, is ...interface{}) bool {
-	global.Suite.PushItNode(text, func() {}, types.FlagTypePending, codelocation.New(1), 0)
+func XSpecify(text string, args ...interface{}) bool {
+	d := parseDecorations("XSpecify", codelocation.New(1), true, true, args...)
+	pushPendingItNode(text, codelocation.New(1), d)
 	return true
 }
 
@@ -454,12 +710,14 @@ func XSpecify(text string, is ...interface{}) bool {
 //
 //By allows you to document such flows.  By must be called within a runnable node (It, BeforeEach, Measure, etc...)
 //By will simply log the passed in text to the GinkgoWriter.  If By is handed a function it will immediately run the function.
+//Steps are also recorded in the SpecSummary handed to reporters.
 func By(text string, callbacks ...func()) {
 	preamble := "\x1b[1mSTEP\x1b[0m"
 	if config.DefaultReporterConfig.NoColor {
 		preamble = "STEP"
 	}
 	fmt.Fprintln(GinkgoWriter, preamble+": "+text)
+	global.Suite.RecordStep(text, codelocation.New(1))
 	if len(callbacks) == 1 {
 		callbacks[0]()
 	}
@@ -473,30 +731,34 @@ func By(text string, callbacks ...func()) {
 //
 //The body function must have the signature:
 //	func(b Benchmarker)
-func Measure(text string, body interface{}, samples int) bool {
+func Measure(text string, body interface{}, samples int, decorators ...interface{}) bool {
 	deprecationTracker.TrackDeprecation(types.Deprecations.Measure(), codelocation.New(1))
-	global.Suite.PushMeasureNode(text, body, types.FlagTypeNone, codelocation.New(1), samples)
+	d := parseDecorations("Measure", codelocation.New(1), false, false, decorators...)
+	global.Suite.PushMeasureNode(text, body, types.FlagTypeNone, codelocation.New(1), samples, d.labels...)
 	return true
 }
 
 //You can focus individual Measures using FMeasure
-func FMeasure(text string, body interface{}, samples int) bool {
+func FMeasure(text string, body interface{}, samples int, decorators ...interface{}) bool {
 	deprecationTracker.TrackDeprecation(types.Deprecations.Measure(), codelocation.New(1))
-	global.Suite.PushMeasureNode(text, body, types.FlagTypeFocused, codelocation.New(1), samples)
+	d := parseDecorations("FMeasure", codelocation.New(1), false, false, decorators...)
+	global.Suite.PushMeasureNode(text, body, types.FlagTypeFocused, codelocation.New(1), samples, d.labels...)
 	return true
 }
 
 //You can mark Measurements as pending using PMeasure
-func PMeasure(text string, _ ...interface{}) bool {
+func PMeasure(text string, args ...interface{}) bool {
 	deprecationTracker.TrackDeprecation(types.Deprecations.Measure(), codelocation.New(1))
-	global.Suite.PushMeasureNode(text, func(b Benchmarker) {}, types.FlagTypePending, codelocation.New(1), 0)
+	d := parseDecorations("PMeasure", codelocation.New(1), false, true, args...)
+	global.Suite.PushMeasureNode(text, func(b Benchmarker) {}, types.FlagTypePending, codelocation.New(1), 0, d.labels...)
 	return true
 }
 
 //You can mark Measurements as pending using XMeasure
-func XMeasure(text string, _ ...interface{}) bool {
+func XMeasure(text string, args ...interface{}) bool {
 	deprecationTracker.TrackDeprecation(types.Deprecations.Measure(), codelocation.New(1))
-	global.Suite.PushMeasureNode(text, func(b Benchmarker) {}, types.FlagTypePending, codelocation.New(1), 0)
+	d := parseDecorations("XMeasure", codelocation.New(1), false, true, args...)
+	global.Suite.PushMeasureNode(text, func(b Benchmarker) {}, types.FlagTypePending, codelocation.New(1), 0, d.labels...)
 	return true
 }
 
@@ -506,12 +768,123 @@ func XMeasure(text string, _ ...interface{}) bool {
 //BeforeSuite blocks can be made asynchronous by providing a body function that accepts a Done channel
 //
 //You may only register *one* BeforeSuite handler per test suite.  You typically do so in your bootstrap file at the top level.
+//Use RegisterSuiteSetup to compose suite setup from several places.
 func BeforeSuite(body interface{}, timeout ...float64) bool {
 	validateBodyFunc(body, codelocation.New(1))
 	global.Suite.SetBeforeSuiteNode(body, codelocation.New(1), parseTimeout(timeout...))
 	return true
 }
 
+//WarmUp blocks run once, before the suite begins: before the suite is announced and the reporters are told it begins,
+//and before BeforeSuite.  Use them for warm-up work whose duration is not to count against the run time of the suite,
+//such as pulling container images or compiling helpers: the suite summary reports it apart, as WarmUpRunTime.  A
+//failing WarmUp is reported the way a failing BeforeSuite is, and none of the specs run.
+//
+//When running in parallel, each parallel node process calls WarmUp.
+//
+//You may only register *one* WarmUp handler per test suite.
+func WarmUp(body interface{}, timeout ...float64) bool {
+	validateBodyFunc(body, codelocation.New(1))
+	global.Suite.SetWarmUpNode(body, codelocation.New(1), parseTimeout(timeout...))
+	return true
+}
+
+//RegisterSuiteSetup registers a named piece of suite setup.  Unlike BeforeSuite, any number of suite setups can be registered,
+//which lets shared helper packages contribute setup without clashing with each other or with the suite's own BeforeSuite:
+//
+//	var _ = RegisterSuiteSetup("database", 10, func() {
+//		db = startDatabase()
+//	})
+//
+//Suite setups run once, before any specs, in order of priority (lowest first) and then in the order they were registered.
+//The suite's BeforeSuite (or SynchronizedBeforeSuite) has priority 0 and runs ahead of the suite setups of the same priority.
+//When running in parallel, each parallel node process runs the suite setups.  If a suite setup fails, the remaining ones are
+//not run and the specs are skipped, just as with a failing BeforeSuite.
+//
+//Registering the same name twice from the same code location (e.g. a helper called from several files) is ignored.  Registering
+//it from a different code location panics, naming both locations.
+func RegisterSuiteSetup(name string, priority int, body interface{}, timeout ...float64) bool {
+	validateBodyFunc(body, codelocation.New(1))
+	global.Suite.RegisterSuiteSetup(name, priority, body, codelocation.New(1), parseTimeout(timeout...))
+	return true
+}
+
+//RegisterTextTransformer registers a function applied to the texts of containers and specs when they are reported, e.g. to
+//localize message keys or to normalize dynamic values:
+//
+//	var _ = RegisterTextTransformer(func(text string) string {
+//		return i18n.Translate(text)
+//	})
+//
+//Transformers apply, in the order they were registered, to the texts reporters display, which they find in the
+//DisplayTexts of the spec summaries.  Focus and skip filters, DependsOn, CurrentGinkgoTestDescription(), rerun commands
+//and the reports that tell specs apart across runs keep using the texts as written in the specs.  Register transformers
+//at the top level, before RunSpecs runs.
+func RegisterTextTransformer(transform func(text string) string) bool {
+	global.Suite.RegisterTextTransformer(transform)
+	return true
+}
+
+//RegisterSpecPolicy registers a policy the specs of the suite must abide by, to enforce conventions such as every spec
+//carrying a team label:
+//
+//	var _ = RegisterSpecPolicy(func(specs []*types.SpecSummary) []error {
+//		errs := []error{}
+//		for _, spec := range specs {
+//			if len(spec.Labels) == 0 {
+//				errs = append(errs, fmt.Errorf("%s has no label", spec.ComponentCodeLocations[len(spec.ComponentCodeLocations)-1]))
+//			}
+//		}
+//		return errs
+//	})
+//
+//Once the spec tree is built and filtered, and before anything runs, each policy receives the summaries of all the specs,
+//skipped and pending ones included, in the order they would run.  Their texts, code locations, labels, owners and states
+//are known at this point.  If a policy returns errors, no spec runs and the suite fails listing the errors.  Register
+//policies at the top level, before RunSpecs runs.
+func RegisterSpecPolicy(policy func(specs []*types.SpecSummary) []error) bool {
+	global.Suite.RegisterSpecPolicy(policy)
+	return true
+}
+
+//RegisterSuiteInvariant registers a check of the state the specs share, to hunt down the spec polluting it for the
+//specs that follow:
+//
+//	var _ = RegisterSuiteInvariant(func() error {
+//		rows, err := db.CountRows("users")
+//		if err == nil && rows > 0 {
+//			err = fmt.Errorf("%d users left over", rows)
+//		}
+//		return err
+//	})
+//
+//Invariants are only checked with -checkInvariants, after every spec, its cleanups included.  The first spec after which
+//an invariant returns an error (or panics) fails with that error.  The specs that follow are only failed again once the
+//invariant held anew.  Register invariants at the top level, before RunSpecs runs.
+func RegisterSuiteInvariant(check func() error) bool {
+	global.Suite.RegisterSuiteInvariant(check, codelocation.New(1))
+	return true
+}
+
+//Clock tells the current time, see SetClock
+type Clock interface {
+	Now() time.Time
+}
+
+//SetClock substitutes c for the clock that stamps the start and end times and run times in the reports of the suite, its
+//specs, nodes, steps, shared fixtures and report entries, e.g. to get reproducible reports from a suite running on a
+//virtual clock:
+//
+//	var _ = SetClock(simulation.Clock)
+//
+//A nil c restores the wall clock.  Timeouts, polling, -slowSpecThreshold and the other behaviors that wait on real time keep
+//using the wall clock.  The clock applies to the test process: in parallel runs the suite-level times that the ginkgo CLI
+//aggregates come from the wall clock.  Set it at the top level, before RunSpecs runs.
+func SetClock(c Clock) bool {
+	clock.Set(c)
+	return true
+}
+
 //AfterSuite blocks are *always* run after all the specs regardless of whether specs have passed or failed.
 //Moreover, if Ginkgo receives an interrupt signal (^C) it will attempt to run the AfterSuite before exiting.
 //
@@ -526,6 +899,27 @@ func AfterSuite(body interface{}, timeout ...float64) bool {
 	return true
 }
 
+//ReportAfterSuite registers a node that runs once the suite is done, AfterSuite included, with the report of the whole
+//run: the specs completed by all the parallel nodes.  Use it to write custom reports or to publish the results:
+//
+//	var _ = ReportAfterSuite("uploads the results", func(report types.AggregatedReport) {
+//		Ω(dashboard.Upload(report)).Should(Succeed())
+//	})
+//
+//When running in parallel, ReportAfterSuite nodes only run on parallel node #1, once all the other nodes have finished.
+//A suite may register several of them: they run in the order they are registered, unless decorated with ReportOrder, and
+//each one runs even when the previous ones failed.  A ReportAfterSuite that fails fails the suite, and is reported by its
+//text and location, with its failure, both in the output and among the SpecialSuiteFailureReasons of the suite summary.
+//When the report of the suite cannot be aggregated, e.g. because the server run by the Ginkgo CLI went away, each
+//ReportAfterSuite fails that way without running.
+//
+//ReportAfterSuite accepts a timeout (float64 or int seconds, or a time.Duration) and a ReportOrder decorator.
+func ReportAfterSuite(text string, body func(types.AggregatedReport), decorators ...interface{}) bool {
+	d := parseDecorations("ReportAfterSuite", codelocation.New(1), true, false, decorators...)
+	global.Suite.PushReportAfterSuiteNode(text, body, d.reportOrder, codelocation.New(1), d.timeout)
+	return true
+}
+
 //SynchronizedBeforeSuite blocks are primarily meant to solve the problem of setting up singleton external resources shared across
 //nodes when running tests in parallel.  For example, say you have a shared database that you can only start one instance of that
 //must be used in your tests.  When running in parallel, only one node should set up the database and all other nodes should wait
@@ -607,7 +1001,7 @@ func SynchronizedAfterSuite(allNodesBody interface{}, node1Body interface{}, tim
 //Describe and Context blocks the outermost BeforeEach blocks are run first.
 //
 //Like It blocks, BeforeEach blocks can be made asynchronous by providing a body function that accepts
-//a Done channel
+//a Done channel, or can accept a SpecContext or return an error
 func BeforeEach(body interface{}, timeout ...float64) bool {
 	validateBodyFunc(body, codelocation.New(1))
 	global.Suite.PushBeforeEachNode(body, codelocation.New(1), parseTimeout(timeout...))
@@ -647,6 +1041,90 @@ func AfterEach(body interface{}, timeout ...float64) bool {
 	return true
 }
 
+//DeferCleanup registers a cleanup from within a BeforeEach, JustBeforeEach, It, JustAfterEach or AfterEach block, as
+//the defer statement does within a function, but run once the spec unwinds the block:
+//
+//	BeforeEach(func() {
+//		server = StartServer()
+//		DeferCleanup(server.Stop)
+//	})
+//
+//Cleanups registered by an It run after the JustAfterEach blocks, and cleanups registered by the blocks of a container
+//run after the AfterEach blocks of that container, before those of the enclosing containers.  Cleanups registered by
+//the same level run in the reverse order of their registration, and appear in the timeline of the spec.
+//
+//A cleanup that fails fails the spec.  A cleanup that panics is only reported in the output of the spec, unless
+//-failOnCleanupPanics is set.
+func DeferCleanup(body interface{}) {
+	global.Suite.DeferCleanup(body, codelocation.New(1))
+}
+
+//RedactOutput registers a function that scrubs the output captured while the suite runs, e.g. of tokens and passwords,
+//before it is handed to reporters and written to reports.  Call it at the top level of a test file:
+//
+//	var _ = RedactOutput(func(output string) string {
+//		return strings.ReplaceAll(output, os.Getenv("API_TOKEN"), "[REDACTED]")
+//	})
+//
+//Redactors run in the order they were registered, before the redactions of the -redact flag.  What is streamed live to
+//the console with -v is left untouched.
+func RedactOutput(redactor func(output string) string) bool {
+	GinkgoWriter.(*writer.Writer).AddRedactor(redactor)
+	return true
+}
+
+//GinkgoSetenv sets an environment variable from within a spec, as testing.T.Setenv does: the variable is restored once
+//the spec unwinds the node calling GinkgoSetenv, as a cleanup registered with DeferCleanup would.  The changes made with
+//GinkgoSetenv are intentional, so they are not reported as leaks with -restoreEnvironment.
+func GinkgoSetenv(key string, value string) {
+	previous, wasSet := os.LookupEnv(key)
+	global.Suite.DeferCleanup(func() {
+		if wasSet {
+			os.Setenv(key, previous)
+		} else {
+			os.Unsetenv(key)
+		}
+	}, codelocation.New(1))
+	if err := os.Setenv(key, value); err != nil {
+		Fail(fmt.Sprintf("Failed to set the environment variable %s: %s", key, err.Error()), 1)
+	}
+}
+
+//AroundEach registers middleware that wraps every spec of the suite, including its BeforeEach and AfterEach blocks.
+//Use it for cross-cutting concerns such as tracing, metrics or setting a request ID, without editing every Describe:
+//
+//	var _ = AroundEach(func(run func()) {
+//		span := tracer.Start(CurrentGinkgoTestDescription().FullTestText)
+//		defer span.End()
+//		run()
+//	})
+//
+//The body must call run exactly once.  AroundEach blocks compose in registration order: the first one registered is
+//the outermost.  They may only be registered at the top level and appear in the timeline of each spec.
+func AroundEach(body func(run func())) bool {
+	global.Suite.PushAroundEachNode(body, codelocation.New(1))
+	return true
+}
+
+//BeforeNode registers a hook that runs right before the body of every node of every spec (BeforeEach, JustBeforeEach,
+//It, JustAfterEach and AfterEach blocks), with a description of the node and of its spec.  Use it to configure Gomega
+//per spec rather than mutating global Gomega settings in BeforeEach blocks:
+//
+//	var _ = BeforeNode(func(node types.NodeInfo) {
+//		if node.HasLabel("slow") {
+//			SetDefaultEventuallyTimeout(time.Minute)
+//		} else {
+//			SetDefaultEventuallyTimeout(time.Second)
+//		}
+//	})
+//
+//Hooks run in registration order.  A hook that fails fails the node it runs for, which then does not run.  BeforeNode
+//hooks may only be registered at the top level.
+func BeforeNode(body func(node types.NodeInfo)) bool {
+	global.Suite.PushBeforeNodeHook(body, codelocation.New(1))
+	return true
+}
+
 func validateBodyFunc(body interface{}, cl types.CodeLocation) {
 	t := reflect.TypeOf(body)
 	if t.Kind() != reflect.Func {
@@ -673,3 +1151,20 @@ func parseTimeout(timeout ...float64) time.Duration {
 		return time.Duration(timeout[0] * float64(time.Second))
 	}
 }
+
+//parseWaitTimeout parses the optional timeout (a time.Duration, or float64 or int seconds) passed to a function that waits
+func parseWaitTimeout(function string, defaultTimeout time.Duration, timeout ...interface{}) time.Duration {
+	if len(timeout) == 0 {
+		return defaultTimeout
+	}
+	switch arg := timeout[0].(type) {
+	case time.Duration:
+		return arg
+	case float64:
+		return time.Duration(arg * float64(time.Second))
+	case int:
+		return time.Duration(arg) * time.Second
+	default:
+		panic(fmt.Sprintf("Invalid timeout %#v passed to %s", arg, function))
+	}
+}
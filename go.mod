@@ -8,6 +8,7 @@ require (
 	github.com/onsi/gomega v1.10.1
 	golang.org/x/sys v0.0.0-20210112080510-489259a85091
 	golang.org/x/tools v0.0.0-20201224043029-2b0845dc783e
+	gopkg.in/yaml.v2 v2.3.0
 )
 
 retract v1.16.3 // git tag accidentally associated with incorrect git commit
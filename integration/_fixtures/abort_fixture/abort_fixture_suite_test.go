@@ -0,0 +1,13 @@
+package abort_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestAbortFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "AbortFixture Suite")
+}
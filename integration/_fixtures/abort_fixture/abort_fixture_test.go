@@ -0,0 +1,21 @@
+package abort_fixture_test
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/failer"
+)
+
+var _ = Describe("AbortFixture", func() {
+	It("aborts", func() {
+		failer.Abort("the database is gone")
+	})
+
+	for i := 0; i < 10; i++ {
+		It(fmt.Sprintf("is left to run %d", i), func() {
+			time.Sleep(100 * time.Millisecond)
+		})
+	}
+})
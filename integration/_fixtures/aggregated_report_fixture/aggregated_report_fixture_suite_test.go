@@ -0,0 +1,13 @@
+package aggregated_report_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestAggregatedReportFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "AggregatedReportFixture Suite")
+}
@@ -0,0 +1,29 @@
+package aggregated_report_fixture_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AggregatedReportFixture", func() {
+	It("fails", func() {
+		Fail("boom")
+	})
+
+	It("sees the failures of the other node", func() {
+		Eventually(func() int {
+			return CurrentAggregatedReport().Failed
+		}, 10).Should(Equal(1))
+
+		var failed *types.SpecSummary
+		for _, summary := range CurrentAggregatedReport().SpecSummaries {
+			if summary.Failed() {
+				failed = summary
+			}
+		}
+		fmt.Printf("saw %q fail on node %d\n", failed.ComponentTexts[len(failed.ComponentTexts)-1], GinkgoParallelNode())
+	})
+})
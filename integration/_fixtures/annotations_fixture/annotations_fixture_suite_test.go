@@ -0,0 +1,13 @@
+package annotations_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestAnnotationsFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "AnnotationsFixture Suite")
+}
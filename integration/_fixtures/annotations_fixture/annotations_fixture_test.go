@@ -0,0 +1,22 @@
+package annotations_fixture_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+)
+
+// ginkgo:label=storage
+var _ = Describe("AnnotationsFixture", func() {
+	// survives restarts of the database
+	// ginkgo:label=slow,restart ginkgo:owner=team-storage
+	// ginkgo:severity=low
+	It("is annotated", func() {
+		fmt.Printf("is annotated:%v\n", CurrentGinkgoTestDescription().Labels)
+	}, Label("db"))
+
+	// the flaky runs are listed at https://ci.example.com/search?q=ginkgo:flaky=true
+	It("is not annotated", func() {
+		fmt.Printf("is not annotated:%v\n", CurrentGinkgoTestDescription().Labels)
+	})
+})
@@ -0,0 +1,13 @@
+package before_node_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestBeforeNodeFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "BeforeNodeFixture Suite")
+}
@@ -0,0 +1,50 @@
+package before_node_fixture_test
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = BeforeNode(func(node types.NodeInfo) {
+	if node.HasLabel("slow") {
+		SetDefaultEventuallyTimeout(time.Second)
+	} else {
+		SetDefaultEventuallyTimeout(50 * time.Millisecond)
+	}
+	fmt.Printf("before %s of %s\n", node.ComponentType, strings.Join(node.ComponentTexts, " "))
+})
+
+var _ = BeforeNode(func(node types.NodeInfo) {
+	if node.HasLabel("broken") {
+		Fail("the hook failed")
+	}
+})
+
+var _ = Describe("eventually", func() {
+	var start time.Time
+
+	BeforeEach(func() {
+		start = time.Now()
+	})
+
+	It("waits long enough for slow specs", func() {
+		Eventually(func() bool {
+			return time.Since(start) > 200*time.Millisecond
+		}).Should(BeTrue())
+	}, Label("slow"))
+
+	It("gives up early on the other specs", func() {
+		Eventually(func() bool {
+			return time.Since(start) > 200*time.Millisecond
+		}).Should(BeTrue())
+	})
+
+	It("is failed by the hook", func() {
+		fmt.Println("never printed")
+	}, Label("broken"))
+})
@@ -0,0 +1,13 @@
+package bisect_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestBisectFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "BisectFixture Suite")
+}
@@ -0,0 +1,38 @@
+package bisect_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var cache = map[string]string{}
+
+var _ = Describe("cache", func() {
+	It("stores a user", func() {
+		Ω(cache).Should(BeEmpty())
+	})
+
+	It("stores a session", func() {
+		Ω(1 + 1).Should(Equal(2))
+	})
+
+	It("warms up", func() {
+		cache["user"] = "stale"
+	})
+
+	It("expires sessions", func() {
+		Ω("session").ShouldNot(BeEmpty())
+	})
+
+	It("counts its entries", func() {
+		Ω(len("entries")).Should(Equal(7))
+	})
+
+	It("misses a user it never stored", func() {
+		Ω(cache).ShouldNot(HaveKey("user"))
+	})
+
+	It("is always broken", func() {
+		Fail("broken")
+	})
+})
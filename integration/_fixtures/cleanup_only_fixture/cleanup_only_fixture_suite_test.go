@@ -0,0 +1,13 @@
+package cleanup_only_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestCleanupOnlyFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "CleanupOnlyFixture Suite")
+}
@@ -0,0 +1,29 @@
+package cleanup_only_fixture_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+)
+
+var _ = BeforeSuite(func() {
+	fmt.Println("creating the bucket")
+})
+
+var _ = AfterSuite(func() {
+	fmt.Println("deleting the bucket")
+})
+
+var _ = Describe("CleanupOnlyFixture", func() {
+	BeforeEach(func() {
+		fmt.Println("creating the object")
+	})
+
+	AfterEach(func() {
+		fmt.Println("deleting the object")
+	})
+
+	It("uploads the object", func() {
+		fmt.Println("uploading the object")
+	})
+})
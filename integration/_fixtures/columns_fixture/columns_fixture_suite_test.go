@@ -0,0 +1,13 @@
+package columns_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestColumnsFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "ColumnsFixture Suite")
+}
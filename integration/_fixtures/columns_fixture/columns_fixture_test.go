@@ -0,0 +1,11 @@
+package columns_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+)
+
+var _ = Describe("ColumnsFixture", func() {
+	It("fails with a long message", func() {
+		Fail("the quick brown fox jumps over the lazy dog and keeps running far beyond the edge of a narrow console")
+	})
+})
@@ -0,0 +1,19 @@
+package concurrency_fixture_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestConcurrencyFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "ConcurrencyFixture Suite")
+}
+
+var _ = AfterSuite(func() {
+	fmt.Printf("max concurrent specs: %d\n", maxRunning)
+})
@@ -0,0 +1,54 @@
+package concurrency_fixture_test
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var lock = &sync.Mutex{}
+var running, maxRunning int
+
+func track(body func()) func() {
+	return func() {
+		lock.Lock()
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		lock.Unlock()
+		defer func() {
+			lock.Lock()
+			running--
+			lock.Unlock()
+		}()
+		body()
+	}
+}
+
+func concurrentSpec(name string, fail bool) func() {
+	return track(func() {
+		fmt.Fprintln(GinkgoWriter, "output of "+name)
+		time.Sleep(300 * time.Millisecond)
+		Ω(CurrentGinkgoTestDescription().TestText).Should(Equal(name))
+		if fail {
+			Fail(name + " failed")
+		}
+	})
+}
+
+var _ = Describe("ConcurrencyFixture", func() {
+	It("A", concurrentSpec("A", false))
+	It("B", concurrentSpec("B", false))
+	It("C", concurrentSpec("C", true))
+	It("D", concurrentSpec("D", false))
+
+	It("runs alone", track(func() {
+		lock.Lock()
+		defer lock.Unlock()
+		Ω(running).Should(Equal(1))
+	}), Serial)
+})
@@ -0,0 +1,20 @@
+package configuration_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+var slowEnvironment bool
+var region = Flags().String("region", "us", "The region the system under test runs in.")
+
+func init() {
+	Flags().BoolVar(&slowEnvironment, "slowEnvironment", false, "If set, specs wait longer for the system under test.")
+}
+
+func TestConfigurationFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "ConfigurationFixture Suite", Label("integration"))
+}
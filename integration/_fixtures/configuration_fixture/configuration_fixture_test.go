@@ -0,0 +1,29 @@
+package configuration_fixture_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ConfigurationFixture", func() {
+	It("sees the configuration of the suite", func() {
+		ginkgoConfig, reporterConfig := GinkgoConfiguration()
+		fmt.Fprintf(GinkgoWriter, "seed=%d\n", ginkgoConfig.RandomSeed)
+		fmt.Fprintf(GinkgoWriter, "labels=%v\n", ginkgoConfig.SuiteLabels)
+		fmt.Fprintf(GinkgoWriter, "slowEnvironment=%s region=%s\n", ginkgoConfig.CustomFlags["slowEnvironment"], ginkgoConfig.CustomFlags["region"])
+		fmt.Fprintf(GinkgoWriter, "noColor=%t\n", reporterConfig.NoColor)
+		Ω(ginkgoConfig.CustomFlags["slowEnvironment"]).Should(Equal(fmt.Sprint(slowEnvironment)))
+	})
+
+	It("hands out copies", func() {
+		ginkgoConfig, _ := GinkgoConfiguration()
+		ginkgoConfig.SuiteLabels[0] = "changed"
+		ginkgoConfig.CustomFlags["region"] = "changed"
+
+		ginkgoConfig, _ = GinkgoConfiguration()
+		Ω(ginkgoConfig.SuiteLabels).Should(Equal([]string{"integration"}))
+		Ω(ginkgoConfig.CustomFlags["region"]).Should(Equal(*region))
+	})
+})
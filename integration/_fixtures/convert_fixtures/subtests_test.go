@@ -0,0 +1,33 @@
+package tmp
+
+import (
+	"testing"
+)
+
+func TestParsing(t *testing.T) {
+	t.Run("numbers", func(t *testing.T) {
+		if parse("1") != 1 {
+			t.Fail()
+		}
+	})
+	t.Run("signs", func(t *testing.T) {
+		t.Run("negative", func(st *testing.T) {
+			if parse("-1") != -1 {
+				st.Fail()
+			}
+		})
+	})
+}
+
+func TestRounding(t *testing.T) {
+	value := parse("1.5")
+	t.Run("up", func(t *testing.T) {
+		if value != 2 {
+			t.Fail()
+		}
+	})
+}
+
+func parse(s string) int {
+	return 0
+}
@@ -0,0 +1,35 @@
+package tmp
+
+import (
+	. "github.com/onsi/ginkgo"
+)
+
+var _ = Describe("Testing with Ginkgo", func() {
+	Describe("parsing", func() {
+		It("numbers", func() {
+			if parse("1") != 1 {
+				GinkgoT().Fail()
+			}
+		})
+		Describe("signs", func() {
+			It("negative", func() {
+				if parse("-1") != -1 {
+					GinkgoT().Fail()
+				}
+			})
+		})
+	})
+	It("rounding", func() {
+
+		value := parse("1.5")
+		By("up", func() {
+			if value != 2 {
+				GinkgoT().Fail()
+			}
+		})
+	})
+})
+
+func parse(s string) int {
+	return 0
+}
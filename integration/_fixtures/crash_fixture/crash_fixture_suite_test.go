@@ -0,0 +1,42 @@
+package crash_fixture_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/config"
+	"github.com/onsi/ginkgo/reporters"
+	"github.com/onsi/ginkgo/types"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestCrashFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecsWithDefaultAndCustomReporters(t, "CrashFixture Suite", []Reporter{crashingReporter{}})
+}
+
+//crashingReporter panics outside of the specs, as a buggy custom reporter would
+type crashingReporter struct{}
+
+func (crashingReporter) SpecSuiteWillBegin(config config.GinkgoConfigType, summary *types.SuiteSummary) {
+}
+
+func (crashingReporter) BeforeSuiteDidRun(setupSummary *types.SetupSummary) {
+}
+
+func (crashingReporter) AfterSuiteDidRun(setupSummary *types.SetupSummary) {
+}
+
+func (crashingReporter) SpecDidComplete(specSummary *types.SpecSummary) {
+}
+
+func (crashingReporter) SpecSuiteDidEnd(summary *types.SuiteSummary) {
+}
+
+func (crashingReporter) SpecWillRun(specSummary *types.SpecSummary) {
+	if strings.Contains(reporters.SpecFullText(specSummary), "crashes") {
+		panic("the reporter is broken")
+	}
+}
@@ -0,0 +1,19 @@
+package crash_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+)
+
+var _ = Describe("CrashFixture", func() {
+	It("passes first", func() {
+	})
+
+	It("passes second", func() {
+	})
+
+	It("crashes", func() {
+	})
+
+	It("never runs", func() {
+	})
+})
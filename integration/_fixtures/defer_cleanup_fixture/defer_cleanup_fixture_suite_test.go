@@ -0,0 +1,13 @@
+package defer_cleanup_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestDeferCleanupFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "DeferCleanupFixture Suite")
+}
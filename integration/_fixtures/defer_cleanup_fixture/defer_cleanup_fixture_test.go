@@ -0,0 +1,40 @@
+package defer_cleanup_fixture_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+)
+
+var _ = Describe("cleanups", func() {
+	BeforeEach(func() {
+		DeferCleanup(func() {
+			fmt.Println("outer cleanup")
+		})
+	})
+
+	AfterEach(func() {
+		fmt.Println("outer AfterEach")
+	})
+
+	Context("nested", func() {
+		AfterEach(func() {
+			fmt.Println("inner AfterEach")
+		})
+
+		It("runs them in order", func() {
+			DeferCleanup(func() {
+				fmt.Println("first it cleanup")
+			})
+			DeferCleanup(func() {
+				fmt.Println("second it cleanup")
+			})
+		})
+	})
+
+	It("panics in a cleanup", func() {
+		DeferCleanup(func() {
+			panic("cleanup panicked")
+		})
+	})
+})
@@ -0,0 +1,13 @@
+package depends_on_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestDependsOnFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "DependsOnFixture Suite")
+}
@@ -0,0 +1,26 @@
+package depends_on_fixture_test
+
+import (
+	"io/ioutil"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("accounts", func() {
+	It("logs into the account", func() {
+		Ω(ioutil.ReadFile("account.txt")).Should(Equal([]byte("created")))
+	}, DependsOn("accounts creates the account"))
+
+	It("restores the account", func() {
+		Fail("the account was never deleted")
+	}, DependsOn("accounts deletes the account"))
+
+	It("creates the account", func() {
+		Ω(ioutil.WriteFile("account.txt", []byte("created"), 0644)).Should(Succeed())
+	})
+
+	It("deletes the account", func() {
+		Fail("the account is locked")
+	})
+})
@@ -0,0 +1,13 @@
+package env_matrix_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestEnvMatrixFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "EnvMatrixFixture Suite")
+}
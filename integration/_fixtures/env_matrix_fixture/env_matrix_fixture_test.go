@@ -0,0 +1,23 @@
+package env_matrix_fixture_test
+
+import (
+	"fmt"
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("EnvMatrixFixture", func() {
+	It("runs against a database", func() {
+		fmt.Printf("running against %s\n", os.Getenv("DB"))
+		Ω(os.Getenv("DB")).ShouldNot(BeEmpty())
+	})
+
+	It("does not support mysql", func() {
+		Ω(os.Getenv("DB")).ShouldNot(Equal("mysql"))
+	})
+
+	It("is focused away by the flags of an entry", func() {
+	})
+})
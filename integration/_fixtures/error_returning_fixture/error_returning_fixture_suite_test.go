@@ -0,0 +1,13 @@
+package error_returning_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestErrorReturningFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "ErrorReturningFixture Suite")
+}
@@ -0,0 +1,33 @@
+package error_returning_fixture_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+)
+
+type Step func() error
+
+var _ = Describe("ErrorReturningFixture", func() {
+	It("returns an error from its It", func() error {
+		return errors.New("an It error")
+	})
+
+	It("returns nil from its It", func() error {
+		return nil
+	})
+
+	It("runs a named step", Step(func() error {
+		return errors.New("a Step error")
+	}))
+
+	Context("with a BeforeEach returning an error", func() {
+		BeforeEach(func() error {
+			return errors.New("a BeforeEach error")
+		})
+
+		It("never runs its body", func() {
+			panic("NEVER SEE THIS")
+		})
+	})
+})
@@ -0,0 +1,13 @@
+package expect_failure_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestExpectFailureFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "ExpectFailureFixture Suite")
+}
@@ -0,0 +1,23 @@
+package expect_failure_fixture_test
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ExpectFailureFixture", func() {
+	It("keeps the order of the items", func() {
+		Ω([]int{2, 1}).Should(Equal([]int{1, 2}))
+	}, ExpectFailure("ISSUE-123: the items are sorted"))
+
+	Context("with a known bug", func() {
+		It("is fixed when FIXED is set", func() {
+			Ω(os.Getenv("FIXED")).ShouldNot(BeEmpty())
+		})
+	}, ExpectFailure("ISSUE-456"))
+
+	It("passes", func() {
+	})
+})
@@ -0,0 +1,13 @@
+package failer_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestFailerFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "FailerFixture Suite")
+}
@@ -0,0 +1,52 @@
+package failer_fixture_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/failer"
+	"github.com/onsi/ginkgo/types"
+)
+
+func expectPositive(n int) {
+	if n <= 0 {
+		failer.Fail(fmt.Sprintf("expected %d to be positive", n), 1)
+	}
+}
+
+func attempt(assertion func()) (failure types.SpecFailure, state types.SpecState) {
+	defer func() {
+		if e := recover(); e != nil && e != GINKGO_PANIC {
+			panic(e)
+		}
+		failure, state = failer.Drain()
+	}()
+	assertion()
+	return
+}
+
+var _ = Describe("FailerFixture", func() {
+	It("fails through the failer", func() {
+		expectPositive(-1)
+	})
+
+	It("is skipped through the failer", func() {
+		failer.Skip("not today")
+	})
+
+	It("panics through the failer", func() {
+		failer.Panic("boom")
+	})
+
+	It("passes once a retried assertion passes", func() {
+		for n := -2; ; n++ {
+			failure, state := attempt(func() { expectPositive(n) })
+			if state == types.SpecStatePassed {
+				break
+			}
+			if failure.Message != fmt.Sprintf("expected %d to be positive", n) {
+				failer.Fail("unexpected failure: " + failure.Message)
+			}
+		}
+	})
+})
@@ -0,0 +1,13 @@
+package fixture_manager_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestFixtureManagerFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "FixtureManagerFixture Suite")
+}
@@ -0,0 +1,35 @@
+package fixture_manager_fixture_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type cassettes struct{}
+
+func (cassettes) SetUpSpec(report SpecReport) {
+	fmt.Printf("load %s.yaml for %q\n", report.FileName(), report.FullText())
+}
+
+func (cassettes) TearDownSpec(report SpecReport) {
+	fmt.Printf("save %s.yaml failed=%t\n", report.FileName(), report.Failed())
+}
+
+var _ = RegisterFixtureManager(cassettes{})
+
+var _ = Describe("Books", func() {
+	BeforeEach(func() {
+		fmt.Println("before each")
+	})
+
+	It("can be borrowed", func() {
+		Ω(CurrentSpecReport().ContainerHierarchyTexts).Should(Equal([]string{"Books"}))
+		Ω(CurrentSpecReport().LeafNodeText).Should(Equal("can be borrowed"))
+	})
+
+	It("can't be borrowed twice!", func() {
+		Fail("already borrowed")
+	})
+})
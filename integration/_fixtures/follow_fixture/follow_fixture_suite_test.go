@@ -0,0 +1,13 @@
+package follow_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestFollowFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "FollowFixture Suite")
+}
@@ -0,0 +1,18 @@
+package follow_fixture_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+)
+
+var _ = Describe("following", func() {
+	It("logs as it goes", func() {
+		fmt.Fprintln(GinkgoWriter, "connecting to the database")
+		fmt.Fprintln(GinkgoWriter, "connected")
+	})
+
+	It("logs something else", func() {
+		fmt.Fprintln(GinkgoWriter, "not followed")
+	})
+})
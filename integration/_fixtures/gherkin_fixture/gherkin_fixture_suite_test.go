@@ -0,0 +1,13 @@
+package gherkin_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestGherkinFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "GherkinFixture Suite")
+}
@@ -0,0 +1,36 @@
+package gherkin_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Account", func() {
+	var balance int
+
+	BeforeEach(func() {
+		balance = -1
+	})
+
+	It("withdraws cash", func() {
+		Given("an account with $100", func() {
+			balance = 100
+		})
+		WhenStep("the customer withdraws $20", func() {
+			balance -= 20
+		})
+		Then("the balance is $80", func() {
+			Ω(balance).Should(Equal(80))
+		})
+	}, Label("banking"))
+
+	It("refuses overdrafts", func() {
+		Given("an empty account", func() {
+			balance = 0
+		})
+		WhenStep("the customer withdraws $20", func() {
+			Ω(balance).Should(BeNumerically(">=", 20), "insufficient funds")
+		})
+		Then("the withdrawal is refused")
+	})
+})
@@ -0,0 +1,13 @@
+package glyphs_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestGlyphsFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "GlyphsFixture Suite")
+}
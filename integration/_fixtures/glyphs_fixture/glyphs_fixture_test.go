@@ -0,0 +1,17 @@
+package glyphs_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+)
+
+var _ = Describe("GlyphsFixture", func() {
+	It("passes", func() {
+	})
+
+	PIt("is pending", func() {
+	})
+
+	It("fails", func() {
+		Fail("failed")
+	})
+})
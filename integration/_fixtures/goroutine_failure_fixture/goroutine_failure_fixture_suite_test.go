@@ -0,0 +1,13 @@
+package goroutine_failure_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestGoroutineFailureFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "GoroutineFailureFixture Suite")
+}
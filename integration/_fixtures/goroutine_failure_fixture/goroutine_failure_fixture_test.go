@@ -0,0 +1,20 @@
+package goroutine_failure_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GoroutineFailureFixture", func() {
+	It("asserts in a goroutine that does not recover", func() {
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			Ω("background").Should(Equal("foreground"))
+		}()
+		<-done
+	})
+
+	It("runs the next spec", func() {
+	})
+})
@@ -0,0 +1,13 @@
+package grouped_failures_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestGroupedFailuresFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "GroupedFailuresFixture Suite")
+}
@@ -0,0 +1,31 @@
+package grouped_failures_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func expectConnected(attempt int) {
+	Ω(attempt).Should(BeZero(), "connection refused")
+}
+
+var _ = Describe("GroupedFailuresFixture", func() {
+	It("passes", func() {
+	})
+
+	It("fails to connect once", func() {
+		expectConnected(1)
+	})
+
+	It("fails to connect twice", func() {
+		expectConnected(2)
+	})
+
+	It("fails to connect thrice", func() {
+		expectConnected(3)
+	})
+
+	It("fails differently", func() {
+		Fail("something else")
+	})
+})
@@ -21,7 +21,9 @@ var _ = Describe("HangingSuite", func() {
 			fmt.Fprintln(GinkgoWriter, "Almost there...")
 		})
 
-		It("should hang out for a while", func() {
+		It("should hang out for a while", func(ctx SpecContext) {
+			By("hanging out")
+			ctx.AttachProgressReporter(func() string { return "still hanging out" })
 			fmt.Fprintln(GinkgoWriter, "Hanging Out")
 			fmt.Println("Sleeping...")
 			time.Sleep(time.Hour)
@@ -0,0 +1,13 @@
+package helper_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestHelperFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "HelperFixture Suite")
+}
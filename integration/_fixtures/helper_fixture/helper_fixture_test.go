@@ -0,0 +1,33 @@
+package helper_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func expectPositive(n int) {
+	GinkgoHelper()
+	Ω(n).Should(BeNumerically(">", 0))
+}
+
+func expectAllPositive(ns ...int) {
+	GinkgoHelper()
+	for _, n := range ns {
+		expectPositive(n)
+	}
+}
+
+func failIn(message string) {
+	GinkgoHelper()
+	Fail(message)
+}
+
+var _ = Describe("helpers", func() {
+	It("reports gomega failures at the caller of the helpers", func() {
+		expectAllPositive(1, -1)
+	})
+
+	It("reports Fail at the caller of the helper", func() {
+		failIn("failed in a helper")
+	})
+})
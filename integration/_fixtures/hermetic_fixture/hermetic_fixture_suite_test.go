@@ -0,0 +1,13 @@
+package hermetic_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestHermeticFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "HermeticFixture Suite")
+}
@@ -0,0 +1,58 @@
+package hermetic_fixture_test
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+//listen listens on the loopback interface for long enough for -hermetic to notice
+func listen() {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	Ω(err).ShouldNot(HaveOccurred())
+	time.Sleep(50 * time.Millisecond)
+	listener.Close()
+}
+
+//write writes the file at path, keeping it open for long enough for -hermetic to notice
+func write(path string) {
+	f, err := os.Create(path)
+	Ω(err).ShouldNot(HaveOccurred())
+	time.Sleep(50 * time.Millisecond)
+	f.Close()
+	os.Remove(path)
+}
+
+var _ = Describe("HermeticFixture", func() {
+	It("computes", func() {
+		Ω(1 + 1).Should(Equal(2))
+	})
+
+	It("writes to the temporary directory", func() {
+		write(filepath.Join(os.TempDir(), "hermetic_fixture.txt"))
+	})
+
+	It("writes to the package directory", func() {
+		write("escaped.txt")
+	})
+
+	It("listens", listen)
+
+	Context("with an allowlist", func() {
+		It("listens", listen)
+
+		It("writes to the package directory", func() {
+			write("allowed.txt")
+		}, HermeticAllow("./"))
+	}, HermeticAllow("127.0.0.1"))
+
+	It("reads", func() {
+		_, err := ioutil.ReadFile("hermetic_fixture_test.go")
+		Ω(err).ShouldNot(HaveOccurred())
+	})
+})
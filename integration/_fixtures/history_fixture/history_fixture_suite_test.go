@@ -0,0 +1,13 @@
+package history_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestHistoryFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "HistoryFixture Suite")
+}
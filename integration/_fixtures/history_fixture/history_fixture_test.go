@@ -0,0 +1,18 @@
+package history_fixture_test
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HistoryFixture", func() {
+	It("passes", func() {
+	})
+
+	It("flips", func() {
+		_, err := os.Stat("fail")
+		Ω(os.IsNotExist(err)).Should(BeTrue(), "the fail file is present")
+	})
+})
@@ -0,0 +1,13 @@
+package interleaved_output_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestInterleavedOutputFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "InterleavedOutputFixture Suite")
+}
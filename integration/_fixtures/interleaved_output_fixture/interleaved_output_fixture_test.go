@@ -0,0 +1,24 @@
+package interleaved_output_fixture_test
+
+import (
+	"fmt"
+	"os"
+
+	. "github.com/onsi/ginkgo"
+)
+
+var _ = Describe("interleaved output", func() {
+	It("fails after writing to both", func() {
+		fmt.Println("stdout 1")
+		fmt.Fprintln(GinkgoWriter, "writer 1")
+		fmt.Fprintln(os.Stderr, "stderr 1")
+		fmt.Fprintln(GinkgoWriter, "writer 2")
+		fmt.Println("stdout 2")
+		Fail("boom")
+	})
+
+	It("passes after writing to both", func() {
+		fmt.Println("passing stdout")
+		fmt.Fprintln(GinkgoWriter, "passing writer")
+	})
+})
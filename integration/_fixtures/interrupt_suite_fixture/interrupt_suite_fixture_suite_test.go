@@ -0,0 +1,16 @@
+package interrupt_suite_fixture_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestInterruptSuiteFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	passed := RunSpecs(t, "InterruptSuiteFixture Suite")
+	fmt.Printf("RunSpecs returned %t\n", passed)
+}
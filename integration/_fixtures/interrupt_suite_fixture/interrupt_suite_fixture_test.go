@@ -0,0 +1,22 @@
+package interrupt_suite_fixture_test
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/types"
+)
+
+var _ = AfterSuite(func() {
+	fmt.Println("Heading Out After Suite")
+})
+
+var _ = Describe("InterruptSuiteFixture", func() {
+	It("should be interrupted by the program running it", func() {
+		time.AfterFunc(500*time.Millisecond, func() {
+			InterruptSuite(types.InterruptCauseTimeout, "The validation window closed.")
+		})
+		time.Sleep(time.Hour)
+	})
+})
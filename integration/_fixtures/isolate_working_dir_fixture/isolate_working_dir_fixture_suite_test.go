@@ -0,0 +1,13 @@
+package isolate_working_dir_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestIsolateWorkingDirFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "IsolateWorkingDirFixture Suite")
+}
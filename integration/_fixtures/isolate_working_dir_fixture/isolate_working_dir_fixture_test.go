@@ -0,0 +1,30 @@
+package isolate_working_dir_fixture_test
+
+import (
+	"io/ioutil"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("isolated specs", func() {
+	BeforeEach(func() {
+		Ω("isolated.txt").ShouldNot(BeAnExistingFile())
+		Ω(ioutil.WriteFile("isolated.txt", []byte("isolated"), 0644)).Should(Succeed())
+	})
+
+	It("writes a file", func() {
+		Ω("isolated.txt").Should(BeAnExistingFile())
+	})
+
+	It("writes the file again", func() {
+		Ω("isolated.txt").Should(BeAnExistingFile())
+	})
+}, IsolateWorkingDir)
+
+var _ = Describe("other specs", func() {
+	It("run in the package directory", func() {
+		Ω("isolate_working_dir_fixture_suite_test.go").Should(BeAnExistingFile())
+		Ω("isolated.txt").ShouldNot(BeAnExistingFile())
+	})
+})
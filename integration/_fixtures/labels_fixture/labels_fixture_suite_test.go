@@ -0,0 +1,13 @@
+package labels_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestLabelsFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "LabelsFixture Suite")
+}
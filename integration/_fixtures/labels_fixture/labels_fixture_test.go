@@ -0,0 +1,32 @@
+package labels_fixture_test
+
+import (
+	"fmt"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LabelsFixture", func() {
+	AfterEach(func() {
+		description := CurrentGinkgoTestDescription()
+		fmt.Printf("%s:[%s]\n", description.TestText, strings.Join(description.Labels, ","))
+	})
+
+	Context("with labeled containers", func() {
+		It("is fast", func() {
+			Ω(true).Should(BeTrue())
+		}, Label("fast"))
+
+		It("is slow", func() {
+			Ω(true).Should(BeTrue())
+		}, Label("slow", "storage"))
+	}, Label("storage"))
+
+	It("has no labels", func() {
+		Ω(true).Should(BeTrue())
+	})
+
+	PIt("is pending", func() {}, Label("fast"))
+})
@@ -0,0 +1,13 @@
+package late_output_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestLateOutputFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "LateOutputFixture Suite")
+}
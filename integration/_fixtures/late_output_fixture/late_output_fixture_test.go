@@ -0,0 +1,26 @@
+package late_output_fixture_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+)
+
+var release = make(chan struct{})
+var done = make(chan struct{})
+
+var _ = Describe("LateOutputFixture", func() {
+	It("leaks a goroutine", func() {
+		go func() {
+			<-release
+			fmt.Fprintln(GinkgoWriter, "written too late")
+			close(done)
+		}()
+	})
+
+	It("fails afterwards", func() {
+		close(release)
+		<-done
+		Fail("showing the output")
+	})
+})
@@ -0,0 +1,13 @@
+package logger_adapters_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestLoggerAdaptersFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "LoggerAdaptersFixture Suite")
+}
@@ -0,0 +1,35 @@
+package logger_adapters_fixture_test
+
+import (
+	"log"
+	"log/slog"
+
+	. "github.com/onsi/ginkgo"
+)
+
+var (
+	infoLogger  *log.Logger
+	debugLogger *log.Logger
+	slogger     *slog.Logger
+)
+
+var _ = BeforeSuite(func() {
+	SetGinkgoLogLevel(LogLevelInfo)
+	infoLogger = log.New(GinkgoWriterAt(LogLevelInfo), "", 0)
+	debugLogger = log.New(GinkgoWriterAt(LogLevelDebug), "", 0)
+	slogger = slog.New(GinkgoSlogHandler(nil))
+})
+
+var _ = Describe("LoggerAdaptersFixture", func() {
+	It("logs and fails", func() {
+		infoLogger.Println("an info line")
+		debugLogger.Println("a debug line")
+		slogger.Info("a slog info record")
+		slogger.Debug("a slog debug record")
+		Fail("failed after logging")
+	})
+
+	It("logs and passes", func() {
+		infoLogger.Println("a line of a passing spec")
+	})
+})
@@ -0,0 +1,13 @@
+package node_timeout_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestNodeTimeoutFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "NodeTimeoutFixture Suite")
+}
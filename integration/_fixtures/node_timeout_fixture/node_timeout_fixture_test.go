@@ -0,0 +1,26 @@
+package node_timeout_fixture_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+)
+
+func waitForever() {
+	select {}
+}
+
+var _ = Describe("a hung setup", func() {
+	BeforeEach(func() {
+		waitForever()
+	})
+
+	It("never runs", func() {
+	})
+})
+
+var _ = Describe("a quick spec", func() {
+	It("passes", func() {
+		time.Sleep(10 * time.Millisecond)
+	})
+})
@@ -0,0 +1,13 @@
+package on_failure_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestOnFailureFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "OnFailureFixture Suite")
+}
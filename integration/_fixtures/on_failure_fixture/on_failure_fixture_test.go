@@ -0,0 +1,33 @@
+package on_failure_fixture_test
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+)
+
+var _ = OnFailure(func(report SpecReport) {
+	fmt.Printf("gathering diagnostics for %s: %s\n", report.FullText(), report.Failure.Message)
+	AddReportEntry("diagnostics", "the pods were not ready")
+})
+
+var _ = Describe("OnFailureFixture", func() {
+	AfterEach(func() {
+		fmt.Println("cleaning up")
+	})
+
+	It("passes", func() {
+	})
+
+	It("fails", func() {
+		Fail("boom")
+	})
+
+	It("is not reached with -failFast", func() {
+	})
+
+	It("hangs", func() {
+		time.Sleep(time.Minute)
+	})
+})
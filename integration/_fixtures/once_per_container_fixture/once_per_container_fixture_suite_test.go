@@ -0,0 +1,13 @@
+package once_per_container_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestOncePerContainerFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "OncePerContainerFixture Suite")
+}
@@ -0,0 +1,46 @@
+package once_per_container_fixture_test
+
+import (
+	"fmt"
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/config"
+	. "github.com/onsi/gomega"
+)
+
+func record(event string) {
+	f, err := os.OpenFile("once_per_container.log", os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	Ω(err).ShouldNot(HaveOccurred())
+	defer f.Close()
+	fmt.Fprintf(f, "%s %d\n", event, config.GinkgoConfig.ParallelNode)
+}
+
+var _ = Describe("OncePerContainerFixture", func() {
+	setups := 0
+
+	BeforeEach(func() {
+		setups++
+		record("setup")
+	})
+
+	AfterEach(func() {
+		record("teardown")
+	})
+
+	It("A", func() {
+		Ω(setups).Should(Equal(1))
+	})
+
+	It("B", func() {
+		Ω(setups).Should(Equal(1))
+	})
+
+	It("C", func() {
+		Ω(setups).Should(Equal(1))
+	})
+
+	It("D", func() {
+		Ω(setups).Should(Equal(1))
+	})
+}, OncePerContainer)
@@ -0,0 +1,13 @@
+package owners_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestOwnersFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "OwnersFixture Suite")
+}
@@ -0,0 +1,18 @@
+package owners_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+)
+
+var _ = Describe("billing", func() {
+	It("charges the card", func() {
+		Fail("card declined")
+	})
+
+	It("searches the invoices", func() {
+		Fail("index missing")
+	}, Owner("team-search"))
+
+	It("refunds the card", func() {
+	})
+}, Owner("team-billing"))
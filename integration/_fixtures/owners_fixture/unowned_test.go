@@ -0,0 +1,11 @@
+package owners_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+)
+
+var _ = Describe("reporting", func() {
+	It("renders the dashboard", func() {
+		Fail("template missing")
+	})
+})
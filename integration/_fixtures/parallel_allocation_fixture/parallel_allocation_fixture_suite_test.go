@@ -0,0 +1,13 @@
+package parallel_allocation_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestParallelAllocationFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "ParallelAllocationFixture Suite")
+}
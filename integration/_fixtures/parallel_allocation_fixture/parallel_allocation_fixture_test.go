@@ -0,0 +1,35 @@
+package parallel_allocation_fixture_test
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func record(port int, namespace string) {
+	f, err := os.OpenFile("allocations.log", os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	Ω(err).ShouldNot(HaveOccurred())
+	defer f.Close()
+	fmt.Fprintf(f, "%d %s\n", port, namespace)
+}
+
+func allocate() {
+	port := GinkgoParallelPort()
+	listener, err := net.Listen("tcp", "127.0.0.1:"+strconv.Itoa(port))
+	Ω(err).ShouldNot(HaveOccurred())
+	time.Sleep(50 * time.Millisecond)
+	record(port, GinkgoUniqueNamespace("books"))
+	listener.Close()
+}
+
+var _ = Describe("ParallelAllocationFixture", func() {
+	It("A", allocate)
+	It("B", allocate)
+	It("C", allocate)
+	It("D", allocate)
+})
@@ -0,0 +1,13 @@
+package parallel_deterministic_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestParallelDeterministicFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "ParallelDeterministicFixture Suite")
+}
@@ -0,0 +1,22 @@
+package parallel_deterministic_fixture_test
+
+import (
+	"fmt"
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func record() {
+	f, err := os.OpenFile("assignments.log", os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	Ω(err).ShouldNot(HaveOccurred())
+	defer f.Close()
+	fmt.Fprintf(f, "%d %s\n", GinkgoParallelNode(), CurrentGinkgoTestDescription().TestText)
+}
+
+var _ = Describe("ParallelDeterministicFixture", func() {
+	for i := 0; i < 12; i++ {
+		It(fmt.Sprintf("spec-%d", i), record)
+	}
+})
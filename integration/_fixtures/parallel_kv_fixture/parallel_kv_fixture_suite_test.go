@@ -0,0 +1,13 @@
+package parallel_kv_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestParallelKVFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "ParallelKVFixture Suite")
+}
@@ -0,0 +1,33 @@
+package parallel_kv_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type service struct {
+	URL   string
+	Token string
+}
+
+var shared service
+
+var _ = SynchronizedBeforeSuite(func() []byte {
+	ParallelKV().Set("service", service{URL: "http://localhost:8080", Token: "s3cr3t"})
+	return nil
+}, func([]byte) {
+	ParallelKV().WaitFor("service", &shared, 5)
+})
+
+var _ = Describe("ParallelKVFixture", func() {
+	It("A", func() {
+		Ω(shared).Should(Equal(service{URL: "http://localhost:8080", Token: "s3cr3t"}))
+	})
+
+	It("B", func() {
+		Ω(shared.Token).Should(Equal("s3cr3t"))
+
+		var missing string
+		Ω(ParallelKV().Get("missing", &missing)).Should(BeFalse())
+	})
+})
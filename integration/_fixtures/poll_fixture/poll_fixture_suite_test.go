@@ -0,0 +1,13 @@
+package poll_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestPollFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "PollFixture Suite")
+}
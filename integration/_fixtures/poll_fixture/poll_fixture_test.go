@@ -0,0 +1,25 @@
+package poll_fixture_test
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+)
+
+var _ = Describe("PollFixture", func() {
+	attempts := 0
+
+	It("eventually succeeds", Poll(10*time.Millisecond, time.Second), func() error {
+		attempts++
+		if attempts < 3 {
+			return fmt.Errorf("not yet: %d", attempts)
+		}
+		return nil
+	})
+
+	It("never succeeds", Poll(10*time.Millisecond, 100*time.Millisecond), func() error {
+		return errors.New("still down")
+	})
+})
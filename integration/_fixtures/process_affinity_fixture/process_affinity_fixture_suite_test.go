@@ -0,0 +1,13 @@
+package process_affinity_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestProcessAffinityFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "ProcessAffinityFixture Suite")
+}
@@ -0,0 +1,30 @@
+package process_affinity_fixture_test
+
+import (
+	"fmt"
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func record() {
+	f, err := os.OpenFile("processes.log", os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	Ω(err).ShouldNot(HaveOccurred())
+	defer f.Close()
+	fmt.Fprintf(f, "%s %d\n", CurrentGinkgoTestDescription().TestText, GinkgoParallelNode())
+}
+
+var _ = Describe("ProcessAffinityFixture", func() {
+	Describe("pinned", func() {
+		It("pinned-A", record)
+		It("pinned-B", record)
+		It("pinned-C", record)
+		It("pinned-D", record)
+	}, ProcessAffinity(2))
+
+	It("free-A", record)
+	It("free-B", record)
+	It("free-C", record)
+	It("free-D", record)
+})
@@ -0,0 +1,27 @@
+package progress_address_fixture_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/config"
+	"github.com/onsi/ginkgo/reporters"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ProgressAddressFixture", func() {
+	It("passes", func() {
+	})
+
+	It("can poll the progress of the suite run", func() {
+		resp, err := http.Get("http://" + config.DefaultReporterConfig.ProgressAddress + "/progress")
+		Ω(err).ShouldNot(HaveOccurred())
+		defer resp.Body.Close()
+
+		var progress reporters.Progress
+		Ω(json.NewDecoder(resp.Body).Decode(&progress)).Should(Succeed())
+		fmt.Printf("polled %q: %d spec(s) to run, finished: %t\n", progress.SuiteDescription, progress.SpecsThatWillBeRun, progress.Finished)
+	})
+})
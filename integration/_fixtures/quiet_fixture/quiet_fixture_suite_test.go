@@ -0,0 +1,13 @@
+package quiet_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestQuietFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "QuietFixture Suite")
+}
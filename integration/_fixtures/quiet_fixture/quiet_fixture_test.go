@@ -0,0 +1,21 @@
+package quiet_fixture_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+)
+
+var _ = Describe("QuietFixture", func() {
+	for i := 0; i < 5; i++ {
+		It(fmt.Sprintf("passes %d", i), func() {
+		})
+	}
+
+	PIt("is pending", func() {
+	})
+
+	It("fails", func() {
+		Fail("quietly failed")
+	})
+})
@@ -0,0 +1,13 @@
+package random_seed_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestRandomSeedFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "RandomSeedFixture Suite")
+}
@@ -0,0 +1,21 @@
+package random_seed_fixture_test
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+)
+
+var _ = Describe("RandomSeedFixture", func() {
+	It("draws from math/rand", func() {
+		fmt.Printf("global: %d\n", rand.Int63())
+	})
+
+	It("draws from a time-based source", func() {
+		r := rand.New(GinkgoRandSource(time.Now().UnixNano()))
+		fmt.Printf("time-based: %d\n", r.Int63())
+		Fail("failing to show the sources of nondeterminism")
+	})
+})
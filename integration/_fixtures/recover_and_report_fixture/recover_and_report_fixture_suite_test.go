@@ -0,0 +1,13 @@
+package recover_and_report_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestRecoverAndReportFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "RecoverAndReportFixture Suite")
+}
@@ -0,0 +1,34 @@
+package recover_and_report_fixture_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RecoverAndReportFixture", func() {
+	It("asserts in a goroutine that reports its failures", func() {
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			defer GinkgoRecoverAndReport()
+			Ω("background").Should(Equal("foreground"))
+		}()
+		<-done
+		fmt.Fprintln(GinkgoWriter, "the spec carried on")
+	})
+
+	It("panics in a goroutine that reports its failures", func() {
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			defer GinkgoRecoverAndReport()
+			panic("kaboom")
+		}()
+		<-done
+	})
+
+	It("runs the next spec", func() {
+	})
+})
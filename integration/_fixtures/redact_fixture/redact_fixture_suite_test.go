@@ -0,0 +1,13 @@
+package redact_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestRedactFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "RedactFixture Suite")
+}
@@ -0,0 +1,19 @@
+package redact_fixture_test
+
+import (
+	"fmt"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+)
+
+var _ = RedactOutput(func(output string) string {
+	return strings.ReplaceAll(output, "tok-1234", "[TOKEN]")
+})
+
+var _ = Describe("redaction", func() {
+	It("logs secrets", func() {
+		fmt.Fprintln(GinkgoWriter, "token: tok-1234, password: hunter2")
+		Fail("failed to log in")
+	})
+})
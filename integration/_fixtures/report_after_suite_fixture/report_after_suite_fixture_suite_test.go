@@ -0,0 +1,13 @@
+package report_after_suite_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestReportAfterSuiteFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "ReportAfterSuiteFixture Suite")
+}
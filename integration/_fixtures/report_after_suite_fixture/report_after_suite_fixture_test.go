@@ -0,0 +1,39 @@
+package report_after_suite_fixture_test
+
+import (
+	"fmt"
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/ginkgo/types"
+)
+
+//record appends what the ReportAfterSuite named text saw of the report to reports.log
+func record(text string, report types.AggregatedReport) {
+	f, err := os.OpenFile("reports.log", os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	Ω(err).ShouldNot(HaveOccurred())
+	defer f.Close()
+	fmt.Fprintf(f, "%s node=%d specs=%d passed=%d\n", text, GinkgoParallelNode(), len(report.SpecSummaries), report.Passed)
+}
+
+var _ = Describe("ReportAfterSuiteFixture", func() {
+	for i := 0; i < 4; i++ {
+		It(fmt.Sprintf("spec-%d", i), func() {})
+	}
+})
+
+var _ = ReportAfterSuite("uploads the results", func(report types.AggregatedReport) {
+	record("uploads the results", report)
+	if os.Getenv("FAIL_UPLOAD") != "" {
+		Fail("the dashboard is down")
+	}
+}, ReportOrder(1))
+
+var _ = ReportAfterSuite("writes the summary", func(report types.AggregatedReport) {
+	record("writes the summary", report)
+})
+
+var _ = ReportAfterSuite("checks the results", func(report types.AggregatedReport) {
+	record("checks the results", report)
+}, ReportOrder(-1))
@@ -0,0 +1,13 @@
+package report_entry_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestReportEntryFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "ReportEntryFixture Suite")
+}
@@ -0,0 +1,27 @@
+package report_entry_fixture_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+)
+
+type latency struct {
+	p50, p99 int
+}
+
+func (l latency) ConsoleString(color bool) string {
+	return fmt.Sprintf("p50: %dms\np99: %dms", l.p50, l.p99)
+}
+
+func (l latency) JSONValue() interface{} {
+	return map[string]int{"p50_ms": l.p50, "p99_ms": l.p99}
+}
+
+var _ = Describe("ReportEntryFixture", func() {
+	It("serves the dashboard quickly", func() {
+		AddReportEntry("latency", latency{p50: 12, p99: 480})
+		AddReportEntry("retries", 3)
+		Fail("too slow")
+	})
+})
@@ -0,0 +1,13 @@
+package requires_feature_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestRequiresFeatureFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "RequiresFeatureFixture Suite")
+}
@@ -0,0 +1,15 @@
+package requires_feature_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+)
+
+var _ = Describe("RequiresFeatureFixture", func() {
+	It("runs anywhere", func() {})
+
+	Context("on GPUs", func() {
+		It("schedules the kernels", func() {})
+
+		It("schedules the kernels over IPv6", func() {}, RequiresFeature("ipv6"))
+	}, RequiresFeature("gpu"))
+})
@@ -0,0 +1,13 @@
+package rerun_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestRerunFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "RerunFixture Suite")
+}
@@ -0,0 +1,20 @@
+package rerun_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("cart", func() {
+	It("adds an item", func() {
+		Ω(1).Should(Equal(1))
+	})
+
+	It("can't add a missing item", func() {
+		Ω(1).Should(Equal(2))
+	})
+
+	It("empties (fast)", func() {
+		Ω("full").Should(BeEmpty())
+	})
+})
@@ -0,0 +1,13 @@
+package resource_lock_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestResourceLockFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "ResourceLockFixture Suite")
+}
@@ -0,0 +1,36 @@
+package resource_lock_fixture_test
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/config"
+	. "github.com/onsi/gomega"
+)
+
+func record(event string) {
+	f, err := os.OpenFile("resource_lock.log", os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	Ω(err).ShouldNot(HaveOccurred())
+	defer f.Close()
+	fmt.Fprintf(f, "%s %d\n", event, config.GinkgoConfig.ParallelNode)
+}
+
+func useGPU() {
+	record("start")
+	time.Sleep(100 * time.Millisecond)
+	record("end")
+}
+
+var _ = Describe("ResourceLockFixture", func() {
+	Describe("requiring the GPU", func() {
+		It("A", useGPU)
+		It("B", useGPU)
+		It("C", useGPU)
+	}, RequiresResource("gpu"))
+
+	It("D", func() {
+		WithLock("gpu", useGPU)
+	})
+})
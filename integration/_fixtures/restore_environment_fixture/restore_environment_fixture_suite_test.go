@@ -0,0 +1,13 @@
+package restore_environment_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestRestoreEnvironmentFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "RestoreEnvironmentFixture Suite")
+}
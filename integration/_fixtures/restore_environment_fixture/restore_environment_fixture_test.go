@@ -0,0 +1,24 @@
+package restore_environment_fixture_test
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("the environment", func() {
+	It("is changed on purpose", func() {
+		GinkgoSetenv("FIXTURE_ON_PURPOSE", "on purpose")
+		Ω(os.Getenv("FIXTURE_ON_PURPOSE")).Should(Equal("on purpose"))
+	})
+
+	It("is leaked", func() {
+		os.Setenv("FIXTURE_LEAKED", "leaked")
+	})
+
+	It("is left clean", func() {
+		Ω(os.LookupEnv("FIXTURE_ON_PURPOSE")).Should(BeZero())
+		Ω(os.LookupEnv("FIXTURE_LEAKED")).Should(BeZero())
+	})
+})
@@ -0,0 +1,13 @@
+package retry_on_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestRetryOnFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "RetryOnFixture Suite")
+}
@@ -0,0 +1,36 @@
+package retry_on_fixture_test
+
+import (
+	"fmt"
+	"regexp"
+
+	. "github.com/onsi/ginkgo"
+)
+
+var attempts = map[string]int{}
+
+//failsOnce fails the first attempt at the spec with message, and passes the others
+func failsOnce(message string) func() {
+	return func() {
+		text := CurrentGinkgoTestDescription().TestText
+		attempts[text]++
+		fmt.Printf("%s attempt %d\n", text, attempts[text])
+		if attempts[text] == 1 {
+			Fail(message)
+		}
+	}
+}
+
+var _ = Describe("RetryOnFixture", func() {
+	Context("with transient failures", func() {
+		It("dials the server", failsOnce("dial tcp: connection refused"))
+
+		It("lists the buckets", failsOnce("unexpected status 503"))
+	}, RetryOn("connection refused", regexp.MustCompile(`status 5\d\d`)))
+
+	It("compares the values", failsOnce("Expected 1 to equal 2"), RetryOn(func(message string) bool {
+		return message == "timeout"
+	}))
+
+	It("follows -retryOn", failsOnce("i/o timeout"))
+})
@@ -0,0 +1,13 @@
+package runtime_budget_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestRuntimeBudgetFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "RuntimeBudgetFixture Suite")
+}
@@ -0,0 +1,20 @@
+package runtime_budget_fixture_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+)
+
+var _ = Describe("RuntimeBudgetFixture", func() {
+	It("is quick", func() {
+	}, Label("unit"))
+
+	It("dawdles", func() {
+		time.Sleep(200 * time.Millisecond)
+	}, Label("unit"))
+
+	It("takes its time", func() {
+		time.Sleep(200 * time.Millisecond)
+	}, Label("integration"))
+})
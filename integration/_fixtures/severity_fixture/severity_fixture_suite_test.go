@@ -0,0 +1,13 @@
+package severity_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestSeverityFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "SeverityFixture Suite")
+}
@@ -0,0 +1,14 @@
+package severity_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+)
+
+var _ = Describe("checkout", func() {
+	It("charges the card", func() {
+	})
+
+	It("suggests related products", func() {
+		Fail("no suggestions")
+	}, Low)
+}, Critical)
@@ -0,0 +1,13 @@
+package shared_fixture_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestSharedFixtureFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "SharedFixtureFixture Suite")
+}
@@ -0,0 +1,44 @@
+package shared_fixture_fixture_test
+
+import (
+	"fmt"
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func record(event string) {
+	f, err := os.OpenFile("shared_fixture.log", os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	Ω(err).ShouldNot(HaveOccurred())
+	defer f.Close()
+	fmt.Fprintln(f, event)
+}
+
+var setups = 0
+
+var db = SharedFixture("db", func() interface{} {
+	setups++
+	record("setup")
+	return setups
+}, func(value interface{}) {
+	record("teardown")
+})
+
+var _ = Describe("SharedFixtureFixture", func() {
+	Describe("using the fixture", func() {
+		It("A", func() {
+			record("A")
+			Ω(db.Get()).Should(Equal(1))
+		})
+
+		It("B", func() {
+			record("B")
+			Ω(db.Get()).Should(Equal(1))
+		})
+	}, db)
+
+	It("C", func() {
+		record("C")
+	})
+})
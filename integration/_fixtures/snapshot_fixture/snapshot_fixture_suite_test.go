@@ -0,0 +1,13 @@
+package snapshot_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestSnapshotFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "SnapshotFixture Suite")
+}
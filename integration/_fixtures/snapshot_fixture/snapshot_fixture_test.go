@@ -0,0 +1,20 @@
+package snapshot_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+)
+
+type invoice struct {
+	Customer string
+	Total    int
+}
+
+var _ = Describe("SnapshotFixture", func() {
+	It("renders the invoice", func() {
+		MatchSnapshot("invoice/text", "Invoice for Ada\nTotal: 42\n")
+	})
+
+	It("encodes the invoice", func() {
+		MatchSnapshot("invoice/json", invoice{Customer: "Ada", Total: 42})
+	})
+})
@@ -0,0 +1,13 @@
+package softly_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestSoftlyFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "SoftlyFixture Suite")
+}
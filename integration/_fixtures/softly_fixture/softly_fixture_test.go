@@ -0,0 +1,23 @@
+package softly_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("soft assertions", func() {
+	It("collects the soft failures and fails once", func() {
+		Softly(func(s *SoftAssertions) {
+			s.Fail("the name is missing")
+			g := NewWithT(s)
+			g.Expect(-1).To(BeNumerically(">", 0))
+			g.Expect(2).To(Equal(2))
+		})
+	})
+
+	It("passes when no soft assertion fails", func() {
+		Softly(func(s *SoftAssertions) {
+			NewWithT(s).Expect(true).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,12 @@
+package spec_coverage_fixture
+
+func Add(a, b int) int {
+	return a + b
+}
+
+func Negate(a int) int {
+	return -a
+}
+
+func Unused() {
+}
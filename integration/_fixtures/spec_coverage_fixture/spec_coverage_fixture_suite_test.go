@@ -0,0 +1,13 @@
+package spec_coverage_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestSpecCoverageFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "SpecCoverageFixture Suite")
+}
@@ -0,0 +1,19 @@
+package spec_coverage_fixture
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SpecCoverageFixture", func() {
+	It("adds", func() {
+		Ω(Add(1, 2)).Should(Equal(3))
+	})
+
+	It("negates", func() {
+		Ω(Negate(1)).Should(Equal(-1))
+	})
+
+	PIt("is pending", func() {
+	})
+})
@@ -0,0 +1,13 @@
+package spec_lease_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestSpecLeaseFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "SpecLeaseFixture Suite")
+}
@@ -0,0 +1,33 @@
+package spec_lease_fixture_test
+
+import (
+	"io/ioutil"
+	"os"
+	"strconv"
+	"syscall"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("leased specs", func() {
+	It("hangs its node the first time it runs", func() {
+		data, err := ioutil.ReadFile("hung.pid")
+		if os.IsNotExist(err) {
+			//stops the whole process, heartbeats included, until the attempt that takes over resumes it
+			Ω(ioutil.WriteFile("hung.pid", []byte(strconv.Itoa(os.Getpid())), 0644)).Should(Succeed())
+			syscall.Kill(os.Getpid(), syscall.SIGSTOP)
+			return
+		}
+		pid, err := strconv.Atoi(string(data))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(syscall.Kill(pid, syscall.SIGCONT)).Should(Succeed())
+	})
+
+	for _, text := range []string{"A", "B", "C"} {
+		It("runs "+text, func() {
+			time.Sleep(100 * time.Millisecond)
+		})
+	}
+})
@@ -0,0 +1,13 @@
+package strict_pending_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestStrictPendingFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "StrictPendingFixture Suite")
+}
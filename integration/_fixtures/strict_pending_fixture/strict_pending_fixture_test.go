@@ -0,0 +1,14 @@
+package strict_pending_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+)
+
+var _ = Describe("StrictPendingFixture", func() {
+	It("does something now", func() {
+	})
+
+	It("does something later")
+
+	Specify("something else later", Label("later"))
+})
@@ -0,0 +1,13 @@
+package suite_invariant_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestSuiteInvariantFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "SuiteInvariantFixture Suite")
+}
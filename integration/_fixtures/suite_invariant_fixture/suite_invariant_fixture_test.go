@@ -0,0 +1,32 @@
+package suite_invariant_fixture_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+)
+
+var rows []string
+
+var _ = RegisterSuiteInvariant(func() error {
+	if len(rows) > 0 {
+		return fmt.Errorf("%d row(s) left over", len(rows))
+	}
+	return nil
+})
+
+var _ = Describe("SuiteInvariantFixture", func() {
+	It("cleans up after itself", func() {
+		rows = append(rows, "a")
+		DeferCleanup(func() {
+			rows = nil
+		})
+	})
+
+	It("leaves a row behind", func() {
+		rows = append(rows, "b")
+	})
+
+	It("runs in a polluted database", func() {
+	})
+})
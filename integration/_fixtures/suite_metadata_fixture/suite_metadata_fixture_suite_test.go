@@ -0,0 +1,13 @@
+package suite_metadata_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestSuiteMetadataFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "SuiteMetadataFixture Suite", Label("integration"), SuiteMetadata{"gitSHA": "abc123", "environment": "local"})
+}
@@ -0,0 +1,13 @@
+package suite_metadata_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+)
+
+var _ = Describe("SuiteMetadataFixture", func() {
+	It("passes", func() {
+	})
+
+	It("passes again", func() {
+	})
+})
@@ -0,0 +1,27 @@
+package suite_process_fixture_test
+
+import (
+	"os"
+	"os/exec"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+var readyFile string
+
+var _ = BeforeSuite(func() {
+	readyFile = StartSuiteProcess("sleeper", func() (*exec.Cmd, string) {
+		return exec.Command("sh", "-c", "echo $$ >> pids.log; echo sleeping; touch ready; exec sleep 30"), "ready"
+	}, func(address string) error {
+		_, err := os.Stat(address)
+		return err
+	}, 10)
+})
+
+func TestSuiteProcessFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "SuiteProcessFixture Suite")
+}
@@ -0,0 +1,16 @@
+package suite_process_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SuiteProcessFixture", func() {
+	It("A", func() {
+		Ω(readyFile).Should(BeAnExistingFile())
+	})
+
+	It("B", func() {
+		Ω(readyFile).Should(BeAnExistingFile())
+	})
+})
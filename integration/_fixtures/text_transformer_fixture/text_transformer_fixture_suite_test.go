@@ -0,0 +1,13 @@
+package text_transformer_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestTextTransformerFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "TextTransformerFixture Suite")
+}
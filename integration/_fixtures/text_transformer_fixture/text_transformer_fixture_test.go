@@ -0,0 +1,35 @@
+package text_transformer_fixture_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var translations = map[string]string{
+	"cart":            "le panier",
+	"cart.add":        "ajoute un article",
+	"cart.checkout":   "passe commande",
+	"cart.empty.fail": "refuse un panier vide",
+}
+
+var _ = RegisterTextTransformer(func(text string) string {
+	if translation, ok := translations[text]; ok {
+		return translation
+	}
+	return text
+})
+
+var _ = Describe("cart", func() {
+	It("cart.add", func() {
+		fmt.Println("running:", CurrentGinkgoTestDescription().FullTestText)
+	})
+
+	It("cart.checkout", func() {
+	})
+
+	It("cart.empty.fail", func() {
+		Ω(0).Should(BeNumerically(">", 0))
+	})
+})
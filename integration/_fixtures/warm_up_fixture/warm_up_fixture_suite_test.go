@@ -0,0 +1,13 @@
+package warm_up_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestWarmUpFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "WarmUpFixture Suite")
+}
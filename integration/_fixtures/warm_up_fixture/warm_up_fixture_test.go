@@ -0,0 +1,30 @@
+package warm_up_fixture_test
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var warmedUp bool
+
+var _ = WarmUp(func() {
+	time.Sleep(time.Second)
+	if os.Getenv("WARM_UP_FIXTURE_FAIL") != "" {
+		Fail("the image could not be pulled")
+	}
+	warmedUp = true
+})
+
+var _ = BeforeSuite(func() {
+	fmt.Println("BeforeSuite ran")
+})
+
+var _ = Describe("warm up", func() {
+	It("runs after the warm-up", func() {
+		Ω(warmedUp).Should(BeTrue())
+	})
+})
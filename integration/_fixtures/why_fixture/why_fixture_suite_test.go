@@ -0,0 +1,13 @@
+package why_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestWhyFixture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "WhyFixture Suite")
+}
@@ -0,0 +1,21 @@
+package why_fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+)
+
+var _ = Describe("account", func() {
+	It("withdraws", func() {
+		Fail("should not run")
+	})
+
+	It("withdraws overdrafts", func() {
+		Fail("should not run")
+	})
+
+	It("deposits", func() {
+		Fail("should not run")
+	})
+
+	PIt("closes", func() {})
+})
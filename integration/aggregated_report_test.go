@@ -0,0 +1,24 @@
+package integration_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("AggregatedReport", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("aggregated_report")
+		copyIn(fixturePath("aggregated_report_fixture"), pathToTest, false)
+	})
+
+	It("should let the specs of a node see the specs completed by the other nodes", func() {
+		session := startGinkgo(pathToTest, "--noColor", "-nodes=2", "--seed=1", "--randomizeAllSpecs=false")
+		Eventually(session).Should(gexec.Exit(1))
+		Ω(session).Should(gbytes.Say(`saw "fails" fail on node \d`))
+		Ω(session).Should(gbytes.Say("1 Passed | 1 Failed"))
+	})
+})
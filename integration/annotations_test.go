@@ -0,0 +1,38 @@
+package integration_test
+
+import (
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/reporters"
+	"github.com/onsi/ginkgo/types"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("Annotations in comments", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("annotations")
+		copyIn(fixturePath("annotations_fixture"), pathToTest, false)
+	})
+
+	It("should decorate specs and containers with the labels, owners and severity annotated in the comments above them", func() {
+		session := startGinkgo(pathToTest, "--noColor", "-v", "--jsonReport=report.json")
+		Eventually(session).Should(gexec.Exit(0))
+		Ω(session).Should(gbytes.Say(`is annotated:\[storage slow restart db\]`))
+		Ω(session).Should(gbytes.Say(`is not annotated:\[storage\]`))
+
+		report, err := reporters.ReadJSONReport(filepath.Join(pathToTest, "report.json"))
+		Ω(err).ShouldNot(HaveOccurred())
+		summaries := map[string]*types.SpecSummary{}
+		for _, summary := range report.SpecSummaries {
+			summaries[reporters.SpecFullText(summary)] = summary
+		}
+		Ω(summaries["AnnotationsFixture is annotated"].Owners).Should(Equal([]string{"team-storage"}))
+		Ω(summaries["AnnotationsFixture is annotated"].Severity).Should(Equal(types.SeverityLow))
+		Ω(summaries["AnnotationsFixture is not annotated"].Owners).Should(BeEmpty())
+	})
+})
@@ -0,0 +1,30 @@
+package integration_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("BeforeNode", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("before_node")
+		copyIn(fixturePath("before_node_fixture"), pathToTest, false)
+	})
+
+	It("should run the hooks before every node, letting them configure Gomega per spec", func() {
+		session := startGinkgo(pathToTest, "--noColor", "-v", "--seed=1", "--randomizeAllSpecs=false")
+		Eventually(session).Should(gexec.Exit(1))
+
+		output := string(session.Out.Contents())
+		Ω(output).Should(ContainSubstring("before BeforeEach of [Top Level] eventually waits long enough for slow specs"))
+		Ω(output).Should(ContainSubstring("before It of [Top Level] eventually waits long enough for slow specs"))
+		Ω(output).Should(ContainSubstring("[Fail] eventually [It] gives up early on the other specs"))
+		Ω(output).Should(ContainSubstring("the hook failed"))
+		Ω(output).Should(ContainSubstring("[Fail] eventually [BeforeEach] is failed by the hook"))
+		Ω(output).ShouldNot(ContainSubstring("never printed"))
+		Ω(output).Should(ContainSubstring("1 Passed | 2 Failed"))
+	})
+})
@@ -0,0 +1,44 @@
+package integration_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("Bisect", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("bisect")
+		copyIn(fixturePath("bisect_fixture"), pathToTest, false)
+	})
+
+	It("should find the earlier spec that makes the failing spec fail", func() {
+		session := startGinkgo(pathToTest, "bisect", "--seed=1", "--failingSpec=cache misses a user it never stored")
+		Eventually(session).Should(gexec.Exit(0))
+
+		output := string(session.Out.Contents())
+		Ω(output).Should(ContainSubstring(`Bisecting the 5 spec(s) that run before "cache misses a user it never stored" with seed 1`))
+		Ω(output).Should(ContainSubstring(`1 spec(s) make "cache misses a user it never stored" fail when they run before it:`))
+		Ω(output).Should(MatchRegexp(`cache warms up  ->  cache misses a user it never stored\n\s+.*bisect_fixture_test.go:19  ->  .*bisect_fixture_test.go:31`))
+	})
+
+	It("should accept the location of the failing spec", func() {
+		session := startGinkgo(pathToTest, "bisect", "--seed=1", "--failingSpec=bisect_fixture_test.go:31")
+		Eventually(session).Should(gexec.Exit(0))
+		Ω(string(session.Out.Contents())).Should(ContainSubstring("cache warms up  ->  cache misses a user it never stored"))
+	})
+
+	It("should tell when the failing spec fails on its own", func() {
+		session := startGinkgo(pathToTest, "bisect", "--seed=1", "--failingSpec=cache is always broken")
+		Eventually(session).Should(gexec.Exit(1))
+		Ω(string(session.Out.Contents())).Should(ContainSubstring(`"cache is always broken" fails on its own`))
+	})
+
+	It("should tell when the failing spec passes after the specs before it", func() {
+		session := startGinkgo(pathToTest, "bisect", "--seed=1", "--failingSpec=cache stores a session")
+		Eventually(session).Should(gexec.Exit(1))
+		Ω(string(session.Out.Contents())).Should(ContainSubstring(`"cache stores a session" passes when it runs after the 1 spec(s) before it`))
+	})
+})
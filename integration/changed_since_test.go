@@ -0,0 +1,103 @@
+package integration_test
+
+import (
+	"io/ioutil"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("ChangedSince", func() {
+	var rootPath string
+
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-c", "user.name=ginkgo", "-c", "user.email=ginkgo@example.com"}, args...)...)
+		cmd.Dir = rootPath
+		output, err := cmd.CombinedOutput()
+		Ω(err).ShouldNot(HaveOccurred(), string(output))
+	}
+
+	appendTo := func(path string, content string) {
+		original, err := ioutil.ReadFile(path)
+		Ω(err).ShouldNot(HaveOccurred())
+		err = ioutil.WriteFile(path, append(original, []byte(content)...), 0666)
+		Ω(err).ShouldNot(HaveOccurred())
+	}
+
+	BeforeEach(func() {
+		rootPath = tmpPath("root")
+		for _, pkg := range []string{"A", "B", "C"} {
+			path := filepath.Join(rootPath, pkg)
+			copyIn(fixturePath(filepath.Join("watch_fixtures", pkg)), path, false)
+			files, err := ioutil.ReadDir(path)
+			Ω(err).ShouldNot(HaveOccurred())
+			for _, f := range files {
+				filePath := filepath.Join(path, f.Name())
+				src, err := ioutil.ReadFile(filePath)
+				Ω(err).ShouldNot(HaveOccurred())
+				out := strings.ReplaceAll(string(src), "$ROOT_PATH$", "github.com/onsi/ginkgo/integration/"+rootPath)
+				Ω(ioutil.WriteFile(filePath, []byte(out), 0666)).Should(Succeed())
+			}
+		}
+
+		git("init", "-q")
+		git("add", "-A")
+		git("commit", "-q", "-m", "initial")
+	})
+
+	It("skips every suite when nothing changed", func() {
+		session := startGinkgo(rootPath, "-r", "-changedSince=HEAD")
+		Eventually(session).Should(gexec.Exit(0))
+		output := string(session.Out.Contents())
+
+		Ω(output).Should(ContainSubstring("Will skip suites unaffected by changes since HEAD"))
+		Ω(output).Should(ContainSubstring("No test suites affected by changes!"))
+		Ω(output).ShouldNot(ContainSubstring("A Suite"))
+	})
+
+	It("runs the suites that depend on a changed package", func() {
+		appendTo(filepath.Join(rootPath, "B", "B.go"), "//")
+
+		session := startGinkgo(rootPath, "-r", "-changedSince=HEAD")
+		Eventually(session).Should(gexec.Exit(0))
+		output := string(session.Out.Contents())
+
+		Ω(output).Should(ContainSubstring("A Suite"))
+		Ω(output).Should(ContainSubstring("B Suite"))
+		Ω(output).ShouldNot(ContainSubstring("C Suite"))
+		Ω(output).Should(ContainSubstring("Ginkgo ran 2 suites"))
+	})
+
+	It("focuses on the specs in the changed test files when only test files changed", func() {
+		extraTest := filepath.Join(rootPath, "A", "A_extra_test.go")
+		err := ioutil.WriteFile(extraTest, []byte(`package A_test
+
+import (
+	. "github.com/onsi/ginkgo"
+)
+
+var _ = Describe("A extra", func() {
+	It("runs the new spec", func() {})
+})
+`), 0666)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		session := startGinkgo(rootPath, "-r", "-noColor", "-changedSince=HEAD")
+		Eventually(session).Should(gexec.Exit(0))
+		output := string(session.Out.Contents())
+
+		Ω(output).Should(ContainSubstring("A Suite"))
+		Ω(output).Should(ContainSubstring("Ran 1 of 2 Specs"))
+		Ω(output).Should(ContainSubstring("Ginkgo ran 1 suite"))
+	})
+
+	It("fails when the ref is unknown", func() {
+		session := startGinkgo(rootPath, "-r", "-changedSince=no-such-ref")
+		Eventually(session).Should(gexec.Exit(1))
+		Ω(session.Err.Contents()).Should(ContainSubstring("git diff --name-only no-such-ref -- failed"))
+	})
+})
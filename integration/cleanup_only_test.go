@@ -0,0 +1,38 @@
+package integration_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("CleanupOnly", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("cleanup_only")
+		copyIn(fixturePath("cleanup_only_fixture"), pathToTest, false)
+	})
+
+	It("should only run the AfterEach and AfterSuite nodes", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--cleanupOnly", "-v")
+		Eventually(session).Should(gexec.Exit(0))
+		output := string(session.Out.Contents())
+
+		Ω(output).Should(ContainSubstring("deleting the object"))
+		Ω(output).Should(ContainSubstring("deleting the bucket"))
+		Ω(output).ShouldNot(ContainSubstring("creating the bucket"))
+		Ω(output).ShouldNot(ContainSubstring("creating the object"))
+		Ω(output).ShouldNot(ContainSubstring("uploading the object"))
+	})
+
+	It("should do the same in parallel", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--cleanupOnly", "-nodes=2", "--stream=false", "--reportPassed")
+		Eventually(session).Should(gexec.Exit(0))
+		output := string(session.Out.Contents())
+
+		Ω(output).Should(ContainSubstring("deleting the object"))
+		Ω(output).ShouldNot(ContainSubstring("creating the bucket"))
+		Ω(output).ShouldNot(ContainSubstring("uploading the object"))
+	})
+})
@@ -0,0 +1,47 @@
+package integration_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("Columns", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("columns")
+		copyIn(fixturePath("columns_fixture"), pathToTest, false)
+	})
+
+	const message = "the quick brown fox jumps over the lazy dog and keeps running far beyond the edge of a narrow console"
+	const wrapped = "the quick brown fox jumps over the\n  lazy dog and keeps running far beyond\n  the edge of a narrow console"
+
+	It("should not wrap failure messages when the output is not a terminal", func() {
+		session := startGinkgo(pathToTest, "--noColor")
+		Eventually(session).Should(gexec.Exit(1))
+
+		Ω(string(session.Out.Contents())).Should(ContainSubstring(message))
+	})
+
+	It("should wrap failure messages at -columns", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--columns=40")
+		Eventually(session).Should(gexec.Exit(1))
+
+		Ω(string(session.Out.Contents())).Should(ContainSubstring(wrapped))
+	})
+
+	It("should wrap failure messages at -columns when running in parallel", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--columns=40", "--nodes=2")
+		Eventually(session).Should(gexec.Exit(1))
+
+		Ω(string(session.Out.Contents())).Should(ContainSubstring(wrapped))
+	})
+
+	It("should not wrap anything with -noWrap", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--columns=40", "--noWrap")
+		Eventually(session).Should(gexec.Exit(1))
+
+		Ω(string(session.Out.Contents())).Should(ContainSubstring(message))
+	})
+})
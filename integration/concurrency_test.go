@@ -0,0 +1,37 @@
+package integration_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("Concurrency", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("concurrency")
+		copyIn(fixturePath("concurrency_fixture"), pathToTest, false)
+	})
+
+	It("should run specs concurrently within the process, keeping their failures and output apart", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--concurrency=4")
+		Eventually(session).Should(gexec.Exit(1))
+		output := string(session.Out.Contents())
+
+		Ω(output).Should(ContainSubstring("max concurrent specs: 4"))
+		Ω(output).Should(ContainSubstring("4 Passed | 1 Failed"))
+		Ω(output).Should(ContainSubstring("C failed"))
+		Ω(output).Should(ContainSubstring("output of C"))
+		Ω(output).ShouldNot(ContainSubstring("output of A"))
+		Ω(output).ShouldNot(ContainSubstring("output of B"))
+		Ω(output).ShouldNot(ContainSubstring("output of D"))
+	})
+
+	It("should run one spec at a time by default", func() {
+		session := startGinkgo(pathToTest, "--noColor")
+		Eventually(session).Should(gexec.Exit(1))
+
+		Ω(string(session.Out.Contents())).Should(ContainSubstring("max concurrent specs: 1"))
+	})
+})
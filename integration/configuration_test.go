@@ -0,0 +1,51 @@
+package integration_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+
+	"os/exec"
+)
+
+var _ = Describe("GinkgoConfiguration", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("configuration")
+		copyIn(fixturePath("configuration_fixture"), pathToTest, false)
+	})
+
+	It("should expose the configuration of the suite and its custom flags to specs", func() {
+		session := startGinkgo(pathToTest, "--noColor", "-v", "--seed=17", "--", "-slowEnvironment", "-region=eu")
+		Eventually(session).Should(gexec.Exit(0))
+
+		output := string(session.Out.Contents())
+		Ω(output).Should(ContainSubstring("seed=17"))
+		Ω(output).Should(ContainSubstring("labels=[integration]"))
+		Ω(output).Should(ContainSubstring("slowEnvironment=true region=eu"))
+		Ω(output).Should(ContainSubstring("noColor=true"))
+	})
+
+	It("should expose the default values of custom flags that are not passed", func() {
+		session := startGinkgo(pathToTest, "--noColor", "-v", "--nodes=2")
+		Eventually(session).Should(gexec.Exit(0))
+
+		Ω(string(session.Out.Contents())).Should(ContainSubstring("slowEnvironment=false region=us"))
+	})
+
+	It("should list the custom flags in a section of their own in the help of the suite", func() {
+		cmd := exec.Command("go", "test", "-c", "-o", "configuration.test")
+		cmd.Dir = pathToTest
+		Ω(cmd.Run()).Should(Succeed())
+
+		cmd = exec.Command("./configuration.test", "-help")
+		cmd.Dir = pathToTest
+		session, err := gexec.Start(cmd, GinkgoWriter, GinkgoWriter)
+		Ω(err).ShouldNot(HaveOccurred())
+		Eventually(session).Should(gexec.Exit(0))
+
+		output := string(session.Err.Contents())
+		Ω(output).Should(MatchRegexp(`-ginkgo.seed int[\s\S]*Custom flags of the suite:\n  -region string\n\s+The region the system under test runs in. \(default "us"\)\n  -slowEnvironment\n`))
+	})
+})
@@ -75,6 +75,12 @@ var _ = Describe("ginkgo convert", func() {
 		Ω(convertedFile).Should(Equal(goldMaster))
 	})
 
+	It("rewrites tests running subtests as nested Describes and Its, or as steps", func() {
+		convertedFile := readConvertedFileNamed("subtests_test.go")
+		goldMaster := readGoldMasterNamed("subtests_test.go")
+		Ω(convertedFile).Should(Equal(goldMaster))
+	})
+
 	It("rewrites tests in the package dir that belong to other packages", func() {
 		convertedFile := readConvertedFileNamed("outside_package_test.go")
 		goldMaster := readGoldMasterNamed("outside_package_test.go")
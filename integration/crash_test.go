@@ -0,0 +1,62 @@
+package integration_test
+
+import (
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/reporters"
+	"github.com/onsi/ginkgo/types"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("Crash", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("crash")
+		copyIn(fixturePath("crash_fixture"), pathToTest, false)
+	})
+
+	summariesOf := func(report reporters.JSONReport) map[string]*types.SpecSummary {
+		summaries := map[string]*types.SpecSummary{}
+		for _, summary := range report.SpecSummaries {
+			summaries[reporters.SpecFullText(summary)] = summary
+		}
+		return summaries
+	}
+
+	It("should write the report of the specs that ran when a panic escapes the specs, with the running spec as crashed", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--jsonReport=report.json")
+		Eventually(session).Should(gexec.Exit())
+		Ω(session.ExitCode()).ShouldNot(Equal(0))
+		Ω(session.Out.Contents()).Should(ContainSubstring("panic: the reporter is broken"))
+
+		report, err := reporters.ReadJSONReport(filepath.Join(pathToTest, "report.json"))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(report.SuiteSucceeded).Should(BeFalse())
+		Ω(report.SuiteSummary.SpecialSuiteFailureReasons).Should(ConsistOf(types.SpecialSuiteFailureReason{Cause: types.InterruptCauseCrash, Message: "The test binary crashed: the reporter is broken"}))
+
+		summaries := summariesOf(report)
+		Ω(summaries).Should(HaveLen(3))
+		Ω(summaries["CrashFixture passes first"].State).Should(Equal(types.SpecStatePassed))
+		Ω(summaries["CrashFixture passes second"].State).Should(Equal(types.SpecStatePassed))
+		crashed := summaries["CrashFixture crashes"]
+		Ω(crashed.State).Should(Equal(types.SpecStatePanicked))
+		Ω(crashed.Failure.Message).Should(Equal("The test binary crashed while this spec ran"))
+		Ω(crashed.Failure.ForwardedPanic).Should(Equal("the reporter is broken"))
+		Ω(crashed.Failure.Location.FullStackTrace).Should(ContainSubstring("crashingReporter"))
+	})
+
+	It("should write the report when running in parallel", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--nodes=2", "--jsonReport=report.json")
+		Eventually(session).Should(gexec.Exit())
+		Ω(session.ExitCode()).ShouldNot(Equal(0))
+
+		report, err := reporters.ReadJSONReport(filepath.Join(pathToTest, "report.json"))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(report.SuiteSucceeded).Should(BeFalse())
+		Ω(report.SuiteSummary.SpecialSuiteFailureReasons).Should(ContainElement(types.SpecialSuiteFailureReason{Cause: types.InterruptCauseCrash, Message: "The test binary crashed: the reporter is broken"}))
+		Ω(summariesOf(report)["CrashFixture crashes"].State).Should(Equal(types.SpecStatePanicked))
+	})
+})
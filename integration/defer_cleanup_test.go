@@ -0,0 +1,35 @@
+package integration_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("DeferCleanup", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("defer_cleanup")
+		copyIn(fixturePath("defer_cleanup_fixture"), pathToTest, false)
+	})
+
+	It("should run the cleanups last registered first, after the AfterEach nodes of their level", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--focus=runs them in order")
+		Eventually(session).Should(gexec.Exit(0))
+
+		output := string(session.Out.Contents())
+		Ω(output).Should(MatchRegexp(`second it cleanup\s+first it cleanup\s+inner AfterEach\s+outer AfterEach\s+outer cleanup`))
+	})
+
+	It("should only report the panics of cleanups, unless told to fail on them", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--focus=panics in a cleanup")
+		Eventually(session).Should(gexec.Exit(0))
+
+		session = startGinkgo(pathToTest, "--noColor", "--focus=panics in a cleanup", "--failOnCleanupPanics")
+		Eventually(session).Should(gexec.Exit(1))
+		output := string(session.Out.Contents())
+		Ω(output).Should(ContainSubstring("cleanup panicked"))
+		Ω(output).Should(ContainSubstring("[DeferCleanup]"))
+	})
+})
@@ -0,0 +1,37 @@
+package integration_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("DependsOn", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("depends_on")
+		copyIn(fixturePath("depends_on_fixture"), pathToTest, false)
+	})
+
+	assertPrerequisitesWereHonored := func(session *gexec.Session) {
+		output := string(session.Out.Contents())
+		Ω(output).Should(ContainSubstring(`Skipped because its prerequisite "accounts deletes the account" (DependsOn("accounts deletes the account")) failed`))
+		Ω(output).ShouldNot(ContainSubstring("the account was never deleted"))
+		Ω(output).Should(ContainSubstring("2 Passed | 1 Failed | 0 Pending | 1 Skipped"))
+	}
+
+	It("should run prerequisites first and skip the specs whose prerequisites failed", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--randomizeAllSpecs")
+		Eventually(session).Should(gexec.Exit(1))
+		assertPrerequisitesWereHonored(session)
+	})
+
+	Context("when running in parallel", func() {
+		It("should wait for prerequisites running on other nodes", func() {
+			session := startGinkgo(pathToTest, "--noColor", "--nodes=2")
+			Eventually(session).Should(gexec.Exit(1))
+			assertPrerequisitesWereHonored(session)
+		})
+	})
+})
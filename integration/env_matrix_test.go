@@ -0,0 +1,56 @@
+package integration_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("-envMatrix", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("env_matrix")
+		copyIn(fixturePath("env_matrix_fixture"), pathToTest, false)
+	})
+
+	It("should run the suite once per entry, and break the results down by matrix value", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--envMatrix=matrix.yaml", "--matrixReport=merged.json", "--keepGoing", "--nodes=2")
+		Eventually(session).Should(gexec.Exit(1))
+
+		output := string(session.Out.Contents())
+		Ω(output).Should(ContainSubstring("Matrix entry 1/3: postgres"))
+		Ω(output).Should(ContainSubstring("Matrix entry 2/3: DB=mysql"))
+		Ω(output).Should(ContainSubstring("Matrix entry 3/3: DB=sqlite flags=--ginkgo.skip=focused away"))
+		Ω(output).Should(ContainSubstring("Matrix: 3 of 3 run(s)"))
+		Ω(output).Should(ContainSubstring("PASS  postgres: 3 passed, 0 failed"))
+		Ω(output).Should(ContainSubstring("FAIL  DB=mysql: 2 passed, 1 failed"))
+		Ω(output).Should(ContainSubstring("By DB:\n  postgres: 1 of 1 run(s) passed, 3 passed | 0 failed | 0 pending | 0 skipped\n  mysql: 0 of 1 run(s) passed, 2 passed | 1 failed | 0 pending | 0 skipped\n  sqlite: 1 of 1 run(s) passed, 2 passed | 0 failed | 0 pending | 1 skipped\n"))
+		Ω(output).Should(ContainSubstring("By flags:\n  (unset): 1 of 2 run(s) passed"))
+
+		matches, err := filepath.Glob(filepath.Join(pathToTest, ".ginkgo-matrix-*.json"))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(matches).Should(BeEmpty())
+
+		data, err := ioutil.ReadFile(filepath.Join(pathToTest, "merged.json"))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(string(data)).Should(ContainSubstring(`"matrix.DB": "sqlite"`))
+	})
+
+	It("should stop at the first failing entry without -keepGoing", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--envMatrix=matrix.yaml")
+		Eventually(session).Should(gexec.Exit(1))
+		Ω(session.Out.Contents()).Should(ContainSubstring("Matrix: 2 of 3 run(s)"))
+	})
+
+	It("should complain about an invalid matrix", func() {
+		Ω(ioutil.WriteFile(filepath.Join(pathToTest, "invalid.yaml"), []byte("- environment: {}\n"), 0666)).Should(Succeed())
+		session := startGinkgo(pathToTest, "--envMatrix=invalid.yaml")
+		Eventually(session).Should(gexec.Exit(1))
+		Ω(session.Err.Contents()).Should(ContainSubstring("Failed to read -envMatrix"))
+	})
+})
+
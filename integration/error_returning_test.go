@@ -0,0 +1,30 @@
+package integration_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("Nodes returning an error", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("error_returning")
+		copyIn(fixturePath("error_returning_fixture"), pathToTest, false)
+	})
+
+	It("should fail the specs with the errors at the locations of the nodes", func() {
+		session := startGinkgo(pathToTest, "--noColor")
+		Eventually(session).Should(gexec.Exit(1))
+		output := string(session.Out.Contents())
+
+		Ω(output).ShouldNot(ContainSubstring("NEVER SEE THIS"))
+
+		Ω(output).Should(MatchRegexp(`returns an error from its It \[It\]\n.*error_returning_fixture_test\.go:12\n\n\s+an It error\n\n\s+.*error_returning_fixture_test\.go:12`))
+		Ω(output).Should(MatchRegexp(`runs a named step \[It\]\n.*error_returning_fixture_test\.go:20\n\n\s+a Step error\n\n\s+.*error_returning_fixture_test\.go:20`))
+		Ω(output).Should(MatchRegexp(`never runs its body \[BeforeEach\]\n.*error_returning_fixture_test\.go:29\n\n\s+a BeforeEach error\n\n\s+.*error_returning_fixture_test\.go:25`))
+
+		Ω(output).Should(ContainSubstring("1 Passed | 3 Failed"))
+	})
+})
@@ -0,0 +1,46 @@
+package integration_test
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("Specs expected to fail", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("expect_failure")
+		copyIn(fixturePath("expect_failure_fixture"), pathToTest, false)
+	})
+
+	It("should report their failures as expected without failing the suite", func() {
+		session := startGinkgo(pathToTest, "--noColor")
+		Eventually(session).Should(gexec.Exit(0))
+		output := string(session.Out.Contents())
+		Ω(output).Should(ContainSubstring("[EXPECTED FAILURE]"))
+		Ω(output).Should(ContainSubstring("Expected to fail: ISSUE-123: the items are sorted"))
+		Ω(output).Should(ContainSubstring("Expected to fail: ISSUE-456"))
+		Ω(output).Should(ContainSubstring("3 Passed | 0 Failed"))
+	})
+
+	Context("when a spec expected to fail passes", func() {
+		BeforeEach(func() {
+			os.Setenv("FIXED", "true")
+		})
+
+		AfterEach(func() {
+			os.Unsetenv("FIXED")
+		})
+
+		It("should fail the suite", func() {
+			session := startGinkgo(pathToTest, "--noColor")
+			Eventually(session).Should(gexec.Exit(1))
+			Ω(session).Should(gbytes.Say(`This spec is expected to fail \(ISSUE-456\), but it passed`))
+			Ω(session).Should(gbytes.Say("2 Passed | 1 Failed"))
+		})
+	})
+})
@@ -0,0 +1,46 @@
+package integration_test
+
+import (
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/reporters"
+	"github.com/onsi/ginkgo/types"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("Failing on empty suites", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("passing")
+		copyIn(fixturePath("passing_ginkgo_tests"), pathToTest, false)
+	})
+
+	It("should pass when no spec matches the filters by default", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--focus=matches nothing")
+		Eventually(session).Should(gexec.Exit(0))
+	})
+
+	It("should fail with -failOnEmpty when no spec matches the filters", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--focus=matches nothing", "--failOnEmpty")
+		Eventually(session).Should(gexec.Exit(1))
+		Ω(string(session.Out.Contents())).Should(ContainSubstring("No spec was left to run once the focus, skip and label filters were applied, and -failOnEmpty is set."))
+	})
+
+	It("should fail with -failOnEmpty when running in parallel", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--nodes=2", "--focus=matches nothing", "--failOnEmpty", "--jsonReport=report.json")
+		Eventually(session).Should(gexec.Exit(1))
+
+		report, err := reporters.ReadJSONReport(filepath.Join(pathToTest, "report.json"))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(report.SuiteSummary.SpecialSuiteFailureReasons).Should(HaveLen(1))
+		Ω(report.SuiteSummary.SpecialSuiteFailureReasons[0].Cause).Should(Equal(types.InterruptCauseEmptySuite))
+	})
+
+	It("should pass with -failOnEmpty when specs run", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--failOnEmpty")
+		Eventually(session).Should(gexec.Exit(0))
+	})
+})
@@ -0,0 +1,99 @@
+package integration_test
+
+import (
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/reporters"
+	"github.com/onsi/ginkgo/types"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("Failer", func() {
+	var pathToTest string
+
+	summariesOf := func(report reporters.JSONReport) map[string]*types.SpecSummary {
+		summaries := map[string]*types.SpecSummary{}
+		for _, summary := range report.SpecSummaries {
+			summaries[reporters.SpecFullText(summary)] = summary
+		}
+		return summaries
+	}
+
+	Context("with an assertion library built on the failer", func() {
+		BeforeEach(func() {
+			pathToTest = tmpPath("failer")
+			copyIn(fixturePath("failer_fixture"), pathToTest, false)
+		})
+
+		It("should record the outcomes it reports, as Gomega's", func() {
+			session := startGinkgo(pathToTest, "--noColor", "--jsonReport=report.json")
+			Eventually(session).Should(gexec.Exit(1))
+
+			report, err := reporters.ReadJSONReport(filepath.Join(pathToTest, "report.json"))
+			Ω(err).ShouldNot(HaveOccurred())
+			summaries := summariesOf(report)
+
+			failed := summaries["FailerFixture fails through the failer"]
+			Ω(failed.State).Should(Equal(types.SpecStateFailed))
+			Ω(failed.Failure.Message).Should(Equal("expected -1 to be positive"))
+			Ω(failed.Failure.Location.FileName).Should(HaveSuffix("failer_fixture_test.go"))
+			Ω(failed.Failure.Location.LineNumber).Should(Equal(30))
+
+			Ω(summaries["FailerFixture is skipped through the failer"].State).Should(Equal(types.SpecStateSkipped))
+			Ω(summaries["FailerFixture is skipped through the failer"].Failure.Message).Should(Equal("not today"))
+			Ω(summaries["FailerFixture panics through the failer"].State).Should(Equal(types.SpecStatePanicked))
+			Ω(summaries["FailerFixture panics through the failer"].Failure.ForwardedPanic).Should(Equal("boom"))
+			Ω(summaries["FailerFixture passes once a retried assertion passes"].State).Should(Equal(types.SpecStatePassed))
+		})
+	})
+
+	Context("when a spec aborts the suite", func() {
+		BeforeEach(func() {
+			pathToTest = tmpPath("abort")
+			copyIn(fixturePath("abort_fixture"), pathToTest, false)
+		})
+
+		It("should skip the specs left to run", func() {
+			session := startGinkgo(pathToTest, "--noColor", "--jsonReport=report.json")
+			Eventually(session).Should(gexec.Exit(1))
+			Ω(session.Out.Contents()).Should(ContainSubstring("the database is gone"))
+
+			report, err := reporters.ReadJSONReport(filepath.Join(pathToTest, "report.json"))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(report.SuiteSummary.SpecialSuiteFailureReasons).Should(HaveLen(1))
+			Ω(report.SuiteSummary.SpecialSuiteFailureReasons[0].Cause).Should(Equal(types.InterruptCauseAbort))
+			Ω(report.SuiteSummary.SpecialSuiteFailureReasons[0].Message).Should(MatchRegexp(`The suite was aborted at \S+abort_fixture_test.go:13: the database is gone`))
+
+			summaries := summariesOf(report)
+			Ω(summaries["AbortFixture aborts"].State).Should(Equal(types.SpecStateFailed))
+			for text, summary := range summaries {
+				if text != "AbortFixture aborts" {
+					Ω(summary.State).Should(Equal(types.SpecStateSkipped), text)
+				}
+			}
+		})
+
+		It("should stop the other parallel nodes", func() {
+			session := startGinkgo(pathToTest, "--noColor", "--nodes=2", "--jsonReport=report.json")
+			Eventually(session).Should(gexec.Exit(1))
+
+			report, err := reporters.ReadJSONReport(filepath.Join(pathToTest, "report.json"))
+			Ω(err).ShouldNot(HaveOccurred())
+			causes := []types.InterruptCause{}
+			for _, reason := range report.SuiteSummary.SpecialSuiteFailureReasons {
+				causes = append(causes, reason.Cause)
+			}
+			Ω(causes).Should(ContainElement(types.InterruptCauseAbort))
+
+			passed := 0
+			for _, summary := range report.SpecSummaries {
+				if summary.State == types.SpecStatePassed {
+					passed++
+				}
+			}
+			Ω(passed).Should(BeNumerically("<", 10))
+		})
+	})
+})
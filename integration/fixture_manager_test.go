@@ -0,0 +1,28 @@
+package integration_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("FixtureManager", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("fixture_manager")
+		copyIn(fixturePath("fixture_manager_fixture"), pathToTest, false)
+	})
+
+	It("should set up and tear down each spec, keyed by a stable file name", func() {
+		session := startGinkgo(pathToTest, "--noColor")
+		Eventually(session).Should(gexec.Exit(1))
+
+		Ω(session).Should(gbytes.Say(`load Books_can_be_borrowed-[0-9a-f]{8}\.yaml for "Books can be borrowed"`))
+		Ω(session).Should(gbytes.Say(`before each`))
+		Ω(session).Should(gbytes.Say(`save Books_can_be_borrowed-[0-9a-f]{8}\.yaml failed=false`))
+		Ω(session).Should(gbytes.Say(`load Books_can_t_be_borrowed_twice-[0-9a-f]{8}\.yaml for "Books can't be borrowed twice!"`))
+		Ω(session).Should(gbytes.Say(`save Books_can_t_be_borrowed_twice-[0-9a-f]{8}\.yaml failed=true`))
+	})
+})
@@ -181,6 +181,18 @@ var _ = Describe("Flags Specs", func() {
 		Ω(output).Should(ContainSubstring("Full Stack Trace"))
 	})
 
+	It("should trim the stack traces as told to", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--focus=a failing test", "--trace", "--fullStackTraces", "--stackTraceDepth=2", "--stackTraceFilter=onsi/gomega")
+		Eventually(session).Should(gexec.Exit(1))
+		output := string(session.Out.Contents())
+
+		trace := strings.SplitN(output, "Full Stack Trace\n", 2)[1]
+		trace = strings.SplitN(trace, "\n---", 2)[0]
+		Ω(strings.Split(strings.TrimSpace(trace), "\n")).Should(HaveLen(4))
+		Ω(trace).Should(ContainSubstring("flags_test.go:86"))
+		Ω(trace).ShouldNot(ContainSubstring("onsi/gomega"))
+	})
+
 	It("should fail fast when told to", func() {
 		pathToTest = tmpPath("fail")
 		copyIn(fixturePath("fail_fixture"), pathToTest, false)
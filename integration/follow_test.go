@@ -0,0 +1,43 @@
+package integration_test
+
+import (
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/reporters"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("-follow", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("follow")
+		copyIn(fixturePath("follow_fixture"), pathToTest, false)
+	})
+
+	It("should not show the output of passing specs by default", func() {
+		session := startGinkgo(pathToTest, "--noColor")
+		Eventually(session).Should(gexec.Exit(0))
+		Ω(session.Out.Contents()).ShouldNot(ContainSubstring("connecting to the database"))
+	})
+
+	It("should stream the output of the running spec, and still capture it in reports", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--follow", "--focus=as it goes", "--jsonReport=report.json")
+		Eventually(session).Should(gexec.Exit(0))
+
+		output := string(session.Out.Contents())
+		Ω(output).Should(ContainSubstring("connecting to the database\nconnected\n"))
+		Ω(output).ShouldNot(ContainSubstring("not followed"))
+		Ω(output).ShouldNot(ContainSubstring("following logs as it goes"))
+
+		report, err := reporters.ReadJSONReport(filepath.Join(pathToTest, "report.json"))
+		Ω(err).ShouldNot(HaveOccurred())
+		captured := map[string]string{}
+		for _, summary := range report.SpecSummaries {
+			captured[reporters.SpecFullText(summary)] = summary.CapturedOutput
+		}
+		Ω(captured).Should(HaveKeyWithValue("following logs as it goes", "connecting to the database\nconnected\n"))
+	})
+})
@@ -0,0 +1,66 @@
+package integration_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/reporters"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("Gherkin steps", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("gherkin")
+		copyIn(fixturePath("gherkin_fixture"), pathToTest, false)
+	})
+
+	It("should announce the steps and write them to the Cucumber report", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--nodes=2", "--cucumberReport=cucumber.json")
+		Eventually(session).Should(gexec.Exit(1))
+
+		output := string(session.Out.Contents())
+		Ω(output).Should(ContainSubstring("STEP: Given an empty account"))
+		Ω(output).Should(ContainSubstring("STEP: When the customer withdraws $20"))
+		Ω(output).Should(ContainSubstring("insufficient funds"))
+
+		data, err := ioutil.ReadFile(filepath.Join(pathToTest, "cucumber.json"))
+		Ω(err).ShouldNot(HaveOccurred())
+		var features []reporters.CucumberFeature
+		Ω(json.Unmarshal(data, &features)).Should(Succeed())
+		Ω(features).Should(HaveLen(1))
+		Ω(features[0].Name).Should(Equal("Account"))
+		Ω(features[0].Elements).Should(HaveLen(2))
+
+		var withdraws, refuses *reporters.CucumberScenario
+		for _, scenario := range features[0].Elements {
+			switch scenario.Name {
+			case "withdraws cash":
+				withdraws = scenario
+			case "refuses overdrafts":
+				refuses = scenario
+			}
+		}
+
+		Ω(withdraws.Before).Should(HaveLen(1))
+		Ω(withdraws.Before[0].Result.Status).Should(Equal("passed"))
+		Ω(withdraws.Tags).Should(HaveLen(1))
+		Ω(withdraws.Tags[0].Name).Should(Equal("@banking"))
+		Ω(withdraws.Steps).Should(HaveLen(3))
+		for i, keyword := range []string{"Given ", "When ", "Then "} {
+			Ω(withdraws.Steps[i].Keyword).Should(Equal(keyword))
+			Ω(withdraws.Steps[i].Result.Status).Should(Equal("passed"))
+			Ω(withdraws.Steps[i].Match.Location).Should(ContainSubstring("gherkin_fixture_test.go"))
+		}
+		Ω(withdraws.Steps[1].Name).Should(Equal("the customer withdraws $20"))
+
+		Ω(refuses.Steps).Should(HaveLen(2))
+		Ω(refuses.Steps[0].Result.Status).Should(Equal("passed"))
+		Ω(refuses.Steps[1].Result.Status).Should(Equal("failed"))
+		Ω(refuses.Steps[1].Result.ErrorMessage).Should(ContainSubstring("insufficient funds"))
+	})
+})
@@ -0,0 +1,54 @@
+package integration_test
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("Glyphs", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("glyphs")
+		copyIn(fixturePath("glyphs_fixture"), pathToTest, false)
+	})
+
+	It("should mark the specs with the glyphs of -glyphs", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--noisyPendings=false", "--glyphs=passed=✓,failed=✗")
+		Eventually(session).Should(gexec.Exit(1))
+		output := string(session.Out.Contents())
+
+		Ω(output).Should(ContainSubstring("✓"))
+		Ω(output).Should(ContainSubstring("✗ Failure"))
+		Ω(output).ShouldNot(ContainSubstring("•"))
+	})
+
+	Context("when GINKGO_GLYPHS is set", func() {
+		BeforeEach(func() {
+			os.Setenv("GINKGO_GLYPHS", "ascii")
+		})
+
+		AfterEach(func() {
+			os.Unsetenv("GINKGO_GLYPHS")
+		})
+
+		It("should use its glyphs, here ASCII-only ones", func() {
+			session := startGinkgo(pathToTest, "--noColor", "--nodes=2")
+			Eventually(session).Should(gexec.Exit(1))
+			output := string(session.Out.Contents())
+
+			Ω(output).Should(ContainSubstring("+ Failure"))
+			Ω(output).ShouldNot(ContainSubstring("•"))
+		})
+	})
+
+	It("should reject unknown states", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--glyphs=flaked=~")
+		Eventually(session).Should(gexec.Exit(1))
+
+		Ω(string(session.Out.Contents())).Should(ContainSubstring(`Invalid -glyphs: unknown state "flaked"`))
+	})
+})
@@ -0,0 +1,35 @@
+package integration_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("Detecting goroutine failures", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("goroutine_failure")
+		copyIn(fixturePath("goroutine_failure_fixture"), pathToTest, false)
+	})
+
+	It("should crash the test binary by default", func() {
+		session := startGinkgo(pathToTest, "--noColor")
+		Eventually(session).Should(gexec.Exit())
+		Ω(session.ExitCode()).ShouldNot(BeZero())
+		Ω(string(session.Out.Contents())).Should(ContainSubstring("panic: "))
+	})
+
+	It("should fail the spec and stop the goroutine with -detectGoroutineFailures", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--detectGoroutineFailures")
+		Eventually(session).Should(gexec.Exit(1))
+
+		output := string(session.Out.Contents())
+		Ω(output).Should(MatchRegexp(`Expected\s+<string>: background\s+to equal\s+<string>: foreground`))
+		Ω(output).Should(MatchRegexp(`This failure was raised from goroutine \d+, which was started by the spec rather than run by Ginkgo`))
+		Ω(output).Should(ContainSubstring("defer GinkgoRecover()"))
+		Ω(output).Should(ContainSubstring("goroutine_failure_fixture_test.go"))
+		Ω(output).Should(ContainSubstring("1 Passed | 1 Failed"))
+	})
+})
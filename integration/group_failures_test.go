@@ -0,0 +1,56 @@
+package integration_test
+
+import (
+	"regexp"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("Grouping failures", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("grouped_failures")
+		copyIn(fixturePath("grouped_failures_fixture"), pathToTest, false)
+	})
+
+	It("should not group failures by default", func() {
+		session := startGinkgo(pathToTest, "--noColor")
+		Eventually(session).Should(gexec.Exit(1))
+
+		Ω(session.Out.Contents()).ShouldNot(ContainSubstring("by Fingerprint"))
+	})
+
+	It("should tell identical failures once in the summary, with the specs they affected", func() {
+		session := startGinkgo(pathToTest, "--noColor")
+		Eventually(session).Should(gexec.Exit(1))
+		output := string(session.Out.Contents())
+
+		Ω(output).Should(ContainSubstring("Summarizing 4 Failures:"))
+		Ω(output).Should(MatchRegexp(`\[Fail\] \[3x\] connection refused\n\S+grouped_failures_fixture_test.go:9\n\s+- GroupedFailuresFixture fails to connect once\n\s+- GroupedFailuresFixture fails to connect twice\n\s+- GroupedFailuresFixture fails to connect thrice\n`))
+		Ω(output).Should(ContainSubstring("[Fail] GroupedFailuresFixture [It] fails differently"))
+	})
+
+	It("should cluster the failures that share a fingerprint", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--groupFailures")
+		Eventually(session).Should(gexec.Exit(1))
+		output := string(session.Out.Contents())
+
+		Ω(output).Should(ContainSubstring("Grouping 4 Failures by Fingerprint into 2 Groups:"))
+		Ω(output).Should(MatchRegexp(`\[3x\] [0-9a-f]{16} connection refused\n\s+\S+grouped_failures_fixture_test.go:9\n\s+- GroupedFailuresFixture fails to connect once\n\s+- GroupedFailuresFixture fails to connect twice\n\s+- GroupedFailuresFixture fails to connect thrice\n`))
+		Ω(output).Should(MatchRegexp(`\[1x\] [0-9a-f]{16} something else\n`))
+		Ω(output).ShouldNot(ContainSubstring("Summarizing 4 Failures:"))
+		Ω(output).ShouldNot(ContainSubstring("[Fail]"))
+	})
+
+	It("should group the failures of every parallel node together", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--groupFailures", "--nodes=2")
+		Eventually(session).Should(gexec.Exit(1))
+		output := string(session.Out.Contents())
+
+		Ω(output).Should(ContainSubstring("Grouping 4 Failures by Fingerprint into 2 Groups:"))
+		Ω(regexp.MustCompile(`\[3x\] ([0-9a-f]{16}) connection refused`).FindStringSubmatch(output)).Should(HaveLen(2))
+	})
+})
@@ -0,0 +1,28 @@
+package integration_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("GinkgoHelper", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("helper")
+		copyIn(fixturePath("helper_fixture"), pathToTest, false)
+	})
+
+	It("should report the failures raised in helpers at the location of their callers", func() {
+		session := startGinkgo(pathToTest, "--noColor")
+		Eventually(session).Should(gexec.Exit(1))
+
+		output := string(session.Out.Contents())
+		Ω(output).Should(ContainSubstring("helper_fixture_test.go:27"))
+		Ω(output).Should(ContainSubstring("failed in a helper"))
+		Ω(output).Should(ContainSubstring("helper_fixture_test.go:31"))
+		Ω(output).ShouldNot(ContainSubstring("helper_fixture_test.go:10"))
+		Ω(output).ShouldNot(ContainSubstring("helper_fixture_test.go:22"))
+	})
+})
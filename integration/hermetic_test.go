@@ -0,0 +1,40 @@
+package integration_test
+
+import (
+	"runtime"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("Hermetic", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		if runtime.GOOS != "linux" {
+			Skip("-hermetic is only enforced on Linux")
+		}
+		pathToTest = tmpPath("hermetic")
+		copyIn(fixturePath("hermetic_fixture"), pathToTest, false)
+	})
+
+	It("should fail the specs that escape their sandbox, unless HermeticAllow allows it", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--hermetic")
+		Eventually(session).Should(gexec.Exit(1))
+		output := string(session.Out.Contents())
+
+		Ω(output).Should(MatchRegexp(`This spec is not hermetic, see -hermetic and HermeticAllow:\n\s*opened \S*escaped\.txt for writing`))
+		Ω(output).Should(MatchRegexp(`This spec is not hermetic, see -hermetic and HermeticAllow:\n\s*listened on 127\.0\.0\.1:\d+ over tcp`))
+		Ω(output).ShouldNot(ContainSubstring("allowed.txt"))
+		Ω(output).ShouldNot(ContainSubstring("hermetic_fixture.txt"))
+		Ω(output).Should(ContainSubstring("5 Passed | 2 Failed"))
+	})
+
+	It("should let the specs escape their sandbox without -hermetic", func() {
+		session := startGinkgo(pathToTest, "--noColor")
+		Eventually(session).Should(gexec.Exit(0))
+
+		Ω(session.Out.Contents()).Should(ContainSubstring("7 Passed | 0 Failed"))
+	})
+})
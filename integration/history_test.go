@@ -0,0 +1,48 @@
+package integration_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("ginkgo history", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("history")
+		copyIn(fixturePath("history_fixture"), pathToTest, false)
+	})
+
+	It("should complain when no run history was recorded", func() {
+		session := startGinkgo(pathToTest, "history")
+		Eventually(session).Should(gexec.Exit(1))
+		Ω(session.Err.Contents()).Should(ContainSubstring("Found no run history in .ginkgo-history"))
+	})
+
+	It("should show the trend of the runs recorded with -historyDir, whether they ran in parallel or not", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--historyDir=.ginkgo-history")
+		Eventually(session).Should(gexec.Exit(0))
+
+		Ω(ioutil.WriteFile(filepath.Join(pathToTest, "fail"), []byte{}, 0666)).Should(Succeed())
+		session = startGinkgo(pathToTest, "--noColor", "--nodes=2", "--historyDir=.ginkgo-history")
+		Eventually(session).Should(gexec.Exit(1))
+
+		reports, err := filepath.Glob(filepath.Join(pathToTest, ".ginkgo-history", "*.json"))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(reports).Should(HaveLen(2))
+
+		session = startGinkgo(pathToTest, "history", "-n=5")
+		Eventually(session).Should(gexec.Exit(0))
+
+		output := string(session.Out.Contents())
+		Ω(output).Should(ContainSubstring("HistoryFixture Suite (last 2 run(s))"))
+		Ω(output).Should(MatchRegexp(`PASS  100% of 2 spec\(s\) passed in`))
+		Ω(output).Should(MatchRegexp(`FAIL   50% of 2 spec\(s\) passed in`))
+		Ω(output).Should(ContainSubstring("Pass rate declining: 100% -> 50%"))
+		Ω(output).Should(ContainSubstring("Newly flaky specs:\n    HistoryFixture flips\n"))
+	})
+})
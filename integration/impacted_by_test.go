@@ -0,0 +1,101 @@
+package integration_test
+
+import (
+	"io/ioutil"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("ImpactedBy", func() {
+	var pathToTest string
+
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-c", "user.name=ginkgo", "-c", "user.email=ginkgo@example.com"}, args...)...)
+		cmd.Dir = pathToTest
+		output, err := cmd.CombinedOutput()
+		Ω(err).ShouldNot(HaveOccurred(), string(output))
+	}
+
+	replaceIn := func(path string, old string, new string) {
+		original, err := ioutil.ReadFile(path)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(string(original)).Should(ContainSubstring(old))
+		err = ioutil.WriteFile(path, []byte(strings.Replace(string(original), old, new, 1)), 0666)
+		Ω(err).ShouldNot(HaveOccurred())
+	}
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("spec_coverage")
+		copyIn(fixturePath("spec_coverage_fixture"), pathToTest, false)
+
+		git("init", "-q")
+		git("add", "-A")
+		git("commit", "-q", "-m", "initial")
+	})
+
+	Context("with coverage data", func() {
+		BeforeEach(func() {
+			session := startGinkgo(pathToTest, "coverage")
+			Eventually(session).Should(gexec.Exit(0))
+		})
+
+		It("runs only the specs that covered a changed function", func() {
+			replaceIn(filepath.Join(pathToTest, "spec_coverage.go"), "return -a", "return 0 - a")
+
+			session := startGinkgo(pathToTest, "--noColor", "-v", "-impactedBy=HEAD")
+			Eventually(session).Should(gexec.Exit(0))
+			output := string(session.Out.Contents())
+
+			Ω(output).Should(ContainSubstring("negates"))
+			Ω(output).ShouldNot(ContainSubstring("adds"))
+			Ω(output).Should(ContainSubstring("Ran 1 of 3 Specs"))
+		})
+
+		It("runs the specs defined in a changed test file", func() {
+			replaceIn(filepath.Join(pathToTest, "spec_coverage_fixture_test.go"), "Equal(3)", "BeNumerically(\"==\", 3)")
+
+			session := startGinkgo(pathToTest, "--noColor", "-impactedBy=HEAD")
+			Eventually(session).Should(gexec.Exit(0))
+			Ω(session.Out.Contents()).Should(ContainSubstring("Ran 2 of 3 Specs"))
+		})
+
+		It("skips the suite when no spec covered the changed functions", func() {
+			replaceIn(filepath.Join(pathToTest, "spec_coverage.go"), "func Unused() {\n", "func Unused() {\n\tprintln()\n")
+
+			session := startGinkgo(pathToTest, "--noColor", "-impactedBy=HEAD")
+			Eventually(session).Should(gexec.Exit(0))
+			output := string(session.Out.Contents())
+
+			Ω(output).Should(ContainSubstring("Will skip suites with no spec impacted by HEAD"))
+			Ω(output).Should(ContainSubstring("No specs impacted by changes!"))
+			Ω(output).ShouldNot(ContainSubstring("Ran "))
+		})
+
+		It("runs every spec when a change cannot be attributed to a function", func() {
+			replaceIn(filepath.Join(pathToTest, "spec_coverage.go"), "func Unused", "var Offset = 0\n\nfunc Unused")
+
+			session := startGinkgo(pathToTest, "--noColor", "-impactedBy=HEAD")
+			Eventually(session).Should(gexec.Exit(0))
+			output := string(session.Out.Contents())
+
+			Ω(output).Should(ContainSubstring("Will run every spec, as some changes to these files cannot be attributed to functions"))
+			Ω(output).Should(ContainSubstring("Ran 2 of 3 Specs"))
+		})
+	})
+
+	It("runs every spec of the suites without coverage data", func() {
+		replaceIn(filepath.Join(pathToTest, "spec_coverage.go"), "return -a", "return 0 - a")
+
+		session := startGinkgo(pathToTest, "--noColor", "-impactedBy=HEAD")
+		Eventually(session).Should(gexec.Exit(0))
+		output := string(session.Out.Contents())
+
+		Ω(output).Should(ContainSubstring("Will run every spec of suites without coverage data"))
+		Ω(output).Should(ContainSubstring("Ran 2 of 3 Specs"))
+	})
+})
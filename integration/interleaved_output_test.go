@@ -0,0 +1,24 @@
+package integration_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("Interleaved output", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("interleaved_output")
+		copyIn(fixturePath("interleaved_output_fixture"), pathToTest, false)
+	})
+
+	It("should report the GinkgoWriter output of failed specs among their stdout and stderr, in the order they were written", func() {
+		session := startGinkgo(pathToTest, "--noColor", "-nodes=2")
+		Eventually(session).Should(gexec.Exit(1))
+		Ω(session).Should(gbytes.Say(`\[stdout/stderr\] stdout 1\n\[GinkgoWriter\] writer 1\n\[stdout/stderr\] stderr 1\n\[GinkgoWriter\] writer 2\n\[stdout/stderr\] stdout 2\n`))
+		Ω(session.Out.Contents()).ShouldNot(ContainSubstring("passing writer"))
+	})
+})
@@ -1,7 +1,9 @@
 package integration_test
 
 import (
+	"io/ioutil"
 	"os/exec"
+	"path/filepath"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -44,8 +46,86 @@ var _ = Describe("Interrupt", func() {
 			Ω(session).Should(gbytes.Say("Hanging Out"))
 		})
 
+		It("should emit the progress of the running spec", func() {
+			Ω(session.Err).Should(gbytes.Say("Progress of the running spec:"))
+			Ω(session.Err).Should(gbytes.Say(`HangingSuite inner context should hang out for a while`))
+			Ω(session.Err).Should(gbytes.Say(`In \[It\] at .*hanging_suite_test.go:\d+ \(running for`))
+			Ω(session.Err).Should(gbytes.Say(`At step \[By\] hanging out`))
+			Ω(session.Err).Should(gbytes.Say("still hanging out"))
+		})
+
 		It("should run the AfterSuite", func() {
 			Ω(session).Should(gbytes.Say("Heading Out After Suite"))
 		})
 	})
+
+	Context("when the go test deadline approaches", func() {
+		var session *gexec.Session
+		var reportPath string
+		BeforeEach(func() {
+			var err error
+			cmd := exec.Command("go", "test", "-c")
+			cmd.Dir = pathToTest
+			session, err = gexec.Start(cmd, GinkgoWriter, GinkgoWriter)
+			Ω(err).ShouldNot(HaveOccurred())
+			Eventually(session).Should(gexec.Exit(0))
+
+			reportPath, err = filepath.Abs(filepath.Join(pathToTest, "report.json"))
+			Ω(err).ShouldNot(HaveOccurred())
+			cmd = exec.Command("./hanging.test", "--test.timeout=3s", "--ginkgo.noColor", "--ginkgo.jsonReport="+reportPath)
+			cmd.Dir = pathToTest
+			session, err = gexec.Start(cmd, GinkgoWriter, GinkgoWriter)
+			Ω(err).ShouldNot(HaveOccurred())
+			Eventually(session, 10).Should(gexec.Exit(1))
+		})
+
+		It("should interrupt the running spec before go test kills the suite", func() {
+			Ω(session.Err).Should(gbytes.Say("Approaching the go test deadline"))
+			Ω(session.Err).Should(gbytes.Say("Progress of the running spec:"))
+			Ω(session.Err).ShouldNot(gbytes.Say("panic: test timed out"))
+			Ω(session).Should(gbytes.Say("Heading Out After Suite"))
+		})
+
+		It("should report the running spec as timed out and write the reports", func() {
+			Ω(session).Should(gbytes.Say("Interrupted shortly before the go test deadline"))
+			Ω(session).Should(gbytes.Say(`0 Passed \| 1 Failed`))
+
+			report, err := ioutil.ReadFile(reportPath)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(string(report)).Should(ContainSubstring(`"Message": "Interrupted shortly before the go test deadline (see -test.timeout)"`))
+		})
+
+		It("should record the suite timeout as a special suite failure reason", func() {
+			report, err := ioutil.ReadFile(reportPath)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(string(report)).Should(ContainSubstring(`"Cause": "suite-timeout"`))
+		})
+	})
+
+	Context("when the program running the suite interrupts it", func() {
+		var session *gexec.Session
+		var reportPath string
+		BeforeEach(func() {
+			pathToTest = tmpPath("interrupt_suite")
+			copyIn(fixturePath("interrupt_suite_fixture"), pathToTest, false)
+
+			var err error
+			reportPath, err = filepath.Abs(filepath.Join(pathToTest, "report.json"))
+			Ω(err).ShouldNot(HaveOccurred())
+			session = startGinkgo(pathToTest, "--noColor", "--jsonReport="+reportPath)
+			Eventually(session, 30).Should(gexec.Exit(1))
+		})
+
+		It("should run the AfterSuite, write the reports and return from RunSpecs", func() {
+			output := string(session.Out.Contents())
+			Ω(output).Should(ContainSubstring("The validation window closed.  Running AfterSuite..."))
+			Ω(output).Should(ContainSubstring("Heading Out After Suite"))
+			Ω(output).Should(ContainSubstring("RunSpecs returned false"))
+
+			report, err := ioutil.ReadFile(reportPath)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(string(report)).Should(ContainSubstring(`"Cause": "timeout"`))
+			Ω(string(report)).Should(ContainSubstring(`"Message": "The validation window closed."`))
+		})
+	})
 })
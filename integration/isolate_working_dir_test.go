@@ -0,0 +1,26 @@
+package integration_test
+
+import (
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("IsolateWorkingDir", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("isolate_working_dir")
+		copyIn(fixturePath("isolate_working_dir_fixture"), pathToTest, false)
+	})
+
+	It("should run the decorated specs in temporary working directories of their own", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--concurrency=3")
+		Eventually(session).Should(gexec.Exit(0))
+
+		Ω(session.Out.Contents()).Should(ContainSubstring("3 Passed"))
+		Ω(filepath.Join(pathToTest, "isolated.txt")).ShouldNot(BeAnExistingFile())
+	})
+})
@@ -0,0 +1,39 @@
+package integration_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("Output written to GinkgoWriter once a spec has ended", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("late_output")
+		copyIn(fixturePath("late_output_fixture"), pathToTest, false)
+	})
+
+	It("should be tagged with the spec that started the writer with -lateOutput=tag", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--lateOutput=tag")
+		Eventually(session).Should(gexec.Exit(1))
+		Ω(session).Should(gbytes.Say(`Warning: a goroutine started by "LateOutputFixture leaks a goroutine" wrote to GinkgoWriter after the spec ended.  Its late output is tagged with the spec.  It wrote from:`))
+		Ω(session).Should(gbytes.Say(`late_output_fixture_test.go`))
+		Ω(session).Should(gbytes.Say(`\[late output of "LateOutputFixture leaks a goroutine"\] written too late`))
+	})
+
+	It("should be dropped with -lateOutput=drop", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--lateOutput=drop")
+		Eventually(session).Should(gexec.Exit(1))
+		Ω(session).Should(gbytes.Say(`Its late output is dropped`))
+		Ω(session.Out.Contents()).ShouldNot(ContainSubstring("written too late"))
+	})
+
+	It("should be left alone by default", func() {
+		session := startGinkgo(pathToTest, "--noColor")
+		Eventually(session).Should(gexec.Exit(1))
+		Ω(session.Out.Contents()).ShouldNot(ContainSubstring("wrote to GinkgoWriter after the spec ended"))
+		Ω(session.Out.Contents()).ShouldNot(ContainSubstring("[late output of"))
+	})
+})
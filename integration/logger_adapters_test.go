@@ -0,0 +1,38 @@
+package integration_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("Logger adapters", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("logger_adapters")
+		copyIn(fixturePath("logger_adapters_fixture"), pathToTest, false)
+	})
+
+	It("should capture what the loggers write with the output of the spec, dropping what is below the log level", func() {
+		session := startGinkgo(pathToTest, "--noColor")
+		Eventually(session).Should(gexec.Exit(1))
+		output := string(session.Out.Contents())
+
+		Ω(output).Should(ContainSubstring("an info line"))
+		Ω(output).Should(ContainSubstring(`msg="a slog info record"`))
+		Ω(output).ShouldNot(ContainSubstring("a debug line"))
+		Ω(output).ShouldNot(ContainSubstring("a slog debug record"))
+		Ω(output).ShouldNot(ContainSubstring("a line of a passing spec"))
+	})
+
+	It("should capture the output of each spec when running in parallel", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--nodes=2")
+		Eventually(session).Should(gexec.Exit(1))
+		output := string(session.Out.Contents())
+
+		Ω(output).Should(ContainSubstring("an info line"))
+		Ω(output).Should(ContainSubstring(`msg="a slog info record"`))
+		Ω(output).ShouldNot(ContainSubstring("a line of a passing spec"))
+	})
+})
@@ -0,0 +1,62 @@
+package integration_test
+
+import (
+	"io/ioutil"
+	"net/http"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Labels and metrics", func() {
+	var (
+		pathToTest string
+		server     *ghttp.Server
+		pushed     chan string
+	)
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("labels")
+		copyIn(fixturePath("labels_fixture"), pathToTest, false)
+
+		pushed = make(chan string, 1)
+		server = ghttp.NewServer()
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", "/metrics/job/ci/suite@base64/TGFiZWxzRml4dHVyZSBTdWl0ZQ"),
+			func(w http.ResponseWriter, req *http.Request) {
+				body, _ := ioutil.ReadAll(req.Body)
+				pushed <- string(body)
+			},
+		))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should make the labels of a spec and its containers available to the spec", func() {
+		session := startGinkgo(pathToTest, "--noColor")
+		Eventually(session).Should(gexec.Exit(0))
+
+		Ω(session).Should(gbytes.Say(`is fast:\[storage,fast\]`))
+		Ω(session).Should(gbytes.Say(`is slow:\[storage,slow\]`))
+		Ω(session).Should(gbytes.Say(`has no labels:\[\]`))
+	})
+
+	It("should push a single set of metrics covering every parallel node", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--nodes=2", "--metricsPushgateway="+server.URL(), "--metricsJob=ci")
+		Eventually(session).Should(gexec.Exit(0))
+
+		var metrics string
+		Eventually(pushed).Should(Receive(&metrics))
+		Ω(metrics).Should(ContainSubstring(`ginkgo_specs_run_total{suite="LabelsFixture Suite"} 3`))
+		Ω(metrics).Should(ContainSubstring(`ginkgo_specs_failed_total{suite="LabelsFixture Suite"} 0`))
+		Ω(metrics).Should(ContainSubstring(`ginkgo_spec_duration_seconds_count{suite="LabelsFixture Suite",label="storage"} 2`))
+		Ω(metrics).Should(ContainSubstring(`ginkgo_spec_duration_seconds_count{suite="LabelsFixture Suite",label="fast"} 1`))
+		Ω(metrics).Should(ContainSubstring(`ginkgo_spec_duration_seconds_count{suite="LabelsFixture Suite",label=""} 1`))
+		Ω(metrics).Should(ContainSubstring(`ginkgo_suite_succeeded{suite="LabelsFixture Suite"} 1`))
+	})
+})
@@ -0,0 +1,27 @@
+package integration_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("Node timeout", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("node_timeout")
+		copyIn(fixturePath("node_timeout_fixture"), pathToTest, false)
+	})
+
+	It("should time out the nodes running longer than -nodeTimeout and carry on with the suite", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--nodeTimeout=0.5")
+		Eventually(session).Should(gexec.Exit(1))
+
+		output := string(session.Out.Contents())
+		Ω(output).Should(ContainSubstring("Timed out after 500ms"))
+		Ω(output).Should(ContainSubstring("node_timeout_fixture_test.go:10"))
+		Ω(output).Should(ContainSubstring("[Timeout...] a hung setup [BeforeEach] never runs"))
+		Ω(output).Should(ContainSubstring("1 Passed | 1 Failed"))
+	})
+})
@@ -0,0 +1,73 @@
+package integration_test
+
+import (
+	"os/exec"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/reporters"
+	"github.com/onsi/ginkgo/types"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("OnFailure", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("on_failure")
+		copyIn(fixturePath("on_failure_fixture"), pathToTest, false)
+	})
+
+	entries := func(summary *types.SpecSummary) []string {
+		names := []string{}
+		for _, entry := range summary.ReportEntries {
+			names = append(names, entry.Name)
+		}
+		return names
+	}
+
+	It("should run the hooks for the failed specs only, once they have unwound, even with -failFast", func() {
+		session := startGinkgo(pathToTest, "--noColor", "-v", "--seed=1", "--randomizeAllSpecs=false", "--failFast", "--skip=hangs", "--jsonReport=report.json")
+		Eventually(session).Should(gexec.Exit(1))
+		Ω(session).Should(gbytes.Say("cleaning up"))
+		Ω(session).Should(gbytes.Say("cleaning up"))
+		Ω(session).Should(gbytes.Say("gathering diagnostics for OnFailureFixture fails: boom"))
+		Ω(session.Out.Contents()).ShouldNot(ContainSubstring("gathering diagnostics for OnFailureFixture passes"))
+
+		report, err := reporters.ReadJSONReport(filepath.Join(pathToTest, "report.json"))
+		Ω(err).ShouldNot(HaveOccurred())
+		summaries := map[string]*types.SpecSummary{}
+		for _, summary := range report.SpecSummaries {
+			summaries[reporters.SpecFullText(summary)] = summary
+		}
+		Ω(entries(summaries["OnFailureFixture fails"])).Should(Equal([]string{"diagnostics"}))
+		Ω(entries(summaries["OnFailureFixture passes"])).Should(BeEmpty())
+		Ω(summaries["OnFailureFixture is not reached with -failFast"].State).Should(Equal(types.SpecStateSkipped))
+	})
+
+	It("should run the hooks for the running spec when the suite is interrupted", func() {
+		cmd := exec.Command("go", "test", "-c")
+		cmd.Dir = pathToTest
+		session, err := gexec.Start(cmd, GinkgoWriter, GinkgoWriter)
+		Ω(err).ShouldNot(HaveOccurred())
+		Eventually(session).Should(gexec.Exit(0))
+
+		reportPath, err := filepath.Abs(filepath.Join(pathToTest, "report.json"))
+		Ω(err).ShouldNot(HaveOccurred())
+		cmd = exec.Command("./on_failure.test", "--test.timeout=3s", "--ginkgo.noColor", "--ginkgo.focus=hangs", "--ginkgo.jsonReport="+reportPath)
+		cmd.Dir = pathToTest
+		session, err = gexec.Start(cmd, GinkgoWriter, GinkgoWriter)
+		Ω(err).ShouldNot(HaveOccurred())
+		Eventually(session, 10).Should(gexec.Exit(1))
+		Ω(session).Should(gbytes.Say(`gathering diagnostics for OnFailureFixture hangs: Approaching the go test deadline`))
+
+		report, err := reporters.ReadJSONReport(reportPath)
+		Ω(err).ShouldNot(HaveOccurred())
+		hanging := report.SpecSummaries[len(report.SpecSummaries)-1]
+		Ω(reporters.SpecFullText(hanging)).Should(Equal("OnFailureFixture hangs"))
+		Ω(hanging.State).Should(Equal(types.SpecStateTimedOut))
+		Ω(entries(hanging)).Should(Equal([]string{"diagnostics"}))
+	})
+})
@@ -0,0 +1,50 @@
+package integration_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("OncePerContainer", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("once_per_container")
+		copyIn(fixturePath("once_per_container_fixture"), pathToTest, false)
+	})
+
+	readLog := func() []string {
+		data, err := ioutil.ReadFile(filepath.Join(pathToTest, "once_per_container.log"))
+		Ω(err).ShouldNot(HaveOccurred())
+		return strings.Split(strings.TrimSpace(string(data)), "\n")
+	}
+
+	It("should set up and tear down the container once", func() {
+		session := startGinkgo(pathToTest, "--noColor")
+		Eventually(session).Should(gexec.Exit(0))
+
+		Ω(readLog()).Should(Equal([]string{"setup 1", "teardown 1"}))
+	})
+
+	It("should set up and tear down the container once per parallel node", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--nodes=2")
+		Eventually(session).Should(gexec.Exit(0))
+
+		setups, teardowns := []string{}, []string{}
+		for _, line := range readLog() {
+			if strings.HasPrefix(line, "setup ") {
+				setups = append(setups, strings.TrimPrefix(line, "setup "))
+			} else {
+				teardowns = append(teardowns, strings.TrimPrefix(line, "teardown "))
+			}
+		}
+		Ω(setups).ShouldNot(BeEmpty())
+		Ω(setups).Should(ConsistOf(teardowns))
+		Ω(setups).Should(Or(ConsistOf("1"), ConsistOf("2"), ConsistOf("1", "2")))
+	})
+})
@@ -0,0 +1,72 @@
+package integration_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("OpenTelemetry trace export", func() {
+	var (
+		pathToTest string
+		server     *ghttp.Server
+		spanNames  chan []string
+	)
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("passing")
+		copyIn(fixturePath("passing_ginkgo_tests"), pathToTest, false)
+
+		spanNames = make(chan []string, 1)
+		server = ghttp.NewServer()
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("POST", "/v1/traces"),
+			func(w http.ResponseWriter, req *http.Request) {
+				var request struct {
+					ResourceSpans []struct {
+						ScopeSpans []struct {
+							Spans []struct{ Name string }
+						}
+					}
+				}
+				body, _ := ioutil.ReadAll(req.Body)
+				json.Unmarshal(body, &request)
+				names := []string{}
+				for _, resourceSpans := range request.ResourceSpans {
+					for _, scopeSpans := range resourceSpans.ScopeSpans {
+						for _, span := range scopeSpans.Spans {
+							names = append(names, span.Name)
+						}
+					}
+				}
+				spanNames <- names
+			},
+		))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should export a single trace covering every parallel node", func() {
+		cmd := ginkgoCommand(pathToTest, "--noColor", "--nodes=2")
+		cmd.Env = append(os.Environ(), "OTEL_EXPORTER_OTLP_ENDPOINT="+server.URL())
+		session, err := gexec.Start(cmd, GinkgoWriter, GinkgoWriter)
+		Ω(err).ShouldNot(HaveOccurred())
+		Eventually(session).Should(gexec.Exit(0))
+		Ω(session.Out.Contents()).Should(ContainSubstring("OpenTelemetry trace"))
+
+		var names []string
+		Eventually(spanNames).Should(Receive(&names))
+		Ω(names).Should(ContainElement("Passing_ginkgo_tests Suite"))
+		Ω(names).Should(ContainElement("PassingGinkgoTests should proxy strings"))
+		Ω(names).Should(ContainElement("[It] should proxy strings"))
+		Ω(names).Should(HaveLen(9))
+	})
+})
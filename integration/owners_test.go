@@ -0,0 +1,49 @@
+package integration_test
+
+import (
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/reporters"
+	"github.com/onsi/ginkgo/types"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("Spec owners", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("owners")
+		copyIn(fixturePath("owners_fixture"), pathToTest, false)
+	})
+
+	It("should summarize the failures by owner", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--jsonReport=report.json")
+		Eventually(session).Should(gexec.Exit(1))
+
+		output := string(session.Out.Contents())
+		Ω(output).Should(MatchRegexp(`Failures by Owner:\s+\[1x\] team-billing\s+- billing charges the card\s+\[1x\] team-search\s+- billing searches the invoices\s+\[1x\] \(no owner\)\s+- reporting renders the dashboard`))
+
+		report, err := reporters.ReadJSONReport(filepath.Join(pathToTest, "report.json"))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(report.FailuresByOwner).Should(Equal(map[string][]string{
+			"team-billing": {"billing charges the card"},
+			"team-search":  {"billing searches the invoices"},
+			types.NoOwner:  {"reporting renders the dashboard"},
+		}))
+	})
+
+	It("should read the owners of the specs that have none from -codeOwners, when running in parallel too", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--nodes=2", "--codeOwners=CODEOWNERS", "--jsonReport=report.json")
+		Eventually(session).Should(gexec.Exit(1))
+
+		output := string(session.Out.Contents())
+		Ω(output).Should(MatchRegexp(`\[1x\] @org/reporting\s+- reporting renders the dashboard`))
+		Ω(output).ShouldNot(ContainSubstring(types.NoOwner))
+
+		report, err := reporters.ReadJSONReport(filepath.Join(pathToTest, "report.json"))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(report.FailuresByOwner).Should(HaveKeyWithValue("@org/reporting", []string{"reporting renders the dashboard"}))
+	})
+})
@@ -0,0 +1,40 @@
+package integration_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("Parallel allocation", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("parallel_allocation")
+		copyIn(fixturePath("parallel_allocation_fixture"), pathToTest, false)
+	})
+
+	It("should hand out ports and namespaces that do not conflict across parallel nodes", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--nodes=2")
+		Eventually(session).Should(gexec.Exit(0))
+
+		data, err := ioutil.ReadFile(filepath.Join(pathToTest, "allocations.log"))
+		Ω(err).ShouldNot(HaveOccurred())
+		lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+		Ω(lines).Should(HaveLen(4))
+
+		ports, namespaces := map[string]bool{}, map[string]bool{}
+		for _, line := range lines {
+			fields := strings.Fields(line)
+			Ω(fields[1]).Should(HavePrefix("books-"))
+			ports[fields[0]] = true
+			namespaces[fields[1]] = true
+		}
+		Ω(ports).Should(HaveLen(4))
+		Ω(namespaces).Should(HaveLen(4))
+	})
+})
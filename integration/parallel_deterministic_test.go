@@ -0,0 +1,49 @@
+package integration_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("ParallelDeterministic", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("parallel_deterministic")
+		copyIn(fixturePath("parallel_deterministic_fixture"), pathToTest, false)
+	})
+
+	//assignments runs the suite and returns the specs each node ran, in the order it ran them
+	assignments := func(args ...string) map[string][]string {
+		logPath := filepath.Join(pathToTest, "assignments.log")
+		os.Remove(logPath)
+		session := startGinkgo(pathToTest, append([]string{"--noColor"}, args...)...)
+		Eventually(session).Should(gexec.Exit(0))
+
+		data, err := ioutil.ReadFile(logPath)
+		Ω(err).ShouldNot(HaveOccurred())
+		result := map[string][]string{}
+		for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+			fields := strings.Fields(line)
+			result[fields[0]] = append(result[fields[0]], fields[1])
+		}
+		return result
+	}
+
+	It("should assign the same specs to the same nodes in the same order given the same seed", func() {
+		first := assignments("--nodes=3", "--randomizeAllSpecs", "--seed=17", "--parallelDeterministic")
+		Ω(first).Should(HaveLen(3))
+		for _, specs := range first {
+			Ω(specs).Should(HaveLen(4))
+		}
+
+		second := assignments("--nodes=3", "--randomizeAllSpecs", "--seed=17", "--parallelDeterministic")
+		Ω(second).Should(Equal(first))
+	})
+})
@@ -0,0 +1,28 @@
+package integration_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("ParallelKV", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("parallel_kv")
+		copyIn(fixturePath("parallel_kv_fixture"), pathToTest, false)
+	})
+
+	It("should share values within a serial run", func() {
+		session := startGinkgo(pathToTest, "--noColor")
+		Eventually(session).Should(gexec.Exit(0))
+	})
+
+	It("should share values across parallel nodes", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--nodes=2")
+		Eventually(session).Should(gexec.Exit(0))
+		Ω(session).Should(gbytes.Say("Ran 2 of 2 Specs"))
+	})
+})
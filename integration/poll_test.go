@@ -0,0 +1,40 @@
+package integration_test
+
+import (
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/reporters"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("Poll", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("poll")
+		copyIn(fixturePath("poll_fixture"), pathToTest, false)
+	})
+
+	It("should retry the body until it succeeds or times out, recording failed attempts as steps", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--jsonReport=report.json")
+		Eventually(session).Should(gexec.Exit(1))
+		output := string(session.Out.Contents())
+
+		Ω(output).Should(ContainSubstring("1 Passed"))
+		Ω(output).Should(ContainSubstring("1 Failed"))
+		Ω(output).Should(MatchRegexp(`Timed out after 100ms polling every 10ms: \d+ attempts failed, the last one with:\s+still down`))
+
+		report, err := reporters.ReadJSONReport(filepath.Join(pathToTest, "report.json"))
+		Ω(err).ShouldNot(HaveOccurred())
+		steps := map[string][]string{}
+		for _, summary := range report.SpecSummaries {
+			for _, step := range summary.Steps {
+				steps[summary.ComponentTexts[len(summary.ComponentTexts)-1]] = append(steps[summary.ComponentTexts[len(summary.ComponentTexts)-1]], step.Text)
+			}
+		}
+		Ω(steps["eventually succeeds"]).Should(Equal([]string{"Poll attempt 1 failed: not yet: 1", "Poll attempt 2 failed: not yet: 2"}))
+		Ω(steps["never succeeds"]).Should(ContainElement("Poll attempt 1 failed: still down"))
+	})
+})
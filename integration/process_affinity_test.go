@@ -0,0 +1,49 @@
+package integration_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("ProcessAffinity", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("process_affinity")
+		copyIn(fixturePath("process_affinity_fixture"), pathToTest, false)
+	})
+
+	processes := func() map[string]string {
+		data, err := ioutil.ReadFile(filepath.Join(pathToTest, "processes.log"))
+		Ω(err).ShouldNot(HaveOccurred())
+		result := map[string]string{}
+		for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+			fields := strings.Fields(line)
+			result[fields[0]] = fields[1]
+		}
+		return result
+	}
+
+	It("should run the specs of a pinned container on their process", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--nodes=3", "--randomizeAllSpecs")
+		Eventually(session).Should(gexec.Exit(0))
+
+		result := processes()
+		Ω(result).Should(HaveLen(8))
+		for _, spec := range []string{"pinned-A", "pinned-B", "pinned-C", "pinned-D"} {
+			Ω(result).Should(HaveKeyWithValue(spec, "2"))
+		}
+	})
+
+	It("should run every spec when running in series", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--nodes=1")
+		Eventually(session).Should(gexec.Exit(0))
+
+		Ω(processes()).Should(HaveLen(8))
+	})
+})
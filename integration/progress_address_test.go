@@ -0,0 +1,39 @@
+package integration_test
+
+import (
+	"net"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("-progressAddress", func() {
+	var (
+		pathToTest string
+		address    string
+	)
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("progress_address")
+		copyIn(fixturePath("progress_address_fixture"), pathToTest, false)
+
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		Ω(err).ShouldNot(HaveOccurred())
+		address = listener.Addr().String()
+		listener.Close()
+	})
+
+	It("should serve the progress of the suite run from the test process", func() {
+		session := startGinkgo(pathToTest, "--noColor", "-v", "--progressAddress="+address)
+		Eventually(session).Should(gexec.Exit(0))
+		Ω(session).Should(gbytes.Say(`polled "ProgressAddressFixture Suite": 2 spec\(s\) to run, finished: false`))
+	})
+
+	It("should serve the progress of the suite run from the CLI when running in parallel", func() {
+		session := startGinkgo(pathToTest, "--noColor", "-v", "--nodes=2", "--progressAddress="+address)
+		Eventually(session).Should(gexec.Exit(0))
+		Ω(session).Should(gbytes.Say(`polled "ProgressAddressFixture Suite": -1 spec\(s\) to run, finished: false`))
+	})
+})
@@ -0,0 +1,48 @@
+package integration_test
+
+import (
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/reporters"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("Quiet", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("quiet")
+		copyIn(fixturePath("quiet_fixture"), pathToTest, false)
+	})
+
+	expectQuietOutput := func(output string) {
+		Ω(output).Should(ContainSubstring("QuietFixture fails"))
+		Ω(output).Should(ContainSubstring("quietly failed"))
+		Ω(output).Should(ContainSubstring("Ran 6 of 7 Specs"))
+		Ω(output).ShouldNot(ContainSubstring("Running Suite"))
+		Ω(output).ShouldNot(ContainSubstring("QuietFixture passes"))
+		Ω(output).ShouldNot(ContainSubstring("•P"))
+	}
+
+	It("should print only the failures and the summary, even with -v", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--quiet", "-v", "--jsonReport=report.json")
+		Eventually(session).Should(gexec.Exit(1))
+		expectQuietOutput(string(session.Out.Contents()))
+
+		report, err := reporters.ReadJSONReport(filepath.Join(pathToTest, "report.json"))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(report.SpecSummaries).Should(HaveLen(7))
+	})
+
+	It("should print only the failures and the summary when running in parallel", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--quiet", "--nodes=2", "--jsonReport=report.json")
+		Eventually(session).Should(gexec.Exit(1))
+		expectQuietOutput(string(session.Out.Contents()))
+
+		report, err := reporters.ReadJSONReport(filepath.Join(pathToTest, "report.json"))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(report.SpecSummaries).Should(HaveLen(7))
+	})
+})
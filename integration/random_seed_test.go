@@ -0,0 +1,39 @@
+package integration_test
+
+import (
+	"regexp"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("RandomSeed", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("random_seed")
+		copyIn(fixturePath("random_seed_fixture"), pathToTest, false)
+	})
+
+	draws := regexp.MustCompile(`(global|time-based): \d+`)
+
+	run := func(args ...string) string {
+		session := startGinkgo(pathToTest, append([]string{"--noColor"}, args...)...)
+		Eventually(session).Should(gexec.Exit(1))
+		return string(session.Out.Contents())
+	}
+
+	It("should draw the same numbers from math/rand and time-based sources given the same seed", func() {
+		output := run("--seedMathRand", "--seed=5")
+		Ω(draws.FindAllString(output, -1)).Should(HaveLen(2))
+		Ω(draws.FindAllString(run("--seedMathRand", "--seed=5", "--randomizeAllSpecs"), -1)).Should(ConsistOf(draws.FindAllString(output, -1)))
+		Ω(draws.FindAllString(run("--seedMathRand", "--seed=6"), -1)).ShouldNot(ContainElement(draws.FindAllString(output, -1)[0]))
+	})
+
+	It("should flag time-based seeds in the report of the failing spec", func() {
+		output := run()
+		Ω(output).Should(ContainSubstring("Sources of nondeterminism detected while the spec ran:"))
+		Ω(output).Should(MatchRegexp(`time-based random seed \d+ replaced with -?\d+ at .*random_seed_fixture_test.go:\d+`))
+	})
+})
@@ -0,0 +1,28 @@
+package integration_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("GinkgoRecoverAndReport", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("recover_and_report")
+		copyIn(fixturePath("recover_and_report_fixture"), pathToTest, false)
+	})
+
+	It("should let the spec carry on and fail it with the failures of the goroutine", func() {
+		session := startGinkgo(pathToTest, "--noColor")
+		Eventually(session).Should(gexec.Exit(1))
+
+		output := string(session.Out.Contents())
+		Ω(output).Should(MatchRegexp(`Expected\s+<string>: background\s+to equal\s+<string>: foreground`))
+		Ω(output).Should(ContainSubstring("the spec carried on"))
+		Ω(output).Should(ContainSubstring("Test Panicked"))
+		Ω(output).Should(ContainSubstring("kaboom"))
+		Ω(output).Should(ContainSubstring("1 Passed | 2 Failed"))
+	})
+})
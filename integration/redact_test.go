@@ -0,0 +1,36 @@
+package integration_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("Redaction", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("redact")
+		copyIn(fixturePath("redact_fixture"), pathToTest, false)
+	})
+
+	It("should redact the captured output with -redact and RedactOutput", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--redact=hunter[0-9]")
+		Eventually(session).Should(gexec.Exit(1))
+
+		output := string(session.Out.Contents())
+		Ω(output).Should(ContainSubstring("token: [TOKEN], password: [REDACTED]"))
+		Ω(output).ShouldNot(ContainSubstring("tok-1234"))
+		Ω(output).ShouldNot(ContainSubstring("hunter2"))
+	})
+
+	It("should redact the output captured by parallel nodes", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--redact=hunter[0-9]", "-nodes=2")
+		Eventually(session).Should(gexec.Exit(1))
+
+		output := string(session.Out.Contents())
+		Ω(output).Should(ContainSubstring("token: [TOKEN], password: [REDACTED]"))
+		Ω(output).ShouldNot(ContainSubstring("tok-1234"))
+		Ω(output).ShouldNot(ContainSubstring("hunter2"))
+	})
+})
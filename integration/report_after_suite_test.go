@@ -0,0 +1,60 @@
+package integration_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("ReportAfterSuite", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("report_after_suite")
+		copyIn(fixturePath("report_after_suite_fixture"), pathToTest, false)
+	})
+
+	reports := func() string {
+		data, err := ioutil.ReadFile(filepath.Join(pathToTest, "reports.log"))
+		Ω(err).ShouldNot(HaveOccurred())
+		return string(data)
+	}
+
+	It("should run the ReportAfterSuite nodes in order with the report of the whole suite", func() {
+		session := startGinkgo(pathToTest, "--noColor")
+		Eventually(session).Should(gexec.Exit(0))
+
+		Ω(reports()).Should(Equal("checks the results node=1 specs=4 passed=4\nwrites the summary node=1 specs=4 passed=4\nuploads the results node=1 specs=4 passed=4\n"))
+	})
+
+	It("should run them once, on node 1, with the report aggregated across the parallel nodes", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--nodes=2")
+		Eventually(session).Should(gexec.Exit(0))
+
+		Ω(reports()).Should(Equal("checks the results node=1 specs=4 passed=4\nwrites the summary node=1 specs=4 passed=4\nuploads the results node=1 specs=4 passed=4\n"))
+	})
+
+	Context("when a ReportAfterSuite fails", func() {
+		BeforeEach(func() {
+			os.Setenv("FAIL_UPLOAD", "1")
+		})
+
+		AfterEach(func() {
+			os.Unsetenv("FAIL_UPLOAD")
+		})
+
+		It("should fail the suite, naming the ReportAfterSuite that failed and why", func() {
+			session := startGinkgo(pathToTest, "--noColor", "--nodes=2")
+			Eventually(session).Should(gexec.Exit(1))
+			output := string(session.Out.Contents())
+
+			Ω(output).Should(ContainSubstring("[ReportAfterSuite] uploads the results"))
+			Ω(output).Should(ContainSubstring("the dashboard is down"))
+			Ω(reports()).Should(ContainSubstring("uploads the results"))
+		})
+	})
+})
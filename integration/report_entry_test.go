@@ -0,0 +1,35 @@
+package integration_test
+
+import (
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/reporters"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("Report entries", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("report_entry")
+		copyIn(fixturePath("report_entry_fixture"), pathToTest, false)
+	})
+
+	It("should render the entries on the console and in the JSON report separately", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--jsonReport=report.json")
+		Eventually(session).Should(gexec.Exit(1))
+
+		output := string(session.Out.Contents())
+		Ω(output).Should(MatchRegexp(`Report Entries:\s+latency - \S+report_entry_fixture_test.go:\d+\s+p50: 12ms\s+p99: 480ms\s+retries - \S+:\d+\s+3`))
+
+		report, err := reporters.ReadJSONReport(filepath.Join(pathToTest, "report.json"))
+		Ω(err).ShouldNot(HaveOccurred())
+		entries := report.SpecSummaries[0].ReportEntries
+		Ω(entries).Should(HaveLen(2))
+		Ω(entries[0].Name).Should(Equal("latency"))
+		Ω(entries[0].Value).Should(MatchJSON(`{"p50_ms": 12, "p99_ms": 480}`))
+		Ω(entries[1].Value).Should(MatchJSON(`3`))
+	})
+})
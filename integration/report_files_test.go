@@ -0,0 +1,180 @@
+package integration_test
+
+import (
+	"encoding/xml"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/reporters"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Report files", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("passing")
+		copyIn(fixturePath("passing_ginkgo_tests"), pathToTest, false)
+	})
+
+	readReport := func(name string) reporters.JSONReport {
+		report, err := reporters.ReadJSONReport(filepath.Join(pathToTest, name))
+		Ω(err).ShouldNot(HaveOccurred())
+		return report
+	}
+
+	Context("when running in series", func() {
+		It("should write a JSON report relative to the package", func() {
+			session := startGinkgo(pathToTest, "--noColor", "--jsonReport=out/report.json")
+			Eventually(session).Should(gexec.Exit(0))
+
+			report := readReport("out/report.json")
+			Ω(report.SuiteDescription).Should(Equal("Passing_ginkgo_tests Suite"))
+			Ω(report.SuiteSucceeded).Should(BeTrue())
+			Ω(report.SpecSummaries).Should(HaveLen(4))
+		})
+
+		It("should gzip a report whose name ends in .gz", func() {
+			session := startGinkgo(pathToTest, "--noColor", "--jsonReport=out/report.json.gz", "--xunitV2Report=out/xunit.xml.gz")
+			Eventually(session).Should(gexec.Exit(0))
+
+			report := readReport("out/report.json.gz")
+			Ω(report.SpecSummaries).Should(HaveLen(4))
+
+			data, err := ioutil.ReadFile(filepath.Join(pathToTest, "out/xunit.xml.gz"))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(data[:2]).Should(Equal([]byte{0x1f, 0x8b}))
+		})
+
+		It("should write a report to stdout with -", func() {
+			session := startGinkgo(pathToTest, "--noColor", "--jsonReport=-")
+			Eventually(session).Should(gexec.Exit(0))
+			Ω(session).Should(gbytes.Say(`"SuiteDescription": "Passing_ginkgo_tests Suite"`))
+			Ω(filepath.Join(pathToTest, "-")).ShouldNot(BeAnExistingFile())
+		})
+	})
+
+	Context("when running in parallel", func() {
+		It("should write a single JSON report covering every node", func() {
+			session := startGinkgo(pathToTest, "--noColor", "--nodes=2", "--jsonReport=report.json")
+			Eventually(session).Should(gexec.Exit(0))
+
+			report := readReport("report.json")
+			Ω(report.SuiteDescription).Should(Equal("Passing_ginkgo_tests Suite"))
+			Ω(report.SuiteSucceeded).Should(BeTrue())
+			Ω(report.SpecSummaries).Should(HaveLen(4))
+			Ω(report.SuiteSummary.NumberOfPassedSpecs).Should(Equal(4))
+		})
+
+		It("should write the report to the stdout of ginkgo with -", func() {
+			session := startGinkgo(pathToTest, "--noColor", "--nodes=2", "--jsonReport=-")
+			Eventually(session).Should(gexec.Exit(0))
+			Ω(session).Should(gbytes.Say(`"SuiteDescription": "Passing_ginkgo_tests Suite"`))
+			Ω(filepath.Join(pathToTest, "-")).ShouldNot(BeAnExistingFile())
+		})
+	})
+
+	Context("when writing Allure results", func() {
+		It("should write a result per spec and a single container covering every node", func() {
+			session := startGinkgo(pathToTest, "--noColor", "--nodes=2", "--allureResultsDir=allure-results")
+			Eventually(session).Should(gexec.Exit(0))
+
+			results, err := filepath.Glob(filepath.Join(pathToTest, "allure-results", "*-result.json"))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(results).Should(HaveLen(4))
+			containers, err := filepath.Glob(filepath.Join(pathToTest, "allure-results", "*-container.json"))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(containers).Should(HaveLen(1))
+		})
+	})
+
+	Context("when writing a SonarQube report", func() {
+		It("should write a single report covering every node", func() {
+			session := startGinkgo(pathToTest, "--noColor", "--nodes=2", "--sonarReport=sonar.xml")
+			Eventually(session).Should(gexec.Exit(0))
+
+			data, err := ioutil.ReadFile(filepath.Join(pathToTest, "sonar.xml"))
+			Ω(err).ShouldNot(HaveOccurred())
+			var executions reporters.SonarTestExecutions
+			Ω(xml.Unmarshal(data, &executions)).Should(Succeed())
+			Ω(executions.Files).Should(HaveLen(1))
+			Ω(executions.Files[0].Path).Should(HaveSuffix("passing_ginkgo_tests_test.go"))
+			Ω(executions.Files[0].TestCases).Should(HaveLen(4))
+		})
+	})
+
+	Context("when writing an xUnit.net v2 report", func() {
+		It("should write a single report covering every node", func() {
+			session := startGinkgo(pathToTest, "--noColor", "--nodes=2", "--xunitV2Report=xunit.xml")
+			Eventually(session).Should(gexec.Exit(0))
+
+			data, err := ioutil.ReadFile(filepath.Join(pathToTest, "xunit.xml"))
+			Ω(err).ShouldNot(HaveOccurred())
+			var assemblies reporters.XUnitV2Assemblies
+			Ω(xml.Unmarshal(data, &assemblies)).Should(Succeed())
+			Ω(assemblies.Assemblies).Should(HaveLen(1))
+			Ω(assemblies.Assemblies[0].Name).Should(Equal("Passing_ginkgo_tests Suite"))
+			Ω(assemblies.Assemblies[0].Total).Should(Equal(4))
+			Ω(assemblies.Assemblies[0].Passed).Should(Equal(4))
+		})
+	})
+
+	Context("when comparing against a baseline", func() {
+		It("should summarize the changes and write the diff file", func() {
+			session := startGinkgo(pathToTest, "--noColor", "--jsonReport=baseline.json")
+			Eventually(session).Should(gexec.Exit(0))
+
+			session = startGinkgo(pathToTest, "--noColor", "--nodes=2", "--baselineReport=baseline.json", "--baselineDiffFile=diff.json")
+			Eventually(session).Should(gexec.Exit(0))
+
+			output := string(session.Out.Contents())
+			Ω(output).Should(ContainSubstring("Comparison with baseline"))
+			Ω(output).Should(ContainSubstring("No changes compared to the baseline"))
+			Ω(filepath.Join(pathToTest, "diff.json")).Should(BeAnExistingFile())
+		})
+	})
+
+	Context("when using a timing store", func() {
+		It("should record the run times of every node", func() {
+			session := startGinkgo(pathToTest, "--noColor", "--nodes=2", "--timingStore=timings.json")
+			Eventually(session).Should(gexec.Exit(0))
+
+			store := reporters.NewFileTimingStore(filepath.Join(pathToTest, "timings.json"))
+			for _, spec := range []string{"should proxy strings", "should proxy integers", "should do it again", "should be able to run Bys"} {
+				_, ok := store.Get("PassingGinkgoTests " + spec)
+				Ω(ok).Should(BeTrue(), spec)
+			}
+
+			session = startGinkgo(pathToTest, "--noColor", "--nodes=2", "--timingStore=timings.json")
+			Eventually(session).Should(gexec.Exit(0))
+			Ω(session).Should(gbytes.Say("4 Passed"))
+		})
+	})
+
+	Context("when notifying a webhook", func() {
+		It("should post a single notification covering every node", func() {
+			payloads := make(chan string, 2)
+			server := ghttp.NewServer()
+			defer server.Close()
+			server.AllowUnhandledRequests = true
+			server.RouteToHandler("POST", "/hook", func(w http.ResponseWriter, req *http.Request) {
+				body, _ := ioutil.ReadAll(req.Body)
+				payloads <- string(body)
+			})
+
+			session := startGinkgo(pathToTest, "--noColor", "--nodes=2", "--notifyWebhook="+server.URL()+"/hook")
+			Eventually(session).Should(gexec.Exit(0))
+
+			var payload string
+			Ω(payloads).Should(Receive(&payload))
+			Ω(payload).Should(ContainSubstring("Passing_ginkgo_tests Suite passed"))
+			Ω(payload).Should(ContainSubstring("4 passed, 0 failed"))
+			Ω(payloads).ShouldNot(Receive())
+		})
+	})
+})
@@ -0,0 +1,42 @@
+package integration_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("RequiresFeature", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("requires_feature")
+		copyIn(fixturePath("requires_feature_fixture"), pathToTest, false)
+	})
+
+	It("should skip the specs requiring features -features does not enable, naming the missing feature", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--noisySkippings", "--features=gpu")
+		Eventually(session).Should(gexec.Exit(0))
+		output := string(session.Out.Contents())
+
+		Ω(output).Should(ContainSubstring(`Requires the feature "ipv6", which -features does not enable`))
+		Ω(output).ShouldNot(ContainSubstring(`Requires the feature "gpu"`))
+		Ω(output).Should(ContainSubstring("2 Passed | 0 Failed | 0 Pending | 1 Skipped"))
+	})
+
+	It("should skip all the specs requiring a feature without -features", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--noisySkippings")
+		Eventually(session).Should(gexec.Exit(0))
+		output := string(session.Out.Contents())
+
+		Ω(output).Should(ContainSubstring(`Requires the feature "gpu", which -features does not enable`))
+		Ω(output).Should(ContainSubstring("1 Passed | 0 Failed | 0 Pending | 2 Skipped"))
+	})
+
+	It("should run the specs whose features are all enabled", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--features=ipv6", "--features=gpu")
+		Eventually(session).Should(gexec.Exit(0))
+
+		Ω(session.Out.Contents()).Should(ContainSubstring("3 Passed | 0 Failed | 0 Pending | 0 Skipped"))
+	})
+})
@@ -0,0 +1,62 @@
+package integration_test
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("Rerun commands", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("rerun")
+		copyIn(fixturePath("rerun_fixture"), pathToTest, false)
+	})
+
+	It("should print the command rerunning each failed spec", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--seed=7")
+		Eventually(session).Should(gexec.Exit(1))
+		Ω(session).Should(gbytes.Say(`Rerun the 2 failed spec\(s\) with:\n`))
+		Ω(session).Should(gbytes.Say(`  cart can't add a missing item\n    ginkgo -seed=7 -focus='\^RerunFixture Suite \\\[Top Level\\\] cart can'\\''t add a missing item\$' .*rerun\n`))
+		Ω(session).Should(gbytes.Say(`  cart empties \(fast\)\n    ginkgo -seed=7 -focus='\^RerunFixture Suite \\\[Top Level\\\] cart empties \\\(fast\\\)\$' .*rerun\n`))
+	})
+
+	It("should print the commands of parallel runs too", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--seed=7", "-nodes=2", "--rerunCommands=go")
+		Eventually(session).Should(gexec.Exit(1))
+		Ω(session).Should(gbytes.Say(`Rerun the 2 failed spec\(s\) with:\n`))
+		Ω(session).Should(gbytes.Say(`go test .*rerun -ginkgo.seed=7 -ginkgo.focus=`))
+	})
+
+	It("should print nothing with -rerunCommands=none", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--rerunCommands=none")
+		Eventually(session).Should(gexec.Exit(1))
+		Ω(session).ShouldNot(gbytes.Say("Rerun the"))
+	})
+
+	It("should write a script rerunning exactly the failed specs with -rerunScript", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--seed=7", "--rerunScript=rerun.sh", "--jsonReport=report.json")
+		Eventually(session).Should(gexec.Exit(1))
+
+		data, err := ioutil.ReadFile(filepath.Join(pathToTest, "report.json"))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(string(data)).Should(ContainSubstring(`"RerunCommands": {`))
+
+		cmd := exec.Command("sh", filepath.Join(pathToTest, "rerun.sh"))
+		cmd.Env = append(os.Environ(), "PATH="+filepath.Dir(pathToGinkgo)+string(os.PathListSeparator)+os.Getenv("PATH"))
+		rerun, err := gexec.Start(cmd, GinkgoWriter, GinkgoWriter)
+		Ω(err).ShouldNot(HaveOccurred())
+		Eventually(rerun).Should(gexec.Exit(1))
+		Ω(rerun).Should(gbytes.Say(`Random Seed: \S*7`))
+		//the script does not pass -noColor
+		Ω(rerun).Should(gbytes.Say(`Ran \S*2\S* of \S*3\S* Specs`))
+		Ω(rerun).Should(gbytes.Say(`\S*0 Passed\S* \| \S*2 Failed`))
+	})
+})
@@ -0,0 +1,34 @@
+package integration_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("Resource locks", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("resource_lock")
+		copyIn(fixturePath("resource_lock_fixture"), pathToTest, false)
+	})
+
+	It("should never let two parallel nodes hold the same resource at the same time", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--nodes=3")
+		Eventually(session).Should(gexec.Exit(0))
+
+		data, err := ioutil.ReadFile(filepath.Join(pathToTest, "resource_lock.log"))
+		Ω(err).ShouldNot(HaveOccurred())
+		lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+		Ω(lines).Should(HaveLen(8))
+		for i := 0; i < len(lines); i += 2 {
+			Ω(lines[i]).Should(HavePrefix("start "))
+			Ω(lines[i+1]).Should(Equal("end " + strings.TrimPrefix(lines[i], "start ")))
+		}
+	})
+})
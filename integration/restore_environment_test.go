@@ -0,0 +1,37 @@
+package integration_test
+
+import (
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/reporters"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("Restoring the environment", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("restore_environment")
+		copyIn(fixturePath("restore_environment_fixture"), pathToTest, false)
+	})
+
+	It("should restore the environment after each spec and report the leaks", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--restoreEnvironment", "--jsonReport=report.json")
+		Eventually(session).Should(gexec.Exit(0))
+		Ω(session.Out.Contents()).Should(ContainSubstring("3 Passed"))
+
+		report, err := reporters.ReadJSONReport(filepath.Join(pathToTest, "report.json"))
+		Ω(err).ShouldNot(HaveOccurred())
+		leaks := map[string]string{}
+		for _, summary := range report.SpecSummaries {
+			for _, entry := range summary.ReportEntries {
+				leaks[reporters.SpecFullText(summary)] = entry.Name + ": " + entry.Representation
+			}
+		}
+		Ω(leaks).Should(Equal(map[string]string{
+			"the environment is leaked": `Environment leak: FIXTURE_LEAKED was set to "leaked"`,
+		}))
+	})
+})
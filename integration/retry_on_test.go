@@ -0,0 +1,36 @@
+package integration_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("RetryOn", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("retry_on")
+		copyIn(fixturePath("retry_on_fixture"), pathToTest, false)
+	})
+
+	It("should only retry the failures matching RetryOn or -retryOn", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--flakeAttempts=3", "--retryOn=timeout$")
+		Eventually(session).Should(gexec.Exit(1))
+		output := string(session.Out.Contents())
+
+		Ω(output).Should(ContainSubstring("dials the server attempt 2"))
+		Ω(output).Should(ContainSubstring("lists the buckets attempt 2"))
+		Ω(output).Should(ContainSubstring("follows -retryOn attempt 2"))
+		Ω(output).Should(ContainSubstring("compares the values attempt 1"))
+		Ω(output).ShouldNot(ContainSubstring("compares the values attempt 2"))
+		Ω(output).Should(ContainSubstring("3 Passed | 1 Failed | 3 Flaked"))
+	})
+
+	It("should retry any failure of the specs that are not decorated with RetryOn without -retryOn", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--flakeAttempts=3", "--focus=follows")
+		Eventually(session).Should(gexec.Exit(0))
+
+		Ω(session.Out.Contents()).Should(ContainSubstring("follows -retryOn attempt 2"))
+	})
+})
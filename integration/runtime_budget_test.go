@@ -0,0 +1,44 @@
+package integration_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("Runtime budgets", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("runtime_budget")
+		copyIn(fixturePath("runtime_budget_fixture"), pathToTest, false)
+	})
+
+	It("should flag the specs that run longer than the budget of their label, and count them", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--runtimeBudgets=budgets.txt")
+		Eventually(session).Should(gexec.Exit(0))
+		output := string(session.Out.Contents())
+
+		Ω(output).Should(MatchRegexp(`\[OVER BUDGET:\d+\.\d+ seconds\]\nRuntimeBudgetFixture\n.*\n\s*dawdles\n`))
+		Ω(output).Should(ContainSubstring(`Ran longer than the 100ms runtime budget of the label "unit"`))
+		Ω(output).Should(ContainSubstring("Over runtime budget -- unit: 1"))
+		Ω(output).ShouldNot(ContainSubstring("integration: 1"))
+	})
+
+	It("should fail the specs that run longer than the budget of their label with -failOnRuntimeBudgets", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--runtimeBudgets=budgets.txt", "--failOnRuntimeBudgets")
+		Eventually(session).Should(gexec.Exit(1))
+		output := string(session.Out.Contents())
+
+		Ω(output).Should(MatchRegexp(`This spec ran for \d+ms, longer than the 100ms runtime budget of the label "unit"`))
+		Ω(output).Should(ContainSubstring("2 Passed | 1 Failed"))
+		Ω(output).Should(ContainSubstring("Over runtime budget -- unit: 1"))
+	})
+
+	It("should count the specs over budget across the parallel nodes", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--runtimeBudgets=budgets.txt", "-nodes=2")
+		Eventually(session).Should(gexec.Exit(0))
+
+		Ω(session.Out.Contents()).Should(ContainSubstring("Over runtime budget -- unit: 1"))
+	})
+})
@@ -0,0 +1,50 @@
+package integration_test
+
+import (
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/reporters"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("Spec severities", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("severity")
+		copyIn(fixturePath("severity_fixture"), pathToTest, false)
+	})
+
+	It("should fail on any failure by default, breaking the failures down by severity", func() {
+		session := startGinkgo(pathToTest, "--noColor")
+		Eventually(session).Should(gexec.Exit(1))
+		Ω(string(session.Out.Contents())).Should(ContainSubstring("Failures by Severity: 1 low"))
+	})
+
+	It("should report the failures of lower severities than -failOnSeverity without failing", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--failOnSeverity=high")
+		Eventually(session).Should(gexec.Exit(0))
+
+		output := string(session.Out.Contents())
+		Ω(output).Should(ContainSubstring("no suggestions"))
+		Ω(output).Should(ContainSubstring("1 Passed | 1 Failed"))
+	})
+
+	It("should honor -failOnSeverity when running in parallel", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--nodes=2", "--failOnSeverity=high", "--jsonReport=report.json")
+		Eventually(session).Should(gexec.Exit(0))
+
+		report, err := reporters.ReadJSONReport(filepath.Join(pathToTest, "report.json"))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(report.SuiteSucceeded).Should(BeTrue())
+		Ω(report.FailuresBySeverity).Should(Equal(map[string]int{"low": 1}))
+	})
+
+	It("should reject an invalid -failOnSeverity", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--failOnSeverity=hgh")
+		Eventually(session).Should(gexec.Exit(1))
+		Ω(string(session.Out.Contents())).Should(ContainSubstring(`Invalid -failOnSeverity: unknown severity "hgh"`))
+	})
+})
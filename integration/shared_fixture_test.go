@@ -0,0 +1,45 @@
+package integration_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("SharedFixture", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("shared_fixture")
+		copyIn(fixturePath("shared_fixture_fixture"), pathToTest, false)
+	})
+
+	readLog := func() []string {
+		data, err := ioutil.ReadFile(filepath.Join(pathToTest, "shared_fixture.log"))
+		Ω(err).ShouldNot(HaveOccurred())
+		return strings.Split(strings.TrimSpace(string(data)), "\n")
+	}
+
+	It("should set the fixture up once and tear it down after the last spec declaring it", func() {
+		session := startGinkgo(pathToTest, "--noColor")
+		Eventually(session).Should(gexec.Exit(0))
+
+		Ω(readLog()).Should(Equal([]string{"A", "setup", "B", "teardown", "C"}))
+	})
+
+	It("should report the lifecycle of the fixture in the JSON report", func() {
+		jsonFile, err := filepath.Abs(filepath.Join(pathToTest, "report.json"))
+		Ω(err).ShouldNot(HaveOccurred())
+		session := startGinkgo(pathToTest, "--noColor", "--jsonReport="+jsonFile)
+		Eventually(session).Should(gexec.Exit(0))
+
+		report, err := ioutil.ReadFile(jsonFile)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(string(report)).Should(ContainSubstring(`"SharedFixtures"`))
+		Ω(string(report)).Should(ContainSubstring(`"TornDownAfter": "SharedFixtureFixture using the fixture B"`))
+	})
+})
@@ -0,0 +1,44 @@
+package integration_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("Skip files", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("passing")
+		copyIn(fixturePath("passing_ginkgo_tests"), pathToTest, false)
+
+		skipList := "# known failures\n" +
+			"proxy strings\n" +
+			"proxy integers ; expires=2999-12-31 ; reason=ISSUE-1\n" +
+			"do it again ; expires=2001-01-01 ; reason=ISSUE-2\n"
+		Ω(ioutil.WriteFile(filepath.Join(pathToTest, "known_failures.txt"), []byte(skipList), 0644)).Should(Succeed())
+	})
+
+	It("should skip the specs matching the entries that have not expired, and warn about those that have", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--skipFile=known_failures.txt")
+		Eventually(session).Should(gexec.Exit(0))
+
+		output := string(session.Out.Contents())
+		Ω(output).Should(ContainSubstring("Ran 2 of 4 Specs"))
+		Ω(output).Should(ContainSubstring(`known_failures.txt:4: skip list entry "do it again" expired on 2001-01-01 and no longer skips specs (reason: ISSUE-2)`))
+	})
+
+	It("should warn only once when running in parallel", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--nodes=2", "--skipFile=known_failures.txt")
+		Eventually(session).Should(gexec.Exit(0))
+
+		output := string(session.Out.Contents())
+		Ω(output).Should(ContainSubstring("Ran 2 of 4 Specs"))
+		Ω(strings.Count(output, "expired on 2001-01-01")).Should(Equal(1))
+	})
+})
@@ -0,0 +1,68 @@
+package integration_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("Snapshots", func() {
+	var pathToTest string
+	var snapshotsDir string
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("snapshot")
+		copyIn(fixturePath("snapshot_fixture"), pathToTest, false)
+		snapshotsDir = filepath.Join(pathToTest, "testdata", "__snapshots__", "invoice")
+	})
+
+	It("should fail on missing snapshots", func() {
+		session := startGinkgo(pathToTest, "--noColor")
+		Eventually(session).Should(gexec.Exit(1))
+		Ω(string(session.Out.Contents())).Should(ContainSubstring(`Snapshot "invoice/text" does not exist`))
+	})
+
+	Context("once the snapshots are written with -updateSnapshots", func() {
+		BeforeEach(func() {
+			session := startGinkgo(pathToTest, "--noColor", "--updateSnapshots")
+			Eventually(session).Should(gexec.Exit(0))
+		})
+
+		It("should have written them", func() {
+			text, err := ioutil.ReadFile(filepath.Join(snapshotsDir, "text.snap"))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(string(text)).Should(Equal("Invoice for Ada\nTotal: 42\n"))
+
+			encoded, err := ioutil.ReadFile(filepath.Join(snapshotsDir, "json.snap"))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(string(encoded)).Should(Equal("{\n  \"Customer\": \"Ada\",\n  \"Total\": 42\n}\n"))
+		})
+
+		It("should pass when the data matches them", func() {
+			session := startGinkgo(pathToTest, "--noColor")
+			Eventually(session).Should(gexec.Exit(0))
+		})
+
+		It("should fail with a diff, recorded in the reports, when the data does not match them", func() {
+			Ω(ioutil.WriteFile(filepath.Join(snapshotsDir, "text.snap"), []byte("Invoice for Ada\nTotal: 40\n"), 0644)).Should(Succeed())
+			reportPath, err := filepath.Abs(filepath.Join(pathToTest, "report.json"))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			session := startGinkgo(pathToTest, "--noColor", "--jsonReport="+reportPath)
+			Eventually(session).Should(gexec.Exit(1))
+
+			output := string(session.Out.Contents())
+			Ω(output).Should(ContainSubstring(`Snapshot "invoice/text"`))
+			Ω(output).Should(MatchRegexp(`- Total: 40\n\s*\+ Total: 42`))
+			Ω(output).Should(ContainSubstring("1 Passed | 1 Failed"))
+
+			report, err := ioutil.ReadFile(reportPath)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(string(report)).Should(ContainSubstring(`"SnapshotMismatch": {`))
+			Ω(string(report)).Should(ContainSubstring(`"Actual": "Invoice for Ada\nTotal: 42\n"`))
+		})
+	})
+})
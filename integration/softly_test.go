@@ -0,0 +1,47 @@
+package integration_test
+
+import (
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/reporters"
+	"github.com/onsi/ginkgo/types"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("Softly", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("softly")
+		copyIn(fixturePath("softly_fixture"), pathToTest, false)
+	})
+
+	It("should record every soft failure as an additional failure and fail the spec once", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--jsonReport=report.json")
+		Eventually(session).Should(gexec.Exit(1))
+
+		output := string(session.Out.Contents())
+		Ω(output).Should(ContainSubstring("1 Passed | 1 Failed"))
+		Ω(output).Should(ContainSubstring("2 soft assertion(s) failed:"))
+		Ω(output).Should(ContainSubstring("softly_fixture_test.go:11"))
+		Ω(output).Should(ContainSubstring("softly_fixture_test.go:13"))
+
+		report, err := reporters.ReadJSONReport(filepath.Join(pathToTest, "report.json"))
+		Ω(err).ShouldNot(HaveOccurred())
+		failed := map[string]*types.SpecSummary{}
+		for _, summary := range report.SpecSummaries {
+			if summary.Failed() {
+				failed[reporters.SpecFullText(summary)] = summary
+			}
+		}
+		Ω(failed).Should(HaveLen(1))
+		summary := failed["soft assertions collects the soft failures and fails once"]
+		Ω(summary).ShouldNot(BeNil())
+		Ω(summary.Failure.Location.LineNumber).Should(Equal(10))
+		Ω(summary.AdditionalFailures).Should(HaveLen(2))
+		Ω(summary.AdditionalFailures[0].Message).Should(Equal("the name is missing"))
+		Ω(summary.AdditionalFailures[1].Location.LineNumber).Should(Equal(13))
+	})
+})
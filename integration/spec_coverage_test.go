@@ -0,0 +1,39 @@
+package integration_test
+
+import (
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/reporters"
+	"github.com/onsi/ginkgo/types"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("ginkgo coverage", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("spec_coverage")
+		copyIn(fixturePath("spec_coverage_fixture"), pathToTest, false)
+	})
+
+	It("should record the functions each spec covered in the report of the suite", func() {
+		session := startGinkgo(pathToTest, "coverage")
+		Eventually(session).Should(gexec.Exit(0))
+		Ω(session.Out.Contents()).Should(ContainSubstring("Covering the 2 spec(s) of spec_coverage"))
+
+		report, err := reporters.ReadJSONReport(filepath.Join(pathToTest, "spec_coverage.json"))
+		Ω(err).ShouldNot(HaveOccurred())
+		summaries := map[string]*types.SpecSummary{}
+		for _, summary := range report.SpecSummaries {
+			summaries[reporters.SpecFullText(summary)] = summary
+		}
+
+		Ω(summaries["SpecCoverageFixture adds"].State).Should(Equal(types.SpecStatePassed))
+		Ω(summaries["SpecCoverageFixture adds"].CoveredFunctions).Should(ConsistOf(HaveSuffix("spec_coverage/spec_coverage.go:Add")))
+		Ω(summaries["SpecCoverageFixture negates"].CoveredFunctions).Should(ConsistOf(HaveSuffix("spec_coverage/spec_coverage.go:Negate")))
+		Ω(summaries["SpecCoverageFixture is pending"].State).Should(Equal(types.SpecStatePending))
+		Ω(summaries["SpecCoverageFixture is pending"].CoveredFunctions).Should(BeEmpty())
+	})
+})
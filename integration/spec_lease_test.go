@@ -0,0 +1,28 @@
+package integration_test
+
+import (
+	"runtime"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("Spec leases", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		if runtime.GOOS == "windows" {
+			Skip("the fixture stops its node with SIGSTOP")
+		}
+		pathToTest = tmpPath("spec_lease")
+		copyIn(fixturePath("spec_lease_fixture"), pathToTest, false)
+	})
+
+	It("should hand the spec of a node that stops checking in to another node, and report the abandoned attempt", func() {
+		session := startGinkgo(pathToTest, "--noColor", "-nodes=2", "-specLeaseTimeout=1s")
+		Eventually(session, 30).Should(gexec.Exit(0))
+		Ω(session).Should(gbytes.Say(`Warning: \[Top Level\] leased specs hangs its node the first time it runs was abandoned by node #(\d), which claimed it \d\.\d+s before its lease expired, and handed to node #\d`))
+	})
+})
@@ -0,0 +1,34 @@
+package integration_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("Its declared without a body", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("strict_pending")
+		copyIn(fixturePath("strict_pending_fixture"), pathToTest, false)
+	})
+
+	It("should mark them pending and list them", func() {
+		session := startGinkgo(pathToTest, "--noColor")
+		Eventually(session).Should(gexec.Exit(0))
+		Ω(session).Should(gbytes.Say(`Warning: 2 It\(s\) declared without a body are pending \(see -strictPending\):`))
+		Ω(session).Should(gbytes.Say(`does something later\n\s+.*strict_pending_fixture_test.go:11`))
+		Ω(session).Should(gbytes.Say(`something else later\n\s+.*strict_pending_fixture_test.go:13`))
+		Ω(session).Should(gbytes.Say("1 Passed | 0 Failed | 2 Pending"))
+	})
+
+	It("should fail them with -strictPending", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--strictPending")
+		Eventually(session).Should(gexec.Exit(1))
+		Ω(session).Should(gbytes.Say(`Warning: 2 It\(s\) declared without a body fail, as -strictPending is set:`))
+		Ω(session).Should(gbytes.Say("This It was declared without a body, and -strictPending is set"))
+		Ω(session).Should(gbytes.Say("1 Passed | 2 Failed | 0 Pending"))
+	})
+})
@@ -535,6 +535,35 @@ var _ = Describe("Subcommand", func() {
 		})
 	})
 
+	Describe("ginkgo docs", func() {
+		var pathToTest string
+		BeforeEach(func() {
+			pathToTest = tmpPath("labels")
+			copyIn(fixturePath("labels_fixture"), pathToTest, false)
+		})
+
+		It("should document the specs as Markdown without running them", func() {
+			session := startGinkgo(pathToTest, "docs")
+			Eventually(session).Should(gexec.Exit(0))
+			output := string(session.Out.Contents())
+
+			Ω(output).Should(ContainSubstring("## LabelsFixture\n\n- has no labels\n- is pending `fast` _(pending)_"))
+			Ω(output).Should(ContainSubstring("### with labeled containers `storage`\n\n- is fast `fast`\n- is slow `slow` `storage`\n"))
+			Ω(output).ShouldNot(ContainSubstring("is fast:["))
+		})
+
+		It("should write the document as HTML to the output file", func() {
+			session := startGinkgo(pathToTest, "docs", "-format=html", "-output=specs.html")
+			Eventually(session).Should(gexec.Exit(0))
+
+			content, err := ioutil.ReadFile(filepath.Join(pathToTest, "specs.html"))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(string(content)).Should(HavePrefix("<!DOCTYPE html>"))
+			Ω(string(content)).Should(ContainSubstring(`<h3>with labeled containers <span class="label">storage</span></h3>`))
+			Ω(string(content)).Should(ContainSubstring(`<li>is slow <span class="label">slow</span> <span class="label">storage</span></li>`))
+		})
+	})
+
 	Describe("ginkgo version", func() {
 		It("should print out the version info", func() {
 			session := startGinkgo("", "version")
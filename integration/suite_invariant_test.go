@@ -0,0 +1,30 @@
+package integration_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("Suite invariants", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("suite_invariant")
+		copyIn(fixturePath("suite_invariant_fixture"), pathToTest, false)
+	})
+
+	It("should not be checked by default", func() {
+		session := startGinkgo(pathToTest, "--noColor")
+		Eventually(session).Should(gexec.Exit(0))
+	})
+
+	It("should fail the first spec to violate them with -checkInvariants", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--checkInvariants")
+		Eventually(session).Should(gexec.Exit(1))
+		Ω(session).Should(gbytes.Say(`This spec is the first to violate the suite invariant registered at .*suite_invariant_fixture_test.go:11:\s+1 row\(s\) left over`))
+		Ω(session).Should(gbytes.Say(`\[Fail\] SuiteInvariantFixture \[It\] leaves a row behind`))
+		Ω(session).Should(gbytes.Say("2 Passed | 1 Failed"))
+	})
+})
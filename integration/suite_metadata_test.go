@@ -0,0 +1,59 @@
+package integration_test
+
+import (
+	"encoding/xml"
+	"io/ioutil"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/reporters"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("Suite labels and metadata", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("suite_metadata")
+		copyIn(fixturePath("suite_metadata_fixture"), pathToTest, false)
+	})
+
+	readReport := func() reporters.JSONReport {
+		report, err := reporters.ReadJSONReport(filepath.Join(pathToTest, "report.json"))
+		Ω(err).ShouldNot(HaveOccurred())
+		return report
+	}
+
+	It("should report the labels and metadata passed to RunSpecs", func() {
+		junitFile, err := filepath.Abs(filepath.Join(pathToTest, "junit.xml"))
+		Ω(err).ShouldNot(HaveOccurred())
+		session := startGinkgo(pathToTest, "--noColor", "--jsonReport=report.json", "--reportFile="+junitFile)
+		Eventually(session).Should(gexec.Exit(0))
+
+		report := readReport()
+		Ω(report.SuiteLabels).Should(Equal([]string{"integration"}))
+		Ω(report.SuiteMetadata).Should(Equal(map[string]string{"gitSHA": "abc123", "environment": "local"}))
+		Ω(report.SuiteSummary.SuiteMetadata).Should(Equal(report.SuiteMetadata))
+
+		data, err := ioutil.ReadFile(junitFile)
+		Ω(err).ShouldNot(HaveOccurred())
+		var junit reporters.JUnitTestSuite
+		Ω(xml.Unmarshal(data, &junit)).Should(Succeed())
+		Ω(junit.Properties.Properties).Should(Equal([]reporters.JUnitProperty{
+			{Name: "SuiteLabels", Value: "integration"},
+			{Name: "environment", Value: "local"},
+			{Name: "gitSHA", Value: "abc123"},
+		}))
+	})
+
+	It("should let flags add labels and override metadata, when running in parallel", func() {
+		session := startGinkgo(pathToTest, "--noColor", "--nodes=2", "--jsonReport=report.json",
+			"--suiteLabel=nightly", "--suiteMetadata=environment=staging", "--suiteMetadata=buildURL=http://ci/42")
+		Eventually(session).Should(gexec.Exit(0))
+
+		report := readReport()
+		Ω(report.SuiteLabels).Should(Equal([]string{"integration", "nightly"}))
+		Ω(report.SuiteMetadata).Should(Equal(map[string]string{"gitSHA": "abc123", "environment": "staging", "buildURL": "http://ci/42"}))
+	})
+})
@@ -0,0 +1,57 @@
+package integration_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("Suite processes", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("suite_process")
+		copyIn(fixturePath("suite_process_fixture"), pathToTest, false)
+	})
+
+	readPIDs := func() []int {
+		data, err := ioutil.ReadFile(filepath.Join(pathToTest, "pids.log"))
+		Ω(err).ShouldNot(HaveOccurred())
+		pids := []int{}
+		for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+			pid, err := strconv.Atoi(line)
+			Ω(err).ShouldNot(HaveOccurred())
+			pids = append(pids, pid)
+		}
+		return pids
+	}
+
+	isRunning := func(pid int) bool {
+		process, err := os.FindProcess(pid)
+		Ω(err).ShouldNot(HaveOccurred())
+		return process.Signal(syscall.Signal(0)) == nil
+	}
+
+	It("should start the process once, even in parallel, and kill it at the end of the suite", func() {
+		jsonFile, err := filepath.Abs(filepath.Join(pathToTest, "report.json"))
+		Ω(err).ShouldNot(HaveOccurred())
+		session := startGinkgo(pathToTest, "--noColor", "--nodes=2", "--jsonReport="+jsonFile)
+		Eventually(session).Should(gexec.Exit(0))
+
+		pids := readPIDs()
+		Ω(pids).Should(HaveLen(1))
+		Ω(isRunning(pids[0])).Should(BeFalse())
+
+		report, err := ioutil.ReadFile(jsonFile)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(string(report)).Should(ContainSubstring(`"Name": "sleeper"`))
+		Ω(string(report)).Should(ContainSubstring(`"Output": "sleeping\n"`))
+	})
+})
@@ -0,0 +1,49 @@
+package integration_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/reporters"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("Text transformers", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("text_transformer")
+		copyIn(fixturePath("text_transformer_fixture"), pathToTest, false)
+	})
+
+	It("should display the transformed texts, in the console and the reports, while focusing on and identifying specs by the original ones", func() {
+		session := startGinkgo(pathToTest, "--noColor", "-v", "--focus=cart.add|cart.empty", "--jsonReport=report.json", "--sonarReport=sonar.xml")
+		Eventually(session).Should(gexec.Exit(1))
+
+		output := string(session.Out.Contents())
+		Ω(output).Should(ContainSubstring("running: cart cart.add"))
+		Ω(output).Should(MatchRegexp(`le panier\s+ajoute un article`))
+		Ω(output).Should(ContainSubstring("[Fail] le panier [It] refuse un panier vide"))
+		Ω(output).ShouldNot(ContainSubstring("passe commande"))
+		Ω(output).Should(ContainSubstring("Ran 2 of 3 Specs"))
+
+		report, err := reporters.ReadJSONReport(filepath.Join(pathToTest, "report.json"))
+		Ω(err).ShouldNot(HaveOccurred())
+		texts, displayTexts := []string{}, []string{}
+		for _, summary := range report.SpecSummaries {
+			texts = append(texts, reporters.SpecFullText(summary))
+			displayTexts = append(displayTexts, strings.Join(summary.DisplayTexts[1:], " "))
+		}
+		Ω(texts).Should(ConsistOf("cart cart.add", "cart cart.checkout", "cart cart.empty.fail"))
+		Ω(displayTexts).Should(ConsistOf("le panier ajoute un article", "le panier passe commande", "le panier refuse un panier vide"))
+		Ω(output).Should(ContainSubstring(`-focus='^TextTransformerFixture Suite \[Top Level\] cart cart\.empty\.fail$'`))
+
+		sonar, err := ioutil.ReadFile(filepath.Join(pathToTest, "sonar.xml"))
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(string(sonar)).Should(ContainSubstring(`name="le panier ajoute un article"`))
+		Ω(string(sonar)).ShouldNot(ContainSubstring("cart.add"))
+	})
+})
@@ -0,0 +1,42 @@
+package integration_test
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("WarmUp", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("warm_up")
+		copyIn(fixturePath("warm_up_fixture"), pathToTest, false)
+	})
+
+	It("should run before the suite begins, and leave its duration out of the run time of the suite", func() {
+		session := startGinkgo(pathToTest, "--noColor")
+		Eventually(session).Should(gexec.Exit(0))
+		Ω(session).Should(gbytes.Say(`Ran 1 of 1 Specs in 0\.\d+ seconds, after a 1\.\d+ second warm-up`))
+	})
+
+	It("should run on every parallel node, and report the longest warm-up", func() {
+		session := startGinkgo(pathToTest, "--noColor", "-nodes=2")
+		Eventually(session).Should(gexec.Exit(0))
+		Ω(session).Should(gbytes.Say(`Ran 1 of 1 Specs in 0\.\d+ seconds, after a 1\.\d+ second warm-up`))
+	})
+
+	It("should fail the suite, running none of the specs, when it fails", func() {
+		os.Setenv("WARM_UP_FIXTURE_FAIL", "1")
+		defer os.Unsetenv("WARM_UP_FIXTURE_FAIL")
+		session := startGinkgo(pathToTest, "--noColor")
+		Eventually(session).Should(gexec.Exit(1))
+		Ω(session).Should(gbytes.Say(`\[WarmUp\] WarmUp`))
+		Ω(session).Should(gbytes.Say("the image could not be pulled"))
+		Ω(session.Out.Contents()).ShouldNot(ContainSubstring("BeforeSuite ran"))
+		Ω(session).Should(gbytes.Say(`0 Passed \| 1 Failed`))
+	})
+})
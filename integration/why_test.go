@@ -0,0 +1,38 @@
+package integration_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("ginkgo why", func() {
+	var pathToTest string
+
+	BeforeEach(func() {
+		pathToTest = tmpPath("why")
+		copyIn(fixturePath("why_fixture"), pathToTest, false)
+	})
+
+	It("should explain which rule selects or leaves out each spec, without running them", func() {
+		session := startGinkgo(pathToTest, "why", "--focus=withdraw", "--skip=overdraft")
+		Eventually(session).Should(gexec.Exit(0))
+
+		output := string(session.Out.Contents())
+		Ω(output).Should(ContainSubstring("why_fixture"))
+		Ω(output).Should(MatchRegexp(`would run\s+account withdraws\n.*why_fixture_test.go:8\n\s+it matches the focus pattern "withdraw"`))
+		Ω(output).Should(MatchRegexp(`skipped\s+account withdraws overdrafts\n.*why_fixture_test.go:12\n\s+it matches the skip pattern "overdraft"`))
+		Ω(output).Should(MatchRegexp(`skipped\s+account deposits\n.*\n\s+it matches none of the focus patterns`))
+		Ω(output).Should(MatchRegexp(`skipped\s+account closes\n.*\n\s+it matches none of the focus patterns`))
+		Ω(output).ShouldNot(ContainSubstring("should not run"))
+	})
+
+	It("should tell pending specs apart", func() {
+		session := startGinkgo(pathToTest, "why", "--skip=overdraft")
+		Eventually(session).Should(gexec.Exit(0))
+
+		output := string(session.Out.Contents())
+		Ω(output).Should(MatchRegexp(`would run\s+account deposits\n.*\n\s+it matches none of the skip patterns`))
+		Ω(output).Should(MatchRegexp(`pending\s+account closes\n.*\n\s+it is pending`))
+	})
+})
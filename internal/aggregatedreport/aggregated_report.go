@@ -0,0 +1,132 @@
+/*
+Package aggregatedreport implements the report of the suite run so far returned by ginkgo.CurrentAggregatedReport, which
+aggregates the specs completed by all the parallel nodes of the suite.
+
+When running in parallel, the server run by the Ginkgo CLI feeds a Collector with the specs the nodes report, and the
+nodes fetch the report through its /AggregatedReport endpoint with a Client.  When running serially the Client reads
+the report from a Collector of its own, registered as a reporter of the suite.
+*/
+package aggregatedreport
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/onsi/ginkgo/config"
+	"github.com/onsi/ginkgo/types"
+)
+
+//Collector is a reporter that aggregates the specs it is told have completed into a types.AggregatedReport
+type Collector struct {
+	lock   *sync.Mutex
+	report types.AggregatedReport
+}
+
+func NewCollector() *Collector {
+	return &Collector{
+		lock:   &sync.Mutex{},
+		report: types.AggregatedReport{SpecSummaries: []*types.SpecSummary{}},
+	}
+}
+
+//Report returns a copy of the report aggregated so far
+func (c *Collector) Report() types.AggregatedReport {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	report := c.report
+	report.SpecSummaries = append([]*types.SpecSummary{}, c.report.SpecSummaries...)
+	return report
+}
+
+func (c *Collector) SpecSuiteWillBegin(config config.GinkgoConfigType, summary *types.SuiteSummary) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.report.SuiteDescription = summary.SuiteDescription
+}
+
+func (c *Collector) BeforeSuiteDidRun(setupSummary *types.SetupSummary) {
+}
+
+func (c *Collector) SpecWillRun(specSummary *types.SpecSummary) {
+}
+
+func (c *Collector) SpecDidComplete(specSummary *types.SpecSummary) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.report.SpecSummaries = append(c.report.SpecSummaries, specSummary)
+	switch {
+	case specSummary.Passed():
+		c.report.Passed++
+	case specSummary.State.IsFailure():
+		c.report.Failed++
+	case specSummary.Pending():
+		c.report.Pending++
+	case specSummary.Skipped():
+		c.report.Skipped++
+	}
+}
+
+func (c *Collector) AfterSuiteDidRun(setupSummary *types.SetupSummary) {
+}
+
+func (c *Collector) SpecSuiteDidEnd(summary *types.SuiteSummary) {
+}
+
+type Client struct {
+	lock     *sync.Mutex
+	syncHost string
+	client   *http.Client
+	local    *Collector
+}
+
+//NewClient returns a client reading the report from local until it connects to a server
+func NewClient(local *Collector) *Client {
+	return &Client{
+		lock:   &sync.Mutex{},
+		client: &http.Client{},
+		local:  local,
+	}
+}
+
+//Connect makes the client fetch the report from the server at syncHost
+func (c *Client) Connect(syncHost string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.syncHost = syncHost
+}
+
+//FetchAggregatedReport returns the report of the specs completed so far by all the parallel nodes
+func (c *Client) FetchAggregatedReport() (types.AggregatedReport, error) {
+	syncHost := c.host()
+	if syncHost == "" {
+		return c.local.Report(), nil
+	}
+
+	report := types.AggregatedReport{}
+	resp, err := c.client.Get(syncHost + "/AggregatedReport")
+	if err == nil {
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			err = fmt.Errorf("unexpected status code %d", resp.StatusCode)
+		} else {
+			err = json.NewDecoder(resp.Body).Decode(&report)
+		}
+	}
+	if err != nil {
+		return types.AggregatedReport{}, fmt.Errorf("Failed to fetch the aggregated report: %s", err.Error())
+	}
+	return report, nil
+}
+
+func (c *Client) host() string {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.syncHost
+}
@@ -0,0 +1,13 @@
+package aggregatedreport_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestAggregatedReport(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "AggregatedReport Suite")
+}
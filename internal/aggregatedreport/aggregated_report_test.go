@@ -0,0 +1,89 @@
+package aggregatedreport_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/onsi/ginkgo/config"
+	. "github.com/onsi/ginkgo/internal/aggregatedreport"
+	"github.com/onsi/ginkgo/internal/remote"
+	"github.com/onsi/ginkgo/types"
+)
+
+var _ = Describe("AggregatedReport", func() {
+	summary := func(text string, state types.SpecState) *types.SpecSummary {
+		return &types.SpecSummary{ComponentTexts: []string{"[Top Level]", text}, State: state}
+	}
+
+	Context("when running serially", func() {
+		It("should read the report aggregated by the local collector", func() {
+			collector := NewCollector()
+			client := NewClient(collector)
+
+			collector.SpecSuiteWillBegin(config.GinkgoConfigType{}, &types.SuiteSummary{SuiteDescription: "Suite"})
+			collector.SpecDidComplete(summary("passes", types.SpecStatePassed))
+			collector.SpecDidComplete(summary("panics", types.SpecStatePanicked))
+			collector.SpecDidComplete(summary("is skipped", types.SpecStateSkipped))
+
+			report, err := client.FetchAggregatedReport()
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(report.SuiteDescription).Should(Equal("Suite"))
+			Ω(report.SpecSummaries).Should(HaveLen(3))
+			Ω(report.SpecSummaries[1].ComponentTexts).Should(Equal([]string{"[Top Level]", "panics"}))
+			Ω(report.Passed).Should(Equal(1))
+			Ω(report.Failed).Should(Equal(1))
+			Ω(report.Skipped).Should(Equal(1))
+			Ω(report.Pending).Should(BeZero())
+		})
+	})
+
+	Context("when running in parallel", func() {
+		var server *remote.Server
+
+		BeforeEach(func() {
+			var err error
+			server, err = remote.NewServer(2)
+			Ω(err).ShouldNot(HaveOccurred())
+			server.Start()
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("should fetch the report of the specs completed by all the nodes from the server", func() {
+			for _, s := range []*types.SpecSummary{summary("fails", types.SpecStateFailed), summary("is pending", types.SpecStatePending)} {
+				data, err := json.Marshal(s)
+				Ω(err).ShouldNot(HaveOccurred())
+				resp, err := http.Post(server.Address()+"/SpecDidComplete", "application/json", bytes.NewReader(data))
+				Ω(err).ShouldNot(HaveOccurred())
+				resp.Body.Close()
+			}
+
+			local := NewCollector()
+			local.SpecDidComplete(summary("passes", types.SpecStatePassed))
+			client := NewClient(local)
+			client.Connect(server.Address())
+
+			report, err := client.FetchAggregatedReport()
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(report.SpecSummaries).Should(HaveLen(2))
+			Ω(report.Failed).Should(Equal(1))
+			Ω(report.Pending).Should(Equal(1))
+			Ω(report.Passed).Should(BeZero())
+		})
+
+		It("should fail when the server cannot be reached", func() {
+			client := NewClient(NewCollector())
+			client.Connect(server.Address())
+			server.Close()
+
+			_, err := client.FetchAggregatedReport()
+			Ω(err).Should(MatchError(HavePrefix("Failed to fetch the aggregated report:")))
+		})
+	})
+})
@@ -0,0 +1,149 @@
+/*
+Package allocation hands out ports and names that do not conflict across the parallel nodes of a suite run.
+
+When running in parallel, the server run by the Ginkgo CLI owns the Allocator and the nodes fetch ports and names
+from its /Port and /UniqueName endpoints through a Client.  When running serially the Client allocates them itself.
+*/
+package allocation
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"github.com/onsi/ginkgo/types"
+)
+
+//maxPortAttempts bounds how many times the Allocator asks the OS for a port it has not handed out yet
+const maxPortAttempts = 100
+
+type Allocator struct {
+	lock   *sync.Mutex
+	runID  string
+	ports  map[int]bool
+	counts map[string]int
+}
+
+func NewAllocator() *Allocator {
+	return &Allocator{
+		lock:   &sync.Mutex{},
+		runID:  randomRunID(),
+		ports:  map[int]bool{},
+		counts: map[string]int{},
+	}
+}
+
+//Port returns a TCP port that is free on the loopback interface and that the Allocator has not handed out before
+func (a *Allocator) Port() (int, error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	for i := 0; i < maxPortAttempts; i++ {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			return 0, err
+		}
+		port := listener.Addr().(*net.TCPAddr).Port
+		listener.Close()
+
+		if !a.ports[port] {
+			a.ports[port] = true
+			return port, nil
+		}
+	}
+	return 0, fmt.Errorf("could not find a free port after %d attempts", maxPortAttempts)
+}
+
+//UniqueName returns a name starting with prefix that the Allocator has not handed out before.  The name includes an ID
+//that differs between suite runs, so that suites running at the same time against the same environment do not conflict.
+func (a *Allocator) UniqueName(prefix string) string {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	a.counts[prefix]++
+	return fmt.Sprintf("%s-%s-%d", prefix, a.runID, a.counts[prefix])
+}
+
+type Client struct {
+	lock     *sync.Mutex
+	syncHost string
+	client   *http.Client
+	local    *Allocator
+}
+
+func NewClient() *Client {
+	return &Client{
+		lock:   &sync.Mutex{},
+		client: &http.Client{},
+		local:  NewAllocator(),
+	}
+}
+
+//Connect makes the client fetch ports and names from the server at syncHost
+func (c *Client) Connect(syncHost string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.syncHost = syncHost
+}
+
+func (c *Client) Port() (int, error) {
+	syncHost := c.host()
+	if syncHost == "" {
+		return c.local.Port()
+	}
+
+	allocation, err := c.get(syncHost + "/Port")
+	if err != nil {
+		return 0, fmt.Errorf("Failed to allocate a port: %s", err.Error())
+	}
+	return allocation.Port, nil
+}
+
+func (c *Client) UniqueName(prefix string) (string, error) {
+	syncHost := c.host()
+	if syncHost == "" {
+		return c.local.UniqueName(prefix), nil
+	}
+
+	allocation, err := c.get(syncHost + "/UniqueName?prefix=" + url.QueryEscape(prefix))
+	if err != nil {
+		return "", fmt.Errorf("Failed to allocate a name prefixed with %q: %s", prefix, err.Error())
+	}
+	return allocation.Name, nil
+}
+
+func (c *Client) host() string {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.syncHost
+}
+
+func (c *Client) get(url string) (types.RemoteAllocation, error) {
+	allocation := types.RemoteAllocation{}
+	resp, err := c.client.Get(url)
+	if err != nil {
+		return allocation, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return allocation, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	err = json.NewDecoder(resp.Body).Decode(&allocation)
+	return allocation, err
+}
+
+func randomRunID() string {
+	b := make([]byte, 4)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "0"
+	}
+	return fmt.Sprintf("%x", b)
+}
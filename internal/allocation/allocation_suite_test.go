@@ -0,0 +1,13 @@
+package allocation_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestAllocation(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Allocation Suite")
+}
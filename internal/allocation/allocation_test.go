@@ -0,0 +1,115 @@
+package allocation_test
+
+import (
+	"net"
+	"strconv"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/onsi/ginkgo/internal/allocation"
+	"github.com/onsi/ginkgo/internal/remote"
+)
+
+var _ = Describe("Allocation", func() {
+	Describe("Allocator", func() {
+		var allocator *Allocator
+
+		BeforeEach(func() {
+			allocator = NewAllocator()
+		})
+
+		It("should hand out free ports, never twice", func() {
+			ports := map[int]bool{}
+			for i := 0; i < 20; i++ {
+				port, err := allocator.Port()
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(ports).ShouldNot(HaveKey(port))
+				ports[port] = true
+			}
+
+			port, _ := allocator.Port()
+			listener, err := net.Listen("tcp", "127.0.0.1:"+strconv.Itoa(port))
+			Ω(err).ShouldNot(HaveOccurred())
+			listener.Close()
+		})
+
+		It("should hand out unique names starting with the prefix", func() {
+			first := allocator.UniqueName("books")
+			second := allocator.UniqueName("books")
+			Ω(first).Should(MatchRegexp(`^books-[0-9a-f]+-1$`))
+			Ω(second).Should(MatchRegexp(`^books-[0-9a-f]+-2$`))
+			Ω(allocator.UniqueName("authors")).Should(MatchRegexp(`^authors-[0-9a-f]+-1$`))
+		})
+
+		It("should hand out different names in different runs", func() {
+			Ω(allocator.UniqueName("books")).ShouldNot(Equal(NewAllocator().UniqueName("books")))
+		})
+	})
+
+	Describe("Client", func() {
+		var client *Client
+
+		BeforeEach(func() {
+			client = NewClient()
+		})
+
+		Context("when running serially", func() {
+			It("should allocate ports and names itself", func() {
+				port, err := client.Port()
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(port).Should(BeNumerically(">", 0))
+
+				name, err := client.UniqueName("books")
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(name).Should(HavePrefix("books-"))
+			})
+		})
+
+		Context("when running in parallel", func() {
+			var (
+				server *remote.Server
+				other  *Client
+			)
+
+			BeforeEach(func() {
+				var err error
+				server, err = remote.NewServer(2)
+				Ω(err).ShouldNot(HaveOccurred())
+				server.Start()
+
+				client.Connect(server.Address())
+				other = NewClient()
+				other.Connect(server.Address())
+			})
+
+			AfterEach(func() {
+				server.Close()
+			})
+
+			It("should fetch ports and names that do not conflict with those of other nodes from the server", func() {
+				port, err := client.Port()
+				Ω(err).ShouldNot(HaveOccurred())
+				otherPort, err := other.Port()
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(port).ShouldNot(Equal(otherPort))
+
+				name, err := client.UniqueName("a books/namespace")
+				Ω(err).ShouldNot(HaveOccurred())
+				otherName, err := other.UniqueName("a books/namespace")
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(name).Should(MatchRegexp(`^a books/namespace-[0-9a-f]+-1$`))
+				Ω(otherName).Should(MatchRegexp(`^a books/namespace-[0-9a-f]+-2$`))
+			})
+
+			It("should fail when the server cannot be reached", func() {
+				server.Close()
+
+				_, err := client.Port()
+				Ω(err).Should(MatchError(ContainSubstring("Failed to allocate a port")))
+				_, err = client.UniqueName("books")
+				Ω(err).Should(MatchError(ContainSubstring(`Failed to allocate a name prefixed with "books"`)))
+			})
+		})
+	})
+})
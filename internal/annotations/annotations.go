@@ -0,0 +1,102 @@
+/*
+Package annotations reads the metadata that specs and containers declare in the comments right above them, for teams
+who prefer comments to decorators:
+
+	// ginkgo:label=slow,storage
+	// ginkgo:owner=team-x  ginkgo:severity=high
+	It("survives a restart", func() {
+		...
+	})
+
+An annotation is the word ginkgo: followed by a key, an equal sign and a value without spaces.  Several annotations may
+share a line.  Only the comment lines directly above the node count: a blank line or code ends them.
+*/
+package annotations
+
+import (
+	"bufio"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/onsi/ginkgo/types"
+)
+
+var annotationRegexp = regexp.MustCompile(`ginkgo:([A-Za-z]+)=(\S+)`)
+
+//Annotation is a single ginkgo:key=value annotation
+type Annotation struct {
+	Key   string
+	Value string
+}
+
+//Values splits a comma-separated value, e.g. the labels of ginkgo:label=slow,storage
+func (annotation Annotation) Values() []string {
+	values := []string{}
+	for _, value := range strings.Split(annotation.Value, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+//Parse returns the annotations found in comment, in order
+func Parse(comment string) []Annotation {
+	annotations := []Annotation{}
+	for _, match := range annotationRegexp.FindAllStringSubmatch(comment, -1) {
+		annotations = append(annotations, Annotation{Key: match[1], Value: match[2]})
+	}
+	return annotations
+}
+
+//Above returns the annotations of the // comment lines directly above the line lineNumber (1-based) of lines
+func Above(lines []string, lineNumber int) []Annotation {
+	first := lineNumber - 1
+	for first > 0 && strings.HasPrefix(strings.TrimSpace(lines[first-1]), "//") {
+		first--
+	}
+	annotations := []Annotation{}
+	for i := first; i < lineNumber-1 && i < len(lines); i++ {
+		annotations = append(annotations, Parse(lines[i])...)
+	}
+	return annotations
+}
+
+var cacheLock = &sync.Mutex{}
+var cache = map[string][]string{}
+
+//Lookup returns the annotations of the node at codeLocation, reading its source file.  Nodes whose source file cannot be
+//read, e.g. when a precompiled suite runs away from its sources, have none.
+func Lookup(codeLocation types.CodeLocation) []Annotation {
+	if codeLocation.FileName == "" || codeLocation.LineNumber < 1 {
+		return nil
+	}
+	lines := sourceLines(codeLocation.FileName)
+	if codeLocation.LineNumber > len(lines) {
+		return nil
+	}
+	return Above(lines, codeLocation.LineNumber)
+}
+
+func sourceLines(fileName string) []string {
+	cacheLock.Lock()
+	defer cacheLock.Unlock()
+	if lines, ok := cache[fileName]; ok {
+		return lines
+	}
+
+	lines := []string{}
+	f, err := os.Open(fileName)
+	if err == nil {
+		defer f.Close()
+		scanner := bufio.NewScanner(f)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			lines = append(lines, scanner.Text())
+		}
+	}
+	cache[fileName] = lines
+	return lines
+}
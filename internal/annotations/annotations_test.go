@@ -0,0 +1,87 @@
+package annotations_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/onsi/ginkgo/internal/annotations"
+	"github.com/onsi/ginkgo/types"
+)
+
+var _ = Describe("Annotations", func() {
+	source := strings.Join([]string{
+		`var _ = Describe("storage", func() {`,
+		`	// survives restarts of the database`,
+		`	// ginkgo:label=slow,restart  ginkgo:owner=team-x`,
+		`	//ginkgo:severity=high`,
+		`	It("survives a restart", func() {`,
+		`	})`,
+		``,
+		`	// ginkgo:label=ignored`,
+		``,
+		`	It("is not annotated", func() {`,
+		`	})`,
+		`})`,
+	}, "\n")
+	lines := strings.Split(source, "\n")
+
+	Describe("Parse", func() {
+		It("returns the annotations of a comment, in order", func() {
+			Ω(annotations.Parse("// ginkgo:label=slow ginkgo:owner=team-x // ginkgo:label=db")).Should(Equal([]annotations.Annotation{
+				{Key: "label", Value: "slow"},
+				{Key: "owner", Value: "team-x"},
+				{Key: "label", Value: "db"},
+			}))
+			Ω(annotations.Parse("// nothing to see here, ginkgo: or ginkgo:label=")).Should(BeEmpty())
+		})
+
+		It("splits comma-separated values", func() {
+			Ω(annotations.Annotation{Key: "label", Value: "slow,,restart,"}.Values()).Should(Equal([]string{"slow", "restart"}))
+		})
+	})
+
+	Describe("Above", func() {
+		It("returns the annotations of the comment lines directly above a line", func() {
+			Ω(annotations.Above(lines, 5)).Should(Equal([]annotations.Annotation{
+				{Key: "label", Value: "slow,restart"},
+				{Key: "owner", Value: "team-x"},
+				{Key: "severity", Value: "high"},
+			}))
+		})
+
+		It("ignores comments separated from the line", func() {
+			Ω(annotations.Above(lines, 10)).Should(BeEmpty())
+			Ω(annotations.Above(lines, 1)).Should(BeEmpty())
+		})
+	})
+
+	Describe("Lookup", func() {
+		var dir string
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "annotations")
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(ioutil.WriteFile(filepath.Join(dir, "storage_test.go"), []byte(source), 0666)).Should(Succeed())
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		It("reads the annotations of the node at a code location from its source file", func() {
+			annotated := annotations.Lookup(types.CodeLocation{FileName: filepath.Join(dir, "storage_test.go"), LineNumber: 5})
+			Ω(annotated).Should(HaveLen(3))
+			Ω(annotations.Lookup(types.CodeLocation{FileName: filepath.Join(dir, "storage_test.go"), LineNumber: 42})).Should(BeEmpty())
+		})
+
+		It("returns no annotations when the source file cannot be read", func() {
+			Ω(annotations.Lookup(types.CodeLocation{FileName: filepath.Join(dir, "missing_test.go"), LineNumber: 5})).Should(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,50 @@
+/*
+Package clock tells the time recorded in reports: when the suite, its specs, nodes, steps and shared fixtures started,
+and how long they ran.  It is the wall clock unless a Clock is substituted with Set, e.g. by a simulation that runs the
+suite on a virtual clock and needs reproducible reports.
+
+Timeouts, polling and the other behaviors of the suite that wait on real time keep using the time package.
+*/
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+//Clock tells the current time
+type Clock interface {
+	Now() time.Time
+}
+
+type wallClock struct{}
+
+func (wallClock) Now() time.Time {
+	return time.Now()
+}
+
+var lock = &sync.RWMutex{}
+var current Clock = wallClock{}
+
+//Set substitutes c for the clock.  A nil c restores the wall clock.
+func Set(c Clock) {
+	if c == nil {
+		c = wallClock{}
+	}
+	lock.Lock()
+	defer lock.Unlock()
+	current = c
+}
+
+//Now returns the current time according to the clock
+func Now() time.Time {
+	lock.RLock()
+	c := current
+	lock.RUnlock()
+	return c.Now()
+}
+
+//Since returns the time elapsed since t according to the clock
+func Since(t time.Time) time.Duration {
+	return Now().Sub(t)
+}
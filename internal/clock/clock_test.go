@@ -0,0 +1,52 @@
+package clock_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/onsi/ginkgo/internal/clock"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+var _ = Describe("Clock", func() {
+	var fake *fakeClock
+
+	BeforeEach(func() {
+		fake = &fakeClock{now: time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)}
+	})
+
+	AfterEach(func() {
+		clock.Set(nil)
+	})
+
+	It("should tell the wall clock time by default", func() {
+		before := time.Now()
+		now := clock.Now()
+		Ω(now).Should(BeTemporally(">=", before))
+		Ω(now).Should(BeTemporally("<=", time.Now()))
+	})
+
+	It("should tell the time of the substituted clock", func() {
+		clock.Set(fake)
+		Ω(clock.Now()).Should(Equal(fake.now))
+
+		start := clock.Now()
+		fake.now = fake.now.Add(time.Hour)
+		Ω(clock.Since(start)).Should(Equal(time.Hour))
+	})
+
+	It("should restore the wall clock when set to nil", func() {
+		clock.Set(fake)
+		clock.Set(nil)
+		Ω(clock.Now()).Should(BeTemporally("~", time.Now(), time.Second))
+	})
+})
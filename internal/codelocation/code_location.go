@@ -5,16 +5,65 @@ import (
 	"runtime"
 	"runtime/debug"
 	"strings"
+	"sync"
 
+	"github.com/onsi/ginkgo/config"
 	"github.com/onsi/ginkgo/types"
 )
 
+// helpers holds the names of the functions marked as helpers with MarkAsHelper
+var helpers = &sync.Map{}
+
+// New returns the location of the caller skip frames up the stack, or of the
+// first caller above it that is not a function marked as a helper.
 func New(skip int) types.CodeLocation {
-	_, file, line, _ := runtime.Caller(skip + 1)
-	stackTrace := PruneStack(string(debug.Stack()), skip+1)
+	skip = skipHelpers(skip + 1)
+	_, file, line, _ := runtime.Caller(skip)
+	reporterConfig := config.DefaultReporterConfig
+	stackTrace := TrimStack(string(debug.Stack()), skip, reporterConfig.FullStackTraces, strings.Split(reporterConfig.StackTraceFilter, ","), reporterConfig.StackTraceDepth)
 	return types.CodeLocation{FileName: file, LineNumber: line, FullStackTrace: stackTrace}
 }
 
+// MarkAsHelper marks the function skip frames up the stack from its caller as
+// a helper: New reports the locations of its callers instead of locations
+// within it.
+func MarkAsHelper(skip int) {
+	pcs := make([]uintptr, skip+3)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(1, pcs)])
+	// Frame 0 is MarkAsHelper itself and frame 1 its caller.
+	for i := 0; ; i++ {
+		frame, more := frames.Next()
+		if i == skip+1 {
+			helpers.Store(frame.Function, true)
+			return
+		}
+		if !more {
+			return
+		}
+	}
+}
+
+// skipHelpers returns how many frames, as counted by runtime.Caller from the
+// caller of skipHelpers, to skip to reach the first frame at or above skip
+// that is not a helper.
+func skipHelpers(skip int) int {
+	pcs := make([]uintptr, 100)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(1, pcs)])
+	// Frame 0 is skipHelpers itself, so the frames of its caller are offset by one.
+	for i := 0; ; i++ {
+		frame, more := frames.Next()
+		if i > skip {
+			if _, isHelper := helpers.Load(frame.Function); !isHelper {
+				return skip
+			}
+			skip++
+		}
+		if !more {
+			return skip
+		}
+	}
+}
+
 // PruneStack removes references to functions that are internal to Ginkgo
 // and the Go runtime from a stack string and a certain number of stack entries
 // at the beginning of the stack. The stack string has the format
@@ -23,6 +72,15 @@ func New(skip int) types.CodeLocation {
 // adds itself as first entry, so typically skip must be >= 1 to remove that
 // entry.
 func PruneStack(fullStackTrace string, skip int) string {
+	return TrimStack(fullStackTrace, skip, false, nil, 0)
+}
+
+// TrimStack removes a certain number of stack entries at the beginning of a
+// stack string, as PruneStack does, then the references to functions that are
+// internal to Ginkgo and the Go runtime unless full is set, and the references
+// to functions whose source code file name contains one of filters. When depth
+// is positive, at most depth entries are kept.
+func TrimStack(fullStackTrace string, skip int, full bool, filters []string, depth int) string {
 	stack := strings.Split(fullStackTrace, "\n")
 	// Ensure that the even entries are the method names and the
 	// the odd entries the source code information.
@@ -38,11 +96,23 @@ func PruneStack(fullStackTrace string, skip int) string {
 	prunedStack := []string{}
 	re := regexp.MustCompile(`\/ginkgo\/|\/pkg\/testing\/|\/pkg\/runtime\/`)
 	for i := 0; i < len(stack)/2; i++ {
+		if depth > 0 && len(prunedStack) == 2*depth {
+			break
+		}
 		// We filter out based on the source code file name.
-		if !re.Match([]byte(stack[i*2+1])) {
+		if (full || !re.Match([]byte(stack[i*2+1]))) && !containsAny(stack[i*2+1], filters) {
 			prunedStack = append(prunedStack, stack[i*2])
 			prunedStack = append(prunedStack, stack[i*2+1])
 		}
 	}
 	return strings.Join(prunedStack, "\n")
 }
+
+func containsAny(s string, substrings []string) bool {
+	for _, substring := range substrings {
+		if substring != "" && strings.Contains(s, substring) {
+			return true
+		}
+	}
+	return false
+}
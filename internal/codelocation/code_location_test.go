@@ -30,6 +30,16 @@ func caller1() {
 	caller0()
 }
 
+func helper0() types.CodeLocation {
+	codelocation.MarkAsHelper(0)
+	return codelocation.New(0)
+}
+
+func helper1() types.CodeLocation {
+	codelocation.MarkAsHelper(0)
+	return helper0()
+}
+
 var _ = Describe("CodeLocation", func() {
 	BeforeEach(func() {
 		caller1()
@@ -46,6 +56,16 @@ var _ = Describe("CodeLocation", func() {
 		})
 	})
 
+	Describe("helpers", func() {
+		It("should skip the functions marked as helpers", func() {
+			_, file, line, _ := runtime.Caller(0)
+			location := helper1()
+			Ω(location.FileName).Should(Equal(file))
+			Ω(location.LineNumber).Should(Equal(line + 1))
+			Ω(location.FullStackTrace).ShouldNot(ContainSubstring("helper0"))
+		})
+	})
+
 	Describe("PruneStack", func() {
 		It("should remove any references to ginkgo and pkg/testing and pkg/runtime", func() {
 			// Hard-coded string, loosely based on what debug.Stack() produces.
@@ -81,6 +101,31 @@ TestFoo: RunSpecs(t, "Foo Suite")
 /Users/whoever/gospace/src/mycode/code_suite_test.go:12 (0x37f08)`))
 		})
 
+		It("should keep the frames internal to Ginkgo when trimming a full stack, and hide the filtered ones", func() {
+			input := `Skip: skip()
+/Skip/me
+Something: Func()
+/Users/whoever/gospace/src/github.com/onsi/ginkgo/whatever.go:10 (0x12314)
+Vendored: Func()
+/Users/whoever/gospace/src/mycode/vendor/lib/lib.go:10 (0x12341)
+MyCode: Func()
+/Users/whoever/gospace/src/mycode/code.go:10 (0x12341)
+MyCodeTest: Func()
+/Users/whoever/gospace/src/mycode/code_test.go:10 (0x12341)
+`
+			Ω(codelocation.TrimStack(input, 0, true, []string{"vendor/"}, 0)).Should(Equal(`Something: Func()
+/Users/whoever/gospace/src/github.com/onsi/ginkgo/whatever.go:10 (0x12314)
+MyCode: Func()
+/Users/whoever/gospace/src/mycode/code.go:10 (0x12341)
+MyCodeTest: Func()
+/Users/whoever/gospace/src/mycode/code_test.go:10 (0x12341)`))
+
+			Ω(codelocation.TrimStack(input, 0, false, nil, 2)).Should(Equal(`Vendored: Func()
+/Users/whoever/gospace/src/mycode/vendor/lib/lib.go:10 (0x12341)
+MyCode: Func()
+/Users/whoever/gospace/src/mycode/code.go:10 (0x12341)`))
+		})
+
 		It("should skip correctly for a Go runtime stack", func() {
 			// Actual string from debug.Stack(), something like:
 			// "goroutine 5 [running]:",
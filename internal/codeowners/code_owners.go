@@ -0,0 +1,157 @@
+/*
+Package codeowners loads the CODEOWNERS files passed to -codeOwners, to tell who owns the specs that do not name their
+owners with the Owner decorator.
+
+A CODEOWNERS file holds one rule per line: a pattern, matched against paths relative to the root of the repository like
+in a .gitignore file, followed by the owners of the matching files:
+
+	# default owners
+	*                @org/core
+	/reporters/      @org/reporting
+	*_fixture_test.go @org/integration
+
+Blank lines and lines starting with # are ignored.  When several rules match a file, the last one wins; a rule without
+owners leaves the files it matches without owners.  The root of the repository is the directory holding the CODEOWNERS
+file, or its parent when the file lives in a .github or docs directory.
+*/
+package codeowners
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+type rule struct {
+	pattern *regexp.Regexp
+	owners  []string
+}
+
+type CodeOwners struct {
+	root  string
+	rules []rule
+}
+
+//Load reads the CODEOWNERS file at path
+func Load(path string) (*CodeOwners, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	root := filepath.Dir(path)
+	if base := filepath.Base(root); base == ".github" || base == "docs" {
+		root = filepath.Dir(root)
+	}
+	return Parse(f, path, root)
+}
+
+//Parse reads a CODEOWNERS file whose patterns are relative to the root directory.  name identifies the file in errors.
+func Parse(r io.Reader, name string, root string) (*CodeOwners, error) {
+	codeOwners := &CodeOwners{root: root}
+	scanner := bufio.NewScanner(r)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		pattern, err := compile(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: invalid pattern %q: %s", name, lineNumber, fields[0], err)
+		}
+		owners := []string{}
+		for _, owner := range fields[1:] {
+			if strings.HasPrefix(owner, "#") {
+				break
+			}
+			owners = append(owners, owner)
+		}
+		codeOwners.rules = append(codeOwners.rules, rule{pattern: pattern, owners: owners})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return codeOwners, nil
+}
+
+//Owners returns the owners of the file at path, or nil if no rule gives it owners or it lies outside of the repository
+func (codeOwners *CodeOwners) Owners(path string) []string {
+	relativePath, err := filepath.Rel(codeOwners.root, path)
+	if err != nil || relativePath == ".." || strings.HasPrefix(relativePath, ".."+string(filepath.Separator)) {
+		return nil
+	}
+	relativePath = filepath.ToSlash(relativePath)
+
+	for i := len(codeOwners.rules) - 1; i >= 0; i-- {
+		if codeOwners.rules[i].pattern.MatchString(relativePath) {
+			if len(codeOwners.rules[i].owners) == 0 {
+				return nil
+			}
+			return append([]string{}, codeOwners.rules[i].owners...)
+		}
+	}
+	return nil
+}
+
+//compile turns a pattern into a regular expression matching the paths of the files it covers, relative to the root.
+//Patterns holding a slash other than a trailing one are anchored to the root, others match at any depth.  Patterns
+//matching a directory cover everything under it, and patterns with a trailing slash only match directories.
+func compile(pattern string) (*regexp.Regexp, error) {
+	anchored := strings.Contains(strings.TrimSuffix(pattern, "/"), "/")
+	directoryOnly := strings.HasSuffix(pattern, "/")
+	pattern = strings.Trim(pattern, "/")
+
+	expression := "^"
+	if !anchored {
+		expression += "(?:.*/)?"
+	}
+	segments := strings.Split(pattern, "/")
+	for i, segment := range segments {
+		last := i == len(segments)-1
+		if segment == "**" {
+			if last {
+				expression += ".*"
+			} else {
+				expression += "(?:.*/)?"
+			}
+			continue
+		}
+		expression += globToExpression(segment)
+		if !last {
+			expression += "/"
+		}
+	}
+	if directoryOnly {
+		expression += "/.*$"
+	} else {
+		expression += "(?:/.*)?$"
+	}
+	return regexp.Compile(expression)
+}
+
+//globToExpression turns a path segment holding * and ? wildcards into a regular expression
+func globToExpression(segment string) string {
+	expression := ""
+	for _, r := range segment {
+		switch r {
+		case '*':
+			expression += "[^/]*"
+		case '?':
+			expression += "[^/]"
+		default:
+			expression += regexp.QuoteMeta(string(r))
+		}
+	}
+	return expression
+}
@@ -0,0 +1,13 @@
+package codeowners_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestCodeOwners(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "CodeOwners Suite")
+}
@@ -0,0 +1,92 @@
+package codeowners_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/internal/codeowners"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CodeOwners", func() {
+	const root = "/repo"
+
+	parse := func(lines ...string) *CodeOwners {
+		codeOwners, err := Parse(strings.NewReader(strings.Join(lines, "\n")), "CODEOWNERS", root)
+		Ω(err).ShouldNot(HaveOccurred())
+		return codeOwners
+	}
+
+	Describe("Owners", func() {
+		It("should let the last matching rule win, ignoring blank lines and comments", func() {
+			codeOwners := parse(
+				"# default owners",
+				"",
+				"*            @org/core",
+				"/reporters/  @org/reporting alice@example.com  # reporting",
+				"*.md",
+			)
+			Ω(codeOwners.Owners("/repo/suite.go")).Should(Equal([]string{"@org/core"}))
+			Ω(codeOwners.Owners("/repo/reporters/json_reporter.go")).Should(Equal([]string{"@org/reporting", "alice@example.com"}))
+			Ω(codeOwners.Owners("/repo/reporters/README.md")).Should(BeNil())
+		})
+
+		It("should match patterns without slashes at any depth, and anchor the others to the root", func() {
+			codeOwners := parse(
+				"*_fixture_test.go @org/integration",
+				"storage @org/storage",
+				"internal/lanes @org/runtime",
+				"docs/**/api.md @org/docs",
+			)
+			Ω(codeOwners.Owners("/repo/integration/_fixtures/a/a_fixture_test.go")).Should(Equal([]string{"@org/integration"}))
+			Ω(codeOwners.Owners("/repo/pkg/storage/disk_test.go")).Should(Equal([]string{"@org/storage"}))
+			Ω(codeOwners.Owners("/repo/internal/lanes/lanes.go")).Should(Equal([]string{"@org/runtime"}))
+			Ω(codeOwners.Owners("/repo/vendor/internal/lanes/lanes.go")).Should(BeNil())
+			Ω(codeOwners.Owners("/repo/docs/api.md")).Should(Equal([]string{"@org/docs"}))
+			Ω(codeOwners.Owners("/repo/docs/v1/http/api.md")).Should(Equal([]string{"@org/docs"}))
+		})
+
+		It("should only match directories with patterns ending with a slash", func() {
+			codeOwners := parse("build/ @org/build")
+			Ω(codeOwners.Owners("/repo/tools/build/main.go")).Should(Equal([]string{"@org/build"}))
+			Ω(codeOwners.Owners("/repo/build")).Should(BeNil())
+		})
+
+		It("should not give owners to files outside of the repository", func() {
+			codeOwners := parse("* @org/core")
+			Ω(codeOwners.Owners("/elsewhere/suite.go")).Should(BeNil())
+		})
+	})
+
+	Describe("Load", func() {
+		var dir string
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "codeowners")
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		It("should take the parent of a .github directory as the root of the repository", func() {
+			Ω(os.Mkdir(filepath.Join(dir, ".github"), 0755)).Should(Succeed())
+			path := filepath.Join(dir, ".github", "CODEOWNERS")
+			Ω(ioutil.WriteFile(path, []byte("/src/ @org/core\n"), 0644)).Should(Succeed())
+
+			codeOwners, err := Load(path)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(codeOwners.Owners(filepath.Join(dir, "src", "suite_test.go"))).Should(Equal([]string{"@org/core"}))
+		})
+
+		It("should fail when the file cannot be read", func() {
+			_, err := Load(filepath.Join(dir, "CODEOWNERS"))
+			Ω(err).Should(HaveOccurred())
+		})
+	})
+})
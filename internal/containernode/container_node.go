@@ -11,6 +11,7 @@ import (
 type subjectOrContainerNode struct {
 	containerNode *ContainerNode
 	subjectNode   leafnodes.SubjectNode
+	subjectLabels []string
 }
 
 func (n subjectOrContainerNode) text() string {
@@ -24,22 +25,32 @@ func (n subjectOrContainerNode) text() string {
 type CollatedNodes struct {
 	Containers []*ContainerNode
 	Subject    leafnodes.SubjectNode
+
+	//Labels holds the labels of the containers, outermost first, followed by those of the subject.  Duplicates are removed.
+	Labels []string
 }
 
 type ContainerNode struct {
 	text         string
 	flag         types.FlagType
 	codeLocation types.CodeLocation
+	labels       []string
+
+	oncePerContainer bool
+	pendingReason    string
+	processAffinity  int
+	serial           bool
 
 	setupNodes               []leafnodes.BasicNode
 	subjectAndContainerNodes []subjectOrContainerNode
 }
 
-func New(text string, flag types.FlagType, codeLocation types.CodeLocation) *ContainerNode {
+func New(text string, flag types.FlagType, codeLocation types.CodeLocation, labels ...string) *ContainerNode {
 	return &ContainerNode{
 		text:         text,
 		flag:         flag,
 		codeLocation: codeLocation,
+		labels:       labels,
 	}
 }
 
@@ -92,9 +103,14 @@ func (node *ContainerNode) collate(enclosingContainers []*ContainerNode) []Colla
 		if subjectOrContainer.containerNode != nil {
 			collated = append(collated, subjectOrContainer.containerNode.collate(containers)...)
 		} else {
+			var labels []string
+			for _, container := range containers {
+				labels = appendLabels(labels, container.labels...)
+			}
 			collated = append(collated, CollatedNodes{
 				Containers: containers,
 				Subject:    subjectOrContainer.subjectNode,
+				Labels:     appendLabels(labels, subjectOrContainer.subjectLabels...),
 			})
 		}
 	}
@@ -106,8 +122,8 @@ func (node *ContainerNode) PushContainerNode(container *ContainerNode) {
 	node.subjectAndContainerNodes = append(node.subjectAndContainerNodes, subjectOrContainerNode{containerNode: container})
 }
 
-func (node *ContainerNode) PushSubjectNode(subject leafnodes.SubjectNode) {
-	node.subjectAndContainerNodes = append(node.subjectAndContainerNodes, subjectOrContainerNode{subjectNode: subject})
+func (node *ContainerNode) PushSubjectNode(subject leafnodes.SubjectNode, labels ...string) {
+	node.subjectAndContainerNodes = append(node.subjectAndContainerNodes, subjectOrContainerNode{subjectNode: subject, subjectLabels: labels})
 }
 
 func (node *ContainerNode) PushSetupNode(setupNode leafnodes.BasicNode) {
@@ -136,6 +152,63 @@ func (node *ContainerNode) Flag() types.FlagType {
 	return node.flag
 }
 
+func (node *ContainerNode) Labels() []string {
+	return node.labels
+}
+
+//SetOncePerContainer makes the setup nodes of the container run once, rather than around each of its specs
+func (node *ContainerNode) SetOncePerContainer() {
+	node.oncePerContainer = true
+}
+
+func (node *ContainerNode) OncePerContainer() bool {
+	return node.oncePerContainer
+}
+
+//SetPendingReason records why the container is pending
+func (node *ContainerNode) SetPendingReason(reason string) {
+	node.pendingReason = reason
+}
+
+func (node *ContainerNode) PendingReason() string {
+	return node.pendingReason
+}
+
+//SetProcessAffinity pins the specs of the container to the given parallel process
+func (node *ContainerNode) SetProcessAffinity(process int) {
+	node.processAffinity = process
+}
+
+//ProcessAffinity returns the parallel process the specs of the container are pinned to, or 0 if they are not pinned
+func (node *ContainerNode) ProcessAffinity() int {
+	return node.processAffinity
+}
+
+//SetSerial keeps the specs of the container from running concurrently with other specs
+func (node *ContainerNode) SetSerial() {
+	node.serial = true
+}
+
+func (node *ContainerNode) Serial() bool {
+	return node.serial
+}
+
+func appendLabels(labels []string, newLabels ...string) []string {
+	for _, newLabel := range newLabels {
+		found := false
+		for _, label := range labels {
+			if label == newLabel {
+				found = true
+				break
+			}
+		}
+		if !found {
+			labels = append(labels, newLabel)
+		}
+	}
+	return labels
+}
+
 //sort.Interface
 
 func (node *ContainerNode) Len() int {
@@ -105,6 +105,25 @@ var _ = Describe("Container Node", func() {
 					Subject:    itB,
 				}))
 			})
+
+			Context("when containers and subjects have labels", func() {
+				It("should attach the labels of the enclosing containers, outermost first, followed by the subject's, without duplicates", func() {
+					labeledContainer := New("labeled", types.FlagTypeNone, codelocation.New(0), "outer", "shared")
+					labeledInnerContainer := New("labeled inner", types.FlagTypeNone, codelocation.New(0), "inner", "shared")
+					labeledIt := leafnodes.NewItNode("labeled it", func() {}, types.FlagTypeNone, codelocation.New(0), 0, nil, 0)
+					unlabeledIt := leafnodes.NewItNode("unlabeled it", func() {}, types.FlagTypeNone, codelocation.New(0), 0, nil, 0)
+
+					labeledContainer.PushContainerNode(labeledInnerContainer)
+					labeledInnerContainer.PushSubjectNode(labeledIt, "subject", "outer")
+					labeledContainer.PushSubjectNode(unlabeledIt)
+
+					collated := labeledContainer.Collate()
+					Ω(collated).Should(HaveLen(2))
+					Ω(collated[0].Labels).Should(Equal([]string{"outer", "shared", "inner", "subject"}))
+					Ω(collated[1].Labels).Should(Equal([]string{"outer", "shared"}))
+					Ω(labeledInnerContainer.Labels()).Should(Equal([]string{"inner", "shared"}))
+				})
+			})
 		})
 
 		Describe("Backpropagating Programmatic Focus", func() {
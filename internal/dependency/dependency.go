@@ -0,0 +1,281 @@
+/*
+Package dependency keeps the specs decorated with DependsOn from running unless their prerequisites passed.
+
+A prerequisite is referred to either by the full text of a spec (see reporters.SpecFullText) or by a label, in which
+case every spec carrying the label is a prerequisite.  Specs are ordered so that prerequisites come before the specs
+that depend on them.  When running in parallel, the outcome of every prerequisite is posted to the /SpecOutcome endpoint
+of the server run by the Ginkgo CLI, and nodes poll it for the outcomes of prerequisites that run on other nodes.
+*/
+package dependency
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/onsi/ginkgo/internal/spec"
+	"github.com/onsi/ginkgo/reporters"
+	"github.com/onsi/ginkgo/types"
+)
+
+//PollingInterval is how long a node waits before checking again for the outcome of a prerequisite running on another node
+var PollingInterval = 50 * time.Millisecond
+
+type declaration struct {
+	ref          string
+	codeLocation types.CodeLocation
+}
+
+//prerequisite is a spec another spec depends on
+type prerequisite struct {
+	key string
+	ref string
+}
+
+type Tracker struct {
+	lock          *sync.Mutex
+	declarations  []declaration
+	prerequisites map[*spec.Spec][]prerequisite
+	order         map[*spec.Spec]int
+	tracked       map[string]bool
+	outcomes      map[string]types.RemoteSpecOutcome
+	parallelNode  int
+	syncHost      string
+	client        *http.Client
+}
+
+func New() *Tracker {
+	return &Tracker{
+		lock:          &sync.Mutex{},
+		prerequisites: map[*spec.Spec][]prerequisite{},
+		order:         map[*spec.Spec]int{},
+		tracked:       map[string]bool{},
+		outcomes:      map[string]types.RemoteSpecOutcome{},
+		client:        &http.Client{},
+	}
+}
+
+//Connect makes the tracker share the outcomes of prerequisites with the other nodes through the server at syncHost
+func (t *Tracker) Connect(parallelNode int, syncHost string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.parallelNode = parallelNode
+	t.syncHost = syncHost
+}
+
+//DeclareAt records that the container or spec at codeLocation depends on the specs refs refer to
+func (t *Tracker) DeclareAt(codeLocation types.CodeLocation, refs ...string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	for _, ref := range refs {
+		t.declarations = append(t.declarations, declaration{ref, codeLocation})
+	}
+}
+
+//Key identifies a spec across the nodes of a parallel run: its full text and where its subject is
+func Key(summary *types.SpecSummary) string {
+	subject := summary.ComponentCodeLocations[len(summary.ComponentCodeLocations)-1]
+	return fmt.Sprintf("%s@%s", reporters.SpecFullText(summary), subject)
+}
+
+//Order resolves the prerequisites of specs and returns specs ordered so that prerequisites come before the specs that
+//depend on them, keeping the order of specs otherwise.  It fails if a reference matches no spec or if specs depend on
+//each other.
+func (t *Tracker) Order(specs []*spec.Spec) ([]*spec.Spec, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if len(t.declarations) == 0 {
+		return specs, nil
+	}
+
+	summaries := make([]*types.SpecSummary, len(specs))
+	for i, s := range specs {
+		summaries[i] = s.Summary("")
+	}
+
+	dependsOn := map[*spec.Spec][]*spec.Spec{}
+	for i, s := range specs {
+		for _, declaration := range t.declarations {
+			if !declaredBy(declaration, summaries[i].ComponentCodeLocations) {
+				continue
+			}
+			matched := false
+			for j, candidate := range specs {
+				if !refersTo(declaration.ref, summaries[j]) {
+					continue
+				}
+				matched = true
+				if candidate == s {
+					continue
+				}
+				key := Key(summaries[j])
+				t.prerequisites[s] = append(t.prerequisites[s], prerequisite{key: key, ref: declaration.ref})
+				t.tracked[key] = true
+				dependsOn[s] = append(dependsOn[s], candidate)
+			}
+			if !matched {
+				return nil, fmt.Errorf("DependsOn(%q) at %s matches no spec: refer to a spec by its full text or to specs by a label", declaration.ref, declaration.codeLocation)
+			}
+		}
+	}
+
+	ordered := []*spec.Spec{}
+	visited := map[*spec.Spec]bool{}
+	visiting := map[*spec.Spec]bool{}
+	var visit func(s *spec.Spec, path []string) error
+	visit = func(s *spec.Spec, path []string) error {
+		text := reporters.SpecFullText(s.Summary(""))
+		if visiting[s] {
+			return fmt.Errorf("specs depend on each other: %s", strings.Join(append(path, text), " -> "))
+		}
+		if visited[s] {
+			return nil
+		}
+		visiting[s] = true
+		for _, prerequisite := range dependsOn[s] {
+			if err := visit(prerequisite, append(path, text)); err != nil {
+				return err
+			}
+		}
+		visiting[s] = false
+		visited[s] = true
+		t.order[s] = len(ordered)
+		ordered = append(ordered, s)
+		return nil
+	}
+	for _, s := range specs {
+		if err := visit(s, nil); err != nil {
+			return nil, err
+		}
+	}
+	return ordered, nil
+}
+
+//Sort sorts specs in the order returned by Order
+func (t *Tracker) Sort(specs []*spec.Spec) []*spec.Spec {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	sorted := append([]*spec.Spec{}, specs...)
+	for i := 1; i < len(sorted); i++ {
+		for j := i; j > 0 && t.order[sorted[j]] < t.order[sorted[j-1]]; j-- {
+			sorted[j], sorted[j-1] = sorted[j-1], sorted[j]
+		}
+	}
+	return sorted
+}
+
+//HasPrerequisites tells whether s depends on other specs
+func (t *Tracker) HasPrerequisites(s *spec.Spec) bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return len(t.prerequisites[s]) > 0
+}
+
+//Check tells whether the outcomes of all the prerequisites of s are known and, if one of them did not pass, why s must
+//be skipped
+func (t *Tracker) Check(s *spec.Spec) (known bool, skipReason string) {
+	t.lock.Lock()
+	prerequisites := t.prerequisites[s]
+	t.lock.Unlock()
+
+	for _, prerequisite := range prerequisites {
+		outcome, known := t.outcome(prerequisite.key)
+		if !known {
+			return false, ""
+		}
+		if outcome.State != types.SpecStatePassed {
+			return true, fmt.Sprintf("Skipped because its prerequisite %q (DependsOn(%q)) %s", outcome.FullText, prerequisite.ref, describe(outcome.State))
+		}
+	}
+	return true, ""
+}
+
+//Record records the outcome of s, once it has completed or been skipped, if other specs depend on it
+func (t *Tracker) Record(s *spec.Spec) {
+	summary := s.Summary("")
+	key := Key(summary)
+
+	t.lock.Lock()
+	if !t.tracked[key] {
+		t.lock.Unlock()
+		return
+	}
+	outcome := types.RemoteSpecOutcome{Key: key, FullText: reporters.SpecFullText(summary), State: summary.State}
+	t.outcomes[key] = outcome
+	syncHost := t.syncHost
+	t.lock.Unlock()
+
+	if syncHost == "" {
+		return
+	}
+	resp, err := t.client.Post(syncHost+"/SpecOutcome", "application/json", bytes.NewReader(outcome.ToJSON()))
+	if err == nil {
+		resp.Body.Close()
+	}
+}
+
+//outcome returns the outcome of the spec identified by key, asking the server for it when running in parallel
+func (t *Tracker) outcome(key string) (types.RemoteSpecOutcome, bool) {
+	t.lock.Lock()
+	outcome, known := t.outcomes[key]
+	syncHost := t.syncHost
+	t.lock.Unlock()
+	if known || syncHost == "" {
+		return outcome, known
+	}
+
+	resp, err := t.client.Get(syncHost + "/SpecOutcome?key=" + url.QueryEscape(key))
+	if err != nil {
+		return outcome, false
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || json.NewDecoder(resp.Body).Decode(&outcome) != nil {
+		return outcome, false
+	}
+
+	t.lock.Lock()
+	t.outcomes[key] = outcome
+	t.lock.Unlock()
+	return outcome, true
+}
+
+func declaredBy(declaration declaration, componentCodeLocations []types.CodeLocation) bool {
+	for _, componentCodeLocation := range componentCodeLocations {
+		if componentCodeLocation.FileName == declaration.codeLocation.FileName && componentCodeLocation.LineNumber == declaration.codeLocation.LineNumber {
+			return true
+		}
+	}
+	return false
+}
+
+//refersTo tells whether ref is the full text or one of the labels of the spec
+func refersTo(ref string, summary *types.SpecSummary) bool {
+	if reporters.SpecFullText(summary) == ref {
+		return true
+	}
+	for _, label := range summary.Labels {
+		if label == ref {
+			return true
+		}
+	}
+	return false
+}
+
+func describe(state types.SpecState) string {
+	switch state {
+	case types.SpecStateSkipped:
+		return "was skipped"
+	case types.SpecStatePending:
+		return "is pending"
+	}
+	return "failed"
+}
@@ -0,0 +1,198 @@
+package dependency_test
+
+import (
+	"io/ioutil"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/onsi/ginkgo/internal/containernode"
+	. "github.com/onsi/ginkgo/internal/dependency"
+	Failer "github.com/onsi/ginkgo/internal/failer"
+	"github.com/onsi/ginkgo/internal/leafnodes"
+	"github.com/onsi/ginkgo/internal/remote"
+	"github.com/onsi/ginkgo/internal/spec"
+	"github.com/onsi/ginkgo/types"
+)
+
+var _ = Describe("Tracker", func() {
+	var (
+		failer          *Failer.Failer
+		root, accounts  *containernode.ContainerNode
+		creates, logsIn *spec.Spec
+		dashboard       *spec.Spec
+	)
+
+	location := func(line int) types.CodeLocation {
+		return types.CodeLocation{FileName: "accounts_test.go", LineNumber: line}
+	}
+
+	newSpec := func(text string, line int, fail bool, labels ...string) *spec.Spec {
+		body := func() {
+			if fail {
+				failer.Fail("boom", location(line))
+			}
+		}
+		it := leafnodes.NewItNode(text, body, types.FlagTypeNone, location(line), 0, failer, 0)
+		return spec.New(it, []*containernode.ContainerNode{root, accounts}, false, labels...)
+	}
+
+	texts := func(specs []*spec.Spec) []string {
+		result := []string{}
+		for _, s := range specs {
+			result = append(result, s.Summary("").ComponentTexts[2])
+		}
+		return result
+	}
+
+	BeforeEach(func() {
+		failer = Failer.New()
+		root = containernode.New("[Top Level]", types.FlagTypeNone, types.CodeLocation{})
+		accounts = containernode.New("accounts", types.FlagTypeNone, location(1))
+		dashboard = newSpec("shows the dashboard", 2, false)
+		logsIn = newSpec("logs in", 3, false)
+		creates = newSpec("creates the account", 4, false, "smoke")
+	})
+
+	Describe("ordering specs", func() {
+		It("should leave the specs alone when none depends on another", func() {
+			tracker := New()
+			ordered, err := tracker.Order([]*spec.Spec{dashboard, logsIn, creates})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(texts(ordered)).Should(Equal([]string{"shows the dashboard", "logs in", "creates the account"}))
+		})
+
+		It("should put prerequisites, referred to by full text or label, before the specs that depend on them", func() {
+			tracker := New()
+			tracker.DeclareAt(location(2), "accounts logs in")
+			tracker.DeclareAt(location(3), "smoke")
+
+			ordered, err := tracker.Order([]*spec.Spec{dashboard, logsIn, creates})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(texts(ordered)).Should(Equal([]string{"creates the account", "logs in", "shows the dashboard"}))
+			Ω(tracker.HasPrerequisites(dashboard)).Should(BeTrue())
+			Ω(tracker.HasPrerequisites(creates)).Should(BeFalse())
+
+			Ω(texts(tracker.Sort([]*spec.Spec{dashboard, creates, logsIn}))).Should(Equal([]string{"creates the account", "logs in", "shows the dashboard"}))
+		})
+
+		It("should apply the declarations of containers to all their specs", func() {
+			tracker := New()
+			tracker.DeclareAt(location(1), "smoke")
+
+			ordered, err := tracker.Order([]*spec.Spec{dashboard, logsIn, creates})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(texts(ordered)).Should(Equal([]string{"creates the account", "shows the dashboard", "logs in"}))
+			Ω(tracker.HasPrerequisites(creates)).Should(BeFalse())
+		})
+
+		It("should fail when a reference matches no spec", func() {
+			tracker := New()
+			tracker.DeclareAt(location(3), "accounts deletes the account")
+
+			_, err := tracker.Order([]*spec.Spec{dashboard, logsIn, creates})
+			Ω(err).Should(MatchError(ContainSubstring(`DependsOn("accounts deletes the account") at accounts_test.go:3 matches no spec`)))
+		})
+
+		It("should fail when specs depend on each other", func() {
+			tracker := New()
+			tracker.DeclareAt(location(2), "accounts logs in")
+			tracker.DeclareAt(location(3), "accounts shows the dashboard")
+
+			_, err := tracker.Order([]*spec.Spec{dashboard, logsIn, creates})
+			Ω(err).Should(MatchError("specs depend on each other: accounts shows the dashboard -> accounts logs in -> accounts shows the dashboard"))
+		})
+	})
+
+	Describe("checking prerequisites", func() {
+		var tracker *Tracker
+
+		BeforeEach(func() {
+			tracker = New()
+			tracker.DeclareAt(location(3), "smoke")
+		})
+
+		It("should not know the outcome of prerequisites that have not completed", func() {
+			_, err := tracker.Order([]*spec.Spec{logsIn, creates})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			known, _ := tracker.Check(logsIn)
+			Ω(known).Should(BeFalse())
+		})
+
+		It("should let the spec run once its prerequisites passed", func() {
+			_, err := tracker.Order([]*spec.Spec{logsIn, creates})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			creates.Run(ioutil.Discard)
+			tracker.Record(creates)
+
+			known, skipReason := tracker.Check(logsIn)
+			Ω(known).Should(BeTrue())
+			Ω(skipReason).Should(BeEmpty())
+		})
+
+		It("should explain why the spec is skipped when a prerequisite failed", func() {
+			creates = newSpec("creates the account", 4, true, "smoke")
+			_, err := tracker.Order([]*spec.Spec{logsIn, creates})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			creates.Run(ioutil.Discard)
+			tracker.Record(creates)
+
+			known, skipReason := tracker.Check(logsIn)
+			Ω(known).Should(BeTrue())
+			Ω(skipReason).Should(Equal(`Skipped because its prerequisite "accounts creates the account" (DependsOn("smoke")) failed`))
+		})
+
+		It("should explain why the spec is skipped when a prerequisite was skipped", func() {
+			_, err := tracker.Order([]*spec.Spec{logsIn, creates})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			creates.Skip()
+			tracker.Record(creates)
+
+			_, skipReason := tracker.Check(logsIn)
+			Ω(skipReason).Should(HaveSuffix("was skipped"))
+		})
+	})
+
+	Context("when running in parallel", func() {
+		var server *remote.Server
+
+		BeforeEach(func() {
+			var err error
+			server, err = remote.NewServer(2)
+			Ω(err).ShouldNot(HaveOccurred())
+			server.Start()
+			PollingInterval = time.Millisecond
+		})
+
+		AfterEach(func() {
+			PollingInterval = 50 * time.Millisecond
+			server.Close()
+		})
+
+		It("should learn the outcome of prerequisites that completed on other nodes", func() {
+			nodeOne, nodeTwo := New(), New()
+			nodeOne.Connect(1, server.Address())
+			nodeTwo.Connect(2, server.Address())
+			for _, tracker := range []*Tracker{nodeOne, nodeTwo} {
+				tracker.DeclareAt(location(3), "accounts creates the account")
+				_, err := tracker.Order([]*spec.Spec{logsIn, creates})
+				Ω(err).ShouldNot(HaveOccurred())
+			}
+
+			known, _ := nodeTwo.Check(logsIn)
+			Ω(known).Should(BeFalse())
+
+			creates.Run(ioutil.Discard)
+			nodeOne.Record(creates)
+
+			known, skipReason := nodeTwo.Check(logsIn)
+			Ω(known).Should(BeTrue())
+			Ω(skipReason).Should(BeEmpty())
+		})
+	})
+})
@@ -2,31 +2,249 @@ package failer
 
 import (
 	"fmt"
+	"runtime"
 	"sync"
+	"time"
 
+	"github.com/onsi/ginkgo/internal/lanes"
 	"github.com/onsi/ginkgo/types"
 )
 
-type Failer struct {
-	lock    *sync.Mutex
+type outcome struct {
 	failure types.SpecFailure
 	state   types.SpecState
 }
 
+type Failer struct {
+	lock    *sync.Mutex
+	outcome *outcome
+	lanes   map[int64]*outcome
+	abort   *types.SpecFailure
+
+	detectGoroutineFailures bool
+	nodeTimeout             time.Duration
+	nodeGoroutines          map[int64]int
+	goroutineFailures       map[int64]goroutineFailure
+
+	generation           uint64
+	nodeGenerations      map[int64]uint64
+	abandonedGenerations map[uint64]bool
+	abandonedInOrder     []uint64
+}
+
+//maxAbandonedGenerations bounds how many abandoned node runs the failer keeps fenced off.  Past it, the oldest are
+//retired: what their goroutines, should they still be running, report from then on is no longer dropped.
+const maxAbandonedGenerations = 64
+
+//goroutineFailure is the last failure raised by a goroutine through Fail, along with the outcome it was raised in and
+//what was recorded in that outcome, unless it had already failed
+type goroutineFailure struct {
+	failure  types.SpecFailure
+	outcome  *outcome
+	recorded *types.SpecFailure
+}
+
 func New() *Failer {
 	return &Failer{
-		lock:  &sync.Mutex{},
-		state: types.SpecStatePassed,
+		lock:              &sync.Mutex{},
+		outcome:           &outcome{state: types.SpecStatePassed},
+		lanes:             map[int64]*outcome{},
+		nodeGoroutines:    map[int64]int{},
+		goroutineFailures: map[int64]goroutineFailure{},
+
+		nodeGenerations:      map[int64]uint64{},
+		abandonedGenerations: map[uint64]bool{},
+	}
+}
+
+//DetectGoroutineFailures makes the failer keep track of the goroutines running nodes, so that FailOutsideNode can
+//catch the failures raised by the other goroutines
+func (f *Failer) DetectGoroutineFailures(detect bool) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.detectGoroutineFailures = detect
+}
+
+//SetNodeTimeout sets how long any node may run before it is timed out, 0 for no limit (see -nodeTimeout)
+func (f *Failer) SetNodeTimeout(timeout time.Duration) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.nodeTimeout = timeout
+}
+
+//NodeTimeout returns how long any node may run before it is timed out, 0 for no limit
+func (f *Failer) NodeTimeout() time.Duration {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.nodeTimeout
+}
+
+//NewGeneration returns a new generation to tag a node run with, see EnterNode and Abandon
+func (f *Failer) NewGeneration() uint64 {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.generation++
+	return f.generation
+}
+
+//EnterNode records that the calling goroutine runs the node run tagged with generation until the returned function is
+//called
+func (f *Failer) EnterNode(generation uint64) func() {
+	//Current, unlike ID, records which goroutine started the calling one, so that the goroutines the node starts can be
+	//told to descend from its lane once it has exited
+	id, _ := lanes.Current()
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	previousGeneration, nested := f.nodeGenerations[id]
+	f.nodeGenerations[id] = generation
+	if f.detectGoroutineFailures {
+		f.nodeGoroutines[id]++
+	}
+	detectGoroutineFailures := f.detectGoroutineFailures
+	return func() {
+		f.lock.Lock()
+		defer f.lock.Unlock()
+		if detectGoroutineFailures {
+			f.nodeGoroutines[id]--
+			if f.nodeGoroutines[id] == 0 {
+				delete(f.nodeGoroutines, id)
+			}
+		}
+		if f.abandonedGenerations[generation] {
+			//the goroutines the abandoned node started may still be running: the goroutine stays fenced off
+			return
+		}
+		if nested {
+			f.nodeGenerations[id] = previousGeneration
+		} else {
+			delete(f.nodeGenerations, id)
+		}
+	}
+}
+
+//Abandon fences off the node run tagged with generation, which is left running once it has timed out: what its
+//goroutine, and the goroutines it started, report from then on is dropped rather than landing in the outcome of the
+//nodes and specs that follow
+func (f *Failer) Abandon(generation uint64) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.abandonedGenerations[generation] {
+		return
+	}
+	f.abandonedGenerations[generation] = true
+	f.abandonedInOrder = append(f.abandonedInOrder, generation)
+	if len(f.abandonedInOrder) > maxAbandonedGenerations {
+		f.retire(f.abandonedInOrder[0])
+		f.abandonedInOrder = f.abandonedInOrder[1:]
+	}
+}
+
+//retire forgets the abandoned node run tagged with generation, see maxAbandonedGenerations.  The lock must be held.
+func (f *Failer) retire(generation uint64) {
+	delete(f.abandonedGenerations, generation)
+	for id, nodeGeneration := range f.nodeGenerations {
+		if nodeGeneration == generation {
+			delete(f.nodeGenerations, id)
+		}
+	}
+}
+
+//fenced tells whether the calling goroutine belongs to an abandoned node run, see Abandon.  The lock must be held.
+func (f *Failer) fenced() bool {
+	if len(f.abandonedGenerations) == 0 {
+		return false
+	}
+	id, ok := lanes.Resolve(func(id int64) bool {
+		_, ok := f.nodeGenerations[id]
+		return ok
+	})
+	return ok && f.abandonedGenerations[f.nodeGenerations[id]]
+}
+
+//FailOutsideNode fails like Fail when the calling goroutine does not run a node while a node runs, and tells whether
+//it did.  Such goroutines are started by the node, and unless they defer GinkgoRecover, the panic Fail raises to stop
+//them crashes the test binary.  The caller stops the goroutine with runtime.Goexit instead, so the failure records how
+//to fix the goroutine along with its stack.
+func (f *Failer) FailOutsideNode(message string, location types.CodeLocation) bool {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if !f.detectGoroutineFailures || len(f.nodeGoroutines) == 0 {
+		return false
+	}
+	id := lanes.ID()
+	if f.nodeGoroutines[id] > 0 {
+		return false
+	}
+	if f.fenced() {
+		return true
+	}
+
+	o := f.current()
+	goroutineFailure := goroutineFailure{failure: types.SpecFailure{Message: message, Location: location}, outcome: o}
+	defer func() { f.goroutineFailures[id] = goroutineFailure }()
+	if o.state == types.SpecStatePassed {
+		buf := make([]byte, 64*1024)
+		buf = buf[:runtime.Stack(buf, false)]
+		o.state = types.SpecStateFailed
+		o.failure = types.SpecFailure{
+			Message:  fmt.Sprintf("%s\n\nThis failure was raised from goroutine %d, which was started by the spec rather than run by Ginkgo.  Ginkgo stopped the goroutine, as -detectGoroutineFailures is set.  Without it, the goroutine must defer GinkgoRecover() for its failures not to crash the test binary:\n\n\tgo func() {\n\t\tdefer GinkgoRecover()\n\t\t...\n\t}()\n\nStack of goroutine %d:\n%s", message, id, id, buf),
+			Location: location,
+		}
+		recorded := o.failure
+		goroutineFailure.recorded = &recorded
 	}
+	return true
+}
+
+//OpenLane routes the failures recorded by the calling goroutine, and by the goroutines it starts, to a lane of their own
+//until CloseLane is called.  This keeps the failures of specs running concurrently apart.
+func (f *Failer) OpenLane() {
+	id := lanes.ID()
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.lanes[id] = &outcome{state: types.SpecStatePassed}
+}
+
+//CloseLane closes the lane opened by the calling goroutine
+func (f *Failer) CloseLane() {
+	id := lanes.ID()
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	delete(f.lanes, id)
+}
+
+//current returns the outcome of the lane of the calling goroutine, if any, or else the outcome shared by all goroutines.
+//The lock must be held.
+func (f *Failer) current() *outcome {
+	if len(f.lanes) == 0 {
+		return f.outcome
+	}
+	if id, ok := lanes.Resolve(func(id int64) bool { return f.lanes[id] != nil }); ok {
+		return f.lanes[id]
+	}
+	return f.outcome
+}
+
+//State returns the state of the node running on the calling goroutine, as recorded so far
+func (f *Failer) State() types.SpecState {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.current().state
 }
 
 func (f *Failer) Panic(location types.CodeLocation, forwardedPanic interface{}) {
 	f.lock.Lock()
 	defer f.lock.Unlock()
+	if f.fenced() {
+		return
+	}
 
-	if f.state == types.SpecStatePassed {
-		f.state = types.SpecStatePanicked
-		f.failure = types.SpecFailure{
+	o := f.current()
+	if o.state == types.SpecStatePassed {
+		o.state = types.SpecStatePanicked
+		o.failure = types.SpecFailure{
 			Message:        "Test Panicked",
 			Location:       location,
 			ForwardedPanic: fmt.Sprintf("%v", forwardedPanic),
@@ -38,53 +256,166 @@ func (f *Failer) Timeout(location types.CodeLocation) {
 	f.lock.Lock()
 	defer f.lock.Unlock()
 
-	if f.state == types.SpecStatePassed {
-		f.state = types.SpecStateTimedOut
-		f.failure = types.SpecFailure{
+	o := f.current()
+	if o.state == types.SpecStatePassed {
+		o.state = types.SpecStateTimedOut
+		o.failure = types.SpecFailure{
 			Message:  "Timed out",
 			Location: location,
 		}
 	}
 }
 
-func (f *Failer) Fail(message string, location types.CodeLocation) {
+//NodeTimedOut times the node out, as Timeout does, for running longer than the node timeout.  The failure records the
+//stacks of all the goroutines, to show where the node is stuck.
+func (f *Failer) NodeTimedOut(location types.CodeLocation) {
+	buf := make([]byte, 1<<16)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+
 	f.lock.Lock()
 	defer f.lock.Unlock()
 
-	if f.state == types.SpecStatePassed {
-		f.state = types.SpecStateFailed
-		f.failure = types.SpecFailure{
-			Message:  message,
+	o := f.current()
+	if o.state == types.SpecStatePassed {
+		o.state = types.SpecStateTimedOut
+		o.failure = types.SpecFailure{
+			Message:  fmt.Sprintf("Timed out after %s, the -nodeTimeout ceiling for any node.  The node is left running.\n\nStacks of all goroutines:\n%s", f.nodeTimeout, buf),
 			Location: location,
 		}
 	}
 }
 
+func (f *Failer) Fail(message string, location types.CodeLocation) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.fenced() {
+		return
+	}
+
+	o := f.current()
+	failure := types.SpecFailure{
+		Message:  message,
+		Location: location,
+	}
+	goroutineFailure := goroutineFailure{failure: failure, outcome: o}
+	if o.state == types.SpecStatePassed {
+		o.state = types.SpecStateFailed
+		o.failure = failure
+		goroutineFailure.recorded = &failure
+	}
+	id := lanes.ID()
+	f.goroutineFailures[id] = goroutineFailure
+}
+
+//Abort fails like Fail, and records that the suite is aborted: no spec is to run after the one that is running
+func (f *Failer) Abort(message string, location types.CodeLocation) {
+	f.Fail(message, location)
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.abort == nil && !f.fenced() {
+		f.abort = &types.SpecFailure{Message: message, Location: location}
+	}
+}
+
+//Aborted returns the failure the suite was aborted with, if Abort was called
+func (f *Failer) Aborted() (types.SpecFailure, bool) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.abort == nil {
+		return types.SpecFailure{}, false
+	}
+	return *f.abort, true
+}
+
+//RetractGoroutineFailure takes back the last failure raised by the calling goroutine through Fail, so that it can be
+//reported apart from the failure of the running node.  It returns false if there is none, or if the node that
+//recorded it has already completed, failing with it.
+func (f *Failer) RetractGoroutineFailure() (types.SpecFailure, bool) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	id := lanes.ID()
+	goroutineFailure, ok := f.goroutineFailures[id]
+	if !ok {
+		return types.SpecFailure{}, false
+	}
+	delete(f.goroutineFailures, id)
+
+	o, recorded := goroutineFailure.outcome, goroutineFailure.recorded
+	if recorded == nil {
+		//the node had already failed, so the failure was not recorded
+		return goroutineFailure.failure, true
+	}
+	if o.state != types.SpecStateFailed || o.failure.Message != recorded.Message || o.failure.Location != recorded.Location {
+		return types.SpecFailure{}, false
+	}
+	o.state = types.SpecStatePassed
+	o.failure = types.SpecFailure{}
+	return goroutineFailure.failure, true
+}
+
+//FailSnapshotMismatch fails like Fail, recording what did not match the snapshot in the failure
+func (f *Failer) FailSnapshotMismatch(message string, location types.CodeLocation, mismatch types.SnapshotMismatch) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.fenced() {
+		return
+	}
+
+	o := f.current()
+	if o.state == types.SpecStatePassed {
+		o.state = types.SpecStateFailed
+		o.failure = types.SpecFailure{
+			Message:          message,
+			Location:         location,
+			SnapshotMismatch: &mismatch,
+		}
+	}
+}
+
 func (f *Failer) Drain(componentType types.SpecComponentType, componentIndex int, componentCodeLocation types.CodeLocation) (types.SpecFailure, types.SpecState) {
 	f.lock.Lock()
 	defer f.lock.Unlock()
 
-	failure := f.failure
-	outcome := f.state
-	if outcome != types.SpecStatePassed {
+	o := f.current()
+	failure := o.failure
+	state := o.state
+	if state != types.SpecStatePassed {
 		failure.ComponentType = componentType
 		failure.ComponentIndex = componentIndex
 		failure.ComponentCodeLocation = componentCodeLocation
 	}
 
-	f.state = types.SpecStatePassed
-	f.failure = types.SpecFailure{}
+	o.state = types.SpecStatePassed
+	o.failure = types.SpecFailure{}
+	for id, goroutineFailure := range f.goroutineFailures {
+		if goroutineFailure.outcome == o {
+			delete(f.goroutineFailures, id)
+		}
+	}
 
-	return failure, outcome
+	return failure, state
 }
 
 func (f *Failer) Skip(message string, location types.CodeLocation) {
 	f.lock.Lock()
 	defer f.lock.Unlock()
+	if f.fenced() {
+		return
+	}
 
-	if f.state == types.SpecStatePassed {
-		f.state = types.SpecStateSkipped
-		f.failure = types.SpecFailure{
+	o := f.current()
+	if o.state == types.SpecStatePassed {
+		o.state = types.SpecStateSkipped
+		o.failure = types.SpecFailure{
 			Message:  message,
 			Location: location,
 		}
@@ -30,6 +30,16 @@ var _ = Describe("Failer", func() {
 		})
 	})
 
+	Describe("State", func() {
+		It("should return the state recorded so far, until drained", func() {
+			Ω(failer.State()).Should(Equal(types.SpecStatePassed))
+			failer.Fail("something failed", codeLocationA)
+			Ω(failer.State()).Should(Equal(types.SpecStateFailed))
+			failer.Drain(types.SpecComponentTypeIt, 3, codeLocationB)
+			Ω(failer.State()).Should(Equal(types.SpecStatePassed))
+		})
+	})
+
 	Describe("Skip", func() {
 		It("should handle failures", func() {
 			failer.Skip("something skipped", codeLocationA)
@@ -62,6 +72,40 @@ var _ = Describe("Failer", func() {
 		})
 	})
 
+	Describe("Abort", func() {
+		It("should fail, and record that the suite is aborted until the failer is recreated", func() {
+			_, aborted := failer.Aborted()
+			Ω(aborted).Should(BeFalse())
+
+			failer.Abort("something went very wrong", codeLocationA)
+			failer.Abort("something else went wrong", codeLocationB)
+			failure, state := failer.Drain(types.SpecComponentTypeIt, 3, codeLocationB)
+			Ω(failure.Message).Should(Equal("something went very wrong"))
+			Ω(state).Should(Equal(types.SpecStateFailed))
+
+			abort, aborted := failer.Aborted()
+			Ω(aborted).Should(BeTrue())
+			Ω(abort).Should(Equal(types.SpecFailure{Message: "something went very wrong", Location: codeLocationA}))
+		})
+	})
+
+	Describe("FailSnapshotMismatch", func() {
+		It("should handle failures, recording the mismatch", func() {
+			mismatch := types.SnapshotMismatch{Name: "invoice", Path: "testdata/__snapshots__/invoice.snap", Expected: "a", Actual: "b", Diff: "- a\n+ b"}
+			failer.FailSnapshotMismatch("snapshot mismatch", codeLocationA, mismatch)
+			failure, state := failer.Drain(types.SpecComponentTypeIt, 3, codeLocationB)
+			Ω(failure).Should(Equal(types.SpecFailure{
+				Message:               "snapshot mismatch",
+				Location:              codeLocationA,
+				ComponentType:         types.SpecComponentTypeIt,
+				ComponentIndex:        3,
+				ComponentCodeLocation: codeLocationB,
+				SnapshotMismatch:      &mismatch,
+			}))
+			Ω(state).Should(Equal(types.SpecStateFailed))
+		})
+	})
+
 	Describe("Panic", func() {
 		It("should handle panics", func() {
 			failer.Panic(codeLocationA, "some forwarded panic")
@@ -138,4 +182,213 @@ var _ = Describe("Failer", func() {
 			Ω(state).Should(Equal(types.SpecStatePassed))
 		})
 	})
+
+	Describe("lanes", func() {
+		It("should keep the failures of each lane apart", func() {
+			drained := make(chan types.SpecState)
+			go func() {
+				failer.OpenLane()
+				defer failer.CloseLane()
+				failer.Fail("something failed in the lane", codeLocationA)
+				_, state := failer.Drain(types.SpecComponentTypeIt, 3, codeLocationB)
+				drained <- state
+			}()
+			Ω(<-drained).Should(Equal(types.SpecStateFailed))
+
+			failure, state := failer.Drain(types.SpecComponentTypeIt, 3, codeLocationB)
+			Ω(failure).Should(BeZero())
+			Ω(state).Should(Equal(types.SpecStatePassed))
+		})
+
+		It("should route the failures of goroutines started in a lane to the lane", func() {
+			drained := make(chan types.SpecFailure)
+			go func() {
+				failer.OpenLane()
+				defer failer.CloseLane()
+				done := make(chan struct{})
+				go func() {
+					failer.Fail("something failed in a goroutine", codeLocationA)
+					close(done)
+				}()
+				<-done
+				failure, _ := failer.Drain(types.SpecComponentTypeIt, 3, codeLocationB)
+				drained <- failure
+			}()
+			Ω((<-drained).Message).Should(Equal("something failed in a goroutine"))
+		})
+	})
+
+	Describe("detecting goroutine failures", func() {
+		failOutsideNode := func() bool {
+			result := make(chan bool)
+			go func() {
+				result <- failer.FailOutsideNode("something failed in a goroutine", codeLocationA)
+			}()
+			return <-result
+		}
+
+		It("should leave failures alone unless told to detect them", func() {
+			exit := failer.EnterNode(failer.NewGeneration())
+			defer exit()
+			Ω(failOutsideNode()).Should(BeFalse())
+		})
+
+		Context("when told to detect them", func() {
+			BeforeEach(func() {
+				failer.DetectGoroutineFailures(true)
+			})
+
+			It("should leave failures alone when no node runs", func() {
+				Ω(failOutsideNode()).Should(BeFalse())
+			})
+
+			It("should leave the failures of the goroutine running the node alone", func() {
+				exit := failer.EnterNode(failer.NewGeneration())
+				defer exit()
+				Ω(failer.FailOutsideNode("something failed in the node", codeLocationA)).Should(BeFalse())
+			})
+
+			It("should record the failures of other goroutines, explaining how to fix them", func() {
+				exit := failer.EnterNode(failer.NewGeneration())
+				Ω(failOutsideNode()).Should(BeTrue())
+				exit()
+
+				failure, state := failer.Drain(types.SpecComponentTypeIt, 3, codeLocationB)
+				Ω(state).Should(Equal(types.SpecStateFailed))
+				Ω(failure.Location).Should(Equal(codeLocationA))
+				Ω(failure.Message).Should(HavePrefix("something failed in a goroutine\n\nThis failure was raised from goroutine "))
+				Ω(failure.Message).Should(ContainSubstring("defer GinkgoRecover()"))
+				Ω(failure.Message).Should(ContainSubstring("failer_test.go"))
+
+				Ω(failOutsideNode()).Should(BeFalse())
+			})
+		})
+	})
+
+	Describe("abandoning node runs", func() {
+		inNode := func(generation uint64, report func()) {
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				defer failer.EnterNode(generation)()
+				report()
+			}()
+			<-done
+		}
+
+		It("should drop what the abandoned node run, and the goroutines it started, report", func() {
+			abandoned := failer.NewGeneration()
+			failer.Abandon(abandoned)
+
+			inNode(abandoned, func() {
+				failer.Fail("too late", codeLocationA)
+				failer.Panic(codeLocationA, "too late")
+				failer.Skip("too late", codeLocationA)
+				failer.Abort("too late", codeLocationA)
+
+				started := make(chan struct{})
+				go func() {
+					defer close(started)
+					failer.Fail("too late from a goroutine", codeLocationA)
+				}()
+				<-started
+			})
+
+			_, state := failer.Drain(types.SpecComponentTypeIt, 3, codeLocationB)
+			Ω(state).Should(Equal(types.SpecStatePassed))
+			_, aborted := failer.Aborted()
+			Ω(aborted).Should(BeFalse())
+		})
+
+		It("should keep recording what the node runs of other generations report", func() {
+			failer.Abandon(failer.NewGeneration())
+
+			inNode(failer.NewGeneration(), func() {
+				failer.Fail("something failed", codeLocationA)
+			})
+
+			failure, state := failer.Drain(types.SpecComponentTypeIt, 3, codeLocationB)
+			Ω(state).Should(Equal(types.SpecStateFailed))
+			Ω(failure.Message).Should(Equal("something failed"))
+		})
+
+		It("should retire the oldest of the 64 abandoned node runs it keeps fenced off", func() {
+			oldest := failer.NewGeneration()
+			failer.Abandon(oldest)
+			entered, release, reported := make(chan struct{}), make(chan struct{}), make(chan struct{})
+			go func() {
+				defer failer.EnterNode(oldest)()
+				close(entered)
+				<-release
+				failer.Fail("much too late", codeLocationA)
+				close(reported)
+			}()
+			<-entered
+
+			for i := 0; i < 64; i++ {
+				failer.Abandon(failer.NewGeneration())
+			}
+			close(release)
+			<-reported
+
+			failure, state := failer.Drain(types.SpecComponentTypeIt, 3, codeLocationB)
+			Ω(state).Should(Equal(types.SpecStateFailed))
+			Ω(failure.Message).Should(Equal("much too late"))
+		})
+	})
+
+	Describe("retracting goroutine failures", func() {
+		retract := func(fail func()) (types.SpecFailure, bool) {
+			type result struct {
+				failure types.SpecFailure
+				ok      bool
+			}
+			retracted := make(chan result)
+			go func() {
+				fail()
+				failure, ok := failer.RetractGoroutineFailure()
+				retracted <- result{failure, ok}
+			}()
+			r := <-retracted
+			return r.failure, r.ok
+		}
+
+		It("should retract nothing when the goroutine did not fail", func() {
+			failer.Fail("something failed elsewhere", codeLocationA)
+			_, ok := retract(func() {})
+			Ω(ok).Should(BeFalse())
+
+			_, state := failer.Drain(types.SpecComponentTypeIt, 3, codeLocationB)
+			Ω(state).Should(Equal(types.SpecStateFailed))
+		})
+
+		It("should take back the failure the goroutine recorded", func() {
+			failure, ok := retract(func() { failer.Fail("something failed in a goroutine", codeLocationA) })
+			Ω(ok).Should(BeTrue())
+			Ω(failure).Should(Equal(types.SpecFailure{Message: "something failed in a goroutine", Location: codeLocationA}))
+
+			failure, state := failer.Drain(types.SpecComponentTypeIt, 3, codeLocationB)
+			Ω(failure).Should(BeZero())
+			Ω(state).Should(Equal(types.SpecStatePassed))
+		})
+
+		It("should return the failure of the goroutine, leaving the failure recorded before alone", func() {
+			failer.Fail("something failed first", codeLocationB)
+			failure, ok := retract(func() { failer.Fail("something failed in a goroutine", codeLocationA) })
+			Ω(ok).Should(BeTrue())
+			Ω(failure.Message).Should(Equal("something failed in a goroutine"))
+
+			failure, state := failer.Drain(types.SpecComponentTypeIt, 3, codeLocationB)
+			Ω(failure.Message).Should(Equal("something failed first"))
+			Ω(state).Should(Equal(types.SpecStateFailed))
+		})
+
+		It("should not retract failures that were already drained", func() {
+			_, ok := retract(func() {
+				failer.Fail("something failed in a goroutine", codeLocationA)
+				failer.Drain(types.SpecComponentTypeIt, 3, codeLocationB)
+			})
+			Ω(ok).Should(BeFalse())
+		})
+	})
 })
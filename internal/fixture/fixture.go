@@ -0,0 +1,195 @@
+/*
+Package fixture implements the shared fixtures behind ginkgo.SharedFixture.
+
+A fixture is set up by the first spec that calls Get and is shared by the specs that follow.  Specs declare that they use a
+fixture by code location: the fixture is torn down as soon as the last of the specs declaring it that are expected to run
+has completed, or at the end of the suite.
+*/
+package fixture
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/onsi/ginkgo/internal/clock"
+	"github.com/onsi/ginkgo/internal/failer"
+	"github.com/onsi/ginkgo/internal/leafnodes"
+	"github.com/onsi/ginkgo/types"
+)
+
+type Fixture struct {
+	name         string
+	setup        func() interface{}
+	teardown     func(interface{})
+	codeLocation types.CodeLocation
+	failer       *failer.Failer
+
+	lock                 *sync.Mutex
+	declaredAt           []types.CodeLocation
+	expectedReferences   int
+	isSetUp              bool
+	value                interface{}
+	setUpFailure         string
+	referencingSpecTexts map[string]bool
+	current              *types.SharedFixtureSummary
+	summaries            []*types.SharedFixtureSummary
+}
+
+func New(name string, setup func() interface{}, teardown func(interface{}), codeLocation types.CodeLocation, failer *failer.Failer) *Fixture {
+	return &Fixture{
+		name:         name,
+		setup:        setup,
+		teardown:     teardown,
+		codeLocation: codeLocation,
+		failer:       failer,
+		lock:         &sync.Mutex{},
+	}
+}
+
+func (f *Fixture) Name() string {
+	return f.name
+}
+
+func (f *Fixture) CodeLocation() types.CodeLocation {
+	return f.codeLocation
+}
+
+//DeclareAt records that the container or spec at codeLocation uses the fixture
+func (f *Fixture) DeclareAt(codeLocation types.CodeLocation) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.declaredAt = append(f.declaredAt, codeLocation)
+}
+
+//IsDeclaredBy tells whether one of the containers or the subject of a spec, given their code locations, uses the fixture
+func (f *Fixture) IsDeclaredBy(componentCodeLocations []types.CodeLocation) bool {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	for _, componentCodeLocation := range componentCodeLocations {
+		for _, declaredAt := range f.declaredAt {
+			if componentCodeLocation.FileName == declaredAt.FileName && componentCodeLocation.LineNumber == declaredAt.LineNumber {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+//ExpectReference records that a spec declaring the fixture is expected to run
+func (f *Fixture) ExpectReference() {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.expectedReferences++
+}
+
+//Get returns the value of the fixture to the spec with the given text, setting the fixture up first if needed.  The
+//error is only returned by the calls that follow a failed set up: the call that attempted it fails (or panics) with it.
+func (f *Fixture) Get(specText string) (interface{}, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if f.setUpFailure != "" {
+		return nil, fmt.Errorf("SharedFixture %q failed to set up in %s", f.name, f.setUpFailure)
+	}
+
+	if !f.isSetUp {
+		f.current = &types.SharedFixtureSummary{
+			Name:         f.name,
+			CodeLocation: f.codeLocation,
+			SetUpBy:      specText,
+			SetUpTime:    clock.Now(),
+		}
+		f.summaries = append(f.summaries, f.current)
+		f.referencingSpecTexts = map[string]bool{}
+
+		setUp := false
+		defer func() {
+			if !setUp {
+				f.setUpFailure = specText
+				f.current.Failure = fmt.Sprintf("failed to set up in %s", specText)
+				f.current.SetUpRunTime = clock.Since(f.current.SetUpTime)
+			}
+		}()
+		f.value = f.setup()
+		setUp = true
+		f.isSetUp = true
+		f.current.SetUpRunTime = clock.Since(f.current.SetUpTime)
+	}
+
+	if !f.referencingSpecTexts[specText] {
+		f.referencingSpecTexts[specText] = true
+		f.current.NumberOfReferences++
+	}
+	return f.value, nil
+}
+
+//Release is called once a spec that declares the fixture has completed.  The fixture is torn down once all the specs
+//declaring it that were expected to run have completed.  When the expected references were not counted (e.g. when running
+//in parallel) Release does nothing and the fixture lives until the end of the suite.  Release returns false if the teardown failed.
+func (f *Fixture) Release(specText string) bool {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if f.expectedReferences == 0 {
+		return true
+	}
+	f.expectedReferences--
+	if f.expectedReferences > 0 {
+		return true
+	}
+	return f.tearDown(specText)
+}
+
+//TearDown tears the fixture down, if it is still set up, at the end of the suite.  It returns false if the teardown failed.
+func (f *Fixture) TearDown() bool {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	return f.tearDown("")
+}
+
+func (f *Fixture) tearDown(specText string) bool {
+	f.setUpFailure = ""
+	if !f.isSetUp {
+		return true
+	}
+
+	f.isSetUp = false
+	f.current.TornDownAfter = specText
+	f.current.TearDownTime = clock.Now()
+	value := f.value
+	f.value = nil
+
+	passed := true
+	if f.teardown != nil {
+		node := leafnodes.NewAfterSuiteNode(func() {
+			f.teardown(value)
+		}, f.codeLocation, 0, f.failer)
+		passed = node.Run(0, 0, "")
+		if !passed {
+			failure := node.Summary().Failure
+			f.current.Failure = fmt.Sprintf("failed to tear down: %s", failure.Message)
+			if failure.ForwardedPanic != "" {
+				f.current.Failure += "\n" + failure.ForwardedPanic
+			}
+		}
+	}
+	f.current.TearDownRunTime = clock.Since(f.current.TearDownTime)
+	return passed
+}
+
+//Summaries describes each lifetime of the fixture so far
+func (f *Fixture) Summaries() []*types.SharedFixtureSummary {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	summaries := []*types.SharedFixtureSummary{}
+	for _, summary := range f.summaries {
+		s := *summary
+		summaries = append(summaries, &s)
+	}
+	return summaries
+}
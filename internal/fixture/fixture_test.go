@@ -0,0 +1,158 @@
+package fixture_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	Failer "github.com/onsi/ginkgo/internal/failer"
+	"github.com/onsi/ginkgo/internal/fixture"
+	"github.com/onsi/ginkgo/types"
+)
+
+var _ = Describe("Fixture", func() {
+	var (
+		failer        *Failer.Failer
+		codeLocation  types.CodeLocation
+		events        []string
+		setups        int
+		sharedFixture *fixture.Fixture
+	)
+
+	BeforeEach(func() {
+		failer = Failer.New()
+		codeLocation = types.CodeLocation{FileName: "fixtures_test.go", LineNumber: 3}
+		events = []string{}
+		setups = 0
+		sharedFixture = fixture.New("postgres", func() interface{} {
+			setups++
+			events = append(events, "setup")
+			return setups
+		}, func(value interface{}) {
+			events = append(events, "teardown")
+			Ω(value).Should(Equal(setups))
+		}, codeLocation, failer)
+	})
+
+	Describe("declarations", func() {
+		It("should match the code locations of containers and specs that declared the fixture", func() {
+			sharedFixture.DeclareAt(types.CodeLocation{FileName: "books_test.go", LineNumber: 10, FullStackTrace: "one stack"})
+
+			Ω(sharedFixture.IsDeclaredBy([]types.CodeLocation{{}, {FileName: "books_test.go", LineNumber: 10, FullStackTrace: "another stack"}})).Should(BeTrue())
+			Ω(sharedFixture.IsDeclaredBy([]types.CodeLocation{{}, {FileName: "books_test.go", LineNumber: 11}})).Should(BeFalse())
+		})
+	})
+
+	Describe("sharing the fixture", func() {
+		It("should set the sharedFixture up once and share its value", func() {
+			value, err := sharedFixture.Get("A")
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(value).Should(Equal(1))
+
+			value, err = sharedFixture.Get("B")
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(value).Should(Equal(1))
+			sharedFixture.Get("B")
+
+			Ω(events).Should(Equal([]string{"setup"}))
+			summaries := sharedFixture.Summaries()
+			Ω(summaries).Should(HaveLen(1))
+			Ω(summaries[0].Name).Should(Equal("postgres"))
+			Ω(summaries[0].CodeLocation).Should(Equal(codeLocation))
+			Ω(summaries[0].SetUpBy).Should(Equal("A"))
+			Ω(summaries[0].SetUpTime).ShouldNot(BeZero())
+			Ω(summaries[0].NumberOfReferences).Should(Equal(2))
+		})
+	})
+
+	Describe("releasing the fixture", func() {
+		BeforeEach(func() {
+			sharedFixture.ExpectReference()
+			sharedFixture.ExpectReference()
+		})
+
+		It("should tear the sharedFixture down once the last expected reference is released", func() {
+			sharedFixture.Get("A")
+			Ω(sharedFixture.Release("A")).Should(BeTrue())
+			Ω(events).Should(Equal([]string{"setup"}))
+
+			sharedFixture.Get("B")
+			Ω(sharedFixture.Release("B")).Should(BeTrue())
+			Ω(events).Should(Equal([]string{"setup", "teardown"}))
+
+			summary := sharedFixture.Summaries()[0]
+			Ω(summary.TornDownAfter).Should(Equal("B"))
+			Ω(summary.TearDownTime).ShouldNot(BeZero())
+			Ω(summary.Failure).Should(BeEmpty())
+
+			By("setting the sharedFixture up again if it is needed after that")
+			value, _ := sharedFixture.Get("C")
+			Ω(value).Should(Equal(2))
+			Ω(sharedFixture.Summaries()).Should(HaveLen(2))
+		})
+
+		It("should not tear down a sharedFixture that was never set up", func() {
+			Ω(sharedFixture.Release("A")).Should(BeTrue())
+			Ω(sharedFixture.Release("B")).Should(BeTrue())
+			Ω(events).Should(BeEmpty())
+			Ω(sharedFixture.Summaries()).Should(BeEmpty())
+		})
+	})
+
+	Describe("tearing the sharedFixture down at the end of the suite", func() {
+		It("should tear the sharedFixture down when references were not counted", func() {
+			sharedFixture.Get("A")
+			Ω(sharedFixture.Release("A")).Should(BeTrue())
+			Ω(events).Should(Equal([]string{"setup"}))
+
+			Ω(sharedFixture.TearDown()).Should(BeTrue())
+			Ω(events).Should(Equal([]string{"setup", "teardown"}))
+			Ω(sharedFixture.Summaries()[0].TornDownAfter).Should(BeEmpty())
+
+			Ω(sharedFixture.TearDown()).Should(BeTrue())
+			Ω(events).Should(Equal([]string{"setup", "teardown"}))
+		})
+	})
+
+	Context("when the setup fails", func() {
+		BeforeEach(func() {
+			sharedFixture = fixture.New("postgres", func() interface{} {
+				panic("connection refused")
+			}, nil, codeLocation, failer)
+		})
+
+		It("should let the panic through, and fail the following calls to Get until it is torn down", func() {
+			Ω(func() {
+				sharedFixture.Get("A")
+			}).Should(PanicWith("connection refused"))
+
+			_, err := sharedFixture.Get("B")
+			Ω(err).Should(MatchError(`SharedFixture "postgres" failed to set up in A`))
+			Ω(sharedFixture.Summaries()[0].Failure).Should(Equal("failed to set up in A"))
+
+			Ω(sharedFixture.TearDown()).Should(BeTrue())
+			Ω(func() {
+				sharedFixture.Get("C")
+			}).Should(PanicWith("connection refused"))
+		})
+	})
+
+	Context("when the teardown fails", func() {
+		BeforeEach(func() {
+			sharedFixture = fixture.New("postgres", func() interface{} {
+				return nil
+			}, func(value interface{}) {
+				failer.Fail("could not stop", codeLocation)
+				panic("stop")
+			}, codeLocation, failer)
+		})
+
+		It("should record the failure and leave the failer clean", func() {
+			sharedFixture.Get("A")
+			Ω(sharedFixture.TearDown()).Should(BeFalse())
+			Ω(sharedFixture.Summaries()[0].Failure).Should(Equal("failed to tear down: could not stop"))
+
+			_, state := failer.Drain(types.SpecComponentTypeIt, 0, codeLocation)
+			Ω(state).Should(Equal(types.SpecStatePassed))
+		})
+	})
+})
@@ -0,0 +1,340 @@
+/*
+Package hermetic detects the specs that escape their sandbox under -hermetic: those that open network connections, or
+open files for writing outside the directories they may write to.
+
+A Monitor lists the file descriptors of the process under /proc while the spec runs, so it only works on Linux, and a
+connection or a file opened and closed between two of its polls goes unnoticed.
+*/
+package hermetic
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+//PollInterval is how often a Monitor lists the file descriptors of the process
+const PollInterval = 2 * time.Millisecond
+
+//Supported tells whether the platform lets a Monitor watch the file descriptors of the process
+func Supported() bool {
+	if runtime.GOOS != "linux" {
+		return false
+	}
+	_, err := os.Stat("/proc/self/fdinfo")
+	return err == nil
+}
+
+//Allowlist is what a spec may do without breaking its hermeticity
+type Allowlist struct {
+	hosts []*net.IPNet
+	ports map[string]bool
+	any   bool
+	dirs  []string
+}
+
+/*
+ParseAllowlist reads the entries passed to ginkgo.HermeticAllow.  Entries that are absolute paths, or that start with ./
+or ../, are directories the spec may write files under.  The others are hosts the spec may connect to or listen on:
+
+	127.0.0.1           an IP address, on any port
+	[::1]:8080          an IP address and a port
+	10.0.0.0/8          a CIDR block
+	*                   any host
+*/
+func ParseAllowlist(entries []string) (Allowlist, error) {
+	allowlist := Allowlist{ports: map[string]bool{}}
+	for _, entry := range entries {
+		switch {
+		case filepath.IsAbs(entry) || strings.HasPrefix(entry, "./") || strings.HasPrefix(entry, "../"):
+			dir, err := resolveDir(entry)
+			if err != nil {
+				return Allowlist{}, err
+			}
+			allowlist.dirs = append(allowlist.dirs, dir)
+		case entry == "*":
+			allowlist.any = true
+		case strings.Contains(entry, "/"):
+			_, block, err := net.ParseCIDR(entry)
+			if err != nil {
+				return Allowlist{}, fmt.Errorf("invalid CIDR block %q: %s", entry, err.Error())
+			}
+			allowlist.hosts = append(allowlist.hosts, block)
+		default:
+			if ip := net.ParseIP(strings.Trim(entry, "[]")); ip != nil {
+				allowlist.hosts = append(allowlist.hosts, &net.IPNet{IP: ip, Mask: net.CIDRMask(len(ip)*8, len(ip)*8)})
+				continue
+			}
+			host, port, err := net.SplitHostPort(entry)
+			ip := net.ParseIP(host)
+			if err != nil || ip == nil {
+				return Allowlist{}, fmt.Errorf("invalid host %q: expected an IP address, optionally followed by a port, a CIDR block, * or a directory", entry)
+			}
+			allowlist.ports[net.JoinHostPort(ip.String(), port)] = true
+		}
+	}
+	return allowlist, nil
+}
+
+//resolveDir returns the absolute path of dir with its symbolic links resolved, as /proc shows the files open under it
+func resolveDir(dir string) (string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	if resolved, err := filepath.EvalSymlinks(abs); err == nil {
+		return resolved, nil
+	}
+	return abs, nil
+}
+
+func (allowlist Allowlist) allowsHost(ip net.IP, port int) bool {
+	if allowlist.any || allowlist.ports[net.JoinHostPort(ip.String(), strconv.Itoa(port))] {
+		return true
+	}
+	for _, block := range allowlist.hosts {
+		if block.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func (allowlist Allowlist) allowsWrite(path string) bool {
+	if strings.HasPrefix(path, "/dev/") {
+		return true
+	}
+	for _, dir := range allowlist.dirs {
+		if path == dir || strings.HasPrefix(path, strings.TrimSuffix(dir, string(filepath.Separator))+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+//Monitor watches the file descriptors the process opens while a spec runs
+type Monitor struct {
+	allowlist Allowlist
+	known     map[string]bool
+
+	violations []string
+	seen       map[string]bool
+
+	stop chan struct{}
+	done chan struct{}
+	lock *sync.Mutex
+}
+
+//Start starts watching the file descriptors the process opens from now on.  The spec may do what allowlist allows, and
+//write files under writableDirs, e.g. the temporary directory.
+func Start(allowlist Allowlist, writableDirs ...string) *Monitor {
+	for _, dir := range writableDirs {
+		if resolved, err := resolveDir(dir); err == nil && dir != "" {
+			allowlist.dirs = append(allowlist.dirs, resolved)
+		}
+	}
+	m := &Monitor{
+		allowlist: allowlist,
+		known:     map[string]bool{},
+		seen:      map[string]bool{},
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+		lock:      &sync.Mutex{},
+	}
+	for _, fd := range openFileDescriptors() {
+		m.known[fd.key()] = true
+	}
+
+	go func() {
+		defer close(m.done)
+		ticker := time.NewTicker(PollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-m.stop:
+				return
+			case <-ticker.C:
+				m.poll()
+			}
+		}
+	}()
+	return m
+}
+
+//Stop stops watching the file descriptors of the process and returns the violations it saw, sorted
+func (m *Monitor) Stop() []string {
+	close(m.stop)
+	<-m.done
+	m.poll()
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	violations := append([]string{}, m.violations...)
+	sort.Strings(violations)
+	return violations
+}
+
+func (m *Monitor) poll() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	var sockets map[string]socket
+	for _, fd := range openFileDescriptors() {
+		if m.known[fd.key()] {
+			continue
+		}
+		m.known[fd.key()] = true
+
+		violation := ""
+		switch {
+		case strings.HasPrefix(fd.target, "socket:["):
+			if sockets == nil {
+				sockets = inetSockets()
+			}
+			s, ok := sockets[strings.TrimSuffix(strings.TrimPrefix(fd.target, "socket:["), "]")]
+			if !ok {
+				continue
+			}
+			if s.localPort == 0 {
+				//the socket is not bound yet: look at it again once it is connected or listening
+				delete(m.known, fd.key())
+				continue
+			}
+			if s.remotePort != 0 && !m.allowlist.allowsHost(s.remoteIP, s.remotePort) {
+				violation = fmt.Sprintf("connected to %s over %s", net.JoinHostPort(s.remoteIP.String(), strconv.Itoa(s.remotePort)), s.protocol)
+			} else if s.remotePort == 0 && !m.allowlist.allowsHost(s.localIP, s.localPort) {
+				violation = fmt.Sprintf("listened on %s over %s", net.JoinHostPort(s.localIP.String(), strconv.Itoa(s.localPort)), s.protocol)
+			}
+		case filepath.IsAbs(fd.target) && fd.writable() && !m.allowlist.allowsWrite(fd.target):
+			violation = fmt.Sprintf("opened %s for writing", fd.target)
+		}
+
+		if violation != "" && !m.seen[violation] {
+			m.seen[violation] = true
+			m.violations = append(m.violations, violation)
+		}
+	}
+}
+
+type fileDescriptor struct {
+	number string
+	target string
+}
+
+func (fd fileDescriptor) key() string {
+	return fd.number + " " + fd.target
+}
+
+//writable tells whether the file descriptor was opened for writing, from the access mode of its flags
+func (fd fileDescriptor) writable() bool {
+	data, err := ioutil.ReadFile("/proc/self/fdinfo/" + fd.number)
+	if err != nil {
+		return false
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if strings.HasPrefix(line, "flags:") {
+			flags, err := strconv.ParseUint(strings.TrimSpace(strings.TrimPrefix(line, "flags:")), 8, 64)
+			return err == nil && flags&uint64(os.O_WRONLY|os.O_RDWR) != 0
+		}
+	}
+	return false
+}
+
+func openFileDescriptors() []fileDescriptor {
+	dir, err := os.Open("/proc/self/fd")
+	if err != nil {
+		return nil
+	}
+	numbers, err := dir.Readdirnames(-1)
+	dir.Close()
+	if err != nil {
+		return nil
+	}
+	fds := []fileDescriptor{}
+	for _, number := range numbers {
+		target, err := os.Readlink("/proc/self/fd/" + number)
+		if err != nil {
+			continue
+		}
+		fds = append(fds, fileDescriptor{number: number, target: target})
+	}
+	return fds
+}
+
+type socket struct {
+	protocol   string
+	localIP    net.IP
+	localPort  int
+	remoteIP   net.IP
+	remotePort int
+}
+
+//inetSockets maps the inodes of the TCP and UDP sockets of the network namespace of the process to their addresses
+func inetSockets() map[string]socket {
+	sockets := map[string]socket{}
+	for _, protocol := range []string{"tcp", "tcp6", "udp", "udp6"} {
+		f, err := os.Open("/proc/self/net/" + protocol)
+		if err != nil {
+			continue
+		}
+		scanner := bufio.NewScanner(f)
+		scanner.Scan()
+		for scanner.Scan() {
+			fields := strings.Fields(scanner.Text())
+			if len(fields) < 10 {
+				continue
+			}
+			localIP, localPort, err := parseAddress(fields[1])
+			if err != nil {
+				continue
+			}
+			remoteIP, remotePort, err := parseAddress(fields[2])
+			if err != nil {
+				continue
+			}
+			sockets[fields[9]] = socket{
+				protocol:   strings.TrimSuffix(protocol, "6"),
+				localIP:    localIP,
+				localPort:  localPort,
+				remoteIP:   remoteIP,
+				remotePort: remotePort,
+			}
+		}
+		f.Close()
+	}
+	return sockets
+}
+
+//parseAddress parses an address of /proc/net/tcp: the hexadecimal IP address, as little-endian 32-bit words, a colon and
+//the hexadecimal port
+func parseAddress(address string) (net.IP, int, error) {
+	fields := strings.Split(address, ":")
+	if len(fields) != 2 {
+		return nil, 0, fmt.Errorf("invalid address %q", address)
+	}
+	data, err := hex.DecodeString(fields[0])
+	if err != nil || (len(data) != net.IPv4len && len(data) != net.IPv6len) {
+		return nil, 0, fmt.Errorf("invalid address %q", address)
+	}
+	port, err := strconv.ParseUint(fields[1], 16, 16)
+	if err != nil {
+		return nil, 0, err
+	}
+	ip := make(net.IP, len(data))
+	for word := 0; word < len(data); word += 4 {
+		for i := 0; i < 4; i++ {
+			ip[word+i] = data[word+3-i]
+		}
+	}
+	return ip, int(port), nil
+}
@@ -0,0 +1,13 @@
+package hermetic_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestHermetic(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Hermetic Suite")
+}
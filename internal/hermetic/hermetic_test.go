@@ -0,0 +1,125 @@
+package hermetic_test
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/internal/hermetic"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Hermetic", func() {
+	Describe("ParseAllowlist", func() {
+		It("should accept directories, IP addresses, ports, CIDR blocks and *", func() {
+			_, err := ParseAllowlist([]string{"/var/cache", "./testdata", "127.0.0.1", "[::1]:8080", "10.0.0.0/8", "*"})
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should reject hosts that are not IP addresses", func() {
+			_, err := ParseAllowlist([]string{"example.com"})
+			Ω(err).Should(MatchError(ContainSubstring(`invalid host "example.com"`)))
+
+			_, err = ParseAllowlist([]string{"10.0.0.0/33"})
+			Ω(err).Should(MatchError(ContainSubstring(`invalid CIDR block "10.0.0.0/33"`)))
+		})
+	})
+
+	Describe("Monitor", func() {
+		var dir string
+
+		BeforeEach(func() {
+			if !Supported() {
+				Skip("hermeticity is only enforced on Linux")
+			}
+			var err error
+			dir, err = ioutil.TempDir("", "hermetic")
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		//hold keeps what the spec opened open long enough for the monitor to see it
+		hold := func() {
+			time.Sleep(10 * PollInterval)
+		}
+
+		It("should report the connections and the listeners the spec opens", func() {
+			monitor := Start(Allowlist{})
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			Ω(err).ShouldNot(HaveOccurred())
+			defer listener.Close()
+			conn, err := net.Dial("tcp", listener.Addr().String())
+			Ω(err).ShouldNot(HaveOccurred())
+			defer conn.Close()
+			hold()
+
+			Ω(monitor.Stop()).Should(ConsistOf(
+				"listened on "+listener.Addr().String()+" over tcp",
+				"connected to "+listener.Addr().String()+" over tcp",
+			))
+		})
+
+		It("should let the spec use the hosts of its allowlist", func() {
+			allowlist, err := ParseAllowlist([]string{"127.0.0.0/8"})
+			Ω(err).ShouldNot(HaveOccurred())
+			monitor := Start(allowlist)
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			Ω(err).ShouldNot(HaveOccurred())
+			defer listener.Close()
+			hold()
+
+			Ω(monitor.Stop()).Should(BeEmpty())
+		})
+
+		It("should report the files the spec opens for writing outside the writable directories", func() {
+			allowed := filepath.Join(dir, "allowed")
+			Ω(os.Mkdir(allowed, 0755)).Should(Succeed())
+			Ω(ioutil.WriteFile(filepath.Join(dir, "read"), []byte("data"), 0644)).Should(Succeed())
+
+			monitor := Start(Allowlist{}, allowed)
+			written, err := os.Create(filepath.Join(dir, "written"))
+			Ω(err).ShouldNot(HaveOccurred())
+			defer written.Close()
+			read, err := os.Open(filepath.Join(dir, "read"))
+			Ω(err).ShouldNot(HaveOccurred())
+			defer read.Close()
+			inside, err := os.Create(filepath.Join(allowed, "written"))
+			Ω(err).ShouldNot(HaveOccurred())
+			defer inside.Close()
+			hold()
+
+			resolved, err := filepath.EvalSymlinks(dir)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(monitor.Stop()).Should(Equal([]string{"opened " + filepath.Join(resolved, "written") + " for writing"}))
+		})
+
+		It("should let the spec write under the directories of its allowlist", func() {
+			allowlist, err := ParseAllowlist([]string{dir})
+			Ω(err).ShouldNot(HaveOccurred())
+			monitor := Start(allowlist)
+			written, err := os.Create(filepath.Join(dir, "written"))
+			Ω(err).ShouldNot(HaveOccurred())
+			defer written.Close()
+			hold()
+
+			Ω(monitor.Stop()).Should(BeEmpty())
+		})
+
+		It("should ignore what was open before it started", func() {
+			written, err := os.Create(filepath.Join(dir, "written"))
+			Ω(err).ShouldNot(HaveOccurred())
+			defer written.Close()
+
+			monitor := Start(Allowlist{})
+			hold()
+
+			Ω(monitor.Stop()).Should(BeEmpty())
+		})
+	})
+})
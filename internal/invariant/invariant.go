@@ -0,0 +1,57 @@
+/*
+Package invariant checks the state the specs of a suite share after each spec, to find the spec that first leaves it
+polluted, e.g. with leftover files or database rows.
+*/
+package invariant
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/onsi/ginkgo/types"
+)
+
+//Invariant is a check of the state shared by the specs, see ginkgo.RegisterSuiteInvariant
+type Invariant struct {
+	check        func() error
+	codeLocation types.CodeLocation
+
+	lock     *sync.Mutex
+	violated bool
+}
+
+func New(check func() error, codeLocation types.CodeLocation) *Invariant {
+	return &Invariant{
+		check:        check,
+		codeLocation: codeLocation,
+		lock:         &sync.Mutex{},
+	}
+}
+
+func (invariant *Invariant) CodeLocation() types.CodeLocation {
+	return invariant.codeLocation
+}
+
+//Check runs the check, and returns its error if the invariant held until now: the spec that just ran is then the first
+//to violate it.  Once violated, the invariant is only reported again after it held anew.  A check that panics is violated.
+func (invariant *Invariant) Check() error {
+	err := invariant.run()
+
+	invariant.lock.Lock()
+	defer invariant.lock.Unlock()
+	wasViolated := invariant.violated
+	invariant.violated = err != nil
+	if wasViolated {
+		return nil
+	}
+	return err
+}
+
+func (invariant *Invariant) run() (err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = fmt.Errorf("panicked: %v", e)
+		}
+	}()
+	return invariant.check()
+}
@@ -0,0 +1,13 @@
+package invariant_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestInvariant(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Invariant Suite")
+}
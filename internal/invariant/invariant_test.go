@@ -0,0 +1,46 @@
+package invariant_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/internal/invariant"
+	"github.com/onsi/ginkgo/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Invariant", func() {
+	var leftovers []string
+	var invariant *Invariant
+
+	BeforeEach(func() {
+		leftovers = nil
+		invariant = New(func() error {
+			if len(leftovers) > 0 {
+				return errors.New("leftovers")
+			}
+			return nil
+		}, types.CodeLocation{})
+	})
+
+	It("should only report the first violation, until the invariant holds again", func() {
+		Ω(invariant.Check()).Should(Succeed())
+
+		leftovers = append(leftovers, "file")
+		Ω(invariant.Check()).Should(MatchError("leftovers"))
+		Ω(invariant.Check()).Should(Succeed())
+
+		leftovers = nil
+		Ω(invariant.Check()).Should(Succeed())
+
+		leftovers = append(leftovers, "row")
+		Ω(invariant.Check()).Should(MatchError("leftovers"))
+	})
+
+	It("should report a check that panics as violated", func() {
+		invariant = New(func() error {
+			panic("boom")
+		}, types.CodeLocation{})
+		Ω(invariant.Check()).Should(MatchError("panicked: boom"))
+	})
+})
@@ -0,0 +1,149 @@
+/*
+Package lanes identifies the goroutines that run specs concurrently within a single process, so that the failures and
+the output of each spec can be routed to the lane of the spec that caused them.
+
+A lane is identified by the ID of the goroutine that runs the spec.  The goroutines it starts, and the goroutines they
+start in turn, belong to the same lane, provided the runtime reports which goroutine started them.  The package records
+which goroutine started each goroutine it comes across, so that the ancestry of a goroutine can be walked up after its
+ancestors have exited.  Ancestors that exited before the package came across them cannot be told, and neither can the
+ancestors of goroutines started before a lane was opened.
+*/
+package lanes
+
+import (
+	"bytes"
+	"regexp"
+	"runtime"
+	"strconv"
+	"sync"
+)
+
+var createdByRegExp = regexp.MustCompile(`created by .* in goroutine (\d+)`)
+
+//maxRecordedParents bounds how many goroutines the package remembers the parents of.  Past it, the records start over.
+const maxRecordedParents = 4096
+
+var (
+	stackBuffers = sync.Pool{New: func() interface{} {
+		buf := make([]byte, 4*1024)
+		return &buf
+	}}
+
+	lock    = &sync.Mutex{}
+	parents = map[int64]int64{}
+)
+
+//ID returns the ID of the calling goroutine
+func ID() int64 {
+	var buf [64]byte
+	return parseID(buf[:runtime.Stack(buf[:], false)])
+}
+
+//Current returns the ID of the calling goroutine, along with the ID of the goroutine that started it, or 0 if the
+//runtime does not report it.  It records which goroutine started the calling one, see Resolve.
+func Current() (id int64, parent int64) {
+	bufp := stackBuffers.Get().(*[]byte)
+	defer stackBuffers.Put(bufp)
+
+	stack := *bufp
+	for {
+		n := runtime.Stack(stack, false)
+		if n < len(stack) {
+			stack = stack[:n]
+			break
+		}
+		stack = make([]byte, 2*len(stack))
+		*bufp = stack
+	}
+
+	id, parent = parseID(stack), parseParent(stack)
+	lock.Lock()
+	defer lock.Unlock()
+	record(id, parent)
+	return id, parent
+}
+
+//Resolve returns the lane the calling goroutine belongs to: its own if isLane reports it is one, or else the one of the
+//closest goroutine it descends from that is one
+func Resolve(isLane func(id int64) bool) (int64, bool) {
+	id := ID()
+	if isLane(id) {
+		return id, true
+	}
+
+	_, ancestor := Current()
+	recordedAll := false
+	for ancestor != 0 {
+		if isLane(ancestor) {
+			return ancestor, true
+		}
+		lock.Lock()
+		parent, ok := parents[ancestor]
+		lock.Unlock()
+		if !ok && !recordedAll {
+			recordAll(ancestor)
+			recordedAll = true
+			continue
+		}
+		ancestor = parent
+	}
+	return 0, false
+}
+
+//recordAll records the parents of all the running goroutines.  unknown, the goroutine whose parent is looked for, is
+//recorded as having none when it is no longer running, so that its ancestry is not looked for again.
+func recordAll(unknown int64) {
+	buf := make([]byte, 64*1024)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+	for _, stack := range bytes.Split(buf, []byte("\n\n")) {
+		record(parseID(stack), parseParent(stack))
+	}
+	if _, ok := parents[unknown]; !ok {
+		parents[unknown] = 0
+	}
+}
+
+//record records that the goroutine id was started by parent, starting the records over once there are too many.  The
+//lock must be held.
+func record(id int64, parent int64) {
+	if id == 0 {
+		return
+	}
+	if _, ok := parents[id]; ok {
+		return
+	}
+	if len(parents) >= maxRecordedParents {
+		parents = map[int64]int64{}
+	}
+	parents[id] = parent
+}
+
+//parseID parses the ID of the goroutine out of the header of its stack, goroutine <id> [<status>]:
+func parseID(stack []byte) int64 {
+	id := int64(0)
+	for _, c := range bytes.TrimPrefix(stack, []byte("goroutine ")) {
+		if c < '0' || c > '9' {
+			break
+		}
+		id = 10*id + int64(c-'0')
+	}
+	return id
+}
+
+func parseParent(stack []byte) int64 {
+	if match := createdByRegExp.FindSubmatch(stack); match != nil {
+		parent, _ := strconv.ParseInt(string(match[1]), 10, 64)
+		return parent
+	}
+	return 0
+}
@@ -0,0 +1,13 @@
+package lanes_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestLanes(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Lanes Suite")
+}
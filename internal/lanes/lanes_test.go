@@ -0,0 +1,87 @@
+package lanes_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/onsi/ginkgo/internal/lanes"
+)
+
+var _ = Describe("Lanes", func() {
+	It("identifies the calling goroutine", func() {
+		id, _ := Current()
+		Ω(id).Should(BeNumerically(">", 0))
+
+		otherID := make(chan int64)
+		go func() {
+			id, _ := Current()
+			otherID <- id
+		}()
+		Ω(<-otherID).ShouldNot(Equal(id))
+	})
+
+	It("resolves the goroutines started by a lane to that lane", func() {
+		lane, _ := Current()
+		isLane := func(id int64) bool { return id == lane }
+
+		id, ok := Resolve(isLane)
+		Ω(ok).Should(BeTrue())
+		Ω(id).Should(Equal(lane))
+
+		resolved := make(chan int64)
+		go func() {
+			id, _ := Resolve(isLane)
+			resolved <- id
+		}()
+		Ω(<-resolved).Should(Equal(lane))
+	})
+
+	It("tells the ID of the calling goroutine cheaply", func() {
+		id, _ := Current()
+		Ω(ID()).Should(Equal(id))
+		Ω(testing.AllocsPerRun(100, func() { ID() })).Should(BeNumerically("<=", 1))
+		Ω(testing.AllocsPerRun(100, func() { Current() })).Should(BeNumerically("<", 5))
+	})
+
+	It("resolves the goroutines started by the goroutines of a lane to that lane", func() {
+		lane := ID()
+		isLane := func(id int64) bool { return id == lane }
+
+		resolved, release := make(chan int64), make(chan struct{})
+		go func() {
+			go func() {
+				id, _ := Resolve(isLane)
+				resolved <- id
+			}()
+			<-release
+		}()
+		Ω(<-resolved).Should(Equal(lane))
+		close(release)
+	})
+
+	It("resolves them once the goroutines in between have exited, provided they were come across", func() {
+		lane := ID()
+		isLane := func(id int64) bool { return id == lane }
+
+		resolved, started, exited := make(chan int64), make(chan struct{}), make(chan struct{})
+		go func() {
+			defer close(exited)
+			Current()
+			go func() {
+				<-started
+				id, _ := Resolve(isLane)
+				resolved <- id
+			}()
+		}()
+		<-exited
+		close(started)
+		Ω(<-resolved).Should(Equal(lane))
+	})
+
+	It("does not resolve goroutines outside of any lane", func() {
+		_, ok := Resolve(func(id int64) bool { return false })
+		Ω(ok).Should(BeFalse())
+	})
+})
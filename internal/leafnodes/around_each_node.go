@@ -0,0 +1,62 @@
+package leafnodes
+
+import (
+	"github.com/onsi/ginkgo/internal/failer"
+	"github.com/onsi/ginkgo/types"
+)
+
+//AroundEachNode wraps every spec of the suite: its body is handed a function that runs the spec
+type AroundEachNode struct {
+	body         func(run func())
+	codeLocation types.CodeLocation
+	failer       *failer.Failer
+}
+
+func NewAroundEachNode(body func(run func()), codeLocation types.CodeLocation, failer *failer.Failer) *AroundEachNode {
+	return &AroundEachNode{
+		body:         body,
+		codeLocation: codeLocation,
+		failer:       failer,
+	}
+}
+
+func (node *AroundEachNode) CodeLocation() types.CodeLocation {
+	return node.codeLocation
+}
+
+//Wrapping returns a node that runs the body of the AroundEach node around run.  The returned node fails if the body
+//returns without calling run.
+func (node *AroundEachNode) Wrapping(run func()) BasicNode {
+	ran := false
+	return &aroundEachRun{
+		runner: newRunner(func() {
+			node.body(func() {
+				ran = true
+				run()
+			})
+			if !ran {
+				node.failer.Fail("AroundEach returned without running the spec: call the function it is handed", node.codeLocation)
+			}
+		}, node.codeLocation, 0, node.failer, types.SpecComponentTypeAroundEach, 0),
+	}
+}
+
+type aroundEachRun struct {
+	runner *runner
+}
+
+func (node *aroundEachRun) Run() (outcome types.SpecState, failure types.SpecFailure) {
+	return node.runner.run()
+}
+
+func (node *aroundEachRun) ProgressReports() []string {
+	return node.runner.progressReports()
+}
+
+func (node *aroundEachRun) Type() types.SpecComponentType {
+	return node.runner.nodeType
+}
+
+func (node *aroundEachRun) CodeLocation() types.CodeLocation {
+	return node.runner.codeLocation
+}
@@ -0,0 +1,62 @@
+package leafnodes_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/types"
+	. "github.com/onsi/gomega"
+
+	. "github.com/onsi/ginkgo/internal/leafnodes"
+
+	"github.com/onsi/ginkgo/internal/codelocation"
+	Failer "github.com/onsi/ginkgo/internal/failer"
+)
+
+var _ = Describe("AroundEachNode", func() {
+	var (
+		failer       *Failer.Failer
+		codeLocation types.CodeLocation
+	)
+
+	BeforeEach(func() {
+		failer = Failer.New()
+		codeLocation = codelocation.New(0)
+	})
+
+	It("should report the correct type and code location", func() {
+		node := NewAroundEachNode(func(run func()) { run() }, codeLocation, failer).Wrapping(func() {})
+		Ω(node.Type()).Should(Equal(types.SpecComponentTypeAroundEach))
+		Ω(node.CodeLocation()).Should(Equal(codeLocation))
+	})
+
+	It("should run the body around the wrapped function", func() {
+		calls := []string{}
+		node := NewAroundEachNode(func(run func()) {
+			calls = append(calls, "before")
+			run()
+			calls = append(calls, "after")
+		}, codeLocation, failer).Wrapping(func() {
+			calls = append(calls, "run")
+		})
+
+		outcome, _ := node.Run()
+		Ω(outcome).Should(Equal(types.SpecStatePassed))
+		Ω(calls).Should(Equal([]string{"before", "run", "after"}))
+	})
+
+	It("should fail if the body does not run the wrapped function", func() {
+		node := NewAroundEachNode(func(run func()) {}, codeLocation, failer).Wrapping(func() {})
+
+		outcome, failure := node.Run()
+		Ω(outcome).Should(Equal(types.SpecStateFailed))
+		Ω(failure.Message).Should(ContainSubstring("AroundEach returned without running the spec"))
+		Ω(failure.ComponentType).Should(Equal(types.SpecComponentTypeAroundEach))
+	})
+
+	It("should report a panic in the body", func() {
+		node := NewAroundEachNode(func(run func()) { panic("boom") }, codeLocation, failer).Wrapping(func() {})
+
+		outcome, failure := node.Run()
+		Ω(outcome).Should(Equal(types.SpecStatePanicked))
+		Ω(failure.ForwardedPanic).Should(Equal("boom"))
+	})
+})
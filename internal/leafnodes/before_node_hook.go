@@ -0,0 +1,56 @@
+package leafnodes
+
+import (
+	"github.com/onsi/ginkgo/internal/failer"
+	"github.com/onsi/ginkgo/types"
+)
+
+//BeforeNodeHook runs right before the body of every node of every spec: its body is handed a description of the node
+type BeforeNodeHook struct {
+	body         func(node types.NodeInfo)
+	codeLocation types.CodeLocation
+	failer       *failer.Failer
+}
+
+func NewBeforeNodeHook(body func(node types.NodeInfo), codeLocation types.CodeLocation, failer *failer.Failer) *BeforeNodeHook {
+	return &BeforeNodeHook{
+		body:         body,
+		codeLocation: codeLocation,
+		failer:       failer,
+	}
+}
+
+func (hook *BeforeNodeHook) CodeLocation() types.CodeLocation {
+	return hook.codeLocation
+}
+
+//Before returns a node that runs the body of the hook for the node described by info, registered in the container at
+//componentIndex.  The returned node fails as that node would, so that the failures of the hook are reported as failures
+//of the node.
+func (hook *BeforeNodeHook) Before(info types.NodeInfo, componentIndex int) BasicNode {
+	return &beforeNodeHookRun{
+		runner: newRunner(func() {
+			hook.body(info)
+		}, info.CodeLocation, 0, hook.failer, info.ComponentType, componentIndex),
+	}
+}
+
+type beforeNodeHookRun struct {
+	runner *runner
+}
+
+func (node *beforeNodeHookRun) Run() (outcome types.SpecState, failure types.SpecFailure) {
+	return node.runner.run()
+}
+
+func (node *beforeNodeHookRun) ProgressReports() []string {
+	return node.runner.progressReports()
+}
+
+func (node *beforeNodeHookRun) Type() types.SpecComponentType {
+	return node.runner.nodeType
+}
+
+func (node *beforeNodeHookRun) CodeLocation() types.CodeLocation {
+	return node.runner.codeLocation
+}
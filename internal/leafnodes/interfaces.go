@@ -10,6 +10,27 @@ type BasicNode interface {
 	CodeLocation() types.CodeLocation
 }
 
+//ProgressReporter is implemented by nodes whose bodies can contribute status lines to progress reports through their SpecContext
+type ProgressReporter interface {
+	ProgressReports() []string
+}
+
+//ComponentIndexer is implemented by nodes that know the index, among the components of their spec, of the container
+//they were registered in
+type ComponentIndexer interface {
+	ComponentIndex() int
+}
+
+//PendingReasoner is implemented by subject nodes that can record why they are pending
+type PendingReasoner interface {
+	PendingReason() string
+}
+
+//SerialMarker is implemented by subject nodes that can be marked as never running concurrently with other specs
+type SerialMarker interface {
+	Serial() bool
+}
+
 type SubjectNode interface {
 	BasicNode
 
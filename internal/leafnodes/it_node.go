@@ -10,8 +10,10 @@ import (
 type ItNode struct {
 	runner *runner
 
-	flag types.FlagType
-	text string
+	flag          types.FlagType
+	text          string
+	pendingReason string
+	serial        bool
 }
 
 func NewItNode(text string, body interface{}, flag types.FlagType, codeLocation types.CodeLocation, timeout time.Duration, failer *failer.Failer, componentIndex int) *ItNode {
@@ -26,6 +28,14 @@ func (node *ItNode) Run() (outcome types.SpecState, failure types.SpecFailure) {
 	return node.runner.run()
 }
 
+func (node *ItNode) ProgressReports() []string {
+	return node.runner.progressReports()
+}
+
+func (node *ItNode) ComponentIndex() int {
+	return node.runner.componentIndex
+}
+
 func (node *ItNode) Type() types.SpecComponentType {
 	return types.SpecComponentTypeIt
 }
@@ -38,6 +48,29 @@ func (node *ItNode) Flag() types.FlagType {
 	return node.flag
 }
 
+//SetFlag changes the flag the node was created with, e.g. to run an It declared without a body when -strictPending is set
+func (node *ItNode) SetFlag(flag types.FlagType) {
+	node.flag = flag
+}
+
+//SetPendingReason records why the node is pending
+func (node *ItNode) SetPendingReason(reason string) {
+	node.pendingReason = reason
+}
+
+func (node *ItNode) PendingReason() string {
+	return node.pendingReason
+}
+
+//SetSerial keeps the node from running concurrently with other specs
+func (node *ItNode) SetSerial() {
+	node.serial = true
+}
+
+func (node *ItNode) Serial() bool {
+	return node.serial
+}
+
 func (node *ItNode) CodeLocation() types.CodeLocation {
 	return node.runner.codeLocation
 }
@@ -1,11 +1,14 @@
 package leafnodes_test
 
 import (
+	"context"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/internal/leafnodes"
 	. "github.com/onsi/gomega"
 
 	"github.com/onsi/ginkgo/internal/codelocation"
+	Failer "github.com/onsi/ginkgo/internal/failer"
 	"github.com/onsi/ginkgo/types"
 )
 
@@ -19,4 +22,46 @@ var _ = Describe("It Nodes", func() {
 		Ω(it.CodeLocation()).Should(Equal(codeLocation))
 		Ω(it.Samples()).Should(Equal(1))
 	})
+
+	Context("when the body accepts a spec context", func() {
+		var (
+			failer *Failer.Failer
+			ctx    context.Context
+		)
+
+		BeforeEach(func() {
+			failer = Failer.New()
+			ctx = nil
+		})
+
+		It("should pass a context that is cancelled when the body returns", func() {
+			it := NewItNode("my it node", func(c SpecContext) {
+				ctx = c
+				Ω(c.Err()).ShouldNot(HaveOccurred())
+			}, types.FlagTypeNone, codelocation.New(0), 0, failer, 0)
+
+			outcome, _ := it.Run()
+			Ω(outcome).Should(Equal(types.SpecStatePassed))
+			Ω(ctx.Err()).Should(MatchError(context.Canceled))
+		})
+
+		It("should include the attached progress reporters in its progress reports while the body runs", func() {
+			var it *ItNode
+			var reportsWhileRunning, reportsAfterDetaching []string
+			it = NewItNode("my it node", func(c SpecContext) {
+				c.AttachProgressReporter(func() string { return "polling cluster" })
+				c.AttachProgressReporter(func() string { return "" })
+				detach := c.AttachProgressReporter(func() string { return "waiting for leader" })
+				reportsWhileRunning = it.ProgressReports()
+				detach()
+				reportsAfterDetaching = it.ProgressReports()
+			}, types.FlagTypeNone, codelocation.New(0), 0, failer, 0)
+
+			Ω(it.ProgressReports()).Should(BeEmpty())
+			it.Run()
+			Ω(reportsWhileRunning).Should(Equal([]string{"polling cluster", "waiting for leader"}))
+			Ω(reportsAfterDetaching).Should(Equal([]string{"polling cluster"}))
+			Ω(it.ProgressReports()).Should(BeEmpty())
+		})
+	})
 })
@@ -41,6 +41,10 @@ func (node *MeasureNode) MeasurementsReport() map[string]*types.SpecMeasurement
 	return node.benchmarker.measurementsReport()
 }
 
+func (node *MeasureNode) ComponentIndex() int {
+	return node.runner.componentIndex
+}
+
 func (node *MeasureNode) Type() types.SpecComponentType {
 	return types.SpecComponentTypeMeasure
 }
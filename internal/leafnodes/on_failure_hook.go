@@ -0,0 +1,35 @@
+package leafnodes
+
+import (
+	"github.com/onsi/ginkgo/internal/failer"
+	"github.com/onsi/ginkgo/types"
+)
+
+//OnFailureHook runs once every spec that fails has unwound: its body is handed the summary of the failed spec
+type OnFailureHook struct {
+	body         func(summary *types.SpecSummary)
+	codeLocation types.CodeLocation
+	failer       *failer.Failer
+}
+
+func NewOnFailureHook(body func(summary *types.SpecSummary), codeLocation types.CodeLocation, failer *failer.Failer) *OnFailureHook {
+	return &OnFailureHook{
+		body:         body,
+		codeLocation: codeLocation,
+		failer:       failer,
+	}
+}
+
+func (hook *OnFailureHook) CodeLocation() types.CodeLocation {
+	return hook.codeLocation
+}
+
+//For returns a node that runs the body of the hook for the failed spec described by summary, reporting its failures as
+//failures of a node registered in the container at componentIndex
+func (hook *OnFailureHook) For(summary *types.SpecSummary, componentIndex int) BasicNode {
+	return &SetupNode{
+		runner: newRunner(func() {
+			hook.body(summary)
+		}, hook.codeLocation, 0, hook.failer, types.SpecComponentTypeOnFailure, componentIndex),
+	}
+}
@@ -0,0 +1,152 @@
+package leafnodes
+
+import (
+	"sync"
+
+	"github.com/onsi/ginkgo/internal/clock"
+	"github.com/onsi/ginkgo/types"
+)
+
+type oncePerContainerSetupNode struct {
+	BasicNode
+
+	lock    *sync.Mutex
+	ran     bool
+	outcome types.SpecState
+	failure types.SpecFailure
+}
+
+//NewOncePerContainerSetupNode wraps a BeforeEach or JustBeforeEach node of a OncePerContainer container.  The wrapped node
+//runs the first time the returned node is run; every run after that replays its outcome.
+func NewOncePerContainerSetupNode(node BasicNode) BasicNode {
+	return &oncePerContainerSetupNode{
+		BasicNode: node,
+		lock:      &sync.Mutex{},
+	}
+}
+
+func (node *oncePerContainerSetupNode) Run() (outcome types.SpecState, failure types.SpecFailure) {
+	node.lock.Lock()
+	defer node.lock.Unlock()
+
+	if !node.ran {
+		node.ran = true
+		node.outcome, node.failure = node.BasicNode.Run()
+	}
+	return node.outcome, node.failure
+}
+
+func (node *oncePerContainerSetupNode) ProgressReports() []string {
+	if progressReporter, ok := node.BasicNode.(ProgressReporter); ok {
+		return progressReporter.ProgressReports()
+	}
+	return []string{}
+}
+
+func (node *oncePerContainerSetupNode) ComponentIndex() int {
+	if indexer, ok := node.BasicNode.(ComponentIndexer); ok {
+		return indexer.ComponentIndex()
+	}
+	return 0
+}
+
+//OncePerContainerTeardownNode wraps an AfterEach or JustAfterEach node of a OncePerContainer container.  Running it only
+//records that the teardown is due: the wrapped node is run once, by the SuiteNode returned by NewOncePerContainerTeardownSuiteNode.
+type OncePerContainerTeardownNode struct {
+	BasicNode
+
+	lock *sync.Mutex
+	due  bool
+}
+
+func NewOncePerContainerTeardownNode(node BasicNode) *OncePerContainerTeardownNode {
+	return &OncePerContainerTeardownNode{
+		BasicNode: node,
+		lock:      &sync.Mutex{},
+	}
+}
+
+func (node *OncePerContainerTeardownNode) Run() (outcome types.SpecState, failure types.SpecFailure) {
+	node.lock.Lock()
+	defer node.lock.Unlock()
+
+	node.due = true
+	return types.SpecStatePassed, types.SpecFailure{}
+}
+
+func (node *OncePerContainerTeardownNode) ComponentIndex() int {
+	if indexer, ok := node.BasicNode.(ComponentIndexer); ok {
+		return indexer.ComponentIndex()
+	}
+	return 0
+}
+
+func (node *OncePerContainerTeardownNode) isDue() bool {
+	node.lock.Lock()
+	defer node.lock.Unlock()
+
+	return node.due
+}
+
+type oncePerContainerTeardownSuiteNode struct {
+	teardownNodes  []*OncePerContainerTeardownNode
+	afterSuiteNode SuiteNode
+
+	summary *types.SetupSummary
+}
+
+//NewOncePerContainerTeardownSuiteNode returns a SuiteNode that runs, in order, the teardown nodes that are due and then
+//afterSuiteNode (which may be nil).  They all run, even if one of them fails.  Its summary is that of the first teardown
+//node that failed or, failing that, that of afterSuiteNode.
+func NewOncePerContainerTeardownSuiteNode(teardownNodes []*OncePerContainerTeardownNode, afterSuiteNode SuiteNode) SuiteNode {
+	return &oncePerContainerTeardownSuiteNode{
+		teardownNodes:  teardownNodes,
+		afterSuiteNode: afterSuiteNode,
+	}
+}
+
+func (node *oncePerContainerTeardownSuiteNode) Run(parallelNode int, parallelTotal int, syncHost string) bool {
+	node.summary = nil
+	for _, teardownNode := range node.teardownNodes {
+		if !teardownNode.isDue() {
+			continue
+		}
+		startTime := clock.Now()
+		outcome, failure := teardownNode.BasicNode.Run()
+		if outcome != types.SpecStatePassed && node.summary == nil {
+			node.summary = &types.SetupSummary{
+				ComponentType: teardownNode.Type(),
+				CodeLocation:  teardownNode.CodeLocation(),
+				State:         outcome,
+				StartTime:     startTime,
+				RunTime:       clock.Since(startTime),
+				Failure:       fingerprinted(outcome, failure),
+			}
+		}
+	}
+	if node.afterSuiteNode != nil {
+		node.afterSuiteNode.Run(parallelNode, parallelTotal, syncHost)
+	}
+
+	return node.Passed()
+}
+
+func (node *oncePerContainerTeardownSuiteNode) Passed() bool {
+	if node.summary != nil {
+		return false
+	}
+	return node.afterSuiteNode == nil || node.afterSuiteNode.Passed()
+}
+
+func (node *oncePerContainerTeardownSuiteNode) Summary() *types.SetupSummary {
+	if node.summary != nil {
+		return node.summary
+	}
+	if node.afterSuiteNode != nil {
+		return node.afterSuiteNode.Summary()
+	}
+	return &types.SetupSummary{
+		ComponentType: types.SpecComponentTypeAfterSuite,
+		State:         types.SpecStatePassed,
+	}
+}
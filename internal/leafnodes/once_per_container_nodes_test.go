@@ -0,0 +1,110 @@
+package leafnodes_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/onsi/ginkgo/internal/leafnodes"
+
+	"github.com/onsi/ginkgo/internal/codelocation"
+	Failer "github.com/onsi/ginkgo/internal/failer"
+	"github.com/onsi/ginkgo/types"
+)
+
+var _ = Describe("OncePerContainer nodes", func() {
+	var (
+		failer       *Failer.Failer
+		codeLocation types.CodeLocation
+		ran          []string
+	)
+
+	newBody := func(text string, fail bool) func() {
+		return func() {
+			ran = append(ran, text)
+			if fail {
+				failer.Fail(text+" failed", codeLocation)
+			}
+		}
+	}
+
+	BeforeEach(func() {
+		failer = Failer.New()
+		codeLocation = codelocation.New(0)
+		ran = []string{}
+	})
+
+	Describe("setup nodes", func() {
+		It("should run the wrapped node once and replay its outcome", func() {
+			node := NewOncePerContainerSetupNode(NewBeforeEachNode(newBody("setup", true), codeLocation, 0, failer, 1))
+			Ω(node.Type()).Should(Equal(types.SpecComponentTypeBeforeEach))
+			Ω(node.CodeLocation()).Should(Equal(codeLocation))
+
+			outcome, failure := node.Run()
+			Ω(outcome).Should(Equal(types.SpecStateFailed))
+			Ω(failure.Message).Should(Equal("setup failed"))
+
+			outcome, failure = node.Run()
+			Ω(outcome).Should(Equal(types.SpecStateFailed))
+			Ω(failure.Message).Should(Equal("setup failed"))
+			Ω(ran).Should(Equal([]string{"setup"}))
+		})
+	})
+
+	Describe("teardown nodes", func() {
+		var first, second, notDue *OncePerContainerTeardownNode
+
+		BeforeEach(func() {
+			first = NewOncePerContainerTeardownNode(NewAfterEachNode(newBody("first", false), codeLocation, 0, failer, 2))
+			second = NewOncePerContainerTeardownNode(NewAfterEachNode(newBody("second", false), codeLocation, 0, failer, 1))
+			notDue = NewOncePerContainerTeardownNode(NewAfterEachNode(newBody("not due", false), codeLocation, 0, failer, 1))
+		})
+
+		It("should only record that the teardown is due when run", func() {
+			outcome, failure := first.Run()
+			Ω(outcome).Should(Equal(types.SpecStatePassed))
+			Ω(failure).Should(BeZero())
+			Ω(ran).Should(BeEmpty())
+		})
+
+		Context("when run by the teardown suite node", func() {
+			It("should run the teardown nodes that are due, once and in order, followed by the AfterSuite node", func() {
+				first.Run()
+				first.Run()
+				second.Run()
+				suiteNode := NewOncePerContainerTeardownSuiteNode([]*OncePerContainerTeardownNode{first, notDue, second}, NewAfterSuiteNode(newBody("AfterSuite", false), codeLocation, 0, failer))
+
+				Ω(suiteNode.Run(1, 1, "")).Should(BeTrue())
+				Ω(suiteNode.Passed()).Should(BeTrue())
+				Ω(ran).Should(Equal([]string{"first", "second", "AfterSuite"}))
+				Ω(suiteNode.Summary().ComponentType).Should(Equal(types.SpecComponentTypeAfterSuite))
+			})
+
+			It("should keep running when a teardown node fails and summarize the first failure", func() {
+				failing := NewOncePerContainerTeardownNode(NewAfterEachNode(newBody("failing", true), codeLocation, 0, failer, 1))
+				failing.Run()
+				second.Run()
+				suiteNode := NewOncePerContainerTeardownSuiteNode([]*OncePerContainerTeardownNode{failing, second}, NewAfterSuiteNode(newBody("AfterSuite", false), codeLocation, 0, failer))
+
+				Ω(suiteNode.Run(1, 1, "")).Should(BeFalse())
+				Ω(suiteNode.Passed()).Should(BeFalse())
+				Ω(ran).Should(Equal([]string{"failing", "second", "AfterSuite"}))
+
+				summary := suiteNode.Summary()
+				Ω(summary.ComponentType).Should(Equal(types.SpecComponentTypeAfterEach))
+				Ω(summary.CodeLocation).Should(Equal(codeLocation))
+				Ω(summary.State).Should(Equal(types.SpecStateFailed))
+				Ω(summary.Failure.Message).Should(Equal("failing failed"))
+				Ω(summary.Failure.Fingerprint).ShouldNot(BeEmpty())
+			})
+
+			It("should pass without an AfterSuite node", func() {
+				first.Run()
+				suiteNode := NewOncePerContainerTeardownSuiteNode([]*OncePerContainerTeardownNode{first}, nil)
+
+				Ω(suiteNode.Run(1, 1, "")).Should(BeTrue())
+				Ω(ran).Should(Equal([]string{"first"}))
+				Ω(suiteNode.Summary().State).Should(Equal(types.SpecStatePassed))
+			})
+		})
+	})
+})
@@ -0,0 +1,79 @@
+package leafnodes
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/onsi/ginkgo/internal/clock"
+	"github.com/onsi/ginkgo/internal/failer"
+	"github.com/onsi/ginkgo/types"
+)
+
+type reportAfterSuiteNode struct {
+	runner      *runner
+	text        string
+	fetchReport func() (types.AggregatedReport, error)
+	report      types.AggregatedReport
+
+	outcome   types.SpecState
+	failure   types.SpecFailure
+	startTime time.Time
+	runTime   time.Duration
+}
+
+//NewReportAfterSuiteNode returns the node of a ReportAfterSuite: it passes body the report fetchReport aggregates across
+//the parallel nodes, once all the other nodes are done.  It fails without running body when the report cannot be
+//aggregated.
+func NewReportAfterSuiteNode(text string, body func(types.AggregatedReport), codeLocation types.CodeLocation, timeout time.Duration, failer *failer.Failer, fetchReport func() (types.AggregatedReport, error)) SuiteNode {
+	node := &reportAfterSuiteNode{
+		text:        text,
+		fetchReport: fetchReport,
+	}
+	node.runner = newRunner(func() {
+		body(node.report)
+	}, codeLocation, timeout, failer, types.SpecComponentTypeReportAfterSuite, 0)
+	return node
+}
+
+func (node *reportAfterSuiteNode) Run(parallelNode int, parallelTotal int, syncHost string) bool {
+	node.startTime = clock.Now()
+	defer func() {
+		node.runTime = clock.Since(node.startTime)
+	}()
+
+	if parallelTotal > 1 {
+		waitUntilOtherNodesAreDone(syncHost)
+	}
+
+	report, err := node.fetchReport()
+	if err != nil {
+		node.outcome = types.SpecStateFailed
+		node.failure = types.SpecFailure{
+			Message:               fmt.Sprintf("The report of the suite could not be aggregated, so this ReportAfterSuite did not run:\n%s", err.Error()),
+			Location:              node.runner.codeLocation,
+			ComponentType:         node.runner.nodeType,
+			ComponentCodeLocation: node.runner.codeLocation,
+		}
+		return false
+	}
+
+	node.report = report
+	node.outcome, node.failure = node.runner.run()
+	return node.outcome == types.SpecStatePassed
+}
+
+func (node *reportAfterSuiteNode) Passed() bool {
+	return node.outcome == types.SpecStatePassed
+}
+
+func (node *reportAfterSuiteNode) Summary() *types.SetupSummary {
+	return &types.SetupSummary{
+		ComponentType: node.runner.nodeType,
+		CodeLocation:  node.runner.codeLocation,
+		Text:          node.text,
+		State:         node.outcome,
+		StartTime:     node.startTime,
+		RunTime:       node.runTime,
+		Failure:       fingerprinted(node.outcome, node.failure),
+	}
+}
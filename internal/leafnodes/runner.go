@@ -3,6 +3,7 @@ package leafnodes
 import (
 	"fmt"
 	"reflect"
+	"sync"
 	"time"
 
 	"github.com/onsi/ginkgo/internal/codelocation"
@@ -10,6 +11,8 @@ import (
 	"github.com/onsi/ginkgo/types"
 )
 
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
 type runner struct {
 	isAsync          bool
 	asyncFunc        func(chan<- interface{})
@@ -19,6 +22,9 @@ type runner struct {
 	nodeType         types.SpecComponentType
 	componentIndex   int
 	failer           *failer.Failer
+
+	lock        *sync.Mutex
+	specContext *specContext
 }
 
 func newRunner(body interface{}, codeLocation types.CodeLocation, timeout time.Duration, failer *failer.Failer, nodeType types.SpecComponentType, componentIndex int) *runner {
@@ -33,17 +39,61 @@ func newRunner(body interface{}, codeLocation types.CodeLocation, timeout time.D
 		failer:           failer,
 		nodeType:         nodeType,
 		componentIndex:   componentIndex,
+		lock:             &sync.Mutex{},
+	}
+
+	returnsError := false
+	switch bodyType.NumOut() {
+	case 0:
+	case 1:
+		if bodyType.Out(0) != errorType {
+			panic(fmt.Sprintf("Function at %v may only return an error", codeLocation))
+		}
+		returnsError = true
+	default:
+		panic(fmt.Sprintf("Too many return values from function at %v", codeLocation))
 	}
 
 	switch bodyType.NumIn() {
 	case 0:
+		if returnsError {
+			bodyValue := reflect.ValueOf(body)
+			runner.syncFunc = func() {
+				results := bodyValue.Call(nil)
+				err, _ := results[0].Interface().(error)
+				runner.failOnError(err)
+			}
+			return runner
+		}
 		runner.syncFunc = body.(func())
 		return runner
 	case 1:
+		if bodyType.In(0).Kind() == reflect.Interface && specContextType.Implements(bodyType.In(0)) {
+			bodyValue := reflect.ValueOf(body)
+			runner.syncFunc = func() {
+				ctx, cancel := newSpecContext()
+				runner.setSpecContext(ctx)
+				defer func() {
+					cancel()
+					runner.setSpecContext(nil)
+				}()
+				results := bodyValue.Call([]reflect.Value{reflect.ValueOf(ctx)})
+				if returnsError {
+					err, _ := results[0].Interface().(error)
+					runner.failOnError(err)
+				}
+			}
+			return runner
+		}
+
 		if !(bodyType.In(0).Kind() == reflect.Chan && bodyType.In(0).Elem().Kind() == reflect.Interface) {
 			panic(fmt.Sprintf("Must pass a Done channel to function at %v", codeLocation))
 		}
 
+		if returnsError {
+			panic(fmt.Sprintf("Asynchronous function at %v must not return an error", codeLocation))
+		}
+
 		wrappedBody := func(done chan<- interface{}) {
 			bodyValue := reflect.ValueOf(body)
 			bodyValue.Call([]reflect.Value{reflect.ValueOf(done)})
@@ -57,19 +107,63 @@ func newRunner(body interface{}, codeLocation types.CodeLocation, timeout time.D
 	panic(fmt.Sprintf("Too many arguments to function at %v", codeLocation))
 }
 
+//failOnError fails the node at its code location when its body returns a non-nil error
+func (r *runner) failOnError(err error) {
+	if err != nil {
+		r.failer.Fail(err.Error(), r.codeLocation)
+	}
+}
+
+func (r *runner) setSpecContext(ctx *specContext) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.specContext = ctx
+}
+
+//progressReports returns the status lines contributed to the SpecContext of the running body, if any
+func (r *runner) progressReports() []string {
+	r.lock.Lock()
+	ctx := r.specContext
+	r.lock.Unlock()
+
+	if ctx == nil {
+		return []string{}
+	}
+	return ctx.progressReports()
+}
+
 func (r *runner) run() (outcome types.SpecState, failure types.SpecFailure) {
 	if r.isAsync {
 		return r.runAsync()
+	} else if r.hasNodeTimeout() {
+		return r.runSyncWithNodeTimeout()
 	} else {
 		return r.runSync()
 	}
 }
 
+//hasNodeTimeout tells whether the node is timed out once it runs longer than the node timeout.  AroundEach nodes wrap
+//the other nodes of their spec, so they are not timed out on their own.
+func (r *runner) hasNodeTimeout() bool {
+	return r.failer.NodeTimeout() > 0 && r.nodeType != types.SpecComponentTypeAroundEach
+}
+
+//nodeTimeout returns a channel that fires once the node runs longer than the node timeout, nil when there is no node
+//timeout
+func (r *runner) nodeTimeout() <-chan time.Time {
+	if !r.hasNodeTimeout() {
+		return nil
+	}
+	return time.After(r.failer.NodeTimeout())
+}
+
 func (r *runner) runAsync() (outcome types.SpecState, failure types.SpecFailure) {
+	generation := r.failer.NewGeneration()
 	done := make(chan interface{}, 1)
 
 	go func() {
 		finished := false
+		defer r.failer.EnterNode(generation)()
 
 		defer func() {
 			if e := recover(); e != nil || !finished {
@@ -93,7 +187,11 @@ func (r *runner) runAsync() (outcome types.SpecState, failure types.SpecFailure)
 	select {
 	case <-done:
 	case <-time.After(r.timeoutThreshold):
+		r.failer.Abandon(generation)
 		r.failer.Timeout(r.codeLocation)
+	case <-r.nodeTimeout():
+		r.failer.Abandon(generation)
+		r.failer.NodeTimedOut(r.codeLocation)
 	}
 
 	failure, outcome = r.failer.Drain(r.nodeType, r.componentIndex, r.codeLocation)
@@ -101,6 +199,7 @@ func (r *runner) runAsync() (outcome types.SpecState, failure types.SpecFailure)
 }
 func (r *runner) runSync() (outcome types.SpecState, failure types.SpecFailure) {
 	finished := false
+	defer r.failer.EnterNode(r.failer.NewGeneration())()
 
 	defer func() {
 		if e := recover(); e != nil || !finished {
@@ -115,3 +214,37 @@ func (r *runner) runSync() (outcome types.SpecState, failure types.SpecFailure)
 
 	return
 }
+
+//runSyncWithNodeTimeout runs the body on a goroutine of its own, as runAsync does, so that the node can be timed out
+//once it runs longer than the node timeout.  The goroutine of a timed out node is left running, fenced off so that
+//what it reports does not land in the outcome of the nodes that follow.
+func (r *runner) runSyncWithNodeTimeout() (outcome types.SpecState, failure types.SpecFailure) {
+	timeout := r.nodeTimeout()
+	generation := r.failer.NewGeneration()
+	done := make(chan interface{})
+
+	go func() {
+		finished := false
+		defer r.failer.EnterNode(generation)()
+
+		defer func() {
+			if e := recover(); e != nil || !finished {
+				r.failer.Panic(codelocation.New(2), e)
+			}
+			close(done)
+		}()
+
+		r.syncFunc()
+		finished = true
+	}()
+
+	select {
+	case <-done:
+	case <-timeout:
+		r.failer.Abandon(generation)
+		r.failer.NodeTimedOut(r.codeLocation)
+	}
+
+	failure, outcome = r.failer.Drain(r.nodeType, r.componentIndex, r.codeLocation)
+	return
+}
@@ -15,6 +15,14 @@ func (node *SetupNode) Run() (outcome types.SpecState, failure types.SpecFailure
 	return node.runner.run()
 }
 
+func (node *SetupNode) ProgressReports() []string {
+	return node.runner.progressReports()
+}
+
+func (node *SetupNode) ComponentIndex() int {
+	return node.runner.componentIndex
+}
+
 func (node *SetupNode) Type() types.SpecComponentType {
 	return node.runner.nodeType
 }
@@ -46,3 +54,11 @@ func NewJustAfterEachNode(body interface{}, codeLocation types.CodeLocation, tim
 		runner: newRunner(body, codeLocation, timeout, failer, types.SpecComponentTypeJustAfterEach, componentIndex),
 	}
 }
+
+//NewDeferCleanupNode returns the node running a cleanup deferred with DeferCleanup by a node registered in the container
+//at componentIndex
+func NewDeferCleanupNode(body interface{}, codeLocation types.CodeLocation, failer *failer.Failer, componentIndex int) *SetupNode {
+	return &SetupNode{
+		runner: newRunner(body, codeLocation, 0, failer, types.SpecComponentTypeDeferCleanup, componentIndex),
+	}
+}
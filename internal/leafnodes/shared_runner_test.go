@@ -5,6 +5,7 @@ import (
 	. "github.com/onsi/ginkgo/internal/leafnodes"
 	. "github.com/onsi/gomega"
 
+	"errors"
 	"reflect"
 	"time"
 
@@ -113,6 +114,68 @@ func SynchronousSharedRunnerBehaviors(build func(body interface{}, timeout time.
 			})
 		})
 
+		Context("when the function runs longer than the node timeout", func() {
+			//the function is left running on a goroutine of its own: it tells it started through started, not didRun
+			var started, release, failed chan struct{}
+
+			BeforeEach(func() {
+				started, release, failed = make(chan struct{}), make(chan struct{}), make(chan struct{})
+				failer.SetNodeTimeout(10 * time.Millisecond)
+				nodeFailer := failer
+				outcome, failure = build(func() {
+					close(started)
+					<-release
+					defer close(failed)
+					nodeFailer.Fail("too late", innerCodeLocation)
+				}, 0, failer, componentCodeLocation).Run()
+			})
+
+			AfterEach(func() {
+				select {
+				case <-release:
+				default:
+					close(release)
+				}
+				Eventually(failed).Should(BeClosed())
+			})
+
+			It("should return a timeout with the stacks of the goroutines", func() {
+				Ω(started).Should(BeClosed())
+
+				Ω(outcome).Should(Equal(types.SpecStateTimedOut))
+				Ω(failure.Message).Should(HavePrefix("Timed out after 10ms"))
+				Ω(failure.Message).Should(ContainSubstring("goroutine"))
+				Ω(failure.Location).Should(Equal(componentCodeLocation))
+				Ω(failure.ComponentType).Should(Equal(componentType))
+			})
+
+			It("should drop what the function reports once it has timed out", func() {
+				close(release)
+				Eventually(failed).Should(BeClosed())
+
+				_, state := failer.Drain(componentType, componentIndex, componentCodeLocation)
+				Ω(state).Should(Equal(types.SpecStatePassed))
+			})
+		})
+
+		Context("when the function fails within the node timeout", func() {
+			BeforeEach(func() {
+				failer.SetNodeTimeout(time.Second)
+				outcome, failure = build(func() {
+					didRun = true
+					failer.Fail("bam", innerCodeLocation)
+					panic("should not matter")
+				}, 0, failer, componentCodeLocation).Run()
+			})
+
+			It("should return the failure", func() {
+				Ω(didRun).Should(BeTrue())
+
+				Ω(outcome).Should(Equal(types.SpecStateFailed))
+				Ω(failure.Message).Should(Equal("bam"))
+				Ω(failure.ComponentIndex).Should(Equal(componentIndex))
+			})
+		})
 	})
 }
 
@@ -262,6 +325,102 @@ func AsynchronousSharedRunnerBehaviors(build func(body interface{}, timeout time
 	})
 }
 
+//step is a named func type, as suites declare for their reusable bodies
+type step func() error
+
+func ErrorReturningSharedRunnerBehaviors(build func(body interface{}, timeout time.Duration, failer *Failer.Failer, componentCodeLocation types.CodeLocation) runnable, componentType types.SpecComponentType, componentIndex int) {
+	var (
+		outcome types.SpecState
+		failure types.SpecFailure
+
+		failer *Failer.Failer
+
+		componentCodeLocation types.CodeLocation
+	)
+
+	BeforeEach(func() {
+		failer = Failer.New()
+		componentCodeLocation = codelocation.New(0)
+	})
+
+	Describe("functions returning an error", func() {
+		Context("when the function returns nil", func() {
+			BeforeEach(func() {
+				outcome, failure = build(func() error {
+					return nil
+				}, 0, failer, componentCodeLocation).Run()
+			})
+
+			It("should have a successful outcome", func() {
+				Ω(outcome).Should(Equal(types.SpecStatePassed))
+				Ω(failure).Should(BeZero())
+			})
+		})
+
+		Context("when the function returns an error", func() {
+			BeforeEach(func() {
+				outcome, failure = build(func() error {
+					return errors.New("boom")
+				}, 0, failer, componentCodeLocation).Run()
+			})
+
+			It("should fail with the error at the location of the node", func() {
+				Ω(outcome).Should(Equal(types.SpecStateFailed))
+				Ω(failure).Should(Equal(types.SpecFailure{
+					Message:               "boom",
+					Location:              componentCodeLocation,
+					ForwardedPanic:        "",
+					ComponentIndex:        componentIndex,
+					ComponentType:         componentType,
+					ComponentCodeLocation: componentCodeLocation,
+				}))
+			})
+		})
+
+		Context("when the function is of a named type returning an error", func() {
+			BeforeEach(func() {
+				outcome, failure = build(step(func() error {
+					return errors.New("boom")
+				}), 0, failer, componentCodeLocation).Run()
+			})
+
+			It("should fail with the error at the location of the node", func() {
+				Ω(outcome).Should(Equal(types.SpecStateFailed))
+				Ω(failure.Message).Should(Equal("boom"))
+				Ω(failure.Location).Should(Equal(componentCodeLocation))
+			})
+		})
+
+		Context("when the function accepts a SpecContext and returns an error", func() {
+			BeforeEach(func() {
+				outcome, failure = build(func(ctx SpecContext) error {
+					return errors.New("boom")
+				}, 0, failer, componentCodeLocation).Run()
+			})
+
+			It("should fail with the error", func() {
+				Ω(outcome).Should(Equal(types.SpecStateFailed))
+				Ω(failure.Message).Should(Equal("boom"))
+				Ω(failure.Location).Should(Equal(componentCodeLocation))
+			})
+		})
+
+		Context("when the function fails before returning an error", func() {
+			BeforeEach(func() {
+				outcome, failure = build(func() error {
+					failer.Fail("bam", componentCodeLocation)
+					panic("should not matter")
+				}, 0, failer, componentCodeLocation).Run()
+			})
+
+			It("should report the first failure", func() {
+				Ω(outcome).Should(Equal(types.SpecStateFailed))
+				Ω(failure.Message).Should(Equal("bam"))
+			})
+		})
+	})
+}
+
 func InvalidSharedRunnerBehaviors(build func(body interface{}, timeout time.Duration, failer *Failer.Failer, componentCodeLocation types.CodeLocation) runnable, componentType types.SpecComponentType) {
 	var (
 		failer                *Failer.Failer
@@ -297,6 +456,25 @@ func InvalidSharedRunnerBehaviors(build func(body interface{}, timeout time.Dura
 				}).Should(Panic())
 			})
 		})
+
+		Context("when the function returns something other than an error", func() {
+			It("should panic", func() {
+				Ω(func() {
+					build(func() string { return "oops" }, 0, failer, componentCodeLocation)
+				}).Should(Panic())
+				Ω(func() {
+					build(func() (string, error) { return "oops", nil }, 0, failer, componentCodeLocation)
+				}).Should(Panic())
+			})
+		})
+
+		Context("when an asynchronous function returns an error", func() {
+			It("should panic", func() {
+				Ω(func() {
+					build(func(done Done) error { return nil }, 0, failer, componentCodeLocation)
+				}).Should(Panic())
+			})
+		})
 	})
 }
 
@@ -308,6 +486,7 @@ var _ = Describe("Shared RunnableNode behavior", func() {
 
 		SynchronousSharedRunnerBehaviors(build, types.SpecComponentTypeIt, 3)
 		AsynchronousSharedRunnerBehaviors(build, types.SpecComponentTypeIt, 3)
+		ErrorReturningSharedRunnerBehaviors(build, types.SpecComponentTypeIt, 3)
 		InvalidSharedRunnerBehaviors(build, types.SpecComponentTypeIt)
 	})
 
@@ -328,6 +507,7 @@ var _ = Describe("Shared RunnableNode behavior", func() {
 
 		SynchronousSharedRunnerBehaviors(build, types.SpecComponentTypeBeforeEach, 3)
 		AsynchronousSharedRunnerBehaviors(build, types.SpecComponentTypeBeforeEach, 3)
+		ErrorReturningSharedRunnerBehaviors(build, types.SpecComponentTypeBeforeEach, 3)
 		InvalidSharedRunnerBehaviors(build, types.SpecComponentTypeBeforeEach)
 	})
 
@@ -338,6 +518,7 @@ var _ = Describe("Shared RunnableNode behavior", func() {
 
 		SynchronousSharedRunnerBehaviors(build, types.SpecComponentTypeAfterEach, 3)
 		AsynchronousSharedRunnerBehaviors(build, types.SpecComponentTypeAfterEach, 3)
+		ErrorReturningSharedRunnerBehaviors(build, types.SpecComponentTypeAfterEach, 3)
 		InvalidSharedRunnerBehaviors(build, types.SpecComponentTypeAfterEach)
 	})
 
@@ -348,6 +529,7 @@ var _ = Describe("Shared RunnableNode behavior", func() {
 
 		SynchronousSharedRunnerBehaviors(build, types.SpecComponentTypeJustBeforeEach, 3)
 		AsynchronousSharedRunnerBehaviors(build, types.SpecComponentTypeJustBeforeEach, 3)
+		ErrorReturningSharedRunnerBehaviors(build, types.SpecComponentTypeJustBeforeEach, 3)
 		InvalidSharedRunnerBehaviors(build, types.SpecComponentTypeJustBeforeEach)
 	})
 })
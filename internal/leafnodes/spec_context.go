@@ -0,0 +1,65 @@
+package leafnodes
+
+import (
+	"context"
+	"reflect"
+	"sync"
+)
+
+//specContext is the context passed to node bodies that accept one (see ginkgo.SpecContext).  It is cancelled once the
+//body returns.
+type specContext struct {
+	context.Context
+
+	lock              *sync.Mutex
+	progressReporters []*progressReporter
+}
+
+type progressReporter struct {
+	report func() string
+}
+
+var specContextType = reflect.TypeOf(&specContext{})
+
+func newSpecContext() (*specContext, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &specContext{
+		Context: ctx,
+		lock:    &sync.Mutex{},
+	}, cancel
+}
+
+//AttachProgressReporter registers a function whose status line is included in progress reports for as long as
+//the body runs.  Call the returned function to detach it early.
+func (ctx *specContext) AttachProgressReporter(report func() string) func() {
+	ctx.lock.Lock()
+	defer ctx.lock.Unlock()
+
+	reporter := &progressReporter{report: report}
+	ctx.progressReporters = append(ctx.progressReporters, reporter)
+	return func() {
+		ctx.lock.Lock()
+		defer ctx.lock.Unlock()
+		for i, attached := range ctx.progressReporters {
+			if attached == reporter {
+				ctx.progressReporters = append(ctx.progressReporters[:i], ctx.progressReporters[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+//progressReports calls the attached progress reporters, skipping those that have nothing to say
+func (ctx *specContext) progressReports() []string {
+	ctx.lock.Lock()
+	reporters := append([]*progressReporter{}, ctx.progressReporters...)
+	ctx.lock.Unlock()
+
+	reports := []string{}
+	for _, reporter := range reporters {
+		if report := reporter.report(); report != "" {
+			reports = append(reports, report)
+		}
+	}
+	return reports
+}
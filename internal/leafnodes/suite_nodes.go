@@ -3,6 +3,7 @@ package leafnodes
 import (
 	"time"
 
+	"github.com/onsi/ginkgo/internal/clock"
 	"github.com/onsi/ginkgo/internal/failer"
 	"github.com/onsi/ginkgo/types"
 )
@@ -14,16 +15,17 @@ type SuiteNode interface {
 }
 
 type simpleSuiteNode struct {
-	runner  *runner
-	outcome types.SpecState
-	failure types.SpecFailure
-	runTime time.Duration
+	runner    *runner
+	outcome   types.SpecState
+	failure   types.SpecFailure
+	startTime time.Time
+	runTime   time.Duration
 }
 
 func (node *simpleSuiteNode) Run(parallelNode int, parallelTotal int, syncHost string) bool {
-	t := time.Now()
+	node.startTime = clock.Now()
 	node.outcome, node.failure = node.runner.run()
-	node.runTime = time.Since(t)
+	node.runTime = clock.Since(node.startTime)
 
 	return node.outcome == types.SpecStatePassed
 }
@@ -37,19 +39,81 @@ func (node *simpleSuiteNode) Summary() *types.SetupSummary {
 		ComponentType: node.runner.nodeType,
 		CodeLocation:  node.runner.codeLocation,
 		State:         node.outcome,
+		StartTime:     node.startTime,
 		RunTime:       node.runTime,
-		Failure:       node.failure,
+		Failure:       fingerprinted(node.outcome, node.failure),
 	}
 }
 
+//fingerprinted returns the failure with its fingerprint set, provided the outcome is a failure
+func fingerprinted(outcome types.SpecState, failure types.SpecFailure) types.SpecFailure {
+	if outcome.IsFailure() {
+		failure.Fingerprint = types.FailureFingerprint(failure)
+	}
+	return failure
+}
+
 func NewBeforeSuiteNode(body interface{}, codeLocation types.CodeLocation, timeout time.Duration, failer *failer.Failer) SuiteNode {
 	return &simpleSuiteNode{
 		runner: newRunner(body, codeLocation, timeout, failer, types.SpecComponentTypeBeforeSuite, 0),
 	}
 }
 
+//NewWarmUpNode returns the node of WarmUp, which runs before the suite begins
+func NewWarmUpNode(body interface{}, codeLocation types.CodeLocation, timeout time.Duration, failer *failer.Failer) SuiteNode {
+	return &simpleSuiteNode{
+		runner: newRunner(body, codeLocation, timeout, failer, types.SpecComponentTypeWarmUp, 0),
+	}
+}
+
 func NewAfterSuiteNode(body interface{}, codeLocation types.CodeLocation, timeout time.Duration, failer *failer.Failer) SuiteNode {
 	return &simpleSuiteNode{
 		runner: newRunner(body, codeLocation, timeout, failer, types.SpecComponentTypeAfterSuite, 0),
 	}
 }
+
+type composedSuiteNode struct {
+	nodes     []SuiteNode
+	ran       []SuiteNode
+	startTime time.Time
+	runTime   time.Duration
+}
+
+//NewComposedSuiteNode returns a SuiteNode that runs nodes in order, stopping at the first one that fails.  Its summary is the
+//summary of the node that failed (or of the last node, if they all passed) spanning the run time of all the nodes that ran.
+func NewComposedSuiteNode(nodes ...SuiteNode) SuiteNode {
+	return &composedSuiteNode{
+		nodes: nodes,
+	}
+}
+
+func (node *composedSuiteNode) Run(parallelNode int, parallelTotal int, syncHost string) bool {
+	node.startTime = clock.Now()
+	node.ran = []SuiteNode{}
+	for _, n := range node.nodes {
+		node.ran = append(node.ran, n)
+		if !n.Run(parallelNode, parallelTotal, syncHost) {
+			break
+		}
+	}
+	node.runTime = clock.Since(node.startTime)
+
+	return node.Passed()
+}
+
+func (node *composedSuiteNode) Passed() bool {
+	if len(node.ran) == 0 {
+		return false
+	}
+	return node.ran[len(node.ran)-1].Passed() && len(node.ran) == len(node.nodes)
+}
+
+func (node *composedSuiteNode) Summary() *types.SetupSummary {
+	if len(node.ran) == 0 {
+		return node.nodes[len(node.nodes)-1].Summary()
+	}
+	summary := node.ran[len(node.ran)-1].Summary()
+	summary.StartTime = node.startTime
+	summary.RunTime = node.runTime
+	return summary
+}
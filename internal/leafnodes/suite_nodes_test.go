@@ -72,6 +72,7 @@ var _ = Describe("SuiteNodes", func() {
 				Ω(summary.Failure.ComponentIndex).Should(Equal(0))
 				Ω(summary.Failure.ComponentType).Should(Equal(types.SpecComponentTypeBeforeSuite))
 				Ω(summary.Failure.ComponentCodeLocation).Should(Equal(codeLocation))
+				Ω(summary.Failure.Fingerprint).Should(Equal(types.FailureFingerprint(summary.Failure)))
 			})
 		})
 
@@ -227,4 +228,68 @@ var _ = Describe("SuiteNodes", func() {
 			})
 		})
 	})
+
+	Describe("Composed suite nodes", func() {
+		var composed SuiteNode
+		var failer *Failer.Failer
+		var firstCodeLocation, secondCodeLocation, thirdCodeLocation types.CodeLocation
+		var ran []string
+		var outcome bool
+
+		newNode := func(text string, codeLocation types.CodeLocation, fail bool) SuiteNode {
+			return NewBeforeSuiteNode(func() {
+				ran = append(ran, text)
+				if fail {
+					failer.Fail(text+" failed", codeLocation)
+				}
+			}, codeLocation, 0, failer)
+		}
+
+		BeforeEach(func() {
+			failer = Failer.New()
+			firstCodeLocation = codelocation.New(0)
+			secondCodeLocation = codelocation.New(0)
+			thirdCodeLocation = codelocation.New(0)
+			ran = []string{}
+		})
+
+		Context("when all the nodes pass", func() {
+			BeforeEach(func() {
+				composed = NewComposedSuiteNode(newNode("first", firstCodeLocation, false), newNode("second", secondCodeLocation, false))
+				outcome = composed.Run(0, 0, "")
+			})
+
+			It("should run every node, in order, and report as passed", func() {
+				Ω(ran).Should(Equal([]string{"first", "second"}))
+				Ω(outcome).Should(BeTrue())
+				Ω(composed.Passed()).Should(BeTrue())
+			})
+
+			It("should summarize the last node", func() {
+				summary := composed.Summary()
+				Ω(summary.State).Should(Equal(types.SpecStatePassed))
+				Ω(summary.CodeLocation).Should(Equal(secondCodeLocation))
+			})
+		})
+
+		Context("when a node fails", func() {
+			BeforeEach(func() {
+				composed = NewComposedSuiteNode(newNode("first", firstCodeLocation, false), newNode("second", secondCodeLocation, true), newNode("third", thirdCodeLocation, false))
+				outcome = composed.Run(0, 0, "")
+			})
+
+			It("should not run the nodes that follow it, and report as failed", func() {
+				Ω(ran).Should(Equal([]string{"first", "second"}))
+				Ω(outcome).Should(BeFalse())
+				Ω(composed.Passed()).Should(BeFalse())
+			})
+
+			It("should summarize the node that failed", func() {
+				summary := composed.Summary()
+				Ω(summary.State).Should(Equal(types.SpecStateFailed))
+				Ω(summary.CodeLocation).Should(Equal(secondCodeLocation))
+				Ω(summary.Failure.Message).Should(Equal("second failed"))
+			})
+		})
+	})
 })
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/onsi/ginkgo/internal/clock"
 	"github.com/onsi/ginkgo/internal/failer"
 	"github.com/onsi/ginkgo/types"
 )
@@ -14,9 +15,10 @@ type synchronizedAfterSuiteNode struct {
 	runnerA *runner
 	runnerB *runner
 
-	outcome types.SpecState
-	failure types.SpecFailure
-	runTime time.Duration
+	outcome   types.SpecState
+	failure   types.SpecFailure
+	startTime time.Time
+	runTime   time.Duration
 }
 
 func NewSynchronizedAfterSuiteNode(bodyA interface{}, bodyB interface{}, codeLocation types.CodeLocation, timeout time.Duration, failer *failer.Failer) SuiteNode {
@@ -27,11 +29,16 @@ func NewSynchronizedAfterSuiteNode(bodyA interface{}, bodyB interface{}, codeLoc
 }
 
 func (node *synchronizedAfterSuiteNode) Run(parallelNode int, parallelTotal int, syncHost string) bool {
+	node.startTime = clock.Now()
+	defer func() {
+		node.runTime = clock.Since(node.startTime)
+	}()
+
 	node.outcome, node.failure = node.runnerA.run()
 
 	if parallelNode == 1 {
 		if parallelTotal > 1 {
-			node.waitUntilOtherNodesAreDone(syncHost)
+			waitUntilOtherNodesAreDone(syncHost)
 		}
 
 		outcome, failure := node.runnerB.run()
@@ -53,14 +60,16 @@ func (node *synchronizedAfterSuiteNode) Summary() *types.SetupSummary {
 		ComponentType: node.runnerA.nodeType,
 		CodeLocation:  node.runnerA.codeLocation,
 		State:         node.outcome,
+		StartTime:     node.startTime,
 		RunTime:       node.runTime,
-		Failure:       node.failure,
+		Failure:       fingerprinted(node.outcome, node.failure),
 	}
 }
 
-func (node *synchronizedAfterSuiteNode) waitUntilOtherNodesAreDone(syncHost string) {
+//waitUntilOtherNodesAreDone waits until all the parallel nodes but node 1 have finished and exited
+func waitUntilOtherNodesAreDone(syncHost string) {
 	for {
-		if node.canRun(syncHost) {
+		if afterSuiteCanRun(syncHost) {
 			return
 		}
 
@@ -68,7 +77,7 @@ func (node *synchronizedAfterSuiteNode) waitUntilOtherNodesAreDone(syncHost stri
 	}
 }
 
-func (node *synchronizedAfterSuiteNode) canRun(syncHost string) bool {
+func afterSuiteCanRun(syncHost string) bool {
 	resp, err := http.Get(syncHost + "/RemoteAfterSuiteData")
 	if err != nil || resp.StatusCode != http.StatusOK {
 		return false
@@ -8,6 +8,7 @@ import (
 	"reflect"
 	"time"
 
+	"github.com/onsi/ginkgo/internal/clock"
 	"github.com/onsi/ginkgo/internal/failer"
 	"github.com/onsi/ginkgo/types"
 )
@@ -18,9 +19,10 @@ type synchronizedBeforeSuiteNode struct {
 
 	data []byte
 
-	outcome types.SpecState
-	failure types.SpecFailure
-	runTime time.Duration
+	outcome   types.SpecState
+	failure   types.SpecFailure
+	startTime time.Time
+	runTime   time.Duration
 }
 
 func NewSynchronizedBeforeSuiteNode(bodyA interface{}, bodyB interface{}, codeLocation types.CodeLocation, timeout time.Duration, failer *failer.Failer) SuiteNode {
@@ -33,9 +35,9 @@ func NewSynchronizedBeforeSuiteNode(bodyA interface{}, bodyB interface{}, codeLo
 }
 
 func (node *synchronizedBeforeSuiteNode) Run(parallelNode int, parallelTotal int, syncHost string) bool {
-	t := time.Now()
+	node.startTime = clock.Now()
 	defer func() {
-		node.runTime = time.Since(t)
+		node.runTime = clock.Since(node.startTime)
 	}()
 
 	if parallelNode == 1 {
@@ -121,8 +123,9 @@ func (node *synchronizedBeforeSuiteNode) Summary() *types.SetupSummary {
 		ComponentType: node.runnerA.nodeType,
 		CodeLocation:  node.runnerA.codeLocation,
 		State:         node.outcome,
+		StartTime:     node.startTime,
 		RunTime:       node.runTime,
-		Failure:       node.failure,
+		Failure:       fingerprinted(node.outcome, node.failure),
 	}
 }
 
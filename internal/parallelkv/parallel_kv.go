@@ -0,0 +1,146 @@
+/*
+Package parallelkv implements the key-value store behind ginkgo.ParallelKV, shared by the parallel nodes of a suite run.
+
+When running in parallel, the server run by the Ginkgo CLI owns the Store and the nodes set and get keys through its
+/KeyValue endpoint with a Client.  When running serially the Client keeps the keys in a Store of its own.
+*/
+package parallelkv
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/onsi/ginkgo/types"
+)
+
+//PollingInterval is how long WaitFor waits before looking for a key again
+var PollingInterval = 50 * time.Millisecond
+
+type Store struct {
+	lock   *sync.Mutex
+	values map[string][]byte
+}
+
+func NewStore() *Store {
+	return &Store{
+		lock:   &sync.Mutex{},
+		values: map[string][]byte{},
+	}
+}
+
+func (s *Store) Set(key string, value []byte) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.values[key] = value
+}
+
+func (s *Store) Get(key string) ([]byte, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	value, found := s.values[key]
+	return value, found
+}
+
+type Client struct {
+	lock     *sync.Mutex
+	syncHost string
+	client   *http.Client
+	local    *Store
+}
+
+func NewClient() *Client {
+	return &Client{
+		lock:   &sync.Mutex{},
+		client: &http.Client{},
+		local:  NewStore(),
+	}
+}
+
+//Connect makes the client set and get keys on the server at syncHost
+func (c *Client) Connect(syncHost string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.syncHost = syncHost
+}
+
+func (c *Client) Set(key string, value []byte) error {
+	syncHost := c.host()
+	if syncHost == "" {
+		c.local.Set(key, value)
+		return nil
+	}
+
+	resp, err := c.client.Post(syncHost+"/KeyValue", "application/json", bytes.NewBuffer(types.RemoteKeyValue{Key: key, Value: value}.ToJSON()))
+	if err == nil {
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			err = fmt.Errorf("unexpected status code %d", resp.StatusCode)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("Failed to set %q: %s", key, err.Error())
+	}
+	return nil
+}
+
+func (c *Client) Get(key string) ([]byte, bool, error) {
+	syncHost := c.host()
+	if syncHost == "" {
+		value, found := c.local.Get(key)
+		return value, found, nil
+	}
+
+	keyValue, err := c.get(syncHost + "/KeyValue?key=" + url.QueryEscape(key))
+	if err != nil {
+		return nil, false, fmt.Errorf("Failed to get %q: %s", key, err.Error())
+	}
+	return keyValue.Value, keyValue.Found, nil
+}
+
+//WaitFor blocks until key is set, and returns its value.  It gives up after timeout, unless timeout is 0.
+func (c *Client) WaitFor(key string, timeout time.Duration) ([]byte, error) {
+	startTime := time.Now()
+	for {
+		value, found, err := c.Get(key)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			return value, nil
+		}
+		if timeout > 0 && time.Since(startTime) >= timeout {
+			return nil, fmt.Errorf("Timed out after %s waiting for %q to be set", timeout, key)
+		}
+		time.Sleep(PollingInterval)
+	}
+}
+
+func (c *Client) host() string {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.syncHost
+}
+
+func (c *Client) get(url string) (types.RemoteKeyValue, error) {
+	keyValue := types.RemoteKeyValue{}
+	resp, err := c.client.Get(url)
+	if err != nil {
+		return keyValue, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return keyValue, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	err = json.NewDecoder(resp.Body).Decode(&keyValue)
+	return keyValue, err
+}
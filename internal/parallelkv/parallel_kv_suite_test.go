@@ -0,0 +1,13 @@
+package parallelkv_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestParallelKV(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "ParallelKV Suite")
+}
@@ -0,0 +1,102 @@
+package parallelkv_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/onsi/ginkgo/internal/parallelkv"
+	"github.com/onsi/ginkgo/internal/remote"
+)
+
+var _ = Describe("ParallelKV", func() {
+	var client *Client
+
+	BeforeEach(func() {
+		PollingInterval = time.Millisecond
+		client = NewClient()
+	})
+
+	AfterEach(func() {
+		PollingInterval = 50 * time.Millisecond
+	})
+
+	Context("when running serially", func() {
+		It("should set and get keys locally", func() {
+			_, found, err := client.Get("token")
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(found).Should(BeFalse())
+
+			Ω(client.Set("token", []byte("abc"))).Should(Succeed())
+			value, found, err := client.Get("token")
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(found).Should(BeTrue())
+			Ω(value).Should(Equal([]byte("abc")))
+		})
+
+		It("should time out waiting for a key that is never set", func() {
+			_, err := client.WaitFor("token", 10*time.Millisecond)
+			Ω(err).Should(MatchError(`Timed out after 10ms waiting for "token" to be set`))
+		})
+	})
+
+	Context("when running in parallel", func() {
+		var (
+			server *remote.Server
+			other  *Client
+		)
+
+		BeforeEach(func() {
+			var err error
+			server, err = remote.NewServer(2)
+			Ω(err).ShouldNot(HaveOccurred())
+			server.Start()
+
+			client.Connect(server.Address())
+			other = NewClient()
+			other.Connect(server.Address())
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("should share keys with the other nodes", func() {
+			Ω(client.Set("url", []byte("http://example.com"))).Should(Succeed())
+
+			value, found, err := other.Get("url")
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(found).Should(BeTrue())
+			Ω(value).Should(Equal([]byte("http://example.com")))
+
+			_, found, err = other.Get("missing")
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(found).Should(BeFalse())
+		})
+
+		It("should wait for a key to be set by another node", func() {
+			received := make(chan []byte)
+			go func() {
+				defer GinkgoRecover()
+				value, err := other.WaitFor("ready", 0)
+				Ω(err).ShouldNot(HaveOccurred())
+				received <- value
+			}()
+			Consistently(received, 20*time.Millisecond).ShouldNot(Receive())
+
+			Ω(client.Set("ready", []byte("true"))).Should(Succeed())
+			Eventually(received).Should(Receive(Equal([]byte("true"))))
+		})
+
+		It("should fail when the server cannot be reached", func() {
+			server.Close()
+
+			Ω(client.Set("url", nil)).Should(MatchError(ContainSubstring(`Failed to set "url"`)))
+			_, _, err := client.Get("url")
+			Ω(err).Should(MatchError(ContainSubstring(`Failed to get "url"`)))
+			_, err = client.WaitFor("url", 0)
+			Ω(err).Should(MatchError(ContainSubstring(`Failed to get "url"`)))
+		})
+	})
+})
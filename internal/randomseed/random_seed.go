@@ -0,0 +1,34 @@
+/*
+Package randomseed derives the random seeds of specs from the seed of the suite, and recognizes seeds derived from the
+current time, which make failures impossible to reproduce.
+*/
+package randomseed
+
+import (
+	"hash/fnv"
+	"time"
+)
+
+//timeBasedWindow is how far from the current time a seed may be and still look derived from it
+const timeBasedWindow = 24 * time.Hour
+
+//ForSpec returns the seed of the spec with the given full text.  It only depends on the seed of the suite and on the
+//text of the spec, so that a spec gets the same seed whatever the order the specs run in.
+func ForSpec(suiteSeed int64, specText string) int64 {
+	hash := fnv.New64a()
+	hash.Write([]byte(specText))
+	return suiteSeed ^ int64(hash.Sum64())
+}
+
+//LooksTimeBased tells whether seed looks derived from now, as a Unix time in seconds, milliseconds, microseconds or
+//nanoseconds
+func LooksTimeBased(seed int64, now time.Time) bool {
+	for _, unit := range []time.Duration{time.Second, time.Millisecond, time.Microsecond, time.Nanosecond} {
+		nowInUnit := now.UnixNano() / int64(unit)
+		window := int64(timeBasedWindow / unit)
+		if seed > nowInUnit-window && seed < nowInUnit+window {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,13 @@
+package randomseed_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestRandomSeed(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "RandomSeed Suite")
+}
@@ -0,0 +1,38 @@
+package randomseed_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/internal/randomseed"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RandomSeed", func() {
+	Describe("ForSpec", func() {
+		It("should only depend on the seed of the suite and the text of the spec", func() {
+			Ω(ForSpec(17, "a spec")).Should(Equal(ForSpec(17, "a spec")))
+			Ω(ForSpec(17, "a spec")).ShouldNot(Equal(ForSpec(18, "a spec")))
+			Ω(ForSpec(17, "a spec")).ShouldNot(Equal(ForSpec(17, "another spec")))
+		})
+	})
+
+	Describe("LooksTimeBased", func() {
+		now := time.Date(2021, 3, 4, 5, 6, 7, 8, time.UTC)
+
+		It("should recognize seeds derived from the current time", func() {
+			Ω(LooksTimeBased(now.Unix(), now)).Should(BeTrue())
+			Ω(LooksTimeBased(now.UnixNano()/int64(time.Millisecond), now)).Should(BeTrue())
+			Ω(LooksTimeBased(now.UnixNano()/int64(time.Microsecond), now)).Should(BeTrue())
+			Ω(LooksTimeBased(now.UnixNano(), now)).Should(BeTrue())
+			Ω(LooksTimeBased(now.Add(-time.Minute).UnixNano(), now)).Should(BeTrue())
+		})
+
+		It("should not flag other seeds", func() {
+			Ω(LooksTimeBased(0, now)).Should(BeFalse())
+			Ω(LooksTimeBased(42, now)).Should(BeFalse())
+			Ω(LooksTimeBased(-now.Unix(), now)).Should(BeFalse())
+			Ω(LooksTimeBased(now.Add(-72*time.Hour).Unix(), now)).Should(BeFalse())
+		})
+	})
+})
@@ -10,9 +10,11 @@ where N is the number of nodes you desire.
 package remote
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/onsi/ginkgo/config"
+	"github.com/onsi/ginkgo/reporters"
 	"github.com/onsi/ginkgo/reporters/stenographer"
 	"github.com/onsi/ginkgo/types"
 )
@@ -26,6 +28,7 @@ type Aggregator struct {
 	nodeCount    int
 	config       config.DefaultReporterConfigType
 	stenographer stenographer.Stenographer
+	reporters    []reporters.Reporter
 	result       chan bool
 
 	suiteBeginnings           chan configAndSuite
@@ -47,12 +50,15 @@ type Aggregator struct {
 	startTime time.Time
 }
 
-func NewAggregator(nodeCount int, result chan bool, config config.DefaultReporterConfigType, stenographer stenographer.Stenographer) *Aggregator {
+//NewAggregator creates a new aggregator.  Any additional reporters passed in are handed a coherent view of the entire
+//parallel run: the suite begins and ends once, with summaries aggregated across all nodes.
+func NewAggregator(nodeCount int, result chan bool, config config.DefaultReporterConfigType, stenographer stenographer.Stenographer, reporters ...reporters.Reporter) *Aggregator {
 	aggregator := &Aggregator{
 		nodeCount:    nodeCount,
 		result:       result,
 		config:       config,
 		stenographer: stenographer,
+		reporters:    reporters,
 
 		suiteBeginnings: make(chan configAndSuite),
 		beforeSuites:    make(chan *types.SetupSummary),
@@ -123,15 +129,31 @@ func (aggregator *Aggregator) registerSuiteBeginning(configAndSuite configAndSui
 		return
 	}
 
-	aggregator.stenographer.AnnounceSuite(configAndSuite.summary.SuiteDescription, configAndSuite.config.RandomSeed, configAndSuite.config.RandomizeAllSpecs, aggregator.config.Succinct)
+	//the summary is copied ahead of the announcements, which tell the nodes' summaries are no longer read
+	summary := *configAndSuite.summary
+
+	if !aggregator.config.Quiet {
+		aggregator.stenographer.AnnounceSuite(summary.SuiteDescription, configAndSuite.config.RandomSeed, configAndSuite.config.RandomizeAllSpecs, aggregator.config.Succinct)
+	}
+	if len(summary.Warnings) > 0 {
+		aggregator.stenographer.AnnounceWarnings(summary.Warnings)
+	}
 
 	totalNumberOfSpecs := 0
 	if len(aggregator.aggregatedSuiteBeginnings) > 0 {
-		totalNumberOfSpecs = configAndSuite.summary.NumberOfSpecsBeforeParallelization
+		totalNumberOfSpecs = summary.NumberOfSpecsBeforeParallelization
+	}
+
+	if !aggregator.config.Quiet {
+		aggregator.stenographer.AnnounceTotalNumberOfSpecs(totalNumberOfSpecs, aggregator.config.Succinct)
+		aggregator.stenographer.AnnounceAggregatedParallelRun(aggregator.nodeCount, aggregator.config.Succinct)
+	}
+
+	summary.NumberOfTotalSpecs = totalNumberOfSpecs
+	for _, reporter := range aggregator.reporters {
+		reporter.SpecSuiteWillBegin(configAndSuite.config, &summary)
 	}
 
-	aggregator.stenographer.AnnounceTotalNumberOfSpecs(totalNumberOfSpecs, aggregator.config.Succinct)
-	aggregator.stenographer.AnnounceAggregatedParallelRun(aggregator.nodeCount, aggregator.config.Succinct)
 	aggregator.flushCompletedSpecs()
 }
 
@@ -158,14 +180,24 @@ func (aggregator *Aggregator) flushCompletedSpecs() {
 
 	for _, setupSummary := range aggregator.aggregatedBeforeSuites {
 		aggregator.announceBeforeSuite(setupSummary)
+		for _, reporter := range aggregator.reporters {
+			reporter.BeforeSuiteDidRun(setupSummary)
+		}
 	}
 
 	for _, specSummary := range aggregator.completedSpecs {
 		aggregator.announceSpec(specSummary)
+		for _, reporter := range aggregator.reporters {
+			reporter.SpecWillRun(specSummary)
+			reporter.SpecDidComplete(specSummary)
+		}
 	}
 
 	for _, setupSummary := range aggregator.aggregatedAfterSuites {
 		aggregator.announceAfterSuite(setupSummary)
+		for _, reporter := range aggregator.reporters {
+			reporter.AfterSuiteDidRun(setupSummary)
+		}
 	}
 
 	aggregator.aggregatedBeforeSuites = []*types.SetupSummary{}
@@ -174,6 +206,9 @@ func (aggregator *Aggregator) flushCompletedSpecs() {
 }
 
 func (aggregator *Aggregator) announceBeforeSuite(setupSummary *types.SetupSummary) {
+	if aggregator.config.Quiet && setupSummary.State == types.SpecStatePassed {
+		return
+	}
 	aggregator.stenographer.AnnounceCapturedOutput(setupSummary.CapturedOutput)
 	if setupSummary.State != types.SpecStatePassed {
 		aggregator.stenographer.AnnounceBeforeSuiteFailure(setupSummary, aggregator.config.Succinct, aggregator.config.FullTrace)
@@ -181,6 +216,9 @@ func (aggregator *Aggregator) announceBeforeSuite(setupSummary *types.SetupSumma
 }
 
 func (aggregator *Aggregator) announceAfterSuite(setupSummary *types.SetupSummary) {
+	if aggregator.config.Quiet && setupSummary.State == types.SpecStatePassed {
+		return
+	}
 	aggregator.stenographer.AnnounceCapturedOutput(setupSummary.CapturedOutput)
 	if setupSummary.State != types.SpecStatePassed {
 		aggregator.stenographer.AnnounceAfterSuiteFailure(setupSummary, aggregator.config.Succinct, aggregator.config.FullTrace)
@@ -188,7 +226,12 @@ func (aggregator *Aggregator) announceAfterSuite(setupSummary *types.SetupSummar
 }
 
 func (aggregator *Aggregator) announceSpec(specSummary *types.SpecSummary) {
-	if aggregator.config.Verbose && specSummary.State != types.SpecStatePending && specSummary.State != types.SpecStateSkipped {
+	//in quiet mode, only the failures are announced, see -quiet
+	if aggregator.config.Quiet && !specSummary.State.IsFailure() {
+		return
+	}
+
+	if aggregator.config.Verbose && !aggregator.config.Quiet && specSummary.State != types.SpecStatePending && specSummary.State != types.SpecStateSkipped {
 		aggregator.stenographer.AnnounceSpecWillRun(specSummary)
 	}
 
@@ -196,8 +239,12 @@ func (aggregator *Aggregator) announceSpec(specSummary *types.SpecSummary) {
 
 	switch specSummary.State {
 	case types.SpecStatePassed:
-		if specSummary.IsMeasurement {
+		if specSummary.ExpectedFailure != nil {
+			aggregator.stenographer.AnnounceExpectedFailure(specSummary, aggregator.config.Succinct)
+		} else if specSummary.IsMeasurement {
 			aggregator.stenographer.AnnounceSuccessfulMeasurement(specSummary, aggregator.config.Succinct)
+		} else if specSummary.RuntimeBudgetViolation != nil {
+			aggregator.stenographer.AnnounceSpecOverRuntimeBudget(specSummary, aggregator.config.Succinct)
 		} else if specSummary.RunTime.Seconds() >= aggregator.config.SlowSpecThreshold {
 			aggregator.stenographer.AnnounceSuccessfulSlowSpec(specSummary, aggregator.config.Succinct)
 		} else {
@@ -225,6 +272,14 @@ func (aggregator *Aggregator) registerSuiteEnding(suite *types.SuiteSummary) (fi
 
 	aggregatedSuiteSummary := &types.SuiteSummary{}
 	aggregatedSuiteSummary.SuiteSucceeded = true
+	if len(aggregator.aggregatedSuiteBeginnings) > 0 {
+		beginning := aggregator.aggregatedSuiteBeginnings[0].summary
+		aggregatedSuiteSummary.SuiteDescription = beginning.SuiteDescription
+		aggregatedSuiteSummary.SuiteID = beginning.SuiteID
+		aggregatedSuiteSummary.NumberOfSpecsBeforeParallelization = beginning.NumberOfSpecsBeforeParallelization
+		aggregatedSuiteSummary.SuiteLabels = beginning.SuiteLabels
+		aggregatedSuiteSummary.SuiteMetadata = beginning.SuiteMetadata
+	}
 
 	for _, suiteSummary := range aggregator.aggregatedSuiteEndings {
 		if !suiteSummary.SuiteSucceeded {
@@ -238,12 +293,59 @@ func (aggregator *Aggregator) registerSuiteEnding(suite *types.SuiteSummary) (fi
 		aggregatedSuiteSummary.NumberOfPendingSpecs += suiteSummary.NumberOfPendingSpecs
 		aggregatedSuiteSummary.NumberOfSkippedSpecs += suiteSummary.NumberOfSkippedSpecs
 		aggregatedSuiteSummary.NumberOfFlakedSpecs += suiteSummary.NumberOfFlakedSpecs
+		if suiteSummary.WarmUpRunTime > aggregatedSuiteSummary.WarmUpRunTime {
+			aggregatedSuiteSummary.WarmUpRunTime = suiteSummary.WarmUpRunTime
+		}
+		aggregatedSuiteSummary.SharedFixtures = append(aggregatedSuiteSummary.SharedFixtures, suiteSummary.SharedFixtures...)
+		aggregatedSuiteSummary.SuiteProcesses = append(aggregatedSuiteSummary.SuiteProcesses, suiteSummary.SuiteProcesses...)
+		for label, count := range suiteSummary.RuntimeBudgetViolations {
+			if aggregatedSuiteSummary.RuntimeBudgetViolations == nil {
+				aggregatedSuiteSummary.RuntimeBudgetViolations = map[string]int{}
+			}
+			aggregatedSuiteSummary.RuntimeBudgetViolations[label] += count
+		}
+		for _, reason := range suiteSummary.SpecialSuiteFailureReasons {
+			aggregatedSuiteSummary.SpecialSuiteFailureReasons = appendSpecialSuiteFailureReason(aggregatedSuiteSummary.SpecialSuiteFailureReasons, reason)
+		}
 	}
 
 	aggregatedSuiteSummary.RunTime = time.Since(aggregator.startTime)
+	if len(aggregator.aggregatedSuiteEndings) > 0 {
+		//the server records the abandoned attempts of all the nodes: the last node to end knows about all of them
+		aggregatedSuiteSummary.AbandonedSpecAttempts = aggregator.aggregatedSuiteEndings[len(aggregator.aggregatedSuiteEndings)-1].AbandonedSpecAttempts
+	}
+	if len(aggregatedSuiteSummary.AbandonedSpecAttempts) > 0 {
+		aggregator.stenographer.AnnounceWarnings(abandonedSpecAttemptWarnings(aggregatedSuiteSummary.AbandonedSpecAttempts))
+	}
 
-	aggregator.stenographer.SummarizeFailures(aggregator.specs)
+	aggregator.stenographer.SummarizeFailures(aggregator.specs, aggregator.config.GroupFailures)
 	aggregator.stenographer.AnnounceSpecRunCompletion(aggregatedSuiteSummary, aggregator.config.Succinct)
 
+	for _, reporter := range aggregator.reporters {
+		reporter.SpecSuiteDidEnd(aggregatedSuiteSummary)
+	}
+
 	return true, aggregatedSuiteSummary.SuiteSucceeded
 }
+
+func abandonedSpecAttemptWarnings(attempts []types.AbandonedSpecAttempt) []string {
+	warnings := []string{}
+	for _, attempt := range attempts {
+		text := attempt.Text
+		if text == "" {
+			text = "a spec"
+		}
+		warnings = append(warnings, fmt.Sprintf("%s was abandoned by node #%d, which claimed it %s before its lease expired, and handed to node #%d", text, attempt.Node, attempt.AbandonedAt.Sub(attempt.ClaimedAt).Round(time.Millisecond), attempt.ReassignedTo))
+	}
+	return warnings
+}
+
+//appendSpecialSuiteFailureReason appends reason to reasons, unless another node already reported it
+func appendSpecialSuiteFailureReason(reasons []types.SpecialSuiteFailureReason, reason types.SpecialSuiteFailureReason) []types.SpecialSuiteFailureReason {
+	for _, existing := range reasons {
+		if existing == reason {
+			return reasons
+		}
+	}
+	return append(reasons, reason)
+}
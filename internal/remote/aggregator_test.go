@@ -4,10 +4,12 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	"sync"
 	"time"
 
 	"github.com/onsi/ginkgo/config"
 	. "github.com/onsi/ginkgo/internal/remote"
+	"github.com/onsi/ginkgo/reporters"
 	st "github.com/onsi/ginkgo/reporters/stenographer"
 	"github.com/onsi/ginkgo/types"
 )
@@ -235,10 +237,14 @@ var _ = Describe("Aggregator", func() {
 				suiteSummary1.NumberOfPassedSpecs = 15
 				suiteSummary1.NumberOfFailedSpecs = 0
 				suiteSummary1.NumberOfFlakedSpecs = 3
+				suiteSummary1.SuiteProcesses = []*types.SuiteProcessSummary{{Name: "api", Node: 1, Output: "listening"}}
+				suiteSummary1.RuntimeBudgetViolations = map[string]int{"unit": 2}
 				suiteSummary2.SuiteSucceeded = false
 				suiteSummary2.NumberOfPassedSpecs = 5
 				suiteSummary2.NumberOfFailedSpecs = 3
 				suiteSummary2.NumberOfFlakedSpecs = 4
+				suiteSummary2.SharedFixtures = []*types.SharedFixtureSummary{{Name: "postgres", NumberOfReferences: 2}}
+				suiteSummary2.RuntimeBudgetViolations = map[string]int{"unit": 1, "integration": 1}
 
 				aggregator.SpecSuiteDidEnd(suiteSummary2)
 				aggregator.SpecSuiteDidEnd(suiteSummary1)
@@ -258,6 +264,9 @@ var _ = Describe("Aggregator", func() {
 				Ω(compositeSummary.NumberOfPendingSpecs).Should(Equal(3))
 				Ω(compositeSummary.NumberOfSkippedSpecs).Should(Equal(4))
 				Ω(compositeSummary.NumberOfFlakedSpecs).Should(Equal(7))
+				Ω(compositeSummary.SharedFixtures).Should(Equal(suiteSummary2.SharedFixtures))
+				Ω(compositeSummary.SuiteProcesses).Should(Equal(suiteSummary1.SuiteProcesses))
+				Ω(compositeSummary.RuntimeBudgetViolations).Should(Equal(map[string]int{"unit": 3, "integration": 1}))
 				Ω(compositeSummary.RunTime.Seconds()).Should(BeNumerically(">", 0.2))
 			})
 		})
@@ -310,4 +319,124 @@ var _ = Describe("Aggregator", func() {
 			})
 		})
 	})
+
+	Describe("Announcing the end of a suite with labels and metadata", func() {
+		BeforeEach(func() {
+			suiteSummary1.SuiteLabels = []string{"integration"}
+			suiteSummary1.SuiteMetadata = map[string]string{"gitSHA": "abc123"}
+			suiteSummary2.SuiteLabels = []string{"integration"}
+			suiteSummary2.SuiteMetadata = map[string]string{"gitSHA": "abc123"}
+			beginSuite()
+			stenographer.Reset()
+
+			aggregator.SpecSuiteDidEnd(suiteSummary2)
+			aggregator.SpecSuiteDidEnd(suiteSummary1)
+			Eventually(func() interface{} {
+				return stenographer.Calls()
+			}).Should(HaveLen(2))
+		})
+
+		It("should carry them over to the aggregated summary", func() {
+			compositeSummary := stenographer.Calls()[1].Args[0].(*types.SuiteSummary)
+			Ω(compositeSummary.SuiteLabels).Should(Equal([]string{"integration"}))
+			Ω(compositeSummary.SuiteMetadata).Should(Equal(map[string]string{"gitSHA": "abc123"}))
+		})
+	})
+
+	Describe("Forwarding to additional reporters", func() {
+		var fakeReporter *lockedReporter
+
+		BeforeEach(func() {
+			fakeReporter = &lockedReporter{fake: reporters.NewFakeReporter()}
+			aggregator = NewAggregator(2, result, reporterConfig, stenographer, fakeReporter)
+		})
+
+		It("should only begin the suite once all the parallel-suites have started", func() {
+			aggregator.SpecSuiteWillBegin(ginkgoConfig2, suiteSummary2)
+			aggregator.SpecDidComplete(specSummary)
+			Consistently(func() interface{} { return fakeReporter.snapshot().BeginSummary }).Should(BeNil())
+			Ω(fakeReporter.snapshot().SpecSummaries).Should(BeEmpty())
+
+			aggregator.SpecSuiteWillBegin(ginkgoConfig1, suiteSummary1)
+			Eventually(func() interface{} { return fakeReporter.snapshot().SpecSummaries }).Should(HaveLen(1))
+			Ω(fakeReporter.snapshot().BeginSummary.SuiteDescription).Should(Equal(suiteDescription))
+			Ω(fakeReporter.snapshot().BeginSummary.NumberOfTotalSpecs).Should(Equal(30))
+			Ω(fakeReporter.snapshot().SpecWillRunSummaries).Should(Equal([]*types.SpecSummary{specSummary}))
+			Ω(fakeReporter.snapshot().SpecSummaries).Should(Equal([]*types.SpecSummary{specSummary}))
+		})
+
+		It("should forward the before and after suites", func() {
+			beginSuite()
+			aggregator.BeforeSuiteDidRun(beforeSummary)
+			aggregator.AfterSuiteDidRun(afterSummary)
+			Eventually(func() interface{} { return fakeReporter.snapshot().AfterSuiteSummary }).Should(Equal(afterSummary))
+			Ω(fakeReporter.snapshot().BeforeSuiteSummary).Should(Equal(beforeSummary))
+		})
+
+		It("should end the suite once, with the aggregated summary", func() {
+			beginSuite()
+			suiteSummary1.SuiteSucceeded = true
+			suiteSummary1.NumberOfPassedSpecs = 15
+			suiteSummary2.SuiteSucceeded = true
+			suiteSummary2.NumberOfPassedSpecs = 5
+
+			aggregator.SpecSuiteDidEnd(suiteSummary2)
+			Consistently(func() interface{} { return fakeReporter.snapshot().EndSummary }).Should(BeNil())
+
+			aggregator.SpecSuiteDidEnd(suiteSummary1)
+			Eventually(func() interface{} { return fakeReporter.snapshot().EndSummary }).ShouldNot(BeNil())
+			Ω(fakeReporter.snapshot().EndSummary.SuiteSucceeded).Should(BeTrue())
+			Ω(fakeReporter.snapshot().EndSummary.SuiteDescription).Should(Equal(suiteDescription))
+			Ω(fakeReporter.snapshot().EndSummary.NumberOfPassedSpecs).Should(Equal(20))
+		})
+	})
 })
+
+//lockedReporter hands what the aggregator reports from its own goroutine over to the specs without racing them
+type lockedReporter struct {
+	lock sync.Mutex
+	fake *reporters.FakeReporter
+}
+
+//snapshot returns what was reported so far
+func (r *lockedReporter) snapshot() reporters.FakeReporter {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return *r.fake
+}
+
+func (r *lockedReporter) SpecSuiteWillBegin(config config.GinkgoConfigType, summary *types.SuiteSummary) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.fake.SpecSuiteWillBegin(config, summary)
+}
+
+func (r *lockedReporter) BeforeSuiteDidRun(setupSummary *types.SetupSummary) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.fake.BeforeSuiteDidRun(setupSummary)
+}
+
+func (r *lockedReporter) SpecWillRun(specSummary *types.SpecSummary) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.fake.SpecWillRun(specSummary)
+}
+
+func (r *lockedReporter) SpecDidComplete(specSummary *types.SpecSummary) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.fake.SpecDidComplete(specSummary)
+}
+
+func (r *lockedReporter) AfterSuiteDidRun(setupSummary *types.SetupSummary) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.fake.AfterSuiteDidRun(setupSummary)
+}
+
+func (r *lockedReporter) SpecSuiteDidEnd(summary *types.SuiteSummary) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.fake.SpecSuiteDidEnd(summary)
+}
@@ -6,6 +6,7 @@ type fakeOutputInterceptor struct {
 	DidStartInterceptingOutput bool
 	DidStopInterceptingOutput  bool
 	InterceptedOutput          string
+	InterceptedOffset          int64
 }
 
 func (interceptor *fakeOutputInterceptor) StartInterceptingOutput() error {
@@ -18,5 +19,9 @@ func (interceptor *fakeOutputInterceptor) StopInterceptingAndReturnOutput() (str
 	return interceptor.InterceptedOutput, nil
 }
 
+func (interceptor *fakeOutputInterceptor) Offset() int64 {
+	return interceptor.InterceptedOffset
+}
+
 func (interceptor *fakeOutputInterceptor) StreamTo(*os.File) {
 }
@@ -36,6 +36,9 @@ type ForwardingReporter struct {
 	serverHost        string
 	poster            Poster
 	outputInterceptor OutputInterceptor
+	stripANSI         bool
+	binaryOutput      string
+	ginkgoWriter      *writer.Writer
 	debugMode         bool
 	debugFile         *os.File
 	nestedReporter    *reporters.DefaultReporter
@@ -46,6 +49,14 @@ func NewForwardingReporter(config config.DefaultReporterConfigType, serverHost s
 		serverHost:        serverHost,
 		poster:            poster,
 		outputInterceptor: outputInterceptor,
+		stripANSI:         config.StripANSI,
+		binaryOutput:      config.BinaryOutput,
+		ginkgoWriter:      ginkgoWriter,
+	}
+
+	if ginkgoWriter != nil {
+		//the GinkgoWriter output of failed specs goes back among their stdout and stderr in the order it was written in
+		ginkgoWriter.SetInterleaving(outputInterceptor.Offset)
 	}
 
 	if debugFile != "" {
@@ -57,8 +68,8 @@ func NewForwardingReporter(config config.DefaultReporterConfigType, serverHost s
 			os.Exit(1)
 		}
 
-		if !config.Verbose {
-			//if verbose is true then the GinkgoWriter emits to stdout.  Don't _also_ redirect GinkgoWriter output as that will result in duplication.
+		if !config.Verbose && !config.Follow {
+			//if verbose or follow is true then the GinkgoWriter emits to stdout.  Don't _also_ redirect GinkgoWriter output as that will result in duplication.
 			ginkgoWriter.AndRedirectTo(reporter.debugFile)
 		}
 		outputInterceptor.StreamTo(reporter.debugFile) //This is not working
@@ -97,8 +108,7 @@ func (reporter *ForwardingReporter) SpecSuiteWillBegin(conf config.GinkgoConfigT
 }
 
 func (reporter *ForwardingReporter) BeforeSuiteDidRun(setupSummary *types.SetupSummary) {
-	output, _ := reporter.outputInterceptor.StopInterceptingAndReturnOutput()
-	reporter.outputInterceptor.StartInterceptingOutput()
+	output := reporter.interceptedOutput()
 	setupSummary.CapturedOutput = output
 	if reporter.debugMode {
 		reporter.nestedReporter.BeforeSuiteDidRun(setupSummary)
@@ -116,8 +126,7 @@ func (reporter *ForwardingReporter) SpecWillRun(specSummary *types.SpecSummary)
 }
 
 func (reporter *ForwardingReporter) SpecDidComplete(specSummary *types.SpecSummary) {
-	output, _ := reporter.outputInterceptor.StopInterceptingAndReturnOutput()
-	reporter.outputInterceptor.StartInterceptingOutput()
+	output := reporter.interceptedOutput()
 	specSummary.CapturedOutput = output
 	if reporter.debugMode {
 		reporter.nestedReporter.SpecDidComplete(specSummary)
@@ -127,8 +136,7 @@ func (reporter *ForwardingReporter) SpecDidComplete(specSummary *types.SpecSumma
 }
 
 func (reporter *ForwardingReporter) AfterSuiteDidRun(setupSummary *types.SetupSummary) {
-	output, _ := reporter.outputInterceptor.StopInterceptingAndReturnOutput()
-	reporter.outputInterceptor.StartInterceptingOutput()
+	output := reporter.interceptedOutput()
 	setupSummary.CapturedOutput = output
 	if reporter.debugMode {
 		reporter.nestedReporter.AfterSuiteDidRun(setupSummary)
@@ -137,6 +145,21 @@ func (reporter *ForwardingReporter) AfterSuiteDidRun(setupSummary *types.SetupSu
 	reporter.post("/AfterSuiteDidRun", setupSummary)
 }
 
+//interceptedOutput returns the output intercepted since the last call, interleaved with the GinkgoWriter output dumped
+//meanwhile, sanitized and redacted for reports, and starts intercepting anew
+func (reporter *ForwardingReporter) interceptedOutput() string {
+	output, _ := reporter.outputInterceptor.StopInterceptingAndReturnOutput()
+	reporter.outputInterceptor.StartInterceptingOutput()
+	if reporter.ginkgoWriter != nil {
+		output = writer.Interleave(output, reporter.ginkgoWriter.TakeInterleavedOutput())
+	}
+	output = string(writer.Sanitize([]byte(output), reporter.stripANSI, reporter.binaryOutput))
+	if reporter.ginkgoWriter != nil {
+		output = reporter.ginkgoWriter.Redact(output)
+	}
+	return output
+}
+
 func (reporter *ForwardingReporter) SpecSuiteDidEnd(summary *types.SuiteSummary) {
 	reporter.outputInterceptor.StopInterceptingAndReturnOutput()
 	if reporter.debugMode {
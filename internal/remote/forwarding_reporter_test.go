@@ -2,10 +2,12 @@ package remote_test
 
 import (
 	"encoding/json"
+	"os"
 
 	. "github.com/onsi/ginkgo"
 	"github.com/onsi/ginkgo/config"
 	. "github.com/onsi/ginkgo/internal/remote"
+	"github.com/onsi/ginkgo/internal/writer"
 	"github.com/onsi/ginkgo/types"
 	. "github.com/onsi/gomega"
 )
@@ -160,6 +162,42 @@ var _ = Describe("ForwardingReporter", func() {
 		})
 	})
 
+	Context("when told to sanitize the intercepted output", func() {
+		BeforeEach(func() {
+			interceptor.InterceptedOutput = "\x1b[32mgreen\x1b[0m \x00\x01"
+			reporter = NewForwardingReporter(config.DefaultReporterConfigType{StripANSI: true, BinaryOutput: "hex"}, serverHost, poster, interceptor, nil, "")
+			reporter.SpecDidComplete(specSummary)
+		})
+
+		It("should POST the sanitized output", func() {
+			var summary *types.SpecSummary
+			err := json.Unmarshal(poster.posts[0].bodyContent, &summary)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(summary.CapturedOutput).Should(Equal(`green \x00\x01`))
+		})
+	})
+
+	Context("when the GinkgoWriter output of a failed spec was dumped", func() {
+		BeforeEach(func() {
+			ginkgoWriter := writer.New(os.Stdout)
+			ginkgoWriter.SetStream(false)
+			reporter = NewForwardingReporter(config.DefaultReporterConfigType{}, serverHost, poster, interceptor, ginkgoWriter, "")
+
+			interceptor.InterceptedOffset = 8
+			ginkgoWriter.Write([]byte("written in between\n"))
+			ginkgoWriter.DumpOut()
+			interceptor.InterceptedOutput = "stdout!\nstdout again!\n"
+			reporter.SpecDidComplete(specSummary)
+		})
+
+		It("should POST it interleaved with the intercepted output", func() {
+			var summary *types.SpecSummary
+			err := json.Unmarshal(poster.posts[0].bodyContent, &summary)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(summary.CapturedOutput).Should(Equal("[stdout/stderr] stdout!\n[GinkgoWriter] written in between\n[stdout/stderr] stdout again!\n"))
+		})
+	})
+
 	Context("When a suite ends", func() {
 		BeforeEach(func() {
 			reporter.SpecSuiteDidEnd(suiteSummary)
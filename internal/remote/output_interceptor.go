@@ -9,5 +9,7 @@ intercept and capture all stdin and stderr output during a test run.
 type OutputInterceptor interface {
 	StartInterceptingOutput() error
 	StopInterceptingAndReturnOutput() (string, error)
+	//Offset returns how many bytes were intercepted since interception last started, or -1 when not intercepting
+	Offset() int64
 	StreamTo(*os.File)
 }
@@ -0,0 +1,42 @@
+// +build js wasip1
+
+package remote
+
+import (
+	"errors"
+	"os"
+)
+
+//NewOutputInterceptor returns an interceptor that captures nothing: js/wasm and wasip1 have no file descriptors to
+//redirect, nor subprocesses whose output to capture
+func NewOutputInterceptor() OutputInterceptor {
+	return &outputInterceptor{}
+}
+
+type outputInterceptor struct {
+	intercepting bool
+}
+
+func (interceptor *outputInterceptor) StartInterceptingOutput() error {
+	if interceptor.intercepting {
+		return errors.New("Already intercepting output!")
+	}
+	interceptor.intercepting = true
+
+	return nil
+}
+
+func (interceptor *outputInterceptor) StopInterceptingAndReturnOutput() (string, error) {
+	if !interceptor.intercepting {
+		return "", errors.New("Not intercepting output!")
+	}
+	interceptor.intercepting = false
+
+	return "", nil
+}
+
+func (interceptor *outputInterceptor) Offset() int64 {
+	return -1
+}
+
+func (interceptor *outputInterceptor) StreamTo(*os.File) {}
@@ -0,0 +1,115 @@
+package remote_test
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/internal/remote"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("OutputInterceptor", func() {
+	var interceptor OutputInterceptor
+
+	BeforeEach(func() {
+		interceptor = NewOutputInterceptor()
+	})
+
+	skipUnlessSupported := func() {
+		if runtime.GOOS == "js" || runtime.GOOS == "wasip1" {
+			Skip("output interception is a no-op on " + runtime.GOOS)
+		}
+	}
+
+	echo := func(text string) *exec.Cmd {
+		if runtime.GOOS == "windows" {
+			return exec.Command("cmd", "/c", "echo "+text)
+		}
+		return exec.Command("echo", text)
+	}
+
+	It("should capture what is written to stdout and stderr", func() {
+		skipUnlessSupported()
+		Ω(interceptor.StartInterceptingOutput()).Should(Succeed())
+		fmt.Fprint(os.Stdout, "to stdout ")
+		fmt.Fprint(os.Stderr, "to stderr")
+		output, err := interceptor.StopInterceptingAndReturnOutput()
+
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(output).Should(Equal("to stdout to stderr"))
+	})
+
+	It("should tell how much it intercepted so far", func() {
+		skipUnlessSupported()
+		Ω(interceptor.Offset()).Should(BeEquivalentTo(-1))
+		Ω(interceptor.StartInterceptingOutput()).Should(Succeed())
+		offset := interceptor.Offset()
+		fmt.Fprint(os.Stdout, "to stdout ")
+		fmt.Fprint(os.Stderr, "to stderr")
+		afterOutput := interceptor.Offset()
+		interceptor.StopInterceptingAndReturnOutput()
+
+		Ω(offset).Should(BeEquivalentTo(0))
+		Ω(afterOutput).Should(BeEquivalentTo(len("to stdout to stderr")))
+		Ω(interceptor.Offset()).Should(BeEquivalentTo(-1))
+	})
+
+	It("should capture the output of subprocesses writing to stdout", func() {
+		skipUnlessSupported()
+		Ω(interceptor.StartInterceptingOutput()).Should(Succeed())
+		cmd := echo("from a subprocess")
+		cmd.Stdout = os.Stdout
+		runErr := cmd.Run()
+		output, err := interceptor.StopInterceptingAndReturnOutput()
+
+		Ω(runErr).ShouldNot(HaveOccurred())
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(output).Should(ContainSubstring("from a subprocess"))
+	})
+
+	It("should only return the output written since it last started intercepting", func() {
+		skipUnlessSupported()
+		Ω(interceptor.StartInterceptingOutput()).Should(Succeed())
+		fmt.Fprint(os.Stdout, "first")
+		interceptor.StopInterceptingAndReturnOutput()
+
+		Ω(interceptor.StartInterceptingOutput()).Should(Succeed())
+		fmt.Fprint(os.Stdout, "second")
+		output, err := interceptor.StopInterceptingAndReturnOutput()
+
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(output).Should(Equal("second"))
+	})
+
+	It("should error, leaving stdout and stderr alone, when it cannot start intercepting", func() {
+		skipUnlessSupported()
+		for _, name := range []string{"TMPDIR", "TMP", "TEMP"} {
+			value, wasSet := os.LookupEnv(name)
+			os.Setenv(name, "/does/not/exist")
+			defer func(name string) {
+				if wasSet {
+					os.Setenv(name, value)
+				} else {
+					os.Unsetenv(name)
+				}
+			}(name)
+		}
+
+		Ω(interceptor.StartInterceptingOutput()).ShouldNot(Succeed())
+		Ω(interceptor.Offset()).Should(BeEquivalentTo(-1))
+		_, err := interceptor.StopInterceptingAndReturnOutput()
+		Ω(err).Should(MatchError("Not intercepting output!"))
+	})
+
+	It("should error when started twice or stopped without having started", func() {
+		_, err := interceptor.StopInterceptingAndReturnOutput()
+		Ω(err).Should(HaveOccurred())
+
+		Ω(interceptor.StartInterceptingOutput()).Should(Succeed())
+		Ω(interceptor.StartInterceptingOutput()).ShouldNot(Succeed())
+		interceptor.StopInterceptingAndReturnOutput()
+	})
+})
@@ -4,6 +4,7 @@ package remote
 
 import (
 	"errors"
+	"io"
 	"io/ioutil"
 	"os"
 
@@ -21,13 +22,15 @@ type outputInterceptor struct {
 	intercepting bool
 	tailer       *tail.Tail
 	doneTailing  chan bool
+
+	stdoutFd int
+	stderrFd int
 }
 
 func (interceptor *outputInterceptor) StartInterceptingOutput() error {
 	if interceptor.intercepting {
 		return errors.New("Already intercepting output!")
 	}
-	interceptor.intercepting = true
 
 	var err error
 
@@ -36,10 +39,30 @@ func (interceptor *outputInterceptor) StartInterceptingOutput() error {
 		return err
 	}
 
+	// Keep the original stdout and stderr around, to restore them once done intercepting.  Without them, stdout and
+	// stderr could not be restored, so they are left alone.
+	interceptor.stdoutFd, err = unix.Dup(1)
+	if err != nil {
+		interceptor.removeRedirectFile()
+		return err
+	}
+	interceptor.stderrFd, err = unix.Dup(2)
+	if err != nil {
+		unix.Close(interceptor.stdoutFd)
+		interceptor.removeRedirectFile()
+		return err
+	}
+
 	// This might call Dup3 if the dup2 syscall is not available, e.g. on
 	// linux/arm64 or linux/riscv64
-	unix.Dup2(int(interceptor.redirectFile.Fd()), 1)
-	unix.Dup2(int(interceptor.redirectFile.Fd()), 2)
+	for _, fd := range []int{1, 2} {
+		if err := unix.Dup2(int(interceptor.redirectFile.Fd()), fd); err != nil {
+			interceptor.restoreOutput()
+			interceptor.removeRedirectFile()
+			return err
+		}
+	}
+	interceptor.intercepting = true
 
 	if interceptor.streamTarget != nil {
 		interceptor.tailer, _ = tail.TailFile(interceptor.redirectFile.Name(), tail.Config{Follow: true})
@@ -61,6 +84,8 @@ func (interceptor *outputInterceptor) StopInterceptingAndReturnOutput() (string,
 		return "", errors.New("Not intercepting output!")
 	}
 
+	interceptor.restoreOutput()
+
 	interceptor.redirectFile.Close()
 	output, err := ioutil.ReadFile(interceptor.redirectFile.Name())
 	os.Remove(interceptor.redirectFile.Name())
@@ -77,6 +102,29 @@ func (interceptor *outputInterceptor) StopInterceptingAndReturnOutput() (string,
 	return string(output), err
 }
 
+//restoreOutput points stdout and stderr back at the files they were attached to before intercepting
+func (interceptor *outputInterceptor) restoreOutput() {
+	unix.Dup2(interceptor.stdoutFd, 1)
+	unix.Dup2(interceptor.stderrFd, 2)
+	unix.Close(interceptor.stdoutFd)
+	unix.Close(interceptor.stderrFd)
+}
+
+//removeRedirectFile removes the file output would have been redirected to when intercepting it could not start
+func (interceptor *outputInterceptor) removeRedirectFile() {
+	interceptor.redirectFile.Close()
+	os.Remove(interceptor.redirectFile.Name())
+}
+
+//Offset asks the kernel where stdout is at: stdout and stderr share the offset of the file they are redirected to
+func (interceptor *outputInterceptor) Offset() int64 {
+	offset, err := unix.Seek(1, 0, io.SeekCurrent)
+	if err != nil || !interceptor.intercepting {
+		return -1
+	}
+	return offset
+}
+
 func (interceptor *outputInterceptor) StreamTo(out *os.File) {
 	interceptor.streamTarget = out
 }
@@ -4,7 +4,12 @@ package remote
 
 import (
 	"errors"
+	"io"
+	"io/ioutil"
 	"os"
+
+	"github.com/nxadm/tail"
+	"golang.org/x/sys/windows"
 )
 
 func NewOutputInterceptor() OutputInterceptor {
@@ -12,25 +17,93 @@ func NewOutputInterceptor() OutputInterceptor {
 }
 
 type outputInterceptor struct {
+	redirectFile *os.File
+	streamTarget *os.File
 	intercepting bool
+	tailer       *tail.Tail
+	doneTailing  chan bool
+
+	stdout *os.File
+	stderr *os.File
 }
 
 func (interceptor *outputInterceptor) StartInterceptingOutput() error {
 	if interceptor.intercepting {
 		return errors.New("Already intercepting output!")
 	}
+
+	var err error
+
+	interceptor.redirectFile, err = ioutil.TempFile("", "ginkgo-output")
+	if err != nil {
+		return err
+	}
+
+	// Windows has no dup2: redirect the console handles, which subprocesses inherit, and the os.Stdout and os.Stderr
+	// files, which the Go runtime resolved when the process started.  Swapping the os.Stdout and os.Stderr globals is
+	// not synchronized with the goroutines that read them: one still running from a previous spec, or holding on to
+	// the file it read, may write outside of the interception.  Only one interceptor may intercept at a time.
+	interceptor.stdout, interceptor.stderr = os.Stdout, os.Stderr
+	handle := windows.Handle(interceptor.redirectFile.Fd())
+	windows.SetStdHandle(windows.STD_OUTPUT_HANDLE, handle)
+	windows.SetStdHandle(windows.STD_ERROR_HANDLE, handle)
+	os.Stdout, os.Stderr = interceptor.redirectFile, interceptor.redirectFile
 	interceptor.intercepting = true
 
-	// not working on windows...
+	if interceptor.streamTarget != nil {
+		interceptor.tailer, _ = tail.TailFile(interceptor.redirectFile.Name(), tail.Config{Follow: true})
+		interceptor.doneTailing = make(chan bool)
+
+		go func() {
+			for line := range interceptor.tailer.Lines {
+				interceptor.streamTarget.Write([]byte(line.Text + "\n"))
+			}
+			close(interceptor.doneTailing)
+		}()
+	}
 
 	return nil
 }
 
 func (interceptor *outputInterceptor) StopInterceptingAndReturnOutput() (string, error) {
-	// not working on windows...
+	if !interceptor.intercepting {
+		return "", errors.New("Not intercepting output!")
+	}
+
+	windows.SetStdHandle(windows.STD_OUTPUT_HANDLE, windows.Handle(interceptor.stdout.Fd()))
+	windows.SetStdHandle(windows.STD_ERROR_HANDLE, windows.Handle(interceptor.stderr.Fd()))
+	os.Stdout, os.Stderr = interceptor.stdout, interceptor.stderr
+
+	interceptor.redirectFile.Close()
+
+	// Windows does not remove files that are still open, so stop tailing first
+	if interceptor.streamTarget != nil {
+		interceptor.tailer.Stop()
+		interceptor.tailer.Cleanup()
+		<-interceptor.doneTailing
+		interceptor.streamTarget.Sync()
+	}
+
+	output, err := ioutil.ReadFile(interceptor.redirectFile.Name())
+	os.Remove(interceptor.redirectFile.Name())
+
 	interceptor.intercepting = false
 
-	return "", nil
+	return string(output), err
 }
 
-func (interceptor *outputInterceptor) StreamTo(*os.File) {}
+//Offset asks where the file stdout and stderr are redirected to is at: their handles share its file pointer
+func (interceptor *outputInterceptor) Offset() int64 {
+	if !interceptor.intercepting {
+		return -1
+	}
+	offset, err := interceptor.redirectFile.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return -1
+	}
+	return offset
+}
+
+func (interceptor *outputInterceptor) StreamTo(out *os.File) {
+	interceptor.streamTarget = out
+}
@@ -12,9 +12,15 @@ import (
 	"io/ioutil"
 	"net"
 	"net/http"
+	"strconv"
 	"sync"
+	"time"
 
+	"github.com/onsi/ginkgo/internal/aggregatedreport"
+	"github.com/onsi/ginkgo/internal/allocation"
+	"github.com/onsi/ginkgo/internal/parallelkv"
 	"github.com/onsi/ginkgo/internal/spec_iterator"
+	"github.com/onsi/ginkgo/internal/speclease"
 
 	"github.com/onsi/ginkgo/config"
 	"github.com/onsi/ginkgo/reporters"
@@ -32,7 +38,15 @@ type Server struct {
 	lock            *sync.Mutex
 	beforeSuiteData types.RemoteBeforeSuiteData
 	parallelTotal   int
-	counter         int
+	leases          *speclease.Ledger
+	resourceLock    *sync.Mutex
+	resourceHolders map[string]int
+	allocator       *allocation.Allocator
+	keyValues       *parallelkv.Store
+	abortedBy       int
+	abortMessage    string
+	specOutcomes    map[string]types.RemoteSpecOutcome
+	aggregated      *aggregatedreport.Collector
 }
 
 //Create a new server, automatically selecting a port
@@ -41,12 +55,21 @@ func NewServer(parallelTotal int) (*Server, error) {
 	if err != nil {
 		return nil, err
 	}
+	aggregated := aggregatedreport.NewCollector()
 	return &Server{
 		listener:        listener,
+		reporters:       []reporters.Reporter{aggregated},
 		lock:            &sync.Mutex{},
 		alives:          make([]func() bool, parallelTotal),
 		beforeSuiteData: types.RemoteBeforeSuiteData{Data: nil, State: types.RemoteBeforeSuiteStatePending},
 		parallelTotal:   parallelTotal,
+		leases:          speclease.NewLedger(0, nil),
+		resourceLock:    &sync.Mutex{},
+		resourceHolders: map[string]int{},
+		allocator:       allocation.NewAllocator(),
+		keyValues:       parallelkv.NewStore(),
+		specOutcomes:    map[string]types.RemoteSpecOutcome{},
+		aggregated:      aggregated,
 	}, nil
 }
 
@@ -68,6 +91,14 @@ func (server *Server) Start() {
 	mux.HandleFunc("/BeforeSuiteState", server.handleBeforeSuiteState)
 	mux.HandleFunc("/RemoteAfterSuiteData", server.handleRemoteAfterSuiteData)
 	mux.HandleFunc("/counter", server.handleCounter)
+	mux.HandleFunc("/SpecLease", server.handleSpecLease)
+	mux.HandleFunc("/ResourceLock", server.handleResourceLock)
+	mux.HandleFunc("/Port", server.handlePort)
+	mux.HandleFunc("/UniqueName", server.handleUniqueName)
+	mux.HandleFunc("/KeyValue", server.handleKeyValue)
+	mux.HandleFunc("/Abort", server.handleAbort)
+	mux.HandleFunc("/SpecOutcome", server.handleSpecOutcome)
+	mux.HandleFunc("/AggregatedReport", server.handleAggregatedReport)
 	mux.HandleFunc("/has-counter", server.handleHasCounter) //for backward compatibility
 
 	go httpServer.Serve(server.listener)
@@ -94,8 +125,15 @@ func (server *Server) readAll(request *http.Request) []byte {
 	return body
 }
 
+//SetSpecLeaseTimeout makes the nodes take leases on the specs they claim from /counter: the spec of a node that neither
+//completes it nor heartbeats within timeout, or that is no longer alive, is handed to the next node claiming a spec.  The
+//attempts of such specs are recorded as abandoned in the summaries of the suite.  Call it before starting the server.
+func (server *Server) SetSpecLeaseTimeout(timeout time.Duration) {
+	server.leases = speclease.NewLedger(timeout, server.nodeIsAlive)
+}
+
 func (server *Server) RegisterReporters(reporters ...reporters.Reporter) {
-	server.reporters = reporters
+	server.reporters = append(reporters, server.aggregated)
 }
 
 func (server *Server) specSuiteWillBegin(writer http.ResponseWriter, request *http.Request) {
@@ -157,6 +195,7 @@ func (server *Server) specSuiteDidEnd(writer http.ResponseWriter, request *http.
 	body := server.readAll(request)
 	var suiteSummary *types.SuiteSummary
 	json.Unmarshal(body, &suiteSummary)
+	suiteSummary.AbandonedSpecAttempts = server.leases.Abandoned()
 
 	for _, reporter := range server.reporters {
 		reporter.SpecSuiteDidEnd(suiteSummary)
@@ -209,16 +248,156 @@ func (server *Server) handleRemoteAfterSuiteData(writer http.ResponseWriter, req
 	enc.Encode(afterSuiteData)
 }
 
+//handleCounter hands the node passed as ?node= the index of the next spec to run among the ?total= specs free to run on
+//any node, see speclease.Ledger.Claim.  Nodes that pass neither merely count.
 func (server *Server) handleCounter(writer http.ResponseWriter, request *http.Request) {
-	c := spec_iterator.Counter{}
+	node, _ := strconv.Atoi(request.URL.Query().Get("node"))
+	total, _ := strconv.Atoi(request.URL.Query().Get("total"))
+
 	server.lock.Lock()
-	c.Index = server.counter
-	server.counter++
+	aborted := server.abortMessage != ""
 	server.lock.Unlock()
 
+	c := spec_iterator.Counter{}
+	if aborted {
+		//a spec aborted the suite: hand out no more specs
+		c.Index = total
+		json.NewEncoder(writer).Encode(c)
+		return
+	}
+	c.Index, c.Wait = server.leases.Claim(node, total)
+	if node != 0 {
+		c.HeartbeatInterval = server.leases.HeartbeatInterval()
+	}
+
 	json.NewEncoder(writer).Encode(c)
 }
 
+//handleSpecLease extends the lease a node holds on the spec it runs, and tells whether it still holds it
+func (server *Server) handleSpecLease(writer http.ResponseWriter, request *http.Request) {
+	var lease types.RemoteSpecLease
+	err := json.NewDecoder(request.Body).Decode(&lease)
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	held := server.leases.Heartbeat(lease.Node, lease.Index, lease.Text)
+	json.NewEncoder(writer).Encode(types.RemoteSpecLeaseState{Held: held})
+}
+
+//handleAbort records the first node that aborts the suite when POSTed to, and returns it when GETted.  Once a spec aborted
+//the suite with a message, /counter hands out no more specs.
+func (server *Server) handleAbort(writer http.ResponseWriter, request *http.Request) {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+
+	if request.Method == http.MethodPost {
+		var abort types.RemoteAbort
+		err := json.NewDecoder(request.Body).Decode(&abort)
+		if err != nil || abort.Node < 1 || abort.Node > server.parallelTotal {
+			writer.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if server.abortedBy == 0 {
+			server.abortedBy = abort.Node
+		}
+		if server.abortMessage == "" {
+			server.abortMessage = abort.Message
+		}
+	}
+
+	json.NewEncoder(writer).Encode(types.RemoteAbort{Node: server.abortedBy, Message: server.abortMessage})
+}
+
+//handleSpecOutcome records the outcome of a spec other specs depend on when POSTed to, and returns it when GETted
+func (server *Server) handleSpecOutcome(writer http.ResponseWriter, request *http.Request) {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+
+	if request.Method == http.MethodPost {
+		var outcome types.RemoteSpecOutcome
+		err := json.NewDecoder(request.Body).Decode(&outcome)
+		if err != nil || outcome.Key == "" {
+			writer.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		server.specOutcomes[outcome.Key] = outcome
+		return
+	}
+
+	outcome, known := server.specOutcomes[request.URL.Query().Get("key")]
+	if !known {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	json.NewEncoder(writer).Encode(outcome)
+}
+
+//handleAggregatedReport returns the report of the specs the nodes have completed so far
+func (server *Server) handleAggregatedReport(writer http.ResponseWriter, request *http.Request) {
+	json.NewEncoder(writer).Encode(server.aggregated.Report())
+}
+
+//handleResourceLock acquires or releases a resource on behalf of a node.  A resource held by a node that is no longer
+//alive is handed over to the next node asking for it.
+func (server *Server) handleResourceLock(writer http.ResponseWriter, request *http.Request) {
+	var lock types.RemoteResourceLock
+	err := json.NewDecoder(request.Body).Decode(&lock)
+	if err != nil || lock.Node < 1 || lock.Node > server.parallelTotal {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	server.resourceLock.Lock()
+	defer server.resourceLock.Unlock()
+
+	state := types.RemoteResourceLockState{}
+	holder, held := server.resourceHolders[lock.Resource]
+	if lock.Release {
+		if held && holder == lock.Node {
+			delete(server.resourceHolders, lock.Resource)
+		}
+	} else if !held || holder == lock.Node || !server.nodeIsAlive(holder) {
+		server.resourceHolders[lock.Resource] = lock.Node
+		state.Acquired = true
+	}
+
+	json.NewEncoder(writer).Encode(state)
+}
+
+func (server *Server) handlePort(writer http.ResponseWriter, request *http.Request) {
+	port, err := server.allocator.Port()
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	json.NewEncoder(writer).Encode(types.RemoteAllocation{Port: port})
+}
+
+func (server *Server) handleUniqueName(writer http.ResponseWriter, request *http.Request) {
+	name := server.allocator.UniqueName(request.URL.Query().Get("prefix"))
+
+	json.NewEncoder(writer).Encode(types.RemoteAllocation{Name: name})
+}
+
+func (server *Server) handleKeyValue(writer http.ResponseWriter, request *http.Request) {
+	if request.Method == "POST" {
+		var keyValue types.RemoteKeyValue
+		err := json.NewDecoder(request.Body).Decode(&keyValue)
+		if err != nil {
+			writer.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		server.keyValues.Set(keyValue.Key, keyValue.Value)
+	} else {
+		key := request.URL.Query().Get("key")
+		value, found := server.keyValues.Get(key)
+		json.NewEncoder(writer).Encode(types.RemoteKeyValue{Key: key, Value: value, Found: found})
+	}
+}
+
 func (server *Server) handleHasCounter(writer http.ResponseWriter, request *http.Request) {
 	writer.Write([]byte(""))
 }
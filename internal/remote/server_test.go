@@ -6,12 +6,14 @@ import (
 	. "github.com/onsi/gomega"
 
 	"github.com/onsi/ginkgo/config"
+	"github.com/onsi/ginkgo/internal/spec_iterator"
 	"github.com/onsi/ginkgo/reporters"
 	"github.com/onsi/ginkgo/types"
 
 	"bytes"
 	"encoding/json"
 	"net/http"
+	"net/url"
 )
 
 var _ = Describe("Server", func() {
@@ -265,5 +267,170 @@ var _ = Describe("Server", func() {
 
 			})
 		})
+		Describe("Abort", func() {
+			decodeAbort := func(resp *http.Response, err error) int {
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(resp.StatusCode).Should(Equal(http.StatusOK))
+				abort := types.RemoteAbort{}
+				Ω(json.NewDecoder(resp.Body).Decode(&abort)).Should(Succeed())
+				return abort.Node
+			}
+			postAbort := func(node int) int {
+				return decodeAbort(http.Post(server.Address()+"/Abort", "application/json", bytes.NewReader(types.RemoteAbort{Node: node}.ToJSON())))
+			}
+
+			It("should report the first node that aborted the suite", func() {
+				Ω(decodeAbort(http.Get(server.Address() + "/Abort"))).Should(Equal(0))
+				Ω(postAbort(2)).Should(Equal(2))
+				Ω(postAbort(1)).Should(Equal(2))
+				Ω(decodeAbort(http.Get(server.Address() + "/Abort"))).Should(Equal(2))
+			})
+
+			It("should hand out no more specs once a spec aborted the suite with a message", func() {
+				claim := func() spec_iterator.Counter {
+					resp, err := http.Get(server.Address() + "/counter?node=1&total=5")
+					Ω(err).ShouldNot(HaveOccurred())
+					counter := spec_iterator.Counter{}
+					Ω(json.NewDecoder(resp.Body).Decode(&counter)).Should(Succeed())
+					return counter
+				}
+
+				Ω(claim().Index).Should(Equal(0))
+				Ω(postAbort(2)).Should(Equal(2))
+				Ω(claim().Index).Should(Equal(1))
+
+				resp, err := http.Post(server.Address()+"/Abort", "application/json", bytes.NewReader(types.RemoteAbort{Node: 1, Message: "the database is gone"}.ToJSON()))
+				Ω(err).ShouldNot(HaveOccurred())
+				abort := types.RemoteAbort{}
+				Ω(json.NewDecoder(resp.Body).Decode(&abort)).Should(Succeed())
+				Ω(abort).Should(Equal(types.RemoteAbort{Node: 2, Message: "the database is gone"}))
+				Ω(claim().Index).Should(Equal(5))
+			})
+
+			It("should reject unknown nodes", func() {
+				resp, err := http.Post(server.Address()+"/Abort", "application/json", bytes.NewReader(types.RemoteAbort{Node: 17}.ToJSON()))
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(resp.StatusCode).Should(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Describe("POSTing ResourceLock", func() {
+			postResourceLock := func(lock types.RemoteResourceLock) bool {
+				resp, err := http.Post(server.Address()+"/ResourceLock", "application/json", bytes.NewReader(lock.ToJSON()))
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(resp.StatusCode).Should(Equal(http.StatusOK))
+
+				state := types.RemoteResourceLockState{}
+				err = json.NewDecoder(resp.Body).Decode(&state)
+				Ω(err).ShouldNot(HaveOccurred())
+				return state.Acquired
+			}
+
+			BeforeEach(func() {
+				server.RegisterAlive(1, func() bool {
+					return true
+				})
+			})
+
+			It("should hand a resource to one node at a time", func() {
+				Ω(postResourceLock(types.RemoteResourceLock{Resource: "gpu-0", Node: 1})).Should(BeTrue())
+				Ω(postResourceLock(types.RemoteResourceLock{Resource: "gpu-0", Node: 1})).Should(BeTrue())
+				Ω(postResourceLock(types.RemoteResourceLock{Resource: "gpu-0", Node: 2})).Should(BeFalse())
+				Ω(postResourceLock(types.RemoteResourceLock{Resource: "gpu-1", Node: 2})).Should(BeTrue())
+
+				By("ignoring releases from other nodes")
+				postResourceLock(types.RemoteResourceLock{Resource: "gpu-0", Node: 2, Release: true})
+				Ω(postResourceLock(types.RemoteResourceLock{Resource: "gpu-0", Node: 3})).Should(BeFalse())
+
+				postResourceLock(types.RemoteResourceLock{Resource: "gpu-0", Node: 1, Release: true})
+				Ω(postResourceLock(types.RemoteResourceLock{Resource: "gpu-0", Node: 3})).Should(BeTrue())
+			})
+
+			It("should hand over a resource held by a node that is no longer alive", func() {
+				Ω(postResourceLock(types.RemoteResourceLock{Resource: "gpu-0", Node: 1})).Should(BeTrue())
+				server.RegisterAlive(1, func() bool {
+					return false
+				})
+				Ω(postResourceLock(types.RemoteResourceLock{Resource: "gpu-0", Node: 2})).Should(BeTrue())
+			})
+
+			It("should reject requests from unknown nodes", func() {
+				resp, err := http.Post(server.Address()+"/ResourceLock", "application/json", bytes.NewReader(types.RemoteResourceLock{Resource: "gpu-0", Node: 4}.ToJSON()))
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(resp.StatusCode).Should(Equal(http.StatusBadRequest))
+			})
+		})
+		Describe("GETting Port and UniqueName", func() {
+			getAllocation := func(path string) types.RemoteAllocation {
+				resp, err := http.Get(server.Address() + path)
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(resp.StatusCode).Should(Equal(http.StatusOK))
+
+				allocation := types.RemoteAllocation{}
+				err = json.NewDecoder(resp.Body).Decode(&allocation)
+				Ω(err).ShouldNot(HaveOccurred())
+				return allocation
+			}
+
+			It("should hand out a different port on each request", func() {
+				port := getAllocation("/Port").Port
+				Ω(port).Should(BeNumerically(">", 0))
+				Ω(getAllocation("/Port").Port).ShouldNot(Equal(port))
+			})
+
+			It("should hand out a different name on each request", func() {
+				name := getAllocation("/UniqueName?prefix=books").Name
+				Ω(name).Should(HavePrefix("books-"))
+				Ω(getAllocation("/UniqueName?prefix=books").Name).ShouldNot(Equal(name))
+			})
+		})
+		Describe("POSTing and GETting SpecOutcome", func() {
+			It("should return the outcome posted for the spec, or 404 until it is posted", func() {
+				resp, err := http.Get(server.Address() + "/SpecOutcome?key=" + url.QueryEscape("creates the account@a_test.go:3"))
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(resp.StatusCode).Should(Equal(http.StatusNotFound))
+
+				outcome := types.RemoteSpecOutcome{Key: "creates the account@a_test.go:3", FullText: "creates the account", State: types.SpecStateFailed}
+				resp, err = http.Post(server.Address()+"/SpecOutcome", "application/json", bytes.NewReader(outcome.ToJSON()))
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(resp.StatusCode).Should(Equal(http.StatusOK))
+
+				resp, err = http.Get(server.Address() + "/SpecOutcome?key=" + url.QueryEscape("creates the account@a_test.go:3"))
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(resp.StatusCode).Should(Equal(http.StatusOK))
+				received := types.RemoteSpecOutcome{}
+				Ω(json.NewDecoder(resp.Body).Decode(&received)).Should(Succeed())
+				Ω(received).Should(Equal(outcome))
+			})
+
+			It("should reject outcomes without a key", func() {
+				resp, err := http.Post(server.Address()+"/SpecOutcome", "application/json", bytes.NewReader(types.RemoteSpecOutcome{}.ToJSON()))
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(resp.StatusCode).Should(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Describe("POSTing and GETting KeyValue", func() {
+			getKeyValue := func(key string) types.RemoteKeyValue {
+				resp, err := http.Get(server.Address() + "/KeyValue?key=" + key)
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(resp.StatusCode).Should(Equal(http.StatusOK))
+
+				keyValue := types.RemoteKeyValue{}
+				err = json.NewDecoder(resp.Body).Decode(&keyValue)
+				Ω(err).ShouldNot(HaveOccurred())
+				return keyValue
+			}
+
+			It("should return the value set for the key", func() {
+				Ω(getKeyValue("token")).Should(Equal(types.RemoteKeyValue{Key: "token"}))
+
+				resp, err := http.Post(server.Address()+"/KeyValue", "application/json", bytes.NewReader(types.RemoteKeyValue{Key: "token", Value: []byte("abc")}.ToJSON()))
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(resp.StatusCode).Should(Equal(http.StatusOK))
+
+				Ω(getKeyValue("token")).Should(Equal(types.RemoteKeyValue{Key: "token", Value: []byte("abc"), Found: true}))
+			})
+		})
 	})
 })
@@ -0,0 +1,79 @@
+/*
+Package reportermanager multiplexes the events of a suite run to its reporters.  Each reporter follows the reporter
+configuration of the flags, unless it was given its own with reporters.WithReporterConfig: the manager then configures
+it with that configuration, so that e.g. the console stays succinct while a JUnit report keeps the output of passed
+specs.
+*/
+package reportermanager
+
+import (
+	"github.com/onsi/ginkgo/config"
+	"github.com/onsi/ginkgo/reporters"
+	"github.com/onsi/ginkgo/types"
+)
+
+//Manager hands the events of a suite run to its reporters, in order
+type Manager struct {
+	reporters []reporters.Reporter
+}
+
+//New returns the manager of reporterList.  The reporters given their own configuration with reporters.WithReporterConfig
+//are configured with it right away.
+func New(reporterList []reporters.Reporter) *Manager {
+	manager := &Manager{}
+	for _, reporter := range reporterList {
+		if configured, ok := reporter.(reporters.ConfiguredReporter); ok {
+			configured.SetReporterConfig(configured.ReporterConfig)
+			reporter = configured.ConfigurableReporter
+		}
+		manager.reporters = append(manager.reporters, reporter)
+	}
+	return manager
+}
+
+//Reporters returns the reporters of the manager, without the configuration they were given
+func (manager *Manager) Reporters() []reporters.Reporter {
+	return manager.reporters
+}
+
+func (manager *Manager) SpecSuiteWillBegin(ginkgoConfig config.GinkgoConfigType, summary *types.SuiteSummary) {
+	for _, reporter := range manager.reporters {
+		reporter.SpecSuiteWillBegin(ginkgoConfig, summary)
+	}
+}
+
+func (manager *Manager) BeforeSuiteDidRun(setupSummary *types.SetupSummary) {
+	for _, reporter := range manager.reporters {
+		reporter.BeforeSuiteDidRun(setupSummary)
+	}
+}
+
+func (manager *Manager) SpecWillRun(specSummary *types.SpecSummary) {
+	for _, reporter := range manager.reporters {
+		reporter.SpecWillRun(specSummary)
+	}
+}
+
+//SpecDidComplete hands specSummary to the reporters, the first one last: it is the console reporter, whose report of the
+//spec must follow what beforeFirst prints, such as the output a failed spec wrote to GinkgoWriter
+func (manager *Manager) SpecDidComplete(specSummary *types.SpecSummary, beforeFirst func()) {
+	for i := len(manager.reporters) - 1; i >= 1; i-- {
+		manager.reporters[i].SpecDidComplete(specSummary)
+	}
+	beforeFirst()
+	if len(manager.reporters) > 0 {
+		manager.reporters[0].SpecDidComplete(specSummary)
+	}
+}
+
+func (manager *Manager) AfterSuiteDidRun(setupSummary *types.SetupSummary) {
+	for _, reporter := range manager.reporters {
+		reporter.AfterSuiteDidRun(setupSummary)
+	}
+}
+
+func (manager *Manager) SpecSuiteDidEnd(summary *types.SuiteSummary) {
+	for _, reporter := range manager.reporters {
+		reporter.SpecSuiteDidEnd(summary)
+	}
+}
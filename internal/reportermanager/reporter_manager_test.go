@@ -0,0 +1,93 @@
+package reportermanager_test
+
+import (
+	"encoding/xml"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/onsi/ginkgo/config"
+	"github.com/onsi/ginkgo/internal/reportermanager"
+	"github.com/onsi/ginkgo/reporters"
+	"github.com/onsi/ginkgo/types"
+)
+
+var _ = Describe("Manager", func() {
+	var first, second *reporters.FakeReporter
+
+	BeforeEach(func() {
+		first = reporters.NewFakeReporter()
+		second = reporters.NewFakeReporter()
+	})
+
+	It("should hand every event to every reporter", func() {
+		manager := reportermanager.New([]reporters.Reporter{first, second})
+		manager.SpecSuiteWillBegin(config.GinkgoConfigType{RandomSeed: 3}, &types.SuiteSummary{SuiteDescription: "suite"})
+		manager.BeforeSuiteDidRun(&types.SetupSummary{})
+		manager.SpecWillRun(&types.SpecSummary{})
+		manager.SpecDidComplete(&types.SpecSummary{}, func() {})
+		manager.AfterSuiteDidRun(&types.SetupSummary{})
+		manager.SpecSuiteDidEnd(&types.SuiteSummary{SuiteSucceeded: true})
+
+		for _, reporter := range []*reporters.FakeReporter{first, second} {
+			Ω(reporter.Config.RandomSeed).Should(Equal(int64(3)))
+			Ω(reporter.BeginSummary.SuiteDescription).Should(Equal("suite"))
+			Ω(reporter.BeforeSuiteSummary).ShouldNot(BeNil())
+			Ω(reporter.SpecWillRunSummaries).Should(HaveLen(1))
+			Ω(reporter.SpecSummaries).Should(HaveLen(1))
+			Ω(reporter.AfterSuiteSummary).ShouldNot(BeNil())
+			Ω(reporter.EndSummary.SuiteSucceeded).Should(BeTrue())
+		}
+	})
+
+	It("should hand a completed spec to the first reporter last, after beforeFirst", func() {
+		events := []string{}
+		first.SpecDidCompleteStub = func(*types.SpecSummary) { events = append(events, "first") }
+		second.SpecDidCompleteStub = func(*types.SpecSummary) { events = append(events, "second") }
+
+		manager := reportermanager.New([]reporters.Reporter{first, second})
+		manager.SpecDidComplete(&types.SpecSummary{}, func() { events = append(events, "beforeFirst") })
+		Ω(events).Should(Equal([]string{"second", "beforeFirst", "first"}))
+	})
+
+	Describe("reporters with their own configuration", func() {
+		It("should configure them, and hand the events to them without their configuration", func() {
+			manager := reportermanager.New([]reporters.Reporter{first, reporters.WithReporterConfig(second, config.DefaultReporterConfigType{Verbose: true})})
+			Ω(manager.Reporters()).Should(Equal([]reporters.Reporter{first, second}))
+			Ω(first.ReporterConfig.Verbose).Should(BeFalse())
+			Ω(second.ReporterConfig.Verbose).Should(BeTrue())
+		})
+
+		It("should keep their configuration over the one of the flags", func() {
+			dir, err := ioutil.TempDir("", "reporter-manager")
+			Ω(err).ShouldNot(HaveOccurred())
+			defer os.RemoveAll(dir)
+
+			junitConfig := config.DefaultReporterConfig
+			junitConfig.ReportPassed = true
+			manager := reportermanager.New([]reporters.Reporter{
+				reporters.WithReporterConfig(reporters.NewJUnitReporter(filepath.Join(dir, "junit.xml")), junitConfig),
+				reporters.NewJUnitReporter(filepath.Join(dir, "flags.xml")),
+			})
+
+			passed := &types.SpecSummary{ComponentTexts: []string{"[Top Level]", "passes"}, State: types.SpecStatePassed, CapturedOutput: "some output"}
+			manager.SpecSuiteWillBegin(config.GinkgoConfigType{}, &types.SuiteSummary{SuiteDescription: "suite"})
+			manager.SpecWillRun(passed)
+			manager.SpecDidComplete(passed, func() {})
+			manager.SpecSuiteDidEnd(&types.SuiteSummary{SuiteDescription: "suite", SuiteSucceeded: true})
+
+			read := func(name string) reporters.JUnitTestSuite {
+				data, err := ioutil.ReadFile(filepath.Join(dir, name))
+				Ω(err).ShouldNot(HaveOccurred())
+				suite := reporters.JUnitTestSuite{}
+				Ω(xml.Unmarshal(data, &suite)).Should(Succeed())
+				return suite
+			}
+			Ω(read("junit.xml").TestCases[0].SystemOut).Should(Equal("some output"))
+			Ω(read("flags.xml").TestCases[0].SystemOut).Should(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,13 @@
+package reportermanager_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestReporterManager(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Reporter Manager Suite")
+}
@@ -0,0 +1,180 @@
+/*
+Package resourcelock serializes the specs that contend on an external resource across parallel nodes.
+
+Resources are identified by name.  When running in parallel, a node acquires a resource by polling the /ResourceLock
+endpoint of the server run by the Ginkgo CLI until it is granted.  A node runs one spec at a time, so when running
+serially there is nothing to contend with and acquiring a resource always succeeds immediately.
+*/
+package resourcelock
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/onsi/ginkgo/types"
+)
+
+//PollingInterval is how long a node waits before asking again for a resource held by another node
+var PollingInterval = 50 * time.Millisecond
+
+type declaration struct {
+	resource     string
+	codeLocation types.CodeLocation
+}
+
+type Locker struct {
+	lock         *sync.Mutex
+	declarations []declaration
+	parallelNode int
+	syncHost     string
+	client       *http.Client
+	held         map[string]int
+}
+
+func New() *Locker {
+	return &Locker{
+		lock:   &sync.Mutex{},
+		client: &http.Client{},
+		held:   map[string]int{},
+	}
+}
+
+//Connect makes the locker acquire resources from the server at syncHost, on behalf of parallelNode
+func (l *Locker) Connect(parallelNode int, syncHost string) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.parallelNode = parallelNode
+	l.syncHost = syncHost
+}
+
+//DeclareAt records that the container or spec at codeLocation requires the given resources
+func (l *Locker) DeclareAt(codeLocation types.CodeLocation, resources ...string) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	for _, resource := range resources {
+		l.declarations = append(l.declarations, declaration{resource, codeLocation})
+	}
+}
+
+//RequiredBy returns, sorted, the resources required by the containers or the subject of a spec, given their code locations
+func (l *Locker) RequiredBy(componentCodeLocations []types.CodeLocation) []string {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	resources := []string{}
+	for _, declaration := range l.declarations {
+		for _, componentCodeLocation := range componentCodeLocations {
+			if componentCodeLocation.FileName == declaration.codeLocation.FileName && componentCodeLocation.LineNumber == declaration.codeLocation.LineNumber {
+				resources = append(resources, declaration.resource)
+				break
+			}
+		}
+	}
+	return uniqueSorted(resources)
+}
+
+//Lock blocks until the node holds all the given resources.  They are acquired in order, so that nodes locking several
+//resources do not deadlock.  Locking is reentrant: a resource is released once it has been unlocked as many times as
+//it was locked.
+func (l *Locker) Lock(resources ...string) error {
+	resources = uniqueSorted(resources)
+	for i, resource := range resources {
+		err := l.acquire(resource)
+		if err != nil {
+			l.Unlock(resources[:i]...)
+			return err
+		}
+	}
+	return nil
+}
+
+//Unlock releases the given resources
+func (l *Locker) Unlock(resources ...string) error {
+	var firstErr error
+	for _, resource := range uniqueSorted(resources) {
+		err := l.release(resource)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func (l *Locker) acquire(resource string) error {
+	l.lock.Lock()
+	mustAcquire := l.held[resource] == 0 && l.syncHost != ""
+	l.lock.Unlock()
+
+	for mustAcquire {
+		state, err := l.post(types.RemoteResourceLock{Resource: resource, Node: l.parallelNode})
+		if err != nil {
+			return fmt.Errorf("Failed to lock resource %q: %s", resource, err.Error())
+		}
+		if state.Acquired {
+			break
+		}
+		time.Sleep(PollingInterval)
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.held[resource]++
+	return nil
+}
+
+func (l *Locker) release(resource string) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.held[resource] == 0 {
+		return nil
+	}
+	l.held[resource]--
+	if l.held[resource] > 0 {
+		return nil
+	}
+	delete(l.held, resource)
+
+	if l.syncHost != "" {
+		_, err := l.post(types.RemoteResourceLock{Resource: resource, Node: l.parallelNode, Release: true})
+		if err != nil {
+			return fmt.Errorf("Failed to unlock resource %q: %s", resource, err.Error())
+		}
+	}
+	return nil
+}
+
+func (l *Locker) post(lock types.RemoteResourceLock) (types.RemoteResourceLockState, error) {
+	state := types.RemoteResourceLockState{}
+	resp, err := l.client.Post(l.syncHost+"/ResourceLock", "application/json", bytes.NewBuffer(lock.ToJSON()))
+	if err != nil {
+		return state, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return state, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	err = json.NewDecoder(resp.Body).Decode(&state)
+	return state, err
+}
+
+func uniqueSorted(resources []string) []string {
+	seen := map[string]bool{}
+	unique := []string{}
+	for _, resource := range resources {
+		if !seen[resource] {
+			seen[resource] = true
+			unique = append(unique, resource)
+		}
+	}
+	sort.Strings(unique)
+	return unique
+}
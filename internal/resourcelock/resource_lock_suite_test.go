@@ -0,0 +1,13 @@
+package resourcelock_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestResourceLock(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "ResourceLock Suite")
+}
@@ -0,0 +1,106 @@
+package resourcelock_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/onsi/ginkgo/internal/remote"
+	. "github.com/onsi/ginkgo/internal/resourcelock"
+	"github.com/onsi/ginkgo/types"
+)
+
+var _ = Describe("Locker", func() {
+	Describe("declarations", func() {
+		It("should return the resources declared by the given code locations, sorted and without duplicates", func() {
+			locker := New()
+			locker.DeclareAt(types.CodeLocation{FileName: "gpu_test.go", LineNumber: 3}, "gpu-1", "gpu-0")
+			locker.DeclareAt(types.CodeLocation{FileName: "gpu_test.go", LineNumber: 8}, "gpu-0")
+			locker.DeclareAt(types.CodeLocation{FileName: "db_test.go", LineNumber: 3}, "db")
+
+			Ω(locker.RequiredBy([]types.CodeLocation{
+				{FileName: "gpu_test.go", LineNumber: 3, FullStackTrace: "a stack"},
+				{FileName: "gpu_test.go", LineNumber: 8},
+			})).Should(Equal([]string{"gpu-0", "gpu-1"}))
+			Ω(locker.RequiredBy([]types.CodeLocation{{FileName: "gpu_test.go", LineNumber: 4}})).Should(BeEmpty())
+		})
+	})
+
+	Context("when running serially", func() {
+		It("should lock and unlock right away", func() {
+			locker := New()
+			Ω(locker.Lock("gpu-0", "gpu-1")).Should(Succeed())
+			Ω(locker.Lock("gpu-0")).Should(Succeed())
+			Ω(locker.Unlock("gpu-0")).Should(Succeed())
+			Ω(locker.Unlock("gpu-0", "gpu-1")).Should(Succeed())
+		})
+	})
+
+	Context("when running in parallel", func() {
+		var (
+			server           *remote.Server
+			nodeOne, nodeTwo *Locker
+		)
+
+		BeforeEach(func() {
+			var err error
+			server, err = remote.NewServer(2)
+			Ω(err).ShouldNot(HaveOccurred())
+			server.Start()
+
+			PollingInterval = time.Millisecond
+			nodeOne = New()
+			nodeOne.Connect(1, server.Address())
+			nodeTwo = New()
+			nodeTwo.Connect(2, server.Address())
+		})
+
+		AfterEach(func() {
+			PollingInterval = 50 * time.Millisecond
+			server.Close()
+		})
+
+		It("should block until the resource is released by the other node", func() {
+			Ω(nodeOne.Lock("gpu-0")).Should(Succeed())
+
+			locked := make(chan bool)
+			go func() {
+				defer GinkgoRecover()
+				Ω(nodeTwo.Lock("gpu-0")).Should(Succeed())
+				close(locked)
+			}()
+			Consistently(locked, 50*time.Millisecond).ShouldNot(BeClosed())
+
+			Ω(nodeOne.Unlock("gpu-0")).Should(Succeed())
+			Eventually(locked).Should(BeClosed())
+		})
+
+		It("should not block on other resources", func() {
+			Ω(nodeOne.Lock("gpu-0")).Should(Succeed())
+			Ω(nodeTwo.Lock("gpu-1")).Should(Succeed())
+		})
+
+		It("should only release a resource once it has been unlocked as many times as it was locked", func() {
+			Ω(nodeOne.Lock("gpu-0")).Should(Succeed())
+			Ω(nodeOne.Lock("gpu-0")).Should(Succeed())
+			Ω(nodeOne.Unlock("gpu-0")).Should(Succeed())
+
+			locked := make(chan bool)
+			go func() {
+				defer GinkgoRecover()
+				Ω(nodeTwo.Lock("gpu-0")).Should(Succeed())
+				close(locked)
+			}()
+			Consistently(locked, 50*time.Millisecond).ShouldNot(BeClosed())
+
+			Ω(nodeOne.Unlock("gpu-0")).Should(Succeed())
+			Eventually(locked).Should(BeClosed())
+		})
+
+		It("should fail to lock when the server cannot be reached", func() {
+			server.Close()
+			Ω(nodeOne.Lock("gpu-0")).Should(MatchError(ContainSubstring(`Failed to lock resource "gpu-0"`)))
+		})
+	})
+})
@@ -0,0 +1,92 @@
+/*
+Package runtimebudget loads the runtime budgets passed to -runtimeBudgets.
+
+A budget file holds one budget per line: a label, <= and the longest the specs with that label may run, as a Go
+duration:
+
+	# test-speed policy
+	unit<=100ms
+	integration <= 30s
+
+Blank lines and lines starting with # are ignored.  A spec with several labels that have a budget gets the tightest of
+their budgets.
+*/
+package runtimebudget
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+type Budget struct {
+	Label string
+	Limit time.Duration
+
+	//Location is the file and line the budget was read from
+	Location string
+}
+
+//Load reads the budget file at path
+func Load(path string) ([]Budget, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Parse(f, path)
+}
+
+//Parse reads a budget file.  name identifies the budget file in the locations of its budgets and in errors.
+func Parse(r io.Reader, name string) ([]Budget, error) {
+	budgets := []Budget{}
+	seen := map[string]string{}
+	scanner := bufio.NewScanner(r)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		location := fmt.Sprintf("%s:%d", name, lineNumber)
+		fields := strings.SplitN(line, "<=", 2)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("%s: expected label<=duration, got %q", location, line)
+		}
+		budget := Budget{Label: strings.TrimSpace(fields[0]), Location: location}
+		if budget.Label == "" {
+			return nil, fmt.Errorf("%s: missing label in %q", location, line)
+		}
+		if previous, ok := seen[budget.Label]; ok {
+			return nil, fmt.Errorf("%s: label %q already has a budget at %s", location, budget.Label, previous)
+		}
+		limit, err := time.ParseDuration(strings.TrimSpace(fields[1]))
+		if err != nil || limit <= 0 {
+			return nil, fmt.Errorf("%s: invalid duration %q, expected a positive duration such as 100ms or 30s", location, strings.TrimSpace(fields[1]))
+		}
+		budget.Limit = limit
+		seen[budget.Label] = location
+		budgets = append(budgets, budget)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return budgets, nil
+}
+
+//For returns the tightest of the budgets of labels, and false when none of labels has a budget
+func For(budgets []Budget, labels []string) (Budget, bool) {
+	var tightest Budget
+	found := false
+	for _, budget := range budgets {
+		for _, label := range labels {
+			if label == budget.Label && (!found || budget.Limit < tightest.Limit) {
+				tightest, found = budget, true
+			}
+		}
+	}
+	return tightest, found
+}
@@ -0,0 +1,13 @@
+package runtimebudget_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestRuntimeBudget(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "RuntimeBudget Suite")
+}
@@ -0,0 +1,80 @@
+package runtimebudget_test
+
+import (
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/internal/runtimebudget"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RuntimeBudget", func() {
+	parse := func(lines ...string) ([]Budget, error) {
+		return Parse(strings.NewReader(strings.Join(lines, "\n")), "budgets.txt")
+	}
+
+	Describe("Parse", func() {
+		It("should read labels and durations, ignoring blank lines and comments", func() {
+			budgets, err := parse(
+				"# test-speed policy",
+				"",
+				"unit<=100ms",
+				"  integration <= 30s ",
+			)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(budgets).Should(Equal([]Budget{
+				{Label: "unit", Limit: 100 * time.Millisecond, Location: "budgets.txt:3"},
+				{Label: "integration", Limit: 30 * time.Second, Location: "budgets.txt:4"},
+			}))
+		})
+
+		It("should reject invalid budgets", func() {
+			_, err := parse("unit=100ms")
+			Ω(err).Should(MatchError(ContainSubstring("budgets.txt:1: expected label<=duration")))
+
+			_, err = parse("<=100ms")
+			Ω(err).Should(MatchError(ContainSubstring("missing label")))
+
+			_, err = parse("unit<=fast")
+			Ω(err).Should(MatchError(ContainSubstring(`invalid duration "fast"`)))
+
+			_, err = parse("unit<=0s")
+			Ω(err).Should(MatchError(ContainSubstring(`invalid duration "0s"`)))
+
+			_, err = parse("unit<=100ms", "unit<=1s")
+			Ω(err).Should(MatchError(ContainSubstring(`budgets.txt:2: label "unit" already has a budget at budgets.txt:1`)))
+		})
+	})
+
+	Describe("For", func() {
+		var budgets []Budget
+
+		BeforeEach(func() {
+			var err error
+			budgets, err = parse("integration<=30s", "unit<=100ms", "db<=1s")
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should return the budget of the label", func() {
+			budget, ok := For(budgets, []string{"fast", "integration"})
+			Ω(ok).Should(BeTrue())
+			Ω(budget.Label).Should(Equal("integration"))
+			Ω(budget.Limit).Should(Equal(30 * time.Second))
+		})
+
+		It("should return the tightest budget when several labels have one", func() {
+			budget, ok := For(budgets, []string{"integration", "db"})
+			Ω(ok).Should(BeTrue())
+			Ω(budget.Label).Should(Equal("db"))
+		})
+
+		It("should return false when no label has a budget", func() {
+			_, ok := For(budgets, []string{"fast"})
+			Ω(ok).Should(BeFalse())
+
+			_, ok = For(budgets, nil)
+			Ω(ok).Should(BeFalse())
+		})
+	})
+})
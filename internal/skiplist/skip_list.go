@@ -0,0 +1,115 @@
+/*
+Package skiplist loads the skip lists passed to -skipFile.
+
+A skip list holds one entry per line.  An entry is a regular expression, matched against specs like the -skip flag,
+optionally followed by an expiry date and a reason, each introduced by a semicolon:
+
+	# known failures
+	Books can be borrowed
+	flaky on arm64 ; expires=2021-06-30 ; reason=ISSUE-123
+
+Blank lines and lines starting with # are ignored.  An entry stops skipping specs once the day of its expiry date is
+over, and produces a warning instead, so that skip lists do not rot silently.
+*/
+package skiplist
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+//dateLayout is the layout of expiry dates
+const dateLayout = "2006-01-02"
+
+type Entry struct {
+	Pattern string
+	Expires time.Time
+	Reason  string
+
+	//Location is the file and line the entry was read from
+	Location string
+}
+
+//Expired tells whether the day the entry expires on is over
+func (entry Entry) Expired(now time.Time) bool {
+	if entry.Expires.IsZero() {
+		return false
+	}
+	return !now.Before(entry.Expires.AddDate(0, 0, 1))
+}
+
+//Load reads the skip list at path
+func Load(path string) ([]Entry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Parse(f, path)
+}
+
+//Parse reads a skip list.  name identifies the skip list in the locations of its entries and in errors.
+func Parse(r io.Reader, name string) ([]Entry, error) {
+	entries := []Entry{}
+	scanner := bufio.NewScanner(r)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		location := fmt.Sprintf("%s:%d", name, lineNumber)
+		fields := strings.Split(line, ";")
+		entry := Entry{Pattern: strings.TrimSpace(fields[0]), Location: location}
+		if _, err := regexp.Compile(entry.Pattern); err != nil {
+			return nil, fmt.Errorf("%s: invalid pattern %q: %s", location, entry.Pattern, err)
+		}
+
+		for _, field := range fields[1:] {
+			components := strings.SplitN(field, "=", 2)
+			key := strings.TrimSpace(components[0])
+			if len(components) != 2 {
+				return nil, fmt.Errorf("%s: expected key=value, got %q", location, strings.TrimSpace(field))
+			}
+			value := strings.TrimSpace(components[1])
+			switch key {
+			case "expires":
+				expires, err := time.ParseInLocation(dateLayout, value, time.Local)
+				if err != nil {
+					return nil, fmt.Errorf("%s: invalid expiry date %q, expected YYYY-MM-DD", location, value)
+				}
+				entry.Expires = expires
+			case "reason":
+				entry.Reason = value
+			default:
+				return nil, fmt.Errorf("%s: unknown key %q, expected expires or reason", location, key)
+			}
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+//Apply returns the patterns of the entries that have not expired, and a warning for each entry that has
+func Apply(entries []Entry, now time.Time) (patterns []string, warnings []string) {
+	for _, entry := range entries {
+		if !entry.Expired(now) {
+			patterns = append(patterns, entry.Pattern)
+			continue
+		}
+		warning := fmt.Sprintf("%s: skip list entry %q expired on %s and no longer skips specs", entry.Location, entry.Pattern, entry.Expires.Format(dateLayout))
+		if entry.Reason != "" {
+			warning += fmt.Sprintf(" (reason: %s)", entry.Reason)
+		}
+		warnings = append(warnings, warning)
+	}
+	return patterns, warnings
+}
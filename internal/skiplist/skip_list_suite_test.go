@@ -0,0 +1,13 @@
+package skiplist_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestSkipList(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "SkipList Suite")
+}
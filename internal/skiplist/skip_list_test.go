@@ -0,0 +1,72 @@
+package skiplist_test
+
+import (
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/internal/skiplist"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SkipList", func() {
+	parse := func(lines ...string) ([]Entry, error) {
+		return Parse(strings.NewReader(strings.Join(lines, "\n")), "known_failures.txt")
+	}
+
+	Describe("Parse", func() {
+		It("should read patterns, expiry dates and reasons, ignoring blank lines and comments", func() {
+			entries, err := parse(
+				"# known failures",
+				"",
+				"Books can be borrowed",
+				"  flaky on (arm64|ppc)  ; expires=2021-06-30 ; reason=ISSUE-123 ",
+			)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(entries).Should(Equal([]Entry{
+				{Pattern: "Books can be borrowed", Location: "known_failures.txt:3"},
+				{Pattern: "flaky on (arm64|ppc)", Expires: time.Date(2021, 6, 30, 0, 0, 0, 0, time.Local), Reason: "ISSUE-123", Location: "known_failures.txt:4"},
+			}))
+		})
+
+		It("should reject invalid entries", func() {
+			_, err := parse("a(")
+			Ω(err).Should(MatchError(ContainSubstring("known_failures.txt:1: invalid pattern")))
+
+			_, err = parse("a ; expires=tomorrow")
+			Ω(err).Should(MatchError(ContainSubstring("invalid expiry date")))
+
+			_, err = parse("a ; owner=me")
+			Ω(err).Should(MatchError(ContainSubstring(`unknown key "owner"`)))
+
+			_, err = parse("a ; ISSUE-123")
+			Ω(err).Should(MatchError(ContainSubstring("expected key=value")))
+		})
+	})
+
+	Describe("Apply", func() {
+		var entries []Entry
+
+		BeforeEach(func() {
+			var err error
+			entries, err = parse(
+				"forever",
+				"until June ; expires=2021-06-30",
+				"until May ; expires=2021-05-31 ; reason=ISSUE-123",
+			)
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should skip with every entry until the end of their expiry day", func() {
+			patterns, warnings := Apply(entries, time.Date(2021, 5, 31, 23, 59, 0, 0, time.Local))
+			Ω(patterns).Should(Equal([]string{"forever", "until June", "until May"}))
+			Ω(warnings).Should(BeEmpty())
+		})
+
+		It("should warn about expired entries instead of skipping with them", func() {
+			patterns, warnings := Apply(entries, time.Date(2021, 6, 1, 0, 0, 0, 0, time.Local))
+			Ω(patterns).Should(Equal([]string{"forever", "until June"}))
+			Ω(warnings).Should(Equal([]string{`known_failures.txt:3: skip list entry "until May" expired on 2021-05-31 and no longer skips specs (reason: ISSUE-123)`}))
+		})
+	})
+})
@@ -0,0 +1,88 @@
+package snapshot
+
+import (
+	"fmt"
+	"strings"
+)
+
+//contextLines is the number of unchanged lines Diff shows around each change
+const contextLines = 3
+
+//Diff returns a line by line diff turning expected into actual.  Removed lines start with "-", added lines with "+" and
+//unchanged lines with a space.  Runs of unchanged lines away from the changes are elided.
+func Diff(expected string, actual string) string {
+	if strings.HasSuffix(expected, "\n") && strings.HasSuffix(actual, "\n") {
+		expected, actual = expected[:len(expected)-1], actual[:len(actual)-1]
+	}
+	expectedLines := strings.Split(expected, "\n")
+	actualLines := strings.Split(actual, "\n")
+
+	//lcs[i][j] is the length of the longest common subsequence of expectedLines[i:] and actualLines[j:]
+	lcs := make([][]int, len(expectedLines)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(actualLines)+1)
+	}
+	for i := len(expectedLines) - 1; i >= 0; i-- {
+		for j := len(actualLines) - 1; j >= 0; j-- {
+			if expectedLines[i] == actualLines[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	lines := []string{}
+	i, j := 0, 0
+	for i < len(expectedLines) || j < len(actualLines) {
+		switch {
+		case i < len(expectedLines) && j < len(actualLines) && expectedLines[i] == actualLines[j]:
+			lines = append(lines, "  "+expectedLines[i])
+			i++
+			j++
+		case j < len(actualLines) && (i == len(expectedLines) || lcs[i][j+1] > lcs[i+1][j]):
+			lines = append(lines, "+ "+actualLines[j])
+			j++
+		default:
+			lines = append(lines, "- "+expectedLines[i])
+			i++
+		}
+	}
+
+	return strings.Join(elideUnchanged(lines), "\n")
+}
+
+//elideUnchanged replaces the unchanged lines further than contextLines from any change with a count of the lines elided
+func elideUnchanged(lines []string) []string {
+	keep := make([]bool, len(lines))
+	for i, line := range lines {
+		if strings.HasPrefix(line, "  ") {
+			continue
+		}
+		for k := i - contextLines; k <= i+contextLines; k++ {
+			if k >= 0 && k < len(lines) {
+				keep[k] = true
+			}
+		}
+	}
+
+	result := []string{}
+	elided := 0
+	for i, line := range lines {
+		if keep[i] {
+			if elided > 0 {
+				result = append(result, fmt.Sprintf("  ... (%d unchanged lines)", elided))
+				elided = 0
+			}
+			result = append(result, line)
+		} else {
+			elided++
+		}
+	}
+	if elided > 0 {
+		result = append(result, fmt.Sprintf("  ... (%d unchanged lines)", elided))
+	}
+	return result
+}
@@ -0,0 +1,68 @@
+/*
+Package snapshot stores the snapshots MatchSnapshot compares data against.
+
+Snapshots live under testdata/__snapshots__ in the directory of the package under test, one file per snapshot, named
+after the snapshot with a .snap extension.  Names may contain slashes to group snapshots in subdirectories.
+*/
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+//Dir is the directory holding the snapshots, relative to the package under test
+var Dir = filepath.Join("testdata", "__snapshots__")
+
+//Path returns the path of the snapshot called name
+func Path(name string) (string, error) {
+	if strings.TrimSpace(name) == "" {
+		return "", fmt.Errorf("snapshots must have a name")
+	}
+	cleaned := filepath.Clean(filepath.FromSlash(name))
+	if filepath.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("snapshot name %q must stay within %s", name, Dir)
+	}
+	return filepath.Join(Dir, cleaned+".snap"), nil
+}
+
+//Serialize turns the data to snapshot into text.  Strings and byte slices are taken as is, other values are encoded as
+//indented JSON.
+func Serialize(data interface{}) (string, error) {
+	switch data := data.(type) {
+	case string:
+		return data, nil
+	case []byte:
+		return string(data), nil
+	}
+	encoded, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode the snapshot data: %s", err)
+	}
+	return string(encoded) + "\n", nil
+}
+
+//Read returns the content of the snapshot at path, and false if there is no such snapshot
+func Read(path string) (string, bool, error) {
+	content, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return string(content), true, nil
+}
+
+//Write writes the snapshot at path, creating its directory as needed
+func Write(path string, content string) error {
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, []byte(content), 0644)
+}
@@ -0,0 +1,104 @@
+package snapshot_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/internal/snapshot"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Snapshot", func() {
+	Describe("Path", func() {
+		It("places the snapshot under testdata/__snapshots__", func() {
+			Ω(Path("invoice")).Should(Equal(filepath.Join("testdata", "__snapshots__", "invoice.snap")))
+			Ω(Path("invoice/with-discount")).Should(Equal(filepath.Join("testdata", "__snapshots__", "invoice", "with-discount.snap")))
+		})
+
+		It("rejects names that are empty or leave the snapshot directory", func() {
+			for _, name := range []string{"", "  ", "..", "../invoice", "/invoice"} {
+				_, err := Path(name)
+				Ω(err).Should(HaveOccurred(), name)
+			}
+		})
+	})
+
+	Describe("Serialize", func() {
+		It("takes strings and byte slices as is", func() {
+			Ω(Serialize("some text")).Should(Equal("some text"))
+			Ω(Serialize([]byte("some bytes"))).Should(Equal("some bytes"))
+		})
+
+		It("encodes other values as indented JSON", func() {
+			Ω(Serialize(map[string]int{"a": 1})).Should(Equal("{\n  \"a\": 1\n}\n"))
+		})
+
+		It("fails on values that cannot be encoded", func() {
+			_, err := Serialize(func() {})
+			Ω(err).Should(HaveOccurred())
+		})
+	})
+
+	Describe("Read and Write", func() {
+		var dir string
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "snapshot")
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		It("round-trips snapshots, creating their directory", func() {
+			path := filepath.Join(dir, "nested", "invoice.snap")
+			_, exists, err := Read(path)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(exists).Should(BeFalse())
+
+			Ω(Write(path, "content")).Should(Succeed())
+			content, exists, err := Read(path)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(exists).Should(BeTrue())
+			Ω(content).Should(Equal("content"))
+		})
+	})
+
+	Describe("Diff", func() {
+		It("marks the removed and added lines", func() {
+			Ω(Diff("a\nb\nc", "a\nB\nc\nd")).Should(Equal(strings.Join([]string{
+				"  a",
+				"- b",
+				"+ B",
+				"  c",
+				"+ d",
+			}, "\n")))
+		})
+
+		It("ignores the trailing newline of both", func() {
+			Ω(Diff("a\nb\n", "a\nB\n")).Should(Equal("  a\n- b\n+ B"))
+		})
+
+		It("elides unchanged lines away from the changes", func() {
+			lines := []string{}
+			for _, c := range "abcdefghij" {
+				lines = append(lines, string(c))
+			}
+			expected := strings.Join(lines, "\n")
+			lines[8] = "I"
+			Ω(Diff(expected, strings.Join(lines, "\n"))).Should(Equal(strings.Join([]string{
+				"  ... (5 unchanged lines)",
+				"  f",
+				"  g",
+				"  h",
+				"- i",
+				"+ I",
+				"  j",
+			}, "\n")))
+		})
+	})
+})
@@ -3,11 +3,19 @@ package spec
 import (
 	"fmt"
 	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
 	"time"
 
 	"sync"
 
+	"github.com/onsi/ginkgo/internal/clock"
 	"github.com/onsi/ginkgo/internal/containernode"
+	"github.com/onsi/ginkgo/internal/failer"
+	"github.com/onsi/ginkgo/internal/hermetic"
+	"github.com/onsi/ginkgo/internal/invariant"
 	"github.com/onsi/ginkgo/internal/leafnodes"
 	"github.com/onsi/ginkgo/types"
 )
@@ -17,23 +25,65 @@ type Spec struct {
 	focused          bool
 	announceProgress bool
 
-	containers []*containernode.ContainerNode
-
-	state            types.SpecState
-	runTime          time.Duration
-	startTime        time.Time
-	failure          types.SpecFailure
-	previousFailures bool
+	containers      []*containernode.ContainerNode
+	labels          []string
+	owners          []string
+	features        []string
+	severity        types.Severity
+	isolateWorkDir  bool
+	restoreEnv      bool
+	hermetic        bool
+	pendingReason   string
+	filterReason    string
+	expectedFailure string
+	retryOn         func(string) bool
+	aroundEachNodes []*leafnodes.AroundEachNode
+	beforeNodeHooks []*leafnodes.BeforeNodeHook
+	onFailureHooks  []*leafnodes.OnFailureHook
+	invariants      []*invariant.Invariant
+
+	failOnCleanupPanics bool
+	cleanupOnly         bool
+
+	runtimeBudgetLabel     string
+	runtimeBudget          time.Duration
+	failOnRuntimeBudget    bool
+	runtimeBudgetViolation *types.RuntimeBudgetViolation
+
+	hermeticAllowlist    hermetic.Allowlist
+	hermeticWritableDirs []string
+	hermeticViolations   []string
+
+	state              types.SpecState
+	runTime            time.Duration
+	startTime          time.Time
+	failure            types.SpecFailure
+	additionalFailures []types.SpecFailure
+	failedAsExpected   *types.SpecFailure
+	previousFailures   bool
+	nodeSummaries      []*types.NodeSummary
+	steps              []*types.StepSummary
+	randomSeed         int64
+	nondeterminism     []string
+	reportEntries      []types.ReportEntry
+
+	runningNode          leafnodes.BasicNode
+	runningNodeStartTime time.Time
+	runningNodeLevel     int
+	cleanups             map[int][]leafnodes.BasicNode
+	onFailureHooksRan    bool
 
 	stateMutex *sync.Mutex
 }
 
-func New(subject leafnodes.SubjectNode, containers []*containernode.ContainerNode, announceProgress bool) *Spec {
+func New(subject leafnodes.SubjectNode, containers []*containernode.ContainerNode, announceProgress bool, labels ...string) *Spec {
 	spec := &Spec{
 		subject:          subject,
 		containers:       containers,
+		labels:           labels,
 		focused:          subject.Flag() == types.FlagTypeFocused,
 		announceProgress: announceProgress,
+		runningNodeLevel: -1,
 		stateMutex:       &sync.Mutex{},
 	}
 
@@ -41,10 +91,75 @@ func New(subject leafnodes.SubjectNode, containers []*containernode.ContainerNod
 	for i := len(containers) - 1; i >= 0; i-- {
 		spec.processFlag(containers[i].Flag())
 	}
+	spec.pendingReason = pendingReason(subject, containers)
 
 	return spec
 }
 
+//pendingReason returns the reason recorded by the innermost pending node that recorded one
+func pendingReason(subject leafnodes.SubjectNode, containers []*containernode.ContainerNode) string {
+	if reasoner, ok := subject.(leafnodes.PendingReasoner); ok && subject.Flag() == types.FlagTypePending && reasoner.PendingReason() != "" {
+		return reasoner.PendingReason()
+	}
+	for i := len(containers) - 1; i >= 0; i-- {
+		if containers[i].Flag() == types.FlagTypePending && containers[i].PendingReason() != "" {
+			return containers[i].PendingReason()
+		}
+	}
+	return ""
+}
+
+//SetAroundEachNodes sets the AroundEach nodes that wrap every sample of the spec, outermost first
+func (spec *Spec) SetAroundEachNodes(nodes []*leafnodes.AroundEachNode) {
+	spec.aroundEachNodes = nodes
+}
+
+//SetBeforeNodeHooks sets the hooks that run right before the body of every node of the spec, in order
+func (spec *Spec) SetBeforeNodeHooks(hooks []*leafnodes.BeforeNodeHook) {
+	spec.beforeNodeHooks = hooks
+}
+
+//SetOnFailureHooks sets the hooks that run once the spec has unwound, if it failed
+func (spec *Spec) SetOnFailureHooks(hooks []*leafnodes.OnFailureHook) {
+	spec.onFailureHooks = hooks
+}
+
+//SetInvariants sets the suite invariants checked once the spec has run, see ginkgo.RegisterSuiteInvariant
+func (spec *Spec) SetInvariants(invariants []*invariant.Invariant) {
+	spec.invariants = invariants
+}
+
+//SetFailOnCleanupPanics sets whether cleanups that panic fail the spec.  Otherwise their panics are only recorded in
+//the timeline of the spec.
+func (spec *Spec) SetFailOnCleanupPanics(fail bool) {
+	spec.failOnCleanupPanics = fail
+}
+
+//SetCleanupOnly sets whether the spec skips its BeforeEach, JustBeforeEach and subject nodes, only running its
+//JustAfterEach and AfterEach nodes, see -cleanupOnly
+func (spec *Spec) SetCleanupOnly(cleanupOnly bool) {
+	spec.cleanupOnly = cleanupOnly
+}
+
+//SetRetryOn sets which failures of the spec are worth another attempt with -flakeAttempts, by their message.  All of
+//them are when retryOn is nil.
+func (spec *Spec) SetRetryOn(retryOn func(string) bool) {
+	spec.retryOn = retryOn
+}
+
+//ShouldRetry tells whether the failure of the spec is worth another attempt, see SetRetryOn
+func (spec *Spec) ShouldRetry() bool {
+	return spec.retryOn == nil || spec.retryOn(spec.Summary("").Failure.Message)
+}
+
+//SetRuntimeBudget sets the longest the spec may run, the budget of its label, see -runtimeBudgets.  When fail is set, a
+//spec that runs longer fails; it is otherwise only flagged.
+func (spec *Spec) SetRuntimeBudget(label string, budget time.Duration, fail bool) {
+	spec.runtimeBudgetLabel = label
+	spec.runtimeBudget = budget
+	spec.failOnRuntimeBudget = fail
+}
+
 func (spec *Spec) processFlag(flag types.FlagType) {
 	if flag == types.FlagTypeFocused {
 		spec.focused = true
@@ -57,6 +172,18 @@ func (spec *Spec) Skip() {
 	spec.setState(types.SpecStateSkipped)
 }
 
+//SkipWithReason skips the spec, recording why in its failure like a call to Skip from the spec would
+func (spec *Spec) SkipWithReason(reason string) {
+	spec.setState(types.SpecStateSkipped)
+	spec.failure = types.SpecFailure{
+		Message:               reason,
+		Location:              spec.subject.CodeLocation(),
+		ComponentType:         spec.subject.Type(),
+		ComponentIndex:        len(spec.containers),
+		ComponentCodeLocation: spec.subject.CodeLocation(),
+	}
+}
+
 func (spec *Spec) Failed() bool {
 	return spec.getState() == types.SpecStateFailed || spec.getState() == types.SpecStatePanicked || spec.getState() == types.SpecStateTimedOut
 }
@@ -81,6 +208,80 @@ func (spec *Spec) Focused() bool {
 	return spec.focused
 }
 
+func (spec *Spec) Labels() []string {
+	return spec.labels
+}
+
+//SetOwners records the owners of the spec, see ginkgo.Owner
+func (spec *Spec) SetOwners(owners []string) {
+	spec.owners = owners
+}
+
+//SetRequiredFeatures sets the features the spec needs the environment to provide, see Specs.SkipMissingFeatures
+func (spec *Spec) SetRequiredFeatures(features []string) {
+	spec.features = features
+}
+
+//SetSeverity records the severity of the spec, see ginkgo.Critical
+func (spec *Spec) SetSeverity(severity types.Severity) {
+	spec.severity = severity
+}
+
+func (spec *Spec) Severity() types.Severity {
+	return spec.severity
+}
+
+//SetExpectedFailure records why the spec is expected to fail, see ginkgo.ExpectFailure
+func (spec *Spec) SetExpectedFailure(reason string) {
+	spec.expectedFailure = reason
+}
+
+//SetIsolateWorkingDir makes the spec run in a temporary working directory of its own, see ginkgo.IsolateWorkingDir
+func (spec *Spec) SetIsolateWorkingDir(isolate bool) {
+	spec.isolateWorkDir = isolate
+}
+
+//SetRestoreEnvironment makes the spec restore the environment variables it changes once it has run, reporting them
+func (spec *Spec) SetRestoreEnvironment(restore bool) {
+	spec.restoreEnv = restore
+}
+
+//SetHermetic makes the spec fail when it opens network connections or files for writing that allowlist does not allow,
+//files under writableDirs aside, see -hermetic
+func (spec *Spec) SetHermetic(allowlist hermetic.Allowlist, writableDirs []string) {
+	spec.hermetic = true
+	spec.hermeticAllowlist = allowlist
+	spec.hermeticWritableDirs = writableDirs
+}
+
+//Serial tells whether the spec must not run concurrently with other specs within its process: it is decorated with Serial,
+//or belongs to a container decorated with Serial or OncePerContainer, or it changes the working directory, restores the
+//environment of the process, checks the suite invariants or watches the file descriptors of the process
+func (spec *Spec) Serial() bool {
+	if spec.isolateWorkDir || spec.restoreEnv || len(spec.invariants) > 0 || spec.hermetic {
+		return true
+	}
+	if marker, ok := spec.subject.(leafnodes.SerialMarker); ok && marker.Serial() {
+		return true
+	}
+	for _, container := range spec.containers {
+		if container.Serial() || container.OncePerContainer() {
+			return true
+		}
+	}
+	return false
+}
+
+//ProcessAffinity returns the parallel process the spec is pinned to by its innermost pinned container, or 0 if it is not pinned
+func (spec *Spec) ProcessAffinity() int {
+	for i := len(spec.containers) - 1; i >= 0; i-- {
+		if process := spec.containers[i].ProcessAffinity(); process > 0 {
+			return process
+		}
+	}
+	return 0
+}
+
 func (spec *Spec) IsMeasurement() bool {
 	return spec.subject.Type() == types.SpecComponentTypeMeasure
 }
@@ -99,7 +300,12 @@ func (spec *Spec) Summary(suiteID string) *types.SpecSummary {
 
 	runTime := spec.runTime
 	if runTime == 0 && !spec.startTime.IsZero() {
-		runTime = time.Since(spec.startTime)
+		runTime = clock.Since(spec.startTime)
+	}
+
+	failure := spec.failure
+	if spec.getState().IsFailure() {
+		failure.Fingerprint = types.FailureFingerprint(failure)
 	}
 
 	return &types.SpecSummary{
@@ -107,10 +313,27 @@ func (spec *Spec) Summary(suiteID string) *types.SpecSummary {
 		NumberOfSamples:        spec.subject.Samples(),
 		ComponentTexts:         componentTexts,
 		ComponentCodeLocations: componentCodeLocations,
+		Labels:                 spec.labels,
+		Owners:                 spec.owners,
+		RequiredFeatures:       spec.features,
+		HermeticViolations:     spec.getHermeticViolations(),
+		Severity:               spec.severity,
+		PendingReason:          spec.pendingReason,
+		FilterReason:           spec.filterReason,
+		ExpectedFailureReason:  spec.expectedFailure,
+		ExpectedFailure:        spec.getFailedAsExpected(),
 		State:                  spec.getState(),
+		StartTime:              spec.startTime,
 		RunTime:                runTime,
-		Failure:                spec.failure,
+		Failure:                failure,
+		AdditionalFailures:     spec.getAdditionalFailures(),
 		Measurements:           spec.measurementsReport(),
+		NodeSummaries:          spec.getNodeSummaries(),
+		Steps:                  spec.getSteps(),
+		RandomSeed:             spec.randomSeed,
+		Nondeterminism:         spec.getNondeterminism(),
+		ReportEntries:          spec.getReportEntries(),
+		RuntimeBudgetViolation: spec.getRuntimeBudgetViolation(),
 		SuiteID:                suiteID,
 	}
 }
@@ -129,11 +352,61 @@ func (spec *Spec) Run(writer io.Writer) {
 		spec.previousFailures = true
 	}
 
-	spec.startTime = time.Now()
+	spec.startTime = clock.Now()
+	spec.stateMutex.Lock()
+	spec.nodeSummaries = []*types.NodeSummary{}
+	spec.steps = []*types.StepSummary{}
+	spec.nondeterminism = []string{}
+	spec.additionalFailures = []types.SpecFailure{}
+	spec.reportEntries = []types.ReportEntry{}
+	spec.onFailureHooksRan = false
+	spec.failedAsExpected = nil
+	spec.runtimeBudgetViolation = nil
+	spec.hermeticViolations = nil
+	spec.stateMutex.Unlock()
 	defer func() {
-		spec.runTime = time.Since(spec.startTime)
+		spec.checkRuntimeBudget()
+		spec.failWithAdditionalFailures()
+		spec.applyExpectedFailure()
+		if spec.Failed() {
+			spec.RunOnFailureHooks(spec.Summary(""), writer)
+		}
+		spec.runTime = clock.Since(spec.startTime)
 	}()
 
+	if len(spec.invariants) > 0 {
+		defer spec.checkInvariants()
+	}
+
+	if spec.restoreEnv {
+		defer spec.restoreEnvironment(os.Environ())
+	}
+
+	if spec.hermetic {
+		defer spec.checkHermeticity(hermetic.Start(spec.hermeticAllowlist, spec.hermeticWritableDirs...))
+	}
+
+	if spec.isolateWorkDir {
+		restore, err := isolateWorkingDir()
+		if err != nil {
+			spec.setState(types.SpecStateFailed)
+			spec.failure = types.SpecFailure{
+				Message:               fmt.Sprintf("Failed to isolate the working directory of the spec: %s", err.Error()),
+				Location:              spec.subject.CodeLocation(),
+				ComponentType:         spec.subject.Type(),
+				ComponentIndex:        len(spec.containers),
+				ComponentCodeLocation: spec.subject.CodeLocation(),
+			}
+			return
+		}
+		defer restore()
+	}
+
+	if spec.cleanupOnly {
+		spec.runSample(0, writer)
+		return
+	}
+
 	for sample := 0; sample < spec.subject.Samples(); sample++ {
 		spec.runSample(sample, writer)
 
@@ -143,6 +416,117 @@ func (spec *Spec) Run(writer io.Writer) {
 	}
 }
 
+//isolateWorkingDir changes the working directory of the process to a new temporary directory, and returns the function
+//changing it back and removing the temporary directory
+func isolateWorkingDir() (func(), error) {
+	workingDir, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	dir, err := ioutil.TempDir("", "ginkgo-spec")
+	if err != nil {
+		return nil, err
+	}
+	err = os.Chdir(dir)
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+	return func() {
+		os.Chdir(workingDir)
+		os.RemoveAll(dir)
+	}, nil
+}
+
+//restoreEnvironment restores the environment variables to their snapshot, taken before the spec ran, and reports the
+//changes the spec leaked in an "Environment leak" report entry
+func (spec *Spec) restoreEnvironment(snapshot []string) {
+	before, after := environMap(snapshot), environMap(os.Environ())
+	leaks := []string{}
+	for key, value := range after {
+		previous, ok := before[key]
+		if !ok {
+			leaks = append(leaks, fmt.Sprintf("%s was set to %q", key, value))
+			os.Unsetenv(key)
+		} else if previous != value {
+			leaks = append(leaks, fmt.Sprintf("%s was changed from %q to %q", key, previous, value))
+			os.Setenv(key, previous)
+		}
+	}
+	for key, previous := range before {
+		if _, ok := after[key]; !ok {
+			leaks = append(leaks, fmt.Sprintf("%s was unset, it was %q", key, previous))
+			os.Setenv(key, previous)
+		}
+	}
+	if len(leaks) == 0 {
+		return
+	}
+
+	sort.Strings(leaks)
+	spec.AddReportEntry(types.NewReportEntry("Environment leak", spec.subject.CodeLocation(), strings.Join(leaks, "\n"), false))
+}
+
+//checkInvariants fails the spec for each suite invariant it is the first to violate
+func (spec *Spec) checkInvariants() {
+	for _, suiteInvariant := range spec.invariants {
+		if err := suiteInvariant.Check(); err != nil {
+			spec.RecordAdditionalFailure(types.SpecFailure{
+				Message:  fmt.Sprintf("This spec is the first to violate the suite invariant registered at %s:\n%s", suiteInvariant.CodeLocation(), err.Error()),
+				Location: spec.subject.CodeLocation(),
+			})
+		}
+	}
+}
+
+//checkHermeticity stops monitor and fails the spec with the network connections and the files it opened without being
+//allowed to
+func (spec *Spec) checkHermeticity(monitor *hermetic.Monitor) {
+	violations := monitor.Stop()
+	if len(violations) == 0 {
+		return
+	}
+
+	spec.stateMutex.Lock()
+	spec.hermeticViolations = violations
+	spec.stateMutex.Unlock()
+	spec.RecordAdditionalFailure(types.SpecFailure{
+		Message:  fmt.Sprintf("This spec is not hermetic, see -hermetic and HermeticAllow:\n  %s", strings.Join(violations, "\n  ")),
+		Location: spec.subject.CodeLocation(),
+	})
+}
+
+//checkRuntimeBudget records that the spec ran longer than its runtime budget, and fails it with -failOnRuntimeBudgets
+func (spec *Spec) checkRuntimeBudget() {
+	if spec.runtimeBudget <= 0 || spec.getState() == types.SpecStateSkipped {
+		return
+	}
+	runTime := clock.Since(spec.startTime)
+	if runTime <= spec.runtimeBudget {
+		return
+	}
+
+	spec.stateMutex.Lock()
+	spec.runtimeBudgetViolation = &types.RuntimeBudgetViolation{Label: spec.runtimeBudgetLabel, Budget: spec.runtimeBudget, RunTime: runTime}
+	spec.stateMutex.Unlock()
+	if spec.failOnRuntimeBudget {
+		spec.RecordAdditionalFailure(types.SpecFailure{
+			Message:  fmt.Sprintf("This spec ran for %s, longer than the %s runtime budget of the label %q", runTime.Round(time.Millisecond), spec.runtimeBudget, spec.runtimeBudgetLabel),
+			Location: spec.subject.CodeLocation(),
+		})
+	}
+}
+
+func environMap(environ []string) map[string]string {
+	m := map[string]string{}
+	for _, variable := range environ {
+		if i := strings.Index(variable, "="); i > 0 {
+			m[variable[:i]] = variable[i+1:]
+		}
+	}
+	return m
+}
+
 func (spec *Spec) getState() types.SpecState {
 	spec.stateMutex.Lock()
 	defer spec.stateMutex.Unlock()
@@ -158,6 +542,39 @@ func (spec *Spec) setState(state types.SpecState) {
 func (spec *Spec) runSample(sample int, writer io.Writer) {
 	spec.setState(types.SpecStatePassed)
 	spec.failure = types.SpecFailure{}
+	spec.stateMutex.Lock()
+	spec.cleanups = map[int][]leafnodes.BasicNode{}
+	spec.stateMutex.Unlock()
+	if spec.cleanupOnly {
+		//the AroundEach nodes set up around the spec as much as they tear down after it
+		spec.runSampleNodes(writer)
+		return
+	}
+	spec.runAroundEachNodes(0, writer)
+}
+
+//runAroundEachNodes runs the AroundEach nodes from the i-th on, each wrapping the next one, the innermost wrapping the
+//setup nodes, the subject and the teardown nodes of the spec
+func (spec *Spec) runAroundEachNodes(i int, writer io.Writer) {
+	if i == len(spec.aroundEachNodes) {
+		spec.runSampleNodes(writer)
+		return
+	}
+
+	aroundEach := spec.aroundEachNodes[i]
+	if spec.announceProgress {
+		writer.Write([]byte(fmt.Sprintf("[AroundEach]\n  %s\n", aroundEach.CodeLocation().String())))
+	}
+	s, f := spec.runNode(aroundEach.Wrapping(func() {
+		spec.runAroundEachNodes(i+1, writer)
+	}))
+	if s != types.SpecStatePassed && spec.getState() == types.SpecStatePassed {
+		spec.setState(s)
+		spec.failure = f
+	}
+}
+
+func (spec *Spec) runSampleNodes(writer io.Writer) {
 	innerMostContainerIndexToUnwind := -1
 
 	defer func() {
@@ -165,7 +582,7 @@ func (spec *Spec) runSample(sample int, writer io.Writer) {
 			container := spec.containers[i]
 			for _, justAfterEach := range container.SetupNodesOfType(types.SpecComponentTypeJustAfterEach) {
 				spec.announceSetupNode(writer, "JustAfterEach", container, justAfterEach)
-				justAfterEachState, justAfterEachFailure := justAfterEach.Run()
+				justAfterEachState, justAfterEachFailure := spec.runNodeAtLevel(i, justAfterEach)
 				if justAfterEachState != types.SpecStatePassed && spec.state == types.SpecStatePassed {
 					spec.state = justAfterEachState
 					spec.failure = justAfterEachFailure
@@ -173,24 +590,31 @@ func (spec *Spec) runSample(sample int, writer io.Writer) {
 			}
 		}
 
+		spec.runCleanups(writer, len(spec.containers))
 		for i := innerMostContainerIndexToUnwind; i >= 0; i-- {
 			container := spec.containers[i]
 			for _, afterEach := range container.SetupNodesOfType(types.SpecComponentTypeAfterEach) {
 				spec.announceSetupNode(writer, "AfterEach", container, afterEach)
-				afterEachState, afterEachFailure := afterEach.Run()
+				afterEachState, afterEachFailure := spec.runNodeAtLevel(i, afterEach)
 				if afterEachState != types.SpecStatePassed && spec.getState() == types.SpecStatePassed {
 					spec.setState(afterEachState)
 					spec.failure = afterEachFailure
 				}
 			}
+			spec.runCleanups(writer, i)
 		}
 	}()
 
+	if spec.cleanupOnly {
+		innerMostContainerIndexToUnwind = len(spec.containers) - 1
+		return
+	}
+
 	for i, container := range spec.containers {
 		innerMostContainerIndexToUnwind = i
 		for _, beforeEach := range container.SetupNodesOfType(types.SpecComponentTypeBeforeEach) {
 			spec.announceSetupNode(writer, "BeforeEach", container, beforeEach)
-			s, f := beforeEach.Run()
+			s, f := spec.runNodeAtLevel(i, beforeEach)
 			spec.failure = f
 			spec.setState(s)
 			if spec.getState() != types.SpecStatePassed {
@@ -199,10 +623,10 @@ func (spec *Spec) runSample(sample int, writer io.Writer) {
 		}
 	}
 
-	for _, container := range spec.containers {
+	for i, container := range spec.containers {
 		for _, justBeforeEach := range container.SetupNodesOfType(types.SpecComponentTypeJustBeforeEach) {
 			spec.announceSetupNode(writer, "JustBeforeEach", container, justBeforeEach)
-			s, f := justBeforeEach.Run()
+			s, f := spec.runNodeAtLevel(i, justBeforeEach)
 			spec.failure = f
 			spec.setState(s)
 			if spec.getState() != types.SpecStatePassed {
@@ -212,11 +636,334 @@ func (spec *Spec) runSample(sample int, writer io.Writer) {
 	}
 
 	spec.announceSubject(writer, spec.subject)
-	s, f := spec.subject.Run()
+	s, f := spec.runNodeAtLevel(len(spec.containers), spec.subject)
 	spec.failure = f
 	spec.setState(s)
 }
 
+//runNodeAtLevel runs the node as runNode does.  The cleanups it defers run when the spec unwinds the given level: the
+//index of the container of a setup or teardown node, one past the innermost container for the subject.
+func (spec *Spec) runNodeAtLevel(level int, node leafnodes.BasicNode) (types.SpecState, types.SpecFailure) {
+	spec.stateMutex.Lock()
+	previousLevel := spec.runningNodeLevel
+	spec.runningNodeLevel = level
+	spec.stateMutex.Unlock()
+
+	defer func() {
+		spec.stateMutex.Lock()
+		spec.runningNodeLevel = previousLevel
+		spec.stateMutex.Unlock()
+	}()
+	return spec.runNode(node)
+}
+
+//DeferCleanup defers a cleanup until the spec unwinds the level of the running node: the cleanups deferred by the
+//subject run after the JustAfterEach nodes, and those deferred by the nodes of a container after its AfterEach nodes.
+//Cleanups of the same level run in the reverse order of their registration.  It returns false when no node of the
+//spec that can defer cleanups is running.
+func (spec *Spec) DeferCleanup(body interface{}, codeLocation types.CodeLocation, failer *failer.Failer) bool {
+	spec.stateMutex.Lock()
+	defer spec.stateMutex.Unlock()
+	if spec.runningNodeLevel < 0 || spec.cleanups == nil {
+		return false
+	}
+
+	componentIndex := 0
+	if indexer, ok := spec.runningNode.(leafnodes.ComponentIndexer); ok {
+		componentIndex = indexer.ComponentIndex()
+	}
+	node := leafnodes.NewDeferCleanupNode(body, codeLocation, failer, componentIndex)
+	spec.cleanups[spec.runningNodeLevel] = append(spec.cleanups[spec.runningNodeLevel], node)
+	return true
+}
+
+//runCleanups runs the cleanups deferred at the level, last deferred first, including those deferred by the cleanups
+//themselves
+func (spec *Spec) runCleanups(writer io.Writer, level int) {
+	for {
+		spec.stateMutex.Lock()
+		cleanups := spec.cleanups[level]
+		if len(cleanups) == 0 {
+			spec.stateMutex.Unlock()
+			return
+		}
+		cleanup := cleanups[len(cleanups)-1]
+		spec.cleanups[level] = cleanups[:len(cleanups)-1]
+		spec.stateMutex.Unlock()
+
+		if spec.announceProgress {
+			writer.Write([]byte(fmt.Sprintf("[DeferCleanup]\n  %s\n", cleanup.CodeLocation().String())))
+		}
+		s, f := spec.runNodeAtLevel(level, cleanup)
+		if s == types.SpecStatePanicked && !spec.failOnCleanupPanics {
+			writer.Write([]byte(fmt.Sprintf("Ignoring the panic of the cleanup at %s, as -failOnCleanupPanics is not set: %s\n", f.Location.String(), f.ForwardedPanic)))
+			continue
+		}
+		if s != types.SpecStatePassed && spec.getState() == types.SpecStatePassed {
+			spec.setState(s)
+			spec.failure = f
+		}
+	}
+}
+
+func (spec *Spec) runNode(node leafnodes.BasicNode) (types.SpecState, types.SpecFailure) {
+	startTime := clock.Now()
+	spec.stateMutex.Lock()
+	previousNode, previousNodeStartTime := spec.runningNode, spec.runningNodeStartTime
+	spec.runningNode, spec.runningNodeStartTime = node, startTime
+	spec.stateMutex.Unlock()
+
+	state, failure := spec.runBeforeNodeHooks(node)
+	if state == types.SpecStatePassed {
+		state, failure = node.Run()
+	}
+
+	spec.stateMutex.Lock()
+	defer spec.stateMutex.Unlock()
+	spec.runningNode, spec.runningNodeStartTime = previousNode, previousNodeStartTime
+	spec.nodeSummaries = append(spec.nodeSummaries, &types.NodeSummary{
+		ComponentType: node.Type(),
+		CodeLocation:  node.CodeLocation(),
+		State:         state,
+		StartTime:     startTime,
+		RunTime:       clock.Since(startTime),
+		Failure:       failure,
+	})
+	return state, failure
+}
+
+//RunOnFailureHooks runs the OnFailure hooks for the failed spec described by summary, once per run of the spec: the spec
+//runs them when it has unwound, unless the suite was interrupted first and ran them for the spec as it was.  The failures
+//of the hooks are recorded as additional failures of the spec.
+func (spec *Spec) RunOnFailureHooks(summary *types.SpecSummary, writer io.Writer) {
+	spec.stateMutex.Lock()
+	if spec.onFailureHooksRan {
+		spec.stateMutex.Unlock()
+		return
+	}
+	spec.onFailureHooksRan = true
+	spec.stateMutex.Unlock()
+
+	for _, hook := range spec.onFailureHooks {
+		if spec.announceProgress {
+			writer.Write([]byte(fmt.Sprintf("[OnFailure]\n  %s\n", hook.CodeLocation().String())))
+		}
+		s, f := spec.runNode(hook.For(summary, len(spec.containers)))
+		if s != types.SpecStatePassed {
+			spec.RecordAdditionalFailure(f)
+		}
+	}
+}
+
+//runBeforeNodeHooks runs the BeforeNode hooks for the node, stopping at the first one that fails.  AroundEach nodes
+//are not handed to the hooks: they wrap the nodes that are.  Nor are OnFailure hooks, which must run whatever happens.
+func (spec *Spec) runBeforeNodeHooks(node leafnodes.BasicNode) (types.SpecState, types.SpecFailure) {
+	if len(spec.beforeNodeHooks) == 0 || node.Type() == types.SpecComponentTypeAroundEach || node.Type() == types.SpecComponentTypeOnFailure {
+		return types.SpecStatePassed, types.SpecFailure{}
+	}
+
+	componentTexts := make([]string, len(spec.containers)+1)
+	for i, container := range spec.containers {
+		componentTexts[i] = container.Text()
+	}
+	componentTexts[len(spec.containers)] = spec.subject.Text()
+	info := types.NodeInfo{
+		ComponentType:  node.Type(),
+		CodeLocation:   node.CodeLocation(),
+		ComponentTexts: componentTexts,
+		Labels:         append([]string{}, spec.labels...),
+	}
+
+	componentIndex := 0
+	if indexer, ok := node.(leafnodes.ComponentIndexer); ok {
+		componentIndex = indexer.ComponentIndex()
+	}
+
+	for _, hook := range spec.beforeNodeHooks {
+		state, failure := hook.Before(info, componentIndex).Run()
+		if state != types.SpecStatePassed {
+			return state, failure
+		}
+	}
+	return types.SpecStatePassed, types.SpecFailure{}
+}
+
+func (spec *Spec) getNodeSummaries() []*types.NodeSummary {
+	spec.stateMutex.Lock()
+	defer spec.stateMutex.Unlock()
+	return append([]*types.NodeSummary{}, spec.nodeSummaries...)
+}
+
+//RecordStep records a step (see By) of the running spec
+func (spec *Spec) RecordStep(text string, codeLocation types.CodeLocation) {
+	spec.stateMutex.Lock()
+	defer spec.stateMutex.Unlock()
+	spec.steps = append(spec.steps, &types.StepSummary{
+		Text:         text,
+		CodeLocation: codeLocation,
+		StartTime:    clock.Now(),
+	})
+}
+
+//RecordGherkinStep records a step of the running spec announced with a Gherkin keyword (see Given).  Call the returned
+//function with the state of the step once it completes to record its state and run time.
+func (spec *Spec) RecordGherkinStep(keyword string, text string, codeLocation types.CodeLocation) func(types.SpecState) {
+	spec.stateMutex.Lock()
+	defer spec.stateMutex.Unlock()
+	step := &types.StepSummary{
+		Keyword:      keyword,
+		Text:         text,
+		CodeLocation: codeLocation,
+		StartTime:    clock.Now(),
+	}
+	spec.steps = append(spec.steps, step)
+	return func(state types.SpecState) {
+		spec.stateMutex.Lock()
+		defer spec.stateMutex.Unlock()
+		step.RunTime = clock.Since(step.StartTime)
+		step.State = state
+	}
+}
+
+//SetRandomSeed records the seed math/rand was seeded with before running the spec
+func (spec *Spec) SetRandomSeed(seed int64) {
+	spec.randomSeed = seed
+}
+
+//RecordNondeterminism records a source of nondeterminism detected while the spec ran
+func (spec *Spec) RecordNondeterminism(description string) {
+	spec.stateMutex.Lock()
+	defer spec.stateMutex.Unlock()
+	spec.nondeterminism = append(spec.nondeterminism, description)
+}
+
+//RecordAdditionalFailure records a failure that occurred while the spec ran without failing the running node, such as
+//the failure of a goroutine reported by GinkgoRecoverAndReport.  The spec fails once it has run.
+func (spec *Spec) RecordAdditionalFailure(failure types.SpecFailure) {
+	spec.stateMutex.Lock()
+	defer spec.stateMutex.Unlock()
+	spec.additionalFailures = append(spec.additionalFailures, failure)
+}
+
+//failWithAdditionalFailures fails a spec that passed despite additional failures with the first of them
+func (spec *Spec) failWithAdditionalFailures() {
+	spec.stateMutex.Lock()
+	defer spec.stateMutex.Unlock()
+	if spec.state != types.SpecStatePassed || len(spec.additionalFailures) == 0 {
+		return
+	}
+	spec.state = types.SpecStateFailed
+	spec.failure = spec.additionalFailures[0]
+	if spec.failure.ForwardedPanic != "" {
+		spec.state = types.SpecStatePanicked
+	}
+	spec.failure.ComponentType = spec.subject.Type()
+	spec.failure.ComponentIndex = len(spec.containers)
+	spec.failure.ComponentCodeLocation = spec.subject.CodeLocation()
+	spec.additionalFailures = spec.additionalFailures[1:]
+}
+
+//applyExpectedFailure flips the outcome of a spec expected to fail: its failure lets it pass, keeping the failure aside,
+//and its success fails it
+func (spec *Spec) applyExpectedFailure() {
+	if spec.expectedFailure == "" {
+		return
+	}
+	spec.stateMutex.Lock()
+	defer spec.stateMutex.Unlock()
+	switch {
+	case spec.state.IsFailure():
+		failure := spec.failure
+		spec.failedAsExpected = &failure
+		spec.state = types.SpecStatePassed
+		spec.failure = types.SpecFailure{}
+	case spec.state == types.SpecStatePassed:
+		spec.state = types.SpecStateFailed
+		spec.failure = types.SpecFailure{
+			Message:               fmt.Sprintf("This spec is expected to fail (%s), but it passed", spec.expectedFailure),
+			Location:              spec.subject.CodeLocation(),
+			ComponentType:         spec.subject.Type(),
+			ComponentIndex:        len(spec.containers),
+			ComponentCodeLocation: spec.subject.CodeLocation(),
+		}
+	}
+}
+
+func (spec *Spec) getFailedAsExpected() *types.SpecFailure {
+	spec.stateMutex.Lock()
+	defer spec.stateMutex.Unlock()
+	return spec.failedAsExpected
+}
+
+func (spec *Spec) getAdditionalFailures() []types.SpecFailure {
+	spec.stateMutex.Lock()
+	defer spec.stateMutex.Unlock()
+	return append([]types.SpecFailure{}, spec.additionalFailures...)
+}
+
+//AddReportEntry attaches entry to the report of the spec
+func (spec *Spec) AddReportEntry(entry types.ReportEntry) {
+	spec.stateMutex.Lock()
+	defer spec.stateMutex.Unlock()
+	spec.reportEntries = append(spec.reportEntries, entry)
+}
+
+func (spec *Spec) getReportEntries() []types.ReportEntry {
+	spec.stateMutex.Lock()
+	defer spec.stateMutex.Unlock()
+	return append([]types.ReportEntry{}, spec.reportEntries...)
+}
+
+func (spec *Spec) getRuntimeBudgetViolation() *types.RuntimeBudgetViolation {
+	spec.stateMutex.Lock()
+	defer spec.stateMutex.Unlock()
+	return spec.runtimeBudgetViolation
+}
+
+func (spec *Spec) getHermeticViolations() []string {
+	spec.stateMutex.Lock()
+	defer spec.stateMutex.Unlock()
+	return spec.hermeticViolations
+}
+
+func (spec *Spec) getNondeterminism() []string {
+	spec.stateMutex.Lock()
+	defer spec.stateMutex.Unlock()
+	return append([]string{}, spec.nondeterminism...)
+}
+
+func (spec *Spec) getSteps() []*types.StepSummary {
+	spec.stateMutex.Lock()
+	defer spec.stateMutex.Unlock()
+	return append([]*types.StepSummary{}, spec.steps...)
+}
+
+//ProgressReport describes what the spec is currently doing, including the status lines contributed by the
+//progress reporters attached to the SpecContext of the running node
+func (spec *Spec) ProgressReport() types.ProgressReport {
+	report := types.ProgressReport{
+		ComponentTexts:    spec.Summary("").ComponentTexts,
+		AdditionalReports: []string{},
+	}
+
+	spec.stateMutex.Lock()
+	node := spec.runningNode
+	if node != nil {
+		report.CurrentNodeType = node.Type()
+		report.CurrentNodeCodeLocation = node.CodeLocation()
+		report.CurrentNodeStartTime = spec.runningNodeStartTime
+	}
+	if len(spec.steps) > 0 {
+		report.CurrentStep = spec.steps[len(spec.steps)-1]
+	}
+	spec.stateMutex.Unlock()
+
+	if progressReporter, ok := node.(leafnodes.ProgressReporter); ok {
+		report.AdditionalReports = progressReporter.ProgressReports()
+	}
+	return report
+}
+
 func (spec *Spec) announceSetupNode(writer io.Writer, nodeType string, container *containernode.ContainerNode, setupNode leafnodes.BasicNode) {
 	if spec.announceProgress {
 		s := fmt.Sprintf("[%s] %s\n  %s\n", nodeType, container.Text(), setupNode.CodeLocation().String())
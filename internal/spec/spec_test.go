@@ -1,6 +1,11 @@
 package spec_test
 
 import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"time"
 
 	. "github.com/onsi/ginkgo"
@@ -9,9 +14,11 @@ import (
 
 	. "github.com/onsi/ginkgo/internal/spec"
 
+	"github.com/onsi/ginkgo/internal/clock"
 	"github.com/onsi/ginkgo/internal/codelocation"
 	"github.com/onsi/ginkgo/internal/containernode"
 	Failer "github.com/onsi/ginkgo/internal/failer"
+	"github.com/onsi/ginkgo/internal/invariant"
 	"github.com/onsi/ginkgo/internal/leafnodes"
 	"github.com/onsi/ginkgo/types"
 )
@@ -20,6 +27,14 @@ var noneFlag = types.FlagTypeNone
 var focusedFlag = types.FlagTypeFocused
 var pendingFlag = types.FlagTypePending
 
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
 var _ = Describe("Spec", func() {
 	var (
 		failer       *Failer.Failer
@@ -226,6 +241,16 @@ var _ = Describe("Spec", func() {
 			Ω(spec.Summary("").State).Should(Equal(types.SpecStateFailed))
 			Ω(spec.Summary("").Failure.Message).Should(Equal("bam"))
 		})
+
+		It("should fingerprint the failure", func() {
+			spec := New(newItWithBody("failing it", func() {
+				failer.Fail("bam", codeLocation)
+			}), containers(), false)
+			spec.Run(buffer)
+			failure := spec.Summary("").Failure
+			Ω(failure.Fingerprint).ShouldNot(BeEmpty())
+			Ω(failure.Fingerprint).Should(Equal(types.FailureFingerprint(failure)))
+		})
 	})
 
 	Describe("Concatenated string", func() {
@@ -653,6 +678,756 @@ var _ = Describe("Spec", func() {
 		})
 	})
 
+	Describe("Node summaries", func() {
+		It("should describe each node that ran, in order, and be reset when the spec is run again", func() {
+			spec = New(
+				newIt("it node", noneFlag, false),
+				containers(
+					newContainer("container", noneFlag,
+						newBef("bef A", false),
+						newAft("aft A", true),
+					),
+				),
+				false,
+			)
+			before := time.Now()
+			spec.Run(buffer)
+
+			nodeSummaries := spec.Summary("").NodeSummaries
+			Ω(nodeSummaries).Should(HaveLen(3))
+			Ω(nodeSummaries[0].ComponentType).Should(Equal(types.SpecComponentTypeBeforeEach))
+			Ω(nodeSummaries[0].State).Should(Equal(types.SpecStatePassed))
+			Ω(nodeSummaries[0].StartTime).Should(BeTemporally(">=", before))
+			Ω(nodeSummaries[1].ComponentType).Should(Equal(types.SpecComponentTypeIt))
+			Ω(nodeSummaries[1].State).Should(Equal(types.SpecStatePassed))
+			Ω(nodeSummaries[1].StartTime).Should(BeTemporally(">=", nodeSummaries[0].StartTime))
+			Ω(nodeSummaries[2].ComponentType).Should(Equal(types.SpecComponentTypeAfterEach))
+			Ω(nodeSummaries[2].State).Should(Equal(types.SpecStateFailed))
+			Ω(nodeSummaries[2].Failure.Message).Should(Equal("aft A"))
+			Ω(spec.Summary("").StartTime).Should(BeTemporally(">=", before))
+
+			spec.Run(buffer)
+			Ω(spec.Summary("").NodeSummaries).Should(HaveLen(3))
+		})
+
+		It("should stamp the nodes and the spec with the substituted clock", func() {
+			fake := &fakeClock{now: time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)}
+			start := fake.now
+			clock.Set(fake)
+			defer clock.Set(nil)
+
+			spec = New(
+				leafnodes.NewItNode("it node", func() {
+					fake.now = fake.now.Add(time.Hour)
+				}, noneFlag, codelocation.New(0), 0, failer, 0),
+				containers(),
+				false,
+			)
+			spec.Run(buffer)
+
+			summary := spec.Summary("")
+			Ω(summary.StartTime).Should(Equal(start))
+			Ω(summary.RunTime).Should(Equal(time.Hour))
+			Ω(summary.NodeSummaries).Should(HaveLen(1))
+			Ω(summary.NodeSummaries[0].StartTime).Should(Equal(start))
+			Ω(summary.NodeSummaries[0].RunTime).Should(Equal(time.Hour))
+		})
+	})
+
+	Describe("AroundEach nodes", func() {
+		newAround := func(text string, failBefore bool, failAfter bool) *leafnodes.AroundEachNode {
+			return leafnodes.NewAroundEachNode(func(run func()) {
+				nodesThatRan = append(nodesThatRan, text+" before")
+				if failBefore {
+					failer.Fail(text+" before", codeLocation)
+					return
+				}
+				run()
+				nodesThatRan = append(nodesThatRan, text+" after")
+				if failAfter {
+					failer.Fail(text+" after", codeLocation)
+				}
+			}, codeLocation, failer)
+		}
+
+		newSpec := func(failIt bool) *Spec {
+			return New(
+				newIt("it node", noneFlag, failIt),
+				containers(newContainer("container", noneFlag, newBef("bef A", false), newAft("aft A", false))),
+				false,
+			)
+		}
+
+		It("should wrap the setup nodes, the subject and the teardown nodes, in registration order", func() {
+			spec = newSpec(false)
+			spec.SetAroundEachNodes([]*leafnodes.AroundEachNode{newAround("outer", false, false), newAround("inner", false, false)})
+			spec.Run(buffer)
+
+			Ω(spec.Passed()).Should(BeTrue())
+			Ω(nodesThatRan).Should(Equal([]string{
+				"outer before",
+				"inner before",
+				"bef A",
+				"it node",
+				"aft A",
+				"inner after",
+				"outer after",
+			}))
+		})
+
+		It("should appear in the node summaries", func() {
+			spec = newSpec(false)
+			spec.SetAroundEachNodes([]*leafnodes.AroundEachNode{newAround("outer", false, false)})
+			spec.Run(buffer)
+
+			nodeSummaries := spec.Summary("").NodeSummaries
+			Ω(nodeSummaries).Should(HaveLen(4))
+			Ω(nodeSummaries[3].ComponentType).Should(Equal(types.SpecComponentTypeAroundEach))
+			Ω(nodeSummaries[3].State).Should(Equal(types.SpecStatePassed))
+			Ω(nodeSummaries[3].StartTime).Should(BeTemporally("<=", nodeSummaries[0].StartTime))
+		})
+
+		It("should describe the running node while wrapping the spec", func() {
+			var report types.ProgressReport
+			spec = New(newItWithBody("it node", func() {}), containers(), false)
+			spec.SetAroundEachNodes([]*leafnodes.AroundEachNode{leafnodes.NewAroundEachNode(func(run func()) {
+				run()
+				report = spec.ProgressReport()
+			}, codeLocation, failer)})
+			spec.Run(buffer)
+
+			Ω(report.CurrentNodeType).Should(Equal(types.SpecComponentTypeAroundEach))
+		})
+
+		Context("when an AroundEach node fails before running the spec", func() {
+			It("should not run the spec and report the failure", func() {
+				spec = newSpec(false)
+				spec.SetAroundEachNodes([]*leafnodes.AroundEachNode{newAround("outer", false, false), newAround("inner", true, false)})
+				spec.Run(buffer)
+
+				Ω(spec.Failed()).Should(BeTrue())
+				Ω(spec.Summary("").Failure.Message).Should(Equal("inner before"))
+				Ω(spec.Summary("").Failure.ComponentType).Should(Equal(types.SpecComponentTypeAroundEach))
+				Ω(nodesThatRan).Should(Equal([]string{"outer before", "inner before", "outer after"}))
+			})
+		})
+
+		Context("when an AroundEach node fails after running the spec", func() {
+			It("should report the failure", func() {
+				spec = newSpec(false)
+				spec.SetAroundEachNodes([]*leafnodes.AroundEachNode{newAround("outer", false, true)})
+				spec.Run(buffer)
+
+				Ω(spec.Failed()).Should(BeTrue())
+				Ω(spec.Summary("").Failure.Message).Should(Equal("outer after"))
+			})
+
+			It("should keep the failure of the spec if it failed first", func() {
+				spec = newSpec(true)
+				spec.SetAroundEachNodes([]*leafnodes.AroundEachNode{newAround("outer", false, true)})
+				spec.Run(buffer)
+
+				Ω(spec.Failed()).Should(BeTrue())
+				Ω(spec.Summary("").Failure.Message).Should(Equal("it node"))
+			})
+		})
+
+		Context("when an AroundEach node does not run the spec", func() {
+			It("should fail", func() {
+				spec = newSpec(false)
+				spec.SetAroundEachNodes([]*leafnodes.AroundEachNode{leafnodes.NewAroundEachNode(func(run func()) {}, codeLocation, failer)})
+				spec.Run(buffer)
+
+				Ω(spec.Failed()).Should(BeTrue())
+				Ω(spec.Summary("").Failure.Message).Should(ContainSubstring("AroundEach returned without running the spec"))
+				Ω(nodesThatRan).Should(BeEmpty())
+			})
+		})
+	})
+
+	Describe("isolating the working directory", func() {
+		It("should run the spec in a temporary directory of its own, and be Serial", func() {
+			workingDir, err := os.Getwd()
+			Ω(err).ShouldNot(HaveOccurred())
+
+			var specDir string
+			spec = New(newItWithBody("it node", func() {
+				specDir, _ = os.Getwd()
+				ioutil.WriteFile("output.txt", []byte("output"), 0644)
+			}), containers(), false)
+			spec.SetIsolateWorkingDir(true)
+			Ω(spec.Serial()).Should(BeTrue())
+			spec.Run(buffer)
+
+			Ω(spec.Passed()).Should(BeTrue())
+			Ω(specDir).ShouldNot(Equal(workingDir))
+			Ω(os.Getwd()).Should(Equal(workingDir))
+			Ω(filepath.Join(specDir, "output.txt")).ShouldNot(BeAnExistingFile())
+			Ω("output.txt").ShouldNot(BeAnExistingFile())
+		})
+	})
+
+	Describe("restoring the environment", func() {
+		BeforeEach(func() {
+			os.Setenv("GINKGO_SPEC_CHANGED", "before")
+			os.Setenv("GINKGO_SPEC_UNSET", "before")
+		})
+
+		AfterEach(func() {
+			os.Unsetenv("GINKGO_SPEC_CHANGED")
+			os.Unsetenv("GINKGO_SPEC_UNSET")
+			os.Unsetenv("GINKGO_SPEC_SET")
+		})
+
+		It("should restore the environment variables changed by the spec, and report them", func() {
+			spec = New(newItWithBody("it node", func() {
+				os.Setenv("GINKGO_SPEC_SET", "set")
+				os.Setenv("GINKGO_SPEC_CHANGED", "after")
+				os.Unsetenv("GINKGO_SPEC_UNSET")
+			}), containers(), false)
+			spec.SetRestoreEnvironment(true)
+			Ω(spec.Serial()).Should(BeTrue())
+			spec.Run(buffer)
+
+			Ω(os.LookupEnv("GINKGO_SPEC_SET")).Should(BeZero())
+			Ω(os.Getenv("GINKGO_SPEC_CHANGED")).Should(Equal("before"))
+			Ω(os.Getenv("GINKGO_SPEC_UNSET")).Should(Equal("before"))
+
+			entries := spec.Summary("").ReportEntries
+			Ω(entries).Should(HaveLen(1))
+			Ω(entries[0].Name).Should(Equal("Environment leak"))
+			Ω(entries[0].Representation).Should(Equal(`GINKGO_SPEC_CHANGED was changed from "before" to "after"
+GINKGO_SPEC_SET was set to "set"
+GINKGO_SPEC_UNSET was unset, it was "before"`))
+		})
+
+		It("should not report anything when the spec leaves the environment as it was", func() {
+			spec = New(newItWithBody("it node", func() {}), containers(), false)
+			spec.SetRestoreEnvironment(true)
+			spec.Run(buffer)
+
+			Ω(spec.Summary("").ReportEntries).Should(BeEmpty())
+		})
+	})
+
+	Describe("cleaning up only", func() {
+		It("should only run the JustAfterEach and AfterEach nodes, innermost first", func() {
+			spec = New(
+				newIt("it node", noneFlag, false),
+				containers(
+					newContainer("outer", noneFlag, newBef("outer bef", false), newJusBef("outer jus bef", false), newAft("outer aft", false)),
+					newContainer("inner", noneFlag, newBef("inner bef", false), newJusAft("inner jus aft", false), newAft("inner aft", false)),
+				),
+				false,
+			)
+			spec.SetAroundEachNodes([]*leafnodes.AroundEachNode{leafnodes.NewAroundEachNode(func(run func()) {
+				nodesThatRan = append(nodesThatRan, "around")
+				run()
+			}, codeLocation, failer)})
+			spec.SetCleanupOnly(true)
+			spec.Run(buffer)
+
+			Ω(spec.Passed()).Should(BeTrue())
+			Ω(nodesThatRan).Should(Equal([]string{"inner jus aft", "inner aft", "outer aft"}))
+		})
+
+		It("should fail the spec when a teardown node fails", func() {
+			spec = New(newIt("it node", noneFlag, false), containers(newContainer("container", noneFlag, newAft("aft", true))), false)
+			spec.SetCleanupOnly(true)
+			spec.Run(buffer)
+
+			Ω(spec.Failed()).Should(BeTrue())
+			Ω(spec.Summary("").Failure.Message).Should(Equal("aft"))
+		})
+	})
+
+	Describe("runtime budgets", func() {
+		var fake *fakeClock
+
+		BeforeEach(func() {
+			fake = &fakeClock{now: time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)}
+			clock.Set(fake)
+		})
+
+		AfterEach(func() {
+			clock.Set(nil)
+		})
+
+		taking := func(d time.Duration) *leafnodes.ItNode {
+			return leafnodes.NewItNode("it node", func() {
+				fake.now = fake.now.Add(d)
+			}, noneFlag, codelocation.New(0), 0, failer, 0)
+		}
+
+		It("should flag the spec that runs longer than its budget, and let it pass", func() {
+			spec = New(taking(time.Second), containers(), false, "unit")
+			spec.SetRuntimeBudget("unit", 100*time.Millisecond, false)
+			spec.Run(buffer)
+
+			summary := spec.Summary("")
+			Ω(summary.State).Should(Equal(types.SpecStatePassed))
+			Ω(summary.RuntimeBudgetViolation).Should(Equal(&types.RuntimeBudgetViolation{Label: "unit", Budget: 100 * time.Millisecond, RunTime: time.Second}))
+		})
+
+		It("should fail the spec that runs longer than its budget when told to", func() {
+			spec = New(taking(time.Second), containers(), false, "unit")
+			spec.SetRuntimeBudget("unit", 100*time.Millisecond, true)
+			spec.Run(buffer)
+
+			summary := spec.Summary("")
+			Ω(summary.State).Should(Equal(types.SpecStateFailed))
+			Ω(summary.Failure.Message).Should(Equal(`This spec ran for 1s, longer than the 100ms runtime budget of the label "unit"`))
+			Ω(summary.RuntimeBudgetViolation).ShouldNot(BeNil())
+		})
+
+		It("should not flag the spec that runs within its budget", func() {
+			spec = New(taking(50*time.Millisecond), containers(), false, "unit")
+			spec.SetRuntimeBudget("unit", 100*time.Millisecond, true)
+			spec.Run(buffer)
+
+			summary := spec.Summary("")
+			Ω(summary.State).Should(Equal(types.SpecStatePassed))
+			Ω(summary.RuntimeBudgetViolation).Should(BeNil())
+		})
+	})
+
+	Describe("DeferCleanup", func() {
+		deferring := func(text string, cleanups ...string) func() {
+			return func() {
+				nodesThatRan = append(nodesThatRan, text)
+				for _, cleanup := range cleanups {
+					cleanup := cleanup
+					Ω(spec.DeferCleanup(func() {
+						nodesThatRan = append(nodesThatRan, cleanup)
+					}, codeLocation, failer)).Should(BeTrue())
+				}
+			}
+		}
+
+		It("should run the cleanups of each level after its AfterEach nodes, last registered first", func() {
+			spec = New(
+				newItWithBody("it node", deferring("it node", "it cleanup A", "it cleanup B")),
+				containers(
+					newContainer("outer", noneFlag,
+						leafnodes.NewBeforeEachNode(deferring("outer bef", "outer bef cleanup"), codeLocation, 0, failer, 0),
+						newAft("outer aft", false),
+					),
+					newContainer("inner", noneFlag,
+						leafnodes.NewBeforeEachNode(deferring("inner bef", "inner bef cleanup"), codeLocation, 0, failer, 0),
+						newJusAft("inner jus aft", false),
+						leafnodes.NewAfterEachNode(deferring("inner aft", "inner aft cleanup"), codeLocation, 0, failer, 0),
+					),
+				),
+				false,
+			)
+			spec.Run(buffer)
+
+			Ω(spec.Passed()).Should(BeTrue())
+			Ω(nodesThatRan).Should(Equal([]string{
+				"outer bef",
+				"inner bef",
+				"it node",
+				"inner jus aft",
+				"it cleanup B",
+				"it cleanup A",
+				"inner aft",
+				"inner aft cleanup",
+				"inner bef cleanup",
+				"outer aft",
+				"outer bef cleanup",
+			}))
+
+			nodeSummaries := spec.Summary("").NodeSummaries
+			Ω(nodeSummaries[4].ComponentType).Should(Equal(types.SpecComponentTypeDeferCleanup))
+			Ω(nodeSummaries[len(nodeSummaries)-1].ComponentType).Should(Equal(types.SpecComponentTypeDeferCleanup))
+		})
+
+		It("should only run the cleanups of the nodes that ran", func() {
+			spec = New(
+				newIt("it node", noneFlag, false),
+				containers(newContainer("container", noneFlag,
+					leafnodes.NewBeforeEachNode(deferring("bef A", "bef A cleanup"), codeLocation, 0, failer, 0),
+					newBef("bef B", true),
+				)),
+				false,
+			)
+			spec.Run(buffer)
+
+			Ω(spec.Failed()).Should(BeTrue())
+			Ω(nodesThatRan).Should(Equal([]string{"bef A", "bef B", "bef A cleanup"}))
+		})
+
+		It("should not defer cleanups while none of the nodes of the spec runs", func() {
+			spec = New(newIt("it node", noneFlag, false), containers(), false)
+			Ω(spec.DeferCleanup(func() {}, codeLocation, failer)).Should(BeFalse())
+		})
+
+		Context("when a cleanup fails", func() {
+			It("should fail the spec", func() {
+				spec = New(newItWithBody("it node", func() {
+					spec.DeferCleanup(func() { failer.Fail("cleanup", codeLocation) }, codeLocation, failer)
+				}), containers(), false)
+				spec.Run(buffer)
+
+				Ω(spec.Failed()).Should(BeTrue())
+				Ω(spec.Summary("").Failure.ComponentType).Should(Equal(types.SpecComponentTypeDeferCleanup))
+			})
+		})
+
+		Context("when a cleanup panics", func() {
+			BeforeEach(func() {
+				spec = New(newItWithBody("it node", func() {
+					spec.DeferCleanup(func() { panic("boom") }, codeLocation, failer)
+				}), containers(), false)
+			})
+
+			It("should only report the panic", func() {
+				spec.Run(buffer)
+
+				Ω(spec.Passed()).Should(BeTrue())
+				Ω(buffer).Should(gbytes.Say("Ignoring the panic of the cleanup"))
+				Ω(spec.Summary("").NodeSummaries[1].State).Should(Equal(types.SpecStatePanicked))
+			})
+
+			It("should fail the spec when told to", func() {
+				spec.SetFailOnCleanupPanics(true)
+				spec.Run(buffer)
+
+				Ω(spec.Summary("").State).Should(Equal(types.SpecStatePanicked))
+			})
+		})
+	})
+
+	Describe("BeforeNode hooks", func() {
+		var infos []types.NodeInfo
+
+		newHook := func(fail bool) *leafnodes.BeforeNodeHook {
+			return leafnodes.NewBeforeNodeHook(func(info types.NodeInfo) {
+				infos = append(infos, info)
+				nodesThatRan = append(nodesThatRan, "hook")
+				if fail {
+					failer.Fail("hook", codeLocation)
+				}
+			}, codeLocation, failer)
+		}
+
+		BeforeEach(func() {
+			infos = nil
+			spec = New(
+				newIt("it node", noneFlag, false),
+				containers(newContainer("container", noneFlag, newBef("bef A", false), newAft("aft A", false))),
+				false,
+				"slow",
+			)
+		})
+
+		It("should run before every node of the spec, describing the node and its spec", func() {
+			spec.SetBeforeNodeHooks([]*leafnodes.BeforeNodeHook{newHook(false)})
+			spec.SetAroundEachNodes([]*leafnodes.AroundEachNode{leafnodes.NewAroundEachNode(func(run func()) { run() }, codeLocation, failer)})
+			spec.Run(buffer)
+
+			Ω(spec.Passed()).Should(BeTrue())
+			Ω(nodesThatRan).Should(Equal([]string{"hook", "bef A", "hook", "it node", "hook", "aft A"}))
+			Ω(infos).Should(HaveLen(3))
+			Ω(infos[0].ComponentType).Should(Equal(types.SpecComponentTypeBeforeEach))
+			Ω(infos[1].ComponentType).Should(Equal(types.SpecComponentTypeIt))
+			Ω(infos[1].ComponentTexts).Should(Equal([]string{"container", "it node"}))
+			Ω(infos[1].HasLabel("slow")).Should(BeTrue())
+			Ω(infos[2].ComponentType).Should(Equal(types.SpecComponentTypeAfterEach))
+		})
+
+		Context("when a hook fails", func() {
+			It("should fail the node it runs for without running it", func() {
+				spec.SetBeforeNodeHooks([]*leafnodes.BeforeNodeHook{newHook(true), newHook(false)})
+				spec.Run(buffer)
+
+				Ω(spec.Failed()).Should(BeTrue())
+				Ω(spec.Summary("").Failure.Message).Should(Equal("hook"))
+				Ω(spec.Summary("").Failure.ComponentType).Should(Equal(types.SpecComponentTypeBeforeEach))
+				Ω(nodesThatRan).Should(Equal([]string{"hook", "hook"}))
+				Ω(spec.Summary("").NodeSummaries[0].State).Should(Equal(types.SpecStateFailed))
+			})
+		})
+	})
+
+	Describe("specs expected to fail", func() {
+		It("should pass when they fail, keeping their failure aside", func() {
+			spec = New(newIt("it node", noneFlag, true), containers(), false)
+			spec.SetExpectedFailure("ISSUE-123")
+			spec.Run(buffer)
+
+			Ω(spec.Passed()).Should(BeTrue())
+			summary := spec.Summary("")
+			Ω(summary.ExpectedFailureReason).Should(Equal("ISSUE-123"))
+			Ω(summary.ExpectedFailure).ShouldNot(BeNil())
+			Ω(summary.ExpectedFailure.Message).Should(Equal("it node"))
+			Ω(summary.Failure).Should(BeZero())
+		})
+
+		It("should fail when they pass", func() {
+			spec = New(newIt("it node", noneFlag, false), containers(), false)
+			spec.SetExpectedFailure("ISSUE-123")
+			spec.Run(buffer)
+
+			Ω(spec.Failed()).Should(BeTrue())
+			summary := spec.Summary("")
+			Ω(summary.ExpectedFailure).Should(BeNil())
+			Ω(summary.Failure.Message).Should(Equal("This spec is expected to fail (ISSUE-123), but it passed"))
+			Ω(summary.Failure.ComponentType).Should(Equal(types.SpecComponentTypeIt))
+		})
+	})
+
+	Describe("suite invariants", func() {
+		It("should fail the first spec to violate an invariant", func() {
+			polluted := false
+			invariants := []*invariant.Invariant{invariant.New(func() error {
+				if polluted {
+					return errors.New("polluted")
+				}
+				return nil
+			}, codeLocation)}
+
+			clean := New(newIt("clean", noneFlag, false), containers(), false)
+			clean.SetInvariants(invariants)
+			clean.Run(buffer)
+			Ω(clean.Passed()).Should(BeTrue())
+
+			polluter := New(newItWithBody("polluter", func() { polluted = true }), containers(), false)
+			polluter.SetInvariants(invariants)
+			Ω(polluter.Serial()).Should(BeTrue())
+			polluter.Run(buffer)
+			Ω(polluter.Failed()).Should(BeTrue())
+			Ω(polluter.Summary("").Failure.Message).Should(Equal(fmt.Sprintf("This spec is the first to violate the suite invariant registered at %s:\npolluted", codeLocation)))
+
+			victim := New(newIt("victim", noneFlag, false), containers(), false)
+			victim.SetInvariants(invariants)
+			victim.Run(buffer)
+			Ω(victim.Passed()).Should(BeTrue())
+		})
+	})
+
+	Describe("OnFailure hooks", func() {
+		var summaries []*types.SpecSummary
+
+		newHook := func(fail bool) *leafnodes.OnFailureHook {
+			return leafnodes.NewOnFailureHook(func(summary *types.SpecSummary) {
+				summaries = append(summaries, summary)
+				nodesThatRan = append(nodesThatRan, "hook")
+				if fail {
+					failer.Fail("hook", codeLocation)
+				}
+			}, codeLocation, failer)
+		}
+
+		BeforeEach(func() {
+			summaries = nil
+		})
+
+		It("should not run for specs that pass", func() {
+			spec = New(newIt("it node", noneFlag, false), containers(newContainer("container", noneFlag, newAft("aft A", false))), false)
+			spec.SetOnFailureHooks([]*leafnodes.OnFailureHook{newHook(false)})
+			spec.Run(buffer)
+
+			Ω(spec.Passed()).Should(BeTrue())
+			Ω(nodesThatRan).Should(Equal([]string{"it node", "aft A"}))
+		})
+
+		It("should run once the failed spec has unwound, with its failure", func() {
+			spec = New(newIt("it node", noneFlag, true), containers(newContainer("container", noneFlag, newAft("aft A", false))), false)
+			spec.SetOnFailureHooks([]*leafnodes.OnFailureHook{newHook(false), newHook(false)})
+			spec.SetBeforeNodeHooks([]*leafnodes.BeforeNodeHook{leafnodes.NewBeforeNodeHook(func(info types.NodeInfo) {
+				nodesThatRan = append(nodesThatRan, "before "+info.ComponentType.String())
+			}, codeLocation, failer)})
+			spec.Run(buffer)
+
+			Ω(spec.Failed()).Should(BeTrue())
+			Ω(nodesThatRan).Should(Equal([]string{"before It", "it node", "before AfterEach", "aft A", "hook", "hook"}))
+			Ω(summaries).Should(HaveLen(2))
+			Ω(summaries[0].State).Should(Equal(types.SpecStateFailed))
+			Ω(summaries[0].Failure.Message).Should(Equal("it node"))
+			Ω(summaries[0].ComponentTexts).Should(Equal([]string{"container", "it node"}))
+
+			nodeSummaries := spec.Summary("").NodeSummaries
+			Ω(nodeSummaries).Should(HaveLen(4))
+			Ω(nodeSummaries[3].ComponentType).Should(Equal(types.SpecComponentTypeOnFailure))
+		})
+
+		It("should only run once when the suite already ran them for the interrupted spec", func() {
+			spec = New(newItWithBody("it node", func() {
+				spec.RunOnFailureHooks(spec.Summary(""), buffer)
+				failer.Fail("interrupted", codeLocation)
+			}), containers(), false)
+			spec.SetOnFailureHooks([]*leafnodes.OnFailureHook{newHook(false)})
+			spec.Run(buffer)
+
+			Ω(spec.Failed()).Should(BeTrue())
+			Ω(nodesThatRan).Should(Equal([]string{"hook"}))
+		})
+
+		Context("when a hook fails", func() {
+			It("should record an additional failure, keeping the failure of the spec and running the other hooks", func() {
+				spec = New(newIt("it node", noneFlag, true), containers(), false)
+				spec.SetOnFailureHooks([]*leafnodes.OnFailureHook{newHook(true), newHook(false)})
+				spec.Run(buffer)
+
+				summary := spec.Summary("")
+				Ω(summary.Failure.Message).Should(Equal("it node"))
+				Ω(summary.AdditionalFailures).Should(HaveLen(1))
+				Ω(summary.AdditionalFailures[0].Message).Should(Equal("hook"))
+				Ω(summary.AdditionalFailures[0].ComponentType).Should(Equal(types.SpecComponentTypeOnFailure))
+				Ω(nodesThatRan).Should(Equal([]string{"it node", "hook", "hook"}))
+			})
+		})
+	})
+
+	Describe("Steps", func() {
+		It("should record the steps of the spec, in order, and be reset when the spec is run again", func() {
+			stepLocation := types.CodeLocation{FileName: "steps_test.go", LineNumber: 7}
+			spec = New(newItWithBody("it node", func() {
+				spec.RecordStep("first", stepLocation)
+				spec.RecordStep("second", stepLocation)
+			}), containers(), false)
+			Ω(spec.Summary("").Steps).Should(BeEmpty())
+
+			before := time.Now()
+			spec.Run(buffer)
+
+			steps := spec.Summary("").Steps
+			Ω(steps).Should(HaveLen(2))
+			Ω(steps[0].Text).Should(Equal("first"))
+			Ω(steps[0].CodeLocation).Should(Equal(stepLocation))
+			Ω(steps[0].StartTime).Should(BeTemporally(">=", before))
+			Ω(steps[1].Text).Should(Equal("second"))
+			Ω(steps[1].StartTime).Should(BeTemporally(">=", steps[0].StartTime))
+
+			spec.Run(buffer)
+			Ω(spec.Summary("").Steps).Should(HaveLen(2))
+		})
+
+		It("should record the keyword of Gherkin steps, along with their state and run time once complete", func() {
+			stepLocation := types.CodeLocation{FileName: "steps_test.go", LineNumber: 9}
+			spec = New(newItWithBody("it node", func() {
+				complete := spec.RecordGherkinStep("Given", "an account", stepLocation)
+				time.Sleep(10 * time.Millisecond)
+				complete(types.SpecStatePassed)
+				spec.RecordGherkinStep("Then", "the balance", stepLocation)
+			}), containers(), false)
+			spec.Run(buffer)
+
+			steps := spec.Summary("").Steps
+			Ω(steps).Should(HaveLen(2))
+			Ω(steps[0].Keyword).Should(Equal("Given"))
+			Ω(steps[0].Text).Should(Equal("an account"))
+			Ω(steps[0].CodeLocation).Should(Equal(stepLocation))
+			Ω(steps[0].State).Should(Equal(types.SpecStatePassed))
+			Ω(steps[0].RunTime).Should(BeNumerically(">=", 10*time.Millisecond))
+			Ω(steps[1].Keyword).Should(Equal("Then"))
+			Ω(steps[1].State).Should(Equal(types.SpecStateInvalid))
+			Ω(steps[1].RunTime).Should(BeZero())
+		})
+	})
+
+	Describe("additional failures", func() {
+		It("should fail a spec that passed with the first of them, listing the others", func() {
+			locationA := types.CodeLocation{FileName: "goroutine_test.go", LineNumber: 3}
+			locationB := types.CodeLocation{FileName: "goroutine_test.go", LineNumber: 7}
+			spec = New(newItWithBody("it node", func() {
+				spec.RecordAdditionalFailure(types.SpecFailure{Message: "goroutine A failed", Location: locationA})
+				spec.RecordAdditionalFailure(types.SpecFailure{Message: "goroutine B panicked", Location: locationB, ForwardedPanic: "boom"})
+			}), containers(newContainer("container", noneFlag)), false)
+			spec.Run(buffer)
+
+			Ω(spec.Failed()).Should(BeTrue())
+			summary := spec.Summary("")
+			Ω(summary.State).Should(Equal(types.SpecStateFailed))
+			Ω(summary.Failure.Message).Should(Equal("goroutine A failed"))
+			Ω(summary.Failure.Location).Should(Equal(locationA))
+			Ω(summary.Failure.ComponentType).Should(Equal(types.SpecComponentTypeIt))
+			Ω(summary.Failure.ComponentIndex).Should(Equal(1))
+			Ω(summary.AdditionalFailures).Should(HaveLen(1))
+			Ω(summary.AdditionalFailures[0].ForwardedPanic).Should(Equal("boom"))
+		})
+
+		It("should list all of them alongside the failure of a spec that failed", func() {
+			spec = New(newItWithBody("it node", func() {
+				spec.RecordAdditionalFailure(types.SpecFailure{Message: "goroutine failed"})
+				failer.Fail("it failed", codeLocation)
+			}), containers(), false)
+			spec.Run(buffer)
+
+			summary := spec.Summary("")
+			Ω(summary.Failure.Message).Should(Equal("it failed"))
+			Ω(summary.AdditionalFailures).Should(HaveLen(1))
+			Ω(summary.AdditionalFailures[0].Message).Should(Equal("goroutine failed"))
+		})
+
+		It("should be reset when the spec is run again", func() {
+			first := true
+			spec = New(newItWithBody("it node", func() {
+				if first {
+					spec.RecordAdditionalFailure(types.SpecFailure{Message: "goroutine failed"})
+				}
+				first = false
+			}), containers(), false)
+			spec.Run(buffer)
+			Ω(spec.Failed()).Should(BeTrue())
+
+			spec.Run(buffer)
+			Ω(spec.Passed()).Should(BeTrue())
+			Ω(spec.Summary("").AdditionalFailures).Should(BeEmpty())
+		})
+	})
+
+	Describe("report entries", func() {
+		It("should record the report entries of the spec, in order, and be reset when the spec is run again", func() {
+			spec = New(newItWithBody("it node", func() {
+				spec.AddReportEntry(types.ReportEntry{Name: "first"})
+				spec.AddReportEntry(types.ReportEntry{Name: "second"})
+			}), containers(), false)
+			spec.Run(buffer)
+
+			entries := spec.Summary("").ReportEntries
+			Ω(entries).Should(HaveLen(2))
+			Ω(entries[0].Name).Should(Equal("first"))
+			Ω(entries[1].Name).Should(Equal("second"))
+
+			spec.Run(buffer)
+			Ω(spec.Summary("").ReportEntries).Should(HaveLen(2))
+		})
+	})
+
+	Describe("ProgressReport", func() {
+		It("should describe the running node, the last step and the attached progress reporters", func() {
+			stepLocation := types.CodeLocation{FileName: "steps_test.go", LineNumber: 7}
+			var report types.ProgressReport
+			before := time.Now()
+			spec = New(newItWithBody("it node", func(ctx SpecContext) {
+				spec.RecordStep("polling", stepLocation)
+				ctx.AttachProgressReporter(func() string { return "3/5 nodes ready" })
+				report = spec.ProgressReport()
+			}), containers(newContainer("container", noneFlag, newBef("bef A", false))), false)
+			spec.Run(buffer)
+
+			Ω(report.ComponentTexts).Should(Equal([]string{"container", "it node"}))
+			Ω(report.CurrentNodeType).Should(Equal(types.SpecComponentTypeIt))
+			Ω(report.CurrentNodeCodeLocation).Should(Equal(codeLocation))
+			Ω(report.CurrentNodeStartTime).Should(BeTemporally(">=", before))
+			Ω(report.CurrentStep.Text).Should(Equal("polling"))
+			Ω(report.AdditionalReports).Should(Equal([]string{"3/5 nodes ready"}))
+		})
+
+		It("should not describe a node when none is running", func() {
+			spec = New(newIt("it node", noneFlag, false), containers(), false)
+			spec.Run(buffer)
+
+			report := spec.ProgressReport()
+			Ω(report.ComponentTexts).Should(Equal([]string{"it node"}))
+			Ω(report.CurrentNodeStartTime).Should(BeZero())
+			Ω(report.CurrentStep).Should(BeNil())
+			Ω(report.AdditionalReports).Should(BeEmpty())
+		})
+	})
+
 	Describe("Summaries for measurements", func() {
 		var summary *types.SpecSummary
 
@@ -1,10 +1,12 @@
 package spec
 
 import (
+	"fmt"
 	"math/rand"
 	"regexp"
 	"sort"
 	"strings"
+	"time"
 )
 
 type Specs struct {
@@ -47,12 +49,58 @@ func (e *Specs) Shuffle(r *rand.Rand) {
 	e.names = names
 }
 
+//SortByExpectedRunTime moves the specs with the longest expected run time to the front, keeping the relative order of specs with equal
+//expectations.  Specs without an expected run time are assumed to be slow and go first.
+func (e *Specs) SortByExpectedRunTime(expectedRunTime func(*Spec) (time.Duration, bool)) {
+	type expectation struct {
+		runTime time.Duration
+		known   bool
+	}
+	expectations := make(map[*Spec]expectation, len(e.specs))
+	for _, spec := range e.specs {
+		runTime, known := expectedRunTime(spec)
+		expectations[spec] = expectation{runTime, known}
+	}
+
+	indices := make([]int, len(e.specs))
+	for i := range indices {
+		indices[i] = i
+	}
+	sort.SliceStable(indices, func(i, j int) bool {
+		a, b := expectations[e.specs[indices[i]]], expectations[e.specs[indices[j]]]
+		if a.known != b.known {
+			return !a.known
+		}
+		return a.runTime > b.runTime
+	})
+
+	sortedSpecs := make([]*Spec, len(e.specs))
+	names := make([]string, len(e.specs))
+	for i, j := range indices {
+		sortedSpecs[i] = e.specs[j]
+		names[i] = e.names[j]
+	}
+	e.specs = sortedSpecs
+	e.names = names
+}
+
+//ApplyFocus skips the specs left out by the focus and skip patterns or, when there are none, by programmatic focus.
+//Each spec records which rule applied to it, see types.SpecSummary.FilterReason.
 func (e *Specs) ApplyFocus(description string, focus, skip []string) {
 	if len(focus)+len(skip) == 0 {
 		e.applyProgrammaticFocus()
 	} else {
 		e.applyRegExpFocusAndSkip(description, focus, skip)
 	}
+
+	for _, spec := range e.specs {
+		if spec.Pending() {
+			spec.filterReason = "it is pending"
+			if spec.pendingReason != "" {
+				spec.filterReason += ": " + spec.pendingReason
+			}
+		}
+	}
 }
 
 func (e *Specs) applyProgrammaticFocus() {
@@ -66,8 +114,11 @@ func (e *Specs) applyProgrammaticFocus() {
 
 	if e.hasProgrammaticFocus {
 		for _, spec := range e.specs {
-			if !spec.Focused() {
+			if spec.Focused() {
+				spec.filterReason = "it is programmatically focused"
+			} else {
 				spec.Skip()
+				spec.filterReason = "other specs are programmatically focused"
 			}
 		}
 	}
@@ -117,13 +168,56 @@ func (e *Specs) applyRegExpFocusAndSkip(description string, focus, skip []string
 		if !matchesFocus || matchesSkip {
 			spec.Skip()
 		}
+
+		if matchesSkip {
+			spec.filterReason = fmt.Sprintf("it matches the skip pattern %q", firstMatch(skip, toMatch))
+		} else if !matchesFocus {
+			spec.filterReason = "it matches none of the focus patterns"
+		} else if focusFilter != nil {
+			spec.filterReason = fmt.Sprintf("it matches the focus pattern %q", firstMatch(focus, toMatch))
+		} else {
+			spec.filterReason = "it matches none of the skip patterns"
+		}
+	}
+}
+
+//firstMatch returns the first of patterns that matches toMatch.  Patterns are only ever matched together, ORed, so
+//failing to find one on its own falls back to all of them.
+func firstMatch(patterns []string, toMatch []byte) string {
+	for _, pattern := range patterns {
+		if re, err := regexp.Compile(pattern); err == nil && re.Match(toMatch) {
+			return pattern
+		}
 	}
+	return strings.Join(patterns, "|")
 }
 
 func (e *Specs) SkipMeasurements() {
 	for _, spec := range e.specs {
 		if spec.IsMeasurement() {
 			spec.Skip()
+			spec.filterReason = "it is a measurement and -skipMeasurements is set"
+		}
+	}
+}
+
+//SkipMissingFeatures skips the specs that would otherwise run but require a feature missing from enabled, naming the first
+//such feature as the reason they are skipped
+func (e *Specs) SkipMissingFeatures(enabled []string) {
+	available := map[string]bool{}
+	for _, feature := range enabled {
+		available[feature] = true
+	}
+	for _, spec := range e.specs {
+		if spec.Skipped() || spec.Pending() {
+			continue
+		}
+		for _, feature := range spec.features {
+			if !available[feature] {
+				spec.filterReason = fmt.Sprintf("it requires the feature %q, which -features does not enable", feature)
+				spec.SkipWithReason(fmt.Sprintf("Requires the feature %q, which -features does not enable", feature))
+				break
+			}
 		}
 	}
 }
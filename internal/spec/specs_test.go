@@ -2,6 +2,7 @@ package spec_test
 
 import (
 	"math/rand"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/internal/spec"
@@ -72,6 +73,14 @@ var _ = Describe("Specs", func() {
 		return texts
 	}
 
+	filterReasons := func(specs *Specs) []string {
+		reasons := []string{}
+		for _, spec := range specs.Specs() {
+			reasons = append(reasons, spec.Summary("").FilterReason)
+		}
+		return reasons
+	}
+
 	Describe("Shuffling specs", func() {
 		It("should shuffle the specs using the passed in randomizer", func() {
 			specs17 := newSpecs("C", noneFlag, "A", noneFlag, "B", noneFlag)
@@ -108,6 +117,23 @@ var _ = Describe("Specs", func() {
 		})
 	})
 
+	Describe("Sorting specs by expected run time", func() {
+		It("should run the slowest specs first, then those with no expectation, keeping ties in order", func() {
+			specs = newSpecs("fast", noneFlag, "unknown", noneFlag, "slow", noneFlag, "also-fast", noneFlag, "also-unknown", noneFlag)
+			runTimes := map[string]time.Duration{
+				"fast":      time.Second,
+				"also-fast": time.Second,
+				"slow":      time.Minute,
+			}
+			specs.SortByExpectedRunTime(func(spec *Spec) (time.Duration, bool) {
+				runTime, ok := runTimes[spec.ConcatenatedString()]
+				return runTime, ok
+			})
+
+			Ω(specTexts(specs)).Should(Equal([]string{"unknown", "also-unknown", "slow", "fast", "also-fast"}))
+		})
+	})
+
 	Describe("with no programmatic focus", func() {
 		BeforeEach(func() {
 			specs = newSpecs("A1", noneFlag, "A2", noneFlag, "B1", noneFlag, "B2", pendingFlag)
@@ -146,6 +172,15 @@ var _ = Describe("Specs", func() {
 			It("should report as having programmatic specs", func() {
 				Ω(specs.HasProgrammaticFocus()).Should(BeTrue())
 			})
+
+			It("should record why each spec runs or is skipped", func() {
+				Ω(filterReasons(specs)).Should(Equal([]string{
+					"it is programmatically focused",
+					"other specs are programmatically focused",
+					"it is programmatically focused",
+					"other specs are programmatically focused",
+				}))
+			})
 		})
 
 		Context("with a focus regexp", func() {
@@ -174,6 +209,15 @@ var _ = Describe("Specs", func() {
 				Ω(skippedTexts(specs)).Should(Equal([]string{"A1", "A2"}))
 				Ω(pendingTexts(specs)).Should(Equal([]string{"B2"}))
 			})
+
+			It("should record why each spec runs, is skipped or is pending", func() {
+				Ω(filterReasons(specs)).Should(Equal([]string{
+					"it matches none of the focus patterns",
+					"it matches none of the focus patterns",
+					`it matches the focus pattern "B"`,
+					"it is pending",
+				}))
+			})
 		})
 
 		Context("with a description", func() {
@@ -230,6 +274,15 @@ var _ = Describe("Specs", func() {
 				Ω(pendingTexts(specs)).Should(BeEmpty())
 			})
 
+			It("should record the skip pattern over the focus patterns", func() {
+				Ω(filterReasons(specs)).Should(Equal([]string{
+					`it matches the focus pattern "1"`,
+					"it matches none of the focus patterns",
+					`it matches the skip pattern "B"`,
+					`it matches the skip pattern "B"`,
+				}))
+			})
+
 			It("should not report as having programmatic specs", func() {
 				Ω(specs.HasProgrammaticFocus()).Should(BeFalse())
 			})
@@ -289,4 +342,31 @@ var _ = Describe("Specs", func() {
 			Ω(pendingTexts(specs)).Should(Equal([]string{"C"}))
 		})
 	})
+
+	Describe("skipping the specs requiring missing features", func() {
+		BeforeEach(func() {
+			a, b, c, d := newSpec("A", noneFlag), newSpec("B", noneFlag), newSpec("C", noneFlag), newSpec("D", pendingFlag)
+			b.SetRequiredFeatures([]string{"gpu"})
+			c.SetRequiredFeatures([]string{"gpu", "ipv6"})
+			d.SetRequiredFeatures([]string{"ipv6"})
+			specs = NewSpecs([]*Spec{a, b, c, d})
+		})
+
+		It("should skip the specs requiring a feature that is not enabled, naming it", func() {
+			specs.SkipMissingFeatures([]string{"gpu"})
+
+			Ω(willRunTexts(specs)).Should(Equal([]string{"A", "B"}))
+			Ω(skippedTexts(specs)).Should(Equal([]string{"C"}))
+			Ω(pendingTexts(specs)).Should(Equal([]string{"D"}))
+			Ω(specs.Specs()[2].Summary("").Failure.Message).Should(Equal(`Requires the feature "ipv6", which -features does not enable`))
+			Ω(filterReasons(specs)[2]).Should(Equal(`it requires the feature "ipv6", which -features does not enable`))
+		})
+
+		It("should run the specs whose features are all enabled", func() {
+			specs.SkipMissingFeatures([]string{"ipv6", "gpu"})
+
+			Ω(willRunTexts(specs)).Should(Equal([]string{"A", "B", "C"}))
+			Ω(skippedTexts(specs)).Should(BeEmpty())
+		})
+	})
 })
@@ -1,49 +1,128 @@
 package spec_iterator
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/onsi/ginkgo/internal/spec"
+	"github.com/onsi/ginkgo/types"
 )
 
 type ParallelIterator struct {
-	specs  []*spec.Spec
-	host   string
-	client *http.Client
+	specs         []*spec.Spec
+	pinned        []*spec.Spec
+	pinnedIndex   int
+	free          []*spec.Spec
+	node          int
+	host          string
+	client        *http.Client
+	stopHeartbeat chan struct{}
 }
 
-func NewParallelIterator(specs []*spec.Spec, host string) *ParallelIterator {
+//NewParallelIterator returns an iterator that runs the specs pinned to node first, then pulls the specs that are free to
+//run on any node off of the counter shared by all the nodes.  When the server leases the specs it hands out, the
+//iterator heartbeats the spec it returned until Next is called again.
+func NewParallelIterator(specs []*spec.Spec, total int, node int, host string) *ParallelIterator {
+	pinned, free := PartitionByProcessAffinity(specs, total, node)
 	return &ParallelIterator{
 		specs:  specs,
+		pinned: pinned,
+		free:   free,
+		node:   node,
 		host:   host,
 		client: &http.Client{},
 	}
 }
 
 func (s *ParallelIterator) Next() (*spec.Spec, error) {
-	resp, err := s.client.Get(s.host + "/counter")
+	s.endHeartbeat()
+
+	if s.pinnedIndex < len(s.pinned) {
+		spec := s.pinned[s.pinnedIndex]
+		s.pinnedIndex++
+		return spec, nil
+	}
+
+	counter, err := s.claim()
+	for err == nil && counter.Wait {
+		time.Sleep(counter.HeartbeatInterval)
+		counter, err = s.claim()
+	}
 	if err != nil {
 		return nil, err
 	}
+
+	if counter.Index >= len(s.free) {
+		return nil, ErrClosed
+	}
+
+	spec := s.free[counter.Index]
+	if counter.HeartbeatInterval > 0 {
+		s.startHeartbeat(counter.Index, spec.ConcatenatedString(), counter.HeartbeatInterval)
+	}
+	return spec, nil
+}
+
+//claim claims the next spec off of the counter, which also completes the spec claimed before
+func (s *ParallelIterator) claim() (Counter, error) {
+	var counter Counter
+	resp, err := s.client.Get(fmt.Sprintf("%s/counter?node=%d&total=%d", s.host, s.node, len(s.free)))
+	if err != nil {
+		return counter, err
+	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+		return counter, fmt.Errorf("unexpected status code %d", resp.StatusCode)
 	}
 
-	var counter Counter
 	err = json.NewDecoder(resp.Body).Decode(&counter)
-	if err != nil {
-		return nil, err
+	return counter, err
+}
+
+//startHeartbeat heartbeats the lease on the spec at index every interval, starting now, until endHeartbeat is called or
+//the lease is lost
+func (s *ParallelIterator) startHeartbeat(index int, text string, interval time.Duration) {
+	stop := make(chan struct{})
+	s.stopHeartbeat = stop
+	lease := types.RemoteSpecLease{Node: s.node, Index: index, Text: text}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for s.heartbeat(lease) {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (s *ParallelIterator) endHeartbeat() {
+	if s.stopHeartbeat != nil {
+		close(s.stopHeartbeat)
+		s.stopHeartbeat = nil
 	}
+}
 
-	if counter.Index >= len(s.specs) {
-		return nil, ErrClosed
+//heartbeat tells whether the node still holds lease.  Failing to reach the server does not give the lease up.
+func (s *ParallelIterator) heartbeat(lease types.RemoteSpecLease) bool {
+	resp, err := s.client.Post(s.host+"/SpecLease", "application/json", bytes.NewReader(lease.ToJSON()))
+	if err != nil {
+		return true
 	}
+	defer resp.Body.Close()
 
-	return s.specs[counter.Index], nil
+	var state types.RemoteSpecLeaseState
+	if resp.StatusCode != http.StatusOK || json.NewDecoder(resp.Body).Decode(&state) != nil {
+		return true
+	}
+	return state.Held
 }
 
 func (s *ParallelIterator) NumberOfSpecsPriorToIteration() int {
@@ -1,7 +1,9 @@
 package spec_iterator_test
 
 import (
+	"encoding/json"
 	"net/http"
+	"time"
 
 	. "github.com/onsi/ginkgo/internal/spec_iterator"
 	"github.com/onsi/gomega/ghttp"
@@ -37,7 +39,7 @@ var _ = Describe("ParallelSpecIterator", func() {
 
 		server = ghttp.NewServer()
 
-		iterator = NewParallelIterator(specs, "http://"+server.Addr())
+		iterator = NewParallelIterator(specs, 2, 1, "http://"+server.Addr())
 	})
 
 	AfterEach(func() {
@@ -109,4 +111,87 @@ var _ = Describe("ParallelSpecIterator", func() {
 			})
 		})
 	})
+
+	Describe("when the server leases the specs", func() {
+		var heartbeats chan types.RemoteSpecLease
+
+		BeforeEach(func() {
+			heartbeats = make(chan types.RemoteSpecLease, 100)
+			server.RouteToHandler("POST", "/SpecLease", func(w http.ResponseWriter, req *http.Request) {
+				var lease types.RemoteSpecLease
+				json.NewDecoder(req.Body).Decode(&lease)
+				heartbeats <- lease
+				json.NewEncoder(w).Encode(types.RemoteSpecLeaseState{Held: true})
+			})
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/counter", "node=1&total=4"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, Counter{Index: 1, HeartbeatInterval: 10 * time.Millisecond}),
+				),
+				ghttp.RespondWithJSONEncoded(http.StatusOK, Counter{Index: 4, Wait: true, HeartbeatInterval: 10 * time.Millisecond}),
+				ghttp.RespondWithJSONEncoded(http.StatusOK, Counter{Index: 4, HeartbeatInterval: 10 * time.Millisecond}),
+			)
+		})
+
+		It("should heartbeat the spec it returned until the next call, and claim again when told to wait", func() {
+			Ω(iterator.Next()).Should(Equal(specs[1]))
+			lease := types.RemoteSpecLease{Node: 1, Index: 1, Text: specs[1].ConcatenatedString()}
+			Eventually(heartbeats).Should(Receive(Equal(lease)))
+			Eventually(heartbeats).Should(Receive(Equal(lease)))
+
+			_, err := iterator.Next()
+			Ω(err).Should(MatchError(ErrClosed))
+			claims := 0
+			for _, req := range server.ReceivedRequests() {
+				if req.URL.Path == "/counter" {
+					claims++
+				}
+			}
+			Ω(claims).Should(Equal(3))
+
+			time.Sleep(50 * time.Millisecond)
+			for len(heartbeats) > 0 {
+				<-heartbeats
+			}
+			Consistently(heartbeats, 50*time.Millisecond).ShouldNot(Receive())
+		})
+	})
+
+	Describe("with specs pinned to a process", func() {
+		BeforeEach(func() {
+			container := containernode.New("container", types.FlagTypeNone, codelocation.New(0))
+			container.SetProcessAffinity(1)
+			pinned := func(text string) *spec.Spec {
+				subject := leafnodes.NewItNode(text, func() {}, types.FlagTypeNone, codelocation.New(0), 0, nil, 0)
+				return spec.New(subject, []*containernode.ContainerNode{container}, false)
+			}
+			specs = []*spec.Spec{
+				newSpec("A", types.FlagTypeNone),
+				pinned("B"),
+				newSpec("C", types.FlagTypeNone),
+				pinned("D"),
+			}
+			server.AppendHandlers(
+				ghttp.RespondWithJSONEncoded(http.StatusOK, Counter{Index: 1}),
+				ghttp.RespondWithJSONEncoded(http.StatusOK, Counter{Index: 2}),
+			)
+		})
+
+		It("runs the specs pinned to the process first, then pulls the others off of the counter", func() {
+			iterator = NewParallelIterator(specs, 2, 1, "http://"+server.Addr())
+			Ω(iterator.Next()).Should(Equal(specs[1]))
+			Ω(iterator.Next()).Should(Equal(specs[3]))
+			Ω(iterator.Next()).Should(Equal(specs[2]))
+			_, err := iterator.Next()
+			Ω(err).Should(MatchError(ErrClosed))
+			Ω(server.ReceivedRequests()).Should(HaveLen(2))
+		})
+
+		It("leaves the specs pinned to other processes out of the counter", func() {
+			iterator = NewParallelIterator(specs, 2, 2, "http://"+server.Addr())
+			Ω(iterator.Next()).Should(Equal(specs[2]))
+			_, err := iterator.Next()
+			Ω(err).Should(MatchError(ErrClosed))
+		})
+	})
 })
@@ -0,0 +1,19 @@
+package spec_iterator
+
+import "github.com/onsi/ginkgo/internal/spec"
+
+//PartitionByProcessAffinity splits specs into the specs pinned to parallelNode and the specs that are free to run on any
+//node, preserving their order.  Specs pinned to other nodes are left out.  Specs pinned to a node beyond parallelTotal are
+//pinned to that node modulo parallelTotal.
+func PartitionByProcessAffinity(specs []*spec.Spec, parallelTotal int, parallelNode int) (pinned []*spec.Spec, free []*spec.Spec) {
+	for _, spec := range specs {
+		process := spec.ProcessAffinity()
+		switch {
+		case process == 0:
+			free = append(free, spec)
+		case (process-1)%parallelTotal+1 == parallelNode:
+			pinned = append(pinned, spec)
+		}
+	}
+	return pinned, free
+}
@@ -4,17 +4,21 @@ import "github.com/onsi/ginkgo/internal/spec"
 
 type ShardedParallelIterator struct {
 	specs    []*spec.Spec
+	toRun    []*spec.Spec
 	index    int
 	maxIndex int
 }
 
 func NewShardedParallelIterator(specs []*spec.Spec, total int, node int) *ShardedParallelIterator {
-	startIndex, count := ParallelizedIndexRange(len(specs), total, node)
+	pinned, free := PartitionByProcessAffinity(specs, total, node)
+	startIndex, count := ParallelizedIndexRange(len(free), total, node)
+	toRun := append(pinned, free[startIndex:startIndex+count]...)
 
 	return &ShardedParallelIterator{
 		specs:    specs,
-		index:    startIndex,
-		maxIndex: startIndex + count,
+		toRun:    toRun,
+		index:    0,
+		maxIndex: len(toRun),
 	}
 }
 
@@ -23,7 +27,7 @@ func (s *ShardedParallelIterator) Next() (*spec.Spec, error) {
 		return nil, ErrClosed
 	}
 
-	spec := s.specs[s.index]
+	spec := s.toRun[s.index]
 	s.index += 1
 	return spec, nil
 }
@@ -39,7 +43,7 @@ func (s *ShardedParallelIterator) NumberOfSpecsToProcessIfKnown() (int, bool) {
 func (s *ShardedParallelIterator) NumberOfSpecsThatWillBeRunIfKnown() (int, bool) {
 	count := 0
 	for i := s.index; i < s.maxIndex; i += 1 {
-		if !s.specs[i].Skipped() && !s.specs[i].Pending() {
+		if !s.toRun[i].Skipped() && !s.toRun[i].Pending() {
 			count += 1
 		}
 	}
@@ -59,4 +59,45 @@ var _ = Describe("ShardedParallelSpecIterator", func() {
 			Ω(err).Should(MatchError(ErrClosed))
 		})
 	})
+
+	Describe("with specs pinned to a process", func() {
+		newPinnedSpec := func(text string, process int) *spec.Spec {
+			container := containernode.New("container", types.FlagTypeNone, codelocation.New(0))
+			container.SetProcessAffinity(process)
+			subject := leafnodes.NewItNode(text, func() {}, types.FlagTypeNone, codelocation.New(0), 0, nil, 0)
+			return spec.New(subject, []*containernode.ContainerNode{container}, false)
+		}
+
+		BeforeEach(func() {
+			specs = []*spec.Spec{
+				newSpec("A", types.FlagTypeNone),
+				newPinnedSpec("B", 2),
+				newSpec("C", types.FlagTypeNone),
+				newPinnedSpec("D", 1),
+				newPinnedSpec("E", 4),
+				newSpec("F", types.FlagTypeNone),
+			}
+		})
+
+		It("runs the specs pinned to the process first, then its share of the others", func() {
+			iterator = NewShardedParallelIterator(specs, 3, 1)
+			Ω(iterator.NumberOfSpecsPriorToIteration()).Should(Equal(6))
+			Ω(iterator.Next()).Should(Equal(specs[3]))
+			Ω(iterator.Next()).Should(Equal(specs[4]))
+			Ω(iterator.Next()).Should(Equal(specs[0]))
+			_, err := iterator.Next()
+			Ω(err).Should(MatchError(ErrClosed))
+		})
+
+		It("does not run specs pinned to other processes", func() {
+			iterator = NewShardedParallelIterator(specs, 3, 2)
+			n, known := iterator.NumberOfSpecsToProcessIfKnown()
+			Ω(n).Should(Equal(2))
+			Ω(known).Should(BeTrue())
+			Ω(iterator.Next()).Should(Equal(specs[1]))
+			Ω(iterator.Next()).Should(Equal(specs[2]))
+			_, err := iterator.Next()
+			Ω(err).Should(MatchError(ErrClosed))
+		})
+	})
 })
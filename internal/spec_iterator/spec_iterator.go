@@ -2,6 +2,7 @@ package spec_iterator
 
 import (
 	"errors"
+	"time"
 
 	"github.com/onsi/ginkgo/internal/spec"
 )
@@ -17,4 +18,9 @@ type SpecIterator interface {
 
 type Counter struct {
 	Index int `json:"index"`
+	//Wait tells the node to claim again after HeartbeatInterval: the other nodes hold leases on the last specs, which
+	//may expire
+	Wait bool `json:"wait,omitempty"`
+	//HeartbeatInterval is how often the node heartbeats the spec it claimed, zero when specs are not leased
+	HeartbeatInterval time.Duration `json:"heartbeatInterval,omitempty"`
 }
@@ -0,0 +1,142 @@
+/*
+Package speclease keeps track of the specs the parallel nodes of a suite run claim off of the counter they share, so that
+the spec claimed by a node that neither completes it nor heartbeats within the lease timeout is handed to another node.
+
+The server run by the Ginkgo CLI owns the Ledger.  A node completes the spec it holds by claiming the next one from
+/counter, and heartbeats through /SpecLease while it runs it.
+*/
+package speclease
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/onsi/ginkgo/types"
+)
+
+type Ledger struct {
+	lock      *sync.Mutex
+	timeout   time.Duration
+	alive     func(node int) bool
+	next      int
+	leases    map[int]*lease
+	abandoned []types.AbandonedSpecAttempt
+}
+
+//lease records that node runs the spec at the index it is filed under
+type lease struct {
+	node      int
+	text      string
+	claimedAt time.Time
+	expiresAt time.Time
+}
+
+//NewLedger returns a ledger whose leases expire once timeout has passed without a heartbeat, or as soon as alive tells
+//that the node holding them is gone.  A ledger without a timeout merely counts.
+func NewLedger(timeout time.Duration, alive func(node int) bool) *Ledger {
+	return &Ledger{
+		lock:    &sync.Mutex{},
+		timeout: timeout,
+		alive:   alive,
+		leases:  map[int]*lease{},
+	}
+}
+
+//HeartbeatInterval is how often the nodes heartbeat the spec they hold: often enough that a couple of lost heartbeats do
+//not let its lease expire
+func (l *Ledger) HeartbeatInterval() time.Duration {
+	return l.timeout / 3
+}
+
+/*
+Claim completes the lease node holds, if any, and returns the index of the spec it is to run next, among the total
+specs the nodes share:
+
+  - the spec of the first expired lease, whose attempt is recorded as abandoned
+  - or else the next spec off of the counter
+  - or else, once the counter has gone past the last spec while other nodes still hold leases that may expire, wait
+    tells node to claim again later
+
+Without a timeout, or for node 0 (a node that does not take leases), Claim merely counts.
+*/
+func (l *Ledger) Claim(node int, total int) (index int, wait bool) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.timeout <= 0 || node == 0 {
+		index = l.next
+		l.next++
+		return index, false
+	}
+
+	for i, lease := range l.leases {
+		if lease.node == node {
+			delete(l.leases, i)
+		}
+	}
+
+	now := time.Now()
+	if i, expired := l.expiredLease(now); expired != nil {
+		l.abandoned = append(l.abandoned, types.AbandonedSpecAttempt{
+			Node:         expired.node,
+			Text:         expired.text,
+			ClaimedAt:    expired.claimedAt,
+			AbandonedAt:  now,
+			ReassignedTo: node,
+		})
+		l.leases[i] = &lease{node: node, text: expired.text, claimedAt: now, expiresAt: now.Add(l.timeout)}
+		return i, false
+	}
+
+	if total <= 0 || l.next < total {
+		index = l.next
+		l.next++
+		l.leases[index] = &lease{node: node, claimedAt: now, expiresAt: now.Add(l.timeout)}
+		return index, false
+	}
+	return l.next, len(l.leases) > 0
+}
+
+//expiredLease returns the expired lease with the lowest index, if any.  The lock must be held.
+func (l *Ledger) expiredLease(now time.Time) (int, *lease) {
+	indices := []int{}
+	for i := range l.leases {
+		indices = append(indices, i)
+	}
+	sort.Ints(indices)
+
+	for _, i := range indices {
+		lease := l.leases[i]
+		if now.After(lease.expiresAt) || (l.alive != nil && !l.alive(lease.node)) {
+			return i, lease
+		}
+	}
+	return 0, nil
+}
+
+//Heartbeat extends the lease node holds on the spec at index, whose text it records for the abandoned attempts, and
+//tells whether node still holds it: a node whose lease expired has lost the spec to another node
+func (l *Ledger) Heartbeat(node int, index int, text string) bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	lease, ok := l.leases[index]
+	if !ok || lease.node != node {
+		return false
+	}
+	lease.text = text
+	lease.expiresAt = time.Now().Add(l.timeout)
+	return true
+}
+
+//Abandoned returns the attempts whose leases expired, in the order their specs were reassigned
+func (l *Ledger) Abandoned() []types.AbandonedSpecAttempt {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if len(l.abandoned) == 0 {
+		return nil
+	}
+	return append([]types.AbandonedSpecAttempt{}, l.abandoned...)
+}
@@ -0,0 +1,13 @@
+package speclease_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestSpecLease(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "SpecLease Suite")
+}
@@ -0,0 +1,87 @@
+package speclease_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/internal/speclease"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Ledger", func() {
+	var ledger *Ledger
+	var dead map[int]bool
+
+	claim := func(node int) (int, bool) {
+		return ledger.Claim(node, 3)
+	}
+
+	BeforeEach(func() {
+		dead = map[int]bool{}
+		ledger = NewLedger(100*time.Millisecond, func(node int) bool { return !dead[node] })
+	})
+
+	It("should hand out the specs in order, then tell the nodes to wait while leases are held", func() {
+		Ω(claim(1)).Should(Equal(0))
+		Ω(claim(2)).Should(Equal(1))
+		Ω(claim(1)).Should(Equal(2))
+
+		index, wait := claim(2)
+		Ω(index).Should(Equal(3))
+		Ω(wait).Should(BeTrue())
+
+		claim(1)
+		index, wait = claim(2)
+		Ω(index).Should(Equal(3))
+		Ω(wait).Should(BeFalse())
+		Ω(ledger.Abandoned()).Should(BeEmpty())
+	})
+
+	It("should keep the leases that are heartbeated", func() {
+		Ω(claim(1)).Should(Equal(0))
+		for i := 0; i < 3; i++ {
+			time.Sleep(60 * time.Millisecond)
+			Ω(ledger.Heartbeat(1, 0, "[Top Level] A")).Should(BeTrue())
+		}
+		Ω(claim(2)).Should(Equal(1))
+		Ω(ledger.Abandoned()).Should(BeEmpty())
+	})
+
+	It("should hand the spec of an expired lease to the next node claiming one, and record the abandoned attempt", func() {
+		Ω(claim(1)).Should(Equal(0))
+		Ω(ledger.Heartbeat(1, 0, "[Top Level] A")).Should(BeTrue())
+		time.Sleep(150 * time.Millisecond)
+
+		Ω(claim(2)).Should(Equal(0))
+		Ω(ledger.Heartbeat(1, 0, "[Top Level] A")).Should(BeFalse())
+		Ω(ledger.Heartbeat(2, 0, "[Top Level] A")).Should(BeTrue())
+
+		abandoned := ledger.Abandoned()
+		Ω(abandoned).Should(HaveLen(1))
+		Ω(abandoned[0].Node).Should(Equal(1))
+		Ω(abandoned[0].Text).Should(Equal("[Top Level] A"))
+		Ω(abandoned[0].ReassignedTo).Should(Equal(2))
+		Ω(abandoned[0].AbandonedAt.Sub(abandoned[0].ClaimedAt)).Should(BeNumerically(">=", 150*time.Millisecond))
+	})
+
+	It("should expire the leases of the nodes that are gone right away", func() {
+		Ω(claim(1)).Should(Equal(0))
+		dead[1] = true
+		Ω(claim(2)).Should(Equal(0))
+		Ω(ledger.Abandoned()).Should(HaveLen(1))
+	})
+
+	It("should merely count without a timeout, or for nodes that do not take leases", func() {
+		ledger = NewLedger(0, nil)
+		Ω(claim(1)).Should(Equal(0))
+		Ω(claim(1)).Should(Equal(1))
+		Ω(ledger.Heartbeat(1, 1, "B")).Should(BeFalse())
+		Ω(ledger.HeartbeatInterval()).Should(BeZero())
+
+		ledger = NewLedger(time.Millisecond, nil)
+		Ω(ledger.Claim(0, 0)).Should(Equal(0))
+		time.Sleep(10 * time.Millisecond)
+		Ω(ledger.Claim(0, 0)).Should(Equal(1))
+		Ω(ledger.Abandoned()).Should(BeEmpty())
+	})
+})
@@ -1,17 +1,34 @@
 package specrunner
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"math/rand"
+	"net/http"
 	"os"
 	"os/signal"
+	"sort"
+	"strings"
 	"sync"
 	"syscall"
 
 	"github.com/onsi/ginkgo/internal/spec_iterator"
 
 	"github.com/onsi/ginkgo/config"
+	"github.com/onsi/ginkgo/formatter"
+	"github.com/onsi/ginkgo/internal/clock"
+	"github.com/onsi/ginkgo/internal/codelocation"
+	"github.com/onsi/ginkgo/internal/dependency"
+	"github.com/onsi/ginkgo/internal/failer"
+	"github.com/onsi/ginkgo/internal/fixture"
+	"github.com/onsi/ginkgo/internal/lanes"
 	"github.com/onsi/ginkgo/internal/leafnodes"
+	"github.com/onsi/ginkgo/internal/randomseed"
+	"github.com/onsi/ginkgo/internal/reportermanager"
+	"github.com/onsi/ginkgo/internal/resourcelock"
 	"github.com/onsi/ginkgo/internal/spec"
+	"github.com/onsi/ginkgo/internal/suiteprocess"
 	Writer "github.com/onsi/ginkgo/internal/writer"
 	"github.com/onsi/ginkgo/reporters"
 	"github.com/onsi/ginkgo/types"
@@ -21,60 +38,230 @@ import (
 
 type SpecRunner struct {
 	description     string
+	warmUpNode      leafnodes.SuiteNode
 	beforeSuiteNode leafnodes.SuiteNode
 	iterator        spec_iterator.SpecIterator
 	afterSuiteNode  leafnodes.SuiteNode
-	reporters       []reporters.Reporter
+	reporters       *reportermanager.Manager
 	startTime       time.Time
 	suiteID         string
 	runningSpec     *spec.Spec
+	runningSpecs    map[int64]*spec.Spec
+	timedOutSpec    *spec.Spec
 	writer          Writer.WriterInterface
 	config          config.GinkgoConfigType
+	failOnSeverity  types.Severity
 	interrupted     bool
 	processedSpecs  []*spec.Spec
 	lock            *sync.Mutex
+	sharedFixtures  []*fixture.Fixture
+	resourceLocker  *resourcelock.Locker
+	dependencies    *dependency.Tracker
+	suiteProcesses  *suiteprocess.Manager
+	deadline        time.Time
+	warnings        []string
+	specsToRun      int
+	failer          *failer.Failer
+	reportLock      *sync.Mutex
+	crashOnce       *sync.Once
+
+	textTransformers      []func(string) string
+	reportAfterSuiteNodes []leafnodes.SuiteNode
+
+	specialSuiteFailureReasons []types.SpecialSuiteFailureReason
+	abortedByOtherProcess      bool
+	abortedBySpec              bool
+	failedBeforeRunning        bool
+
+	interrupts   chan types.SpecialSuiteFailureReason
+	shutDownDone chan struct{}
+	suiteEnded   chan struct{}
 }
 
+//laneRouter is implemented by the failer and the writer, which route what each spec running concurrently records to a
+//lane of its own
+type laneRouter interface {
+	OpenLane()
+	CloseLane()
+}
+
+//writerSessions is implemented by the writer, which tells apart what the goroutines started by a spec write once the
+//spec has run
+type writerSessions interface {
+	TracksSessions() bool
+	BeginSession(spec string)
+	EndSession()
+}
+
+//maxDeadlineGracePeriod bounds how long before the go test deadline the runner interrupts the suite.  The runner
+//leaves itself a tenth of the time remaining when the suite starts, up to this bound, to run AfterSuite and write reports.
+const maxDeadlineGracePeriod = 5 * time.Second
+
 func New(description string, beforeSuiteNode leafnodes.SuiteNode, iterator spec_iterator.SpecIterator, afterSuiteNode leafnodes.SuiteNode, reporters []reporters.Reporter, writer Writer.WriterInterface, config config.GinkgoConfigType) *SpecRunner {
+	//RunSpecs rejects invalid -failOnSeverity values, leaving only the empty one, which fails on every severity
+	failOnSeverity, _ := types.ParseSeverity(config.FailOnSeverity)
 	return &SpecRunner{
 		description:     description,
 		beforeSuiteNode: beforeSuiteNode,
 		iterator:        iterator,
 		afterSuiteNode:  afterSuiteNode,
-		reporters:       reporters,
+		reporters:       reportermanager.New(reporters),
 		writer:          writer,
 		config:          config,
+		failOnSeverity:  failOnSeverity,
 		suiteID:         randomID(),
+		specsToRun:      -1,
 		lock:            &sync.Mutex{},
+		runningSpecs:    map[int64]*spec.Spec{},
+		reportLock:      &sync.Mutex{},
+		crashOnce:       &sync.Once{},
+		interrupts:      make(chan types.SpecialSuiteFailureReason, 1),
+		shutDownDone:    make(chan struct{}),
+		suiteEnded:      make(chan struct{}),
 	}
 }
 
+//SetFailer hands the runner the failer of the suite, so that it can keep the failures of specs running concurrently apart
+func (runner *SpecRunner) SetFailer(failer *failer.Failer) {
+	runner.failer = failer
+}
+
+//SetSharedFixtures hands the runner the shared fixtures to release after the specs that declare them and to tear
+//down at the end of the suite
+func (runner *SpecRunner) SetSharedFixtures(sharedFixtures []*fixture.Fixture) {
+	runner.sharedFixtures = sharedFixtures
+}
+
+//SetTextTransformers hands the runner the transformers to apply, in order, to the texts of the specs it reports
+func (runner *SpecRunner) SetTextTransformers(textTransformers []func(string) string) {
+	runner.textTransformers = textTransformers
+}
+
+//SetWarmUpNode hands the runner the node to run before the suite begins, see runWarmUp
+func (runner *SpecRunner) SetWarmUpNode(warmUpNode leafnodes.SuiteNode) {
+	runner.warmUpNode = warmUpNode
+}
+
+//SetReportAfterSuiteNodes hands the runner the ReportAfterSuite nodes, in the order they run once the suite is done
+func (runner *SpecRunner) SetReportAfterSuiteNodes(nodes []leafnodes.SuiteNode) {
+	runner.reportAfterSuiteNodes = nodes
+}
+
+//SetWarnings hands the runner the warnings about the configuration of the suite run to include in its summaries
+func (runner *SpecRunner) SetWarnings(warnings []string) {
+	runner.warnings = warnings
+}
+
+//SetNumberOfSpecsToRun tells the runner how many specs run across all the parallel nodes, so that it fails the suite
+//when there are none and the suite runs with -failOnEmpty
+func (runner *SpecRunner) SetNumberOfSpecsToRun(specsToRun int) {
+	runner.specsToRun = specsToRun
+}
+
+//FailSuite makes the suite fail, for a reason found before it runs, even if all its specs pass
+func (runner *SpecRunner) FailSuite(cause types.InterruptCause, message string) {
+	runner.recordSpecialSuiteFailureReason(cause, message)
+	runner.failedBeforeRunning = true
+}
+
+//SetResourceLocker hands the runner the locker holding the resources required by each spec while it runs
+func (runner *SpecRunner) SetResourceLocker(resourceLocker *resourcelock.Locker) {
+	runner.resourceLocker = resourceLocker
+}
+
+//SetDependencies hands the runner the tracker of the specs each spec depends on
+func (runner *SpecRunner) SetDependencies(dependencies *dependency.Tracker) {
+	runner.dependencies = dependencies
+}
+
+//SetSuiteProcesses hands the runner the external processes to stop at the end of the suite, or when it is interrupted
+func (runner *SpecRunner) SetSuiteProcesses(suiteProcesses *suiteprocess.Manager) {
+	runner.suiteProcesses = suiteProcesses
+}
+
+//SetDeadline makes the runner interrupt the suite shortly before deadline, the deadline set by go test -timeout.  go test
+//kills the suite without running AfterSuite or writing reports when it reaches its deadline.
+func (runner *SpecRunner) SetDeadline(deadline time.Time) {
+	runner.deadline = deadline
+}
+
 func (runner *SpecRunner) Run() bool {
 	if runner.config.DryRun {
 		runner.performDryRun()
 		return true
 	}
 
+	runner.runWarmUp()
 	runner.reportSuiteWillBegin()
 	signalRegistered := make(chan struct{})
 	go runner.registerForInterrupts(signalRegistered)
 	<-signalRegistered
 
-	suitePassed := runner.runBeforeSuite()
+	//the suite runs in a goroutine of its own so that Run can return once an interrupt requested through Interrupt has
+	//been handled, even if the running spec hangs
+	finished := make(chan bool, 1)
+	go func() {
+		defer runner.flushReportsOnCrash()
+		finished <- runner.runSuite()
+	}()
+
+	select {
+	case suitePassed := <-finished:
+		close(runner.suiteEnded)
+		return suitePassed
+	case <-runner.shutDownDone:
+		return false
+	}
+}
+
+func (runner *SpecRunner) runSuite() bool {
+	suitePassed := runner.reportWarmUp() && runner.runBeforeSuite()
 
 	if suitePassed {
 		suitePassed = runner.runSpecs()
 	}
 
-	runner.blockForeverIfInterrupted()
+	if runner.waitForShutdownIfInterrupted() {
+		return false
+	}
 
 	suitePassed = runner.runAfterSuite() && suitePassed
+	suitePassed = runner.tearDownSharedFixtures() && suitePassed
+	suitePassed = runner.runReportAfterSuites() && suitePassed
+	suitePassed = runner.checkForEmptySuite() && suitePassed
+	suitePassed = !runner.failedBeforeRunning && suitePassed
+	if runner.suiteProcesses != nil {
+		runner.suiteProcesses.Stop()
+	}
 
 	runner.reportSuiteDidEnd(suitePassed)
 
 	return suitePassed
 }
 
+//checkForEmptySuite fails the suite when it runs with -failOnEmpty and no spec was left to run once the filters were
+//applied, as a suite that runs nothing most likely has broken filters
+func (runner *SpecRunner) checkForEmptySuite() bool {
+	if !runner.config.FailOnEmpty || runner.specsToRun != 0 {
+		return true
+	}
+	runner.recordSpecialSuiteFailureReason(types.InterruptCauseEmptySuite, "No spec was left to run once the focus, skip and label filters were applied, and -failOnEmpty is set.")
+	return false
+}
+
+//Interrupt shuts the running suite down the way SIGINT does: the running spec is reported, AfterSuite runs, the shared
+//fixtures are torn down and the reports are written.  Rather than exiting the process, Run then returns false, which
+//lets programs embedding the suite carry on.  Interrupts past the first are ignored.
+func (runner *SpecRunner) Interrupt(cause types.InterruptCause, message string) {
+	if message == "" {
+		message = fmt.Sprintf("Interrupted (%s).", cause)
+	}
+	select {
+	case runner.interrupts <- types.SpecialSuiteFailureReason{Cause: cause, Message: message}:
+	default:
+	}
+}
+
 func (runner *SpecRunner) performDryRun() {
 	runner.reportSuiteWillBegin()
 
@@ -110,11 +297,44 @@ func (runner *SpecRunner) performDryRun() {
 		runner.reportAfterSuite(summary)
 	}
 
+	if runner.config.ParallelNode == 1 {
+		for _, node := range runner.reportAfterSuiteNodes {
+			summary := node.Summary()
+			summary.State = types.SpecStatePassed
+			runner.reportAfterSuite(summary)
+		}
+	}
+
 	runner.reportSuiteDidEnd(true)
 }
 
+//runWarmUp runs the WarmUp node before the suite is reported to begin, so that its duration is left out of the run time
+//of the suite.  A passing warm-up is not reported.
+func (runner *SpecRunner) runWarmUp() {
+	if runner.warmUpNode == nil || runner.config.CleanupOnly {
+		return
+	}
+
+	runner.writer.Truncate()
+	conf := runner.config
+	if !runner.warmUpNode.Run(conf.ParallelNode, conf.ParallelTotal, conf.SyncHost) {
+		runner.writer.DumpOut()
+	}
+}
+
+//reportWarmUp reports a failed warm-up the way a failed BeforeSuite is reported, now that the suite has begun, and
+//tells whether the specs may run
+func (runner *SpecRunner) reportWarmUp() bool {
+	if runner.warmUpNode == nil || runner.warmUpNode.Passed() || runner.config.CleanupOnly {
+		return true
+	}
+	runner.reportBeforeSuite(runner.warmUpNode.Summary())
+	return false
+}
+
+//runBeforeSuite runs the BeforeSuite node, which -cleanupOnly skips, and tells whether the specs may run
 func (runner *SpecRunner) runBeforeSuite() bool {
-	if runner.beforeSuiteNode == nil || runner.wasInterrupted() {
+	if runner.beforeSuiteNode == nil || runner.wasInterrupted() || runner.config.CleanupOnly {
 		return true
 	}
 
@@ -143,9 +363,70 @@ func (runner *SpecRunner) runAfterSuite() bool {
 	return passed
 }
 
+//runReportAfterSuites runs the ReportAfterSuite nodes on parallel node 1, each one whether the previous ones passed or
+//not, and records the failure of each one that fails in the summary of the suite
+func (runner *SpecRunner) runReportAfterSuites() bool {
+	if runner.config.ParallelNode != 1 {
+		return true
+	}
+
+	passed := true
+	conf := runner.config
+	for _, node := range runner.reportAfterSuiteNodes {
+		runner.writer.Truncate()
+		if !node.Run(conf.ParallelNode, conf.ParallelTotal, conf.SyncHost) {
+			runner.writer.DumpOut()
+			summary := node.Summary()
+			runner.recordSpecialSuiteFailureReason(types.InterruptCauseReportAfterSuiteFailure, fmt.Sprintf("ReportAfterSuite %q at %s failed: %s", summary.Text, summary.CodeLocation, summary.Failure.Message))
+			passed = false
+		}
+		runner.reportAfterSuite(node.Summary())
+	}
+	return passed
+}
+
 func (runner *SpecRunner) runSpecs() bool {
+	if runner.concurrent() {
+		return runner.runSpecsConcurrently()
+	}
+
 	suiteFailed := false
 	skipRemainingSpecs := false
+	processSpec := func(spec *spec.Spec) {
+		if runner.checkForAbortByOtherProcess() {
+			skipRemainingSpecs = true
+		}
+		if skipRemainingSpecs {
+			spec.Skip()
+		}
+
+		if !spec.Skipped() && !spec.Pending() {
+			if passed := runner.runInWriterSession(spec, false, func() bool { return runner.runSpec(spec) }); !passed {
+				suiteFailed = true
+			}
+			if released := runner.releaseSharedFixtures(spec); !released {
+				suiteFailed = true
+			}
+		} else if spec.Pending() && runner.config.FailOnPending {
+			runner.reportSpecWillRun(spec.Summary(runner.suiteID))
+			suiteFailed = true
+			runner.reportSpecDidComplete(spec.Summary(runner.suiteID), spec.Failed())
+		} else {
+			runner.reportSpecWillRun(spec.Summary(runner.suiteID))
+			runner.reportSpecDidComplete(spec.Summary(runner.suiteID), spec.Failed())
+		}
+		runner.recordOutcome(spec)
+
+		if runner.checkForAbortBySpec() {
+			skipRemainingSpecs = true
+		}
+		if runner.failsSuite(spec) && runner.config.FailFast {
+			skipRemainingSpecs = true
+			runner.abortOtherProcesses()
+		}
+	}
+
+	waiting := []*spec.Spec{}
 	for {
 		spec, err := runner.iterator.Next()
 		if err == spec_iterator.ErrClosed {
@@ -162,31 +443,327 @@ func (runner *SpecRunner) runSpecs() bool {
 		if runner.wasInterrupted() {
 			break
 		}
-		if skipRemainingSpecs {
-			spec.Skip()
+		if !runner.checkPrerequisites(spec) {
+			waiting = append(waiting, spec)
+			continue
 		}
+		processSpec(spec)
+	}
 
-		if !spec.Skipped() && !spec.Pending() {
-			if passed := runner.runSpec(spec); !passed {
-				suiteFailed = true
-			}
-		} else if spec.Pending() && runner.config.FailOnPending {
-			runner.reportSpecWillRun(spec.Summary(runner.suiteID))
+	for _, spec := range runner.sortWaitingSpecs(waiting) {
+		if !runner.waitForPrerequisites(spec) {
+			break
+		}
+		processSpec(spec)
+	}
+
+	return !suiteFailed
+}
+
+//canAbortOtherProcesses tells whether a failure on this node stops the other parallel nodes: they all run with -failFast
+func (runner *SpecRunner) canAbortOtherProcesses() bool {
+	return runner.config.FailFast && runner.config.ParallelTotal > 1 && runner.config.SyncHost != ""
+}
+
+//abortOtherProcesses tells the other parallel nodes to stop running specs, as a spec failed on this node
+func (runner *SpecRunner) abortOtherProcesses() {
+	if !runner.canAbortOtherProcesses() {
+		return
+	}
+	abort := types.RemoteAbort{Node: runner.config.ParallelNode}
+	resp, err := http.Post(runner.config.SyncHost+"/Abort", "application/json", bytes.NewReader(abort.ToJSON()))
+	if err == nil {
+		resp.Body.Close()
+	}
+}
+
+//checkForAbortByOtherProcess tells whether another parallel node aborted the suite, recording it as a special suite
+//failure reason the first time
+func (runner *SpecRunner) checkForAbortByOtherProcess() bool {
+	if !runner.canAbortOtherProcesses() {
+		return false
+	}
+
+	runner.lock.Lock()
+	aborted := runner.abortedByOtherProcess
+	runner.lock.Unlock()
+	if aborted {
+		return true
+	}
+
+	resp, err := http.Get(runner.config.SyncHost + "/Abort")
+	if err != nil {
+		return false
+	}
+	defer resp.Body.Close()
+	abort := types.RemoteAbort{}
+	if json.NewDecoder(resp.Body).Decode(&abort) != nil || abort.Node == 0 || abort.Node == runner.config.ParallelNode {
+		return false
+	}
+
+	runner.lock.Lock()
+	runner.abortedByOtherProcess = true
+	runner.lock.Unlock()
+	runner.recordSpecialSuiteFailureReason(types.InterruptCauseAbortByOtherProcess, fmt.Sprintf("A spec failed on parallel process #%d, which runs with -failFast.", abort.Node))
+	return true
+}
+
+//checkForAbortBySpec tells whether a spec aborted the suite with failer.Abort, recording it as a special suite failure
+//reason the first time and telling the other parallel nodes to stop running specs
+func (runner *SpecRunner) checkForAbortBySpec() bool {
+	if runner.failer == nil {
+		return false
+	}
+	abort, aborted := runner.failer.Aborted()
+	if !aborted {
+		return false
+	}
+
+	runner.lock.Lock()
+	first := !runner.abortedBySpec
+	runner.abortedBySpec = true
+	runner.lock.Unlock()
+	if !first {
+		return true
+	}
+
+	runner.recordSpecialSuiteFailureReason(types.InterruptCauseAbort, fmt.Sprintf("The suite was aborted at %s: %s", abort.Location, abort.Message))
+	if runner.config.ParallelTotal > 1 && runner.config.SyncHost != "" {
+		remoteAbort := types.RemoteAbort{Node: runner.config.ParallelNode, Message: abort.Message}
+		resp, err := http.Post(runner.config.SyncHost+"/Abort", "application/json", bytes.NewReader(remoteAbort.ToJSON()))
+		if err == nil {
+			resp.Body.Close()
+		}
+	}
+	return true
+}
+
+//recordSpecialSuiteFailureReason records why the suite fails other than because of a failing spec
+func (runner *SpecRunner) recordSpecialSuiteFailureReason(cause types.InterruptCause, message string) {
+	runner.lock.Lock()
+	defer runner.lock.Unlock()
+	runner.specialSuiteFailureReasons = append(runner.specialSuiteFailureReasons, types.SpecialSuiteFailureReason{Cause: cause, Message: message})
+}
+
+//concurrent tells whether the runner runs several specs at once within the process
+func (runner *SpecRunner) concurrent() bool {
+	return runner.config.Concurrency > 1
+}
+
+//runSpecsConcurrently runs up to Concurrency specs at once, each on a goroutine of its own.  Specs that must run alone
+//wait for the specs running concurrently to complete, and no other spec starts until they complete.
+func (runner *SpecRunner) runSpecsConcurrently() bool {
+	resultLock := &sync.Mutex{}
+	suiteFailed := false
+	skipRemainingSpecs := false
+	recordResult := func(spec *spec.Spec, passed bool) {
+		resultLock.Lock()
+		defer resultLock.Unlock()
+		if !passed {
 			suiteFailed = true
-			runner.reportSpecDidComplete(spec.Summary(runner.suiteID), spec.Failed())
-		} else {
+		}
+		if runner.checkForAbortBySpec() {
+			skipRemainingSpecs = true
+		}
+		if runner.failsSuite(spec) && runner.config.FailFast {
+			skipRemainingSpecs = true
+			runner.abortOtherProcesses()
+		}
+	}
+	shouldSkipRemainingSpecs := func() bool {
+		resultLock.Lock()
+		defer resultLock.Unlock()
+		return skipRemainingSpecs
+	}
+
+	slots := make(chan struct{}, runner.config.Concurrency)
+	running := &sync.WaitGroup{}
+	runConcurrently := func(spec *spec.Spec) {
+		defer running.Done()
+		defer func() { <-slots }()
+		defer runner.flushReportsOnCrash()
+		recordResult(spec, runner.runSpecInLane(spec, true))
+		runner.recordOutcome(spec)
+	}
+	processSpec := func(spec *spec.Spec) {
+		if runner.checkForAbortByOtherProcess() {
+			resultLock.Lock()
+			skipRemainingSpecs = true
+			resultLock.Unlock()
+		}
+		if shouldSkipRemainingSpecs() {
+			spec.Skip()
+		}
+
+		if spec.Skipped() || spec.Pending() {
 			runner.reportSpecWillRun(spec.Summary(runner.suiteID))
 			runner.reportSpecDidComplete(spec.Summary(runner.suiteID), spec.Failed())
+			recordResult(spec, !(spec.Pending() && runner.config.FailOnPending))
+			runner.recordOutcome(spec)
+			return
 		}
 
-		if spec.Failed() && runner.config.FailFast {
-			skipRemainingSpecs = true
+		if runner.mustRunAlone(spec) {
+			running.Wait()
+			recordResult(spec, runner.runSpecInLane(spec, false))
+			runner.recordOutcome(spec)
+			return
+		}
+
+		slots <- struct{}{}
+		running.Add(1)
+		go runConcurrently(spec)
+	}
+
+	waiting := []*spec.Spec{}
+	for {
+		spec, err := runner.iterator.Next()
+		if err == spec_iterator.ErrClosed {
+			break
+		}
+		if err != nil {
+			fmt.Println("failed to iterate over tests:\n" + err.Error())
+			resultLock.Lock()
+			suiteFailed = true
+			resultLock.Unlock()
+			break
+		}
+
+		runner.processedSpecs = append(runner.processedSpecs, spec)
+
+		if runner.wasInterrupted() {
+			break
+		}
+		if !runner.checkPrerequisites(spec) {
+			waiting = append(waiting, spec)
+			continue
 		}
+		processSpec(spec)
+	}
+	running.Wait()
+
+	//the specs still waiting for their prerequisites run one after the other, as each might depend on the one before
+	for _, spec := range runner.sortWaitingSpecs(waiting) {
+		if !runner.waitForPrerequisites(spec) {
+			break
+		}
+		processSpec(spec)
+		running.Wait()
 	}
 
 	return !suiteFailed
 }
 
+//checkPrerequisites skips spec if one of the specs it depends on did not pass, and tells whether the outcomes of the
+//specs it depends on are known.  They might not be yet when they run concurrently or on other parallel nodes.
+func (runner *SpecRunner) checkPrerequisites(spec *spec.Spec) bool {
+	if runner.dependencies == nil || spec.Skipped() || spec.Pending() || !runner.dependencies.HasPrerequisites(spec) {
+		return true
+	}
+	known, skipReason := runner.dependencies.Check(spec)
+	if known && skipReason != "" {
+		spec.SkipWithReason(skipReason)
+	}
+	return known
+}
+
+//waitForPrerequisites waits for the outcomes of the specs spec depends on to be known, and tells whether they are: the
+//suite might be interrupted while waiting
+func (runner *SpecRunner) waitForPrerequisites(spec *spec.Spec) bool {
+	for !runner.checkPrerequisites(spec) {
+		if runner.wasInterrupted() {
+			return false
+		}
+		time.Sleep(dependency.PollingInterval)
+	}
+	return !runner.wasInterrupted()
+}
+
+//sortWaitingSpecs sorts the specs waiting for their prerequisites so that prerequisites come first
+func (runner *SpecRunner) sortWaitingSpecs(specs []*spec.Spec) []*spec.Spec {
+	if runner.dependencies == nil {
+		return specs
+	}
+	return runner.dependencies.Sort(specs)
+}
+
+//recordOutcome records the outcome of spec for the specs that depend on it
+func (runner *SpecRunner) recordOutcome(spec *spec.Spec) {
+	if runner.dependencies != nil {
+		runner.dependencies.Record(spec)
+	}
+}
+
+//mustRunAlone tells whether spec must not run concurrently with other specs: it is Serial, or it requires resources,
+//which the specs of a process share
+func (runner *SpecRunner) mustRunAlone(spec *spec.Spec) bool {
+	if spec.Serial() {
+		return true
+	}
+	return runner.resourceLocker != nil && len(runner.resourceLocker.RequiredBy(spec.Summary(runner.suiteID).ComponentCodeLocations)) > 0
+}
+
+//runSpecInLane runs spec, and releases the shared fixtures it declares, with its failures and output routed to a lane
+//of its own.  ownGoroutine tells whether the calling goroutine was started to run spec alone.
+func (runner *SpecRunner) runSpecInLane(spec *spec.Spec, ownGoroutine bool) bool {
+	return runner.runInWriterSession(spec, ownGoroutine, func() bool {
+		for _, router := range runner.laneRouters() {
+			router.OpenLane()
+			defer router.CloseLane()
+		}
+
+		passed := runner.runSpec(spec)
+		released := runner.releaseSharedFixtures(spec)
+		return passed && released
+	})
+}
+
+//runInWriterSession calls run within a session of the writer for spec, when the writer tracks sessions: what the
+//goroutines started by spec write once it has run is then told apart from the output of the specs that follow.  The
+//session is told apart by the goroutine that runs it, so unless ownGoroutine tells that the calling goroutine was started
+//to run spec alone, run is called on a goroutine of its own.
+func (runner *SpecRunner) runInWriterSession(spec *spec.Spec, ownGoroutine bool, run func() bool) bool {
+	sessions, ok := runner.writer.(writerSessions)
+	if !ok || !sessions.TracksSessions() {
+		return run()
+	}
+
+	texts := spec.Summary(runner.suiteID).ComponentTexts
+	if len(texts) > 1 {
+		texts = texts[1:]
+	}
+	runInSession := func() bool {
+		sessions.BeginSession(strings.Join(texts, " "))
+		defer sessions.EndSession()
+		return run()
+	}
+	if ownGoroutine {
+		return runInSession()
+	}
+
+	result := make(chan bool, 1)
+	go func() {
+		passed := false
+		defer func() { result <- passed }()
+		defer runner.flushReportsOnCrash()
+		passed = runInSession()
+	}()
+	return <-result
+}
+
+func (runner *SpecRunner) laneRouters() []laneRouter {
+	routers := []laneRouter{}
+	if runner.failer != nil {
+		routers = append(routers, runner.failer)
+	}
+	if router, ok := runner.writer.(laneRouter); ok {
+		routers = append(routers, router)
+	}
+	return routers
+}
+
+//runSpec runs spec, making up to -flakeAttempts attempts as long as its failures are worth retrying (see RetryOn), and
+//tells whether the suite can still pass: the spec passed, or its failure does not fail the suite
 func (runner *SpecRunner) runSpec(spec *spec.Spec) (passed bool) {
 	maxAttempts := 1
 	if runner.config.FlakeAttempts > 0 {
@@ -194,25 +771,193 @@ func (runner *SpecRunner) runSpec(spec *spec.Spec) (passed bool) {
 		maxAttempts = runner.config.FlakeAttempts
 	}
 
+	if runner.resourceLocker != nil {
+		resources := runner.resourceLocker.RequiredBy(spec.Summary(runner.suiteID).ComponentCodeLocations)
+		if err := runner.resourceLocker.Lock(resources...); err != nil {
+			fmt.Fprintf(os.Stderr, "\n%s\n", err.Error())
+			return false
+		}
+		defer runner.resourceLocker.Unlock(resources...)
+	}
+
 	for i := 0; i < maxAttempts; i++ {
+		if runner.config.SeedMathRand && !runner.concurrent() {
+			seed := runner.SpecRandomSeed(spec)
+			rand.Seed(seed)
+			spec.SetRandomSeed(seed)
+		}
+		runner.setRunningSpec(spec)
 		runner.reportSpecWillRun(spec.Summary(runner.suiteID))
-		runner.runningSpec = spec
 		spec.Run(runner.writer)
-		runner.runningSpec = nil
+		runner.setRunningSpec(nil)
 		runner.reportSpecDidComplete(spec.Summary(runner.suiteID), spec.Failed())
 		if !spec.Failed() {
 			return true
 		}
+		if !spec.ShouldRetry() {
+			break
+		}
 	}
-	return false
+	return !runner.failsSuite(spec)
+}
+
+//failsSuite tells whether spec failed, and its severity is high enough for the failure to fail the suite (see
+//-failOnSeverity)
+func (runner *SpecRunner) failsSuite(spec *spec.Spec) bool {
+	return spec.Failed() && spec.Severity().FailsSuite(runner.failOnSeverity)
+}
+
+//releaseSharedFixtures releases the shared fixtures declared by spec, once it has completed
+func (runner *SpecRunner) releaseSharedFixtures(spec *spec.Spec) bool {
+	if len(runner.sharedFixtures) == 0 {
+		return true
+	}
+
+	summary := spec.Summary(runner.suiteID)
+	passed := true
+	for _, sharedFixture := range runner.sharedFixtures {
+		if sharedFixture.IsDeclaredBy(summary.ComponentCodeLocations) && !sharedFixture.Release(reporters.SpecFullText(summary)) {
+			runner.reportSharedFixtureFailure(sharedFixture)
+			passed = false
+		}
+	}
+	return passed
+}
+
+//tearDownSharedFixtures tears down the shared fixtures still set up at the end of the suite
+func (runner *SpecRunner) tearDownSharedFixtures() bool {
+	passed := true
+	for _, sharedFixture := range runner.sharedFixtures {
+		if !sharedFixture.TearDown() {
+			runner.reportSharedFixtureFailure(sharedFixture)
+			passed = false
+		}
+	}
+	return passed
+}
+
+func (runner *SpecRunner) reportSharedFixtureFailure(sharedFixture *fixture.Fixture) {
+	summaries := sharedFixture.Summaries()
+	summary := summaries[len(summaries)-1]
+	fmt.Fprintf(os.Stderr, "\nSharedFixture %q (%s) %s\n", summary.Name, summary.CodeLocation, summary.Failure)
+}
+
+//setRunningSpec records the spec the calling goroutine runs, or that it no longer runs one if spec is nil
+func (runner *SpecRunner) setRunningSpec(spec *spec.Spec) {
+	runner.lock.Lock()
+	defer runner.lock.Unlock()
+	if !runner.concurrent() {
+		runner.runningSpec = spec
+		return
+	}
+
+	id := lanes.ID()
+	if spec == nil {
+		delete(runner.runningSpecs, id)
+	} else {
+		runner.runningSpecs[id] = spec
+	}
+}
+
+//currentSpec returns the running spec, or the one running in the lane of the calling goroutine when running specs
+//concurrently.  Goroutines the specs start may call it at any time, so it only reads the running specs under the lock.
+func (runner *SpecRunner) currentSpec() *spec.Spec {
+	runner.lock.Lock()
+	defer runner.lock.Unlock()
+	if !runner.concurrent() {
+		return runner.runningSpec
+	}
+
+	if id, ok := lanes.Resolve(func(id int64) bool { return runner.runningSpecs[id] != nil }); ok {
+		return runner.runningSpecs[id]
+	}
+	return nil
 }
 
 func (runner *SpecRunner) CurrentSpecSummary() (*types.SpecSummary, bool) {
-	if runner.runningSpec == nil {
+	runningSpec := runner.currentSpec()
+	if runningSpec == nil {
 		return nil, false
 	}
 
-	return runner.runningSpec.Summary(runner.suiteID), true
+	return runningSpec.Summary(runner.suiteID), true
+}
+
+//CurrentSpecProgressReport describes what the running spec is currently doing
+func (runner *SpecRunner) CurrentSpecProgressReport() (types.ProgressReport, bool) {
+	runningSpec := runner.currentSpec()
+	if runningSpec == nil {
+		return types.ProgressReport{}, false
+	}
+
+	return runningSpec.ProgressReport(), true
+}
+
+//RecordStep records a step of the running spec.  Steps taken outside of a spec are ignored.
+func (runner *SpecRunner) RecordStep(text string, codeLocation types.CodeLocation) {
+	if runningSpec := runner.currentSpec(); runningSpec != nil {
+		runningSpec.RecordStep(text, codeLocation)
+	}
+}
+
+//RecordGherkinStep records a step of the running spec announced with a Gherkin keyword.  Steps taken outside of a spec
+//are ignored.
+func (runner *SpecRunner) RecordGherkinStep(keyword string, text string, codeLocation types.CodeLocation) func(types.SpecState) {
+	if runningSpec := runner.currentSpec(); runningSpec != nil {
+		return runningSpec.RecordGherkinStep(keyword, text, codeLocation)
+	}
+	return func(types.SpecState) {}
+}
+
+//DeferCleanup defers a cleanup in the running spec, and tells whether it could
+func (runner *SpecRunner) DeferCleanup(body interface{}, codeLocation types.CodeLocation) bool {
+	if runningSpec := runner.currentSpec(); runningSpec != nil {
+		return runningSpec.DeferCleanup(body, codeLocation, runner.failer)
+	}
+	return false
+}
+
+//SpecRandomSeed returns the seed of spec, derived from the seed of the suite
+func (runner *SpecRunner) SpecRandomSeed(spec *spec.Spec) int64 {
+	return randomseed.ForSpec(runner.config.RandomSeed, spec.ConcatenatedString())
+}
+
+//CurrentSpecRandomSeed returns the seed of the running spec, or the seed of the suite outside of a spec
+func (runner *SpecRunner) CurrentSpecRandomSeed() int64 {
+	runningSpec := runner.currentSpec()
+	if runningSpec == nil {
+		return runner.config.RandomSeed
+	}
+	return runner.SpecRandomSeed(runningSpec)
+}
+
+//RecordNondeterminism records a source of nondeterminism detected while the running spec ran.  Sources detected
+//outside of a spec are ignored.
+func (runner *SpecRunner) RecordNondeterminism(description string) {
+	if runningSpec := runner.currentSpec(); runningSpec != nil {
+		runningSpec.RecordNondeterminism(description)
+	}
+}
+
+//RecordAdditionalFailure records a failure against the running spec without failing its running node, and returns
+//false when no spec is running
+func (runner *SpecRunner) RecordAdditionalFailure(failure types.SpecFailure) bool {
+	runningSpec := runner.currentSpec()
+	if runningSpec == nil {
+		return false
+	}
+	runningSpec.RecordAdditionalFailure(failure)
+	return true
+}
+
+//AddReportEntry attaches entry to the report of the running spec, and returns false when no spec is running
+func (runner *SpecRunner) AddReportEntry(entry types.ReportEntry) bool {
+	runningSpec := runner.currentSpec()
+	if runningSpec == nil {
+		return false
+	}
+	runningSpec.AddReportEntry(entry)
+	return true
 }
 
 func (runner *SpecRunner) registerForInterrupts(signalRegistered chan struct{}) {
@@ -220,43 +965,229 @@ func (runner *SpecRunner) registerForInterrupts(signalRegistered chan struct{})
 	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 	close(signalRegistered)
 
-	<-c
+	cause := "Received interrupt."
+	approachingDeadline := false
+	exit := true
+	select {
+	case sig := <-c:
+		runner.recordSpecialSuiteFailureReason(types.InterruptCauseSignal, fmt.Sprintf("Interrupted by %s.", signalName(sig)))
+	case <-runner.approachingDeadline():
+		cause = fmt.Sprintf("Approaching the go test deadline (%s).", runner.deadline.Format(time.RFC3339))
+		approachingDeadline = true
+		runner.recordSpecialSuiteFailureReason(types.InterruptCauseSuiteTimeout, cause)
+	case reason := <-runner.interrupts:
+		cause = reason.Message
+		exit = false
+		runner.recordSpecialSuiteFailureReason(reason.Cause, reason.Message)
+	case <-runner.suiteEnded:
+		signal.Stop(c)
+		return
+	}
 	signal.Stop(c)
 	runner.markInterrupted()
 	go runner.registerForHardInterrupts()
-	runner.writer.DumpOutWithHeader(`
-Received interrupt.  Emitting contents of GinkgoWriter...
+	runner.writer.DumpOutWithHeader(fmt.Sprintf(`
+%s  Emitting contents of GinkgoWriter...
+---------------------------------------------------------
+`, cause))
+	if report, ok := runner.CurrentSpecProgressReport(); ok {
+		fmt.Fprintf(os.Stderr, `
 ---------------------------------------------------------
-`)
+%s  Progress of the running spec:
+`, cause)
+		fmt.Fprint(os.Stderr, formatProgressReport(report, formatter.ConsoleWidth(config.DefaultReporterConfig.Columns, config.DefaultReporterConfig.NoWrap)))
+		runner.runOnFailureHooksOfInterruptedSpec(report, cause, approachingDeadline)
+		if approachingDeadline {
+			runner.reportRunningSpecTimedOut(report)
+		}
+	}
 	if runner.afterSuiteNode != nil {
-		fmt.Fprint(os.Stderr, `
+		fmt.Fprintf(os.Stderr, `
 ---------------------------------------------------------
-Received interrupt.  Running AfterSuite...
+%s  Running AfterSuite...
 ^C again to terminate immediately
-`)
+`, cause)
 		runner.runAfterSuite()
 	}
+	runner.tearDownSharedFixtures()
+	runner.stopSuiteProcessesNow()
 	runner.reportSuiteDidEnd(false)
-	os.Exit(1)
+	if exit {
+		os.Exit(1)
+	}
+	close(runner.shutDownDone)
+}
+
+//flushReportsOnCrash writes the reports when a panic escapes the specs, e.g. from a custom reporter, before letting the
+//panic crash the test binary: the reports hold the specs that completed, and the specs that were running are reported
+//as panicked, with the stack of the panic.  Panics on the goroutines the specs start, and calls to os.Exit, end the
+//process without unwinding the runner, so that their reports cannot be written.
+func (runner *SpecRunner) flushReportsOnCrash() {
+	e := recover()
+	if e == nil {
+		return
+	}
+	location := codelocation.New(2)
+	runner.crashOnce.Do(func() {
+		runner.recordSpecialSuiteFailureReason(types.InterruptCauseCrash, fmt.Sprintf("The test binary crashed: %v", e))
+		crashedSpecs := runner.crashedSpecSummaries(location, e)
+		summary := runner.suiteDidEndSummary(false)
+		summary.RunTime = clock.Since(runner.startTime)
+		summary.NumberOfFailedSpecs += len(crashedSpecs)
+		for _, reporter := range runner.reporters.Reporters() {
+			flushReportOnCrash(reporter, crashedSpecs, summary, runner.concurrent())
+		}
+	})
+	panic(e)
+}
+
+//crashedSpecSummaries returns the summaries of the running specs, failed by the panic e that crashed the test binary at
+//location
+func (runner *SpecRunner) crashedSpecSummaries(location types.CodeLocation, e interface{}) []*types.SpecSummary {
+	runner.lock.Lock()
+	runningSpecs := []*spec.Spec{}
+	if runner.runningSpec != nil {
+		runningSpecs = append(runningSpecs, runner.runningSpec)
+	}
+	for _, runningSpec := range runner.runningSpecs {
+		runningSpecs = append(runningSpecs, runningSpec)
+	}
+	runner.lock.Unlock()
+
+	summaries := []*types.SpecSummary{}
+	for _, runningSpec := range runningSpecs {
+		summary := runningSpec.Summary(runner.suiteID)
+		summary.State = types.SpecStatePanicked
+		summary.Failure = interruptedSpecFailure(summary, runningSpec.ProgressReport(), "The test binary crashed while this spec ran")
+		summary.Failure.Location = location
+		summary.Failure.ForwardedPanic = fmt.Sprintf("%v", e)
+		summary.Failure.Fingerprint = types.FailureFingerprint(summary.Failure)
+		summary.CapturedOutput = string(runner.writer.Bytes())
+		runner.transformTexts(summary)
+		summaries = append(summaries, summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].ComponentCodeLocations[len(summaries[i].ComponentCodeLocations)-1].String() < summaries[j].ComponentCodeLocations[len(summaries[j].ComponentCodeLocations)-1].String()
+	})
+	return summaries
+}
+
+//flushReportOnCrash hands the crashed specs and the end of the suite to reporter.  A reporter that panics in turn is
+//given up on, so that the reporters after it still write their reports.
+func flushReportOnCrash(reporter reporters.Reporter, crashedSpecs []*types.SpecSummary, summary *types.SuiteSummary, concurrent bool) {
+	defer func() {
+		recover()
+	}()
+	for _, crashedSpec := range crashedSpecs {
+		if concurrent {
+			reporter.SpecWillRun(crashedSpec)
+		}
+		reporter.SpecDidComplete(crashedSpec)
+	}
+	reporter.SpecSuiteDidEnd(summary)
+}
+
+func signalName(sig os.Signal) string {
+	switch sig {
+	case os.Interrupt:
+		return "SIGINT"
+	case syscall.SIGTERM:
+		return "SIGTERM"
+	}
+	return sig.String()
+}
+
+//approachingDeadline fires shortly before the go test deadline, if there is one
+func (runner *SpecRunner) approachingDeadline() <-chan time.Time {
+	if runner.deadline.IsZero() {
+		return nil
+	}
+
+	remaining := time.Until(runner.deadline)
+	gracePeriod := remaining / 10
+	if gracePeriod > maxDeadlineGracePeriod {
+		gracePeriod = maxDeadlineGracePeriod
+	}
+	return time.After(remaining - gracePeriod)
+}
+
+//reportRunningSpecTimedOut reports the running spec as timed out, as go test would otherwise kill it without reporting it
+func (runner *SpecRunner) reportRunningSpecTimedOut(report types.ProgressReport) {
+	runningSpec := runner.currentSpec()
+	if runningSpec == nil {
+		return
+	}
+	//the spec is still running, so it counts as failed by the summary of the suite for having been reported so
+	runner.lock.Lock()
+	runner.timedOutSpec = runningSpec
+	runner.lock.Unlock()
+
+	summary := runningSpec.Summary(runner.suiteID)
+	summary.State = types.SpecStateTimedOut
+	summary.Failure = interruptedSpecFailure(summary, report, "Interrupted shortly before the go test deadline (see -test.timeout)")
+	runner.reportSpecDidComplete(summary, true)
+}
+
+//runOnFailureHooksOfInterruptedSpec runs the OnFailure hooks for the running spec, as the interrupt stops the suite before
+//the spec unwinds.  The hooks are handed the spec as it was when the interrupt came, failed with cause.
+func (runner *SpecRunner) runOnFailureHooksOfInterruptedSpec(report types.ProgressReport, cause string, approachingDeadline bool) {
+	runningSpec := runner.currentSpec()
+	if runningSpec == nil {
+		return
+	}
+
+	summary := runningSpec.Summary(runner.suiteID)
+	summary.State = types.SpecStateFailed
+	if approachingDeadline {
+		summary.State = types.SpecStateTimedOut
+	}
+	summary.Failure = interruptedSpecFailure(summary, report, cause)
+	runningSpec.RunOnFailureHooks(summary, runner.writer)
+}
+
+//interruptedSpecFailure is the failure of the spec described by summary when an interrupt stops it in the node described
+//by report
+func interruptedSpecFailure(summary *types.SpecSummary, report types.ProgressReport, message string) types.SpecFailure {
+	failure := types.SpecFailure{
+		Message:               message,
+		Location:              report.CurrentNodeCodeLocation,
+		ComponentIndex:        len(summary.ComponentTexts) - 1,
+		ComponentType:         report.CurrentNodeType,
+		ComponentCodeLocation: report.CurrentNodeCodeLocation,
+	}
+	failure.Fingerprint = types.FailureFingerprint(failure)
+	return failure
+}
+
+func (runner *SpecRunner) stopSuiteProcessesNow() {
+	if runner.suiteProcesses != nil {
+		runner.suiteProcesses.StopNow()
+	}
 }
 
 func (runner *SpecRunner) registerForHardInterrupts() {
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 
-	<-c
+	select {
+	case <-c:
+	case <-runner.shutDownDone:
+		signal.Stop(c)
+		return
+	}
 	fmt.Fprintln(os.Stderr, "\nReceived second interrupt.  Shutting down.")
+	runner.stopSuiteProcessesNow()
 	os.Exit(1)
 }
 
-func (runner *SpecRunner) blockForeverIfInterrupted() {
-	runner.lock.Lock()
-	interrupted := runner.interrupted
-	runner.lock.Unlock()
-
-	if interrupted {
-		select {}
+//waitForShutdownIfInterrupted leaves shutting the suite down to the interrupt handler, which exits the process unless the
+//interrupt was requested through Interrupt
+func (runner *SpecRunner) waitForShutdownIfInterrupted() bool {
+	if !runner.wasInterrupted() {
+		return false
 	}
+	<-runner.shutDownDone
+	return true
 }
 
 func (runner *SpecRunner) markInterrupted() {
@@ -272,54 +1203,69 @@ func (runner *SpecRunner) wasInterrupted() bool {
 }
 
 func (runner *SpecRunner) reportSuiteWillBegin() {
-	runner.startTime = time.Now()
-	summary := runner.suiteWillBeginSummary()
-	for _, reporter := range runner.reporters {
-		reporter.SpecSuiteWillBegin(runner.config, summary)
-	}
+	runner.startTime = clock.Now()
+	runner.reporters.SpecSuiteWillBegin(runner.config, runner.suiteWillBeginSummary())
 }
 
 func (runner *SpecRunner) reportBeforeSuite(summary *types.SetupSummary) {
-	for _, reporter := range runner.reporters {
-		reporter.BeforeSuiteDidRun(summary)
-	}
+	runner.reporters.BeforeSuiteDidRun(summary)
 }
 
 func (runner *SpecRunner) reportAfterSuite(summary *types.SetupSummary) {
-	for _, reporter := range runner.reporters {
-		reporter.AfterSuiteDidRun(summary)
-	}
+	runner.reporters.AfterSuiteDidRun(summary)
 }
 
 func (runner *SpecRunner) reportSpecWillRun(summary *types.SpecSummary) {
 	runner.writer.Truncate()
 
-	for _, reporter := range runner.reporters {
-		reporter.SpecWillRun(summary)
+	//when running specs concurrently, reporters hear about a spec once it completes, so that their output is not interleaved
+	if runner.concurrent() {
+		return
 	}
+
+	runner.transformTexts(summary)
+	runner.reporters.SpecWillRun(summary)
 }
 
 func (runner *SpecRunner) reportSpecDidComplete(summary *types.SpecSummary, failed bool) {
 	if len(summary.CapturedOutput) == 0 {
 		summary.CapturedOutput = string(runner.writer.Bytes())
 	}
-	for i := len(runner.reporters) - 1; i >= 1; i-- {
-		runner.reporters[i].SpecDidComplete(summary)
-	}
+	runner.transformTexts(summary)
 
-	if failed {
-		runner.writer.DumpOut()
+	runner.reportLock.Lock()
+	defer runner.reportLock.Unlock()
+
+	if runner.concurrent() {
+		runner.reporters.SpecWillRun(summary)
 	}
+	runner.reporters.SpecDidComplete(summary, func() {
+		if failed {
+			runner.writer.DumpOut()
+		}
+	})
+}
 
-	runner.reporters[0].SpecDidComplete(summary)
+//transformTexts sets the DisplayTexts of the summary, which is about to be reported, by applying the text transformers
+//to its texts.  The ComponentTexts are left alone: specs are matched against -focus and -skip, refer to each other, and
+//are told apart across runs by their original texts.
+func (runner *SpecRunner) transformTexts(summary *types.SpecSummary) {
+	if len(runner.textTransformers) == 0 {
+		return
+	}
+	summary.DisplayTexts = make([]string, len(summary.ComponentTexts))
+	for i, text := range summary.ComponentTexts {
+		for _, transform := range runner.textTransformers {
+			text = transform(text)
+		}
+		summary.DisplayTexts[i] = text
+	}
 }
 
 func (runner *SpecRunner) reportSuiteDidEnd(success bool) {
 	summary := runner.suiteDidEndSummary(success)
-	summary.RunTime = time.Since(runner.startTime)
-	for _, reporter := range runner.reporters {
-		reporter.SpecSuiteDidEnd(summary)
-	}
+	summary.RunTime = clock.Since(runner.startTime)
+	runner.reporters.SpecSuiteDidEnd(summary)
 }
 
 func (runner *SpecRunner) countSpecsThatRanSatisfying(filter func(ex *spec.Spec) bool) (count int) {
@@ -335,6 +1281,10 @@ func (runner *SpecRunner) countSpecsThatRanSatisfying(filter func(ex *spec.Spec)
 }
 
 func (runner *SpecRunner) suiteDidEndSummary(success bool) *types.SuiteSummary {
+	runner.lock.Lock()
+	timedOutSpec := runner.timedOutSpec
+	runner.lock.Unlock()
+
 	numberOfSpecsThatWillBeRun := runner.countSpecsThatRanSatisfying(func(ex *spec.Spec) bool {
 		return !ex.Skipped() && !ex.Pending()
 	})
@@ -348,7 +1298,7 @@ func (runner *SpecRunner) suiteDidEndSummary(success bool) *types.SuiteSummary {
 	})
 
 	numberOfPassedSpecs := runner.countSpecsThatRanSatisfying(func(ex *spec.Spec) bool {
-		return ex.Passed()
+		return ex.Passed() && ex != timedOutSpec
 	})
 
 	numberOfFlakedSpecs := runner.countSpecsThatRanSatisfying(func(ex *spec.Spec) bool {
@@ -356,10 +1306,10 @@ func (runner *SpecRunner) suiteDidEndSummary(success bool) *types.SuiteSummary {
 	})
 
 	numberOfFailedSpecs := runner.countSpecsThatRanSatisfying(func(ex *spec.Spec) bool {
-		return ex.Failed()
+		return ex.Failed() || ex == timedOutSpec
 	})
 
-	if runner.beforeSuiteNode != nil && !runner.beforeSuiteNode.Passed() && !runner.config.DryRun {
+	if runner.beforeSuiteNode != nil && !runner.beforeSuiteNode.Passed() && !runner.config.DryRun && !runner.config.CleanupOnly {
 		var known bool
 		numberOfSpecsThatWillBeRun, known = runner.iterator.NumberOfSpecsThatWillBeRunIfKnown()
 		if !known {
@@ -381,7 +1331,66 @@ func (runner *SpecRunner) suiteDidEndSummary(success bool) *types.SuiteSummary {
 		NumberOfPassedSpecs:                numberOfPassedSpecs,
 		NumberOfFailedSpecs:                numberOfFailedSpecs,
 		NumberOfFlakedSpecs:                numberOfFlakedSpecs,
+		WarmUpRunTime:                      runner.warmUpRunTime(),
+
+		SuiteLabels:   runner.config.SuiteLabels,
+		SuiteMetadata: runner.config.SuiteMetadata,
+		Warnings:      runner.warnings,
+
+		SpecialSuiteFailureReasons: runner.specialSuiteFailureReasonsSoFar(),
+
+		SharedFixtures: runner.sharedFixtureSummaries(),
+		SuiteProcesses: runner.suiteProcessSummaries(),
+
+		RuntimeBudgetViolations: runner.runtimeBudgetViolations(),
+	}
+}
+
+//runtimeBudgetViolations counts the specs that ran longer than the runtime budget of each label
+func (runner *SpecRunner) runtimeBudgetViolations() map[string]int {
+	var violations map[string]int
+	for _, spec := range runner.processedSpecs {
+		violation := spec.Summary(runner.suiteID).RuntimeBudgetViolation
+		if violation == nil {
+			continue
+		}
+		if violations == nil {
+			violations = map[string]int{}
+		}
+		violations[violation.Label]++
 	}
+	return violations
+}
+
+func (runner *SpecRunner) warmUpRunTime() time.Duration {
+	if runner.warmUpNode == nil {
+		return 0
+	}
+	return runner.warmUpNode.Summary().RunTime
+}
+
+func (runner *SpecRunner) specialSuiteFailureReasonsSoFar() []types.SpecialSuiteFailureReason {
+	runner.lock.Lock()
+	defer runner.lock.Unlock()
+	if len(runner.specialSuiteFailureReasons) == 0 {
+		return nil
+	}
+	return append([]types.SpecialSuiteFailureReason{}, runner.specialSuiteFailureReasons...)
+}
+
+func (runner *SpecRunner) suiteProcessSummaries() []*types.SuiteProcessSummary {
+	if runner.suiteProcesses == nil {
+		return nil
+	}
+	return runner.suiteProcesses.Summaries()
+}
+
+func (runner *SpecRunner) sharedFixtureSummaries() []*types.SharedFixtureSummary {
+	var summaries []*types.SharedFixtureSummary
+	for _, sharedFixture := range runner.sharedFixtures {
+		summaries = append(summaries, sharedFixture.Summaries()...)
+	}
+	return summaries
 }
 
 func (runner *SpecRunner) suiteWillBeginSummary() *types.SuiteSummary {
@@ -407,5 +1416,31 @@ func (runner *SpecRunner) suiteWillBeginSummary() *types.SuiteSummary {
 		NumberOfPassedSpecs:                -1,
 		NumberOfFailedSpecs:                -1,
 		NumberOfFlakedSpecs:                -1,
+
+		SuiteLabels:   runner.config.SuiteLabels,
+		SuiteMetadata: runner.config.SuiteMetadata,
+		Warnings:      runner.warnings,
+	}
+}
+
+//formatProgressReport describes report, wrapped at width columns
+func formatProgressReport(report types.ProgressReport, width uint) string {
+	texts := report.ComponentTexts
+	if len(texts) > 1 {
+		texts = texts[1:]
+	}
+	out := &strings.Builder{}
+	fmt.Fprintln(out, strings.Join(texts, " "))
+	if !report.CurrentNodeStartTime.IsZero() {
+		fmt.Fprintf(out, "  In [%s] at %s (running for %s)\n", report.CurrentNodeType, report.CurrentNodeCodeLocation, clock.Since(report.CurrentNodeStartTime).Round(time.Millisecond))
+	}
+	if report.CurrentStep != nil {
+		fmt.Fprintf(out, "  At step [By] %s at %s\n", report.CurrentStep.Text, report.CurrentStep.CodeLocation)
+	}
+	for _, additionalReport := range report.AdditionalReports {
+		for _, line := range strings.Split(strings.TrimRight(additionalReport, "\n"), "\n") {
+			fmt.Fprintf(out, "    %s\n", line)
+		}
 	}
+	return formatter.Wrap(out.String(), width)
 }
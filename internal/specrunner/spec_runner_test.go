@@ -1,6 +1,10 @@
 package specrunner_test
 
 import (
+	"errors"
+	"math/rand"
+	"strings"
+
 	. "github.com/onsi/ginkgo"
 	"github.com/onsi/ginkgo/internal/spec_iterator"
 	. "github.com/onsi/ginkgo/internal/specrunner"
@@ -12,6 +16,7 @@ import (
 	"github.com/onsi/ginkgo/internal/containernode"
 	Failer "github.com/onsi/ginkgo/internal/failer"
 	"github.com/onsi/ginkgo/internal/leafnodes"
+	"github.com/onsi/ginkgo/internal/randomseed"
 	"github.com/onsi/ginkgo/internal/spec"
 	Writer "github.com/onsi/ginkgo/internal/writer"
 	"github.com/onsi/ginkgo/reporters"
@@ -182,6 +187,20 @@ var _ = Describe("Spec Runner", func() {
 			Ω(reporter1.EndSummary.NumberOfFailedSpecs).Should(Equal(1))
 		})
 
+		Context("when the suite has labels and metadata", func() {
+			BeforeEach(func() {
+				conf.SuiteLabels = []string{"integration"}
+				conf.SuiteMetadata = map[string]string{"gitSHA": "abc123"}
+			})
+
+			It("should report them at the beginning and the end of the suite", func() {
+				Ω(reporter1.BeginSummary.SuiteLabels).Should(Equal([]string{"integration"}))
+				Ω(reporter1.BeginSummary.SuiteMetadata).Should(Equal(map[string]string{"gitSHA": "abc123"}))
+				Ω(reporter1.EndSummary.SuiteLabels).Should(Equal([]string{"integration"}))
+				Ω(reporter1.EndSummary.SuiteMetadata).Should(Equal(map[string]string{"gitSHA": "abc123"}))
+			})
+		})
+
 		Context("when told to perform a dry run", func() {
 			BeforeEach(func() {
 				conf.DryRun = true
@@ -293,6 +312,54 @@ var _ = Describe("Spec Runner", func() {
 		})
 	})
 
+	Describe("seeding math/rand", func() {
+		var drawn map[string]int64
+
+		newRandomSpec := func(text string) *spec.Spec {
+			return newSpecWithBody(text, func() {
+				drawn[text] = rand.Int63()
+			})
+		}
+
+		BeforeEach(func() {
+			drawn = map[string]int64{}
+		})
+
+		It("should seed math/rand before each spec from the seed of the suite and the text of the spec", func() {
+			runner = newRunner(config.GinkgoConfigType{RandomSeed: 17, SeedMathRand: true}, nil, nil, newRandomSpec("A"), newRandomSpec("B"))
+			runner.Run()
+
+			for _, text := range []string{"A", "B"} {
+				seed := randomseed.ForSpec(17, text)
+				Ω(drawn[text]).Should(Equal(rand.New(rand.NewSource(seed)).Int63()))
+			}
+			Ω(reporter1.SpecSummaries[0].RandomSeed).Should(Equal(randomseed.ForSpec(17, "A")))
+			Ω(reporter1.SpecSummaries[1].RandomSeed).Should(Equal(randomseed.ForSpec(17, "B")))
+		})
+
+		It("should leave math/rand alone when not told to seed it", func() {
+			runner = newRunner(config.GinkgoConfigType{RandomSeed: 17}, nil, nil, newRandomSpec("A"))
+			runner.Run()
+
+			Ω(drawn["A"]).ShouldNot(Equal(rand.New(rand.NewSource(randomseed.ForSpec(17, "A"))).Int63()))
+			Ω(reporter1.SpecSummaries[0].RandomSeed).Should(BeZero())
+		})
+
+		It("should report the sources of nondeterminism recorded by the running spec", func() {
+			var seed int64
+			runner = newRunner(config.GinkgoConfigType{RandomSeed: 17}, nil, nil, newSpecWithBody("A", func() {
+				seed = runner.CurrentSpecRandomSeed()
+				runner.RecordNondeterminism("time-based seed")
+			}))
+			runner.RecordNondeterminism("outside of a spec")
+			runner.Run()
+
+			Ω(seed).Should(Equal(randomseed.ForSpec(17, "A")))
+			Ω(runner.CurrentSpecRandomSeed()).Should(Equal(int64(17)))
+			Ω(reporter1.SpecSummaries[0].Nondeterminism).Should(Equal([]string{"time-based seed"}))
+		})
+	})
+
 	Describe("Running and Reporting when there's flakes", func() {
 		var specA, pendingSpec, flakySpec, failedSpec, specB, skippedSpec *spec.Spec
 		var willRunCalls, didCompleteCalls []string
@@ -450,6 +517,25 @@ var _ = Describe("Spec Runner", func() {
 		})
 	})
 
+	Describe("Retrying only the failures worth retrying", func() {
+		It("should stop retrying a spec at its first failure its RetryOn rejects", func() {
+			isTransient := func(message string) bool { return message == "connection refused" }
+			transientSpec := newFlakySpec("connection refused", noneFlag, 3)
+			transientSpec.SetRetryOn(isTransient)
+			genuineSpec := newFlakySpec("assertion failed", noneFlag, 3)
+			genuineSpec.SetRetryOn(isTransient)
+
+			runner = newRunner(config.GinkgoConfigType{RandomSeed: 17, FlakeAttempts: 5}, nil, nil, transientSpec, genuineSpec)
+			Ω(runner.Run()).Should(BeFalse())
+
+			Ω(thingsThatRan).Should(Equal([]string{"connection refused", "connection refused", "connection refused", "assertion failed"}))
+			Ω(transientSpec.Passed()).Should(BeTrue())
+			Ω(genuineSpec.Failed()).Should(BeTrue())
+			Ω(reporter1.EndSummary.NumberOfFlakedSpecs).Should(Equal(1))
+			Ω(reporter1.EndSummary.NumberOfFailedSpecs).Should(Equal(1))
+		})
+	})
+
 	Describe("Running BeforeSuite & AfterSuite", func() {
 		var success bool
 		var befSuite leafnodes.SuiteNode
@@ -479,6 +565,89 @@ var _ = Describe("Spec Runner", func() {
 			})
 		})
 
+		Context("with ReportAfterSuite nodes", func() {
+			newReportAfterSuite := func(text string, fail bool, fetchReport func() (types.AggregatedReport, error)) leafnodes.SuiteNode {
+				return leafnodes.NewReportAfterSuiteNode(text, func(report types.AggregatedReport) {
+					thingsThatRan = append(thingsThatRan, text)
+					Ω(report.Passed).Should(Equal(1))
+					if fail {
+						failer.Fail(text+" failed", codelocation.New(0))
+					}
+				}, codelocation.New(0), 0, failer, fetchReport)
+			}
+			fetchReport := func() (types.AggregatedReport, error) {
+				return types.AggregatedReport{Passed: 1}, nil
+			}
+
+			It("should run them all in order after the AfterSuite, once the specs ran, and pass when they all pass", func() {
+				runner = newRunner(config.GinkgoConfigType{ParallelNode: 1, ParallelTotal: 1}, nil, newAftSuite("AftSuite", false), newSpec("A", noneFlag, false))
+				runner.SetReportAfterSuiteNodes([]leafnodes.SuiteNode{newReportAfterSuite("first", false, fetchReport), newReportAfterSuite("second", false, fetchReport)})
+
+				Ω(runner.Run()).Should(BeTrue())
+				Ω(thingsThatRan).Should(Equal([]string{"A", "AftSuite", "first", "second"}))
+				Ω(reporter1.AfterSuiteSummary.Text).Should(Equal("second"))
+				Ω(reporter1.AfterSuiteSummary.ComponentType).Should(Equal(types.SpecComponentTypeReportAfterSuite))
+			})
+
+			It("should run each of them even when the previous ones failed, and attribute the failures", func() {
+				runner = newRunner(config.GinkgoConfigType{ParallelNode: 1, ParallelTotal: 1}, nil, nil, newSpec("A", noneFlag, false))
+				runner.SetReportAfterSuiteNodes([]leafnodes.SuiteNode{newReportAfterSuite("first", true, fetchReport), newReportAfterSuite("second", false, fetchReport)})
+
+				Ω(runner.Run()).Should(BeFalse())
+				Ω(thingsThatRan).Should(Equal([]string{"A", "first", "second"}))
+				Ω(reporter1.EndSummary.SpecialSuiteFailureReasons).Should(HaveLen(1))
+				Ω(reporter1.EndSummary.SpecialSuiteFailureReasons[0].Cause).Should(Equal(types.InterruptCauseReportAfterSuiteFailure))
+				Ω(reporter1.EndSummary.SpecialSuiteFailureReasons[0].Message).Should(HavePrefix(`ReportAfterSuite "first" at `))
+				Ω(reporter1.EndSummary.SpecialSuiteFailureReasons[0].Message).Should(HaveSuffix("failed: first failed"))
+			})
+
+			It("should fail them without running them when the report cannot be aggregated", func() {
+				brokenFetch := func() (types.AggregatedReport, error) {
+					return types.AggregatedReport{}, errors.New("connection refused")
+				}
+				runner = newRunner(config.GinkgoConfigType{ParallelNode: 1, ParallelTotal: 1}, nil, nil, newSpec("A", noneFlag, false))
+				runner.SetReportAfterSuiteNodes([]leafnodes.SuiteNode{newReportAfterSuite("first", false, brokenFetch)})
+
+				Ω(runner.Run()).Should(BeFalse())
+				Ω(thingsThatRan).Should(Equal([]string{"A"}))
+				Ω(reporter1.AfterSuiteSummary.State).Should(Equal(types.SpecStateFailed))
+				Ω(reporter1.AfterSuiteSummary.Failure.Message).Should(ContainSubstring("The report of the suite could not be aggregated, so this ReportAfterSuite did not run:\nconnection refused"))
+				Ω(reporter1.EndSummary.SpecialSuiteFailureReasons[0].Message).Should(ContainSubstring("connection refused"))
+			})
+
+			It("should only run them on parallel node 1", func() {
+				runner = newRunner(config.GinkgoConfigType{ParallelNode: 2, ParallelTotal: 2}, nil, nil, newSpec("A", noneFlag, false))
+				runner.SetReportAfterSuiteNodes([]leafnodes.SuiteNode{newReportAfterSuite("first", false, fetchReport)})
+
+				Ω(runner.Run()).Should(BeTrue())
+				Ω(thingsThatRan).Should(Equal([]string{"A"}))
+				Ω(reporter1.AfterSuiteSummary).Should(BeNil())
+			})
+		})
+
+		Context("when cleaning up only", func() {
+			BeforeEach(func() {
+				befSuite = newBefSuite("BefSuite", false)
+				aftSuite = newBefSuite("AftSuite", false)
+				specA := newSpec("A", noneFlag, false)
+				specA.SetCleanupOnly(true)
+				runner = newRunner(config.GinkgoConfigType{CleanupOnly: true}, befSuite, aftSuite, specA)
+				success = runner.Run()
+			})
+
+			It("should skip the BeforeSuite and the spec bodies, and run the AfterSuite", func() {
+				Ω(thingsThatRan).Should(Equal([]string{"AftSuite"}))
+				Ω(reporter1.BeforeSuiteSummary).Should(BeNil())
+				Ω(reporter1.AfterSuiteSummary).Should(Equal(aftSuite.Summary()))
+			})
+
+			It("should report success", func() {
+				Ω(success).Should(BeTrue())
+				Ω(reporter1.EndSummary.NumberOfPassedSpecs).Should(Equal(1))
+				Ω(reporter1.EndSummary.NumberOfFailedSpecs).Should(Equal(0))
+			})
+		})
+
 		Context("when the BeforeSuite & AfterSuite pass", func() {
 			BeforeEach(func() {
 				befSuite = newBefSuite("BefSuite", false)
@@ -627,6 +796,63 @@ var _ = Describe("Spec Runner", func() {
 		})
 	})
 
+	Describe("Running the WarmUp", func() {
+		var success bool
+		var warmUp leafnodes.SuiteNode
+		var begunBeforeWarmUp bool
+
+		newWarmUp := func(fail bool) leafnodes.SuiteNode {
+			return leafnodes.NewWarmUpNode(func() {
+				begunBeforeWarmUp = reporter1.BeginSummary != nil
+				thingsThatRan = append(thingsThatRan, "WarmUp")
+				if fail {
+					failer.Fail("WarmUp", codelocation.New(0))
+				}
+			}, codelocation.New(0), 0, failer)
+		}
+
+		Context("when it passes", func() {
+			BeforeEach(func() {
+				warmUp = newWarmUp(false)
+				runner = newRunner(config.GinkgoConfigType{}, newBefSuite("BefSuite", false), nil, newSpec("A", noneFlag, false))
+				runner.SetWarmUpNode(warmUp)
+				success = runner.Run()
+			})
+
+			It("should run it before the suite begins, then the BeforeSuite and the specs", func() {
+				Ω(begunBeforeWarmUp).Should(BeFalse())
+				Ω(thingsThatRan).Should(Equal([]string{"WarmUp", "BefSuite", "A"}))
+				Ω(success).Should(BeTrue())
+			})
+
+			It("should not report it, but report its run time apart", func() {
+				Ω(reporter1.BeforeSuiteSummary.ComponentType).Should(Equal(types.SpecComponentTypeBeforeSuite))
+				Ω(reporter1.EndSummary.WarmUpRunTime).Should(Equal(warmUp.Summary().RunTime))
+			})
+		})
+
+		Context("when it fails", func() {
+			BeforeEach(func() {
+				warmUp = newWarmUp(true)
+				runner = newRunner(config.GinkgoConfigType{}, newBefSuite("BefSuite", false), newAftSuite("AftSuite", false), newSpec("A", noneFlag, false))
+				runner.SetWarmUpNode(warmUp)
+				success = runner.Run()
+			})
+
+			It("should report it the way a failed BeforeSuite is, and run neither the BeforeSuite nor the specs", func() {
+				Ω(reporter1.BeforeSuiteSummary).Should(Equal(warmUp.Summary()))
+				Ω(reporter1.BeforeSuiteSummary.ComponentType).Should(Equal(types.SpecComponentTypeWarmUp))
+				Ω(thingsThatRan).Should(Equal([]string{"WarmUp", "AftSuite"}))
+				Ω(writer.EventStream).Should(ContainElement("DUMP"))
+			})
+
+			It("should report failure", func() {
+				Ω(success).Should(BeFalse())
+				Ω(reporter1.EndSummary.SuiteSucceeded).Should(BeFalse())
+			})
+		})
+	})
+
 	Describe("When instructed to fail fast", func() {
 		BeforeEach(func() {
 			conf := config.GinkgoConfigType{
@@ -654,6 +880,139 @@ var _ = Describe("Spec Runner", func() {
 		})
 	})
 
+	Describe("Running specs concurrently", func() {
+		var started chan string
+		var release chan bool
+
+		blockingBody := func(text string, fail bool) func() {
+			return func() {
+				started <- text
+				<-release
+				if fail {
+					failer.Fail(text, codelocation.New(0))
+				}
+			}
+		}
+
+		BeforeEach(func() {
+			started = make(chan string, 10)
+			release = make(chan bool)
+		})
+
+		It("should run up to Concurrency specs at once, keeping their failures apart", func() {
+			passing := newSpecWithBody("passing", blockingBody("passing", false))
+			failing := newSpecWithBody("failing", blockingBody("failing", true))
+			runner = newRunner(config.GinkgoConfigType{Concurrency: 2}, nil, nil, passing, failing)
+			runner.SetFailer(failer)
+
+			done := make(chan bool)
+			go func() {
+				done <- runner.Run()
+			}()
+			Eventually(started).Should(Receive())
+			Eventually(started).Should(Receive())
+			close(release)
+
+			Eventually(done).Should(Receive(BeFalse()))
+			Ω(passing.Passed()).Should(BeTrue())
+			Ω(failing.Failed()).Should(BeTrue())
+			Ω(reporter1.EndSummary.NumberOfPassedSpecs).Should(Equal(1))
+			Ω(reporter1.EndSummary.NumberOfFailedSpecs).Should(Equal(1))
+		})
+
+		It("should hand each spec its own random seed and sources of nondeterminism, even from the goroutines it starts", func() {
+			seeds := make(chan int64, 2)
+			seedingBody := func(text string) func() {
+				return func() {
+					started <- text
+					<-release
+					done := make(chan bool)
+					go func() {
+						seeds <- runner.CurrentSpecRandomSeed()
+						runner.RecordNondeterminism(text)
+						close(done)
+					}()
+					<-done
+				}
+			}
+			a := newSpecWithBody("A", seedingBody("A"))
+			b := newSpecWithBody("B", seedingBody("B"))
+			runner = newRunner(config.GinkgoConfigType{RandomSeed: 17, Concurrency: 2}, nil, nil, a, b)
+			runner.SetFailer(failer)
+
+			done := make(chan bool)
+			go func() {
+				done <- runner.Run()
+			}()
+			Eventually(started).Should(Receive())
+			Eventually(started).Should(Receive())
+			close(release)
+
+			Eventually(done).Should(Receive(BeTrue()))
+			Ω([]int64{<-seeds, <-seeds}).Should(ConsistOf(runner.SpecRandomSeed(a), runner.SpecRandomSeed(b)))
+			Ω(runner.SpecRandomSeed(a)).ShouldNot(Equal(runner.SpecRandomSeed(b)))
+			for _, summary := range reporter1.SpecSummaries {
+				Ω(summary.Nondeterminism).Should(Equal([]string{summary.ComponentTexts[len(summary.ComponentTexts)-1]}))
+			}
+		})
+
+		It("should run Serial specs alone", func() {
+			container := containernode.New("serial container", noneFlag, codelocation.New(0))
+			container.SetSerial()
+			serialSubject := leafnodes.NewItNode("serial", blockingBody("serial", false), noneFlag, codelocation.New(0), 0, failer, 0)
+			serial := spec.New(serialSubject, []*containernode.ContainerNode{container}, false)
+			runner = newRunner(config.GinkgoConfigType{Concurrency: 2}, nil, nil, newSpecWithBody("concurrent", blockingBody("concurrent", false)), serial)
+			runner.SetFailer(failer)
+
+			done := make(chan bool)
+			go func() {
+				done <- runner.Run()
+			}()
+			Eventually(started).Should(Receive(Equal("concurrent")))
+			Consistently(started).ShouldNot(Receive())
+			release <- true
+			Eventually(started).Should(Receive(Equal("serial")))
+			release <- true
+
+			Eventually(done).Should(Receive(BeTrue()))
+		})
+	})
+
+	Describe("Interrupting the suite", func() {
+		var started chan bool
+		var release chan bool
+
+		BeforeEach(func() {
+			started = make(chan bool, 1)
+			release = make(chan bool)
+			hanging := newSpecWithBody("hanging", func() {
+				started <- true
+				<-release
+			})
+			runner = newRunner(config.GinkgoConfigType{}, nil, newAftSuite("after-suite", false), hanging, newSpec("dont-see", noneFlag, false))
+		})
+
+		AfterEach(func() {
+			close(release)
+		})
+
+		It("should run the AfterSuite, report the cause and return false without waiting for the running spec", func() {
+			done := make(chan bool)
+			go func() {
+				done <- runner.Run()
+			}()
+			Eventually(started).Should(Receive())
+			runner.Interrupt(types.InterruptCauseTimeout, "The validation window closed.")
+
+			Eventually(done).Should(Receive(BeFalse()))
+			Ω(thingsThatRan).Should(Equal([]string{"after-suite"}))
+			Ω(reporter1.EndSummary.SuiteSucceeded).Should(BeFalse())
+			Ω(reporter1.EndSummary.SpecialSuiteFailureReasons).Should(Equal([]types.SpecialSuiteFailureReason{
+				{Cause: types.InterruptCauseTimeout, Message: "The validation window closed."},
+			}))
+		})
+	})
+
 	Describe("Marking failure and success", func() {
 		Context("when all tests pass", func() {
 			BeforeEach(func() {
@@ -687,6 +1046,49 @@ var _ = Describe("Spec Runner", func() {
 				Ω(reporter1.EndSummary.SuiteSucceeded).Should(BeFalse())
 			})
 		})
+		Context("when the suite only fails on failures of a given severity", func() {
+			newSpecWithSeverity := func(text string, severity types.Severity) *spec.Spec {
+				s := newSpec(text, noneFlag, true)
+				s.SetSeverity(severity)
+				return s
+			}
+
+			It("should report the failures of lower severities without failing", func() {
+				runner = newRunner(config.GinkgoConfigType{FailOnSeverity: "high", FailFast: true}, nil, nil, newSpecWithSeverity("low", types.SeverityLow), newSpecWithSeverity("medium", types.SeverityMedium), newSpec("passing", noneFlag, false))
+
+				Ω(runner.Run()).Should(BeTrue())
+				Ω(reporter1.EndSummary.SuiteSucceeded).Should(BeTrue())
+				Ω(reporter1.EndSummary.NumberOfFailedSpecs).Should(Equal(2))
+				Ω(thingsThatRan).Should(Equal([]string{"low", "medium", "passing"}))
+			})
+
+			It("should fail on failures of that severity or higher, and on failures without a severity", func() {
+				runner = newRunner(config.GinkgoConfigType{FailOnSeverity: "high"}, nil, nil, newSpecWithSeverity("critical", types.SeverityCritical))
+				Ω(runner.Run()).Should(BeFalse())
+
+				runner = newRunner(config.GinkgoConfigType{FailOnSeverity: "high"}, nil, nil, newSpec("unrated", noneFlag, true))
+				Ω(runner.Run()).Should(BeFalse())
+			})
+		})
+
+		Context("when no spec is left to run and the suite fails on empty", func() {
+			It("should return false and report why", func() {
+				runner = newRunner(config.GinkgoConfigType{FailOnEmpty: true}, nil, nil, newSpec("pending", pendingFlag, false))
+				runner.SetNumberOfSpecsToRun(0)
+
+				Ω(runner.Run()).Should(BeFalse())
+				Ω(reporter1.EndSummary.SuiteSucceeded).Should(BeFalse())
+				Ω(reporter1.EndSummary.SpecialSuiteFailureReasons).Should(HaveLen(1))
+				Ω(reporter1.EndSummary.SpecialSuiteFailureReasons[0].Cause).Should(Equal(types.InterruptCauseEmptySuite))
+			})
+
+			It("should pass when specs run", func() {
+				runner = newRunner(config.GinkgoConfigType{FailOnEmpty: true}, nil, nil, newSpec("passing", noneFlag, false))
+				runner.SetNumberOfSpecsToRun(1)
+
+				Ω(runner.Run()).Should(BeTrue())
+			})
+		})
 	})
 
 	Describe("Managing the writer", func() {
@@ -767,6 +1169,26 @@ var _ = Describe("Spec Runner", func() {
 		})
 	})
 
+	Describe("transforming texts", func() {
+		It("should apply the transformers, in order, to the texts reporters display only", func() {
+			var textWhileRunning string
+			runner = newRunner(config.GinkgoConfigType{}, nil, nil, newSpecWithBody("greeting.hello", func() {
+				summary, _ := runner.CurrentSpecSummary()
+				textWhileRunning = summary.ComponentTexts[0]
+			}))
+			runner.SetTextTransformers([]func(string) string{
+				func(text string) string { return strings.Replace(text, "greeting.hello", "Bonjour", 1) },
+				strings.ToUpper,
+			})
+			runner.Run()
+
+			Ω(textWhileRunning).Should(Equal("greeting.hello"))
+			Ω(reporter1.SpecWillRunSummaries[0].DisplayTexts).Should(Equal([]string{"BONJOUR"}))
+			Ω(reporter1.SpecSummaries[0].DisplayTexts).Should(Equal([]string{"BONJOUR"}))
+			Ω(reporter1.SpecSummaries[0].ComponentTexts).Should(Equal([]string{"greeting.hello"}))
+		})
+	})
+
 	Describe("generating a suite id", func() {
 		It("should generate an id randomly", func() {
 			runnerA := newRunner(config.GinkgoConfigType{}, nil, nil)
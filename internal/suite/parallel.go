@@ -0,0 +1,10 @@
+// +build !js,!wasip1
+
+package suite
+
+import "github.com/onsi/ginkgo/config"
+
+//inProcess returns conf as is: the parallel nodes are processes started by the Ginkgo CLI, which reach its server
+func inProcess(conf config.GinkgoConfigType) config.GinkgoConfigType {
+	return conf
+}
@@ -0,0 +1,17 @@
+// +build js wasip1
+
+package suite
+
+import "github.com/onsi/ginkgo/config"
+
+//inProcess folds the parallel nodes of conf into the calling process on js/wasm and wasip1, whose nodes are not started
+//by the Ginkgo CLI and cannot reach its server.  The process runs every spec as the only node: the resource locker, the
+//dependency tracker, the allocation and key-value clients and the suite processes keep their state in memory, and the
+//synchronized nodes run all their functions in the process.
+func inProcess(conf config.GinkgoConfigType) config.GinkgoConfigType {
+	conf.ParallelNode = 1
+	conf.ParallelTotal = 1
+	conf.SyncHost = ""
+	conf.StreamHost = ""
+	return conf
+}
@@ -1,18 +1,37 @@
 package suite
 
 import (
+	"fmt"
 	"math/rand"
 	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/onsi/ginkgo/internal/spec_iterator"
 
 	"github.com/onsi/ginkgo/config"
+	"github.com/onsi/ginkgo/internal/aggregatedreport"
+	"github.com/onsi/ginkgo/internal/allocation"
+	"github.com/onsi/ginkgo/internal/codeowners"
 	"github.com/onsi/ginkgo/internal/containernode"
+	"github.com/onsi/ginkgo/internal/dependency"
 	"github.com/onsi/ginkgo/internal/failer"
+	"github.com/onsi/ginkgo/internal/fixture"
+	"github.com/onsi/ginkgo/internal/hermetic"
+	"github.com/onsi/ginkgo/internal/invariant"
 	"github.com/onsi/ginkgo/internal/leafnodes"
+	"github.com/onsi/ginkgo/internal/parallelkv"
+	"github.com/onsi/ginkgo/internal/resourcelock"
+	"github.com/onsi/ginkgo/internal/runtimebudget"
+	"github.com/onsi/ginkgo/internal/skiplist"
 	"github.com/onsi/ginkgo/internal/spec"
 	"github.com/onsi/ginkgo/internal/specrunner"
+	"github.com/onsi/ginkgo/internal/suiteprocess"
 	"github.com/onsi/ginkgo/internal/writer"
 	"github.com/onsi/ginkgo/reporters"
 	"github.com/onsi/ginkgo/types"
@@ -22,11 +41,60 @@ type ginkgoTestingT interface {
 	Fail()
 }
 
+//deadliner is implemented by *testing.T, whose Deadline reports the deadline set by go test -timeout
+type deadliner interface {
+	Deadline() (deadline time.Time, ok bool)
+}
+
 type deferredContainerNode struct {
 	text         string
 	body         func()
 	flag         types.FlagType
 	codeLocation types.CodeLocation
+	labels       []string
+	options      ContainerOptions
+}
+
+//containerBuild records how long the body of a container took to build the spec tree, leaving out the time spent in
+//the bodies of its nested containers
+type containerBuild struct {
+	text         string
+	codeLocation types.CodeLocation
+	nested       time.Duration
+	duration     time.Duration
+}
+
+//bodylessIt records an It declared without a body, which is pending unless -strictPending is set
+type bodylessIt struct {
+	node         *leafnodes.ItNode
+	flag         types.FlagType
+	text         string
+	codeLocation types.CodeLocation
+}
+
+//ContainerOptions holds the optional behaviours of a container
+type ContainerOptions struct {
+	//OncePerContainer makes the BeforeEach and JustBeforeEach nodes of the container run once per process, before the
+	//first of its specs that runs, and its AfterEach and JustAfterEach nodes run once, after the last spec of the process
+	OncePerContainer bool
+	//PendingReason records why a pending container is pending
+	PendingReason string
+	//ProcessAffinity pins the specs of the container to the given parallel process when running in parallel
+	ProcessAffinity int
+	//Serial keeps the specs of the container from running concurrently with other specs within their process
+	Serial bool
+}
+
+type suiteSetup struct {
+	name         string
+	priority     int
+	codeLocation types.CodeLocation
+	node         leafnodes.SuiteNode
+}
+
+type oncePerContainerTeardown struct {
+	containerIndex int
+	node           *leafnodes.OncePerContainerTeardownNode
 }
 
 type Suite struct {
@@ -34,12 +102,41 @@ type Suite struct {
 	currentContainer  *containernode.ContainerNode
 
 	deferredContainerNodes []deferredContainerNode
+	containerBuilds        []*containerBuild
+	bodylessIts            []bodylessIt
+	buildingContainers     []*containerBuild
 
 	containerIndex      int
+	warmUpNode          leafnodes.SuiteNode
 	beforeSuiteNode     leafnodes.SuiteNode
+	suiteSetups         []suiteSetup
 	afterSuiteNode      leafnodes.SuiteNode
+	reportAfterSuites   []reportAfterSuite
+	onceTeardowns       []oncePerContainerTeardown
+	aroundEachNodes     []*leafnodes.AroundEachNode
+	beforeNodeHooks     []*leafnodes.BeforeNodeHook
+	onFailureHooks      []*leafnodes.OnFailureHook
+	invariants          []*invariant.Invariant
+	sharedFixtures      []*fixture.Fixture
+	resourceLocker      *resourcelock.Locker
+	dependencies        *dependency.Tracker
+	owners              map[string][]string
+	requiredFeatures    map[string][]string
+	hermeticAllowlists  map[string][]string
+	severities          map[string]types.Severity
+	expectedFailures    map[string]string
+	retryOn             map[string]func(string) bool
+	isolatedWorkDirs    map[string]bool
+	textTransformers    []func(string) string
+	specPolicies        []func([]*types.SpecSummary) []error
+	allocationClient    *allocation.Client
+	keyValueClient      *parallelkv.Client
+	aggregatedReport    *aggregatedreport.Collector
+	aggregatedClient    *aggregatedreport.Client
+	suiteProcesses      *suiteprocess.Manager
 	runner              *specrunner.SpecRunner
 	failer              *failer.Failer
+	config              config.GinkgoConfigType
 	running             bool
 	expandTopLevelNodes bool
 }
@@ -47,12 +144,28 @@ type Suite struct {
 func New(failer *failer.Failer) *Suite {
 	topLevelContainer := containernode.New("[Top Level]", types.FlagTypeNone, types.CodeLocation{})
 
+	keyValueClient := parallelkv.NewClient()
+	aggregatedReport := aggregatedreport.NewCollector()
 	return &Suite{
 		topLevelContainer:      topLevelContainer,
 		currentContainer:       topLevelContainer,
 		failer:                 failer,
 		containerIndex:         1,
 		deferredContainerNodes: []deferredContainerNode{},
+		resourceLocker:         resourcelock.New(),
+		dependencies:           dependency.New(),
+		owners:                 map[string][]string{},
+		requiredFeatures:       map[string][]string{},
+		hermeticAllowlists:     map[string][]string{},
+		severities:             map[string]types.Severity{},
+		expectedFailures:       map[string]string{},
+		retryOn:                map[string]func(string) bool{},
+		isolatedWorkDirs:       map[string]bool{},
+		allocationClient:       allocation.NewClient(),
+		keyValueClient:         keyValueClient,
+		aggregatedReport:       aggregatedReport,
+		aggregatedClient:       aggregatedreport.NewClient(aggregatedReport),
+		suiteProcesses:         suiteprocess.New(keyValueClient),
 	}
 }
 
@@ -64,17 +177,58 @@ func (suite *Suite) Run(t ginkgoTestingT, description string, reporters []report
 	if config.ParallelNode > config.ParallelTotal || config.ParallelNode < 1 {
 		panic("ginkgo.parallel.node is one-indexed and must be <= ginkgo.parallel.total")
 	}
+	config = inProcess(config)
 
 	suite.expandTopLevelNodes = true
 	for _, deferredNode := range suite.deferredContainerNodes {
-		suite.PushContainerNode(deferredNode.text, deferredNode.body, deferredNode.flag, deferredNode.codeLocation)
+		suite.pushContainerNode(deferredNode)
 	}
 
 	r := rand.New(rand.NewSource(config.RandomSeed))
 	suite.topLevelContainer.Shuffle(r)
-	iterator, hasProgrammaticFocus := suite.generateSpecsIterator(description, config)
-	suite.runner = specrunner.New(description, suite.beforeSuiteNode, iterator, suite.afterSuiteNode, reporters, writer, config)
+	skipStrings, warnings := skipStrings(config)
+	warnings = append(warnings, suite.applyStrictPending(config.StrictPending)...)
+	slowContainerWarnings := suite.slowContainerWarnings(config.SlowContainerThreshold)
+	warnings = append(warnings, slowContainerWarnings...)
+	if config.Hermetic && !hermetic.Supported() {
+		warnings = append(warnings, "-hermetic is only enforced on Linux: the specs ran without their hermeticity being checked.")
+	}
+	iterator, numberOfSpecsToRun, hasProgrammaticFocus, policyViolations := suite.generateSpecsIterator(description, config, skipStrings)
+	reporters = append(reporters, suite.aggregatedReport)
+	suite.runner = specrunner.New(description, suite.composedBeforeSuiteNode(), iterator, suite.composedAfterSuiteNode(), reporters, writer, config)
+	if len(policyViolations) > 0 {
+		suite.runner.FailSuite(types.InterruptCausePolicyViolation, fmt.Sprintf("The spec policies vetoed the run:\n  %s", strings.Join(policyViolations, "\n  ")))
+	}
+	if config.FailOnSlowContainers && len(slowContainerWarnings) > 0 {
+		suite.runner.FailSuite(types.InterruptCauseSlowContainers, fmt.Sprintf("%d container(s) took longer than -slowContainerThreshold to build the spec tree, and -failOnSlowContainers is set.", len(slowContainerWarnings)))
+	}
+	suite.runner.SetWarnings(warnings)
+	suite.runner.SetWarmUpNode(suite.warmUpNode)
+	suite.runner.SetReportAfterSuiteNodes(suite.reportAfterSuiteNodes())
+	suite.runner.SetTextTransformers(suite.textTransformers)
+	suite.runner.SetNumberOfSpecsToRun(numberOfSpecsToRun)
+	suite.runner.SetSharedFixtures(suite.sharedFixtures)
+	if config.ParallelTotal > 1 {
+		suite.resourceLocker.Connect(config.ParallelNode, config.SyncHost)
+		suite.dependencies.Connect(config.ParallelNode, config.SyncHost)
+		suite.allocationClient.Connect(config.SyncHost)
+		suite.keyValueClient.Connect(config.SyncHost)
+		suite.aggregatedClient.Connect(config.SyncHost)
+		suite.suiteProcesses.Connect(config.ParallelNode, config.ParallelTotal, config.SyncHost)
+	}
+	suite.runner.SetResourceLocker(suite.resourceLocker)
+	suite.runner.SetDependencies(suite.dependencies)
+	suite.runner.SetFailer(suite.failer)
+	suite.failer.DetectGoroutineFailures(config.DetectGoroutineFailures)
+	suite.failer.SetNodeTimeout(time.Duration(config.NodeTimeout * float64(time.Second)))
+	suite.runner.SetSuiteProcesses(suite.suiteProcesses)
+	if t, ok := t.(deadliner); ok {
+		if deadline, ok := t.Deadline(); ok {
+			suite.runner.SetDeadline(deadline)
+		}
+	}
 
+	suite.config = config
 	suite.running = true
 	success := suite.runner.Run()
 	if !success {
@@ -83,13 +237,100 @@ func (suite *Suite) Run(t ginkgoTestingT, description string, reporters []report
 	return success, hasProgrammaticFocus
 }
 
-func (suite *Suite) generateSpecsIterator(description string, config config.GinkgoConfigType) (spec_iterator.SpecIterator, bool) {
+//composedBeforeSuiteNode runs the BeforeSuite node and the registered suite setups ordered by priority.  The BeforeSuite
+//node has priority 0 and runs ahead of the suite setups of the same priority.
+func (suite *Suite) composedBeforeSuiteNode() leafnodes.SuiteNode {
+	if len(suite.suiteSetups) == 0 {
+		return suite.beforeSuiteNode
+	}
+
+	setups := append([]suiteSetup{}, suite.suiteSetups...)
+	sort.SliceStable(setups, func(i, j int) bool {
+		return setups[i].priority < setups[j].priority
+	})
+
+	nodes := []leafnodes.SuiteNode{}
+	addedBeforeSuiteNode := suite.beforeSuiteNode == nil
+	for _, setup := range setups {
+		if !addedBeforeSuiteNode && setup.priority >= 0 {
+			nodes = append(nodes, suite.beforeSuiteNode)
+			addedBeforeSuiteNode = true
+		}
+		nodes = append(nodes, setup.node)
+	}
+	if !addedBeforeSuiteNode {
+		nodes = append(nodes, suite.beforeSuiteNode)
+	}
+	return leafnodes.NewComposedSuiteNode(nodes...)
+}
+
+//composedAfterSuiteNode runs the teardown nodes of the OncePerContainer containers that are due, before the AfterSuite node.
+//They run in the order they would have run around a spec: JustAfterEach nodes first, innermost containers first.
+func (suite *Suite) composedAfterSuiteNode() leafnodes.SuiteNode {
+	if len(suite.onceTeardowns) == 0 {
+		return suite.afterSuiteNode
+	}
+
+	teardowns := append([]oncePerContainerTeardown{}, suite.onceTeardowns...)
+	sort.SliceStable(teardowns, func(i, j int) bool {
+		iIsJustAfterEach := teardowns[i].node.Type() == types.SpecComponentTypeJustAfterEach
+		jIsJustAfterEach := teardowns[j].node.Type() == types.SpecComponentTypeJustAfterEach
+		if iIsJustAfterEach != jIsJustAfterEach {
+			return iIsJustAfterEach
+		}
+		return teardowns[i].containerIndex > teardowns[j].containerIndex
+	})
+
+	nodes := []*leafnodes.OncePerContainerTeardownNode{}
+	for _, teardown := range teardowns {
+		nodes = append(nodes, teardown.node)
+	}
+	return leafnodes.NewOncePerContainerTeardownSuiteNode(nodes, suite.afterSuiteNode)
+}
+
+//skipStrings returns the -skip patterns along with those of the entries of the -skipFile skip list that have not expired,
+//and a warning for each entry that has
+func skipStrings(config config.GinkgoConfigType) ([]string, []string) {
+	if config.SkipFile == "" {
+		return config.SkipStrings, nil
+	}
+
+	entries, err := skiplist.Load(config.SkipFile)
+	if err != nil {
+		panic(fmt.Sprintf("Failed to load the skip file: %s", err))
+	}
+	patterns, warnings := skiplist.Apply(entries, time.Now())
+	return append(append([]string{}, config.SkipStrings...), patterns...), warnings
+}
+
+//generateSpecsIterator returns the iterator over the specs of the suite, how many of them run across all the parallel
+//nodes, whether the suite has programmatically focused specs and the errors of the spec policies that vetoed the run
+func (suite *Suite) generateSpecsIterator(description string, config config.GinkgoConfigType, skipStrings []string) (spec_iterator.SpecIterator, int, bool, []string) {
 	specsSlice := []*spec.Spec{}
 	suite.topLevelContainer.BackPropagateProgrammaticFocus()
 	for _, collatedNodes := range suite.topLevelContainer.Collate() {
-		specsSlice = append(specsSlice, spec.New(collatedNodes.Subject, collatedNodes.Containers, config.EmitSpecProgress))
+		s := spec.New(collatedNodes.Subject, collatedNodes.Containers, config.EmitSpecProgress, collatedNodes.Labels...)
+		s.SetAroundEachNodes(suite.aroundEachNodes)
+		s.SetBeforeNodeHooks(suite.beforeNodeHooks)
+		s.SetOnFailureHooks(suite.onFailureHooks)
+		if config.CheckInvariants {
+			s.SetInvariants(suite.invariants)
+		}
+		s.SetFailOnCleanupPanics(config.FailOnCleanupPanics)
+		s.SetRestoreEnvironment(config.RestoreEnvironment)
+		s.SetCleanupOnly(config.CleanupOnly)
+		specsSlice = append(specsSlice, s)
 	}
 
+	suite.assignOwners(specsSlice, config.CodeOwnersFile)
+	suite.assignSeverities(specsSlice)
+	suite.assignRequiredFeatures(specsSlice)
+	suite.assignExpectedFailures(specsSlice)
+	suite.assignRetryOn(specsSlice, config.RetryOnPatterns)
+	suite.assignIsolatedWorkingDirs(specsSlice)
+	suite.assignHermeticity(specsSlice, config)
+	assignRuntimeBudgets(specsSlice, config)
+
 	specs := spec.NewSpecs(specsSlice)
 	specs.RegexScansFilePath = config.RegexScansFilePath
 
@@ -97,25 +338,300 @@ func (suite *Suite) generateSpecsIterator(description string, config config.Gink
 		specs.Shuffle(rand.New(rand.NewSource(config.RandomSeed)))
 	}
 
-	specs.ApplyFocus(description, config.FocusStrings, config.SkipStrings)
+	specs.ApplyFocus(description, config.FocusStrings, skipStrings)
+	specs.SkipMissingFeatures(config.Features)
 
 	if config.SkipMeasurements {
 		specs.SkipMeasurements()
 	}
 
-	var iterator spec_iterator.SpecIterator
+	policyViolations := suite.checkSpecPolicies(specs.Specs())
+	if len(policyViolations) > 0 {
+		for _, spec := range specs.Specs() {
+			spec.SkipWithReason("the spec policies vetoed the run")
+		}
+	}
 
-	if config.ParallelTotal > 1 {
-		iterator = spec_iterator.NewParallelIterator(specs.Specs(), config.SyncHost)
+	//when running in parallel, which of the specs run on this node is not known in advance, so shared fixtures live until the end of the suite
+	if config.ParallelTotal == 1 {
+		suite.expectSharedFixtureReferences(specs.Specs())
+	}
+
+	//-parallelDeterministic shards the specs as when the server has no counter: each node runs the same slice of the specs
+	//in every run, whatever the timing store holds
+	sharded := config.ParallelDeterministic
+	if config.ParallelTotal > 1 && !sharded {
 		resp, err := http.Get(config.SyncHost + "/has-counter")
-		if err != nil || resp.StatusCode != http.StatusOK {
-			iterator = spec_iterator.NewShardedParallelIterator(specs.Specs(), config.ParallelTotal, config.ParallelNode)
+		sharded = err != nil || resp.StatusCode != http.StatusOK
+		if !sharded {
+			//nodes pull specs off of a shared counter, so running the longest specs first keeps any one node from finishing last
+			if store := reporters.NewTimingStore(config, ""); store != nil {
+				specs.SortByExpectedRunTime(func(spec *spec.Spec) (time.Duration, bool) {
+					return store.Get(reporters.SpecFullText(spec.Summary("")))
+				})
+			}
 		}
+	}
+
+	//prerequisites must come before the specs that depend on them, whatever the order the specs were sorted in
+	orderedSpecs, err := suite.dependencies.Order(specs.Specs())
+	if err != nil {
+		panic(err.Error())
+	}
+
+	var iterator spec_iterator.SpecIterator
+	if config.ParallelTotal == 1 {
+		iterator = spec_iterator.NewSerialIterator(orderedSpecs)
+	} else if sharded {
+		iterator = spec_iterator.NewShardedParallelIterator(orderedSpecs, config.ParallelTotal, config.ParallelNode)
 	} else {
-		iterator = spec_iterator.NewSerialIterator(specs.Specs())
+		iterator = spec_iterator.NewParallelIterator(orderedSpecs, config.ParallelTotal, config.ParallelNode, config.SyncHost)
+	}
+
+	numberOfSpecsToRun := 0
+	for _, spec := range orderedSpecs {
+		if !spec.Skipped() && !spec.Pending() {
+			numberOfSpecsToRun++
+		}
+	}
+
+	return iterator, numberOfSpecsToRun, specs.HasProgrammaticFocus(), policyViolations
+}
+
+//checkSpecPolicies hands the summaries of all the specs of the suite, in the order they are about to run, to the spec
+//policies and returns the errors they raised
+func (suite *Suite) checkSpecPolicies(specs []*spec.Spec) []string {
+	if len(suite.specPolicies) == 0 {
+		return nil
+	}
+	summaries := []*types.SpecSummary{}
+	for _, spec := range specs {
+		summaries = append(summaries, spec.Summary(""))
+	}
+	violations := []string{}
+	for _, policy := range suite.specPolicies {
+		for _, err := range policy(summaries) {
+			violations = append(violations, err.Error())
+		}
+	}
+	return violations
+}
+
+//assignOwners gives each spec the owners declared by its innermost node decorated with Owner or, failing that, the
+//owners the CODEOWNERS file at codeOwnersFile gives to the file of the spec
+func (suite *Suite) assignOwners(specs []*spec.Spec, codeOwnersFile string) {
+	var codeOwners *codeowners.CodeOwners
+	if codeOwnersFile != "" {
+		var err error
+		codeOwners, err = codeowners.Load(codeOwnersFile)
+		if err != nil {
+			panic(fmt.Sprintf("Failed to load the CODEOWNERS file: %s", err))
+		}
 	}
 
-	return iterator, specs.HasProgrammaticFocus()
+	for _, s := range specs {
+		locations := s.Summary("").ComponentCodeLocations
+		var owners []string
+		for i := len(locations) - 1; i >= 0 && owners == nil; i-- {
+			owners = suite.owners[locations[i].String()]
+		}
+		if owners == nil && codeOwners != nil {
+			owners = codeOwners.Owners(locations[len(locations)-1].FileName)
+		}
+		s.SetOwners(owners)
+	}
+}
+
+//assignSeverities gives each spec the severity declared by its innermost node decorated with one
+func (suite *Suite) assignSeverities(specs []*spec.Spec) {
+	for _, s := range specs {
+		locations := s.Summary("").ComponentCodeLocations
+		severity := types.SeverityNone
+		for i := len(locations) - 1; i >= 0 && severity == types.SeverityNone; i-- {
+			severity = suite.severities[locations[i].String()]
+		}
+		s.SetSeverity(severity)
+	}
+}
+
+//assignExpectedFailures gives each spec the reason to fail declared by its innermost node decorated with ExpectFailure
+func (suite *Suite) assignExpectedFailures(specs []*spec.Spec) {
+	if len(suite.expectedFailures) == 0 {
+		return
+	}
+	for _, s := range specs {
+		locations := s.Summary("").ComponentCodeLocations
+		reason := ""
+		for i := len(locations) - 1; i >= 0 && reason == ""; i-- {
+			reason = suite.expectedFailures[locations[i].String()]
+		}
+		s.SetExpectedFailure(reason)
+	}
+}
+
+//assignRequiredFeatures gives each spec the features required by any of its nodes decorated with RequiresFeature
+func (suite *Suite) assignRequiredFeatures(specs []*spec.Spec) {
+	if len(suite.requiredFeatures) == 0 {
+		return
+	}
+	for _, s := range specs {
+		var features []string
+		for _, location := range s.Summary("").ComponentCodeLocations {
+			features = append(features, suite.requiredFeatures[location.String()]...)
+		}
+		s.SetRequiredFeatures(features)
+	}
+}
+
+//assignRetryOn tells each spec which of its failures -flakeAttempts retries: those matching the patterns of its innermost
+//node decorated with RetryOn or, failing that, those matching the -retryOn patterns
+func (suite *Suite) assignRetryOn(specs []*spec.Spec, patterns []string) {
+	var fallback func(string) bool
+	if len(patterns) > 0 {
+		re, err := regexp.Compile(strings.Join(patterns, "|"))
+		if err != nil {
+			panic(fmt.Sprintf("Invalid -retryOn: %s", err))
+		}
+		fallback = re.MatchString
+	}
+	for _, s := range specs {
+		locations := s.Summary("").ComponentCodeLocations
+		retryOn := fallback
+		for i := len(locations) - 1; i >= 0; i-- {
+			if declared, ok := suite.retryOn[locations[i].String()]; ok {
+				retryOn = declared
+				break
+			}
+		}
+		s.SetRetryOn(retryOn)
+	}
+}
+
+//assignIsolatedWorkingDirs isolates the working directory of the specs decorated with IsolateWorkingDir, or belonging to
+//a container decorated with it
+func (suite *Suite) assignIsolatedWorkingDirs(specs []*spec.Spec) {
+	if len(suite.isolatedWorkDirs) == 0 {
+		return
+	}
+	for _, s := range specs {
+		for _, location := range s.Summary("").ComponentCodeLocations {
+			if suite.isolatedWorkDirs[location.String()] {
+				s.SetIsolateWorkingDir(true)
+				break
+			}
+		}
+	}
+}
+
+//assignHermeticity makes the specs fail when they escape their sandbox with -hermetic.  Each spec may do what the
+//HermeticAllow decorators of all its nodes allow, write files under the temporary directory and -artifactsDir, and talk
+//to the servers of the parallel nodes.
+func (suite *Suite) assignHermeticity(specs []*spec.Spec, config config.GinkgoConfigType) {
+	if !config.Hermetic || !hermetic.Supported() {
+		return
+	}
+	writableDirs := []string{os.TempDir()}
+	if config.ArtifactsDir != "" {
+		writableDirs = append(writableDirs, config.ArtifactsDir)
+	}
+	servers := []string{}
+	for _, host := range []string{config.SyncHost, config.StreamHost} {
+		if u, err := url.Parse(host); err == nil && u.Host != "" {
+			if _, err := hermetic.ParseAllowlist([]string{u.Host}); err == nil {
+				servers = append(servers, u.Host)
+			}
+		}
+	}
+
+	for _, s := range specs {
+		entries := append([]string{}, servers...)
+		for _, location := range s.Summary("").ComponentCodeLocations {
+			entries = append(entries, suite.hermeticAllowlists[location.String()]...)
+		}
+		allowlist, err := hermetic.ParseAllowlist(entries)
+		if err != nil {
+			panic(fmt.Sprintf("Invalid HermeticAllow: %s", err))
+		}
+		s.SetHermetic(allowlist, writableDirs)
+	}
+}
+
+//assignRuntimeBudgets gives each spec the tightest of the budgets the -runtimeBudgets file gives to its labels
+func assignRuntimeBudgets(specs []*spec.Spec, config config.GinkgoConfigType) {
+	if config.RuntimeBudgetsFile == "" {
+		return
+	}
+	budgets, err := runtimebudget.Load(config.RuntimeBudgetsFile)
+	if err != nil {
+		panic(fmt.Sprintf("Failed to load the runtime budgets: %s", err))
+	}
+	for _, s := range specs {
+		if budget, ok := runtimebudget.For(budgets, s.Labels()); ok {
+			s.SetRuntimeBudget(budget.Label, budget.Limit, config.FailOnRuntimeBudgets)
+		}
+	}
+}
+
+//slowContainerWarnings warns about the containers whose body took longer than threshold seconds to build the spec tree:
+//their slow work runs in every parallel process, even when all their specs are filtered out
+func (suite *Suite) slowContainerWarnings(threshold float64) []string {
+	if threshold <= 0 {
+		return nil
+	}
+	warnings := []string{}
+	for _, build := range suite.containerBuilds {
+		if build.duration.Seconds() > threshold {
+			warnings = append(warnings, fmt.Sprintf("%s: the body of the container %q took %s to build the spec tree, more than -slowContainerThreshold (%gs).  Move slow work out of container bodies, into BeforeSuite, BeforeEach or the specs.", build.codeLocation, build.text, build.duration.Round(time.Millisecond), threshold))
+		}
+	}
+	return warnings
+}
+
+//applyStrictPending makes the Its declared without a body run, and fail, when strict is set: they are pending otherwise.
+//It returns a warning listing them either way.
+func (suite *Suite) applyStrictPending(strict bool) []string {
+	if len(suite.bodylessIts) == 0 {
+		return nil
+	}
+	outcome := "are pending (see -strictPending)"
+	if strict {
+		outcome = "fail, as -strictPending is set"
+	}
+	warning := fmt.Sprintf("%d It(s) declared without a body %s:", len(suite.bodylessIts), outcome)
+	for _, it := range suite.bodylessIts {
+		if strict {
+			it.node.SetFlag(it.flag)
+			it.node.SetPendingReason("")
+		}
+		warning += fmt.Sprintf("\n  %s\n    %s", it.text, it.codeLocation)
+	}
+	return []string{warning}
+}
+
+//expectSharedFixtureReferences counts, for each shared fixture, the specs that declare it and are expected to run
+func (suite *Suite) expectSharedFixtureReferences(specs []*spec.Spec) {
+	if len(suite.sharedFixtures) == 0 {
+		return
+	}
+	for _, spec := range specs {
+		if spec.Skipped() || spec.Pending() {
+			continue
+		}
+		componentCodeLocations := spec.Summary("").ComponentCodeLocations
+		for _, sharedFixture := range suite.sharedFixtures {
+			if sharedFixture.IsDeclaredBy(componentCodeLocations) {
+				sharedFixture.ExpectReference()
+			}
+		}
+	}
+}
+
+//Config returns the configuration the suite runs with, once it is running
+func (suite *Suite) Config() (config.GinkgoConfigType, bool) {
+	if !suite.running {
+		return config.GinkgoConfigType{}, false
+	}
+	return suite.config, true
 }
 
 func (suite *Suite) CurrentRunningSpecSummary() (*types.SpecSummary, bool) {
@@ -125,13 +641,215 @@ func (suite *Suite) CurrentRunningSpecSummary() (*types.SpecSummary, bool) {
 	return suite.runner.CurrentSpecSummary()
 }
 
+func (suite *Suite) RecordStep(text string, codeLocation types.CodeLocation) {
+	if suite.running {
+		suite.runner.RecordStep(text, codeLocation)
+	}
+}
+
+func (suite *Suite) RecordGherkinStep(keyword string, text string, codeLocation types.CodeLocation) func(types.SpecState) {
+	if suite.running {
+		return suite.runner.RecordGherkinStep(keyword, text, codeLocation)
+	}
+	return func(types.SpecState) {}
+}
+
+func (suite *Suite) DeferCleanup(body interface{}, codeLocation types.CodeLocation) {
+	if !suite.running || !suite.runner.DeferCleanup(body, codeLocation) {
+		suite.failer.Fail("You may only call DeferCleanup from within the BeforeEach, JustBeforeEach, It, JustAfterEach and AfterEach nodes of a spec", codeLocation)
+	}
+}
+
+//SpecRandomSeed returns the seed of the running spec, or the seed of the suite outside of a spec
+func (suite *Suite) SpecRandomSeed(suiteSeed int64) int64 {
+	if !suite.running {
+		return suiteSeed
+	}
+	return suite.runner.CurrentSpecRandomSeed()
+}
+
+func (suite *Suite) RecordNondeterminism(description string) {
+	if suite.running {
+		suite.runner.RecordNondeterminism(description)
+	}
+}
+
+//RecordAdditionalFailure records a failure against the running spec, and returns false when no spec is running
+func (suite *Suite) RecordAdditionalFailure(failure types.SpecFailure) bool {
+	if !suite.running {
+		return false
+	}
+	return suite.runner.RecordAdditionalFailure(failure)
+}
+
+//AddReportEntry attaches entry to the report of the running spec, and returns false when no spec is running
+func (suite *Suite) AddReportEntry(entry types.ReportEntry) bool {
+	if !suite.running {
+		return false
+	}
+	return suite.runner.AddReportEntry(entry)
+}
+
+//Interrupt shuts the running suite down the way SIGINT does, except that Run returns rather than exiting the process.
+//It returns false when the suite is not running.
+func (suite *Suite) Interrupt(cause types.InterruptCause, message string) bool {
+	if !suite.running {
+		return false
+	}
+	suite.runner.Interrupt(cause, message)
+	return true
+}
+
+//RegisterTextTransformer adds a transformer to apply to the texts of the specs handed to reporters
+func (suite *Suite) RegisterTextTransformer(transform func(string) string) {
+	if suite.running {
+		panic("You may only call RegisterTextTransformer before running the specs")
+	}
+	suite.textTransformers = append(suite.textTransformers, transform)
+}
+
+//RegisterSpecPolicy adds a policy to check the specs of the suite against before they run
+func (suite *Suite) RegisterSpecPolicy(policy func([]*types.SpecSummary) []error) {
+	if suite.running {
+		panic("You may only call RegisterSpecPolicy before running the specs")
+	}
+	suite.specPolicies = append(suite.specPolicies, policy)
+}
+
+//RegisterSuiteInvariant registers a check of the state shared by the specs, run after each spec with -checkInvariants
+func (suite *Suite) RegisterSuiteInvariant(check func() error, codeLocation types.CodeLocation) {
+	if suite.running {
+		panic("You may only call RegisterSuiteInvariant before running the specs")
+	}
+	suite.invariants = append(suite.invariants, invariant.New(check, codeLocation))
+}
+
+func (suite *Suite) SetWarmUpNode(body interface{}, codeLocation types.CodeLocation, timeout time.Duration) {
+	if suite.warmUpNode != nil {
+		panic(fmt.Sprintf("You may only call WarmUp once!  WarmUp was already called at %s.", suite.warmUpNode.Summary().CodeLocation))
+	}
+	suite.warmUpNode = leafnodes.NewWarmUpNode(body, codeLocation, timeout, suite.failer)
+}
+
 func (suite *Suite) SetBeforeSuiteNode(body interface{}, codeLocation types.CodeLocation, timeout time.Duration) {
 	if suite.beforeSuiteNode != nil {
-		panic("You may only call BeforeSuite once!")
+		panic(fmt.Sprintf("You may only call BeforeSuite once!  BeforeSuite was already called at %s.  Use RegisterSuiteSetup to compose suite setup from several places.", suite.beforeSuiteNode.Summary().CodeLocation))
 	}
 	suite.beforeSuiteNode = leafnodes.NewBeforeSuiteNode(body, codeLocation, timeout, suite.failer)
 }
 
+func (suite *Suite) RegisterSuiteSetup(name string, priority int, body interface{}, codeLocation types.CodeLocation, timeout time.Duration) {
+	for _, setup := range suite.suiteSetups {
+		if setup.name != name {
+			continue
+		}
+		if setup.codeLocation.FileName == codeLocation.FileName && setup.codeLocation.LineNumber == codeLocation.LineNumber {
+			return
+		}
+		panic(fmt.Sprintf("Suite setup %q was registered twice: at %s and at %s", name, setup.codeLocation, codeLocation))
+	}
+
+	suite.suiteSetups = append(suite.suiteSetups, suiteSetup{
+		name:         name,
+		priority:     priority,
+		codeLocation: codeLocation,
+		node:         leafnodes.NewBeforeSuiteNode(body, codeLocation, timeout, suite.failer),
+	})
+}
+
+func (suite *Suite) RegisterSharedFixture(name string, setup func() interface{}, teardown func(interface{}), codeLocation types.CodeLocation) *fixture.Fixture {
+	for _, sharedFixture := range suite.sharedFixtures {
+		if sharedFixture.Name() == name {
+			panic(fmt.Sprintf("SharedFixture %q was registered twice: at %s and at %s", name, sharedFixture.CodeLocation(), codeLocation))
+		}
+	}
+
+	sharedFixture := fixture.New(name, setup, teardown, codeLocation, suite.failer)
+	suite.sharedFixtures = append(suite.sharedFixtures, sharedFixture)
+	return sharedFixture
+}
+
+//DeclareDependencies records that the container or spec at codeLocation only runs if the specs refs refer to passed
+func (suite *Suite) DeclareDependencies(codeLocation types.CodeLocation, refs ...string) {
+	suite.dependencies.DeclareAt(codeLocation, refs...)
+}
+
+//DeclareOwners records the owners of the container or spec at codeLocation
+func (suite *Suite) DeclareOwners(codeLocation types.CodeLocation, owners ...string) {
+	suite.owners[codeLocation.String()] = append(suite.owners[codeLocation.String()], owners...)
+}
+
+//DeclareRequiredFeatures records the features the specs of the container or the spec at codeLocation require
+func (suite *Suite) DeclareRequiredFeatures(codeLocation types.CodeLocation, features ...string) {
+	suite.requiredFeatures[codeLocation.String()] = append(suite.requiredFeatures[codeLocation.String()], features...)
+}
+
+//DeclareHermeticAllowlist records what the specs of the container or the spec at codeLocation may do under -hermetic
+func (suite *Suite) DeclareHermeticAllowlist(codeLocation types.CodeLocation, entries ...string) {
+	suite.hermeticAllowlists[codeLocation.String()] = append(suite.hermeticAllowlists[codeLocation.String()], entries...)
+}
+
+//DeclareSeverity records the severity of the container or spec at codeLocation
+func (suite *Suite) DeclareSeverity(codeLocation types.CodeLocation, severity types.Severity) {
+	suite.severities[codeLocation.String()] = severity
+}
+
+//DeclareExpectedFailure records why the container or spec at codeLocation is expected to fail
+func (suite *Suite) DeclareExpectedFailure(codeLocation types.CodeLocation, reason string) {
+	suite.expectedFailures[codeLocation.String()] = reason
+}
+
+//DeclareRetryOn records which failures of the specs of the container or the spec at codeLocation -flakeAttempts retries
+func (suite *Suite) DeclareRetryOn(codeLocation types.CodeLocation, retryOn func(string) bool) {
+	suite.retryOn[codeLocation.String()] = retryOn
+}
+
+//DeclareIsolatedWorkingDir records that the specs of the container or the spec at codeLocation run in a temporary
+//working directory of their own
+func (suite *Suite) DeclareIsolatedWorkingDir(codeLocation types.CodeLocation) {
+	suite.isolatedWorkDirs[codeLocation.String()] = true
+}
+
+//DeclareResources records that the container or spec at codeLocation requires the given resources: such specs hold them
+//while they run, so that they do not run at the same time as other specs requiring them on other parallel nodes
+func (suite *Suite) DeclareResources(codeLocation types.CodeLocation, resources ...string) {
+	suite.resourceLocker.DeclareAt(codeLocation, resources...)
+}
+
+//LockResources blocks until the running node holds the given resources
+func (suite *Suite) LockResources(resources ...string) error {
+	return suite.resourceLocker.Lock(resources...)
+}
+
+func (suite *Suite) UnlockResources(resources ...string) error {
+	return suite.resourceLocker.Unlock(resources...)
+}
+
+//AllocatePort returns a port that no other parallel node is handed
+func (suite *Suite) AllocatePort() (int, error) {
+	return suite.allocationClient.Port()
+}
+
+//UniqueName returns a name starting with prefix that no other parallel node is handed
+func (suite *Suite) UniqueName(prefix string) (string, error) {
+	return suite.allocationClient.UniqueName(prefix)
+}
+
+//KeyValueClient returns the client of the key-value store shared by the parallel nodes
+func (suite *Suite) KeyValueClient() *parallelkv.Client {
+	return suite.keyValueClient
+}
+
+//AggregatedReport returns the report of the specs completed so far by all the parallel nodes
+func (suite *Suite) AggregatedReport() (types.AggregatedReport, error) {
+	return suite.aggregatedClient.FetchAggregatedReport()
+}
+
+//StartSuiteProcess starts an external process once for the whole suite run, see suiteprocess.Manager
+func (suite *Suite) StartSuiteProcess(name string, command func() (*exec.Cmd, string), readinessCheck func(address string) error, timeout time.Duration) (string, error) {
+	return suite.suiteProcesses.Start(name, command, readinessCheck, timeout)
+}
+
 func (suite *Suite) SetAfterSuiteNode(body interface{}, codeLocation types.CodeLocation, timeout time.Duration) {
 	if suite.afterSuiteNode != nil {
 		panic("You may only call AfterSuite once!")
@@ -139,9 +857,37 @@ func (suite *Suite) SetAfterSuiteNode(body interface{}, codeLocation types.CodeL
 	suite.afterSuiteNode = leafnodes.NewAfterSuiteNode(body, codeLocation, timeout, suite.failer)
 }
 
+//reportAfterSuite is a ReportAfterSuite node, along with its ReportOrder
+type reportAfterSuite struct {
+	node  leafnodes.SuiteNode
+	order int
+}
+
+//PushReportAfterSuiteNode registers a ReportAfterSuite node, which body runs with the report of the whole suite run
+func (suite *Suite) PushReportAfterSuiteNode(text string, body func(types.AggregatedReport), order int, codeLocation types.CodeLocation, timeout time.Duration) {
+	if suite.running {
+		panic("You may only call ReportAfterSuite before running the specs")
+	}
+	node := leafnodes.NewReportAfterSuiteNode(text, body, codeLocation, timeout, suite.failer, suite.aggregatedClient.FetchAggregatedReport)
+	suite.reportAfterSuites = append(suite.reportAfterSuites, reportAfterSuite{node: node, order: order})
+}
+
+//reportAfterSuiteNodes returns the ReportAfterSuite nodes by ReportOrder, then in the order they were registered
+func (suite *Suite) reportAfterSuiteNodes() []leafnodes.SuiteNode {
+	reports := append([]reportAfterSuite{}, suite.reportAfterSuites...)
+	sort.SliceStable(reports, func(i, j int) bool {
+		return reports[i].order < reports[j].order
+	})
+	nodes := []leafnodes.SuiteNode{}
+	for _, report := range reports {
+		nodes = append(nodes, report.node)
+	}
+	return nodes
+}
+
 func (suite *Suite) SetSynchronizedBeforeSuiteNode(bodyA interface{}, bodyB interface{}, codeLocation types.CodeLocation, timeout time.Duration) {
 	if suite.beforeSuiteNode != nil {
-		panic("You may only call BeforeSuite once!")
+		panic(fmt.Sprintf("You may only call BeforeSuite once!  BeforeSuite was already called at %s.  Use RegisterSuiteSetup to compose suite setup from several places.", suite.beforeSuiteNode.Summary().CodeLocation))
 	}
 	suite.beforeSuiteNode = leafnodes.NewSynchronizedBeforeSuiteNode(bodyA, bodyB, codeLocation, timeout, suite.failer)
 }
@@ -153,7 +899,27 @@ func (suite *Suite) SetSynchronizedAfterSuiteNode(bodyA interface{}, bodyB inter
 	suite.afterSuiteNode = leafnodes.NewSynchronizedAfterSuiteNode(bodyA, bodyB, codeLocation, timeout, suite.failer)
 }
 
-func (suite *Suite) PushContainerNode(text string, body func(), flag types.FlagType, codeLocation types.CodeLocation) {
+func (suite *Suite) PushContainerNode(text string, body func(), flag types.FlagType, codeLocation types.CodeLocation, labels ...string) {
+	suite.pushContainerNode(deferredContainerNode{text, body, flag, codeLocation, labels, ContainerOptions{}})
+}
+
+//PushPendingContainerNode pushes a pending container, along with the reason it is pending
+func (suite *Suite) PushPendingContainerNode(text string, body func(), reason string, codeLocation types.CodeLocation, labels ...string) {
+	suite.pushContainerNode(deferredContainerNode{text, body, types.FlagTypePending, codeLocation, labels, ContainerOptions{PendingReason: reason}})
+}
+
+//PushOncePerContainerNode pushes a container whose BeforeEach and JustBeforeEach nodes run once per process, before the first
+//of its specs that runs, and whose AfterEach and JustAfterEach nodes run once, after the last spec of the process.
+func (suite *Suite) PushOncePerContainerNode(text string, body func(), flag types.FlagType, codeLocation types.CodeLocation, labels ...string) {
+	suite.pushContainerNode(deferredContainerNode{text, body, flag, codeLocation, labels, ContainerOptions{OncePerContainer: true}})
+}
+
+//PushContainerNodeWithOptions pushes a container with the given optional behaviours
+func (suite *Suite) PushContainerNodeWithOptions(text string, body func(), flag types.FlagType, codeLocation types.CodeLocation, options ContainerOptions, labels ...string) {
+	suite.pushContainerNode(deferredContainerNode{text, body, flag, codeLocation, labels, options})
+}
+
+func (suite *Suite) pushContainerNode(node deferredContainerNode) {
 	/*
 		We defer walking the container nodes (which immediately evaluates the `body` function)
 		until `RunSpecs` is called.  We do this by storing off the deferred container nodes.  Then, when
@@ -167,61 +933,159 @@ func (suite *Suite) PushContainerNode(text string, body func(), flag types.FlagT
 
 	*/
 	if !suite.expandTopLevelNodes {
-		suite.deferredContainerNodes = append(suite.deferredContainerNodes, deferredContainerNode{text, body, flag, codeLocation})
+		suite.deferredContainerNodes = append(suite.deferredContainerNodes, node)
 		return
 	}
 
-	container := containernode.New(text, flag, codeLocation)
+	container := containernode.New(node.text, node.flag, node.codeLocation, node.labels...)
+	if node.options.OncePerContainer {
+		container.SetOncePerContainer()
+	}
+	if node.options.PendingReason != "" {
+		container.SetPendingReason(node.options.PendingReason)
+	}
+	if node.options.ProcessAffinity > 0 {
+		container.SetProcessAffinity(node.options.ProcessAffinity)
+	}
+	if node.options.Serial {
+		container.SetSerial()
+	}
 	suite.currentContainer.PushContainerNode(container)
 
 	previousContainer := suite.currentContainer
 	suite.currentContainer = container
 	suite.containerIndex++
 
-	body()
+	build := &containerBuild{text: node.text, codeLocation: node.codeLocation}
+	suite.buildingContainers = append(suite.buildingContainers, build)
+	start := time.Now()
+	node.body()
+	elapsed := time.Since(start)
+	suite.buildingContainers = suite.buildingContainers[:len(suite.buildingContainers)-1]
+	if len(suite.buildingContainers) > 0 {
+		suite.buildingContainers[len(suite.buildingContainers)-1].nested += elapsed
+	}
+	build.duration = elapsed - build.nested
+	suite.containerBuilds = append(suite.containerBuilds, build)
 
 	suite.containerIndex--
 	suite.currentContainer = previousContainer
 }
 
-func (suite *Suite) PushItNode(text string, body interface{}, flag types.FlagType, codeLocation types.CodeLocation, timeout time.Duration) {
+func (suite *Suite) PushItNode(text string, body interface{}, flag types.FlagType, codeLocation types.CodeLocation, timeout time.Duration, labels ...string) {
+	if suite.running {
+		suite.failer.Fail("You may only call It from within a Describe, Context or When", codeLocation)
+	}
+	suite.currentContainer.PushSubjectNode(leafnodes.NewItNode(text, body, flag, codeLocation, timeout, suite.failer, suite.containerIndex), labels...)
+}
+
+//PushPendingItNode pushes a pending It, along with the reason it is pending
+func (suite *Suite) PushPendingItNode(text string, reason string, codeLocation types.CodeLocation, labels ...string) {
+	if suite.running {
+		suite.failer.Fail("You may only call It from within a Describe, Context or When", codeLocation)
+	}
+	node := leafnodes.NewItNode(text, func() {}, types.FlagTypePending, codeLocation, 0, suite.failer, suite.containerIndex)
+	node.SetPendingReason(reason)
+	suite.currentContainer.PushSubjectNode(node, labels...)
+}
+
+//PushBodylessItNode pushes an It declared without a body.  It is pending, unless -strictPending is set: it then fails.
+func (suite *Suite) PushBodylessItNode(text string, flag types.FlagType, codeLocation types.CodeLocation, labels ...string) {
 	if suite.running {
 		suite.failer.Fail("You may only call It from within a Describe, Context or When", codeLocation)
 	}
-	suite.currentContainer.PushSubjectNode(leafnodes.NewItNode(text, body, flag, codeLocation, timeout, suite.failer, suite.containerIndex))
+	node := leafnodes.NewItNode(text, func() {
+		suite.failer.Fail("This It was declared without a body, and -strictPending is set", codeLocation)
+	}, types.FlagTypePending, codeLocation, 0, suite.failer, suite.containerIndex)
+	node.SetPendingReason("declared without a body")
+	suite.bodylessIts = append(suite.bodylessIts, bodylessIt{node: node, flag: flag, text: text, codeLocation: codeLocation})
+	suite.currentContainer.PushSubjectNode(node, labels...)
 }
 
-func (suite *Suite) PushMeasureNode(text string, body interface{}, flag types.FlagType, codeLocation types.CodeLocation, samples int) {
+//PushSerialItNode pushes an It that never runs concurrently with other specs
+func (suite *Suite) PushSerialItNode(text string, body interface{}, flag types.FlagType, codeLocation types.CodeLocation, timeout time.Duration, labels ...string) {
+	if suite.running {
+		suite.failer.Fail("You may only call It from within a Describe, Context or When", codeLocation)
+	}
+	node := leafnodes.NewItNode(text, body, flag, codeLocation, timeout, suite.failer, suite.containerIndex)
+	node.SetSerial()
+	suite.currentContainer.PushSubjectNode(node, labels...)
+}
+
+func (suite *Suite) PushMeasureNode(text string, body interface{}, flag types.FlagType, codeLocation types.CodeLocation, samples int, labels ...string) {
 	if suite.running {
 		suite.failer.Fail("You may only call Measure from within a Describe, Context or When", codeLocation)
 	}
-	suite.currentContainer.PushSubjectNode(leafnodes.NewMeasureNode(text, body, flag, codeLocation, samples, suite.failer, suite.containerIndex))
+	suite.currentContainer.PushSubjectNode(leafnodes.NewMeasureNode(text, body, flag, codeLocation, samples, suite.failer, suite.containerIndex), labels...)
 }
 
 func (suite *Suite) PushBeforeEachNode(body interface{}, codeLocation types.CodeLocation, timeout time.Duration) {
 	if suite.running {
 		suite.failer.Fail("You may only call BeforeEach from within a Describe, Context or When", codeLocation)
 	}
-	suite.currentContainer.PushSetupNode(leafnodes.NewBeforeEachNode(body, codeLocation, timeout, suite.failer, suite.containerIndex))
+	suite.pushSetupNode(leafnodes.NewBeforeEachNode(body, codeLocation, timeout, suite.failer, suite.containerIndex))
 }
 
 func (suite *Suite) PushJustBeforeEachNode(body interface{}, codeLocation types.CodeLocation, timeout time.Duration) {
 	if suite.running {
 		suite.failer.Fail("You may only call JustBeforeEach from within a Describe, Context or When", codeLocation)
 	}
-	suite.currentContainer.PushSetupNode(leafnodes.NewJustBeforeEachNode(body, codeLocation, timeout, suite.failer, suite.containerIndex))
+	suite.pushSetupNode(leafnodes.NewJustBeforeEachNode(body, codeLocation, timeout, suite.failer, suite.containerIndex))
 }
 
 func (suite *Suite) PushJustAfterEachNode(body interface{}, codeLocation types.CodeLocation, timeout time.Duration) {
 	if suite.running {
 		suite.failer.Fail("You may only call JustAfterEach from within a Describe or Context", codeLocation)
 	}
-	suite.currentContainer.PushSetupNode(leafnodes.NewJustAfterEachNode(body, codeLocation, timeout, suite.failer, suite.containerIndex))
+	suite.pushTeardownNode(leafnodes.NewJustAfterEachNode(body, codeLocation, timeout, suite.failer, suite.containerIndex))
 }
 
 func (suite *Suite) PushAfterEachNode(body interface{}, codeLocation types.CodeLocation, timeout time.Duration) {
 	if suite.running {
 		suite.failer.Fail("You may only call AfterEach from within a Describe, Context or When", codeLocation)
 	}
-	suite.currentContainer.PushSetupNode(leafnodes.NewAfterEachNode(body, codeLocation, timeout, suite.failer, suite.containerIndex))
+	suite.pushTeardownNode(leafnodes.NewAfterEachNode(body, codeLocation, timeout, suite.failer, suite.containerIndex))
+}
+
+func (suite *Suite) PushAroundEachNode(body func(run func()), codeLocation types.CodeLocation) {
+	if suite.running || suite.currentContainer != suite.topLevelContainer {
+		suite.failer.Fail("You may only call AroundEach at the top level", codeLocation)
+		return
+	}
+	suite.aroundEachNodes = append(suite.aroundEachNodes, leafnodes.NewAroundEachNode(body, codeLocation, suite.failer))
+}
+
+func (suite *Suite) PushBeforeNodeHook(body func(node types.NodeInfo), codeLocation types.CodeLocation) {
+	if suite.running || suite.currentContainer != suite.topLevelContainer {
+		suite.failer.Fail("You may only call BeforeNode at the top level", codeLocation)
+		return
+	}
+	suite.beforeNodeHooks = append(suite.beforeNodeHooks, leafnodes.NewBeforeNodeHook(body, codeLocation, suite.failer))
+}
+
+func (suite *Suite) PushOnFailureHook(body func(summary *types.SpecSummary), codeLocation types.CodeLocation) {
+	if suite.running || suite.currentContainer != suite.topLevelContainer {
+		suite.failer.Fail("You may only call OnFailure at the top level", codeLocation)
+		return
+	}
+	suite.onFailureHooks = append(suite.onFailureHooks, leafnodes.NewOnFailureHook(body, codeLocation, suite.failer))
+}
+
+func (suite *Suite) pushSetupNode(node leafnodes.BasicNode) {
+	if suite.currentContainer.OncePerContainer() {
+		node = leafnodes.NewOncePerContainerSetupNode(node)
+	}
+	suite.currentContainer.PushSetupNode(node)
+}
+
+func (suite *Suite) pushTeardownNode(node leafnodes.BasicNode) {
+	if suite.currentContainer.OncePerContainer() {
+		teardownNode := leafnodes.NewOncePerContainerTeardownNode(node)
+		suite.onceTeardowns = append(suite.onceTeardowns, oncePerContainerTeardown{
+			containerIndex: suite.containerIndex,
+			node:           teardownNode,
+		})
+		node = teardownNode
+	}
+	suite.currentContainer.PushSetupNode(node)
 }
@@ -2,6 +2,7 @@ package suite_test
 
 import (
 	"bytes"
+	"fmt"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/internal/suite"
@@ -334,6 +335,306 @@ var _ = Describe("Suite", func() {
 				}).Should(Panic())
 
 			})
+
+			It("should name the code location of the first BeforeSuite", func() {
+				codeLocation := codelocation.New(0)
+				specSuite.SetBeforeSuiteNode(func() {}, codeLocation, 0)
+
+				Ω(func() {
+					specSuite.SetBeforeSuiteNode(func() {}, codelocation.New(0), 0)
+				}).Should(PanicWith(ContainSubstring("BeforeSuite was already called at " + codeLocation.String())))
+			})
+		})
+	})
+
+	Describe("OncePerContainer containers", func() {
+		var runOrder []string
+
+		var f = func(runText string) func() {
+			return func() {
+				runOrder = append(runOrder, runText)
+			}
+		}
+
+		run := func() bool {
+			success, _ := specSuite.Run(fakeT, "suite description", []reporters.Reporter{fakeR}, writer, config.GinkgoConfigType{
+				ParallelNode:  1,
+				ParallelTotal: 1,
+			})
+			return success
+		}
+
+		BeforeEach(func() {
+			runOrder = []string{}
+		})
+
+		It("runs the setup nodes of the container once and its teardown nodes once, after the last spec", func() {
+			specSuite.PushOncePerContainerNode("container", func() {
+				specSuite.PushBeforeEachNode(f("once BE"), codelocation.New(0), 0)
+				specSuite.PushJustBeforeEachNode(f("once JBE"), codelocation.New(0), 0)
+				specSuite.PushJustAfterEachNode(f("once JAE"), codelocation.New(0), 0)
+				specSuite.PushAfterEachNode(f("once AE"), codelocation.New(0), 0)
+				specSuite.PushItNode("A", f("A"), types.FlagTypeNone, codelocation.New(0), 0)
+
+				specSuite.PushOncePerContainerNode("inner", func() {
+					specSuite.PushAfterEachNode(f("inner once AE"), codelocation.New(0), 0)
+					specSuite.PushJustAfterEachNode(f("inner once JAE"), codelocation.New(0), 0)
+					specSuite.PushContainerNode("innermost", func() {
+						specSuite.PushBeforeEachNode(f("BE"), codelocation.New(0), 0)
+						specSuite.PushItNode("B", f("B"), types.FlagTypeNone, codelocation.New(0), 0)
+						specSuite.PushItNode("C", f("C"), types.FlagTypeNone, codelocation.New(0), 0)
+					}, types.FlagTypeNone, codelocation.New(0))
+				}, types.FlagTypeNone, codelocation.New(0))
+			}, types.FlagTypeNone, codelocation.New(0))
+			specSuite.PushItNode("D", f("D"), types.FlagTypeNone, codelocation.New(0), 0)
+			specSuite.SetAfterSuiteNode(f("AfterSuite"), codelocation.New(0), 0)
+
+			Ω(run()).Should(BeTrue())
+			Ω(runOrder).Should(Equal([]string{
+				"once BE", "once JBE", "A",
+				"BE", "B",
+				"BE", "C",
+				"D",
+				"inner once JAE", "once JAE", "inner once AE", "once AE",
+				"AfterSuite",
+			}))
+		})
+
+		It("fails every spec of the container when its setup fails, and still runs its teardown", func() {
+			specSuite.PushOncePerContainerNode("container", func() {
+				specSuite.PushBeforeEachNode(func() {
+					runOrder = append(runOrder, "once BE")
+					failer.Fail("setup failed", codelocation.New(0))
+				}, codelocation.New(0), 0)
+				specSuite.PushAfterEachNode(f("once AE"), codelocation.New(0), 0)
+				specSuite.PushItNode("A", f("A"), types.FlagTypeNone, codelocation.New(0), 0)
+				specSuite.PushItNode("B", f("B"), types.FlagTypeNone, codelocation.New(0), 0)
+			}, types.FlagTypeNone, codelocation.New(0))
+
+			Ω(run()).Should(BeFalse())
+			Ω(runOrder).Should(Equal([]string{"once BE", "once AE"}))
+			Ω(fakeR.SpecSummaries).Should(HaveLen(2))
+			for _, summary := range fakeR.SpecSummaries {
+				Ω(summary.State).Should(Equal(types.SpecStateFailed))
+				Ω(summary.Failure.Message).Should(Equal("setup failed"))
+			}
+		})
+
+		It("does not run the teardown of a container none of whose specs ran", func() {
+			specSuite.PushOncePerContainerNode("container", func() {
+				specSuite.PushBeforeEachNode(f("once BE"), codelocation.New(0), 0)
+				specSuite.PushAfterEachNode(f("once AE"), codelocation.New(0), 0)
+				specSuite.PushItNode("A", f("A"), types.FlagTypePending, codelocation.New(0), 0)
+			}, types.FlagTypeNone, codelocation.New(0))
+			specSuite.PushItNode("B", f("B"), types.FlagTypeNone, codelocation.New(0), 0)
+
+			Ω(run()).Should(BeTrue())
+			Ω(runOrder).Should(Equal([]string{"B"}))
+			Ω(fakeR.AfterSuiteSummary.State).Should(Equal(types.SpecStatePassed))
+		})
+	})
+
+	Describe("AroundEach", func() {
+		var runOrder []string
+
+		var f = func(runText string) func() {
+			return func() {
+				runOrder = append(runOrder, runText)
+			}
+		}
+
+		var around = func(runText string) func(run func()) {
+			return func(run func()) {
+				runOrder = append(runOrder, runText+" before")
+				run()
+				runOrder = append(runOrder, runText+" after")
+			}
+		}
+
+		run := func() bool {
+			success, _ := specSuite.Run(fakeT, "suite description", []reporters.Reporter{fakeR}, writer, config.GinkgoConfigType{
+				ParallelNode:  1,
+				ParallelTotal: 1,
+			})
+			return success
+		}
+
+		BeforeEach(func() {
+			runOrder = []string{}
+		})
+
+		It("wraps every spec, in registration order", func() {
+			specSuite.PushAroundEachNode(around("outer"), codelocation.New(0))
+			specSuite.PushContainerNode("container", func() {
+				specSuite.PushBeforeEachNode(f("bef"), codelocation.New(0), 0)
+				specSuite.PushItNode("A", f("A"), types.FlagTypeNone, codelocation.New(0), 0)
+				specSuite.PushItNode("B", f("B"), types.FlagTypeNone, codelocation.New(0), 0)
+			}, types.FlagTypeNone, codelocation.New(0))
+			specSuite.PushAroundEachNode(around("inner"), codelocation.New(0))
+
+			Ω(run()).Should(BeTrue())
+			Ω(runOrder).Should(Equal([]string{
+				"outer before", "inner before", "bef", "A", "inner after", "outer after",
+				"outer before", "inner before", "bef", "B", "inner after", "outer after",
+			}))
+		})
+
+		It("fails when AroundEach is not called at the top level", func() {
+			specSuite.PushContainerNode("container", func() {
+				specSuite.PushAroundEachNode(around("nested"), codelocation.New(0))
+				specSuite.PushItNode("A", f("A"), types.FlagTypeNone, codelocation.New(0), 0)
+			}, types.FlagTypeNone, codelocation.New(0))
+
+			Ω(run()).Should(BeFalse())
+			Ω(runOrder).ShouldNot(ContainElement("nested before"))
+		})
+	})
+
+	Describe("SharedFixtures", func() {
+		var runOrder []string
+
+		var f = func(runText string) func() {
+			return func() {
+				runOrder = append(runOrder, runText)
+			}
+		}
+
+		run := func(parallelTotal int) bool {
+			success, _ := specSuite.Run(fakeT, "suite description", []reporters.Reporter{fakeR}, writer, config.GinkgoConfigType{
+				ParallelNode:  1,
+				ParallelTotal: parallelTotal,
+			})
+			return success
+		}
+
+		BeforeEach(func() {
+			runOrder = []string{}
+			db := specSuite.RegisterSharedFixture("db", func() interface{} {
+				runOrder = append(runOrder, "set up db")
+				return "db"
+			}, func(value interface{}) {
+				runOrder = append(runOrder, "tear down "+value.(string))
+			}, codelocation.New(0))
+
+			get := func(runText string) func() {
+				return func() {
+					value, err := db.Get(runText)
+					Ω(err).ShouldNot(HaveOccurred())
+					runOrder = append(runOrder, runText+" uses "+value.(string))
+				}
+			}
+
+			containerCodeLocation := codelocation.New(0)
+			db.DeclareAt(containerCodeLocation)
+			specSuite.PushContainerNode("container", func() {
+				specSuite.PushItNode("A", get("A"), types.FlagTypeNone, codelocation.New(0), 0)
+				specSuite.PushItNode("B", get("B"), types.FlagTypeNone, codelocation.New(0), 0)
+				specSuite.PushItNode("C", f("C"), types.FlagTypePending, codelocation.New(0), 0)
+			}, types.FlagTypeNone, containerCodeLocation)
+			specSuite.PushItNode("D", f("D"), types.FlagTypeNone, codelocation.New(0), 0)
+			specSuite.SetAfterSuiteNode(f("AfterSuite"), codelocation.New(0), 0)
+		})
+
+		It("tears the fixture down once the last spec declaring it that runs has completed", func() {
+			Ω(run(1)).Should(BeTrue())
+			Ω(runOrder).Should(Equal([]string{"set up db", "A uses db", "B uses db", "tear down db", "D", "AfterSuite"}))
+
+			Ω(fakeR.EndSummary.SharedFixtures).Should(HaveLen(1))
+			summary := fakeR.EndSummary.SharedFixtures[0]
+			Ω(summary.Name).Should(Equal("db"))
+			Ω(summary.SetUpBy).Should(Equal("A"))
+			Ω(summary.TornDownAfter).Should(Equal("container B"))
+			Ω(summary.NumberOfReferences).Should(Equal(2))
+			Ω(summary.Failure).Should(BeEmpty())
+		})
+
+		It("tears the fixture down at the end of the suite when running in parallel", func() {
+			Ω(run(2)).Should(BeTrue())
+			Ω(runOrder).Should(Equal([]string{"set up db", "A uses db", "B uses db", "AfterSuite", "tear down db"}))
+			Ω(fakeR.EndSummary.SharedFixtures[0].TornDownAfter).Should(BeEmpty())
+		})
+
+		It("panics when two fixtures share a name", func() {
+			Ω(func() {
+				specSuite.RegisterSharedFixture("db", func() interface{} { return nil }, nil, codelocation.New(0))
+			}).Should(PanicWith(ContainSubstring(`SharedFixture "db" was registered twice`)))
+		})
+	})
+
+	Describe("RegisterSuiteSetup", func() {
+		var runOrder []string
+
+		var f = func(runText string) func() {
+			return func() {
+				runOrder = append(runOrder, runText)
+			}
+		}
+
+		run := func() bool {
+			success, _ := specSuite.Run(fakeT, "suite description", []reporters.Reporter{fakeR}, writer, config.GinkgoConfigType{
+				ParallelNode:  1,
+				ParallelTotal: 1,
+			})
+			return success
+		}
+
+		BeforeEach(func() {
+			runOrder = []string{}
+			specSuite.PushItNode("it", f("IT"), types.FlagTypeNone, codelocation.New(0), 0)
+		})
+
+		It("runs the BeforeSuite and the suite setups by priority, then in registration order", func() {
+			specSuite.RegisterSuiteSetup("late", 10, f("late"), codelocation.New(0), 0)
+			specSuite.RegisterSuiteSetup("default", 0, f("default"), codelocation.New(0), 0)
+			specSuite.SetBeforeSuiteNode(f("BeforeSuite"), codelocation.New(0), 0)
+			specSuite.RegisterSuiteSetup("early", -10, f("early"), codelocation.New(0), 0)
+			specSuite.RegisterSuiteSetup("also late", 10, f("also late"), codelocation.New(0), 0)
+
+			Ω(run()).Should(BeTrue())
+			Ω(runOrder).Should(Equal([]string{"early", "BeforeSuite", "default", "late", "also late", "IT"}))
+			Ω(fakeR.BeforeSuiteSummary.State).Should(Equal(types.SpecStatePassed))
+		})
+
+		It("runs the suite setups when there is no BeforeSuite", func() {
+			specSuite.RegisterSuiteSetup("first", 0, f("first"), codelocation.New(0), 0)
+			specSuite.RegisterSuiteSetup("second", 1, f("second"), codelocation.New(0), 0)
+
+			Ω(run()).Should(BeTrue())
+			Ω(runOrder).Should(Equal([]string{"first", "second", "IT"}))
+		})
+
+		It("skips the remaining setups and the specs when a setup fails", func() {
+			failingCodeLocation := codelocation.New(0)
+			specSuite.RegisterSuiteSetup("failing", 0, func() {
+				runOrder = append(runOrder, "failing")
+				failer.Fail("database unavailable", failingCodeLocation)
+			}, failingCodeLocation, 0)
+			specSuite.RegisterSuiteSetup("next", 1, f("next"), codelocation.New(0), 0)
+
+			Ω(run()).Should(BeFalse())
+			Ω(runOrder).Should(Equal([]string{"failing"}))
+			Ω(fakeR.BeforeSuiteSummary.State).Should(Equal(types.SpecStateFailed))
+			Ω(fakeR.BeforeSuiteSummary.CodeLocation).Should(Equal(failingCodeLocation))
+			Ω(fakeR.BeforeSuiteSummary.Failure.Message).Should(Equal("database unavailable"))
+		})
+
+		It("ignores a name registered twice from the same code location", func() {
+			codeLocation := codelocation.New(0)
+			specSuite.RegisterSuiteSetup("shared", 0, f("shared"), codeLocation, 0)
+			specSuite.RegisterSuiteSetup("shared", 0, f("shared"), codeLocation, 0)
+
+			Ω(run()).Should(BeTrue())
+			Ω(runOrder).Should(Equal([]string{"shared", "IT"}))
+		})
+
+		It("panics, naming both code locations, when a name is registered twice from different code locations", func() {
+			firstCodeLocation := codelocation.New(0)
+			secondCodeLocation := codelocation.New(0)
+			specSuite.RegisterSuiteSetup("shared", 0, f("shared"), firstCodeLocation, 0)
+
+			Ω(func() {
+				specSuite.RegisterSuiteSetup("shared", 0, f("shared"), secondCodeLocation, 0)
+			}).Should(PanicWith(fmt.Sprintf(`Suite setup "shared" was registered twice: at %s and at %s`, firstCodeLocation, secondCodeLocation)))
 		})
 	})
 
@@ -382,4 +683,225 @@ var _ = Describe("Suite", func() {
 			Ω(GinkgoRandomSeed()).Should(Equal(config.GinkgoConfig.RandomSeed))
 		})
 	})
+
+	Describe("labels", func() {
+		It("reports the labels of each spec and its containers", func() {
+			specSuite.PushContainerNode("container", func() {
+				specSuite.PushItNode("labeled it", func() {}, types.FlagTypeNone, codelocation.New(0), 0, "fast", "storage")
+				specSuite.PushMeasureNode("labeled measure", func(Benchmarker) {}, types.FlagTypeNone, codelocation.New(0), 1, "benchmark")
+			}, types.FlagTypeNone, codelocation.New(0), "storage")
+			specSuite.PushItNode("unlabeled it", func() {}, types.FlagTypeNone, codelocation.New(0), 0)
+
+			specSuite.Run(fakeT, "suite description", []reporters.Reporter{fakeR}, writer, config.GinkgoConfigType{ParallelNode: 1, ParallelTotal: 1})
+
+			labels := map[string][]string{}
+			for _, summary := range fakeR.SpecSummaries {
+				labels[summary.ComponentTexts[len(summary.ComponentTexts)-1]] = summary.Labels
+			}
+			Ω(labels).Should(HaveLen(3))
+			Ω(labels["labeled it"]).Should(Equal([]string{"storage", "fast"}))
+			Ω(labels["labeled measure"]).Should(Equal([]string{"storage", "benchmark"}))
+			Ω(labels["unlabeled it"]).Should(BeEmpty())
+		})
+	})
+
+	Describe("pending reasons", func() {
+		It("reports the reason of the innermost pending node that has one", func() {
+			specSuite.PushPendingContainerNode("pending container", func() {
+				specSuite.PushItNode("inherits", func() {}, types.FlagTypeNone, codelocation.New(0), 0)
+				specSuite.PushPendingItNode("overrides", "blocked on ISSUE-2", codelocation.New(0))
+			}, "blocked on ISSUE-1", codelocation.New(0))
+			specSuite.PushPendingItNode("pending it", "blocked on ISSUE-3", codelocation.New(0))
+			specSuite.PushItNode("without reason", func() {}, types.FlagTypePending, codelocation.New(0), 0)
+
+			specSuite.Run(fakeT, "suite description", []reporters.Reporter{fakeR}, writer, config.GinkgoConfigType{ParallelNode: 1, ParallelTotal: 1})
+
+			reasons := map[string]string{}
+			for _, summary := range fakeR.SpecSummaries {
+				Ω(summary.State).Should(Equal(types.SpecStatePending))
+				reasons[summary.ComponentTexts[len(summary.ComponentTexts)-1]] = summary.PendingReason
+			}
+			Ω(reasons).Should(Equal(map[string]string{
+				"inherits":       "blocked on ISSUE-1",
+				"overrides":      "blocked on ISSUE-2",
+				"pending it":     "blocked on ISSUE-3",
+				"without reason": "",
+			}))
+		})
+	})
+
+	Describe("slow containers", func() {
+		BeforeEach(func() {
+			specSuite.PushContainerNode("slow container", func() {
+				time.Sleep(60 * time.Millisecond)
+				specSuite.PushContainerNode("nested container", func() {
+					time.Sleep(60 * time.Millisecond)
+					specSuite.PushItNode("it", func() {}, types.FlagTypeNone, codelocation.New(0), 0)
+				}, types.FlagTypeNone, codelocation.New(0))
+			}, types.FlagTypeNone, codelocation.New(0))
+			specSuite.PushContainerNode("fast container", func() {
+				specSuite.PushItNode("it", func() {}, types.FlagTypeNone, codelocation.New(0), 0)
+			}, types.FlagTypeNone, codelocation.New(0))
+		})
+
+		It("leaves the time spent in nested containers out of the time of their parent", func() {
+			success, _ := specSuite.Run(fakeT, "suite description", []reporters.Reporter{fakeR}, writer, config.GinkgoConfigType{ParallelNode: 1, ParallelTotal: 1, SlowContainerThreshold: 0.1})
+			Ω(success).Should(BeTrue())
+			Ω(fakeR.BeginSummary.Warnings).Should(BeEmpty())
+		})
+
+		It("warns about each container slower than the threshold", func() {
+			success, _ := specSuite.Run(fakeT, "suite description", []reporters.Reporter{fakeR}, writer, config.GinkgoConfigType{ParallelNode: 1, ParallelTotal: 1, SlowContainerThreshold: 0.05})
+			Ω(success).Should(BeTrue())
+			Ω(fakeR.BeginSummary.Warnings).Should(HaveLen(2))
+			Ω(fakeR.BeginSummary.Warnings[0]).Should(ContainSubstring(`the body of the container "nested container" took`))
+			Ω(fakeR.BeginSummary.Warnings[1]).Should(ContainSubstring(`the body of the container "slow container" took`))
+			Ω(fakeR.BeginSummary.Warnings[1]).Should(ContainSubstring("more than -slowContainerThreshold (0.05s)"))
+		})
+
+		It("fails the suite with -failOnSlowContainers", func() {
+			success, _ := specSuite.Run(fakeT, "suite description", []reporters.Reporter{fakeR}, writer, config.GinkgoConfigType{ParallelNode: 1, ParallelTotal: 1, SlowContainerThreshold: 0.05, FailOnSlowContainers: true})
+			Ω(success).Should(BeFalse())
+			Ω(fakeR.EndSummary.SpecialSuiteFailureReasons).Should(HaveLen(1))
+			Ω(fakeR.EndSummary.SpecialSuiteFailureReasons[0].Cause).Should(Equal(types.InterruptCauseSlowContainers))
+			Ω(fakeR.EndSummary.NumberOfPassedSpecs).Should(Equal(2))
+		})
+	})
+
+	Describe("Its declared without a body", func() {
+		var summaries map[string]*types.SpecSummary
+
+		BeforeEach(func() {
+			specSuite.PushItNode("has a body", func() {}, types.FlagTypeNone, codelocation.New(0), 0)
+			specSuite.PushBodylessItNode("does something later", types.FlagTypeNone, codelocation.New(0), "later")
+		})
+
+		run := func(strictPending bool) bool {
+			success, _ := specSuite.Run(fakeT, "suite description", []reporters.Reporter{fakeR}, writer, config.GinkgoConfigType{ParallelNode: 1, ParallelTotal: 1, StrictPending: strictPending})
+			summaries = map[string]*types.SpecSummary{}
+			for _, summary := range fakeR.SpecSummaries {
+				summaries[summary.ComponentTexts[len(summary.ComponentTexts)-1]] = summary
+			}
+			return success
+		}
+
+		It("marks them pending, and lists them in a warning", func() {
+			Ω(run(false)).Should(BeTrue())
+			Ω(summaries["has a body"].State).Should(Equal(types.SpecStatePassed))
+			Ω(summaries["does something later"].State).Should(Equal(types.SpecStatePending))
+			Ω(summaries["does something later"].PendingReason).Should(Equal("declared without a body"))
+			Ω(summaries["does something later"].Labels).Should(Equal([]string{"later"}))
+			Ω(fakeR.EndSummary.Warnings).Should(HaveLen(1))
+			Ω(fakeR.EndSummary.Warnings[0]).Should(HavePrefix("1 It(s) declared without a body are pending (see -strictPending):\n  does something later\n    "))
+			Ω(fakeR.EndSummary.Warnings[0]).Should(ContainSubstring("suite_test.go"))
+		})
+
+		It("fails them with -strictPending", func() {
+			Ω(run(true)).Should(BeFalse())
+			Ω(summaries["has a body"].State).Should(Equal(types.SpecStatePassed))
+			Ω(summaries["does something later"].State).Should(Equal(types.SpecStateFailed))
+			Ω(summaries["does something later"].Failure.Message).Should(Equal("This It was declared without a body, and -strictPending is set"))
+			Ω(fakeR.EndSummary.Warnings[0]).Should(HavePrefix("1 It(s) declared without a body fail, as -strictPending is set:\n  does something later"))
+		})
+	})
+
+	Describe("spec policies", func() {
+		var ran []string
+		var checked []*types.SpecSummary
+
+		BeforeEach(func() {
+			ran = []string{}
+			checked = nil
+			specSuite.PushItNode("labeled it", func() {
+				ran = append(ran, "labeled it")
+			}, types.FlagTypeNone, codelocation.New(0), 0, "team-a")
+			specSuite.PushItNode("unlabeled it", func() {
+				ran = append(ran, "unlabeled it")
+			}, types.FlagTypeNone, codelocation.New(0), 0)
+			specSuite.PushItNode("pending it", func() {}, types.FlagTypePending, codelocation.New(0), 0)
+		})
+
+		requireLabels := func(specs []*types.SpecSummary) []error {
+			checked = specs
+			errs := []error{}
+			for _, spec := range specs {
+				if len(spec.Labels) == 0 {
+					errs = append(errs, fmt.Errorf("%s has no label", spec.ComponentTexts[len(spec.ComponentTexts)-1]))
+				}
+			}
+			return errs
+		}
+
+		It("hands the summaries of all the specs to the policies, and runs the specs when they raise no error", func() {
+			specSuite.RegisterSpecPolicy(func(specs []*types.SpecSummary) []error {
+				checked = specs
+				return nil
+			})
+			success, _ := specSuite.Run(fakeT, "suite description", []reporters.Reporter{fakeR}, writer, config.GinkgoConfigType{ParallelNode: 1, ParallelTotal: 1})
+			Ω(success).Should(BeTrue())
+			Ω(checked).Should(HaveLen(3))
+			summaries := map[string]*types.SpecSummary{}
+			for _, summary := range checked {
+				summaries[summary.ComponentTexts[1]] = summary
+			}
+			Ω(summaries["labeled it"].Labels).Should(Equal([]string{"team-a"}))
+			Ω(summaries["unlabeled it"].Labels).Should(BeEmpty())
+			Ω(summaries["pending it"].State).Should(Equal(types.SpecStatePending))
+			Ω(ran).Should(ConsistOf("labeled it", "unlabeled it"))
+		})
+
+		It("runs no spec and fails the suite, listing the errors, when a policy vetoes the run", func() {
+			specSuite.RegisterSpecPolicy(requireLabels)
+			success, _ := specSuite.Run(fakeT, "suite description", []reporters.Reporter{fakeR}, writer, config.GinkgoConfigType{ParallelNode: 1, ParallelTotal: 1})
+			Ω(success).Should(BeFalse())
+			Ω(fakeT.didFail).Should(BeTrue())
+			Ω(ran).Should(BeEmpty())
+			Ω(fakeR.EndSummary.SpecialSuiteFailureReasons).Should(HaveLen(1))
+			Ω(fakeR.EndSummary.SpecialSuiteFailureReasons[0].Cause).Should(Equal(types.InterruptCausePolicyViolation))
+			Ω(fakeR.EndSummary.SpecialSuiteFailureReasons[0].Message).Should(HavePrefix("The spec policies vetoed the run:\n  "))
+			Ω(fakeR.EndSummary.SpecialSuiteFailureReasons[0].Message).Should(ContainSubstring("\n  unlabeled it has no label"))
+			Ω(fakeR.EndSummary.SpecialSuiteFailureReasons[0].Message).Should(ContainSubstring("\n  pending it has no label"))
+			for _, summary := range fakeR.SpecSummaries {
+				Ω(summary.State).Should(Equal(types.SpecStateSkipped))
+				Ω(summary.Failure.Message).Should(Equal("the spec policies vetoed the run"))
+			}
+		})
+	})
 })
+
+var _ = Describe("PendingReason", func() {
+	It("panics when decorating a node that is not pending", func() {
+		Ω(func() {
+			Describe("not pending", func() {}, PendingReason("blocked"))
+		}).Should(Panic())
+	})
+
+	It("panics when given an empty reason", func() {
+		Ω(func() {
+			PIt("empty", PendingReason(" "))
+		}).Should(Panic())
+	})
+})
+
+var _ = Describe("Label", func() {
+	It("makes the labels available to the running spec", func() {
+		Ω(CurrentGinkgoTestDescription().Labels).Should(Equal([]string{"outer", "inner"}))
+	}, Label("inner"))
+
+	It("accepts a timeout alongside labels", func(done Done) {
+		Ω(CurrentGinkgoTestDescription().Labels).Should(Equal([]string{"outer", "async"}))
+		close(done)
+	}, Label("async"), 2*time.Second)
+
+	It("panics when given an empty label", func() {
+		Ω(func() {
+			It("empty", func() {}, Label(" "))
+		}).Should(Panic())
+	})
+
+	It("panics when given something that is not a decorator", func() {
+		Ω(func() {
+			Describe("not a decorator", func() {}, "label")
+		}).Should(Panic())
+	})
+}, Label("outer"))
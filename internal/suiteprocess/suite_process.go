@@ -0,0 +1,310 @@
+/*
+Package suiteprocess manages the external processes started once for a whole suite run with ginkgo.StartSuiteProcess.
+
+When running in parallel, node 1 starts each process and shares its address with the other nodes through the parallel
+key-value store.  Processes are killed at the end of the suite, once every node is done, or when the suite is interrupted.
+*/
+package suiteprocess
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/onsi/ginkgo/internal/parallelkv"
+	"github.com/onsi/ginkgo/types"
+)
+
+//PollingInterval is how long the Manager waits between two readiness checks, and between two checks that the other
+//parallel nodes are done
+var PollingInterval = 100 * time.Millisecond
+
+//killGracePeriod bounds how long the Manager waits for a killed process to be reaped.  Children of the process that
+//inherited its stdout or stderr keep them open after it is killed, and would otherwise hold the end of the suite.
+const killGracePeriod = time.Second
+
+//sharedState is what node 1 shares with the other parallel nodes about a process it started
+type sharedState struct {
+	Address string
+	Failure string
+}
+
+type process struct {
+	cmd     *exec.Cmd
+	output  *lockedBuffer
+	exited  chan struct{}
+	exitErr error
+	summary *types.SuiteProcessSummary
+}
+
+type Manager struct {
+	startLock     *sync.Mutex
+	lock          *sync.Mutex
+	keyValues     *parallelkv.Client
+	parallelNode  int
+	parallelTotal int
+	syncHost      string
+	addresses     map[string]string
+	processes     []*process
+}
+
+func New(keyValues *parallelkv.Client) *Manager {
+	return &Manager{
+		startLock:     &sync.Mutex{},
+		lock:          &sync.Mutex{},
+		keyValues:     keyValues,
+		parallelNode:  1,
+		parallelTotal: 1,
+		addresses:     map[string]string{},
+	}
+}
+
+//Connect makes the manager share the processes started on node 1 with the other parallel nodes
+func (m *Manager) Connect(parallelNode int, parallelTotal int, syncHost string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.parallelNode = parallelNode
+	m.parallelTotal = parallelTotal
+	m.syncHost = syncHost
+}
+
+//Start starts the process returned by command, once for the whole suite run, and returns its address once readinessCheck
+//(which may be nil) succeeds.  On parallel nodes other than node 1, Start waits for node 1 to have started the process.
+//Further calls with the same name return the same address.
+func (m *Manager) Start(name string, command func() (*exec.Cmd, string), readinessCheck func(address string) error, timeout time.Duration) (string, error) {
+	m.startLock.Lock()
+	defer m.startLock.Unlock()
+
+	m.lock.Lock()
+	address, started := m.addresses[name]
+	parallelNode, parallelTotal := m.parallelNode, m.parallelTotal
+	m.lock.Unlock()
+	if started {
+		return address, nil
+	}
+
+	var err error
+	if parallelNode == 1 {
+		address, err = m.start(name, command, readinessCheck, timeout)
+		if parallelTotal > 1 {
+			state := sharedState{Address: address}
+			if err != nil {
+				state.Failure = err.Error()
+			}
+			data, _ := json.Marshal(state)
+			if shareErr := m.keyValues.Set(key(name), data); shareErr != nil && err == nil {
+				err = shareErr
+			}
+		}
+	} else {
+		address, err = m.waitForNodeOne(name, timeout)
+	}
+
+	if err != nil {
+		return "", err
+	}
+	m.lock.Lock()
+	m.addresses[name] = address
+	m.lock.Unlock()
+	return address, nil
+}
+
+func (m *Manager) start(name string, command func() (*exec.Cmd, string), readinessCheck func(address string) error, timeout time.Duration) (string, error) {
+	cmd, address := command()
+	p := &process{
+		cmd:    cmd,
+		output: &lockedBuffer{},
+		exited: make(chan struct{}),
+		summary: &types.SuiteProcessSummary{
+			Name:      name,
+			Node:      1,
+			Command:   strings.Join(cmd.Args, " "),
+			Address:   address,
+			StartTime: time.Now(),
+		},
+	}
+	cmd.Stdout = writerFor(cmd.Stdout, p.output)
+	cmd.Stderr = writerFor(cmd.Stderr, p.output)
+
+	fail := func(failure string, withOutput bool) error {
+		m.lock.Lock()
+		defer m.lock.Unlock()
+
+		p.summary.Failure = failure
+		m.kill(p)
+		if withOutput {
+			return fmt.Errorf("Suite process %q %s\n%s", name, failure, p.output.String())
+		}
+		return fmt.Errorf("Suite process %q %s", name, failure)
+	}
+
+	m.lock.Lock()
+	m.processes = append(m.processes, p)
+	err := cmd.Start()
+	if err != nil {
+		close(p.exited)
+	} else {
+		go func() {
+			p.exitErr = cmd.Wait()
+			close(p.exited)
+		}()
+	}
+	m.lock.Unlock()
+	if err != nil {
+		return "", fail(fmt.Sprintf("failed to start: %s", err.Error()), false)
+	}
+
+	for {
+		select {
+		case <-p.exited:
+			return "", fail(fmt.Sprintf("exited before becoming ready: %v", p.exitErr), true)
+		default:
+		}
+
+		if readinessCheck == nil || readinessCheck(address) == nil {
+			m.lock.Lock()
+			p.summary.ReadyRunTime = time.Since(p.summary.StartTime)
+			m.lock.Unlock()
+			return address, nil
+		}
+
+		if timeout > 0 && time.Since(p.summary.StartTime) >= timeout {
+			return "", fail(fmt.Sprintf("was not ready after %s", timeout), true)
+		}
+		time.Sleep(PollingInterval)
+	}
+}
+
+func (m *Manager) waitForNodeOne(name string, timeout time.Duration) (string, error) {
+	data, err := m.keyValues.WaitFor(key(name), timeout)
+	if err != nil {
+		return "", fmt.Errorf("Suite process %q was not started by node 1: %s", name, err.Error())
+	}
+
+	state := sharedState{}
+	err = json.Unmarshal(data, &state)
+	if err != nil {
+		return "", err
+	}
+	if state.Failure != "" {
+		return "", fmt.Errorf("Suite process %q failed to start on node 1", name)
+	}
+	return state.Address, nil
+}
+
+//Stop kills the processes started by this node.  On node 1, when running in parallel, it first waits for the other
+//nodes to be done with them.
+func (m *Manager) Stop() {
+	m.lock.Lock()
+	waitForOtherNodes := m.parallelNode == 1 && m.parallelTotal > 1 && len(m.processes) > 0
+	m.lock.Unlock()
+
+	if waitForOtherNodes {
+		for !m.otherNodesAreDone() {
+			time.Sleep(PollingInterval)
+		}
+	}
+	m.StopNow()
+}
+
+//StopNow kills the processes started by this node right away
+func (m *Manager) StopNow() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for _, p := range m.processes {
+		m.kill(p)
+	}
+}
+
+func (m *Manager) kill(p *process) {
+	if !p.summary.StopTime.IsZero() {
+		return
+	}
+	select {
+	case <-p.exited:
+		if p.summary.Failure == "" && p.exitErr != nil {
+			p.summary.Failure = fmt.Sprintf("exited before the end of the suite: %s", p.exitErr.Error())
+		}
+	default:
+		p.cmd.Process.Kill()
+		select {
+		case <-p.exited:
+		case <-time.After(killGracePeriod):
+		}
+	}
+	p.summary.StopTime = time.Now()
+	p.summary.Output = p.output.String()
+}
+
+func (m *Manager) otherNodesAreDone() bool {
+	resp, err := http.Get(m.syncHost + "/RemoteAfterSuiteData")
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return false
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return false
+	}
+
+	afterSuiteData := types.RemoteAfterSuiteData{}
+	err = json.Unmarshal(body, &afterSuiteData)
+	if err != nil {
+		return false
+	}
+	return afterSuiteData.CanRun
+}
+
+//Summaries describes the processes started by this node
+func (m *Manager) Summaries() []*types.SuiteProcessSummary {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	var summaries []*types.SuiteProcessSummary
+	for _, p := range m.processes {
+		summary := *p.summary
+		if summary.StopTime.IsZero() {
+			summary.Output = p.output.String()
+		}
+		summaries = append(summaries, &summary)
+	}
+	return summaries
+}
+
+func key(name string) string {
+	return "ginkgo/suite-process/" + name
+}
+
+func writerFor(writer io.Writer, output *lockedBuffer) io.Writer {
+	if writer == nil {
+		return output
+	}
+	return io.MultiWriter(writer, output)
+}
+
+type lockedBuffer struct {
+	lock   sync.Mutex
+	buffer bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.buffer.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.buffer.String()
+}
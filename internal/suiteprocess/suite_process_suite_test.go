@@ -0,0 +1,13 @@
+package suiteprocess_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestSuiteProcess(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "SuiteProcess Suite")
+}
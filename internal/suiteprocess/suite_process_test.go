@@ -0,0 +1,178 @@
+package suiteprocess_test
+
+import (
+	"errors"
+	"os/exec"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/onsi/ginkgo/internal/parallelkv"
+	"github.com/onsi/ginkgo/internal/remote"
+	. "github.com/onsi/ginkgo/internal/suiteprocess"
+)
+
+var _ = Describe("Manager", func() {
+	var (
+		manager *Manager
+		starts  int
+	)
+
+	command := func(script string) func() (*exec.Cmd, string) {
+		return func() (*exec.Cmd, string) {
+			starts++
+			return exec.Command("sh", "-c", script), "127.0.0.1:1234"
+		}
+	}
+
+	BeforeEach(func() {
+		PollingInterval = time.Millisecond
+		parallelkv.PollingInterval = time.Millisecond
+		manager = New(parallelkv.NewClient())
+		starts = 0
+	})
+
+	AfterEach(func() {
+		manager.StopNow()
+		PollingInterval = 100 * time.Millisecond
+		parallelkv.PollingInterval = 50 * time.Millisecond
+	})
+
+	Context("when running serially", func() {
+		It("should start the process once, wait for it to be ready, and kill it when stopped", func() {
+			checks := 0
+			readinessCheck := func(address string) error {
+				Ω(address).Should(Equal("127.0.0.1:1234"))
+				checks++
+				if checks < 3 {
+					return errors.New("not ready")
+				}
+				return nil
+			}
+
+			address, err := manager.Start("api", command("echo listening; exec sleep 10"), readinessCheck, time.Minute)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(address).Should(Equal("127.0.0.1:1234"))
+			Ω(checks).Should(Equal(3))
+
+			address, err = manager.Start("api", command("exec sleep 10"), nil, time.Minute)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(address).Should(Equal("127.0.0.1:1234"))
+			Ω(starts).Should(Equal(1))
+
+			Eventually(func() string {
+				return manager.Summaries()[0].Output
+			}).Should(Equal("listening\n"))
+
+			manager.Stop()
+			summaries := manager.Summaries()
+			Ω(summaries).Should(HaveLen(1))
+			Ω(summaries[0].Name).Should(Equal("api"))
+			Ω(summaries[0].Node).Should(Equal(1))
+			Ω(summaries[0].Command).Should(Equal("sh -c echo listening; exec sleep 10"))
+			Ω(summaries[0].Address).Should(Equal("127.0.0.1:1234"))
+			Ω(summaries[0].StopTime).ShouldNot(BeZero())
+			Ω(summaries[0].Output).Should(Equal("listening\n"))
+			Ω(summaries[0].Failure).Should(BeEmpty())
+		})
+
+		It("should fail when the process exits before becoming ready", func() {
+			_, err := manager.Start("api", command("echo oops >&2; exit 3"), func(string) error {
+				return errors.New("not ready")
+			}, time.Minute)
+			Ω(err).Should(MatchError(ContainSubstring(`Suite process "api" exited before becoming ready: exit status 3`)))
+			Ω(err.Error()).Should(ContainSubstring("oops"))
+			Ω(manager.Summaries()[0].Failure).Should(Equal("exited before becoming ready: exit status 3"))
+		})
+
+		It("should kill the process when it is not ready in time", func() {
+			_, err := manager.Start("api", command("exec sleep 10"), func(string) error {
+				return errors.New("not ready")
+			}, 20*time.Millisecond)
+			Ω(err).Should(MatchError(`Suite process "api" was not ready after 20ms` + "\n"))
+			Ω(manager.Summaries()[0].StopTime).ShouldNot(BeZero())
+		})
+
+		It("should fail when the process cannot be started", func() {
+			_, err := manager.Start("api", func() (*exec.Cmd, string) {
+				return exec.Command("/does/not/exist"), ""
+			}, nil, time.Minute)
+			Ω(err).Should(MatchError(ContainSubstring(`Suite process "api" failed to start`)))
+		})
+	})
+
+	Context("when running in parallel", func() {
+		var (
+			server         *remote.Server
+			otherNode      *Manager
+			nodeTwoIsAlive int32
+		)
+
+		BeforeEach(func() {
+			var err error
+			server, err = remote.NewServer(2)
+			Ω(err).ShouldNot(HaveOccurred())
+			server.Start()
+			atomic.StoreInt32(&nodeTwoIsAlive, 1)
+			server.RegisterAlive(2, func() bool {
+				return atomic.LoadInt32(&nodeTwoIsAlive) == 1
+			})
+
+			keyValues := parallelkv.NewClient()
+			keyValues.Connect(server.Address())
+			manager = New(keyValues)
+			manager.Connect(1, 2, server.Address())
+
+			otherKeyValues := parallelkv.NewClient()
+			otherKeyValues.Connect(server.Address())
+			otherNode = New(otherKeyValues)
+			otherNode.Connect(2, 2, server.Address())
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("should only start the process on node 1 and share its address with the other nodes", func() {
+			address, err := otherNode.Start("api", command("exec sleep 10"), nil, time.Second)
+			Ω(err).Should(MatchError(ContainSubstring(`Suite process "api" was not started by node 1`)))
+
+			address, err = manager.Start("api", command("exec sleep 10"), nil, time.Minute)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			otherAddress, err := otherNode.Start("api", command("exec sleep 10"), nil, time.Minute)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(otherAddress).Should(Equal(address))
+			Ω(starts).Should(Equal(1))
+			Ω(otherNode.Summaries()).Should(BeEmpty())
+		})
+
+		It("should tell the other nodes when the process failed to start", func() {
+			_, err := manager.Start("api", command("exit 1"), func(string) error {
+				return errors.New("not ready")
+			}, time.Minute)
+			Ω(err).Should(HaveOccurred())
+
+			_, err = otherNode.Start("api", command("exec sleep 10"), nil, time.Minute)
+			Ω(err).Should(MatchError(`Suite process "api" failed to start on node 1`))
+		})
+
+		It("should wait for the other nodes to be done before killing the process", func() {
+			_, err := manager.Start("api", command("exec sleep 10"), nil, time.Minute)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			stopped := make(chan bool)
+			go func() {
+				manager.Stop()
+				close(stopped)
+			}()
+			Consistently(stopped, 50*time.Millisecond).ShouldNot(BeClosed())
+
+			atomic.StoreInt32(&nodeTwoIsAlive, 0)
+			Eventually(stopped).Should(BeClosed())
+			Ω(manager.Summaries()[0].StopTime).ShouldNot(BeZero())
+		})
+	})
+})
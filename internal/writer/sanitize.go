@@ -0,0 +1,85 @@
+package writer
+
+import (
+	"fmt"
+	"regexp"
+	"unicode/utf8"
+)
+
+//The ways of handling binary output, see Sanitize
+const (
+	BinaryOutputKeep  = ""
+	BinaryOutputHex   = "hex"
+	BinaryOutputElide = "elide"
+)
+
+//ansiEscape matches the CSI sequences (colors, cursor moves), the OSC sequences (titles, hyperlinks) and the other
+//two-byte escape sequences
+var ansiEscape = regexp.MustCompile("\x1b\\[[0-?]*[ -/]*[@-~]|\x1b\\][^\x07\x1b]*(\x07|\x1b\\\\)|\x1b[@-Z\\\\-_]")
+
+//ValidateBinaryOutput returns an error when binaryOutput is not one of the ways of handling binary output
+func ValidateBinaryOutput(binaryOutput string) error {
+	switch binaryOutput {
+	case BinaryOutputKeep, BinaryOutputHex, BinaryOutputElide:
+		return nil
+	}
+	return fmt.Errorf("%q is neither %q nor %q", binaryOutput, BinaryOutputHex, BinaryOutputElide)
+}
+
+//Sanitize prepares captured output for reports.  stripANSI removes the ANSI escape sequences.  binaryOutput tells what
+//to do with binary garbage, the bytes that are not valid UTF-8 and the control characters XML forbids: BinaryOutputHex
+//writes each of them as \xNN, BinaryOutputElide replaces each run of them with a note of its length and
+//BinaryOutputKeep leaves them be.
+func Sanitize(output []byte, stripANSI bool, binaryOutput string) []byte {
+	if stripANSI {
+		output = ansiEscape.ReplaceAll(output, nil)
+	}
+	if binaryOutput != BinaryOutputHex && binaryOutput != BinaryOutputElide {
+		return output
+	}
+
+	sanitized := make([]byte, 0, len(output))
+	elided := 0
+	for len(output) > 0 {
+		r, size := utf8.DecodeRune(output)
+		if !isBinary(r, size) {
+			if elided > 0 {
+				sanitized = append(sanitized, fmt.Sprintf("[%d binary bytes elided]", elided)...)
+				elided = 0
+			}
+			sanitized = append(sanitized, output[:size]...)
+		} else if binaryOutput == BinaryOutputHex {
+			sanitized = append(sanitized, fmt.Sprintf(`\x%02x`, output[0])...)
+		} else {
+			elided++
+		}
+		output = output[size:]
+	}
+	if elided > 0 {
+		sanitized = append(sanitized, fmt.Sprintf("[%d binary bytes elided]", elided)...)
+	}
+	return sanitized
+}
+
+//isBinary tells whether the rune decoded out of size bytes is binary garbage: an invalid UTF-8 byte, or a control
+//character other than tab, line feed and carriage return
+func isBinary(r rune, size int) bool {
+	if r == utf8.RuneError && size == 1 {
+		return true
+	}
+	return r < 0x20 && r != '\t' && r != '\n' && r != '\r'
+}
+
+//RedactedText replaces what redactors match
+const RedactedText = "[REDACTED]"
+
+//RegexpRedactor returns a redactor replacing what pattern matches with RedactedText, see Writer.AddRedactor
+func RegexpRedactor(pattern string) (func(string) string, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return func(output string) string {
+		return re.ReplaceAllString(output, RedactedText)
+	}, nil
+}
@@ -0,0 +1,35 @@
+package writer_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/internal/writer"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Sanitize", func() {
+	output := []byte("\x1b[1;31mred\x1b[0m\x1b]8;;http://example.com\x07link\x1b]8;;\x07 caf\xc3\xa9\t\xff\xfe\x00\n")
+
+	It("should leave the output untouched by default", func() {
+		Ω(Sanitize(output, false, BinaryOutputKeep)).Should(Equal(output))
+	})
+
+	It("should strip the ANSI escape sequences when told to", func() {
+		Ω(string(Sanitize(output, true, BinaryOutputKeep))).Should(Equal("redlink caf\xc3\xa9\t\xff\xfe\x00\n"))
+	})
+
+	It("should hex-encode the binary garbage, keeping valid UTF-8, tabs and newlines", func() {
+		Ω(string(Sanitize(output, true, BinaryOutputHex))).Should(Equal("redlink café\t\\xff\\xfe\\x00\n"))
+		Ω(string(Sanitize([]byte("\x1b[0m"), false, BinaryOutputHex))).Should(Equal(`\x1b[0m`))
+	})
+
+	It("should elide each run of binary garbage", func() {
+		Ω(string(Sanitize(output, true, BinaryOutputElide))).Should(Equal("redlink café\t[3 binary bytes elided]\n"))
+		Ω(string(Sanitize([]byte("a\x00"), false, BinaryOutputElide))).Should(Equal("a[1 binary bytes elided]"))
+	})
+
+	It("should validate the ways of handling binary output", func() {
+		Ω(ValidateBinaryOutput("hex")).Should(Succeed())
+		Ω(ValidateBinaryOutput("")).Should(Succeed())
+		Ω(ValidateBinaryOutput("base64")).ShouldNot(Succeed())
+	})
+})
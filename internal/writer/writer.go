@@ -2,8 +2,13 @@ package writer
 
 import (
 	"bytes"
+	"fmt"
 	"io"
+	"runtime"
+	"strings"
 	"sync"
+
+	"github.com/onsi/ginkgo/internal/lanes"
 )
 
 type WriterInterface interface {
@@ -16,20 +21,274 @@ type WriterInterface interface {
 }
 
 type Writer struct {
-	buffer     *bytes.Buffer
+	buffer     *capture
 	outWriter  io.Writer
 	lock       *sync.Mutex
 	stream     bool
+	limit      int
+	stripANSI  bool
+	binary     string
+	redactors  []func(string) string
 	redirector io.Writer
+	lanes      map[int64]*capture
+
+	sessions        map[int64]*session
+	endedSessions   []int64
+	trackSessions   bool
+	dropLateOutput  bool
+	warnedLateWrite map[int64]bool
+
+	interleaveOffset func() int64
+	marks            []interleaveMark
+	written          int
+	interleaved      []InterleavedOutput
+}
+
+//interleaveMark records that what was written to the shared buffer from written on was written once the intercepted
+//output had reached offset
+type interleaveMark struct {
+	written int
+	offset  int64
+}
+
+//InterleavedOutput is output captured by the writer, along with the offset the intercepted stdout and stderr had
+//reached when it was written.  A negative offset stands for the end of the intercepted output.
+type InterleavedOutput struct {
+	Offset int64
+	Output string
+}
+
+//maxEndedSessions bounds how many ended sessions the writer keeps to tell late output apart.  Past it, the oldest are
+//forgotten, and what their goroutines write is no longer told apart from the output of the specs that follow.
+const maxEndedSessions = 64
+
+//session records the spec run by a goroutine, see BeginSession
+type session struct {
+	spec  string
+	ended bool
 }
 
 func New(outWriter io.Writer) *Writer {
 	return &Writer{
-		buffer:    &bytes.Buffer{},
+		buffer:    &capture{},
 		lock:      &sync.Mutex{},
 		outWriter: outWriter,
 		stream:    true,
+		lanes:     map[int64]*capture{},
+
+		sessions:        map[int64]*session{},
+		warnedLateWrite: map[int64]bool{},
+	}
+}
+
+//SetSanitization sets how the captured output handed out by Bytes and DumpOut is sanitized, see Sanitize.  What is
+//streamed is left untouched.
+func (w *Writer) SetSanitization(stripANSI bool, binaryOutput string) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.stripANSI = stripANSI
+	w.binary = binaryOutput
+}
+
+//AddRedactor registers a function applied to the captured output handed out by Bytes and DumpOut once sanitized, e.g.
+//to scrub secrets out of reports.  What is streamed is left untouched.
+func (w *Writer) AddRedactor(redactor func(output string) string) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.redactors = append(w.redactors, redactor)
+}
+
+//Redact applies the registered redactors to output, in the order they were registered
+func (w *Writer) Redact(output string) string {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return w.redact(output)
+}
+
+//redact applies the registered redactors to output.  The lock must be held.
+func (w *Writer) redact(output string) string {
+	for _, redactor := range w.redactors {
+		output = redactor(output)
+	}
+	return output
+}
+
+//SetLimit caps what is buffered between two truncations to limit bytes, see capture.  0 lifts the cap.
+func (w *Writer) SetLimit(limit int) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.limit = limit
+	w.buffer.limit = limit
+}
+
+//OpenLane captures what the calling goroutine, and the goroutines it starts, write in a buffer of their own until
+//CloseLane is called.  This keeps the output of specs running concurrently apart.
+func (w *Writer) OpenLane() {
+	id := lanes.ID()
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.lanes[id] = &capture{limit: w.limit}
+}
+
+//CloseLane closes the lane opened by the calling goroutine
+func (w *Writer) CloseLane() {
+	id := lanes.ID()
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	delete(w.lanes, id)
+}
+
+//currentBuffer returns the buffer of the lane of the calling goroutine, if any, or else the buffer shared by all
+//goroutines.  The lock must be held.
+func (w *Writer) currentBuffer() *capture {
+	if len(w.lanes) == 0 {
+		return w.buffer
+	}
+	if id, ok := lanes.Resolve(func(id int64) bool { return w.lanes[id] != nil }); ok {
+		return w.lanes[id]
+	}
+	return w.buffer
+}
+
+//BeginSession records that the calling goroutine runs spec until EndSession is called.  What the goroutines it starts
+//write once the session has ended bleeds into the output of the specs that follow: such late output is tagged with
+//spec, or dropped (see SetDropLateOutput), and a warning with the stack of the late writer is printed.
+func (w *Writer) BeginSession(spec string) {
+	id := lanes.ID()
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.sessions[id] = &session{spec: spec}
+}
+
+//EndSession ends the session begun by the calling goroutine
+func (w *Writer) EndSession() {
+	id := lanes.ID()
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	s, ok := w.sessions[id]
+	if !ok || s.ended {
+		return
+	}
+	s.ended = true
+	w.endedSessions = append(w.endedSessions, id)
+	if len(w.endedSessions) > maxEndedSessions {
+		delete(w.sessions, w.endedSessions[0])
+		w.endedSessions = w.endedSessions[1:]
+	}
+}
+
+//SetTrackSessions sets whether the specs are to run in sessions, see BeginSession.  Sessions are not tracked by default:
+//late output is then left alone, and the runner spares the goroutine of its own each spec needs to be told apart, and
+//the writer the lookup of the goroutine that started each writer.
+func (w *Writer) SetTrackSessions(track bool) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.trackSessions = track
+}
+
+//TracksSessions tells whether the specs are to run in sessions, see SetTrackSessions
+func (w *Writer) TracksSessions() bool {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return w.trackSessions
+}
+
+//SetDropLateOutput makes the writer drop late output rather than tag it, see BeginSession
+func (w *Writer) SetDropLateOutput(drop bool) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.dropLateOutput = drop
+}
+
+//lateSession returns the ended session the calling goroutine descends from, if any.  The lock must be held.
+func (w *Writer) lateSession() (int64, *session) {
+	if !w.trackSessions || len(w.endedSessions) == 0 {
+		return 0, nil
+	}
+	id := lanes.ID()
+	sessionID, ok := lanes.Resolve(func(id int64) bool { return w.sessions[id] != nil })
+	if !ok || !w.sessions[sessionID].ended {
+		return id, nil
+	}
+	return id, w.sessions[sessionID]
+}
+
+//warnAboutLateWrite prints, once per goroutine, where the goroutine id wrote late output from.  The lock must be held.
+func (w *Writer) warnAboutLateWrite(id int64, s *session) {
+	if w.warnedLateWrite[id] {
+		return
+	}
+	w.warnedLateWrite[id] = true
+
+	fate := "tagged with the spec"
+	if w.dropLateOutput {
+		fate = "dropped"
+	}
+	stack := make([]byte, 64*1024)
+	stack = stack[:runtime.Stack(stack, false)]
+	fmt.Fprintf(w.outWriter, "\nWarning: a goroutine started by %q wrote to GinkgoWriter after the spec ended.  Its late output is %s.  It wrote from:\n%s\n", s.spec, fate, stack)
+}
+
+//SetInterleaving makes DumpOut keep the captured output for TakeInterleavedOutput rather than write it out, along with
+//where offset, which tells how much of stdout and stderr was intercepted so far, was at when each part of it was
+//written.  Interleave then puts it back in order among the intercepted output.  Only the output shared by all
+//goroutines is interleaved: lanes are dumped as usual.
+func (w *Writer) SetInterleaving(offset func() int64) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.interleaveOffset = offset
+	w.resetMarks()
+}
+
+//TakeInterleavedOutput returns the output dumped since the last call, see SetInterleaving
+func (w *Writer) TakeInterleavedOutput() []InterleavedOutput {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	interleaved := w.interleaved
+	w.interleaved = nil
+	return interleaved
+}
+
+//interleaving tells whether the output of the calling goroutine is interleaved.  The lock must be held.
+func (w *Writer) interleaving() bool {
+	return w.interleaveOffset != nil && !w.stream && w.currentBuffer() == w.buffer
+}
+
+//mark records where the intercepted output is at before b is written.  The lock must be held.
+func (w *Writer) mark(b []byte) {
+	if !w.interleaving() {
+		return
+	}
+	offset := w.interleaveOffset()
+	if len(w.marks) == 0 || w.marks[len(w.marks)-1].offset != offset {
+		w.marks = append(w.marks, interleaveMark{written: w.written, offset: offset})
+	}
+	w.written += len(b)
+}
+
+//resetMarks forgets the marks of the shared buffer, which must be reset along.  The lock must be held.
+func (w *Writer) resetMarks() {
+	w.marks = nil
+	w.written = 0
+}
+
+//takeInterleavedBuffer splits what the shared buffer captured at its marks, and resets it.  When its limit dropped part
+//of the output, it is kept as a whole at the end of the intercepted output.  The lock must be held.
+func (w *Writer) takeInterleavedBuffer() {
+	b := string(w.buffer.Bytes())
+	if len(b) != w.written {
+		w.interleaved = append(w.interleaved, InterleavedOutput{Offset: -1, Output: b})
+	} else {
+		for i, mark := range w.marks {
+			end := len(b)
+			if i+1 < len(w.marks) {
+				end = w.marks[i+1].written
+			}
+			w.interleaved = append(w.interleaved, InterleavedOutput{Offset: mark.offset, Output: b[mark.written:end]})
+		}
 	}
+	w.buffer.Reset()
+	w.resetMarks()
 }
 
 func (w *Writer) AndRedirectTo(writer io.Writer) {
@@ -46,7 +305,23 @@ func (w *Writer) Write(b []byte) (n int, err error) {
 	w.lock.Lock()
 	defer w.lock.Unlock()
 
-	n, err = w.buffer.Write(b)
+	if id, late := w.lateSession(); late != nil {
+		w.warnAboutLateWrite(id, late)
+		if w.dropLateOutput {
+			return len(b), nil
+		}
+		if _, err := w.write(append([]byte(fmt.Sprintf("[late output of %q] ", late.spec)), b...)); err != nil {
+			return 0, err
+		}
+		return len(b), nil
+	}
+	return w.write(b)
+}
+
+//write buffers and streams b.  The lock must be held.
+func (w *Writer) write(b []byte) (n int, err error) {
+	w.mark(b)
+	n, err = w.currentBuffer().Write(b)
 	if w.redirector != nil {
 		w.redirector.Write(b)
 	}
@@ -59,31 +334,158 @@ func (w *Writer) Write(b []byte) (n int, err error) {
 func (w *Writer) Truncate() {
 	w.lock.Lock()
 	defer w.lock.Unlock()
-	w.buffer.Reset()
+	if w.currentBuffer() == w.buffer {
+		w.resetMarks()
+	}
+	w.currentBuffer().Reset()
 }
 
 func (w *Writer) DumpOut() {
 	w.lock.Lock()
 	defer w.lock.Unlock()
-	if !w.stream {
-		w.buffer.WriteTo(w.outWriter)
+	if w.interleaving() {
+		w.takeInterleavedBuffer()
+	} else if !w.stream {
+		w.outWriter.Write(w.captured())
+		w.currentBuffer().Reset()
 	}
 }
 
 func (w *Writer) Bytes() []byte {
 	w.lock.Lock()
 	defer w.lock.Unlock()
-	b := w.buffer.Bytes()
+	return w.captured()
+}
+
+//captured returns a copy of what the current buffer captured, sanitized and redacted.  The lock must be held.
+func (w *Writer) captured() []byte {
+	b := w.currentBuffer().Bytes()
 	copied := make([]byte, len(b))
 	copy(copied, b)
-	return copied
+	sanitized := Sanitize(copied, w.stripANSI, w.binary)
+	if len(w.redactors) == 0 {
+		return sanitized
+	}
+	return []byte(w.redact(string(sanitized)))
 }
 
 func (w *Writer) DumpOutWithHeader(header string) {
 	w.lock.Lock()
 	defer w.lock.Unlock()
-	if !w.stream && w.buffer.Len() > 0 {
+	buffer := w.currentBuffer()
+	if !w.stream && buffer.Len() > 0 {
 		w.outWriter.Write([]byte(header))
-		w.buffer.WriteTo(w.outWriter)
+		w.outWriter.Write(w.captured())
+		buffer.Reset()
+		if buffer == w.buffer {
+			w.resetMarks()
+		}
+	}
+}
+
+//Interleave puts the output taken from TakeInterleavedOutput back into output, the stdout and stderr intercepted
+//meanwhile, at the offsets it was written at.  When both streams hold something, each line is tagged with the one it
+//comes from, so that the timeline of a spec reads in the order it ran.
+func Interleave(output string, interleaved []InterleavedOutput) string {
+	if len(interleaved) == 0 {
+		return output
+	}
+
+	type segment struct {
+		tag  string
+		text string
+	}
+	segments := []segment{}
+	position := 0
+	for _, chunk := range interleaved {
+		offset := int(chunk.Offset)
+		if offset < 0 || offset > len(output) {
+			offset = len(output)
+		}
+		if offset > position {
+			segments = append(segments, segment{"[stdout/stderr] ", output[position:offset]})
+			position = offset
+		}
+		segments = append(segments, segment{"[GinkgoWriter] ", chunk.Output})
+	}
+	if position < len(output) {
+		segments = append(segments, segment{"[stdout/stderr] ", output[position:]})
+	}
+
+	tagged := output != ""
+	result := &strings.Builder{}
+	for i, segment := range segments {
+		if !tagged {
+			result.WriteString(segment.text)
+			continue
+		}
+		for _, line := range strings.SplitAfter(segment.text, "\n") {
+			if line != "" {
+				result.WriteString(segment.tag + line)
+			}
+		}
+		if i < len(segments)-1 && segment.text != "" && !strings.HasSuffix(segment.text, "\n") {
+			result.WriteString("\n")
+		}
+	}
+	return result.String()
+}
+
+//capture buffers output.  When its limit is positive, it keeps the first and the last halves of the limit, and
+//replaces the bytes in between with a marker telling how many were dropped, so that a chatty spec cannot exhaust the
+//memory.
+type capture struct {
+	head    bytes.Buffer
+	tail    []byte
+	dropped int
+	limit   int
+}
+
+func (c *capture) Write(b []byte) (int, error) {
+	n := len(b)
+	if c.limit <= 0 {
+		return c.head.Write(b)
+	}
+
+	if room := c.limit - c.limit/2 - c.head.Len(); room > 0 {
+		if room > len(b) {
+			room = len(b)
+		}
+		c.head.Write(b[:room])
+		b = b[room:]
+	}
+	c.tail = append(c.tail, b...)
+	//dropping the start of the tail copies it, so let it grow to twice its size first
+	if tailLimit := c.limit / 2; len(c.tail) > 2*tailLimit {
+		excess := len(c.tail) - tailLimit
+		c.dropped += excess
+		c.tail = append(c.tail[:0], c.tail[excess:]...)
+	}
+	return n, nil
+}
+
+func (c *capture) Len() int {
+	return len(c.Bytes())
+}
+
+func (c *capture) Bytes() []byte {
+	tail, dropped := c.tail, c.dropped
+	if excess := len(tail) - c.limit/2; c.limit > 0 && excess > 0 {
+		tail, dropped = tail[excess:], dropped+excess
+	}
+	if len(tail) == 0 && dropped == 0 {
+		return c.head.Bytes()
 	}
+
+	b := append([]byte{}, c.head.Bytes()...)
+	if dropped > 0 {
+		b = append(b, fmt.Sprintf("\n... output truncated (%d bytes dropped) ...\n", dropped)...)
+	}
+	return append(b, tail...)
+}
+
+func (c *capture) Reset() {
+	c.head.Reset()
+	c.tail = c.tail[:0]
+	c.dropped = 0
 }
@@ -1,6 +1,8 @@
 package writer_test
 
 import (
+	"strings"
+
 	"github.com/onsi/gomega/gbytes"
 
 	. "github.com/onsi/ginkgo"
@@ -72,4 +74,193 @@ var _ = Describe("Writer", func() {
 			})
 		})
 	})
+
+	Describe("limiting the buffered output", func() {
+		BeforeEach(func() {
+			writer.SetStream(false)
+			writer.SetLimit(10)
+		})
+
+		It("should keep the beginning and the end of the output, and tell how much was dropped", func() {
+			writer.Write([]byte("01234"))
+			writer.Write([]byte("56789abcdefghij"))
+			writer.Write([]byte("klmno"))
+			Ω(string(writer.Bytes())).Should(Equal("01234\n... output truncated (15 bytes dropped) ...\nklmno"))
+		})
+
+		It("should leave output within the limit untouched", func() {
+			writer.Write([]byte("0123456789"))
+			Ω(string(writer.Bytes())).Should(Equal("0123456789"))
+		})
+
+		It("should start over once truncated", func() {
+			writer.Write([]byte("0123456789abcdef"))
+			writer.Truncate()
+			writer.Write([]byte("foo"))
+			writer.DumpOut()
+			Ω(string(out.Contents())).Should(Equal("foo"))
+		})
+	})
+
+	It("should sanitize the captured output for reports but not what it streams", func() {
+		writer.SetSanitization(true, BinaryOutputHex)
+		writer.Write([]byte("\x1b[32mok\x1b[0m\x00"))
+		Ω(string(out.Contents())).Should(Equal("\x1b[32mok\x1b[0m\x00"))
+		Ω(string(writer.Bytes())).Should(Equal(`ok\x00`))
+	})
+
+	It("should redact the captured output for reports but not what it streams", func() {
+		redactor, err := RegexpRedactor("secret[0-9]+")
+		Ω(err).ShouldNot(HaveOccurred())
+		writer.AddRedactor(redactor)
+		writer.AddRedactor(strings.ToUpper)
+		writer.Write([]byte("the secret42 is out"))
+		Ω(string(out.Contents())).Should(Equal("the secret42 is out"))
+		Ω(string(writer.Bytes())).Should(Equal("THE [REDACTED] IS OUT"))
+	})
+
+	Describe("lanes", func() {
+		BeforeEach(func() {
+			writer.SetStream(false)
+		})
+
+		It("should capture what each lane writes apart", func() {
+			writer.Write([]byte("shared"))
+
+			captured := make(chan []byte)
+			go func() {
+				writer.OpenLane()
+				defer writer.CloseLane()
+				writer.Write([]byte("in the lane"))
+				captured <- writer.Bytes()
+			}()
+			Ω(string(<-captured)).Should(Equal("in the lane"))
+			Ω(string(writer.Bytes())).Should(Equal("shared"))
+		})
+	})
+
+	Describe("interleaving", func() {
+		var offset int64
+
+		BeforeEach(func() {
+			offset = 0
+			writer.SetStream(false)
+			writer.SetInterleaving(func() int64 { return offset })
+		})
+
+		It("should keep what it dumps, split where the intercepted output moved on, rather than write it out", func() {
+			writer.Write([]byte("first "))
+			writer.Write([]byte("line\n"))
+			offset = 4
+			writer.Write([]byte("second line\n"))
+			writer.DumpOut()
+
+			Ω(out.Contents()).Should(BeEmpty())
+			Ω(writer.TakeInterleavedOutput()).Should(Equal([]InterleavedOutput{
+				{Offset: 0, Output: "first line\n"},
+				{Offset: 4, Output: "second line\n"},
+			}))
+			Ω(writer.TakeInterleavedOutput()).Should(BeEmpty())
+		})
+
+		It("should forget what it truncates", func() {
+			writer.Write([]byte("passed\n"))
+			writer.Truncate()
+			offset = 7
+			writer.Write([]byte("failed\n"))
+			writer.DumpOut()
+
+			Ω(writer.TakeInterleavedOutput()).Should(Equal([]InterleavedOutput{{Offset: 7, Output: "failed\n"}}))
+		})
+
+		It("should keep output its limit dropped part of at the end", func() {
+			writer.SetLimit(8)
+			writer.Write([]byte("0123456789"))
+			writer.DumpOut()
+
+			Ω(writer.TakeInterleavedOutput()).Should(Equal([]InterleavedOutput{{Offset: -1, Output: "0123\n... output truncated (2 bytes dropped) ...\n6789"}}))
+		})
+
+		It("should put it back in order among the intercepted output, tagging the source of each line", func() {
+			output := Interleave("out 1\nout 2\npartial", []InterleavedOutput{
+				{Offset: 0, Output: "gw 1\n"},
+				{Offset: 6, Output: "gw 2\ngw 3\n"},
+				{Offset: -1, Output: "gw 4"},
+			})
+			Ω(output).Should(Equal("[GinkgoWriter] gw 1\n[stdout/stderr] out 1\n[GinkgoWriter] gw 2\n[GinkgoWriter] gw 3\n[stdout/stderr] out 2\n[stdout/stderr] partial\n[GinkgoWriter] gw 4"))
+		})
+
+		It("should leave the output of a single source untagged", func() {
+			Ω(Interleave("out\n", nil)).Should(Equal("out\n"))
+			Ω(Interleave("", []InterleavedOutput{{Offset: 0, Output: "gw 1\n"}, {Offset: -1, Output: "gw 2\n"}})).Should(Equal("gw 1\ngw 2\n"))
+		})
+	})
+
+	Describe("late output", func() {
+		var spawnLateWriter func(endSessions int) chan struct{}
+
+		BeforeEach(func() {
+			writer.SetStream(false)
+			writer.SetTrackSessions(true)
+
+			//starts a spec whose goroutine writes once the spec and then the specs ran by endSessions have ended
+			spawnLateWriter = func(endSessions int) chan struct{} {
+				release, done := make(chan struct{}), make(chan struct{})
+				ended := make(chan struct{})
+				go func() {
+					writer.BeginSession("the leaky spec")
+					go func() {
+						<-release
+						writer.Write([]byte("late"))
+						writer.Write([]byte(" again"))
+						close(done)
+					}()
+					writer.Write([]byte("on time"))
+					writer.EndSession()
+					close(ended)
+				}()
+				<-ended
+				for i := 0; i < endSessions; i++ {
+					ended := make(chan struct{})
+					go func() {
+						writer.BeginSession("a later spec")
+						writer.EndSession()
+						close(ended)
+					}()
+					<-ended
+				}
+				Ω(string(writer.Bytes())).Should(Equal("on time"))
+				writer.Truncate()
+				close(release)
+				return done
+			}
+		})
+
+		It("should tag it with the spec, warning once with the stack of the late writer", func() {
+			<-spawnLateWriter(0)
+			Ω(string(writer.Bytes())).Should(Equal(`[late output of "the leaky spec"] late[late output of "the leaky spec"]  again`))
+			Ω(out).Should(gbytes.Say(`Warning: a goroutine started by "the leaky spec" wrote to GinkgoWriter after the spec ended.  Its late output is tagged with the spec.  It wrote from:\n`))
+			Ω(out).Should(gbytes.Say(`writer_test.go`))
+			Ω(strings.Count(string(out.Contents()), "Warning")).Should(Equal(1))
+		})
+
+		It("should drop it when told to", func() {
+			writer.SetDropLateOutput(true)
+			<-spawnLateWriter(0)
+			Ω(writer.Bytes()).Should(BeEmpty())
+			Ω(out).Should(gbytes.Say(`Its late output is dropped`))
+		})
+
+		It("should leave it alone when sessions are not tracked", func() {
+			writer.SetTrackSessions(false)
+			<-spawnLateWriter(0)
+			Ω(string(writer.Bytes())).Should(Equal("late again"))
+			Ω(out.Contents()).Should(BeEmpty())
+		})
+
+		It("should forget the oldest of the 64 ended sessions it keeps", func() {
+			<-spawnLateWriter(64)
+			Ω(string(writer.Bytes())).Should(Equal("late again"))
+		})
+	})
 })
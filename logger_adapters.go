@@ -0,0 +1,86 @@
+package ginkgo
+
+import (
+	"io"
+	"sync/atomic"
+)
+
+//LogLevel is the severity of what an application logger writes to the GinkgoWriter through the logger adapters.  Its
+//values match the ones of log/slog.
+type LogLevel int
+
+const (
+	LogLevelDebug LogLevel = -4
+	LogLevelInfo  LogLevel = 0
+	LogLevelWarn  LogLevel = 4
+	LogLevelError LogLevel = 8
+)
+
+var ginkgoLogLevel int32 = int32(LogLevelInfo)
+
+//SetGinkgoLogLevel sets the level below which the logger adapters drop what they are handed, LogLevelInfo by default.
+//Call it once, typically in BeforeSuite, along with plugging the application loggers into the adapters:
+//
+//	BeforeSuite(func() {
+//		SetGinkgoLogLevel(LogLevelDebug)
+//		log.SetOutput(GinkgoWriterAt(LogLevelInfo))
+//	})
+func SetGinkgoLogLevel(level LogLevel) {
+	atomic.StoreInt32(&ginkgoLogLevel, int32(level))
+}
+
+//GinkgoLogLevel returns the level set with SetGinkgoLogLevel
+func GinkgoLogLevel() LogLevel {
+	return LogLevel(atomic.LoadInt32(&ginkgoLogLevel))
+}
+
+//GinkgoWriterAt returns a writer for loggers that only know about an io.Writer: what it is handed is written to the
+//GinkgoWriter, and so captured along with the output of the running spec, unless level is below GinkgoLogLevel, in
+//which case it is dropped.  Close does nothing, so that the writer can be handed to loggers that close their output.
+func GinkgoWriterAt(level LogLevel) io.WriteCloser {
+	return levelWriter{level: level}
+}
+
+//GinkgoZapWriteSyncer returns a writer that satisfies zapcore.WriteSyncer, to build a zap core writing to the
+//GinkgoWriter without Ginkgo depending on zap.  Zap filters the levels itself:
+//
+//	core := zapcore.NewCore(zapcore.NewConsoleEncoder(zap.NewDevelopmentEncoderConfig()), GinkgoZapWriteSyncer(), zapcore.DebugLevel)
+//	logger := zap.New(core)
+func GinkgoZapWriteSyncer() WriteSyncer {
+	return ginkgoWriterProxy{}
+}
+
+//WriteSyncer is an io.Writer that can be flushed, as expected by zap
+type WriteSyncer interface {
+	io.Writer
+	Sync() error
+}
+
+//ginkgoWriterProxy writes to whatever the GinkgoWriter is at the time of the write, so that adapters made before the
+//GinkgoWriter is swapped keep working
+type ginkgoWriterProxy struct{}
+
+func (ginkgoWriterProxy) Write(p []byte) (int, error) {
+	return GinkgoWriter.Write(p)
+}
+
+func (ginkgoWriterProxy) Sync() error {
+	return nil
+}
+
+func (ginkgoWriterProxy) Close() error {
+	return nil
+}
+
+//levelWriter drops what it is handed when its level is below GinkgoLogLevel
+type levelWriter struct {
+	ginkgoWriterProxy
+	level LogLevel
+}
+
+func (w levelWriter) Write(p []byte) (int, error) {
+	if w.level < GinkgoLogLevel() {
+		return len(p), nil
+	}
+	return w.ginkgoWriterProxy.Write(p)
+}
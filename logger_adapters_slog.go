@@ -0,0 +1,27 @@
+// +build go1.21
+
+package ginkgo
+
+import (
+	"log/slog"
+)
+
+//GinkgoSlogHandler returns a slog handler writing text records to the GinkgoWriter, so that they are captured along
+//with the output of the running spec.  Unless opts sets a Level, records below GinkgoLogLevel are dropped.
+func GinkgoSlogHandler(opts *slog.HandlerOptions) slog.Handler {
+	options := slog.HandlerOptions{}
+	if opts != nil {
+		options = *opts
+	}
+	if options.Level == nil {
+		options.Level = ginkgoLeveler{}
+	}
+	return slog.NewTextHandler(ginkgoWriterProxy{}, &options)
+}
+
+//ginkgoLeveler reads GinkgoLogLevel every time slog asks, so that SetGinkgoLogLevel applies to the handlers made before
+type ginkgoLeveler struct{}
+
+func (ginkgoLeveler) Level() slog.Level {
+	return slog.Level(GinkgoLogLevel())
+}
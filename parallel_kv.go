@@ -0,0 +1,72 @@
+package ginkgo
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/onsi/ginkgo/internal/global"
+)
+
+//KV is the key-value store returned by ParallelKV
+type KV struct{}
+
+//ParallelKV returns a key-value store shared by all the parallel nodes of the suite run.  Use it to share tokens, URLs or
+//readiness signals between nodes, beyond the single []byte SynchronizedBeforeSuite hands from node 1 to the others:
+//
+//	SynchronizedBeforeSuite(func() []byte {
+//		ParallelKV().Set("api-url", startAPI().URL)
+//		return nil
+//	}, func([]byte) {
+//		var url string
+//		ParallelKV().WaitFor("api-url", &url)
+//		client = NewClient(url)
+//	})
+//
+//Values are serialized as JSON.  When running serially the store is local to the process.  Only use the store from
+//within setup nodes and specs: keys set while the spec tree is being built are not shared with the other nodes.
+func ParallelKV() *KV {
+	return &KV{}
+}
+
+//Set sets key to value, which must be serializable as JSON
+func (kv *KV) Set(key string, value interface{}) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		Fail(fmt.Sprintf("Failed to serialize %q: %s", key, err.Error()), 1)
+	}
+	err = global.Suite.KeyValueClient().Set(key, data)
+	if err != nil {
+		Fail(err.Error(), 1)
+	}
+}
+
+//Get deserializes the value of key into value, which must be a pointer.  It returns false, leaving value untouched, if
+//key has not been set.
+func (kv *KV) Get(key string, value interface{}) bool {
+	data, found, err := global.Suite.KeyValueClient().Get(key)
+	if err != nil {
+		Fail(err.Error(), 1)
+	}
+	if found {
+		kv.decode(key, data, value, 2)
+	}
+	return found
+}
+
+//WaitFor blocks until key is set, possibly by another parallel node, and deserializes its value into value, which must
+//be a pointer.  By default WaitFor waits forever; pass a timeout (a time.Duration, or float64 or int seconds) to fail
+//the running spec if key is not set by then.
+func (kv *KV) WaitFor(key string, value interface{}, timeout ...interface{}) {
+	data, err := global.Suite.KeyValueClient().WaitFor(key, parseWaitTimeout("WaitFor", 0, timeout...))
+	if err != nil {
+		Fail(err.Error(), 1)
+	}
+	kv.decode(key, data, value, 2)
+}
+
+func (kv *KV) decode(key string, data []byte, value interface{}, callerSkip int) {
+	err := json.Unmarshal(data, value)
+	if err != nil {
+		Fail(fmt.Sprintf("Failed to deserialize %q: %s", key, err.Error()), callerSkip)
+	}
+}
@@ -0,0 +1,37 @@
+package ginkgo
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/onsi/ginkgo/config"
+	"github.com/onsi/ginkgo/internal/codelocation"
+	"github.com/onsi/ginkgo/internal/global"
+	"github.com/onsi/ginkgo/internal/randomseed"
+)
+
+//GinkgoSpecRandomSeed returns the seed of the running spec.  It is derived from the seed of the suite (see
+//GinkgoRandomSeed) and the text of the spec, so it does not change when the specs are shuffled.  Outside of a spec,
+//GinkgoSpecRandomSeed returns the seed of the suite.
+//
+//Run ginkgo -seedMathRand to seed math/rand with it before each spec.
+func GinkgoSpecRandomSeed() int64 {
+	return global.Suite.SpecRandomSeed(config.GinkgoConfig.RandomSeed)
+}
+
+//GinkgoRandSource wraps rand.NewSource to guard against seeds derived from the current time, which make failures
+//impossible to reproduce:
+//
+//	r := rand.New(GinkgoRandSource(time.Now().UnixNano()))
+//
+//When seed looks derived from the current time, GinkgoRandSource seeds the source with the seed of the running spec
+//instead (see GinkgoSpecRandomSeed) and flags the time-based seed as a source of nondeterminism in the report of the spec.
+func GinkgoRandSource(seed int64) rand.Source {
+	if randomseed.LooksTimeBased(seed, time.Now()) {
+		specSeed := GinkgoSpecRandomSeed()
+		global.Suite.RecordNondeterminism(fmt.Sprintf("time-based random seed %d replaced with %d at %s", seed, specSeed, codelocation.New(1)))
+		seed = specSeed
+	}
+	return rand.NewSource(seed)
+}
@@ -0,0 +1,25 @@
+package ginkgo
+
+import (
+	"github.com/onsi/ginkgo/config"
+	"github.com/onsi/ginkgo/internal/codelocation"
+	"github.com/onsi/ginkgo/internal/global"
+	"github.com/onsi/ginkgo/types"
+)
+
+//AddReportEntry attaches a named value to the report of the running spec, e.g. a diagnostic gathered while it ran:
+//
+//	It("serves the dashboard quickly", func() {
+//		...
+//		AddReportEntry("latency", latencies)
+//	})
+//
+//The value is rendered right away: it is printed on the console along with the failure of the spec, and written to JSON
+//reports through SpecSummary.ReportEntries.  Values implementing types.ReportEntryRenderer control how they are rendered
+//in each; others are printed with their String method, or with %+v, and written to JSON reports as they marshal.
+//
+//AddReportEntry does nothing outside of a spec.
+func AddReportEntry(name string, value interface{}) {
+	entry := types.NewReportEntry(name, codelocation.New(1), value, !config.DefaultReporterConfig.NoColor)
+	global.Suite.AddReportEntry(entry)
+}
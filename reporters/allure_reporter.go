@@ -0,0 +1,352 @@
+/*
+
+Allure Reporter for Ginkgo
+
+The Allure reporter writes the results of a suite run in the Allure 2 results format, ready to be rendered with `allure generate`:
+
+	ginkgo -allureResultsDir=allure-results
+
+Each spec attempt is written as a result file, and the suite as a container file holding BeforeSuite and AfterSuite as fixtures.
+The nodes that ran as part of a spec (BeforeEach, It, ...) become steps, and the steps announced with By are nested
+within them.  Captured output and measurements are attached to the results, and spec labels become Allure tags.
+Suite labels are added as tags to every result, and the suite metadata is written to environment.properties.
+
+*/
+
+package reporters
+
+import (
+	"crypto/md5"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/onsi/ginkgo/config"
+	"github.com/onsi/ginkgo/internal/clock"
+	"github.com/onsi/ginkgo/types"
+)
+
+type AllureResult struct {
+	UUID          string               `json:"uuid"`
+	HistoryID     string               `json:"historyId"`
+	TestCaseID    string               `json:"testCaseId"`
+	FullName      string               `json:"fullName"`
+	Name          string               `json:"name"`
+	Status        string               `json:"status"`
+	StatusDetails *AllureStatusDetails `json:"statusDetails,omitempty"`
+	Stage         string               `json:"stage"`
+	Start         int64                `json:"start"`
+	Stop          int64                `json:"stop"`
+	Labels        []AllureLabel        `json:"labels"`
+	Steps         []*AllureStep        `json:"steps"`
+	Attachments   []AllureAttachment   `json:"attachments"`
+	Parameters    []AllureParameter    `json:"parameters"`
+}
+
+type AllureContainer struct {
+	UUID     string        `json:"uuid"`
+	Name     string        `json:"name"`
+	Children []string      `json:"children"`
+	Befores  []*AllureStep `json:"befores"`
+	Afters   []*AllureStep `json:"afters"`
+	Start    int64         `json:"start"`
+	Stop     int64         `json:"stop"`
+}
+
+//AllureStep is used both for steps and for fixtures, which share the same structure
+type AllureStep struct {
+	Name          string               `json:"name"`
+	Status        string               `json:"status"`
+	StatusDetails *AllureStatusDetails `json:"statusDetails,omitempty"`
+	Stage         string               `json:"stage"`
+	Start         int64                `json:"start"`
+	Stop          int64                `json:"stop"`
+	Steps         []*AllureStep        `json:"steps"`
+	Attachments   []AllureAttachment   `json:"attachments"`
+}
+
+type AllureStatusDetails struct {
+	Message string `json:"message,omitempty"`
+	Trace   string `json:"trace,omitempty"`
+}
+
+type AllureLabel struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type AllureParameter struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type AllureAttachment struct {
+	Name   string `json:"name"`
+	Source string `json:"source"`
+	Type   string `json:"type"`
+}
+
+type AllureReporter struct {
+	dir string
+
+	container   AllureContainer
+	suiteLabels []string
+}
+
+//NewAllureReporter creates a new Allure reporter.  Results are written to dir, which is created if need be.
+func NewAllureReporter(dir string) *AllureReporter {
+	return &AllureReporter{
+		dir: dir,
+	}
+}
+
+func (reporter *AllureReporter) SpecSuiteWillBegin(ginkgoConfig config.GinkgoConfigType, summary *types.SuiteSummary) {
+	reporter.container = AllureContainer{
+		UUID:     newAllureUUID(),
+		Name:     summary.SuiteDescription,
+		Children: []string{},
+		Befores:  []*AllureStep{},
+		Afters:   []*AllureStep{},
+		Start:    allureTime(clock.Now()),
+	}
+	reporter.suiteLabels = summary.SuiteLabels
+	err := os.MkdirAll(longPath(reportFilePath(reporter.dir)), os.ModePerm)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\nFailed to create Allure results directory: %s\n\t%s", reporter.dir, err.Error())
+	}
+}
+
+func (reporter *AllureReporter) BeforeSuiteDidRun(setupSummary *types.SetupSummary) {
+	reporter.container.Befores = append(reporter.container.Befores, allureFixture("BeforeSuite", setupSummary))
+}
+
+func (reporter *AllureReporter) SpecWillRun(specSummary *types.SpecSummary) {
+}
+
+func (reporter *AllureReporter) SpecDidComplete(specSummary *types.SpecSummary) {
+	result := reporter.result(specSummary)
+	reporter.container.Children = append(reporter.container.Children, result.UUID)
+	reporter.write(result.UUID+"-result.json", result)
+}
+
+func (reporter *AllureReporter) AfterSuiteDidRun(setupSummary *types.SetupSummary) {
+	reporter.container.Afters = append(reporter.container.Afters, allureFixture("AfterSuite", setupSummary))
+}
+
+func (reporter *AllureReporter) SpecSuiteDidEnd(summary *types.SuiteSummary) {
+	reporter.container.Stop = allureTime(clock.Now())
+	reporter.write(reporter.container.UUID+"-container.json", reporter.container)
+
+	if len(summary.SuiteMetadata) > 0 {
+		properties := &strings.Builder{}
+		for _, key := range SortedSuiteMetadataKeys(summary) {
+			fmt.Fprintf(properties, "%s=%s\n", allurePropertiesEscaper.Replace(key), allurePropertiesEscaper.Replace(summary.SuiteMetadata[key]))
+		}
+		err := ioutil.WriteFile(reporter.path("environment.properties"), []byte(properties.String()), 0666)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "\nFailed to write Allure environment: %s\n\t%s", reporter.dir, err.Error())
+		}
+	}
+}
+
+var allurePropertiesEscaper = strings.NewReplacer("\\", "\\\\", "\n", "\\n", "=", "\\=", ":", "\\:")
+
+func (reporter *AllureReporter) result(specSummary *types.SpecSummary) AllureResult {
+	//the history of a spec is told by its original texts, which the text transformers leave alone
+	historyID := md5.Sum([]byte(SpecFullText(specSummary)))
+	texts := specSummary.TextsToDisplay()
+	start, stop := allureSpan(specSummary.StartTime, specSummary.RunTime)
+
+	result := AllureResult{
+		UUID:          newAllureUUID(),
+		HistoryID:     hex.EncodeToString(historyID[:]),
+		TestCaseID:    hex.EncodeToString(historyID[:]),
+		FullName:      SpecDisplayText(specSummary),
+		Name:          texts[len(texts)-1],
+		Status:        allureStatus(specSummary.State),
+		StatusDetails: allureStatusDetails(specSummary.State, specSummary.Failure),
+		Stage:         "finished",
+		Start:         start,
+		Stop:          stop,
+		Labels:        reporter.labels(specSummary),
+		Steps:         allureSteps(specSummary),
+		Attachments:   []AllureAttachment{},
+		Parameters:    []AllureParameter{},
+	}
+
+	if specSummary.CapturedOutput != "" {
+		result.Attachments = append(result.Attachments, reporter.attach("Captured output", "text/plain", "txt", []byte(specSummary.CapturedOutput)))
+	}
+	if len(specSummary.Measurements) > 0 {
+		data, err := json.MarshalIndent(specSummary.Measurements, "", "  ")
+		if err == nil {
+			result.Attachments = append(result.Attachments, reporter.attach("Measurements", "application/json", "json", data))
+		}
+	}
+	return result
+}
+
+func (reporter *AllureReporter) labels(specSummary *types.SpecSummary) []AllureLabel {
+	labels := []AllureLabel{
+		{Name: "framework", Value: "ginkgo"},
+		{Name: "language", Value: "go"},
+		{Name: "suite", Value: reporter.container.Name},
+	}
+	if texts := specSummary.TextsToDisplay(); len(texts) > 2 {
+		labels = append(labels, AllureLabel{Name: "subSuite", Value: strings.Join(texts[1:len(texts)-1], " ")})
+	}
+	for _, label := range reporter.suiteLabels {
+		labels = append(labels, AllureLabel{Name: "tag", Value: label})
+	}
+	for _, label := range specSummary.Labels {
+		labels = append(labels, AllureLabel{Name: "tag", Value: label})
+	}
+	return labels
+}
+
+func (reporter *AllureReporter) attach(name string, mimeType string, extension string, data []byte) AllureAttachment {
+	source := newAllureUUID() + "-attachment." + extension
+	err := ioutil.WriteFile(reporter.path(source), data, 0666)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\nFailed to write Allure attachment: %s\n\t%s", source, err.Error())
+	}
+	return AllureAttachment{Name: name, Source: source, Type: mimeType}
+}
+
+//path returns the path of the file name of the results directory, extended on Windows when it is too long
+func (reporter *AllureReporter) path(name string) string {
+	return longPath(reportFilePath(filepath.Join(reporter.dir, name)))
+}
+
+func (reporter *AllureReporter) write(name string, document interface{}) {
+	data, err := json.Marshal(document)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\nFailed to generate Allure result:\n\t%s", err.Error())
+		return
+	}
+	err = ioutil.WriteFile(reporter.path(name), data, 0666)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\nFailed to write Allure result: %s\n\t%s", name, err.Error())
+	}
+}
+
+//allureSteps turns the nodes that ran as part of the spec into steps, and nests the steps announced with By in the node that announced them
+func allureSteps(specSummary *types.SpecSummary) []*AllureStep {
+	_, specStop := allureSpan(specSummary.StartTime, specSummary.RunTime)
+
+	steps := []*AllureStep{}
+	byIndex := 0
+	for _, nodeSummary := range specSummary.NodeSummaries {
+		start, stop := allureSpan(nodeSummary.StartTime, nodeSummary.RunTime)
+		step := &AllureStep{
+			Name:          fmt.Sprintf("%s (%s)", nodeSummary.ComponentType, nodeSummary.CodeLocation),
+			Status:        allureStatus(nodeSummary.State),
+			StatusDetails: allureStatusDetails(nodeSummary.State, nodeSummary.Failure),
+			Stage:         "finished",
+			Start:         start,
+			Stop:          stop,
+			Steps:         []*AllureStep{},
+			Attachments:   []AllureAttachment{},
+		}
+		nodeEnd := nodeSummary.StartTime.Add(nodeSummary.RunTime)
+		for byIndex < len(specSummary.Steps) && !specSummary.Steps[byIndex].StartTime.After(nodeEnd) {
+			step.Steps = append(step.Steps, allureByStep(specSummary.Steps, byIndex, stop))
+			byIndex++
+		}
+		//the last By step of a failed node is the one that failed
+		if len(step.Steps) > 0 && nodeSummary.State != types.SpecStatePassed {
+			last := step.Steps[len(step.Steps)-1]
+			last.Status, last.StatusDetails = step.Status, step.StatusDetails
+		}
+		steps = append(steps, step)
+	}
+	for ; byIndex < len(specSummary.Steps); byIndex++ {
+		steps = append(steps, allureByStep(specSummary.Steps, byIndex, specStop))
+	}
+	return steps
+}
+
+func allureByStep(steps []*types.StepSummary, index int, stop int64) *AllureStep {
+	if index+1 < len(steps) {
+		if next := allureTime(steps[index+1].StartTime); next < stop {
+			stop = next
+		}
+	}
+	return &AllureStep{
+		Name:        steps[index].Text,
+		Status:      "passed",
+		Stage:       "finished",
+		Start:       allureTime(steps[index].StartTime),
+		Stop:        stop,
+		Steps:       []*AllureStep{},
+		Attachments: []AllureAttachment{},
+	}
+}
+
+func allureFixture(name string, setupSummary *types.SetupSummary) *AllureStep {
+	start, stop := allureSpan(setupSummary.StartTime, setupSummary.RunTime)
+	fixture := &AllureStep{
+		Name:          name,
+		Status:        allureStatus(setupSummary.State),
+		StatusDetails: allureStatusDetails(setupSummary.State, setupSummary.Failure),
+		Stage:         "finished",
+		Start:         start,
+		Stop:          stop,
+		Steps:         []*AllureStep{},
+		Attachments:   []AllureAttachment{},
+	}
+	return fixture
+}
+
+func allureStatus(state types.SpecState) string {
+	switch state {
+	case types.SpecStatePassed:
+		return "passed"
+	case types.SpecStateFailed:
+		return "failed"
+	case types.SpecStatePending, types.SpecStateSkipped:
+		return "skipped"
+	default:
+		return "broken"
+	}
+}
+
+func allureStatusDetails(state types.SpecState, failure types.SpecFailure) *AllureStatusDetails {
+	if !state.IsFailure() {
+		return nil
+	}
+	trace := failure.Location.String()
+	if failure.ForwardedPanic != "" {
+		trace += "\n" + failure.ForwardedPanic
+	}
+	if failure.Location.FullStackTrace != "" {
+		trace += "\n" + failure.Location.FullStackTrace
+	}
+	return &AllureStatusDetails{Message: failure.Message, Trace: trace}
+}
+
+func allureSpan(startTime time.Time, runTime time.Duration) (int64, int64) {
+	if startTime.IsZero() {
+		startTime = clock.Now().Add(-runTime)
+	}
+	return allureTime(startTime), allureTime(startTime.Add(runTime))
+}
+
+//allureTime converts t to the milliseconds since the epoch used by Allure
+func allureTime(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+func newAllureUUID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
@@ -0,0 +1,206 @@
+package reporters_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/config"
+	"github.com/onsi/ginkgo/reporters"
+	"github.com/onsi/ginkgo/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Allure Reporter", func() {
+	var (
+		dir      string
+		reporter *reporters.AllureReporter
+		start    time.Time
+	)
+
+	readResults := func() (map[string]reporters.AllureResult, reporters.AllureContainer) {
+		results := map[string]reporters.AllureResult{}
+		var container reporters.AllureContainer
+		files, err := ioutil.ReadDir(dir)
+		Ω(err).ShouldNot(HaveOccurred())
+		for _, file := range files {
+			if !strings.HasSuffix(file.Name(), ".json") || strings.Contains(file.Name(), "-attachment.") {
+				continue
+			}
+			data, err := ioutil.ReadFile(filepath.Join(dir, file.Name()))
+			Ω(err).ShouldNot(HaveOccurred())
+			if strings.HasSuffix(file.Name(), "-container.json") {
+				Ω(json.Unmarshal(data, &container)).Should(Succeed())
+			} else {
+				var result reporters.AllureResult
+				Ω(json.Unmarshal(data, &result)).Should(Succeed())
+				Ω(file.Name()).Should(Equal(result.UUID + "-result.json"))
+				results[result.Name] = result
+			}
+		}
+		return results, container
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "allure")
+		Ω(err).ShouldNot(HaveOccurred())
+		reporter = reporters.NewAllureReporter(filepath.Join(dir, "allure-results"))
+		dir = filepath.Join(dir, "allure-results")
+		start = time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+
+		reporter.SpecSuiteWillBegin(config.GinkgoConfigType{}, &types.SuiteSummary{SuiteDescription: "My test suite", SuiteLabels: []string{"nightly"}})
+		reporter.BeforeSuiteDidRun(&types.SetupSummary{State: types.SpecStatePassed, StartTime: start, RunTime: time.Second})
+
+		passing := &types.SpecSummary{
+			ComponentTexts: []string{"[Top Level]", "Suite", "Context", "passes"},
+			Labels:         []string{"fast", "storage"},
+			State:          types.SpecStatePassed,
+			StartTime:      start.Add(time.Second),
+			RunTime:        3 * time.Second,
+			NodeSummaries: []*types.NodeSummary{
+				{ComponentType: types.SpecComponentTypeBeforeEach, CodeLocation: types.CodeLocation{FileName: "suite_test.go", LineNumber: 5}, State: types.SpecStatePassed, StartTime: start.Add(time.Second), RunTime: time.Second},
+				{ComponentType: types.SpecComponentTypeIt, CodeLocation: types.CodeLocation{FileName: "suite_test.go", LineNumber: 9}, State: types.SpecStatePassed, StartTime: start.Add(2 * time.Second), RunTime: 2 * time.Second},
+			},
+			Steps: []*types.StepSummary{
+				{Text: "preparing", StartTime: start.Add(1500 * time.Millisecond)},
+				{Text: "acting", StartTime: start.Add(2500 * time.Millisecond)},
+				{Text: "asserting", StartTime: start.Add(3 * time.Second)},
+			},
+			Measurements: map[string]*types.SpecMeasurement{
+				"runtime": {Name: "runtime", Results: []float64{1, 2}},
+			},
+		}
+		failing := &types.SpecSummary{
+			ComponentTexts: []string{"[Top Level]", "Suite", "fails"},
+			State:          types.SpecStateFailed,
+			StartTime:      start.Add(4 * time.Second),
+			RunTime:        time.Second,
+			Failure: types.SpecFailure{
+				Message:  "boom",
+				Location: types.CodeLocation{FileName: "suite_test.go", LineNumber: 20},
+			},
+			NodeSummaries: []*types.NodeSummary{
+				{ComponentType: types.SpecComponentTypeIt, CodeLocation: types.CodeLocation{FileName: "suite_test.go", LineNumber: 18}, State: types.SpecStateFailed, StartTime: start.Add(4 * time.Second), RunTime: time.Second, Failure: types.SpecFailure{Message: "boom"}},
+			},
+			Steps: []*types.StepSummary{
+				{Text: "doing something", StartTime: start.Add(4 * time.Second)},
+				{Text: "doing something else", StartTime: start.Add(4500 * time.Millisecond)},
+			},
+			CapturedOutput: "some output",
+		}
+		panicking := &types.SpecSummary{
+			ComponentTexts: []string{"[Top Level]", "Suite", "panics"},
+			State:          types.SpecStatePanicked,
+			Failure:        types.SpecFailure{Message: "Test Panicked", ForwardedPanic: "oops"},
+		}
+		pending := &types.SpecSummary{
+			ComponentTexts: []string{"[Top Level]", "Suite", "is pending"},
+			State:          types.SpecStatePending,
+		}
+		for _, specSummary := range []*types.SpecSummary{passing, failing, panicking, pending} {
+			reporter.SpecWillRun(specSummary)
+			reporter.SpecDidComplete(specSummary)
+		}
+
+		reporter.AfterSuiteDidRun(&types.SetupSummary{State: types.SpecStateFailed, StartTime: start.Add(5 * time.Second), RunTime: time.Second, Failure: types.SpecFailure{Message: "cleanup failed"}})
+		reporter.SpecSuiteDidEnd(&types.SuiteSummary{SuiteMetadata: map[string]string{"gitSHA": "abc123", "url": "http://ci:8080/job"}})
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(filepath.Dir(dir))
+	})
+
+	It("should write a result per spec", func() {
+		results, _ := readResults()
+		Ω(results).Should(HaveLen(4))
+
+		passing := results["passes"]
+		Ω(passing.FullName).Should(Equal("Suite Context passes"))
+		Ω(passing.HistoryID).Should(HaveLen(32))
+		Ω(passing.Status).Should(Equal("passed"))
+		Ω(passing.StatusDetails).Should(BeNil())
+		Ω(passing.Stage).Should(Equal("finished"))
+		Ω(passing.Start).Should(Equal(start.Add(time.Second).UnixNano() / int64(time.Millisecond)))
+		Ω(passing.Stop).Should(Equal(start.Add(4*time.Second).UnixNano() / int64(time.Millisecond)))
+
+		Ω(results["fails"].Status).Should(Equal("failed"))
+		Ω(results["fails"].StatusDetails.Message).Should(Equal("boom"))
+		Ω(results["fails"].StatusDetails.Trace).Should(Equal("suite_test.go:20"))
+		Ω(results["panics"].Status).Should(Equal("broken"))
+		Ω(results["panics"].StatusDetails.Trace).Should(ContainSubstring("oops"))
+		Ω(results["is pending"].Status).Should(Equal("skipped"))
+	})
+
+	It("should map the suite, containers and spec labels to Allure labels", func() {
+		results, _ := readResults()
+		Ω(results["passes"].Labels).Should(Equal([]reporters.AllureLabel{
+			{Name: "framework", Value: "ginkgo"},
+			{Name: "language", Value: "go"},
+			{Name: "suite", Value: "My test suite"},
+			{Name: "subSuite", Value: "Suite Context"},
+			{Name: "tag", Value: "nightly"},
+			{Name: "tag", Value: "fast"},
+			{Name: "tag", Value: "storage"},
+		}))
+		Ω(results["fails"].Labels).Should(ContainElement(reporters.AllureLabel{Name: "subSuite", Value: "Suite"}))
+	})
+
+	It("should write the suite metadata as the Allure environment", func() {
+		Ω(ioutil.ReadFile(filepath.Join(dir, "environment.properties"))).Should(Equal([]byte("gitSHA=abc123\nurl=http\\://ci\\:8080/job\n")))
+	})
+
+	It("should nest the By steps within the nodes that ran", func() {
+		results, _ := readResults()
+		steps := results["passes"].Steps
+		Ω(steps).Should(HaveLen(2))
+		Ω(steps[0].Name).Should(Equal("BeforeEach (suite_test.go:5)"))
+		Ω(steps[0].Steps).Should(HaveLen(1))
+		Ω(steps[0].Steps[0].Name).Should(Equal("preparing"))
+		Ω(steps[0].Steps[0].Stop).Should(Equal(steps[0].Stop))
+		Ω(steps[1].Name).Should(Equal("It (suite_test.go:9)"))
+		Ω(steps[1].Steps).Should(HaveLen(2))
+		Ω(steps[1].Steps[0].Name).Should(Equal("acting"))
+		Ω(steps[1].Steps[0].Stop).Should(Equal(steps[1].Steps[1].Start))
+		Ω(steps[1].Steps[1].Name).Should(Equal("asserting"))
+		Ω(steps[1].Steps[1].Stop).Should(Equal(steps[1].Stop))
+
+		failingSteps := results["fails"].Steps[0].Steps
+		Ω(failingSteps).Should(HaveLen(2))
+		Ω(failingSteps[0].Status).Should(Equal("passed"))
+		Ω(failingSteps[1].Status).Should(Equal("failed"))
+		Ω(failingSteps[1].StatusDetails.Message).Should(Equal("boom"))
+	})
+
+	It("should attach the captured output and measurements", func() {
+		results, _ := readResults()
+		Ω(results["fails"].Attachments).Should(HaveLen(1))
+		attachment := results["fails"].Attachments[0]
+		Ω(attachment.Name).Should(Equal("Captured output"))
+		Ω(attachment.Type).Should(Equal("text/plain"))
+		Ω(ioutil.ReadFile(filepath.Join(dir, attachment.Source))).Should(Equal([]byte("some output")))
+
+		Ω(results["passes"].Attachments).Should(HaveLen(1))
+		attachment = results["passes"].Attachments[0]
+		Ω(attachment.Name).Should(Equal("Measurements"))
+		Ω(attachment.Type).Should(Equal("application/json"))
+		Ω(ioutil.ReadFile(filepath.Join(dir, attachment.Source))).Should(ContainSubstring(`"runtime"`))
+	})
+
+	It("should write a container for the suite holding BeforeSuite and AfterSuite", func() {
+		results, container := readResults()
+		Ω(container.Name).Should(Equal("My test suite"))
+		Ω(container.Children).Should(ConsistOf(results["passes"].UUID, results["fails"].UUID, results["panics"].UUID, results["is pending"].UUID))
+		Ω(container.Befores).Should(HaveLen(1))
+		Ω(container.Befores[0].Name).Should(Equal("BeforeSuite"))
+		Ω(container.Befores[0].Status).Should(Equal("passed"))
+		Ω(container.Afters).Should(HaveLen(1))
+		Ω(container.Afters[0].Name).Should(Equal("AfterSuite"))
+		Ω(container.Afters[0].Status).Should(Equal("failed"))
+		Ω(container.Afters[0].StatusDetails.Message).Should(Equal("cleanup failed"))
+	})
+})
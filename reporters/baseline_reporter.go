@@ -0,0 +1,213 @@
+/*
+
+Baseline Reporter for Ginkgo
+
+The baseline reporter compares the current suite run against a JSON report generated by a previous run:
+
+	ginkgo -jsonReport=baseline.json
+	ginkgo -baselineReport=baseline.json -baselineDiffFile=diff.json
+
+At the end of the run it summarizes regressions (specs that are newly failing), fixes (specs that are newly passing) and
+specs whose run time changed by more than -baselineDurationThreshold seconds.  The same information is written to the diff file as JSON.
+
+*/
+
+package reporters
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/onsi/ginkgo/config"
+	"github.com/onsi/ginkgo/formatter"
+	"github.com/onsi/ginkgo/types"
+)
+
+//BaselineSpecChange describes how a single spec differs from its baseline
+type BaselineSpecChange struct {
+	Spec         string
+	CodeLocation types.CodeLocation
+
+	BaselineState   types.SpecState
+	State           types.SpecState
+	BaselineRunTime time.Duration
+	RunTime         time.Duration
+	Failure         types.SpecFailure
+}
+
+//RunTimeDelta returns how much slower (positive) or faster (negative) the spec ran compared to the baseline
+func (c BaselineSpecChange) RunTimeDelta() time.Duration {
+	return c.RunTime - c.BaselineRunTime
+}
+
+//BaselineDiff is the document written by the BaselineReporter to its diff file
+type BaselineDiff struct {
+	BaselineReportFile string
+	DurationThreshold  time.Duration
+
+	Regressions     []BaselineSpecChange
+	Fixes           []BaselineSpecChange
+	DurationChanges []BaselineSpecChange
+}
+
+type BaselineReporter struct {
+	baselineFile      string
+	diffFile          string
+	durationThreshold time.Duration
+	writer            io.Writer
+	specSummaries     []*types.SpecSummary
+	ReporterConfig    config.DefaultReporterConfigType
+	hasReporterConfig bool
+}
+
+//NewBaselineReporter creates a new reporter that compares the run against the JSON report stored in baselineFile.
+//The summary is printed to writer and, if diffFile is not empty, the BaselineDiff is written there as JSON.
+func NewBaselineReporter(writer io.Writer, baselineFile string, diffFile string, durationThreshold time.Duration) *BaselineReporter {
+	return &BaselineReporter{
+		baselineFile:      baselineFile,
+		diffFile:          diffFile,
+		durationThreshold: durationThreshold,
+		writer:            writer,
+	}
+}
+
+//SetReporterConfig gives the reporter its own configuration rather than the one of the flags
+func (reporter *BaselineReporter) SetReporterConfig(reporterConfig config.DefaultReporterConfigType) {
+	reporter.ReporterConfig = reporterConfig
+	reporter.hasReporterConfig = true
+}
+
+func (reporter *BaselineReporter) SpecSuiteWillBegin(ginkgoConfig config.GinkgoConfigType, summary *types.SuiteSummary) {
+	reporter.specSummaries = []*types.SpecSummary{}
+	if !reporter.hasReporterConfig {
+		reporter.ReporterConfig = config.DefaultReporterConfig
+	}
+}
+
+func (reporter *BaselineReporter) BeforeSuiteDidRun(setupSummary *types.SetupSummary) {
+}
+
+func (reporter *BaselineReporter) SpecWillRun(specSummary *types.SpecSummary) {
+}
+
+func (reporter *BaselineReporter) SpecDidComplete(specSummary *types.SpecSummary) {
+	reporter.specSummaries = append(reporter.specSummaries, specSummary)
+}
+
+func (reporter *BaselineReporter) AfterSuiteDidRun(setupSummary *types.SetupSummary) {
+}
+
+func (reporter *BaselineReporter) SpecSuiteDidEnd(summary *types.SuiteSummary) {
+	f := formatter.NewWithNoColorBool(reporter.ReporterConfig.NoColor)
+
+	baseline, err := ReadJSONReport(reporter.baselineFile)
+	if err != nil {
+		fmt.Fprint(reporter.writer, f.F("\n{{orange}}Failed to read baseline report %s:{{/}}\n\t%s\n", reporter.baselineFile, err.Error()))
+		return
+	}
+
+	diff := CompareToBaseline(baseline, reporter.specSummaries, reporter.durationThreshold)
+	diff.BaselineReportFile = reporter.baselineFile
+	reporter.printSummary(f, diff)
+
+	if reporter.diffFile != "" {
+		reporter.writeDiffFile(diff)
+	}
+}
+
+//CompareToBaseline computes the BaselineDiff between a baseline report and the spec summaries of the current run.
+//A durationThreshold of zero disables the detection of duration changes.
+func CompareToBaseline(baseline JSONReport, specSummaries []*types.SpecSummary, durationThreshold time.Duration) BaselineDiff {
+	diff := BaselineDiff{
+		DurationThreshold: durationThreshold,
+		Regressions:       []BaselineSpecChange{},
+		Fixes:             []BaselineSpecChange{},
+		DurationChanges:   []BaselineSpecChange{},
+	}
+
+	baselineSpecs := map[string]*types.SpecSummary{}
+	for _, baselineSpec := range baseline.SpecSummaries {
+		baselineSpecs[SpecFullText(baselineSpec)] = baselineSpec
+	}
+
+	for _, specSummary := range specSummaries {
+		change := BaselineSpecChange{
+			Spec:    SpecFullText(specSummary),
+			State:   specSummary.State,
+			RunTime: specSummary.RunTime,
+			Failure: specSummary.Failure,
+		}
+		if len(specSummary.ComponentCodeLocations) > 0 {
+			change.CodeLocation = specSummary.ComponentCodeLocations[len(specSummary.ComponentCodeLocations)-1]
+		}
+
+		baselineSpec, inBaseline := baselineSpecs[change.Spec]
+		if inBaseline {
+			change.BaselineState = baselineSpec.State
+			change.BaselineRunTime = baselineSpec.RunTime
+		}
+
+		switch {
+		case specSummary.HasFailureState() && !change.BaselineState.IsFailure():
+			diff.Regressions = append(diff.Regressions, change)
+		case specSummary.Passed() && change.BaselineState.IsFailure():
+			diff.Fixes = append(diff.Fixes, change)
+		case specSummary.Passed() && change.BaselineState == types.SpecStatePassed && durationThreshold > 0:
+			delta := change.RunTimeDelta()
+			if delta >= durationThreshold || -delta >= durationThreshold {
+				diff.DurationChanges = append(diff.DurationChanges, change)
+			}
+		}
+	}
+
+	return diff
+}
+
+func (reporter *BaselineReporter) printSummary(f formatter.Formatter, diff BaselineDiff) {
+	out := f.F("\n{{bold}}Comparison with baseline %s{{/}}\n", diff.BaselineReportFile)
+	if len(diff.Regressions)+len(diff.Fixes)+len(diff.DurationChanges) == 0 {
+		out += f.Fi(1, "{{green}}No changes compared to the baseline{{/}}\n")
+	}
+
+	if len(diff.Regressions) > 0 {
+		out += f.Fi(1, "{{red}}{{bold}}%d Newly Failing:{{/}}\n", len(diff.Regressions))
+		for _, change := range diff.Regressions {
+			out += f.Fi(2, "{{red}}%s{{/}}\n", change.Spec)
+			out += f.Fi(2, "{{gray}}%s{{/}}\n", change.CodeLocation)
+		}
+	}
+
+	if len(diff.Fixes) > 0 {
+		out += f.Fi(1, "{{green}}{{bold}}%d Newly Passing:{{/}}\n", len(diff.Fixes))
+		for _, change := range diff.Fixes {
+			out += f.Fi(2, "{{green}}%s{{/}}\n", change.Spec)
+			out += f.Fi(2, "{{gray}}%s{{/}}\n", change.CodeLocation)
+		}
+	}
+
+	if len(diff.DurationChanges) > 0 {
+		out += f.Fi(1, "{{yellow}}{{bold}}%d Run Time Changes (threshold %s):{{/}}\n", len(diff.DurationChanges), diff.DurationThreshold)
+		for _, change := range diff.DurationChanges {
+			out += f.Fi(2, "{{yellow}}%s{{/}} %s -> %s (%+.3fs)\n", change.Spec, change.BaselineRunTime, change.RunTime, change.RunTimeDelta().Seconds())
+			out += f.Fi(2, "{{gray}}%s{{/}}\n", change.CodeLocation)
+		}
+	}
+
+	fmt.Fprint(reporter.writer, out)
+}
+
+func (reporter *BaselineReporter) writeDiffFile(diff BaselineDiff) {
+	data, err := json.MarshalIndent(diff, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\nFailed to generate baseline diff data:\n\t%s", err.Error())
+		return
+	}
+
+	err = writeReportFile(reporter.diffFile, data)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\nFailed to create baseline diff file: %s\n\t%s", reportFilePath(reporter.diffFile), err.Error())
+	}
+}
@@ -0,0 +1,163 @@
+package reporters_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/config"
+	"github.com/onsi/ginkgo/reporters"
+	"github.com/onsi/ginkgo/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Baseline Reporter", func() {
+	var (
+		dir          string
+		baselineFile string
+		diffFile     string
+		buffer       *bytes.Buffer
+		reporter     *reporters.BaselineReporter
+	)
+
+	spec := func(text string, state types.SpecState, runTime time.Duration) *types.SpecSummary {
+		return &types.SpecSummary{
+			ComponentTexts:         []string{"[Top Level]", "Suite", text},
+			ComponentCodeLocations: []types.CodeLocation{{}, {}, {FileName: "file_test.go", LineNumber: 10}},
+			State:                  state,
+			RunTime:                runTime,
+		}
+	}
+
+	baseline := reporters.JSONReport{
+		SpecSummaries: []*types.SpecSummary{
+			spec("still passes", types.SpecStatePassed, time.Second),
+			spec("regresses", types.SpecStatePassed, time.Second),
+			spec("gets fixed", types.SpecStateFailed, time.Second),
+			spec("gets slower", types.SpecStatePassed, time.Second),
+			spec("gets faster", types.SpecStatePassed, 3*time.Second),
+			spec("still fails", types.SpecStatePanicked, time.Second),
+		},
+	}
+
+	current := func() []*types.SpecSummary {
+		return []*types.SpecSummary{
+			spec("still passes", types.SpecStatePassed, 1200*time.Millisecond),
+			spec("regresses", types.SpecStateFailed, time.Second),
+			spec("gets fixed", types.SpecStatePassed, time.Second),
+			spec("gets slower", types.SpecStatePassed, 3*time.Second),
+			spec("gets faster", types.SpecStatePassed, time.Second),
+			spec("still fails", types.SpecStateFailed, time.Second),
+			spec("is new and fails", types.SpecStateTimedOut, time.Second),
+			spec("is new and passes", types.SpecStatePassed, time.Second),
+		}
+	}
+
+	specs := func(changes []reporters.BaselineSpecChange) []string {
+		texts := []string{}
+		for _, change := range changes {
+			texts = append(texts, change.Spec)
+		}
+		return texts
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "baseline-reporter")
+		Expect(err).ToNot(HaveOccurred())
+		baselineFile = filepath.Join(dir, "baseline.json")
+		diffFile = filepath.Join(dir, "diff", "diff.json")
+		buffer = &bytes.Buffer{}
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	Describe("CompareToBaseline", func() {
+		It("should find regressions, fixes and duration changes", func() {
+			diff := reporters.CompareToBaseline(baseline, current(), time.Second)
+
+			Expect(specs(diff.Regressions)).To(Equal([]string{"Suite regresses", "Suite is new and fails"}))
+			Expect(specs(diff.Fixes)).To(Equal([]string{"Suite gets fixed"}))
+			Expect(specs(diff.DurationChanges)).To(Equal([]string{"Suite gets slower", "Suite gets faster"}))
+
+			Expect(diff.Regressions[0].BaselineState).To(Equal(types.SpecStatePassed))
+			Expect(diff.Regressions[0].State).To(Equal(types.SpecStateFailed))
+			Expect(diff.Regressions[0].CodeLocation.LineNumber).To(Equal(10))
+			Expect(diff.DurationChanges[0].RunTimeDelta()).To(Equal(2 * time.Second))
+			Expect(diff.DurationChanges[1].RunTimeDelta()).To(Equal(-2 * time.Second))
+		})
+
+		It("should not report duration changes when the threshold is zero", func() {
+			diff := reporters.CompareToBaseline(baseline, current(), 0)
+			Expect(diff.DurationChanges).To(BeEmpty())
+		})
+	})
+
+	Describe("running the reporter", func() {
+		run := func(specSummaries []*types.SpecSummary) {
+			reporter = reporters.NewBaselineReporter(buffer, baselineFile, diffFile, time.Second)
+			reporter.SpecSuiteWillBegin(config.GinkgoConfigType{}, &types.SuiteSummary{})
+			reporter.ReporterConfig.NoColor = true
+			for _, specSummary := range specSummaries {
+				reporter.SpecWillRun(specSummary)
+				reporter.SpecDidComplete(specSummary)
+			}
+			reporter.SpecSuiteDidEnd(&types.SuiteSummary{})
+		}
+
+		Context("when the baseline report exists", func() {
+			BeforeEach(func() {
+				data, err := json.Marshal(baseline)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(ioutil.WriteFile(baselineFile, data, 0666)).To(Succeed())
+			})
+
+			It("should print a summary of the changes", func() {
+				run(current())
+				Expect(buffer.String()).To(ContainSubstring("Comparison with baseline " + baselineFile))
+				Expect(buffer.String()).To(ContainSubstring("2 Newly Failing:"))
+				Expect(buffer.String()).To(ContainSubstring("Suite regresses"))
+				Expect(buffer.String()).To(ContainSubstring("1 Newly Passing:"))
+				Expect(buffer.String()).To(ContainSubstring("Suite gets fixed"))
+				Expect(buffer.String()).To(ContainSubstring("2 Run Time Changes (threshold 1s):"))
+				Expect(buffer.String()).To(ContainSubstring("Suite gets slower 1s -> 3s (+2.000s)"))
+				Expect(buffer.String()).To(ContainSubstring("file_test.go:10"))
+				Expect(buffer.String()).ToNot(ContainSubstring("\x1b["))
+			})
+
+			It("should write the diff file", func() {
+				run(current())
+				data, err := ioutil.ReadFile(diffFile)
+				Expect(err).ToNot(HaveOccurred())
+
+				var diff reporters.BaselineDiff
+				Expect(json.Unmarshal(data, &diff)).To(Succeed())
+				Expect(diff.BaselineReportFile).To(Equal(baselineFile))
+				Expect(diff.DurationThreshold).To(Equal(time.Second))
+				Expect(specs(diff.Regressions)).To(Equal([]string{"Suite regresses", "Suite is new and fails"}))
+				Expect(specs(diff.Fixes)).To(Equal([]string{"Suite gets fixed"}))
+				Expect(specs(diff.DurationChanges)).To(Equal([]string{"Suite gets slower", "Suite gets faster"}))
+			})
+
+			It("should say so when nothing changed", func() {
+				run(baseline.SpecSummaries)
+				Expect(buffer.String()).To(ContainSubstring("No changes compared to the baseline"))
+			})
+		})
+
+		Context("when the baseline report is missing", func() {
+			It("should print a warning and not write a diff file", func() {
+				run(current())
+				Expect(buffer.String()).To(ContainSubstring("Failed to read baseline report " + baselineFile))
+				_, err := os.Stat(diffFile)
+				Expect(os.IsNotExist(err)).To(BeTrue())
+			})
+		})
+	})
+})
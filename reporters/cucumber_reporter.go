@@ -0,0 +1,357 @@
+/*
+
+Cucumber Reporter for Ginkgo
+
+The Cucumber reporter writes the results of a suite run in the Cucumber JSON format, for BDD-oriented reporting tools:
+
+	ginkgo -cucumberReport=cucumber.json
+
+Top level containers become features, tagged with the suite labels, and specs become scenarios, tagged with their
+labels.  The steps announced with Given, WhenStep and Then become the steps of the scenarios, as do those announced with By,
+which use the * keyword.  A spec that announced no step is reported as a single step named after it.  The BeforeEach and
+AfterEach nodes that ran around a spec become the before and after hooks of its scenario, and the output captured while the
+spec ran is attached to its last step.  BeforeSuite and AfterSuite are only reported when they fail, as scenarios of a
+feature named after the suite.
+
+The report can be ingested by tools that consume Cucumber JSON, such as Cucumber Reports or Xray.
+
+*/
+
+package reporters
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/onsi/ginkgo/config"
+	"github.com/onsi/ginkgo/types"
+)
+
+type CucumberFeature struct {
+	URI         string              `json:"uri"`
+	ID          string              `json:"id"`
+	Keyword     string              `json:"keyword"`
+	Name        string              `json:"name"`
+	Description string              `json:"description"`
+	Line        int                 `json:"line"`
+	Tags        []CucumberTag       `json:"tags"`
+	Elements    []*CucumberScenario `json:"elements"`
+}
+
+type CucumberScenario struct {
+	ID          string         `json:"id"`
+	Keyword     string         `json:"keyword"`
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Line        int            `json:"line"`
+	Type        string         `json:"type"`
+	Tags        []CucumberTag  `json:"tags"`
+	Before      []CucumberHook `json:"before,omitempty"`
+	Steps       []CucumberStep `json:"steps"`
+	After       []CucumberHook `json:"after,omitempty"`
+}
+
+type CucumberTag struct {
+	Name string `json:"name"`
+	Line int    `json:"line"`
+}
+
+type CucumberStep struct {
+	Keyword string         `json:"keyword"`
+	Name    string         `json:"name"`
+	Line    int            `json:"line"`
+	Match   CucumberMatch  `json:"match"`
+	Result  CucumberResult `json:"result"`
+	Output  []string       `json:"output,omitempty"`
+}
+
+type CucumberHook struct {
+	Match  CucumberMatch  `json:"match"`
+	Result CucumberResult `json:"result"`
+}
+
+type CucumberMatch struct {
+	Location string `json:"location"`
+}
+
+type CucumberResult struct {
+	Status       string `json:"status"`
+	Duration     int64  `json:"duration,omitempty"`
+	ErrorMessage string `json:"error_message,omitempty"`
+}
+
+type CucumberReporter struct {
+	filename string
+
+	suiteDescription string
+	suiteLabels      []string
+	features         []*CucumberFeature
+	scenarios        map[string]cucumberScenarioIndex
+}
+
+type cucumberScenarioIndex struct {
+	feature *CucumberFeature
+	index   int
+}
+
+//NewCucumberReporter creates a new Cucumber reporter.  The report will be stored in the passed in filename.
+func NewCucumberReporter(filename string) *CucumberReporter {
+	return &CucumberReporter{
+		filename: filename,
+	}
+}
+
+func (reporter *CucumberReporter) SpecSuiteWillBegin(ginkgoConfig config.GinkgoConfigType, summary *types.SuiteSummary) {
+	reporter.suiteDescription = summary.SuiteDescription
+	reporter.suiteLabels = summary.SuiteLabels
+	reporter.features = []*CucumberFeature{}
+	reporter.scenarios = map[string]cucumberScenarioIndex{}
+}
+
+func (reporter *CucumberReporter) BeforeSuiteDidRun(setupSummary *types.SetupSummary) {
+	reporter.handleSetupSummary("BeforeSuite", setupSummary)
+}
+
+func (reporter *CucumberReporter) SpecWillRun(specSummary *types.SpecSummary) {
+}
+
+func (reporter *CucumberReporter) SpecDidComplete(specSummary *types.SpecSummary) {
+	texts, locations := specSummary.TextsToDisplay(), specSummary.ComponentCodeLocations
+	location := locations[len(locations)-1]
+
+	featureName, featureLocation, scenarioName := filepath.Base(location.FileName), location, texts[len(texts)-1]
+	if len(texts) > 2 {
+		featureName, featureLocation, scenarioName = texts[1], locations[1], strings.Join(texts[2:], " ")
+	}
+
+	tags := []CucumberTag{}
+	for _, label := range specSummary.Labels {
+		tags = append(tags, CucumberTag{Name: "@" + label, Line: location.LineNumber})
+	}
+
+	steps := cucumberSteps(specSummary)
+	if specSummary.CapturedOutput != "" {
+		steps[len(steps)-1].Output = []string{specSummary.CapturedOutput}
+	}
+
+	feature := reporter.feature(featureName, featureLocation)
+	reporter.record(feature, &CucumberScenario{
+		ID:      feature.ID + ";" + cucumberID(scenarioName),
+		Keyword: "Scenario",
+		Name:    scenarioName,
+		Line:    location.LineNumber,
+		Type:    "scenario",
+		Tags:    tags,
+		Before:  cucumberHooks(specSummary, cucumberBeforeHooks),
+		Steps:   steps,
+		After:   cucumberHooks(specSummary, cucumberAfterHooks),
+	})
+}
+
+func (reporter *CucumberReporter) AfterSuiteDidRun(setupSummary *types.SetupSummary) {
+	reporter.handleSetupSummary("AfterSuite", setupSummary)
+}
+
+func (reporter *CucumberReporter) SpecSuiteDidEnd(summary *types.SuiteSummary) {
+	data, err := json.MarshalIndent(reporter.features, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\nFailed to generate Cucumber report data:\n\t%s", err.Error())
+		return
+	}
+
+	err = writeReportFile(reporter.filename, data)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\nFailed to write Cucumber report file: %s\n\t%s", reportFilePath(reporter.filename), err.Error())
+	}
+}
+
+func (reporter *CucumberReporter) handleSetupSummary(name string, setupSummary *types.SetupSummary) {
+	if setupSummary.State == types.SpecStatePassed {
+		return
+	}
+	feature := reporter.feature(reporter.suiteDescription, setupSummary.CodeLocation)
+	reporter.record(feature, &CucumberScenario{
+		ID:      feature.ID + ";" + cucumberID(name),
+		Keyword: "Scenario",
+		Name:    name,
+		Line:    setupSummary.CodeLocation.LineNumber,
+		Type:    "scenario",
+		Tags:    []CucumberTag{},
+		Steps: []CucumberStep{{
+			Keyword: "* ",
+			Name:    name,
+			Line:    setupSummary.CodeLocation.LineNumber,
+			Match:   CucumberMatch{Location: setupSummary.CodeLocation.String()},
+			Result: CucumberResult{
+				Status:       cucumberStatus(setupSummary.State),
+				Duration:     setupSummary.RunTime.Nanoseconds(),
+				ErrorMessage: cucumberErrorMessage(setupSummary.Failure),
+			},
+		}},
+	})
+}
+
+//feature returns the feature with the given name, adding it to the report if need be
+func (reporter *CucumberReporter) feature(name string, location types.CodeLocation) *CucumberFeature {
+	for _, feature := range reporter.features {
+		if feature.Name == name {
+			return feature
+		}
+	}
+	feature := &CucumberFeature{
+		URI:      location.FileName,
+		ID:       cucumberID(name),
+		Keyword:  "Feature",
+		Name:     name,
+		Line:     location.LineNumber,
+		Tags:     []CucumberTag{},
+		Elements: []*CucumberScenario{},
+	}
+	for _, label := range reporter.suiteLabels {
+		feature.Tags = append(feature.Tags, CucumberTag{Name: "@" + label, Line: location.LineNumber})
+	}
+	reporter.features = append(reporter.features, feature)
+	return feature
+}
+
+//record adds scenario to feature.  When a spec is retried (see -flakeAttempts) only its last attempt is kept.
+func (reporter *CucumberReporter) record(feature *CucumberFeature, scenario *CucumberScenario) {
+	key := fmt.Sprintf("%s\x00%d", scenario.ID, scenario.Line)
+	if existing, ok := reporter.scenarios[key]; ok {
+		existing.feature.Elements[existing.index] = scenario
+		return
+	}
+	reporter.scenarios[key] = cucumberScenarioIndex{feature: feature, index: len(feature.Elements)}
+	feature.Elements = append(feature.Elements, scenario)
+}
+
+//cucumberBeforeHooks and cucumberAfterHooks are the types of the nodes reported as the hooks of scenarios
+var cucumberBeforeHooks = []types.SpecComponentType{types.SpecComponentTypeBeforeEach, types.SpecComponentTypeJustBeforeEach}
+var cucumberAfterHooks = []types.SpecComponentType{types.SpecComponentTypeJustAfterEach, types.SpecComponentTypeAfterEach, types.SpecComponentTypeDeferCleanup, types.SpecComponentTypeOnFailure}
+
+//cucumberHooks turns the nodes of the given types that ran as part of the spec into Cucumber hooks
+func cucumberHooks(specSummary *types.SpecSummary, componentTypes []types.SpecComponentType) []CucumberHook {
+	hooks := []CucumberHook{}
+	for _, nodeSummary := range specSummary.NodeSummaries {
+		for _, componentType := range componentTypes {
+			if nodeSummary.ComponentType == componentType {
+				hooks = append(hooks, CucumberHook{
+					Match:  CucumberMatch{Location: nodeSummary.CodeLocation.String()},
+					Result: cucumberResult(nodeSummary.State, nodeSummary.RunTime, nodeSummary.Failure),
+				})
+			}
+		}
+	}
+	return hooks
+}
+
+//cucumberFailureIn tells whether the spec failed in a node of one of the given types
+func cucumberFailureIn(specSummary *types.SpecSummary, componentTypes []types.SpecComponentType) bool {
+	if !specSummary.State.IsFailure() {
+		return false
+	}
+	for _, componentType := range componentTypes {
+		if specSummary.Failure.ComponentType == componentType {
+			return true
+		}
+	}
+	return false
+}
+
+//cucumberSteps turns the steps of the spec into Cucumber steps.  The state of the steps that were not handed a callback
+//is unknown, so they are reported as passed.  When the spec failed in its subject but none of its steps did, the last step
+//carries the failure of the spec.  The steps that follow a failed step are skipped.
+func cucumberSteps(specSummary *types.SpecSummary) []CucumberStep {
+	location := specSummary.ComponentCodeLocations[len(specSummary.ComponentCodeLocations)-1]
+	if len(specSummary.Steps) == 0 {
+		result := cucumberResult(specSummary.State, specSummary.RunTime, specSummary.Failure)
+		//when a hook failed, the hook carries the failure
+		if cucumberFailureIn(specSummary, cucumberBeforeHooks) {
+			result = CucumberResult{Status: "skipped"}
+		} else if cucumberFailureIn(specSummary, cucumberAfterHooks) {
+			result = CucumberResult{Status: "passed", Duration: specSummary.RunTime.Nanoseconds()}
+		}
+		texts := specSummary.TextsToDisplay()
+		return []CucumberStep{{
+			Keyword: "* ",
+			Name:    texts[len(texts)-1],
+			Line:    location.LineNumber,
+			Match:   CucumberMatch{Location: location.String()},
+			Result:  result,
+		}}
+	}
+
+	steps := []CucumberStep{}
+	failedStep := -1
+	for i, step := range specSummary.Steps {
+		keyword := "* "
+		if step.Keyword != "" {
+			keyword = step.Keyword + " "
+		}
+		result := CucumberResult{Status: "passed"}
+		if step.State != types.SpecStateInvalid {
+			result = cucumberResult(step.State, step.RunTime, specSummary.Failure)
+		}
+		if failedStep >= 0 {
+			result = CucumberResult{Status: "skipped"}
+		} else if step.State.IsFailure() {
+			failedStep = i
+		}
+		steps = append(steps, CucumberStep{
+			Keyword: keyword,
+			Name:    step.Text,
+			Line:    step.CodeLocation.LineNumber,
+			Match:   CucumberMatch{Location: step.CodeLocation.String()},
+			Result:  result,
+		})
+	}
+
+	inHook := cucumberFailureIn(specSummary, cucumberBeforeHooks) || cucumberFailureIn(specSummary, cucumberAfterHooks)
+	if specSummary.State.IsFailure() && failedStep < 0 && !inHook {
+		last := &steps[len(steps)-1]
+		last.Result.Status = cucumberStatus(specSummary.State)
+		last.Result.ErrorMessage = cucumberErrorMessage(specSummary.Failure)
+	}
+	return steps
+}
+
+func cucumberResult(state types.SpecState, runTime time.Duration, failure types.SpecFailure) CucumberResult {
+	result := CucumberResult{
+		Status:   cucumberStatus(state),
+		Duration: runTime.Nanoseconds(),
+	}
+	if state.IsFailure() {
+		result.ErrorMessage = cucumberErrorMessage(failure)
+	}
+	return result
+}
+
+func cucumberStatus(state types.SpecState) string {
+	switch state {
+	case types.SpecStatePassed:
+		return "passed"
+	case types.SpecStatePending:
+		return "pending"
+	case types.SpecStateSkipped:
+		return "skipped"
+	default:
+		return "failed"
+	}
+}
+
+func cucumberErrorMessage(failure types.SpecFailure) string {
+	message := failureMessage(failure)
+	if failure.ForwardedPanic != "" {
+		message += "\n" + failure.ForwardedPanic
+	}
+	return message
+}
+
+//cucumberID derives the id of a feature or a scenario from its name, as Cucumber does
+func cucumberID(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), "-"))
+}
@@ -0,0 +1,243 @@
+package reporters_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/config"
+	"github.com/onsi/ginkgo/reporters"
+	"github.com/onsi/ginkgo/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cucumber Reporter", func() {
+	var (
+		dir        string
+		outputFile string
+		reporter   *reporters.CucumberReporter
+	)
+
+	stepLocation := func(line int) types.CodeLocation {
+		return types.CodeLocation{FileName: "/src/account_test.go", LineNumber: line}
+	}
+
+	summary := func(texts []string, state types.SpecState, steps ...*types.StepSummary) *types.SpecSummary {
+		locations := []types.CodeLocation{{}}
+		for i := 1; i < len(texts); i++ {
+			locations = append(locations, stepLocation(i*10))
+		}
+		return &types.SpecSummary{
+			ComponentTexts:         texts,
+			ComponentCodeLocations: locations,
+			State:                  state,
+			RunTime:                time.Second,
+			Steps:                  steps,
+		}
+	}
+
+	readOutputFile := func() []reporters.CucumberFeature {
+		data, err := ioutil.ReadFile(outputFile)
+		Ω(err).ShouldNot(HaveOccurred())
+		var features []reporters.CucumberFeature
+		Ω(json.Unmarshal(data, &features)).Should(Succeed())
+		return features
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "cucumber")
+		Ω(err).ShouldNot(HaveOccurred())
+		outputFile = filepath.Join(dir, "nested", "cucumber.json")
+		reporter = reporters.NewCucumberReporter(outputFile)
+		reporter.SpecSuiteWillBegin(config.GinkgoConfigType{}, &types.SuiteSummary{SuiteDescription: "My test suite"})
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	Context("with specs announcing steps", func() {
+		BeforeEach(func() {
+			reporter.BeforeSuiteDidRun(&types.SetupSummary{State: types.SpecStatePassed})
+
+			passing := summary([]string{"[Top Level]", "Account", "withdrawing", "updates the balance"}, types.SpecStatePassed,
+				&types.StepSummary{Keyword: "Given", Text: "an account", CodeLocation: stepLocation(31), State: types.SpecStatePassed, RunTime: time.Millisecond},
+				&types.StepSummary{Text: "logging in", CodeLocation: stepLocation(32)},
+				&types.StepSummary{Keyword: "Then", Text: "the balance is updated", CodeLocation: stepLocation(33), State: types.SpecStatePassed, RunTime: 2 * time.Millisecond},
+			)
+			passing.Labels = []string{"banking"}
+
+			failingStep := summary([]string{"[Top Level]", "Account", "overdrawing", "is refused"}, types.SpecStateFailed,
+				&types.StepSummary{Keyword: "Given", Text: "an empty account", CodeLocation: stepLocation(31), State: types.SpecStatePassed},
+				&types.StepSummary{Keyword: "When", Text: "the customer withdraws", CodeLocation: stepLocation(32), State: types.SpecStateFailed, RunTime: time.Millisecond},
+				&types.StepSummary{Keyword: "Then", Text: "the withdrawal is refused", CodeLocation: stepLocation(33)},
+			)
+			failingStep.Failure = types.SpecFailure{Message: "boom", Location: stepLocation(32), ComponentCodeLocation: stepLocation(30)}
+
+			failingSpec := summary([]string{"[Top Level]", "Account", "closes"}, types.SpecStateFailed,
+				&types.StepSummary{Text: "closing the account", CodeLocation: stepLocation(21)},
+			)
+			failingSpec.Failure = types.SpecFailure{Message: "still open", Location: stepLocation(22), ComponentCodeLocation: stepLocation(20)}
+
+			for _, specSummary := range []*types.SpecSummary{
+				passing,
+				failingStep,
+				failingSpec,
+				summary([]string{"[Top Level]", "is top level"}, types.SpecStatePassed),
+				summary([]string{"[Top Level]", "Account", "is pending"}, types.SpecStatePending),
+			} {
+				reporter.SpecWillRun(specSummary)
+				reporter.SpecDidComplete(specSummary)
+			}
+
+			reporter.AfterSuiteDidRun(&types.SetupSummary{
+				State:        types.SpecStateFailed,
+				CodeLocation: types.CodeLocation{FileName: "/src/suite_test.go", LineNumber: 12},
+				Failure:      types.SpecFailure{Message: "cleanup failed"},
+			})
+			reporter.SpecSuiteDidEnd(&types.SuiteSummary{})
+		})
+
+		It("should turn top level containers into features and specs into scenarios", func() {
+			features := readOutputFile()
+			Ω(features).Should(HaveLen(3))
+
+			Ω(features[0].Keyword).Should(Equal("Feature"))
+			Ω(features[0].Name).Should(Equal("Account"))
+			Ω(features[0].ID).Should(Equal("account"))
+			Ω(features[0].URI).Should(Equal("/src/account_test.go"))
+			Ω(features[0].Line).Should(Equal(10))
+			Ω(features[0].Elements).Should(HaveLen(4))
+
+			scenario := features[0].Elements[0]
+			Ω(scenario.Keyword).Should(Equal("Scenario"))
+			Ω(scenario.Type).Should(Equal("scenario"))
+			Ω(scenario.Name).Should(Equal("withdrawing updates the balance"))
+			Ω(scenario.ID).Should(Equal("account;withdrawing-updates-the-balance"))
+			Ω(scenario.Line).Should(Equal(30))
+			Ω(scenario.Tags).Should(Equal([]reporters.CucumberTag{{Name: "@banking", Line: 30}}))
+
+			Ω(features[1].Name).Should(Equal("account_test.go"))
+			Ω(features[1].Elements[0].Name).Should(Equal("is top level"))
+
+			Ω(features[2].Name).Should(Equal("My test suite"))
+			Ω(features[2].Elements[0].Name).Should(Equal("AfterSuite"))
+			Ω(features[2].Elements[0].Steps[0].Result.Status).Should(Equal("failed"))
+		})
+
+		It("should report the steps with their keyword, state and run time", func() {
+			steps := readOutputFile()[0].Elements[0].Steps
+			Ω(steps).Should(HaveLen(3))
+			Ω(steps[0]).Should(Equal(reporters.CucumberStep{
+				Keyword: "Given ",
+				Name:    "an account",
+				Line:    31,
+				Match:   reporters.CucumberMatch{Location: "/src/account_test.go:31"},
+				Result:  reporters.CucumberResult{Status: "passed", Duration: int64(time.Millisecond)},
+			}))
+			Ω(steps[1].Keyword).Should(Equal("* "))
+			Ω(steps[1].Result).Should(Equal(reporters.CucumberResult{Status: "passed"}))
+			Ω(steps[2].Keyword).Should(Equal("Then "))
+			Ω(steps[2].Result.Duration).Should(Equal(int64(2 * time.Millisecond)))
+		})
+
+		It("should report the failed step and skip the steps that follow it", func() {
+			steps := readOutputFile()[0].Elements[1].Steps
+			Ω(steps[0].Result.Status).Should(Equal("passed"))
+			Ω(steps[1].Result.Status).Should(Equal("failed"))
+			Ω(steps[1].Result.ErrorMessage).Should(Equal("/src/account_test.go:30\nboom\n/src/account_test.go:32"))
+			Ω(steps[2].Result).Should(Equal(reporters.CucumberResult{Status: "skipped"}))
+		})
+
+		It("should report the failure of a spec on its last step when none of its steps failed", func() {
+			steps := readOutputFile()[0].Elements[2].Steps
+			Ω(steps).Should(HaveLen(1))
+			Ω(steps[0].Name).Should(Equal("closing the account"))
+			Ω(steps[0].Result.Status).Should(Equal("failed"))
+			Ω(steps[0].Result.ErrorMessage).Should(ContainSubstring("still open"))
+		})
+
+		It("should report specs without steps as a single step", func() {
+			features := readOutputFile()
+			Ω(features[1].Elements[0].Steps).Should(Equal([]reporters.CucumberStep{{
+				Keyword: "* ",
+				Name:    "is top level",
+				Line:    10,
+				Match:   reporters.CucumberMatch{Location: "/src/account_test.go:10"},
+				Result:  reporters.CucumberResult{Status: "passed", Duration: int64(time.Second)},
+			}}))
+
+			pending := features[0].Elements[3]
+			Ω(pending.Name).Should(Equal("is pending"))
+			Ω(pending.Steps[0].Result.Status).Should(Equal("pending"))
+		})
+	})
+
+	Context("with BeforeEach and AfterEach nodes", func() {
+		var specSummary *types.SpecSummary
+
+		BeforeEach(func() {
+			reporter.SpecSuiteWillBegin(config.GinkgoConfigType{}, &types.SuiteSummary{SuiteDescription: "My test suite", SuiteLabels: []string{"TEST-42"}})
+			specSummary = summary([]string{"[Top Level]", "Account", "opens"}, types.SpecStatePassed)
+			specSummary.NodeSummaries = []*types.NodeSummary{
+				{ComponentType: types.SpecComponentTypeBeforeEach, CodeLocation: stepLocation(12), State: types.SpecStatePassed, RunTime: time.Millisecond},
+				{ComponentType: types.SpecComponentTypeIt, CodeLocation: stepLocation(20), State: types.SpecStatePassed},
+				{ComponentType: types.SpecComponentTypeAfterEach, CodeLocation: stepLocation(14), State: types.SpecStatePassed},
+			}
+			specSummary.CapturedOutput = "opening the account"
+		})
+
+		It("should report them as hooks, tag the features with the suite labels and attach the captured output to the last step", func() {
+			reporter.SpecDidComplete(specSummary)
+			reporter.SpecSuiteDidEnd(&types.SuiteSummary{})
+
+			feature := readOutputFile()[0]
+			Ω(feature.Tags).Should(Equal([]reporters.CucumberTag{{Name: "@TEST-42", Line: 10}}))
+
+			scenario := feature.Elements[0]
+			Ω(scenario.Before).Should(Equal([]reporters.CucumberHook{{
+				Match:  reporters.CucumberMatch{Location: "/src/account_test.go:12"},
+				Result: reporters.CucumberResult{Status: "passed", Duration: int64(time.Millisecond)},
+			}}))
+			Ω(scenario.After).Should(HaveLen(1))
+			Ω(scenario.After[0].Match.Location).Should(Equal("/src/account_test.go:14"))
+			Ω(scenario.Steps[0].Output).Should(Equal([]string{"opening the account"}))
+		})
+
+		It("should report a failure in a hook on the hook rather than on the steps", func() {
+			specSummary.State = types.SpecStateFailed
+			specSummary.Failure = types.SpecFailure{Message: "setup failed", ComponentType: types.SpecComponentTypeBeforeEach}
+			specSummary.NodeSummaries = []*types.NodeSummary{
+				{ComponentType: types.SpecComponentTypeBeforeEach, CodeLocation: stepLocation(12), State: types.SpecStateFailed, Failure: specSummary.Failure},
+			}
+			reporter.SpecDidComplete(specSummary)
+			reporter.SpecSuiteDidEnd(&types.SuiteSummary{})
+
+			scenario := readOutputFile()[0].Elements[0]
+			Ω(scenario.Before[0].Result.Status).Should(Equal("failed"))
+			Ω(scenario.Before[0].Result.ErrorMessage).Should(ContainSubstring("setup failed"))
+			Ω(scenario.Steps[0].Result).Should(Equal(reporters.CucumberResult{Status: "skipped"}))
+			Ω(scenario.After).Should(BeEmpty())
+		})
+	})
+
+	Context("when a spec is retried", func() {
+		It("should only keep its last attempt", func() {
+			for _, specSummary := range []*types.SpecSummary{
+				summary([]string{"[Top Level]", "Account", "flakes"}, types.SpecStateFailed),
+				summary([]string{"[Top Level]", "Account", "flakes"}, types.SpecStatePassed),
+			} {
+				reporter.SpecDidComplete(specSummary)
+			}
+			reporter.SpecSuiteDidEnd(&types.SuiteSummary{})
+
+			features := readOutputFile()
+			Ω(features[0].Elements).Should(HaveLen(1))
+			Ω(features[0].Elements[0].Steps[0].Result.Status).Should(Equal("passed"))
+		})
+	})
+})
@@ -26,8 +26,23 @@ func NewDefaultReporter(config config.DefaultReporterConfigType, stenographer st
 	}
 }
 
+//SetReporterConfig replaces the configuration the reporter was created with.  Whether the console output is colored is
+//up to its stenographer.
+func (reporter *DefaultReporter) SetReporterConfig(reporterConfig config.DefaultReporterConfigType) {
+	reporter.config = reporterConfig
+}
+
 func (reporter *DefaultReporter) SpecSuiteWillBegin(config config.GinkgoConfigType, summary *types.SuiteSummary) {
+	if reporter.config.Quiet {
+		if len(summary.Warnings) > 0 {
+			reporter.stenographer.AnnounceWarnings(summary.Warnings)
+		}
+		return
+	}
 	reporter.stenographer.AnnounceSuite(summary.SuiteDescription, config.RandomSeed, config.RandomizeAllSpecs, reporter.config.Succinct)
+	if len(summary.Warnings) > 0 {
+		reporter.stenographer.AnnounceWarnings(summary.Warnings)
+	}
 	if config.ParallelTotal > 1 {
 		reporter.stenographer.AnnounceParallelRun(config.ParallelNode, config.ParallelTotal, reporter.config.Succinct)
 	} else {
@@ -48,16 +63,25 @@ func (reporter *DefaultReporter) AfterSuiteDidRun(setupSummary *types.SetupSumma
 }
 
 func (reporter *DefaultReporter) SpecWillRun(specSummary *types.SpecSummary) {
-	if reporter.config.Verbose && !reporter.config.Succinct && specSummary.State != types.SpecStatePending && specSummary.State != types.SpecStateSkipped {
+	if reporter.config.Verbose && !reporter.config.Succinct && !reporter.config.Quiet && specSummary.State != types.SpecStatePending && specSummary.State != types.SpecStateSkipped {
 		reporter.stenographer.AnnounceSpecWillRun(specSummary)
 	}
 }
 
 func (reporter *DefaultReporter) SpecDidComplete(specSummary *types.SpecSummary) {
+	reporter.specSummaries = append(reporter.specSummaries, specSummary)
+	if reporter.config.Quiet && !specSummary.State.IsFailure() {
+		return
+	}
+
 	switch specSummary.State {
 	case types.SpecStatePassed:
-		if specSummary.IsMeasurement {
+		if specSummary.ExpectedFailure != nil {
+			reporter.stenographer.AnnounceExpectedFailure(specSummary, reporter.config.Succinct)
+		} else if specSummary.IsMeasurement {
 			reporter.stenographer.AnnounceSuccessfulMeasurement(specSummary, reporter.config.Succinct)
+		} else if specSummary.RuntimeBudgetViolation != nil {
+			reporter.stenographer.AnnounceSpecOverRuntimeBudget(specSummary, reporter.config.Succinct)
 		} else if specSummary.RunTime.Seconds() >= reporter.config.SlowSpecThreshold {
 			reporter.stenographer.AnnounceSuccessfulSlowSpec(specSummary, reporter.config.Succinct)
 		} else {
@@ -77,11 +101,9 @@ func (reporter *DefaultReporter) SpecDidComplete(specSummary *types.SpecSummary)
 	case types.SpecStateFailed:
 		reporter.stenographer.AnnounceSpecFailed(specSummary, reporter.config.Succinct, reporter.config.FullTrace)
 	}
-
-	reporter.specSummaries = append(reporter.specSummaries, specSummary)
 }
 
 func (reporter *DefaultReporter) SpecSuiteDidEnd(summary *types.SuiteSummary) {
-	reporter.stenographer.SummarizeFailures(reporter.specSummaries)
+	reporter.stenographer.SummarizeFailures(reporter.specSummaries, reporter.config.GroupFailures)
 	reporter.stenographer.AnnounceSpecRunCompletion(summary, reporter.config.Succinct)
 }
@@ -81,6 +81,36 @@ var _ = Describe("DefaultReporter", func() {
 				Ω(stenographer.Calls()[1]).Should(Equal(call("AnnounceParallelRun", 1, 2, false)))
 			})
 		})
+
+		Context("when the suite summary carries warnings", func() {
+			BeforeEach(func() {
+				ginkgoConfig.ParallelTotal = 1
+				suite.Warnings = []string{"known_failures.txt:4: skip list entry expired"}
+
+				reporter.SpecSuiteWillBegin(ginkgoConfig, suite)
+			})
+
+			It("should announce the warnings right after the suite", func() {
+				Ω(stenographer.Calls()).Should(HaveLen(3))
+				Ω(stenographer.Calls()[1]).Should(Equal(call("AnnounceWarnings", []string{"known_failures.txt:4: skip list entry expired"})))
+			})
+		})
+
+		Context("in quiet mode", func() {
+			BeforeEach(func() {
+				ginkgoConfig.ParallelTotal = 1
+				suite.Warnings = []string{"known_failures.txt:4: skip list entry expired"}
+				reporterConfig.Quiet = true
+				reporter = reporters.NewDefaultReporter(reporterConfig, stenographer)
+
+				reporter.SpecSuiteWillBegin(ginkgoConfig, suite)
+			})
+
+			It("should only announce the warnings", func() {
+				Ω(stenographer.Calls()).Should(HaveLen(1))
+				Ω(stenographer.Calls()[0]).Should(Equal(call("AnnounceWarnings", []string{"known_failures.txt:4: skip list entry expired"})))
+			})
+		})
 	})
 
 	Describe("BeforeSuiteDidRun", func() {
@@ -235,6 +265,28 @@ var _ = Describe("DefaultReporter", func() {
 				})
 			})
 
+			Context("When the spec ran longer than its runtime budget", func() {
+				BeforeEach(func() {
+					spec.RunTime = time.Second
+					spec.RuntimeBudgetViolation = &types.RuntimeBudgetViolation{Label: "unit", Budget: 100 * time.Millisecond, RunTime: time.Second}
+				})
+
+				It("should announce that it was over budget", func() {
+					Ω(stenographer.Calls()[0]).Should(Equal(call("AnnounceSpecOverRuntimeBudget", spec, false)))
+				})
+			})
+
+			Context("When the spec failed as expected", func() {
+				BeforeEach(func() {
+					spec.ExpectedFailureReason = "ISSUE-123"
+					spec.ExpectedFailure = &types.SpecFailure{Message: "boom"}
+				})
+
+				It("should announce the expected failure", func() {
+					Ω(stenographer.Calls()[0]).Should(Equal(call("AnnounceExpectedFailure", spec, false)))
+				})
+			})
+
 			Context("When the spec is successful", func() {
 				It("should announce the successful spec", func() {
 					Ω(stenographer.Calls()[0]).Should(Equal(call("AnnounceSuccessfulSpec", spec)))
@@ -304,6 +356,44 @@ var _ = Describe("DefaultReporter", func() {
 			})
 		})
 
+		Context("in quiet mode", func() {
+			BeforeEach(func() {
+				reporterConfig.Quiet = true
+				reporter = reporters.NewDefaultReporter(reporterConfig, stenographer)
+			})
+
+			Context("When the spec passed", func() {
+				BeforeEach(func() {
+					spec.State = types.SpecStatePassed
+					spec.RunTime = time.Second
+				})
+
+				It("should announce nothing", func() {
+					Ω(stenographer.Calls()).Should(BeEmpty())
+				})
+			})
+
+			Context("When the spec is pending", func() {
+				BeforeEach(func() {
+					spec.State = types.SpecStatePending
+				})
+
+				It("should announce nothing", func() {
+					Ω(stenographer.Calls()).Should(BeEmpty())
+				})
+			})
+
+			Context("When the spec failed", func() {
+				BeforeEach(func() {
+					spec.State = types.SpecStateFailed
+				})
+
+				It("should announce the failure", func() {
+					Ω(stenographer.Calls()[0]).Should(Equal(call("AnnounceSpecFailed", spec, false, true)))
+				})
+			})
+		})
+
 		Context("in noisy pendings mode", func() {
 			BeforeEach(func() {
 				reporterConfig.Succinct = false
@@ -451,5 +541,27 @@ var _ = Describe("DefaultReporter", func() {
 		It("should announce the spec run's completion", func() {
 			Ω(stenographer.Calls()[1]).Should(Equal(call("AnnounceSpecRunCompletion", suite, false)))
 		})
+
+		It("should summarize the failures without grouping them by fingerprint", func() {
+			Ω(stenographer.Calls()).Should(HaveLen(2))
+			Ω(stenographer.Calls()[0].Method).Should(Equal("SummarizeFailures"))
+			Ω(stenographer.Calls()[0].Args[1]).Should(BeFalse())
+		})
+
+		Context("when the GroupFailures flag is set", func() {
+			BeforeEach(func() {
+				stenographer.Reset()
+				reporterConfig.GroupFailures = true
+				reporter = reporters.NewDefaultReporter(reporterConfig, stenographer)
+				reporter.SpecSuiteDidEnd(suite)
+			})
+
+			It("should summarize the failures once, grouped by fingerprint, before announcing the spec run's completion", func() {
+				Ω(stenographer.Calls()).Should(HaveLen(2))
+				Ω(stenographer.Calls()[0].Method).Should(Equal("SummarizeFailures"))
+				Ω(stenographer.Calls()[0].Args[1]).Should(BeTrue())
+				Ω(stenographer.Calls()[1]).Should(Equal(call("AnnounceSpecRunCompletion", suite, false)))
+			})
+		})
 	})
 })
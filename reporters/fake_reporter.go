@@ -7,7 +7,8 @@ import (
 
 //FakeReporter is useful for testing purposes
 type FakeReporter struct {
-	Config config.GinkgoConfigType
+	Config         config.GinkgoConfigType
+	ReporterConfig config.DefaultReporterConfigType
 
 	BeginSummary         *types.SuiteSummary
 	BeforeSuiteSummary   *types.SetupSummary
@@ -27,6 +28,10 @@ func NewFakeReporter() *FakeReporter {
 	}
 }
 
+func (fakeR *FakeReporter) SetReporterConfig(reporterConfig config.DefaultReporterConfigType) {
+	fakeR.ReporterConfig = reporterConfig
+}
+
 func (fakeR *FakeReporter) SpecSuiteWillBegin(config config.GinkgoConfigType, summary *types.SuiteSummary) {
 	fakeR.Config = config
 	fakeR.BeginSummary = summary
@@ -0,0 +1,240 @@
+/*
+
+Run History for Ginkgo
+
+With -historyDir, every suite run stores its JSON report (see -jsonReport) in a directory of its own:
+
+	ginkgo -historyDir=.ginkgo-history
+
+`ginkgo history` then reads the reports back to show, for each suite, how its pass rate evolved over the last runs, which
+specs became flaky and which specs got slower.
+
+*/
+
+package reporters
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/onsi/ginkgo/types"
+)
+
+//HistoryReportFile returns the name of a new file under dir to store the JSON report of a suite run in, see -historyDir
+func HistoryReportFile(dir string) string {
+	return filepath.Join(dir, fmt.Sprintf("%s-%d.json", time.Now().UTC().Format("20060102T150405.000000000"), os.Getpid()))
+}
+
+//ReadHistory loads the JSON reports stored in dir by -historyDir, oldest first
+func ReadHistory(dir string) ([]JSONReport, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	reports := []JSONReport{}
+	for _, file := range files {
+		report, err := ReadJSONReport(file)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to read %s: %s", file, err.Error())
+		}
+		reports = append(reports, report)
+	}
+	sort.SliceStable(reports, func(i, j int) bool {
+		return reports[i].StartTime.Before(reports[j].StartTime)
+	})
+	return reports, nil
+}
+
+//HistoryRun summarizes a single run of a suite
+type HistoryRun struct {
+	StartTime      time.Time
+	RunTime        time.Duration
+	SuiteSucceeded bool
+
+	//Passed, Failed and Flaked count the specs that ran by their final outcome; flaked specs passed after failing
+	Passed int
+	Failed int
+	Flaked int
+}
+
+//PassRate returns the share of the specs that ran which passed, between 0 and 1.  It is 1 when no spec ran.
+func (run HistoryRun) PassRate() float64 {
+	if run.Passed+run.Failed == 0 {
+		return 1
+	}
+	return float64(run.Passed) / float64(run.Passed+run.Failed)
+}
+
+//HistoryDurationRegression describes a spec that ran slower in the latest run than on average in the runs before it
+type HistoryDurationRegression struct {
+	Spec            string
+	CodeLocation    types.CodeLocation
+	PreviousRunTime time.Duration
+	RunTime         time.Duration
+}
+
+//SuiteHistory is the trend of a suite over its last runs, as computed by AnalyzeHistory
+type SuiteHistory struct {
+	SuiteDescription string
+	//Runs lists the last runs of the suite, oldest first
+	Runs []HistoryRun
+
+	//NewlyFlakySpecs lists the specs that flaked, or both passed and failed, over the last runs but not in the runs before
+	NewlyFlakySpecs []string
+	//DurationRegressions lists the specs whose run time in the latest run exceeds their average run time over the previous
+	//runs by more than the duration threshold
+	DurationRegressions []HistoryDurationRegression
+}
+
+//specOutcome is the final outcome of a spec in a run, along with whether it failed before passing
+type specOutcome struct {
+	passed       bool
+	flaked       bool
+	runTime      time.Duration
+	codeLocation types.CodeLocation
+}
+
+//specOutcomes returns the final outcome of each spec that ran in report, keyed by the spec's full text
+func specOutcomes(report JSONReport) map[string]specOutcome {
+	outcomes := map[string]specOutcome{}
+	for _, summary := range report.SpecSummaries {
+		if summary.Skipped() || summary.Pending() {
+			continue
+		}
+		spec := SpecFullText(summary)
+		previous, ranBefore := outcomes[spec]
+		outcome := specOutcome{
+			passed:  summary.Passed(),
+			flaked:  summary.Passed() && ranBefore && !previous.passed,
+			runTime: summary.RunTime,
+		}
+		if len(summary.ComponentCodeLocations) > 0 {
+			outcome.codeLocation = summary.ComponentCodeLocations[len(summary.ComponentCodeLocations)-1]
+		}
+		outcomes[spec] = outcome
+	}
+	return outcomes
+}
+
+//flakySpecs returns the specs that flaked in one of runs, or passed in some of runs and failed in others
+func flakySpecs(runs []map[string]specOutcome) map[string]bool {
+	passed, failed, flaky := map[string]bool{}, map[string]bool{}, map[string]bool{}
+	for _, outcomes := range runs {
+		for spec, outcome := range outcomes {
+			if outcome.flaked {
+				flaky[spec] = true
+			}
+			if outcome.passed {
+				passed[spec] = true
+			} else {
+				failed[spec] = true
+			}
+		}
+	}
+	for spec := range passed {
+		if failed[spec] {
+			flaky[spec] = true
+		}
+	}
+	return flaky
+}
+
+//AnalyzeHistory groups reports (oldest first, as returned by ReadHistory) by suite and computes the trend of each suite over
+//its last lastRuns runs.  Specs that ran slower by more than durationThreshold are reported as duration regressions; a
+//durationThreshold of zero disables their detection.  Suites are listed in the order they first appear in reports.
+func AnalyzeHistory(reports []JSONReport, lastRuns int, durationThreshold time.Duration) []SuiteHistory {
+	suites := []string{}
+	reportsBySuite := map[string][]JSONReport{}
+	for _, report := range reports {
+		if _, ok := reportsBySuite[report.SuiteDescription]; !ok {
+			suites = append(suites, report.SuiteDescription)
+		}
+		reportsBySuite[report.SuiteDescription] = append(reportsBySuite[report.SuiteDescription], report)
+	}
+
+	histories := []SuiteHistory{}
+	for _, suite := range suites {
+		histories = append(histories, analyzeSuiteHistory(suite, reportsBySuite[suite], lastRuns, durationThreshold))
+	}
+	return histories
+}
+
+func analyzeSuiteHistory(suite string, reports []JSONReport, lastRuns int, durationThreshold time.Duration) SuiteHistory {
+	outcomes := []map[string]specOutcome{}
+	for _, report := range reports {
+		outcomes = append(outcomes, specOutcomes(report))
+	}
+	first := 0
+	if lastRuns > 0 && len(reports) > lastRuns {
+		first = len(reports) - lastRuns
+	}
+
+	history := SuiteHistory{
+		SuiteDescription:    suite,
+		Runs:                []HistoryRun{},
+		NewlyFlakySpecs:     []string{},
+		DurationRegressions: []HistoryDurationRegression{},
+	}
+	for i := first; i < len(reports); i++ {
+		run := HistoryRun{
+			StartTime:      reports[i].StartTime,
+			RunTime:        reports[i].RunTime,
+			SuiteSucceeded: reports[i].SuiteSucceeded,
+		}
+		for _, outcome := range outcomes[i] {
+			if outcome.passed {
+				run.Passed++
+			} else {
+				run.Failed++
+			}
+			if outcome.flaked {
+				run.Flaked++
+			}
+		}
+		history.Runs = append(history.Runs, run)
+	}
+
+	flakyBefore := flakySpecs(outcomes[:first])
+	for spec := range flakySpecs(outcomes[first:]) {
+		if !flakyBefore[spec] {
+			history.NewlyFlakySpecs = append(history.NewlyFlakySpecs, spec)
+		}
+	}
+	sort.Strings(history.NewlyFlakySpecs)
+
+	if durationThreshold > 0 && len(outcomes)-first > 1 {
+		latest := outcomes[len(outcomes)-1]
+		for spec, outcome := range latest {
+			if !outcome.passed {
+				continue
+			}
+			total, count := time.Duration(0), 0
+			for _, previous := range outcomes[first : len(outcomes)-1] {
+				if previousOutcome, ok := previous[spec]; ok && previousOutcome.passed {
+					total += previousOutcome.runTime
+					count++
+				}
+			}
+			if count == 0 {
+				continue
+			}
+			average := total / time.Duration(count)
+			if outcome.runTime-average > durationThreshold {
+				history.DurationRegressions = append(history.DurationRegressions, HistoryDurationRegression{
+					Spec:            spec,
+					CodeLocation:    outcome.codeLocation,
+					PreviousRunTime: average,
+					RunTime:         outcome.runTime,
+				})
+			}
+		}
+		sort.Slice(history.DurationRegressions, func(i, j int) bool {
+			return history.DurationRegressions[i].Spec < history.DurationRegressions[j].Spec
+		})
+	}
+
+	return history
+}
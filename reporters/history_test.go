@@ -0,0 +1,145 @@
+package reporters_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/reporters"
+	"github.com/onsi/ginkgo/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Run History", func() {
+	start := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	summary := func(text string, state types.SpecState, runTime time.Duration) *types.SpecSummary {
+		return &types.SpecSummary{
+			ComponentTexts:         []string{"[Top Level]", "Suite", text},
+			ComponentCodeLocations: []types.CodeLocation{{}, {}, {FileName: "suite_test.go", LineNumber: 12}},
+			State:                  state,
+			RunTime:                runTime,
+		}
+	}
+
+	run := func(day int, suite string, specSummaries ...*types.SpecSummary) reporters.JSONReport {
+		return reporters.JSONReport{
+			SuiteDescription: suite,
+			StartTime:        start.AddDate(0, 0, day),
+			SpecSummaries:    specSummaries,
+		}
+	}
+
+	Describe("reading the history", func() {
+		var dir string
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "history")
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		It("should load the reports stored in the directory, oldest first", func() {
+			for _, day := range []int{2, 0, 1} {
+				data, err := json.Marshal(run(day, "Suite"))
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(ioutil.WriteFile(reporters.HistoryReportFile(dir), data, 0666)).Should(Succeed())
+			}
+
+			reports, err := reporters.ReadHistory(dir)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(reports).Should(HaveLen(3))
+			for i, report := range reports {
+				Ω(report.StartTime.Equal(start.AddDate(0, 0, i))).Should(BeTrue())
+			}
+		})
+
+		It("should return no reports when the directory does not exist", func() {
+			reports, err := reporters.ReadHistory(filepath.Join(dir, "missing"))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(reports).Should(BeEmpty())
+		})
+	})
+
+	Describe("analyzing the history", func() {
+		var reports []reporters.JSONReport
+
+		BeforeEach(func() {
+			reports = []reporters.JSONReport{
+				run(0, "Suite",
+					summary("was always flaky", types.SpecStateFailed, time.Second),
+					summary("is stable", types.SpecStatePassed, time.Second),
+				),
+				run(1, "Suite",
+					summary("was always flaky", types.SpecStatePassed, time.Second),
+					summary("is stable", types.SpecStatePassed, time.Second),
+					summary("flips", types.SpecStatePassed, time.Second),
+					summary("gets slower", types.SpecStatePassed, time.Second),
+				),
+				run(2, "Other Suite", summary("is elsewhere", types.SpecStatePassed, time.Second)),
+				run(3, "Suite",
+					summary("was always flaky", types.SpecStateFailed, time.Second),
+					summary("is stable", types.SpecStatePassed, time.Second),
+					summary("flips", types.SpecStateFailed, time.Second),
+					summary("gets slower", types.SpecStatePassed, 3*time.Second),
+					summary("is pending", types.SpecStatePending, 0),
+				),
+				run(4, "Suite",
+					summary("was always flaky", types.SpecStatePassed, time.Second),
+					summary("is stable", types.SpecStatePassed, 1500*time.Millisecond),
+					summary("flips", types.SpecStatePassed, time.Second),
+					summary("retries", types.SpecStateFailed, time.Second),
+					summary("retries", types.SpecStatePassed, time.Second),
+					summary("gets slower", types.SpecStatePassed, 5*time.Second),
+				),
+			}
+		})
+
+		It("should group the runs by suite and summarize the last ones", func() {
+			histories := reporters.AnalyzeHistory(reports, 3, time.Second)
+			Ω(histories).Should(HaveLen(2))
+			Ω(histories[0].SuiteDescription).Should(Equal("Suite"))
+			Ω(histories[1].SuiteDescription).Should(Equal("Other Suite"))
+
+			runs := histories[0].Runs
+			Ω(runs).Should(HaveLen(3))
+			Ω(runs[0].StartTime.Equal(start.AddDate(0, 0, 1))).Should(BeTrue())
+			Ω(runs[0].Passed).Should(Equal(4))
+			Ω(runs[0].PassRate()).Should(Equal(1.0))
+			Ω(runs[1].Passed).Should(Equal(2))
+			Ω(runs[1].Failed).Should(Equal(2))
+			Ω(runs[1].PassRate()).Should(Equal(0.5))
+			Ω(runs[2].Passed).Should(Equal(5))
+			Ω(runs[2].Flaked).Should(Equal(1))
+		})
+
+		It("should only report the specs that became flaky over the last runs", func() {
+			histories := reporters.AnalyzeHistory(reports, 2, time.Second)
+			Ω(histories[0].NewlyFlakySpecs).Should(Equal([]string{"Suite flips", "Suite retries"}))
+
+			histories = reporters.AnalyzeHistory(reports, 0, time.Second)
+			Ω(histories[0].Runs).Should(HaveLen(4))
+			Ω(histories[0].NewlyFlakySpecs).Should(Equal([]string{"Suite flips", "Suite retries", "Suite was always flaky"}))
+		})
+
+		It("should report the specs that ran slower than on average by more than the threshold", func() {
+			histories := reporters.AnalyzeHistory(reports, 3, time.Second)
+			Ω(histories[0].DurationRegressions).Should(Equal([]reporters.HistoryDurationRegression{{
+				Spec:            "Suite gets slower",
+				CodeLocation:    types.CodeLocation{FileName: "suite_test.go", LineNumber: 12},
+				PreviousRunTime: 2 * time.Second,
+				RunTime:         5 * time.Second,
+			}}))
+
+			histories = reporters.AnalyzeHistory(reports, 3, 0)
+			Ω(histories[0].DurationRegressions).Should(BeEmpty())
+		})
+	})
+})
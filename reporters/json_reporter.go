@@ -0,0 +1,196 @@
+/*
+
+JSON Reporter for Ginkgo
+
+The JSON reporter writes a machine-readable report of the entire suite run to a file.  To generate one:
+
+	ginkgo -jsonReport=report.json
+
+The report can later be handed back to Ginkgo (e.g. with -baselineReport) or consumed by external tooling.
+
+*/
+
+package reporters
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/onsi/ginkgo/config"
+	"github.com/onsi/ginkgo/internal/clock"
+	"github.com/onsi/ginkgo/types"
+)
+
+//JSONReport is the document written by the JSONReporter
+type JSONReport struct {
+	SuiteDescription string
+	SuiteSucceeded   bool
+	SuiteID          string
+	RandomSeed       int64
+	StartTime        time.Time
+	RunTime          time.Duration
+	SuiteLabels      []string          `json:",omitempty"`
+	SuiteMetadata    map[string]string `json:",omitempty"`
+
+	SuiteSummary   *types.SuiteSummary
+	SetupSummaries []*types.SetupSummary
+	SpecSummaries  []*types.SpecSummary
+
+	//FailuresByOwner lists the full texts of the failing specs under each of their owners, see types.FailuresByOwner.
+	//It is left out when none of the failing specs has an owner.
+	FailuresByOwner map[string][]string `json:",omitempty"`
+	//FailuresBySeverity counts the failing specs of each severity, see types.FailuresBySeverity.  It is left out when
+	//none of the failing specs has a severity.
+	FailuresBySeverity map[string]int `json:",omitempty"`
+	//RerunCommands maps the full text of each failing spec to the command that reruns it, see RerunCommand.  It is left
+	//out when no spec failed.
+	RerunCommands map[string]string `json:",omitempty"`
+}
+
+//ReadJSONReport loads a JSONReport previously written by the JSONReporter, gzipped or not
+func ReadJSONReport(filename string) (JSONReport, error) {
+	var report JSONReport
+	data, err := readReportFile(filename)
+	if err != nil {
+		return report, err
+	}
+	err = json.Unmarshal(data, &report)
+	return report, err
+}
+
+//WriteJSONReport writes report as the JSONReporter does, gzipped if filename ends in .gz
+func WriteJSONReport(filename string, report JSONReport) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeReportFile(filename, data)
+}
+
+//SpecFullText returns the texts of all the containers and the subject of a spec, joined by spaces.
+//Reporters use it to identify a spec across runs.
+func SpecFullText(specSummary *types.SpecSummary) string {
+	return joinSpecTexts(specSummary.ComponentTexts)
+}
+
+//SpecDisplayText returns what SpecFullText does, with the texts as the text transformers render them, see
+//types.SpecSummary.TextsToDisplay.  Reporters use it to name a spec to their readers.
+func SpecDisplayText(specSummary *types.SpecSummary) string {
+	return joinSpecTexts(specSummary.TextsToDisplay())
+}
+
+//joinSpecTexts joins the texts of a spec, leaving out the top level container unless it is all there is
+func joinSpecTexts(texts []string) string {
+	if len(texts) <= 1 {
+		return strings.Join(texts, " ")
+	}
+	return strings.Join(texts[1:], " ")
+}
+
+//SortedSuiteMetadataKeys returns the keys of the suite's metadata in alphabetical order, so that reporters
+//serialize the metadata deterministically.
+func SortedSuiteMetadataKeys(summary *types.SuiteSummary) []string {
+	keys := []string{}
+	for key := range summary.SuiteMetadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func failuresByOwner(summaries []*types.SpecSummary) map[string][]string {
+	owners, groups := types.FailuresByOwner(summaries)
+	if len(owners) == 0 {
+		return nil
+	}
+	result := map[string][]string{}
+	for _, owner := range owners {
+		for _, summary := range groups[owner] {
+			result[owner] = append(result[owner], SpecFullText(summary))
+		}
+	}
+	return result
+}
+
+func failuresBySeverity(summaries []*types.SpecSummary) map[string]int {
+	counts := types.FailuresBySeverity(summaries)
+	if len(counts) == 0 {
+		return nil
+	}
+	result := map[string]int{}
+	for severity, count := range counts {
+		result[severity.String()] = count
+	}
+	return result
+}
+
+type JSONReporter struct {
+	report       JSONReport
+	filename     string
+	ginkgoConfig config.GinkgoConfigType
+
+	//rerunStyle and packageDir shape the rerun commands of the report, see RerunCommand
+	rerunStyle string
+	packageDir string
+}
+
+//NewJSONReporter creates a new JSON reporter.  The report will be stored in the passed in filename.
+func NewJSONReporter(filename string) *JSONReporter {
+	return &JSONReporter{
+		filename: filename,
+	}
+}
+
+func (reporter *JSONReporter) SpecSuiteWillBegin(ginkgoConfig config.GinkgoConfigType, summary *types.SuiteSummary) {
+	reporter.ginkgoConfig = ginkgoConfig
+	reporter.report = JSONReport{
+		SuiteDescription: summary.SuiteDescription,
+		SuiteID:          summary.SuiteID,
+		RandomSeed:       ginkgoConfig.RandomSeed,
+		StartTime:        clock.Now(),
+		SuiteLabels:      summary.SuiteLabels,
+		SuiteMetadata:    summary.SuiteMetadata,
+		SetupSummaries:   []*types.SetupSummary{},
+		SpecSummaries:    []*types.SpecSummary{},
+	}
+}
+
+func (reporter *JSONReporter) BeforeSuiteDidRun(setupSummary *types.SetupSummary) {
+	reporter.report.SetupSummaries = append(reporter.report.SetupSummaries, setupSummary)
+}
+
+func (reporter *JSONReporter) SpecWillRun(specSummary *types.SpecSummary) {
+}
+
+func (reporter *JSONReporter) SpecDidComplete(specSummary *types.SpecSummary) {
+	reporter.report.SpecSummaries = append(reporter.report.SpecSummaries, specSummary)
+}
+
+func (reporter *JSONReporter) AfterSuiteDidRun(setupSummary *types.SetupSummary) {
+	reporter.report.SetupSummaries = append(reporter.report.SetupSummaries, setupSummary)
+}
+
+func (reporter *JSONReporter) SpecSuiteDidEnd(summary *types.SuiteSummary) {
+	reporter.report.SuiteSucceeded = summary.SuiteSucceeded
+	reporter.report.RunTime = summary.RunTime
+	reporter.report.SuiteSummary = summary
+	reporter.report.FailuresByOwner = failuresByOwner(reporter.report.SpecSummaries)
+	reporter.report.FailuresBySeverity = failuresBySeverity(reporter.report.SpecSummaries)
+	reporter.report.RerunCommands = rerunCommands(reporter.rerunStyle, reporter.ginkgoConfig, reporter.report.SuiteDescription, packageDirFor(reporter.packageDir), reporter.report.SpecSummaries)
+
+	filePath := reportFilePath(reporter.filename)
+	data, err := json.MarshalIndent(reporter.report, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\nFailed to generate JSON report data:\n\t%s", err.Error())
+		return
+	}
+
+	err = writeReportFile(reporter.filename, data)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\nFailed to create JSON report file: %s\n\t%s", filePath, err.Error())
+	}
+}
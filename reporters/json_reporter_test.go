@@ -0,0 +1,123 @@
+package reporters_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/config"
+	"github.com/onsi/ginkgo/internal/codelocation"
+	"github.com/onsi/ginkgo/reporters"
+	"github.com/onsi/ginkgo/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("JSON Reporter", func() {
+	var (
+		dir        string
+		outputFile string
+		reporter   *reporters.JSONReporter
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "json-reporter")
+		Expect(err).ToNot(HaveOccurred())
+		outputFile = filepath.Join(dir, "nested", "report.json")
+
+		reporter = reporters.NewJSONReporter(outputFile)
+		reporter.SpecSuiteWillBegin(config.GinkgoConfigType{RandomSeed: 17}, &types.SuiteSummary{
+			SuiteDescription: "My test suite",
+			SuiteID:          "suite-id",
+			SuiteLabels:      []string{"integration"},
+			SuiteMetadata:    map[string]string{"gitSHA": "abc123"},
+		})
+		reporter.BeforeSuiteDidRun(&types.SetupSummary{
+			ComponentType: types.SpecComponentTypeBeforeSuite,
+			State:         types.SpecStatePassed,
+		})
+		reporter.SpecWillRun(&types.SpecSummary{ComponentTexts: []string{"[Top Level]", "A", "B"}})
+		reporter.SpecDidComplete(&types.SpecSummary{
+			ComponentTexts:         []string{"[Top Level]", "A", "B"},
+			ComponentCodeLocations: []types.CodeLocation{codelocation.New(0)},
+			Owners:                 []string{"team-a"},
+			Severity:               types.SeverityHigh,
+			State:                  types.SpecStateFailed,
+			RunTime:                3 * time.Second,
+			Failure: types.SpecFailure{
+				Message:  "boom",
+				Location: codelocation.New(0),
+			},
+		})
+		reporter.AfterSuiteDidRun(&types.SetupSummary{
+			ComponentType: types.SpecComponentTypeAfterSuite,
+			State:         types.SpecStatePassed,
+		})
+		reporter.SpecSuiteDidEnd(&types.SuiteSummary{
+			SuiteDescription:    "My test suite",
+			SuiteSucceeded:      false,
+			NumberOfFailedSpecs: 1,
+			RunTime:             5 * time.Second,
+		})
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("should write a report that can be read back, creating the directory if needed", func() {
+		report, err := reporters.ReadJSONReport(outputFile)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(report.SuiteDescription).To(Equal("My test suite"))
+		Expect(report.SuiteID).To(Equal("suite-id"))
+		Expect(report.SuiteSucceeded).To(BeFalse())
+		Expect(report.RandomSeed).To(Equal(int64(17)))
+		Expect(report.RunTime).To(Equal(5 * time.Second))
+		Expect(report.StartTime).ToNot(BeZero())
+		Expect(report.SuiteSummary.NumberOfFailedSpecs).To(Equal(1))
+		Expect(report.SuiteLabels).To(Equal([]string{"integration"}))
+		Expect(report.SuiteMetadata).To(Equal(map[string]string{"gitSHA": "abc123"}))
+
+		Expect(report.SetupSummaries).To(HaveLen(2))
+		Expect(report.SetupSummaries[0].ComponentType).To(Equal(types.SpecComponentTypeBeforeSuite))
+		Expect(report.SetupSummaries[1].ComponentType).To(Equal(types.SpecComponentTypeAfterSuite))
+
+		Expect(report.SpecSummaries).To(HaveLen(1))
+		Expect(report.SpecSummaries[0].State).To(Equal(types.SpecStateFailed))
+		Expect(report.SpecSummaries[0].RunTime).To(Equal(3 * time.Second))
+		Expect(report.SpecSummaries[0].Failure.Message).To(Equal("boom"))
+		Expect(reporters.SpecFullText(report.SpecSummaries[0])).To(Equal("A B"))
+		Expect(report.SpecSummaries[0].Owners).To(Equal([]string{"team-a"}))
+
+		Expect(report.SpecSummaries[0].Severity).To(Equal(types.SeverityHigh))
+
+		Expect(report.FailuresByOwner).To(Equal(map[string][]string{"team-a": {"A B"}}))
+		Expect(report.FailuresBySeverity).To(Equal(map[string]int{"high": 1}))
+
+		wd, _ := os.Getwd()
+		Expect(report.RerunCommands).To(Equal(map[string]string{"A B": "ginkgo -seed=17 -focus='^My test suite \\[Top Level\\] A B$' " + wd}))
+	})
+
+	It("should gzip the report when its name ends in .gz", func() {
+		gzipped := reporters.NewJSONReporter(filepath.Join(dir, "report.json.gz"))
+		gzipped.SpecSuiteWillBegin(config.GinkgoConfigType{RandomSeed: 17}, &types.SuiteSummary{SuiteDescription: "My test suite"})
+		gzipped.SpecSuiteDidEnd(&types.SuiteSummary{SuiteDescription: "My test suite", SuiteSucceeded: true})
+
+		data, err := ioutil.ReadFile(filepath.Join(dir, "report.json.gz"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(data[:2]).To(Equal([]byte{0x1f, 0x8b}))
+
+		report, err := reporters.ReadJSONReport(filepath.Join(dir, "report.json.gz"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(report.SuiteDescription).To(Equal("My test suite"))
+		Expect(report.SuiteSucceeded).To(BeTrue())
+	})
+
+	It("should fail to read a missing report", func() {
+		_, err := reporters.ReadJSONReport(filepath.Join(dir, "missing.json"))
+		Expect(err).To(HaveOccurred())
+	})
+})
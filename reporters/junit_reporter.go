@@ -11,9 +11,9 @@ package reporters
 import (
 	"encoding/xml"
 	"fmt"
+	"io"
 	"math"
 	"os"
-	"path/filepath"
 	"strings"
 
 	"github.com/onsi/ginkgo/config"
@@ -21,13 +21,23 @@ import (
 )
 
 type JUnitTestSuite struct {
-	XMLName   xml.Name        `xml:"testsuite"`
-	TestCases []JUnitTestCase `xml:"testcase"`
-	Name      string          `xml:"name,attr"`
-	Tests     int             `xml:"tests,attr"`
-	Failures  int             `xml:"failures,attr"`
-	Errors    int             `xml:"errors,attr"`
-	Time      float64         `xml:"time,attr"`
+	XMLName    xml.Name         `xml:"testsuite"`
+	Properties *JUnitProperties `xml:"properties,omitempty"`
+	TestCases  []JUnitTestCase  `xml:"testcase"`
+	Name       string           `xml:"name,attr"`
+	Tests      int              `xml:"tests,attr"`
+	Failures   int              `xml:"failures,attr"`
+	Errors     int              `xml:"errors,attr"`
+	Time       float64          `xml:"time,attr"`
+}
+
+type JUnitProperties struct {
+	Properties []JUnitProperty `xml:"property"`
+}
+
+type JUnitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
 }
 
 type JUnitTestCase struct {
@@ -53,6 +63,8 @@ type JUnitReporter struct {
 	filename       string
 	testSuiteName  string
 	ReporterConfig config.DefaultReporterConfigType
+	//hasReporterConfig is set once the reporter is given its own configuration, which the flags then no longer override
+	hasReporterConfig bool
 }
 
 //NewJUnitReporter creates a new JUnit XML reporter.  The XML will be stored in the passed in filename.
@@ -62,13 +74,33 @@ func NewJUnitReporter(filename string) *JUnitReporter {
 	}
 }
 
+//SetReporterConfig gives the reporter its own configuration rather than the one of the flags
+func (reporter *JUnitReporter) SetReporterConfig(reporterConfig config.DefaultReporterConfigType) {
+	reporter.ReporterConfig = reporterConfig
+	reporter.hasReporterConfig = true
+}
+
 func (reporter *JUnitReporter) SpecSuiteWillBegin(ginkgoConfig config.GinkgoConfigType, summary *types.SuiteSummary) {
 	reporter.suite = JUnitTestSuite{
 		Name:      summary.SuiteDescription,
 		TestCases: []JUnitTestCase{},
 	}
 	reporter.testSuiteName = summary.SuiteDescription
-	reporter.ReporterConfig = config.DefaultReporterConfig
+	if !reporter.hasReporterConfig {
+		reporter.ReporterConfig = config.DefaultReporterConfig
+	}
+
+	//the suite labels and metadata are recorded as properties of the test suite
+	properties := []JUnitProperty{}
+	if len(summary.SuiteLabels) > 0 {
+		properties = append(properties, JUnitProperty{Name: "SuiteLabels", Value: strings.Join(summary.SuiteLabels, ",")})
+	}
+	for _, key := range SortedSuiteMetadataKeys(summary) {
+		properties = append(properties, JUnitProperty{Name: key, Value: summary.SuiteMetadata[key]})
+	}
+	if len(properties) > 0 {
+		reporter.suite.Properties = &JUnitProperties{Properties: properties}
+	}
 }
 
 func (reporter *JUnitReporter) SpecWillRun(specSummary *types.SpecSummary) {
@@ -105,7 +137,7 @@ func (reporter *JUnitReporter) handleSetupSummary(name string, setupSummary *typ
 
 func (reporter *JUnitReporter) SpecDidComplete(specSummary *types.SpecSummary) {
 	testCase := JUnitTestCase{
-		Name:      strings.Join(specSummary.ComponentTexts[1:], " "),
+		Name:      strings.Join(specSummary.TextsToDisplay()[1:], " "),
 		ClassName: reporter.testSuiteName,
 	}
 	if reporter.ReporterConfig.ReportPassed && specSummary.State == types.SpecStatePassed {
@@ -142,25 +174,24 @@ func (reporter *JUnitReporter) SpecSuiteDidEnd(summary *types.SuiteSummary) {
 		reporter.filename = reporter.ReporterConfig.ReportFile
 		fmt.Printf("\nJUnit path was configured: %s\n", reporter.filename)
 	}
-	filePath, _ := filepath.Abs(reporter.filename)
-	dirPath := filepath.Dir(filePath)
-	err := os.MkdirAll(dirPath, os.ModePerm)
-	if err != nil {
-		fmt.Printf("\nFailed to create JUnit directory: %s\n\t%s", filePath, err.Error())
-	}
-	file, err := os.Create(filePath)
+	filePath := reportFilePath(reporter.filename)
+	file, err := createReportFile(reporter.filename)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to create JUnit report file: %s\n\t%s", filePath, err.Error())
+		return
 	}
 	defer file.Close()
-	file.WriteString(xml.Header)
+	io.WriteString(file, xml.Header)
 	encoder := xml.NewEncoder(file)
 	encoder.Indent("  ", "    ")
 	err = encoder.Encode(reporter.suite)
 	if err == nil {
-		fmt.Fprintf(os.Stdout, "\nJUnit report was created: %s\n", filePath)
+		//the report itself goes to stdout with -
+		if filePath != StdoutReportFile {
+			fmt.Fprintf(os.Stdout, "\nJUnit report was created: %s\n", filePath)
+		}
 	} else {
-		fmt.Fprintf(os.Stderr,"\nFailed to generate JUnit report data:\n\t%s", err.Error())
+		fmt.Fprintf(os.Stderr, "\nFailed to generate JUnit report data:\n\t%s", err.Error())
 	}
 }
 
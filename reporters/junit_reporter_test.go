@@ -49,6 +49,31 @@ var _ = Describe("JUnit Reporter", func() {
 		os.RemoveAll(outputFile)
 	})
 
+	It("should not write any properties when the suite has neither labels nor metadata", func() {
+		reporter.SpecSuiteDidEnd(&types.SuiteSummary{NumberOfSpecsThatWillBeRun: 1})
+		Expect(readOutputFile().Properties).To(BeNil())
+	})
+
+	Describe("when the suite has labels and metadata", func() {
+		BeforeEach(func() {
+			reporter.SpecSuiteWillBegin(config.GinkgoConfigType{}, &types.SuiteSummary{
+				SuiteDescription: "My test suite",
+				SuiteLabels:      []string{"integration", "storage"},
+				SuiteMetadata:    map[string]string{"gitSHA": "abc123", "environment": "staging"},
+			})
+			reporter.SpecSuiteDidEnd(&types.SuiteSummary{NumberOfSpecsThatWillBeRun: 1})
+		})
+
+		It("should record them as properties of the test suite", func() {
+			output := readOutputFile()
+			Expect(output.Properties.Properties).To(Equal([]reporters.JUnitProperty{
+				{Name: "SuiteLabels", Value: "integration,storage"},
+				{Name: "environment", Value: "staging"},
+				{Name: "gitSHA", Value: "abc123"},
+			}))
+		})
+	})
+
 	Describe("when configured with ReportPassed, and test has passed", func() {
 		BeforeEach(func() {
 			beforeSuite := &types.SetupSummary{
@@ -97,6 +122,25 @@ var _ = Describe("JUnit Reporter", func() {
 		})
 	})
 
+	Describe("when the text transformers rendered the texts of the test", func() {
+		BeforeEach(func() {
+			spec := &types.SpecSummary{
+				ComponentTexts: []string{"[Top Level]", "cart", "cart.add"},
+				DisplayTexts:   []string{"[Top Level]", "le panier", "ajoute un article"},
+				State:          types.SpecStatePassed,
+			}
+			reporter.SpecWillRun(spec)
+			reporter.SpecDidComplete(spec)
+			reporter.SpecSuiteDidEnd(&types.SuiteSummary{NumberOfSpecsThatWillBeRun: 1})
+		})
+
+		It("should name the test by the rendered texts", func() {
+			output := readOutputFile()
+			Expect(output.TestCases).To(HaveLen(1))
+			Expect(output.TestCases[0].Name).To(Equal("le panier ajoute un article"))
+		})
+	})
+
 	Describe("when configured with ReportFile <file path>", func() {
 		BeforeEach(func() {
 			beforeSuite := &types.SetupSummary{
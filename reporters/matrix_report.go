@@ -0,0 +1,119 @@
+package reporters
+
+import (
+	"sort"
+)
+
+//MatrixRun is one run of the suites for an entry of the matrix passed to ginkgo -envMatrix
+type MatrixRun struct {
+	Name string
+	//Values are the environment variables of the entry, along with its flags under the "flags" key
+	Values    map[string]string
+	Succeeded bool
+
+	//Reports holds the JSON reports of the suites that ran for the entry
+	Reports []JSONReport
+}
+
+//MatrixReport merges the runs of the suites for each entry of the matrix passed to ginkgo -envMatrix
+type MatrixReport struct {
+	SuiteSucceeded bool
+	Runs           []MatrixRun
+
+	//Dimensions break the results of the runs down by each of the matrix values, e.g. by database for a matrix running
+	//the suites against several databases
+	Dimensions []MatrixDimension
+}
+
+//MatrixDimension breaks the results of the runs down by the values they took for one of the matrix values
+type MatrixDimension struct {
+	Name   string
+	Values []MatrixDimensionValue
+}
+
+//MatrixDimensionValue sums up the runs that took one value
+type MatrixDimensionValue struct {
+	Value      string
+	Runs       int
+	FailedRuns int
+
+	Passed  int
+	Failed  int
+	Pending int
+	Skipped int
+}
+
+//NewMatrixReport merges runs into a report, tagging the reports of each run with its matrix values (as matrix.<name>
+//suite metadata) and breaking their results down by matrix value.  A run that left out one of the matrix values falls
+//under the empty value of that dimension.
+func NewMatrixReport(runs []MatrixRun) MatrixReport {
+	report := MatrixReport{SuiteSucceeded: len(runs) > 0}
+
+	names := []string{}
+	seen := map[string]bool{}
+	for _, run := range runs {
+		for name := range run.Values {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+
+	dimensions := make([]MatrixDimension, len(names))
+	for i, name := range names {
+		dimensions[i].Name = name
+	}
+
+	for _, run := range runs {
+		report.SuiteSucceeded = report.SuiteSucceeded && run.Succeeded
+		tagged := MatrixRun{Name: run.Name, Values: run.Values, Succeeded: run.Succeeded}
+		for _, jsonReport := range run.Reports {
+			metadata := map[string]string{}
+			for key, value := range jsonReport.SuiteMetadata {
+				metadata[key] = value
+			}
+			for name, value := range run.Values {
+				metadata["matrix."+name] = value
+			}
+			jsonReport.SuiteMetadata = metadata
+			tagged.Reports = append(tagged.Reports, jsonReport)
+		}
+		report.Runs = append(report.Runs, tagged)
+
+		for i := range dimensions {
+			value := dimensions[i].value(run.Values[dimensions[i].Name])
+			value.add(run)
+		}
+	}
+	report.Dimensions = dimensions
+
+	return report
+}
+
+func (dimension *MatrixDimension) value(value string) *MatrixDimensionValue {
+	for i := range dimension.Values {
+		if dimension.Values[i].Value == value {
+			return &dimension.Values[i]
+		}
+	}
+	dimension.Values = append(dimension.Values, MatrixDimensionValue{Value: value})
+	return &dimension.Values[len(dimension.Values)-1]
+}
+
+func (value *MatrixDimensionValue) add(run MatrixRun) {
+	value.Runs++
+	if !run.Succeeded {
+		value.FailedRuns++
+	}
+	for _, report := range run.Reports {
+		if report.SuiteSummary == nil {
+			continue
+		}
+		value.Passed += report.SuiteSummary.NumberOfPassedSpecs
+		value.Failed += report.SuiteSummary.NumberOfFailedSpecs
+		value.Pending += report.SuiteSummary.NumberOfPendingSpecs
+		value.Skipped += report.SuiteSummary.NumberOfSkippedSpecs
+	}
+}
@@ -0,0 +1,57 @@
+package reporters_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/reporters"
+	"github.com/onsi/ginkgo/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Matrix Report", func() {
+	jsonReport := func(passed int, failed int) reporters.JSONReport {
+		return reporters.JSONReport{
+			SuiteDescription: "Suite",
+			SuiteSummary:     &types.SuiteSummary{NumberOfPassedSpecs: passed, NumberOfFailedSpecs: failed, NumberOfPendingSpecs: 1},
+			SuiteMetadata:    map[string]string{"sha": "abc"},
+		}
+	}
+
+	var runs []reporters.MatrixRun
+
+	BeforeEach(func() {
+		runs = []reporters.MatrixRun{
+			{Name: "postgres eu", Values: map[string]string{"DB": "postgres", "REGION": "eu"}, Succeeded: true, Reports: []reporters.JSONReport{jsonReport(3, 0)}},
+			{Name: "postgres us", Values: map[string]string{"DB": "postgres", "REGION": "us"}, Succeeded: false, Reports: []reporters.JSONReport{jsonReport(2, 1)}},
+			{Name: "sqlite", Values: map[string]string{"DB": "sqlite"}, Succeeded: true, Reports: []reporters.JSONReport{jsonReport(3, 0)}},
+		}
+	})
+
+	It("should tag the reports of each run with its matrix values", func() {
+		report := reporters.NewMatrixReport(runs)
+		Ω(report.Runs).Should(HaveLen(3))
+		Ω(report.Runs[0].Reports[0].SuiteMetadata).Should(Equal(map[string]string{"sha": "abc", "matrix.DB": "postgres", "matrix.REGION": "eu"}))
+		Ω(report.Runs[2].Reports[0].SuiteMetadata).Should(Equal(map[string]string{"sha": "abc", "matrix.DB": "sqlite"}))
+		Ω(runs[0].Reports[0].SuiteMetadata).Should(Equal(map[string]string{"sha": "abc"}))
+	})
+
+	It("should break the results down by matrix value", func() {
+		report := reporters.NewMatrixReport(runs)
+		Ω(report.SuiteSucceeded).Should(BeFalse())
+		Ω(report.Dimensions).Should(Equal([]reporters.MatrixDimension{
+			{Name: "DB", Values: []reporters.MatrixDimensionValue{
+				{Value: "postgres", Runs: 2, FailedRuns: 1, Passed: 5, Failed: 1, Pending: 2},
+				{Value: "sqlite", Runs: 1, Passed: 3, Pending: 1},
+			}},
+			{Name: "REGION", Values: []reporters.MatrixDimensionValue{
+				{Value: "eu", Runs: 1, Passed: 3, Pending: 1},
+				{Value: "us", Runs: 1, FailedRuns: 1, Passed: 2, Failed: 1, Pending: 1},
+				{Value: "", Runs: 1, Passed: 3, Pending: 1},
+			}},
+		}))
+	})
+
+	It("should succeed when all the runs succeeded", func() {
+		Ω(reporters.NewMatrixReport([]reporters.MatrixRun{runs[0], runs[2]}).SuiteSucceeded).Should(BeTrue())
+		Ω(reporters.NewMatrixReport(nil).SuiteSucceeded).Should(BeFalse())
+	})
+})
@@ -0,0 +1,289 @@
+/*
+
+Metrics Reporter for Ginkgo
+
+The metrics reporter exposes counters and histograms describing a suite run in the Prometheus text exposition format.
+Metrics can be scraped while the suite runs, pushed to a Pushgateway once the suite ends, or both:
+
+	ginkgo -metricsAddress=:9090                            # serves http://localhost:9090/metrics while the suite runs
+	ginkgo -metricsPushgateway=http://pushgateway:9091      # pushes the metrics under job "ginkgo" (see -metricsJob)
+
+Every metric carries a suite label.  Spec durations are further broken down by spec label (see ginkgo.Label); unlabelled specs
+are observed with an empty label.
+
+*/
+
+package reporters
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/onsi/ginkgo/config"
+	"github.com/onsi/ginkgo/formatter"
+	"github.com/onsi/ginkgo/types"
+)
+
+//MetricsDurationBuckets are the upper bounds, in seconds, of the ginkgo_spec_duration_seconds histogram buckets
+var MetricsDurationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 300}
+
+type durationHistogram struct {
+	buckets []uint64
+	sum     float64
+	count   uint64
+}
+
+func (histogram *durationHistogram) observe(seconds float64) {
+	for i, bound := range MetricsDurationBuckets {
+		if seconds <= bound {
+			histogram.buckets[i]++
+		}
+	}
+	histogram.sum += seconds
+	histogram.count++
+}
+
+type MetricsReporter struct {
+	address        string
+	pushgatewayURL string
+	job            string
+	writer         io.Writer
+	client         *http.Client
+
+	lock          *sync.Mutex
+	suite         string
+	run           uint64
+	failed        uint64
+	flaked        uint64
+	failedSpecs   map[string]bool
+	durations     map[string]*durationHistogram
+	suiteRunTime  time.Duration
+	suiteFinished bool
+	succeeded     bool
+
+	listener net.Listener
+	server   *http.Server
+	noColor  bool
+}
+
+//NewMetricsReporter creates a new reporter.  If address is not empty the metrics are served on it under /metrics while the suite runs.
+//If pushgatewayURL is not empty the metrics are pushed to that Pushgateway under job once the suite ends.  Problems are reported to writer.
+func NewMetricsReporter(writer io.Writer, address string, pushgatewayURL string, job string) *MetricsReporter {
+	if job == "" {
+		job = "ginkgo"
+	}
+	return &MetricsReporter{
+		address:        address,
+		pushgatewayURL: strings.TrimRight(pushgatewayURL, "/"),
+		job:            job,
+		writer:         writer,
+		client:         &http.Client{Timeout: 10 * time.Second},
+		lock:           &sync.Mutex{},
+		failedSpecs:    map[string]bool{},
+		durations:      map[string]*durationHistogram{},
+	}
+}
+
+//Address returns the address metrics are served on, once the suite has begun.  This is useful when listening on port 0.
+func (reporter *MetricsReporter) Address() string {
+	reporter.lock.Lock()
+	defer reporter.lock.Unlock()
+	if reporter.listener == nil {
+		return ""
+	}
+	return reporter.listener.Addr().String()
+}
+
+func (reporter *MetricsReporter) SpecSuiteWillBegin(ginkgoConfig config.GinkgoConfigType, summary *types.SuiteSummary) {
+	reporter.lock.Lock()
+	reporter.suite = summary.SuiteDescription
+	reporter.noColor = config.DefaultReporterConfig.NoColor
+	reporter.lock.Unlock()
+
+	if reporter.address == "" {
+		return
+	}
+	listener, err := net.Listen("tcp", reporter.address)
+	if err != nil {
+		reporter.printError("Failed to serve metrics:", err)
+		return
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		w.Write([]byte(reporter.Metrics()))
+	})
+	server := &http.Server{Handler: mux}
+	go server.Serve(listener)
+
+	reporter.lock.Lock()
+	reporter.listener = listener
+	reporter.server = server
+	reporter.lock.Unlock()
+}
+
+func (reporter *MetricsReporter) BeforeSuiteDidRun(setupSummary *types.SetupSummary) {
+}
+
+func (reporter *MetricsReporter) SpecWillRun(specSummary *types.SpecSummary) {
+}
+
+func (reporter *MetricsReporter) SpecDidComplete(specSummary *types.SpecSummary) {
+	if specSummary.Skipped() || specSummary.Pending() {
+		return
+	}
+
+	reporter.lock.Lock()
+	defer reporter.lock.Unlock()
+
+	specID := SpecFullText(specSummary)
+	reporter.run++
+	if specSummary.Passed() {
+		if reporter.failedSpecs[specID] {
+			reporter.flaked++
+			delete(reporter.failedSpecs, specID)
+		}
+	} else {
+		reporter.failed++
+		reporter.failedSpecs[specID] = true
+	}
+
+	labels := specSummary.Labels
+	if len(labels) == 0 {
+		labels = []string{""}
+	}
+	for _, label := range labels {
+		histogram, ok := reporter.durations[label]
+		if !ok {
+			histogram = &durationHistogram{buckets: make([]uint64, len(MetricsDurationBuckets))}
+			reporter.durations[label] = histogram
+		}
+		histogram.observe(specSummary.RunTime.Seconds())
+	}
+}
+
+func (reporter *MetricsReporter) AfterSuiteDidRun(setupSummary *types.SetupSummary) {
+}
+
+func (reporter *MetricsReporter) SpecSuiteDidEnd(summary *types.SuiteSummary) {
+	reporter.lock.Lock()
+	reporter.suiteRunTime = summary.RunTime
+	reporter.succeeded = summary.SuiteSucceeded
+	reporter.suiteFinished = true
+	server := reporter.server
+	reporter.listener, reporter.server = nil, nil
+	reporter.lock.Unlock()
+
+	if server != nil {
+		server.Close()
+	}
+
+	if reporter.pushgatewayURL != "" {
+		err := reporter.push()
+		if err != nil {
+			reporter.printError("Failed to push metrics:", err)
+		}
+	}
+}
+
+//Metrics renders the current metrics in the Prometheus text exposition format
+func (reporter *MetricsReporter) Metrics() string {
+	reporter.lock.Lock()
+	defer reporter.lock.Unlock()
+
+	suite := fmt.Sprintf(`suite="%s"`, escapeMetricLabel(reporter.suite))
+	out := &bytes.Buffer{}
+
+	fmt.Fprintln(out, "# HELP ginkgo_specs_run_total Number of spec attempts that ran.")
+	fmt.Fprintln(out, "# TYPE ginkgo_specs_run_total counter")
+	fmt.Fprintf(out, "ginkgo_specs_run_total{%s} %d\n", suite, reporter.run)
+	fmt.Fprintln(out, "# HELP ginkgo_specs_failed_total Number of spec attempts that failed.")
+	fmt.Fprintln(out, "# TYPE ginkgo_specs_failed_total counter")
+	fmt.Fprintf(out, "ginkgo_specs_failed_total{%s} %d\n", suite, reporter.failed)
+	fmt.Fprintln(out, "# HELP ginkgo_specs_flaked_total Number of specs that passed after failing.")
+	fmt.Fprintln(out, "# TYPE ginkgo_specs_flaked_total counter")
+	fmt.Fprintf(out, "ginkgo_specs_flaked_total{%s} %d\n", suite, reporter.flaked)
+
+	fmt.Fprintln(out, "# HELP ginkgo_spec_duration_seconds Run time of spec attempts, by spec label.")
+	fmt.Fprintln(out, "# TYPE ginkgo_spec_duration_seconds histogram")
+	labels := []string{}
+	for label := range reporter.durations {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	for _, label := range labels {
+		histogram := reporter.durations[label]
+		series := fmt.Sprintf(`%s,label="%s"`, suite, escapeMetricLabel(label))
+		for i, bound := range MetricsDurationBuckets {
+			fmt.Fprintf(out, "ginkgo_spec_duration_seconds_bucket{%s,le=\"%s\"} %d\n", series, formatMetricValue(bound), histogram.buckets[i])
+		}
+		fmt.Fprintf(out, "ginkgo_spec_duration_seconds_bucket{%s,le=\"+Inf\"} %d\n", series, histogram.count)
+		fmt.Fprintf(out, "ginkgo_spec_duration_seconds_sum{%s} %s\n", series, formatMetricValue(histogram.sum))
+		fmt.Fprintf(out, "ginkgo_spec_duration_seconds_count{%s} %d\n", series, histogram.count)
+	}
+
+	if reporter.suiteFinished {
+		succeeded := 0
+		if reporter.succeeded {
+			succeeded = 1
+		}
+		fmt.Fprintln(out, "# HELP ginkgo_suite_duration_seconds Run time of the suite.")
+		fmt.Fprintln(out, "# TYPE ginkgo_suite_duration_seconds gauge")
+		fmt.Fprintf(out, "ginkgo_suite_duration_seconds{%s} %s\n", suite, formatMetricValue(reporter.suiteRunTime.Seconds()))
+		fmt.Fprintln(out, "# HELP ginkgo_suite_succeeded Whether the suite succeeded (1) or failed (0).")
+		fmt.Fprintln(out, "# TYPE ginkgo_suite_succeeded gauge")
+		fmt.Fprintf(out, "ginkgo_suite_succeeded{%s} %d\n", suite, succeeded)
+	}
+
+	return out.String()
+}
+
+func (reporter *MetricsReporter) push() error {
+	reporter.lock.Lock()
+	suite := reporter.suite
+	reporter.lock.Unlock()
+
+	encodedSuite := base64.RawURLEncoding.EncodeToString([]byte(suite))
+	if encodedSuite == "" {
+		encodedSuite = "="
+	}
+	url := fmt.Sprintf("%s/metrics/job/%s/suite@base64/%s", reporter.pushgatewayURL, reporter.job, encodedSuite)
+
+	req, err := http.NewRequest(http.MethodPut, url, strings.NewReader(reporter.Metrics()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "text/plain; version=0.0.4")
+	resp, err := reporter.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code %d from %s", resp.StatusCode, url)
+	}
+	return nil
+}
+
+func (reporter *MetricsReporter) printError(message string, err error) {
+	reporter.lock.Lock()
+	f := formatter.NewWithNoColorBool(reporter.noColor)
+	reporter.lock.Unlock()
+	fmt.Fprint(reporter.writer, f.F("\n{{orange}}%s{{/}}\n\t%s\n", message, err.Error()))
+}
+
+func escapeMetricLabel(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
+
+func formatMetricValue(value float64) string {
+	return fmt.Sprintf("%g", value)
+}
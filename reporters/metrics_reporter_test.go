@@ -0,0 +1,170 @@
+package reporters_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/config"
+	"github.com/onsi/ginkgo/reporters"
+	"github.com/onsi/ginkgo/types"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Metrics Reporter", func() {
+	var (
+		buffer   *bytes.Buffer
+		reporter *reporters.MetricsReporter
+	)
+
+	summary := func(text string, state types.SpecState, runTime time.Duration, labels ...string) *types.SpecSummary {
+		return &types.SpecSummary{
+			ComponentTexts: []string{"[Top Level]", "Suite", text},
+			State:          state,
+			RunTime:        runTime,
+			Labels:         labels,
+		}
+	}
+
+	begin := func() {
+		reporter.SpecSuiteWillBegin(config.GinkgoConfigType{}, &types.SuiteSummary{SuiteDescription: `My "test" suite`})
+	}
+
+	complete := func() {
+		for _, specSummary := range []*types.SpecSummary{
+			summary("is fast", types.SpecStatePassed, 20*time.Millisecond, "fast"),
+			summary("is slow", types.SpecStatePassed, 2*time.Second, "slow", "storage"),
+			summary("is flaky", types.SpecStateFailed, 40*time.Millisecond),
+			summary("is flaky", types.SpecStatePassed, 40*time.Millisecond),
+			summary("fails", types.SpecStateFailed, 3*time.Second, "storage"),
+			summary("is pending", types.SpecStatePending, 0),
+			summary("is skipped", types.SpecStateSkipped, 0),
+		} {
+			reporter.SpecWillRun(specSummary)
+			reporter.SpecDidComplete(specSummary)
+		}
+	}
+
+	end := func() {
+		reporter.SpecSuiteDidEnd(&types.SuiteSummary{SuiteSucceeded: false, RunTime: 10 * time.Second})
+	}
+
+	BeforeEach(func() {
+		buffer = &bytes.Buffer{}
+	})
+
+	Describe("rendering metrics", func() {
+		BeforeEach(func() {
+			reporter = reporters.NewMetricsReporter(buffer, "", "", "")
+			begin()
+			complete()
+		})
+
+		It("should count the spec attempts that ran, failed and flaked", func() {
+			metrics := reporter.Metrics()
+			Ω(metrics).Should(ContainSubstring("# TYPE ginkgo_specs_run_total counter\n"))
+			Ω(metrics).Should(ContainSubstring(`ginkgo_specs_run_total{suite="My \"test\" suite"} 5` + "\n"))
+			Ω(metrics).Should(ContainSubstring(`ginkgo_specs_failed_total{suite="My \"test\" suite"} 2` + "\n"))
+			Ω(metrics).Should(ContainSubstring(`ginkgo_specs_flaked_total{suite="My \"test\" suite"} 1` + "\n"))
+		})
+
+		It("should observe spec durations once per label", func() {
+			metrics := reporter.Metrics()
+			Ω(metrics).Should(ContainSubstring("# TYPE ginkgo_spec_duration_seconds histogram\n"))
+			Ω(metrics).Should(ContainSubstring(`ginkgo_spec_duration_seconds_bucket{suite="My \"test\" suite",label="",le="0.025"} 0` + "\n"))
+			Ω(metrics).Should(ContainSubstring(`ginkgo_spec_duration_seconds_bucket{suite="My \"test\" suite",label="",le="0.05"} 2` + "\n"))
+			Ω(metrics).Should(ContainSubstring(`ginkgo_spec_duration_seconds_count{suite="My \"test\" suite",label=""} 2` + "\n"))
+			Ω(metrics).Should(ContainSubstring(`ginkgo_spec_duration_seconds_bucket{suite="My \"test\" suite",label="fast",le="0.025"} 1` + "\n"))
+			Ω(metrics).Should(ContainSubstring(`ginkgo_spec_duration_seconds_bucket{suite="My \"test\" suite",label="storage",le="2.5"} 1` + "\n"))
+			Ω(metrics).Should(ContainSubstring(`ginkgo_spec_duration_seconds_bucket{suite="My \"test\" suite",label="storage",le="+Inf"} 2` + "\n"))
+			Ω(metrics).Should(ContainSubstring(`ginkgo_spec_duration_seconds_sum{suite="My \"test\" suite",label="storage"} 5` + "\n"))
+			Ω(metrics).Should(ContainSubstring(`ginkgo_spec_duration_seconds_count{suite="My \"test\" suite",label="slow"} 1` + "\n"))
+		})
+
+		It("should only report the suite's run time once the suite has ended", func() {
+			Ω(reporter.Metrics()).ShouldNot(ContainSubstring("ginkgo_suite_duration_seconds"))
+			end()
+			metrics := reporter.Metrics()
+			Ω(metrics).Should(ContainSubstring(`ginkgo_suite_duration_seconds{suite="My \"test\" suite"} 10` + "\n"))
+			Ω(metrics).Should(ContainSubstring(`ginkgo_suite_succeeded{suite="My \"test\" suite"} 0` + "\n"))
+		})
+	})
+
+	Describe("serving metrics", func() {
+		BeforeEach(func() {
+			reporter = reporters.NewMetricsReporter(buffer, "127.0.0.1:0", "", "")
+			begin()
+		})
+
+		It("should serve the metrics under /metrics until the suite ends", func() {
+			address := reporter.Address()
+			Ω(address).ShouldNot(BeEmpty())
+			complete()
+
+			resp, err := http.Get("http://" + address + "/metrics")
+			Ω(err).ShouldNot(HaveOccurred())
+			defer resp.Body.Close()
+			Ω(resp.Header.Get("Content-Type")).Should(Equal("text/plain; version=0.0.4"))
+			body, err := ioutil.ReadAll(resp.Body)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(string(body)).Should(ContainSubstring(`ginkgo_specs_run_total{suite="My \"test\" suite"} 5`))
+
+			end()
+			Ω(reporter.Address()).Should(BeEmpty())
+			_, err = http.Get("http://" + address + "/metrics")
+			Ω(err).Should(HaveOccurred())
+		})
+	})
+
+	Describe("pushing metrics", func() {
+		var server *ghttp.Server
+
+		BeforeEach(func() {
+			server = ghttp.NewServer()
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("should push the metrics to the Pushgateway once the suite ends", func() {
+			var pushed string
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("PUT", "/metrics/job/nightly/suite@base64/"+base64.RawURLEncoding.EncodeToString([]byte(`My "test" suite`))),
+				ghttp.VerifyHeaderKV("Content-Type", "text/plain; version=0.0.4"),
+				func(w http.ResponseWriter, req *http.Request) {
+					body, _ := ioutil.ReadAll(req.Body)
+					pushed = string(body)
+				},
+			))
+
+			reporter = reporters.NewMetricsReporter(buffer, "", server.URL()+"/", "nightly")
+			begin()
+			complete()
+			end()
+
+			Ω(server.ReceivedRequests()).Should(HaveLen(1))
+			Ω(pushed).Should(Equal(reporter.Metrics()))
+			Ω(pushed).Should(ContainSubstring(`ginkgo_suite_succeeded{suite="My \"test\" suite"} 0`))
+			Ω(buffer.String()).Should(BeEmpty())
+		})
+
+		It("should report failures to push", func() {
+			server.AppendHandlers(ghttp.RespondWith(http.StatusInternalServerError, ""))
+
+			reporter = reporters.NewMetricsReporter(buffer, "", server.URL(), "")
+			begin()
+			complete()
+			end()
+
+			Ω(server.ReceivedRequests()).Should(HaveLen(1))
+			Ω(server.ReceivedRequests()[0].URL.Path).Should(HavePrefix("/metrics/job/ginkgo/"))
+			Ω(buffer.String()).Should(ContainSubstring("Failed to push metrics:"))
+			Ω(buffer.String()).Should(ContainSubstring("unexpected status code 500"))
+		})
+	})
+})
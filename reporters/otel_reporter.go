@@ -0,0 +1,444 @@
+/*
+
+OpenTelemetry Reporter for Ginkgo
+
+The OpenTelemetry reporter exports the suite run as a trace: the suite is the root span, each spec (and the BeforeSuite
+and AfterSuite) is a child span and each node that ran within a spec is a grandchild span.  Failures become "exception"
+span events and measurements become "ginkgo.measurement" span events.
+
+The reporter is configured through the standard OpenTelemetry environment variables and is enabled whenever an OTLP
+endpoint is configured:
+
+	OTEL_EXPORTER_OTLP_ENDPOINT=http://localhost:4318 ginkgo
+
+Traces are sent with the OTLP/HTTP JSON encoding.  The following variables are honored:
+
+	OTEL_SDK_DISABLED, OTEL_TRACES_EXPORTER
+	OTEL_EXPORTER_OTLP_ENDPOINT, OTEL_EXPORTER_OTLP_TRACES_ENDPOINT
+	OTEL_EXPORTER_OTLP_HEADERS, OTEL_EXPORTER_OTLP_TRACES_HEADERS
+	OTEL_EXPORTER_OTLP_TIMEOUT, OTEL_EXPORTER_OTLP_TRACES_TIMEOUT
+	OTEL_EXPORTER_OTLP_PROTOCOL, OTEL_EXPORTER_OTLP_TRACES_PROTOCOL (only http/json is supported)
+	OTEL_SERVICE_NAME, OTEL_RESOURCE_ATTRIBUTES
+
+If TRACEPARENT is set (as it is by many CI systems) the suite span joins that trace.
+
+*/
+
+package reporters
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/onsi/ginkgo/config"
+	"github.com/onsi/ginkgo/internal/clock"
+	"github.com/onsi/ginkgo/types"
+)
+
+//OTelConfig holds the OpenTelemetry settings used by the OTelReporter
+type OTelConfig struct {
+	Endpoint           string
+	Protocol           string
+	Headers            map[string]string
+	Timeout            time.Duration
+	ResourceAttributes map[string]string
+	TraceParent        string
+}
+
+//OTelConfigFromEnvironment reads the OpenTelemetry settings from the standard environment variables.
+//It returns false if trace export is not configured, or is disabled.
+func OTelConfigFromEnvironment() (OTelConfig, bool) {
+	if strings.ToLower(os.Getenv("OTEL_SDK_DISABLED")) == "true" {
+		return OTelConfig{}, false
+	}
+	if exporters := os.Getenv("OTEL_TRACES_EXPORTER"); exporters != "" && !strings.Contains(exporters, "otlp") {
+		return OTelConfig{}, false
+	}
+
+	otelConfig := OTelConfig{
+		Protocol:           "http/json",
+		Headers:            parseOTelKeyValues(os.Getenv("OTEL_EXPORTER_OTLP_HEADERS")),
+		Timeout:            10 * time.Second,
+		ResourceAttributes: parseOTelKeyValues(os.Getenv("OTEL_RESOURCE_ATTRIBUTES")),
+		TraceParent:        os.Getenv("TRACEPARENT"),
+	}
+
+	if endpoint := os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"); endpoint != "" {
+		otelConfig.Endpoint = endpoint
+	} else if endpoint := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT"); endpoint != "" {
+		otelConfig.Endpoint = strings.TrimSuffix(endpoint, "/") + "/v1/traces"
+	} else {
+		return OTelConfig{}, false
+	}
+
+	for key, value := range parseOTelKeyValues(os.Getenv("OTEL_EXPORTER_OTLP_TRACES_HEADERS")) {
+		otelConfig.Headers[key] = value
+	}
+	for _, name := range []string{"OTEL_EXPORTER_OTLP_PROTOCOL", "OTEL_EXPORTER_OTLP_TRACES_PROTOCOL"} {
+		if protocol := os.Getenv(name); protocol != "" {
+			otelConfig.Protocol = protocol
+		}
+	}
+	for _, name := range []string{"OTEL_EXPORTER_OTLP_TIMEOUT", "OTEL_EXPORTER_OTLP_TRACES_TIMEOUT"} {
+		if milliseconds, err := strconv.Atoi(os.Getenv(name)); err == nil && milliseconds > 0 {
+			otelConfig.Timeout = time.Duration(milliseconds) * time.Millisecond
+		}
+	}
+	if serviceName := os.Getenv("OTEL_SERVICE_NAME"); serviceName != "" {
+		otelConfig.ResourceAttributes["service.name"] = serviceName
+	}
+	if otelConfig.ResourceAttributes["service.name"] == "" {
+		otelConfig.ResourceAttributes["service.name"] = "ginkgo"
+	}
+
+	return otelConfig, true
+}
+
+func parseOTelKeyValues(s string) map[string]string {
+	result := map[string]string{}
+	for _, pair := range strings.Split(s, ",") {
+		components := strings.SplitN(pair, "=", 2)
+		if len(components) != 2 {
+			continue
+		}
+		key, keyErr := url.QueryUnescape(strings.TrimSpace(components[0]))
+		value, valueErr := url.QueryUnescape(strings.TrimSpace(components[1]))
+		if keyErr != nil || valueErr != nil || key == "" {
+			continue
+		}
+		result[key] = value
+	}
+	return result
+}
+
+type OTelReporter struct {
+	config OTelConfig
+	client *http.Client
+
+	traceID      string
+	parentSpanID string
+	suiteSpanID  string
+	startTime    time.Time
+	ginkgoConfig config.GinkgoConfigType
+	spans        []otlpSpan
+}
+
+//NewOTelReporter creates a new reporter that exports the suite run as a trace to the endpoint in otelConfig
+func NewOTelReporter(otelConfig OTelConfig) *OTelReporter {
+	return &OTelReporter{
+		config: otelConfig,
+		client: &http.Client{Timeout: otelConfig.Timeout},
+	}
+}
+
+//TraceID returns the ID of the trace generated for the current suite run
+func (reporter *OTelReporter) TraceID() string {
+	return reporter.traceID
+}
+
+func (reporter *OTelReporter) SpecSuiteWillBegin(ginkgoConfig config.GinkgoConfigType, summary *types.SuiteSummary) {
+	reporter.traceID, reporter.parentSpanID = parseTraceParent(reporter.config.TraceParent)
+	if reporter.traceID == "" {
+		reporter.traceID = randomOTelID(16)
+	}
+	reporter.suiteSpanID = randomOTelID(8)
+	reporter.startTime = clock.Now()
+	reporter.ginkgoConfig = ginkgoConfig
+	reporter.spans = []otlpSpan{}
+}
+
+func (reporter *OTelReporter) BeforeSuiteDidRun(setupSummary *types.SetupSummary) {
+	reporter.addSetupSpan(setupSummary)
+}
+
+func (reporter *OTelReporter) SpecWillRun(specSummary *types.SpecSummary) {
+}
+
+func (reporter *OTelReporter) SpecDidComplete(specSummary *types.SpecSummary) {
+	if specSummary.Pending() || specSummary.Skipped() || specSummary.StartTime.IsZero() {
+		return
+	}
+
+	specSpan := reporter.newSpan(reporter.suiteSpanID, SpecDisplayText(specSummary), specSummary.StartTime, specSummary.RunTime, specSummary.State, specSummary.Failure)
+	location := specSummary.ComponentCodeLocations[len(specSummary.ComponentCodeLocations)-1]
+	specSpan.Attributes = append(specSpan.Attributes,
+		otlpStringAttribute("code.filepath", location.FileName),
+		otlpIntAttribute("code.lineno", location.LineNumber),
+		otlpStringAttribute("ginkgo.spec.state", specSummary.State.String()),
+	)
+	for _, measurement := range specSummary.Measurements {
+		specSpan.Events = append(specSpan.Events, otlpEvent{
+			TimeUnixNano: otlpTime(specSummary.StartTime.Add(specSummary.RunTime)),
+			Name:         "ginkgo.measurement",
+			Attributes: []otlpAttribute{
+				otlpStringAttribute("ginkgo.measurement.name", measurement.Name),
+				otlpStringAttribute("ginkgo.measurement.units", measurement.Units),
+				otlpDoubleAttribute("ginkgo.measurement.smallest", measurement.Smallest),
+				otlpDoubleAttribute("ginkgo.measurement.largest", measurement.Largest),
+				otlpDoubleAttribute("ginkgo.measurement.average", measurement.Average),
+				otlpDoubleAttribute("ginkgo.measurement.std_deviation", measurement.StdDeviation),
+			},
+		})
+	}
+	reporter.spans = append(reporter.spans, specSpan)
+
+	for _, node := range specSummary.NodeSummaries {
+		name := "[" + node.ComponentType.String() + "]"
+		if node.ComponentType == types.SpecComponentTypeIt || node.ComponentType == types.SpecComponentTypeMeasure {
+			texts := specSummary.TextsToDisplay()
+			name += " " + texts[len(texts)-1]
+		}
+		nodeSpan := reporter.newSpan(specSpan.SpanID, name, node.StartTime, node.RunTime, node.State, node.Failure)
+		nodeSpan.Attributes = append(nodeSpan.Attributes,
+			otlpStringAttribute("code.filepath", node.CodeLocation.FileName),
+			otlpIntAttribute("code.lineno", node.CodeLocation.LineNumber),
+			otlpStringAttribute("ginkgo.node.type", node.ComponentType.String()),
+		)
+		reporter.spans = append(reporter.spans, nodeSpan)
+	}
+}
+
+func (reporter *OTelReporter) AfterSuiteDidRun(setupSummary *types.SetupSummary) {
+	reporter.addSetupSpan(setupSummary)
+}
+
+func (reporter *OTelReporter) SpecSuiteDidEnd(summary *types.SuiteSummary) {
+	state := types.SpecStatePassed
+	if !summary.SuiteSucceeded {
+		state = types.SpecStateFailed
+	}
+	suiteSpan := reporter.newSpan(reporter.parentSpanID, summary.SuiteDescription, reporter.startTime, clock.Since(reporter.startTime), state, types.SpecFailure{})
+	suiteSpan.SpanID = reporter.suiteSpanID
+	suiteSpan.Attributes = append(suiteSpan.Attributes,
+		otlpStringAttribute("ginkgo.suite.id", summary.SuiteID),
+		otlpIntAttribute("ginkgo.random_seed", int(reporter.ginkgoConfig.RandomSeed)),
+		otlpBoolAttribute("ginkgo.suite.succeeded", summary.SuiteSucceeded),
+		otlpIntAttribute("ginkgo.specs.total", summary.NumberOfTotalSpecs),
+		otlpIntAttribute("ginkgo.specs.passed", summary.NumberOfPassedSpecs),
+		otlpIntAttribute("ginkgo.specs.failed", summary.NumberOfFailedSpecs),
+		otlpIntAttribute("ginkgo.specs.pending", summary.NumberOfPendingSpecs),
+		otlpIntAttribute("ginkgo.specs.skipped", summary.NumberOfSkippedSpecs),
+		otlpIntAttribute("ginkgo.specs.flaked", summary.NumberOfFlakedSpecs),
+	)
+	if len(summary.SuiteLabels) > 0 {
+		suiteSpan.Attributes = append(suiteSpan.Attributes, otlpStringAttribute("ginkgo.suite.labels", strings.Join(summary.SuiteLabels, ",")))
+	}
+	for _, key := range SortedSuiteMetadataKeys(summary) {
+		suiteSpan.Attributes = append(suiteSpan.Attributes, otlpStringAttribute("ginkgo.suite.metadata."+key, summary.SuiteMetadata[key]))
+	}
+	spans := append([]otlpSpan{suiteSpan}, reporter.spans...)
+
+	err := reporter.export(spans)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\nFailed to export OpenTelemetry trace to %s:\n\t%s", reporter.config.Endpoint, err.Error())
+		return
+	}
+	fmt.Printf("\nOpenTelemetry trace %s was exported to %s\n", reporter.traceID, reporter.config.Endpoint)
+}
+
+func (reporter *OTelReporter) addSetupSpan(setupSummary *types.SetupSummary) {
+	if setupSummary.State == types.SpecStateInvalid || setupSummary.StartTime.IsZero() {
+		return
+	}
+	span := reporter.newSpan(reporter.suiteSpanID, "["+setupSummary.ComponentType.String()+"]", setupSummary.StartTime, setupSummary.RunTime, setupSummary.State, setupSummary.Failure)
+	span.Attributes = append(span.Attributes,
+		otlpStringAttribute("code.filepath", setupSummary.CodeLocation.FileName),
+		otlpIntAttribute("code.lineno", setupSummary.CodeLocation.LineNumber),
+		otlpStringAttribute("ginkgo.node.type", setupSummary.ComponentType.String()),
+	)
+	reporter.spans = append(reporter.spans, span)
+}
+
+func (reporter *OTelReporter) newSpan(parentSpanID string, name string, startTime time.Time, runTime time.Duration, state types.SpecState, failure types.SpecFailure) otlpSpan {
+	span := otlpSpan{
+		TraceID:           reporter.traceID,
+		SpanID:            randomOTelID(8),
+		ParentSpanID:      parentSpanID,
+		Name:              name,
+		Kind:              otlpSpanKindInternal,
+		StartTimeUnixNano: otlpTime(startTime),
+		EndTimeUnixNano:   otlpTime(startTime.Add(runTime)),
+		Attributes:        []otlpAttribute{},
+		Events:            []otlpEvent{},
+		Status:            otlpStatus{Code: otlpStatusCodeOk},
+	}
+	if state.IsFailure() {
+		span.Status = otlpStatus{Code: otlpStatusCodeError, Message: failure.Message}
+		if failure.Message != "" {
+			event := otlpEvent{
+				TimeUnixNano: span.EndTimeUnixNano,
+				Name:         "exception",
+				Attributes: []otlpAttribute{
+					otlpStringAttribute("exception.type", "ginkgo."+state.String()),
+					otlpStringAttribute("exception.message", failure.Message),
+					otlpStringAttribute("code.filepath", failure.Location.FileName),
+					otlpIntAttribute("code.lineno", failure.Location.LineNumber),
+				},
+			}
+			if failure.Location.FullStackTrace != "" {
+				event.Attributes = append(event.Attributes, otlpStringAttribute("exception.stacktrace", failure.Location.FullStackTrace))
+			}
+			if failure.ForwardedPanic != "" {
+				event.Attributes = append(event.Attributes, otlpStringAttribute("ginkgo.forwarded_panic", failure.ForwardedPanic))
+			}
+			span.Events = append(span.Events, event)
+		}
+	}
+	return span
+}
+
+func (reporter *OTelReporter) export(spans []otlpSpan) error {
+	if reporter.config.Protocol != "http/json" {
+		return fmt.Errorf("unsupported OTLP protocol %q, only http/json is supported", reporter.config.Protocol)
+	}
+
+	resourceAttributes := []otlpAttribute{}
+	for key, value := range reporter.config.ResourceAttributes {
+		resourceAttributes = append(resourceAttributes, otlpStringAttribute(key, value))
+	}
+	request := otlpTraceRequest{
+		ResourceSpans: []otlpResourceSpans{{
+			Resource: otlpResource{Attributes: resourceAttributes},
+			ScopeSpans: []otlpScopeSpans{{
+				Scope: otlpScope{Name: "github.com/onsi/ginkgo", Version: config.VERSION},
+				Spans: spans,
+			}},
+		}},
+	}
+
+	data, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest("POST", reporter.config.Endpoint, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range reporter.config.Headers {
+		req.Header.Set(key, value)
+	}
+	resp, err := reporter.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func parseTraceParent(traceParent string) (string, string) {
+	components := strings.Split(strings.TrimSpace(traceParent), "-")
+	if len(components) != 4 || len(components[1]) != 32 || len(components[2]) != 16 {
+		return "", ""
+	}
+	if _, err := hex.DecodeString(components[1] + components[2]); err != nil {
+		return "", ""
+	}
+	return components[1], components[2]
+}
+
+func randomOTelID(length int) string {
+	id := make([]byte, length)
+	rand.Read(id)
+	return hex.EncodeToString(id)
+}
+
+//The types below implement the subset of the OTLP/HTTP JSON encoding used by the OTelReporter
+
+const (
+	otlpSpanKindInternal = 1
+	otlpStatusCodeOk     = 1
+	otlpStatusCodeError  = 2
+)
+
+type otlpTraceRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpAttribute `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type otlpSpan struct {
+	TraceID           string          `json:"traceId"`
+	SpanID            string          `json:"spanId"`
+	ParentSpanID      string          `json:"parentSpanId,omitempty"`
+	Name              string          `json:"name"`
+	Kind              int             `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []otlpAttribute `json:"attributes"`
+	Events            []otlpEvent     `json:"events"`
+	Status            otlpStatus      `json:"status"`
+}
+
+type otlpEvent struct {
+	TimeUnixNano string          `json:"timeUnixNano"`
+	Name         string          `json:"name"`
+	Attributes   []otlpAttribute `json:"attributes"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpAttribute struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+}
+
+func otlpTime(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
+
+func otlpStringAttribute(key string, value string) otlpAttribute {
+	return otlpAttribute{Key: key, Value: otlpAnyValue{StringValue: &value}}
+}
+
+func otlpIntAttribute(key string, value int) otlpAttribute {
+	s := strconv.Itoa(value)
+	return otlpAttribute{Key: key, Value: otlpAnyValue{IntValue: &s}}
+}
+
+func otlpDoubleAttribute(key string, value float64) otlpAttribute {
+	return otlpAttribute{Key: key, Value: otlpAnyValue{DoubleValue: &value}}
+}
+
+func otlpBoolAttribute(key string, value bool) otlpAttribute {
+	return otlpAttribute{Key: key, Value: otlpAnyValue{BoolValue: &value}}
+}
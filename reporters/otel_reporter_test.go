@@ -0,0 +1,328 @@
+package reporters_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/config"
+	"github.com/onsi/ginkgo/reporters"
+	"github.com/onsi/ginkgo/types"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+type otlpValue struct {
+	StringValue *string
+	IntValue    *string
+	BoolValue   *bool
+	DoubleValue *float64
+}
+
+type otlpAttributes []struct {
+	Key   string
+	Value otlpValue
+}
+
+func (attributes otlpAttributes) get(key string) interface{} {
+	for _, attribute := range attributes {
+		if attribute.Key == key {
+			switch {
+			case attribute.Value.StringValue != nil:
+				return *attribute.Value.StringValue
+			case attribute.Value.IntValue != nil:
+				return *attribute.Value.IntValue
+			case attribute.Value.BoolValue != nil:
+				return *attribute.Value.BoolValue
+			case attribute.Value.DoubleValue != nil:
+				return *attribute.Value.DoubleValue
+			}
+		}
+	}
+	return nil
+}
+
+type otlpTestSpan struct {
+	TraceID           string
+	SpanID            string
+	ParentSpanID      string
+	Name              string
+	StartTimeUnixNano string
+	EndTimeUnixNano   string
+	Attributes        otlpAttributes
+	Events            []struct {
+		Name       string
+		Attributes otlpAttributes
+	}
+	Status struct {
+		Code    int
+		Message string
+	}
+}
+
+type otlpTestRequest struct {
+	ResourceSpans []struct {
+		Resource struct {
+			Attributes otlpAttributes
+		}
+		ScopeSpans []struct {
+			Scope struct {
+				Name    string
+				Version string
+			}
+			Spans []otlpTestSpan
+		}
+	}
+}
+
+var _ = Describe("OpenTelemetry Reporter", func() {
+	otelEnvironment := []string{
+		"OTEL_SDK_DISABLED", "OTEL_TRACES_EXPORTER",
+		"OTEL_EXPORTER_OTLP_ENDPOINT", "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT",
+		"OTEL_EXPORTER_OTLP_HEADERS", "OTEL_EXPORTER_OTLP_TRACES_HEADERS",
+		"OTEL_EXPORTER_OTLP_TIMEOUT", "OTEL_EXPORTER_OTLP_TRACES_TIMEOUT",
+		"OTEL_EXPORTER_OTLP_PROTOCOL", "OTEL_EXPORTER_OTLP_TRACES_PROTOCOL",
+		"OTEL_SERVICE_NAME", "OTEL_RESOURCE_ATTRIBUTES", "TRACEPARENT",
+	}
+	var savedEnvironment map[string]string
+
+	BeforeEach(func() {
+		savedEnvironment = map[string]string{}
+		for _, name := range otelEnvironment {
+			if value, ok := os.LookupEnv(name); ok {
+				savedEnvironment[name] = value
+			}
+			os.Unsetenv(name)
+		}
+	})
+
+	AfterEach(func() {
+		for _, name := range otelEnvironment {
+			os.Unsetenv(name)
+		}
+		for name, value := range savedEnvironment {
+			os.Setenv(name, value)
+		}
+	})
+
+	Describe("OTelConfigFromEnvironment", func() {
+		It("should be disabled when no endpoint is configured", func() {
+			_, ok := reporters.OTelConfigFromEnvironment()
+			Ω(ok).Should(BeFalse())
+		})
+
+		It("should derive the traces endpoint from the base endpoint", func() {
+			os.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://collector:4318/")
+			otelConfig, ok := reporters.OTelConfigFromEnvironment()
+			Ω(ok).Should(BeTrue())
+			Ω(otelConfig.Endpoint).Should(Equal("http://collector:4318/v1/traces"))
+			Ω(otelConfig.Protocol).Should(Equal("http/json"))
+			Ω(otelConfig.Timeout).Should(Equal(10 * time.Second))
+			Ω(otelConfig.ResourceAttributes).Should(Equal(map[string]string{"service.name": "ginkgo"}))
+		})
+
+		It("should prefer the trace specific variables", func() {
+			os.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://collector:4318")
+			os.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "http://traces:4318/custom")
+			os.Setenv("OTEL_EXPORTER_OTLP_HEADERS", "a=1,b=2")
+			os.Setenv("OTEL_EXPORTER_OTLP_TRACES_HEADERS", "b=3,Authorization=Bearer%20token")
+			os.Setenv("OTEL_EXPORTER_OTLP_TIMEOUT", "500")
+			os.Setenv("OTEL_EXPORTER_OTLP_TRACES_TIMEOUT", "2000")
+			os.Setenv("OTEL_EXPORTER_OTLP_TRACES_PROTOCOL", "grpc")
+			os.Setenv("OTEL_RESOURCE_ATTRIBUTES", "service.name=from-attributes,team=core")
+			os.Setenv("OTEL_SERVICE_NAME", "my-suite")
+			os.Setenv("TRACEPARENT", "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01")
+
+			otelConfig, ok := reporters.OTelConfigFromEnvironment()
+			Ω(ok).Should(BeTrue())
+			Ω(otelConfig.Endpoint).Should(Equal("http://traces:4318/custom"))
+			Ω(otelConfig.Headers).Should(Equal(map[string]string{"a": "1", "b": "3", "Authorization": "Bearer token"}))
+			Ω(otelConfig.Timeout).Should(Equal(2 * time.Second))
+			Ω(otelConfig.Protocol).Should(Equal("grpc"))
+			Ω(otelConfig.ResourceAttributes).Should(Equal(map[string]string{"service.name": "my-suite", "team": "core"}))
+			Ω(otelConfig.TraceParent).Should(Equal("00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"))
+		})
+
+		It("should be disabled when the SDK or the otlp exporter is disabled", func() {
+			os.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://collector:4318")
+			os.Setenv("OTEL_TRACES_EXPORTER", "none")
+			_, ok := reporters.OTelConfigFromEnvironment()
+			Ω(ok).Should(BeFalse())
+
+			os.Setenv("OTEL_TRACES_EXPORTER", "otlp")
+			os.Setenv("OTEL_SDK_DISABLED", "true")
+			_, ok = reporters.OTelConfigFromEnvironment()
+			Ω(ok).Should(BeFalse())
+		})
+	})
+
+	Describe("exporting the suite", func() {
+		var (
+			server   *ghttp.Server
+			reporter *reporters.OTelReporter
+			request  otlpTestRequest
+			spans    map[string]otlpTestSpan
+			start    time.Time
+		)
+
+		BeforeEach(func() {
+			server = ghttp.NewServer()
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("POST", "/v1/traces"),
+				ghttp.VerifyContentType("application/json"),
+				ghttp.VerifyHeaderKV("X-Tenant", "core"),
+				func(w http.ResponseWriter, req *http.Request) {
+					body, err := ioutil.ReadAll(req.Body)
+					Ω(err).ShouldNot(HaveOccurred())
+					Ω(json.Unmarshal(body, &request)).Should(Succeed())
+				},
+			))
+
+			reporter = reporters.NewOTelReporter(reporters.OTelConfig{
+				Endpoint:           server.URL() + "/v1/traces",
+				Protocol:           "http/json",
+				Headers:            map[string]string{"X-Tenant": "core"},
+				Timeout:            time.Second,
+				ResourceAttributes: map[string]string{"service.name": "ginkgo"},
+			})
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		run := func() {
+			start = time.Now()
+			reporter.SpecSuiteWillBegin(config.GinkgoConfigType{RandomSeed: 42}, &types.SuiteSummary{SuiteDescription: "My test suite"})
+			reporter.BeforeSuiteDidRun(&types.SetupSummary{
+				ComponentType: types.SpecComponentTypeBeforeSuite,
+				State:         types.SpecStatePassed,
+				StartTime:     start,
+				RunTime:       time.Millisecond,
+			})
+			reporter.SpecDidComplete(&types.SpecSummary{
+				ComponentTexts:         []string{"[Top Level]", "A", "fails"},
+				ComponentCodeLocations: []types.CodeLocation{{}, {}, {FileName: "a_test.go", LineNumber: 12}},
+				State:                  types.SpecStateFailed,
+				StartTime:              start,
+				RunTime:                2 * time.Second,
+				Failure:                types.SpecFailure{Message: "boom", Location: types.CodeLocation{FileName: "a_test.go", LineNumber: 13}},
+				Measurements: map[string]*types.SpecMeasurement{
+					"speed": {Name: "speed", Units: "s", Average: 1.5},
+				},
+				NodeSummaries: []*types.NodeSummary{
+					{ComponentType: types.SpecComponentTypeBeforeEach, State: types.SpecStatePassed, StartTime: start, RunTime: time.Second},
+					{ComponentType: types.SpecComponentTypeIt, State: types.SpecStateFailed, StartTime: start.Add(time.Second), RunTime: time.Second,
+						Failure: types.SpecFailure{Message: "boom", Location: types.CodeLocation{FileName: "a_test.go", LineNumber: 13}}},
+				},
+			})
+			reporter.SpecDidComplete(&types.SpecSummary{
+				ComponentTexts:         []string{"[Top Level]", "A", "is pending"},
+				ComponentCodeLocations: []types.CodeLocation{{}, {}, {}},
+				State:                  types.SpecStatePending,
+			})
+			reporter.AfterSuiteDidRun(&types.SetupSummary{ComponentType: types.SpecComponentTypeAfterSuite})
+			reporter.SpecSuiteDidEnd(&types.SuiteSummary{
+				SuiteDescription:    "My test suite",
+				SuiteSucceeded:      false,
+				NumberOfFailedSpecs: 1,
+				SuiteLabels:         []string{"nightly", "storage"},
+				SuiteMetadata:       map[string]string{"gitSHA": "abc123"},
+			})
+
+			Ω(server.ReceivedRequests()).Should(HaveLen(1))
+			Ω(request.ResourceSpans).Should(HaveLen(1))
+			Ω(request.ResourceSpans[0].ScopeSpans).Should(HaveLen(1))
+			spans = map[string]otlpTestSpan{}
+			for _, span := range request.ResourceSpans[0].ScopeSpans[0].Spans {
+				spans[span.Name] = span
+			}
+		}
+
+		It("should export the suite as the root span, specs as children and nodes as grandchildren", func() {
+			run()
+			Ω(request.ResourceSpans[0].Resource.Attributes.get("service.name")).Should(Equal("ginkgo"))
+			Ω(request.ResourceSpans[0].ScopeSpans[0].Scope.Name).Should(Equal("github.com/onsi/ginkgo"))
+			Ω(spans).Should(HaveLen(5))
+
+			suiteSpan := spans["My test suite"]
+			Ω(suiteSpan.TraceID).Should(Equal(reporter.TraceID()))
+			Ω(suiteSpan.TraceID).Should(HaveLen(32))
+			Ω(suiteSpan.SpanID).Should(HaveLen(16))
+			Ω(suiteSpan.ParentSpanID).Should(BeEmpty())
+			Ω(suiteSpan.Status.Code).Should(Equal(2))
+			Ω(suiteSpan.Attributes.get("ginkgo.random_seed")).Should(Equal("42"))
+			Ω(suiteSpan.Attributes.get("ginkgo.suite.succeeded")).Should(Equal(false))
+			Ω(suiteSpan.Attributes.get("ginkgo.specs.failed")).Should(Equal("1"))
+			Ω(suiteSpan.Attributes.get("ginkgo.suite.labels")).Should(Equal("nightly,storage"))
+			Ω(suiteSpan.Attributes.get("ginkgo.suite.metadata.gitSHA")).Should(Equal("abc123"))
+
+			Ω(spans["[BeforeSuite]"].ParentSpanID).Should(Equal(suiteSpan.SpanID))
+			Ω(spans["[BeforeSuite]"].Status.Code).Should(Equal(1))
+
+			specSpan := spans["A fails"]
+			Ω(specSpan.TraceID).Should(Equal(suiteSpan.TraceID))
+			Ω(specSpan.ParentSpanID).Should(Equal(suiteSpan.SpanID))
+			Ω(specSpan.Status.Code).Should(Equal(2))
+			Ω(specSpan.Status.Message).Should(Equal("boom"))
+			Ω(specSpan.Attributes.get("code.filepath")).Should(Equal("a_test.go"))
+			Ω(specSpan.Attributes.get("code.lineno")).Should(Equal("12"))
+			Ω(specSpan.Attributes.get("ginkgo.spec.state")).Should(Equal("failed"))
+
+			Ω(spans["[BeforeEach]"].ParentSpanID).Should(Equal(specSpan.SpanID))
+			Ω(spans["[BeforeEach]"].Status.Code).Should(Equal(1))
+			Ω(spans["[It] fails"].ParentSpanID).Should(Equal(specSpan.SpanID))
+			Ω(spans["[It] fails"].Status.Code).Should(Equal(2))
+		})
+
+		It("should time the spans", func() {
+			run()
+			specSpan := spans["A fails"]
+			Ω(specSpan.StartTimeUnixNano).Should(Equal(nanos(start)))
+			Ω(specSpan.EndTimeUnixNano).Should(Equal(nanos(start.Add(2 * time.Second))))
+			Ω(spans["[It] fails"].StartTimeUnixNano).Should(Equal(nanos(start.Add(time.Second))))
+		})
+
+		It("should record failures and measurements as span events", func() {
+			run()
+			specSpan := spans["A fails"]
+			Ω(specSpan.Events).Should(HaveLen(2))
+			Ω(specSpan.Events[0].Name).Should(Equal("exception"))
+			Ω(specSpan.Events[0].Attributes.get("exception.message")).Should(Equal("boom"))
+			Ω(specSpan.Events[0].Attributes.get("exception.type")).Should(Equal("ginkgo.failed"))
+			Ω(specSpan.Events[0].Attributes.get("code.lineno")).Should(Equal("13"))
+			Ω(specSpan.Events[1].Name).Should(Equal("ginkgo.measurement"))
+			Ω(specSpan.Events[1].Attributes.get("ginkgo.measurement.name")).Should(Equal("speed"))
+			Ω(specSpan.Events[1].Attributes.get("ginkgo.measurement.average")).Should(Equal(1.5))
+
+			Ω(spans["[It] fails"].Events).Should(HaveLen(1))
+			Ω(spans["[BeforeEach]"].Events).Should(BeEmpty())
+		})
+
+		Context("when a trace parent is configured", func() {
+			BeforeEach(func() {
+				reporter = reporters.NewOTelReporter(reporters.OTelConfig{
+					Endpoint:    server.URL() + "/v1/traces",
+					Protocol:    "http/json",
+					Headers:     map[string]string{"X-Tenant": "core"},
+					TraceParent: "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01",
+				})
+			})
+
+			It("should join the parent trace", func() {
+				run()
+				Ω(spans["My test suite"].TraceID).Should(Equal("0af7651916cd43dd8448eb211c80319c"))
+				Ω(spans["My test suite"].ParentSpanID).Should(Equal("b7ad6b7169203331"))
+				Ω(spans["A fails"].TraceID).Should(Equal("0af7651916cd43dd8448eb211c80319c"))
+			})
+		})
+	})
+})
+
+func nanos(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
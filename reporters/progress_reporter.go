@@ -0,0 +1,244 @@
+/*
+
+Progress Reporter for Ginkgo
+
+The progress reporter serves the current state of a suite run as JSON over HTTP, so that watchdogs and dashboards can poll
+a long-running suite:
+
+	ginkgo -progressAddress=:8080
+
+serves, while the suite runs:
+
+	/progress   the number of specs run so far, by outcome, the spec currently running and whether the suite has finished
+	/specs      the summaries of the specs that completed so far
+	/report     the JSON report of the suite run so far, in the format written by -jsonReport
+
+When running in parallel the endpoint is served by the Ginkgo CLI, which aggregates the results of all the nodes.
+
+*/
+
+package reporters
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/onsi/ginkgo/config"
+	"github.com/onsi/ginkgo/formatter"
+	"github.com/onsi/ginkgo/internal/clock"
+	"github.com/onsi/ginkgo/types"
+)
+
+//Progress is the document served by the ProgressReporter under /progress
+type Progress struct {
+	SuiteDescription string
+	StartTime        time.Time
+	Elapsed          time.Duration
+
+	//SpecsThatWillBeRun is -1 when it is not known up front, as when running in parallel
+	SpecsThatWillBeRun int
+	Completed          int
+	Passed             int
+	Failed             int
+	Pending            int
+	Skipped            int
+
+	//RunningSpec is the full text of the spec currently running, if any
+	RunningSpec string `json:",omitempty"`
+
+	Finished       bool
+	SuiteSucceeded bool
+}
+
+type ProgressReporter struct {
+	address string
+	writer  io.Writer
+
+	lock        *sync.Mutex
+	report      JSONReport
+	progress    Progress
+	runningSpec *types.SpecSummary
+
+	listener net.Listener
+	server   *http.Server
+	noColor  bool
+}
+
+//NewProgressReporter creates a new reporter serving the progress of the suite run on address.  Problems are reported to writer.
+func NewProgressReporter(writer io.Writer, address string) *ProgressReporter {
+	return &ProgressReporter{
+		address: address,
+		writer:  writer,
+		lock:    &sync.Mutex{},
+	}
+}
+
+//Address returns the address progress is served on, once the suite has begun.  This is useful when listening on port 0.
+func (reporter *ProgressReporter) Address() string {
+	reporter.lock.Lock()
+	defer reporter.lock.Unlock()
+	if reporter.listener == nil {
+		return ""
+	}
+	return reporter.listener.Addr().String()
+}
+
+func (reporter *ProgressReporter) SpecSuiteWillBegin(ginkgoConfig config.GinkgoConfigType, summary *types.SuiteSummary) {
+	startTime := clock.Now()
+
+	reporter.lock.Lock()
+	reporter.noColor = config.DefaultReporterConfig.NoColor
+	reporter.report = JSONReport{
+		SuiteDescription: summary.SuiteDescription,
+		SuiteID:          summary.SuiteID,
+		RandomSeed:       ginkgoConfig.RandomSeed,
+		StartTime:        startTime,
+		SuiteLabels:      summary.SuiteLabels,
+		SuiteMetadata:    summary.SuiteMetadata,
+		SetupSummaries:   []*types.SetupSummary{},
+		SpecSummaries:    []*types.SpecSummary{},
+	}
+	reporter.progress = Progress{
+		SuiteDescription:   summary.SuiteDescription,
+		StartTime:          startTime,
+		SpecsThatWillBeRun: summary.NumberOfSpecsThatWillBeRun,
+	}
+	reporter.runningSpec = nil
+	reporter.lock.Unlock()
+
+	listener, err := net.Listen("tcp", reporter.address)
+	if err != nil {
+		reporter.printError("Failed to serve progress:", err)
+		return
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/progress", func(w http.ResponseWriter, r *http.Request) {
+		reporter.serveJSON(w, reporter.Progress())
+	})
+	mux.HandleFunc("/specs", func(w http.ResponseWriter, r *http.Request) {
+		reporter.serveJSON(w, reporter.Specs())
+	})
+	mux.HandleFunc("/report", func(w http.ResponseWriter, r *http.Request) {
+		reporter.serveJSON(w, reporter.Report())
+	})
+	server := &http.Server{Handler: mux}
+	go server.Serve(listener)
+
+	reporter.lock.Lock()
+	reporter.listener = listener
+	reporter.server = server
+	reporter.lock.Unlock()
+}
+
+func (reporter *ProgressReporter) BeforeSuiteDidRun(setupSummary *types.SetupSummary) {
+	reporter.lock.Lock()
+	defer reporter.lock.Unlock()
+	reporter.report.SetupSummaries = append(reporter.report.SetupSummaries, setupSummary)
+}
+
+func (reporter *ProgressReporter) SpecWillRun(specSummary *types.SpecSummary) {
+	reporter.lock.Lock()
+	defer reporter.lock.Unlock()
+	reporter.runningSpec = specSummary
+}
+
+func (reporter *ProgressReporter) SpecDidComplete(specSummary *types.SpecSummary) {
+	reporter.lock.Lock()
+	defer reporter.lock.Unlock()
+	reporter.runningSpec = nil
+	reporter.report.SpecSummaries = append(reporter.report.SpecSummaries, specSummary)
+
+	switch {
+	case specSummary.Pending():
+		reporter.progress.Pending++
+	case specSummary.Skipped():
+		reporter.progress.Skipped++
+	case specSummary.Passed():
+		reporter.progress.Completed++
+		reporter.progress.Passed++
+	default:
+		reporter.progress.Completed++
+		reporter.progress.Failed++
+	}
+}
+
+func (reporter *ProgressReporter) AfterSuiteDidRun(setupSummary *types.SetupSummary) {
+	reporter.lock.Lock()
+	defer reporter.lock.Unlock()
+	reporter.report.SetupSummaries = append(reporter.report.SetupSummaries, setupSummary)
+}
+
+func (reporter *ProgressReporter) SpecSuiteDidEnd(summary *types.SuiteSummary) {
+	reporter.lock.Lock()
+	reporter.report.SuiteSucceeded = summary.SuiteSucceeded
+	reporter.report.RunTime = summary.RunTime
+	reporter.report.SuiteSummary = summary
+	reporter.report.FailuresByOwner = failuresByOwner(reporter.report.SpecSummaries)
+	reporter.report.FailuresBySeverity = failuresBySeverity(reporter.report.SpecSummaries)
+	reporter.progress.Elapsed = summary.RunTime
+	reporter.progress.Finished = true
+	reporter.progress.SuiteSucceeded = summary.SuiteSucceeded
+	server := reporter.server
+	reporter.listener, reporter.server = nil, nil
+	reporter.lock.Unlock()
+
+	if server != nil {
+		server.Close()
+	}
+}
+
+//Progress returns the progress of the suite run, as served under /progress
+func (reporter *ProgressReporter) Progress() Progress {
+	reporter.lock.Lock()
+	defer reporter.lock.Unlock()
+	progress := reporter.progress
+	if !progress.Finished {
+		progress.Elapsed = clock.Since(progress.StartTime)
+	}
+	if reporter.runningSpec != nil {
+		progress.RunningSpec = SpecDisplayText(reporter.runningSpec)
+	}
+	return progress
+}
+
+//Specs returns the summaries of the specs that completed so far, as served under /specs
+func (reporter *ProgressReporter) Specs() []*types.SpecSummary {
+	reporter.lock.Lock()
+	defer reporter.lock.Unlock()
+	return append([]*types.SpecSummary{}, reporter.report.SpecSummaries...)
+}
+
+//Report returns the JSON report of the suite run so far, as served under /report
+func (reporter *ProgressReporter) Report() JSONReport {
+	reporter.lock.Lock()
+	defer reporter.lock.Unlock()
+	report := reporter.report
+	report.SetupSummaries = append([]*types.SetupSummary{}, report.SetupSummaries...)
+	report.SpecSummaries = append([]*types.SpecSummary{}, report.SpecSummaries...)
+	if !reporter.progress.Finished {
+		report.RunTime = clock.Since(report.StartTime)
+	}
+	return report
+}
+
+func (reporter *ProgressReporter) serveJSON(w http.ResponseWriter, document interface{}) {
+	data, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
+
+func (reporter *ProgressReporter) printError(message string, err error) {
+	reporter.lock.Lock()
+	f := formatter.NewWithNoColorBool(reporter.noColor)
+	reporter.lock.Unlock()
+	fmt.Fprint(reporter.writer, f.F("\n{{orange}}%s{{/}}\n\t%s\n", message, err.Error()))
+}
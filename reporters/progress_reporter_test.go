@@ -0,0 +1,115 @@
+package reporters_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/config"
+	"github.com/onsi/ginkgo/reporters"
+	"github.com/onsi/ginkgo/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Progress Reporter", func() {
+	var (
+		buffer   *bytes.Buffer
+		reporter *reporters.ProgressReporter
+	)
+
+	summary := func(text string, state types.SpecState) *types.SpecSummary {
+		return &types.SpecSummary{
+			ComponentTexts: []string{"[Top Level]", "Suite", text},
+			State:          state,
+			RunTime:        time.Millisecond,
+		}
+	}
+
+	get := func(path string, document interface{}) {
+		resp, err := http.Get("http://" + reporter.Address() + path)
+		Ω(err).ShouldNot(HaveOccurred())
+		defer resp.Body.Close()
+		Ω(resp.Header.Get("Content-Type")).Should(Equal("application/json"))
+		Ω(json.NewDecoder(resp.Body).Decode(document)).Should(Succeed())
+	}
+
+	BeforeEach(func() {
+		buffer = &bytes.Buffer{}
+		reporter = reporters.NewProgressReporter(buffer, "127.0.0.1:0")
+		reporter.SpecSuiteWillBegin(config.GinkgoConfigType{RandomSeed: 17}, &types.SuiteSummary{SuiteDescription: "My test suite", NumberOfSpecsThatWillBeRun: 4})
+		reporter.BeforeSuiteDidRun(&types.SetupSummary{State: types.SpecStatePassed})
+		for _, specSummary := range []*types.SpecSummary{
+			summary("passes", types.SpecStatePassed),
+			summary("fails", types.SpecStateFailed),
+			summary("is pending", types.SpecStatePending),
+		} {
+			reporter.SpecWillRun(specSummary)
+			reporter.SpecDidComplete(specSummary)
+		}
+		reporter.SpecWillRun(summary("is running", types.SpecStateInvalid))
+	})
+
+	It("should serve the progress of the suite run under /progress", func() {
+		Ω(reporter.Address()).ShouldNot(BeEmpty())
+
+		var progress reporters.Progress
+		get("/progress", &progress)
+		Ω(progress.SuiteDescription).Should(Equal("My test suite"))
+		Ω(progress.SpecsThatWillBeRun).Should(Equal(4))
+		Ω(progress.Completed).Should(Equal(2))
+		Ω(progress.Passed).Should(Equal(1))
+		Ω(progress.Failed).Should(Equal(1))
+		Ω(progress.Pending).Should(Equal(1))
+		Ω(progress.RunningSpec).Should(Equal("Suite is running"))
+		Ω(progress.Elapsed).Should(BeNumerically(">", 0))
+		Ω(progress.Finished).Should(BeFalse())
+	})
+
+	It("should serve the summaries of the completed specs under /specs", func() {
+		var specs []*types.SpecSummary
+		get("/specs", &specs)
+		Ω(specs).Should(HaveLen(3))
+		Ω(specs[1].ComponentTexts).Should(Equal([]string{"[Top Level]", "Suite", "fails"}))
+		Ω(specs[1].State).Should(Equal(types.SpecStateFailed))
+	})
+
+	It("should serve the JSON report of the suite run so far under /report", func() {
+		var report reporters.JSONReport
+		get("/report", &report)
+		Ω(report.SuiteDescription).Should(Equal("My test suite"))
+		Ω(report.RandomSeed).Should(Equal(int64(17)))
+		Ω(report.SetupSummaries).Should(HaveLen(1))
+		Ω(report.SpecSummaries).Should(HaveLen(3))
+	})
+
+	It("should stop serving once the suite ends", func() {
+		address := reporter.Address()
+		specSummary := summary("is running", types.SpecStatePassed)
+		reporter.SpecDidComplete(specSummary)
+		reporter.SpecSuiteDidEnd(&types.SuiteSummary{SuiteSucceeded: false, RunTime: time.Second})
+
+		progress := reporter.Progress()
+		Ω(progress.Finished).Should(BeTrue())
+		Ω(progress.Elapsed).Should(Equal(time.Second))
+		Ω(progress.Completed).Should(Equal(3))
+		Ω(progress.RunningSpec).Should(BeEmpty())
+		Ω(reporter.Report().SuiteSummary.RunTime).Should(Equal(time.Second))
+
+		Ω(reporter.Address()).Should(BeEmpty())
+		_, err := http.Get("http://" + address + "/progress")
+		Ω(err).Should(HaveOccurred())
+	})
+
+	It("should report when it cannot listen on the address", func() {
+		other := reporters.NewProgressReporter(buffer, reporter.Address())
+		other.SpecSuiteWillBegin(config.GinkgoConfigType{}, &types.SuiteSummary{})
+		Ω(buffer.String()).Should(ContainSubstring("Failed to serve progress:"))
+		Ω(other.Address()).Should(BeEmpty())
+	})
+
+	AfterEach(func() {
+		reporter.SpecSuiteDidEnd(&types.SuiteSummary{})
+	})
+})
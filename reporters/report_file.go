@@ -0,0 +1,112 @@
+package reporters
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+//StdoutReportFile is the report file name that writes the report to stdout
+const StdoutReportFile = "-"
+
+//reportFilePath returns the absolute path of the report file filename, or StdoutReportFile as is
+func reportFilePath(filename string) string {
+	if filename == StdoutReportFile {
+		return filename
+	}
+	filePath, err := filepath.Abs(filename)
+	if err != nil {
+		return filename
+	}
+	return filePath
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+//gzipFile closes both the gzip stream and the file it compresses into
+type gzipFile struct {
+	*gzip.Writer
+	file *os.File
+}
+
+func (f gzipFile) Close() error {
+	err := f.Writer.Close()
+	if closeErr := f.file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+//createReportFile creates the report file filename, along with its missing parent directories, and returns the writer
+//the report is written to.  Closing the writer flushes the report.  All the reporters write their reports through it so
+//that they handle filename the same way: - writes the report to stdout, e.g. to pipe it out of a container, and a name
+//ending in .gz gzips it.  On Windows, paths longer than MAX_PATH are turned into extended-length paths, so that reports
+//can be written deep down a directory tree.
+func createReportFile(filename string) (io.WriteCloser, error) {
+	if filename == StdoutReportFile {
+		return nopWriteCloser{os.Stdout}, nil
+	}
+	filePath := reportFilePath(filename)
+	if err := os.MkdirAll(longPath(filepath.Dir(filePath)), os.ModePerm); err != nil {
+		return nil, err
+	}
+	file, err := os.Create(longPath(filePath))
+	if err != nil {
+		return nil, err
+	}
+	if strings.HasSuffix(filePath, ".gz") {
+		return gzipFile{Writer: gzip.NewWriter(file), file: file}, nil
+	}
+	return file, nil
+}
+
+//writeReportFile writes data to the report file filename, see createReportFile
+func writeReportFile(filename string, data []byte) error {
+	file, err := createReportFile(filename)
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(data)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+//readReportFile reads the report file filename, decompressing it when it is gzipped
+func readReportFile(filename string) ([]byte, error) {
+	data, err := ioutil.ReadFile(longPath(reportFilePath(filename)))
+	if err != nil || !strings.HasSuffix(filename, ".gz") {
+		return data, err
+	}
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return ioutil.ReadAll(reader)
+}
+
+//longPath turns the absolute path into an extended-length path on Windows when it would exceed MAX_PATH.  Directories
+//are limited to 248 characters, files to 260: the lower limit covers both.
+func longPath(path string) string {
+	if runtime.GOOS != "windows" || len(path) < 248 || strings.HasPrefix(path, `\\?\`) {
+		return path
+	}
+	path = filepath.Clean(path)
+	if strings.HasPrefix(path, `\\`) {
+		return `\\?\UNC\` + path[2:]
+	}
+	return `\\?\` + path
+}
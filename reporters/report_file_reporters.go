@@ -0,0 +1,94 @@
+package reporters
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/onsi/ginkgo/config"
+	colorable "github.com/onsi/ginkgo/reporters/stenographer/support/go-colorable"
+)
+
+/*
+NewReportFileReporters builds the reporters requested through the report file flags (-jsonReport, -historyDir, -allureResultsDir,
+-sonarReport, -xunitV2Report, -cucumberReport, -baselineReport), the timing store flags (-timingStore, -timingStoreURL), the metrics flags
+(-metricsAddress, -metricsPushgateway), the progress flag (-progressAddress), the notification flags (-notifyWebhook), the rerun flags (-rerunCommands, -rerunScript) and the
+OpenTelemetry environment variables.
+
+Relative paths are resolved against dir so that the Ginkgo CLI (which aggregates parallel runs) and the test process itself
+(which runs in the package directory) agree on where reports live.  Pass an empty dir to leave paths untouched.  A report
+file named - is written to stdout, and one whose name ends in .gz is gzipped.
+*/
+func NewReportFileReporters(ginkgoConfig config.GinkgoConfigType, reporterConfig config.DefaultReporterConfigType, dir string) []Reporter {
+	resolve := resolverFor(dir)
+
+	packageDir := packageDirFor(dir)
+	newJSONReporter := func(filename string) *JSONReporter {
+		reporter := NewJSONReporter(filename)
+		reporter.rerunStyle, reporter.packageDir = reporterConfig.RerunCommands, packageDir
+		return reporter
+	}
+
+	reporters := []Reporter{}
+	if reporterConfig.JSONReportFile != "" {
+		reporters = append(reporters, newJSONReporter(resolve(reporterConfig.JSONReportFile)))
+	}
+	if reporterConfig.HistoryDir != "" {
+		reporters = append(reporters, newJSONReporter(HistoryReportFile(resolve(reporterConfig.HistoryDir))))
+	}
+	if reporterConfig.AllureResultsDir != "" {
+		reporters = append(reporters, NewAllureReporter(resolve(reporterConfig.AllureResultsDir)))
+	}
+	if reporterConfig.SonarReportFile != "" {
+		reporters = append(reporters, NewSonarReporter(resolve(reporterConfig.SonarReportFile)))
+	}
+	if reporterConfig.XUnitV2ReportFile != "" {
+		reporters = append(reporters, NewXUnitV2Reporter(resolve(reporterConfig.XUnitV2ReportFile)))
+	}
+	if reporterConfig.CucumberReportFile != "" {
+		reporters = append(reporters, NewCucumberReporter(resolve(reporterConfig.CucumberReportFile)))
+	}
+	if reporterConfig.BaselineReportFile != "" {
+		threshold := time.Duration(reporterConfig.BaselineDurationThreshold * float64(time.Second))
+		reporters = append(reporters, NewBaselineReporter(colorable.NewColorableStdout(), resolve(reporterConfig.BaselineReportFile), resolve(reporterConfig.BaselineDiffFile), threshold))
+	}
+	if store := NewTimingStore(ginkgoConfig, dir); store != nil {
+		reporters = append(reporters, NewTimingReporter(colorable.NewColorableStdout(), store))
+	}
+	if reporterConfig.MetricsAddress != "" || reporterConfig.MetricsPushgatewayURL != "" {
+		reporters = append(reporters, NewMetricsReporter(colorable.NewColorableStdout(), reporterConfig.MetricsAddress, reporterConfig.MetricsPushgatewayURL, reporterConfig.MetricsJob))
+	}
+	if reporterConfig.ProgressAddress != "" {
+		reporters = append(reporters, NewProgressReporter(colorable.NewColorableStdout(), reporterConfig.ProgressAddress))
+	}
+	if reporterConfig.NotifyWebhookURL != "" {
+		reporters = append(reporters, NewWebhookReporter(colorable.NewColorableStdout(), reporterConfig.NotifyWebhookURL, resolve(reporterConfig.NotifyTemplateFile), reporterConfig.NotifyArtifactsURL))
+	}
+	if reporterConfig.RerunCommands != "none" || reporterConfig.RerunScriptFile != "" {
+		reporters = append(reporters, NewRerunReporter(colorable.NewColorableStdout(), reporterConfig.RerunCommands, resolve(reporterConfig.RerunScriptFile), packageDir))
+	}
+	if otelConfig, ok := OTelConfigFromEnvironment(); ok {
+		reporters = append(reporters, NewOTelReporter(otelConfig))
+	}
+	return reporters
+}
+
+//NewTimingStore returns the TimingStore configured by the -timingStore and -timingStoreURL flags, or nil if neither is set.
+//Relative paths are resolved against dir, as in NewReportFileReporters.
+func NewTimingStore(ginkgoConfig config.GinkgoConfigType, dir string) TimingStore {
+	if ginkgoConfig.TimingStoreURL != "" {
+		return NewHTTPTimingStore(ginkgoConfig.TimingStoreURL)
+	}
+	if ginkgoConfig.TimingStoreFile != "" {
+		return NewFileTimingStore(resolverFor(dir)(ginkgoConfig.TimingStoreFile))
+	}
+	return nil
+}
+
+func resolverFor(dir string) func(string) string {
+	return func(path string) string {
+		if path == "" || path == StdoutReportFile || dir == "" || filepath.IsAbs(path) {
+			return path
+		}
+		return filepath.Join(dir, path)
+	}
+}
@@ -0,0 +1,34 @@
+package reporters
+
+import (
+	"github.com/onsi/ginkgo/config"
+)
+
+//ConfigurableReporter is implemented by the reporters whose output depends on the reporter configuration, such as
+//-succinct, -v, -noColor or -reportPassed.  They follow the configuration of the flags unless they are given their own
+//with WithReporterConfig.
+type ConfigurableReporter interface {
+	Reporter
+	SetReporterConfig(reporterConfig config.DefaultReporterConfigType)
+}
+
+//ConfiguredReporter is a reporter along with its own reporter configuration, see WithReporterConfig
+type ConfiguredReporter struct {
+	ConfigurableReporter
+	ReporterConfig config.DefaultReporterConfigType
+}
+
+/*
+WithReporterConfig gives reporter its own reporter configuration rather than the one of the flags, which the other
+reporters of the suite keep sharing.  The suite configures the reporter with it before the suite begins.  For instance,
+to keep the captured output of passed specs in the JUnit report without printing it to the console:
+
+	junitConfig := config.DefaultReporterConfig
+	junitConfig.ReportPassed = true
+	RunSpecsWithDefaultAndCustomReporters(t, "Books Suite", []Reporter{
+		reporters.WithReporterConfig(reporters.NewJUnitReporter("junit.xml"), junitConfig),
+	})
+*/
+func WithReporterConfig(reporter ConfigurableReporter, reporterConfig config.DefaultReporterConfigType) ConfiguredReporter {
+	return ConfiguredReporter{ConfigurableReporter: reporter, ReporterConfig: reporterConfig}
+}
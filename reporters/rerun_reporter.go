@@ -0,0 +1,188 @@
+package reporters
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/onsi/ginkgo/config"
+	"github.com/onsi/ginkgo/types"
+)
+
+//FocusPattern returns the -focus regular expression that selects exactly the spec of specSummary in the suite described
+//by suiteDescription: the suite matches -focus against its description followed by the texts of the spec's containers
+//and its own, as written in the specs rather than as the text transformers display them
+func FocusPattern(suiteDescription string, specSummary *types.SpecSummary) string {
+	return "^" + regexp.QuoteMeta(suiteDescription+" "+strings.Join(specSummary.ComponentTexts, " ")) + "$"
+}
+
+//focusPatternScanningFilePath returns the -focus regular expression that selects exactly the spec of specSummary in a
+//suite run with -regexScansFilePath, which matches -focus against the file of the spec as well
+func focusPatternScanningFilePath(suiteDescription string, specSummary *types.SpecSummary) string {
+	text := suiteDescription + " " + strings.Join(specSummary.ComponentTexts, " ") + " "
+	locations := specSummary.ComponentCodeLocations
+	if len(locations) == 0 {
+		return "^" + regexp.QuoteMeta(text)
+	}
+	return "^" + regexp.QuoteMeta(text+locations[len(locations)-1].FileName) + "$"
+}
+
+/*
+RerunCommand returns the command that reruns the specs of specSummaries, with the seed and randomization of ginkgoConfig
+so that they run in the same order, in the package at packageDir.  style is the -rerunCommands style, any style but go
+giving the ginkgo command:
+
+	ginkgo  ginkgo -seed=1 -focus='^Suite \[Top Level\] fails$' /path/to/package
+	go      go test /path/to/package -ginkgo.seed=1 -ginkgo.focus='^Suite \[Top Level\] fails$'
+*/
+func RerunCommand(style string, ginkgoConfig config.GinkgoConfigType, suiteDescription string, packageDir string, specSummaries ...*types.SpecSummary) string {
+	patterns := []string{}
+	for _, specSummary := range specSummaries {
+		if ginkgoConfig.RegexScansFilePath {
+			patterns = append(patterns, focusPatternScanningFilePath(suiteDescription, specSummary))
+		} else {
+			patterns = append(patterns, FocusPattern(suiteDescription, specSummary))
+		}
+	}
+
+	prefix := "-"
+	if style == "go" {
+		prefix = "-ginkgo."
+	}
+	flags := []string{fmt.Sprintf("%sseed=%d", prefix, ginkgoConfig.RandomSeed)}
+	if ginkgoConfig.RandomizeAllSpecs {
+		flags = append(flags, prefix+"randomizeAllSpecs")
+	}
+	if ginkgoConfig.RegexScansFilePath {
+		flags = append(flags, prefix+"regexScansFilePath")
+	}
+	flags = append(flags, prefix+"focus="+shellQuote(strings.Join(patterns, "|")))
+
+	if style == "go" {
+		return "go test " + shellQuote(packageDir) + " " + strings.Join(flags, " ")
+	}
+	return "ginkgo " + strings.Join(flags, " ") + " " + shellQuote(packageDir)
+}
+
+//rerunCommands maps the full text of each failed spec of specSummaries to the command that reruns it
+func rerunCommands(style string, ginkgoConfig config.GinkgoConfigType, suiteDescription string, packageDir string, specSummaries []*types.SpecSummary) map[string]string {
+	commands := map[string]string{}
+	for _, specSummary := range failedSpecs(specSummaries) {
+		commands[SpecFullText(specSummary)] = RerunCommand(style, ginkgoConfig, suiteDescription, packageDir, specSummary)
+	}
+	if len(commands) == 0 {
+		return nil
+	}
+	return commands
+}
+
+func failedSpecs(specSummaries []*types.SpecSummary) []*types.SpecSummary {
+	failed := []*types.SpecSummary{}
+	for _, specSummary := range specSummaries {
+		if specSummary.HasFailureState() {
+			failed = append(failed, specSummary)
+		}
+	}
+	return failed
+}
+
+//shellQuote quotes s for POSIX shells, unless it only holds characters they leave alone
+func shellQuote(s string) string {
+	if s != "" && regexp.MustCompile(`^[A-Za-z0-9_./:=@%+-]+$`).MatchString(s) {
+		return s
+	}
+	return "'" + strings.Replace(s, "'", `'\''`, -1) + "'"
+}
+
+//packageDirFor returns the absolute path of dir, which is the working directory of the process when dir is empty: the
+//test process runs in the directory of its package
+func packageDirFor(dir string) string {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return dir
+	}
+	return abs
+}
+
+//RerunReporter prints the commands that rerun each failed spec once the suite ends, and writes a shell script rerunning
+//all of them
+type RerunReporter struct {
+	writer     io.Writer
+	style      string
+	scriptFile string
+	packageDir string
+
+	ginkgoConfig     config.GinkgoConfigType
+	suiteDescription string
+	specSummaries    []*types.SpecSummary
+}
+
+//NewRerunReporter creates a new reporter printing the rerun commands in style (ginkgo, go, or none to print nothing) to
+//writer, and writing the rerun script to scriptFile unless it is empty.  The commands rerun the specs in packageDir.
+func NewRerunReporter(writer io.Writer, style string, scriptFile string, packageDir string) *RerunReporter {
+	return &RerunReporter{
+		writer:     writer,
+		style:      style,
+		scriptFile: scriptFile,
+		packageDir: packageDir,
+	}
+}
+
+func (reporter *RerunReporter) SpecSuiteWillBegin(ginkgoConfig config.GinkgoConfigType, summary *types.SuiteSummary) {
+	reporter.ginkgoConfig = ginkgoConfig
+	reporter.suiteDescription = summary.SuiteDescription
+	reporter.specSummaries = []*types.SpecSummary{}
+}
+
+func (reporter *RerunReporter) BeforeSuiteDidRun(setupSummary *types.SetupSummary) {
+}
+
+func (reporter *RerunReporter) SpecWillRun(specSummary *types.SpecSummary) {
+}
+
+func (reporter *RerunReporter) SpecDidComplete(specSummary *types.SpecSummary) {
+	reporter.specSummaries = append(reporter.specSummaries, specSummary)
+}
+
+func (reporter *RerunReporter) AfterSuiteDidRun(setupSummary *types.SetupSummary) {
+}
+
+func (reporter *RerunReporter) SpecSuiteDidEnd(summary *types.SuiteSummary) {
+	failed := failedSpecs(reporter.specSummaries)
+
+	if reporter.style != "none" && len(failed) > 0 {
+		s := fmt.Sprintf("\nRerun the %d failed spec(s) with:\n", len(failed))
+		for _, specSummary := range failed {
+			s += fmt.Sprintf("  %s\n    %s\n", SpecFullText(specSummary), RerunCommand(reporter.style, reporter.ginkgoConfig, reporter.suiteDescription, reporter.packageDir, specSummary))
+		}
+		fmt.Fprint(reporter.writer, s)
+	}
+
+	if reporter.scriptFile != "" {
+		if err := reporter.writeScript(failed); err != nil {
+			fmt.Fprintf(os.Stderr, "\nFailed to write the rerun script: %s\n\t%s", reporter.scriptFile, err.Error())
+		}
+	}
+}
+
+func (reporter *RerunReporter) writeScript(failed []*types.SpecSummary) error {
+	script := "#!/bin/sh\n"
+	if len(failed) == 0 {
+		script += fmt.Sprintf("# No spec of %s failed\n", reporter.suiteDescription)
+	} else {
+		script += fmt.Sprintf("# Reruns the %d failed spec(s) of %s\n", len(failed), reporter.suiteDescription)
+		for _, specSummary := range failed {
+			script += fmt.Sprintf("#   %s\n", SpecFullText(specSummary))
+		}
+		script += RerunCommand(reporter.style, reporter.ginkgoConfig, reporter.suiteDescription, reporter.packageDir, failed...) + "\n"
+	}
+
+	if err := os.MkdirAll(filepath.Dir(reporter.scriptFile), os.ModePerm); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(reporter.scriptFile, []byte(script), 0755)
+}
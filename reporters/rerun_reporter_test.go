@@ -0,0 +1,105 @@
+package reporters_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/config"
+	"github.com/onsi/ginkgo/reporters"
+	"github.com/onsi/ginkgo/types"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("Rerun Reporter", func() {
+	var (
+		dir        string
+		scriptFile string
+		buffer     *gbytes.Buffer
+	)
+
+	failing := &types.SpecSummary{ComponentTexts: []string{"[Top Level]", "cart", "can't add it"}, State: types.SpecStateFailed}
+	panicking := &types.SpecSummary{ComponentTexts: []string{"[Top Level]", "cart", "empties"}, State: types.SpecStatePanicked}
+	passing := &types.SpecSummary{ComponentTexts: []string{"[Top Level]", "cart", "adds"}, State: types.SpecStatePassed}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "rerun-reporter")
+		Expect(err).ToNot(HaveOccurred())
+		scriptFile = filepath.Join(dir, "nested", "rerun.sh")
+		buffer = gbytes.NewBuffer()
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	run := func(reporter *reporters.RerunReporter, ginkgoConfig config.GinkgoConfigType, specSummaries ...*types.SpecSummary) {
+		reporter.SpecSuiteWillBegin(ginkgoConfig, &types.SuiteSummary{SuiteDescription: "Shop"})
+		for _, specSummary := range specSummaries {
+			reporter.SpecWillRun(specSummary)
+			reporter.SpecDidComplete(specSummary)
+		}
+		reporter.SpecSuiteDidEnd(&types.SuiteSummary{SuiteDescription: "Shop"})
+	}
+
+	Describe("RerunCommand", func() {
+		It("should focus on exactly the specs, quoting them for the shell", func() {
+			Expect(reporters.RerunCommand("ginkgo", config.GinkgoConfigType{RandomSeed: 3}, "Shop", "/src/shop", failing)).To(Equal(`ginkgo -seed=3 -focus='^Shop \[Top Level\] cart can'\''t add it$' /src/shop`))
+		})
+
+		It("should keep the randomization of the run and join several specs", func() {
+			Expect(reporters.RerunCommand("go", config.GinkgoConfigType{RandomSeed: 3, RandomizeAllSpecs: true}, "Shop", "/src/my shop", passing, panicking)).To(Equal(`go test '/src/my shop' -ginkgo.seed=3 -ginkgo.randomizeAllSpecs -ginkgo.focus='^Shop \[Top Level\] cart adds$|^Shop \[Top Level\] cart empties$'`))
+		})
+
+		It("should match the file of the specs too when the suite scans file paths", func() {
+			located := &types.SpecSummary{
+				ComponentTexts:         []string{"[Top Level]", "cart", "adds"},
+				ComponentCodeLocations: []types.CodeLocation{{}, {FileName: "/src/shop/cart_test.go"}, {FileName: "/src/shop/cart_test.go", LineNumber: 12}},
+				DisplayTexts:           []string{"[Top Level]", "le panier", "ajoute"},
+			}
+			Expect(reporters.RerunCommand("ginkgo", config.GinkgoConfigType{RandomSeed: 3, RegexScansFilePath: true}, "Shop", "/src/shop", located)).To(Equal(`ginkgo -seed=3 -regexScansFilePath -focus='^Shop \[Top Level\] cart adds /src/shop/cart_test\.go$' /src/shop`))
+		})
+	})
+
+	It("should print the command rerunning each failed spec", func() {
+		run(reporters.NewRerunReporter(buffer, "ginkgo", "", "/src/shop"), config.GinkgoConfigType{RandomSeed: 3}, passing, failing, panicking)
+
+		Expect(buffer).To(gbytes.Say(`Rerun the 2 failed spec\(s\) with:\n`))
+		Expect(buffer).To(gbytes.Say(`  cart can't add it\n    ginkgo -seed=3 -focus='\^Shop .* can'\\''t add it\$' /src/shop\n`))
+		Expect(buffer).To(gbytes.Say(`  cart empties\n    ginkgo -seed=3 -focus='\^Shop \\\[Top Level\\\] cart empties\$' /src/shop\n`))
+		Expect(string(buffer.Contents())).ToNot(ContainSubstring("cart adds"))
+	})
+
+	It("should print nothing when no spec failed, or with the none style", func() {
+		run(reporters.NewRerunReporter(buffer, "ginkgo", "", "/src/shop"), config.GinkgoConfigType{}, passing)
+		run(reporters.NewRerunReporter(buffer, "none", "", "/src/shop"), config.GinkgoConfigType{}, failing)
+		Expect(buffer.Contents()).To(BeEmpty())
+	})
+
+	It("should write a script rerunning all the failed specs at once", func() {
+		run(reporters.NewRerunReporter(buffer, "none", scriptFile, "/src/shop"), config.GinkgoConfigType{RandomSeed: 3}, passing, failing, panicking)
+
+		script, err := ioutil.ReadFile(scriptFile)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(script)).To(Equal(`#!/bin/sh
+# Reruns the 2 failed spec(s) of Shop
+#   cart can't add it
+#   cart empties
+ginkgo -seed=3 -focus='^Shop \[Top Level\] cart can'\''t add it$|^Shop \[Top Level\] cart empties$' /src/shop
+`))
+		info, err := os.Stat(scriptFile)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(info.Mode() & 0100).ToNot(BeZero())
+	})
+
+	It("should write a script doing nothing when no spec failed", func() {
+		run(reporters.NewRerunReporter(buffer, "ginkgo", scriptFile, "/src/shop"), config.GinkgoConfigType{RandomSeed: 3}, passing)
+
+		script, err := ioutil.ReadFile(scriptFile)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(script)).To(Equal("#!/bin/sh\n# No spec of Shop failed\n"))
+	})
+})
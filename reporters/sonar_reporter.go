@@ -0,0 +1,169 @@
+/*
+
+SonarQube Reporter for Ginkgo
+
+The SonarQube reporter writes the results of a suite run in SonarQube's Generic Test Execution format:
+
+	ginkgo -sonarReport=sonar-report.xml
+
+Specs are grouped by the file that defines them.  Point SonarQube at the report with the sonar.testExecutionReportPaths property.
+
+*/
+
+package reporters
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/onsi/ginkgo/config"
+	"github.com/onsi/ginkgo/types"
+)
+
+type SonarTestExecutions struct {
+	XMLName xml.Name    `xml:"testExecutions"`
+	Version int         `xml:"version,attr"`
+	Files   []SonarFile `xml:"file"`
+}
+
+type SonarFile struct {
+	Path      string          `xml:"path,attr"`
+	TestCases []SonarTestCase `xml:"testCase"`
+}
+
+type SonarTestCase struct {
+	Name     string        `xml:"name,attr"`
+	Duration int64         `xml:"duration,attr"`
+	Failure  *SonarProblem `xml:"failure,omitempty"`
+	Error    *SonarProblem `xml:"error,omitempty"`
+	Skipped  *SonarProblem `xml:"skipped,omitempty"`
+}
+
+type SonarProblem struct {
+	Message    string `xml:"message,attr"`
+	StackTrace string `xml:",chardata"`
+}
+
+type SonarReporter struct {
+	filename string
+
+	files     []*SonarFile
+	testCases map[string]sonarTestCaseIndex
+}
+
+type sonarTestCaseIndex struct {
+	file  *SonarFile
+	index int
+}
+
+//NewSonarReporter creates a new SonarQube reporter.  The report will be stored in the passed in filename.
+func NewSonarReporter(filename string) *SonarReporter {
+	return &SonarReporter{
+		filename: filename,
+	}
+}
+
+func (reporter *SonarReporter) SpecSuiteWillBegin(ginkgoConfig config.GinkgoConfigType, summary *types.SuiteSummary) {
+	reporter.files = []*SonarFile{}
+	reporter.testCases = map[string]sonarTestCaseIndex{}
+}
+
+func (reporter *SonarReporter) BeforeSuiteDidRun(setupSummary *types.SetupSummary) {
+	reporter.handleSetupSummary("BeforeSuite", setupSummary)
+}
+
+func (reporter *SonarReporter) SpecWillRun(specSummary *types.SpecSummary) {
+}
+
+func (reporter *SonarReporter) SpecDidComplete(specSummary *types.SpecSummary) {
+	location := specSummary.ComponentCodeLocations[len(specSummary.ComponentCodeLocations)-1]
+	testCase := SonarTestCase{
+		Name:     SpecDisplayText(specSummary),
+		Duration: specSummary.RunTime.Nanoseconds() / 1e6,
+	}
+	switch specSummary.State {
+	case types.SpecStateFailed:
+		testCase.Failure = sonarProblem(specSummary.Failure)
+	case types.SpecStatePanicked, types.SpecStateTimedOut:
+		testCase.Error = sonarProblem(specSummary.Failure)
+		if specSummary.State == types.SpecStatePanicked {
+			testCase.Error.StackTrace += fmt.Sprintf("\n\nPanic: %s\n\nFull stack:\n%s", specSummary.Failure.ForwardedPanic, specSummary.Failure.Location.FullStackTrace)
+		}
+	case types.SpecStatePending, types.SpecStateSkipped:
+		testCase.Skipped = &SonarProblem{Message: specSummary.State.String()}
+		if specSummary.Failure.Message != "" {
+			testCase.Skipped.Message = specSummary.Failure.Message
+		}
+	}
+	reporter.record(location.FileName, testCase)
+}
+
+func (reporter *SonarReporter) AfterSuiteDidRun(setupSummary *types.SetupSummary) {
+	reporter.handleSetupSummary("AfterSuite", setupSummary)
+}
+
+func (reporter *SonarReporter) SpecSuiteDidEnd(summary *types.SuiteSummary) {
+	executions := SonarTestExecutions{Version: 1, Files: []SonarFile{}}
+	for _, file := range reporter.files {
+		executions.Files = append(executions.Files, *file)
+	}
+
+	filePath := reportFilePath(reporter.filename)
+	file, err := createReportFile(reporter.filename)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\nFailed to create SonarQube report file: %s\n\t%s", filePath, err.Error())
+		return
+	}
+	defer file.Close()
+	io.WriteString(file, xml.Header)
+	encoder := xml.NewEncoder(file)
+	encoder.Indent("", "  ")
+	err = encoder.Encode(executions)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\nFailed to generate SonarQube report data:\n\t%s", err.Error())
+	}
+}
+
+func (reporter *SonarReporter) handleSetupSummary(name string, setupSummary *types.SetupSummary) {
+	if setupSummary.State == types.SpecStatePassed {
+		return
+	}
+	testCase := SonarTestCase{
+		Name:     name,
+		Duration: setupSummary.RunTime.Nanoseconds() / 1e6,
+		Error:    sonarProblem(setupSummary.Failure),
+	}
+	reporter.record(setupSummary.CodeLocation.FileName, testCase)
+}
+
+//record adds testCase to the report.  When a spec is retried (see -flakeAttempts) only its last attempt is kept.
+func (reporter *SonarReporter) record(path string, testCase SonarTestCase) {
+	key := path + "\x00" + testCase.Name
+	if existing, ok := reporter.testCases[key]; ok {
+		existing.file.TestCases[existing.index] = testCase
+		return
+	}
+
+	var file *SonarFile
+	for _, candidate := range reporter.files {
+		if candidate.Path == path {
+			file = candidate
+			break
+		}
+	}
+	if file == nil {
+		file = &SonarFile{Path: path}
+		reporter.files = append(reporter.files, file)
+	}
+	reporter.testCases[key] = sonarTestCaseIndex{file: file, index: len(file.TestCases)}
+	file.TestCases = append(file.TestCases, testCase)
+}
+
+func sonarProblem(failure types.SpecFailure) *SonarProblem {
+	return &SonarProblem{
+		Message:    failure.Message,
+		StackTrace: failureMessage(failure),
+	}
+}
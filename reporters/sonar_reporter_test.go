@@ -0,0 +1,146 @@
+package reporters_test
+
+import (
+	"encoding/xml"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/config"
+	"github.com/onsi/ginkgo/reporters"
+	"github.com/onsi/ginkgo/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SonarQube Reporter", func() {
+	var (
+		dir        string
+		outputFile string
+		reporter   *reporters.SonarReporter
+	)
+
+	summary := func(fileName string, text string, state types.SpecState, runTime time.Duration) *types.SpecSummary {
+		return &types.SpecSummary{
+			ComponentTexts:         []string{"[Top Level]", "Suite", text},
+			ComponentCodeLocations: []types.CodeLocation{{}, {FileName: fileName, LineNumber: 1}, {FileName: fileName, LineNumber: 3}},
+			State:                  state,
+			RunTime:                runTime,
+		}
+	}
+
+	readOutputFile := func() (string, reporters.SonarTestExecutions) {
+		data, err := ioutil.ReadFile(outputFile)
+		Ω(err).ShouldNot(HaveOccurred())
+		var executions reporters.SonarTestExecutions
+		Ω(xml.Unmarshal(data, &executions)).Should(Succeed())
+		return string(data), executions
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "sonar")
+		Ω(err).ShouldNot(HaveOccurred())
+		outputFile = filepath.Join(dir, "nested", "sonar.xml")
+		reporter = reporters.NewSonarReporter(outputFile)
+		reporter.SpecSuiteWillBegin(config.GinkgoConfigType{}, &types.SuiteSummary{SuiteDescription: "My test suite"})
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	Context("with a mix of spec outcomes across files", func() {
+		BeforeEach(func() {
+			reporter.BeforeSuiteDidRun(&types.SetupSummary{State: types.SpecStatePassed})
+
+			failing := summary("/src/b_test.go", "fails", types.SpecStateFailed, 20*time.Millisecond)
+			failing.Failure = types.SpecFailure{
+				Message:               "boom",
+				Location:              types.CodeLocation{FileName: "/src/b_test.go", LineNumber: 5},
+				ComponentCodeLocation: types.CodeLocation{FileName: "/src/b_test.go", LineNumber: 3},
+			}
+			panicking := summary("/src/a_test.go", "panics", types.SpecStatePanicked, 0)
+			panicking.Failure = types.SpecFailure{Message: "Test Panicked", ForwardedPanic: "oops"}
+			pending := summary("/src/a_test.go", "is pending", types.SpecStatePending, 0)
+
+			for _, specSummary := range []*types.SpecSummary{
+				summary("/src/a_test.go", "passes", types.SpecStatePassed, 1500*time.Millisecond),
+				failing,
+				panicking,
+				pending,
+			} {
+				reporter.SpecWillRun(specSummary)
+				reporter.SpecDidComplete(specSummary)
+			}
+
+			reporter.AfterSuiteDidRun(&types.SetupSummary{
+				State:        types.SpecStateFailed,
+				CodeLocation: types.CodeLocation{FileName: "/src/suite_test.go", LineNumber: 12},
+				Failure:      types.SpecFailure{Message: "cleanup failed"},
+			})
+			reporter.SpecSuiteDidEnd(&types.SuiteSummary{})
+		})
+
+		It("should group the test cases by file, in the order they were first seen", func() {
+			data, executions := readOutputFile()
+			Ω(data).Should(HavePrefix(xml.Header + "<testExecutions version=\"1\">"))
+			Ω(executions.Version).Should(Equal(1))
+			Ω(executions.Files).Should(HaveLen(3))
+			Ω(executions.Files[0].Path).Should(Equal("/src/a_test.go"))
+			Ω(executions.Files[0].TestCases).Should(HaveLen(3))
+			Ω(executions.Files[1].Path).Should(Equal("/src/b_test.go"))
+			Ω(executions.Files[2].Path).Should(Equal("/src/suite_test.go"))
+		})
+
+		It("should report durations in milliseconds", func() {
+			_, executions := readOutputFile()
+			passing := executions.Files[0].TestCases[0]
+			Ω(passing.Name).Should(Equal("Suite passes"))
+			Ω(passing.Duration).Should(Equal(int64(1500)))
+			Ω(passing.Failure).Should(BeNil())
+			Ω(passing.Error).Should(BeNil())
+			Ω(passing.Skipped).Should(BeNil())
+		})
+
+		It("should report failures, errors and skipped specs", func() {
+			_, executions := readOutputFile()
+			failing := executions.Files[1].TestCases[0]
+			Ω(failing.Failure.Message).Should(Equal("boom"))
+			Ω(failing.Failure.StackTrace).Should(Equal("/src/b_test.go:3\nboom\n/src/b_test.go:5"))
+
+			panicking := executions.Files[0].TestCases[1]
+			Ω(panicking.Failure).Should(BeNil())
+			Ω(panicking.Error.Message).Should(Equal("Test Panicked"))
+			Ω(panicking.Error.StackTrace).Should(ContainSubstring("Panic: oops"))
+
+			pending := executions.Files[0].TestCases[2]
+			Ω(pending.Skipped.Message).Should(Equal("pending"))
+
+			afterSuite := executions.Files[2].TestCases[0]
+			Ω(afterSuite.Name).Should(Equal("AfterSuite"))
+			Ω(afterSuite.Error.Message).Should(Equal("cleanup failed"))
+		})
+	})
+
+	Context("when a spec is retried", func() {
+		It("should only keep its last attempt", func() {
+			for _, specSummary := range []*types.SpecSummary{
+				summary("/src/a_test.go", "flakes", types.SpecStateFailed, time.Second),
+				summary("/src/a_test.go", "passes", types.SpecStatePassed, time.Second),
+				summary("/src/a_test.go", "flakes", types.SpecStatePassed, 2*time.Second),
+			} {
+				reporter.SpecDidComplete(specSummary)
+			}
+			reporter.SpecSuiteDidEnd(&types.SuiteSummary{})
+
+			data, executions := readOutputFile()
+			Ω(strings.Count(data, "<testCase ")).Should(Equal(2))
+			Ω(executions.Files[0].TestCases[0].Name).Should(Equal("Suite flakes"))
+			Ω(executions.Files[0].TestCases[0].Failure).Should(BeNil())
+			Ω(executions.Files[0].TestCases[0].Duration).Should(Equal(int64(2000)))
+		})
+	})
+})
@@ -3,6 +3,8 @@ package stenographer
 import (
 	"fmt"
 	"strings"
+
+	"github.com/onsi/ginkgo/formatter"
 )
 
 func (s *consoleStenographer) colorize(colorCode string, format string, args ...interface{}) string {
@@ -42,6 +44,18 @@ func (s *consoleStenographer) println(indentation int, format string, args ...in
 	fmt.Fprintln(s.w, s.indent(indentation, format, args...))
 }
 
+//wrap wraps text to the width of the console, once indented by indentation
+func (s *consoleStenographer) wrap(indentation int, text string) string {
+	if s.width == 0 {
+		return text
+	}
+	width := int(s.width) - 2*indentation
+	if width < 1 {
+		width = 1
+	}
+	return formatter.Wrap(text, uint(width))
+}
+
 func (s *consoleStenographer) indent(indentation int, format string, args ...interface{}) string {
 	var text string
 
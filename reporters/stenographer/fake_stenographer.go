@@ -70,6 +70,10 @@ func (stenographer *FakeStenographer) AnnounceSuite(description string, randomSe
 	stenographer.registerCall("AnnounceSuite", description, randomSeed, randomizingAll, succinct)
 }
 
+func (stenographer *FakeStenographer) AnnounceWarnings(warnings []string) {
+	stenographer.registerCall("AnnounceWarnings", warnings)
+}
+
 func (stenographer *FakeStenographer) AnnounceAggregatedParallelRun(nodes int, succinct bool) {
 	stenographer.registerCall("AnnounceAggregatedParallelRun", nodes, succinct)
 }
@@ -113,10 +117,18 @@ func (stenographer *FakeStenographer) AnnounceSuccessfulSlowSpec(spec *types.Spe
 	stenographer.registerCall("AnnounceSuccessfulSlowSpec", spec, succinct)
 }
 
+func (stenographer *FakeStenographer) AnnounceSpecOverRuntimeBudget(spec *types.SpecSummary, succinct bool) {
+	stenographer.registerCall("AnnounceSpecOverRuntimeBudget", spec, succinct)
+}
+
 func (stenographer *FakeStenographer) AnnounceSuccessfulMeasurement(spec *types.SpecSummary, succinct bool) {
 	stenographer.registerCall("AnnounceSuccessfulMeasurement", spec, succinct)
 }
 
+func (stenographer *FakeStenographer) AnnounceExpectedFailure(spec *types.SpecSummary, succinct bool) {
+	stenographer.registerCall("AnnounceExpectedFailure", spec, succinct)
+}
+
 func (stenographer *FakeStenographer) AnnouncePendingSpec(spec *types.SpecSummary, noisy bool) {
 	stenographer.registerCall("AnnouncePendingSpec", spec, noisy)
 }
@@ -137,6 +149,6 @@ func (stenographer *FakeStenographer) AnnounceSpecFailed(spec *types.SpecSummary
 	stenographer.registerCall("AnnounceSpecFailed", spec, succinct, fullTrace)
 }
 
-func (stenographer *FakeStenographer) SummarizeFailures(summaries []*types.SpecSummary) {
-	stenographer.registerCall("SummarizeFailures", summaries)
+func (stenographer *FakeStenographer) SummarizeFailures(summaries []*types.SpecSummary, groupByFingerprint bool) {
+	stenographer.registerCall("SummarizeFailures", summaries, groupByFingerprint)
 }
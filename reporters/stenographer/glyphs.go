@@ -0,0 +1,97 @@
+package stenographer
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+//Glyphs are the markers the stenographer prints for the specs in each state: alone while the specs stream by, and at
+//the head of the block reporting a spec in detail
+type Glyphs struct {
+	Passed   string
+	Failed   string
+	Panicked string
+	TimedOut string
+	Pending  string
+	Skipped  string
+}
+
+//DefaultGlyphs returns the glyphs the stenographer prints unless told otherwise.  Windows consoles get + rather than •.
+func DefaultGlyphs() Glyphs {
+	denoter := "•"
+	if runtime.GOOS == "windows" {
+		denoter = "+"
+	}
+	return Glyphs{
+		Passed:   denoter,
+		Failed:   denoter,
+		Panicked: denoter + "!",
+		TimedOut: denoter + "...",
+		Pending:  "P",
+		Skipped:  "S",
+	}
+}
+
+//ASCIIGlyphs returns glyphs restricted to ASCII, for terminals and logs that mangle unicode
+func ASCIIGlyphs() Glyphs {
+	return Glyphs{
+		Passed:   "+",
+		Failed:   "+",
+		Panicked: "+!",
+		TimedOut: "+...",
+		Pending:  "P",
+		Skipped:  "S",
+	}
+}
+
+/*
+ParseGlyphs reads the glyphs set with -glyphs or $GINKGO_GLYPHS: a comma-separated list starting, optionally, with the set
+of glyphs to start from (default or ascii), followed by the glyphs to override, by state:
+
+	ascii
+	passed=✓,failed=✗
+	ascii,passed=.
+
+The states are passed, failed, panicked, timedOut, pending and skipped.  An empty list leaves the default glyphs.
+*/
+func ParseGlyphs(list string) (Glyphs, error) {
+	glyphs := DefaultGlyphs()
+	for i, entry := range strings.Split(list, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if i == 0 && !strings.Contains(entry, "=") {
+			switch entry {
+			case "default":
+			case "ascii":
+				glyphs = ASCIIGlyphs()
+			default:
+				return Glyphs{}, fmt.Errorf("unknown set of glyphs %q, expected default or ascii", entry)
+			}
+			continue
+		}
+		fields := strings.SplitN(entry, "=", 2)
+		if len(fields) != 2 || fields[1] == "" {
+			return Glyphs{}, fmt.Errorf("invalid glyph %q, expected state=glyph", entry)
+		}
+		switch fields[0] {
+		case "passed":
+			glyphs.Passed = fields[1]
+		case "failed":
+			glyphs.Failed = fields[1]
+		case "panicked":
+			glyphs.Panicked = fields[1]
+		case "timedOut":
+			glyphs.TimedOut = fields[1]
+		case "pending":
+			glyphs.Pending = fields[1]
+		case "skipped":
+			glyphs.Skipped = fields[1]
+		default:
+			return Glyphs{}, fmt.Errorf("unknown state %q, expected passed, failed, panicked, timedOut, pending or skipped", fields[0])
+		}
+	}
+	return glyphs, nil
+}
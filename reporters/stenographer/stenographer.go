@@ -9,7 +9,7 @@ package stenographer
 import (
 	"fmt"
 	"io"
-	"runtime"
+	"sort"
 	"strings"
 
 	"github.com/onsi/ginkgo/types"
@@ -35,6 +35,7 @@ const (
 
 type Stenographer interface {
 	AnnounceSuite(description string, randomSeed int64, randomizingAll bool, succinct bool)
+	AnnounceWarnings(warnings []string)
 	AnnounceAggregatedParallelRun(nodes int, succinct bool)
 	AnnounceParallelRun(node int, nodes int, succinct bool)
 	AnnounceTotalNumberOfSpecs(total int, succinct bool)
@@ -49,7 +50,9 @@ type Stenographer interface {
 
 	AnnounceSuccessfulSpec(spec *types.SpecSummary)
 	AnnounceSuccessfulSlowSpec(spec *types.SpecSummary, succinct bool)
+	AnnounceSpecOverRuntimeBudget(spec *types.SpecSummary, succinct bool)
 	AnnounceSuccessfulMeasurement(spec *types.SpecSummary, succinct bool)
+	AnnounceExpectedFailure(spec *types.SpecSummary, succinct bool)
 
 	AnnouncePendingSpec(spec *types.SpecSummary, noisy bool)
 	AnnounceSkippedSpec(spec *types.SpecSummary, succinct bool, fullTrace bool)
@@ -58,28 +61,38 @@ type Stenographer interface {
 	AnnounceSpecPanicked(spec *types.SpecSummary, succinct bool, fullTrace bool)
 	AnnounceSpecFailed(spec *types.SpecSummary, succinct bool, fullTrace bool)
 
-	SummarizeFailures(summaries []*types.SpecSummary)
+	SummarizeFailures(summaries []*types.SpecSummary, groupByFingerprint bool)
 }
 
 func New(color bool, enableFlakes bool, writer io.Writer) Stenographer {
-	denoter := "•"
-	if runtime.GOOS == "windows" {
-		denoter = "+"
-	}
+	return NewWithWidth(color, enableFlakes, 0, writer)
+}
+
+//NewWithWidth returns a stenographer that wraps failure messages at width columns, see formatter.ConsoleWidth.  A width
+//of 0 leaves them unwrapped, as New does.
+func NewWithWidth(color bool, enableFlakes bool, width uint, writer io.Writer) Stenographer {
+	return NewWithGlyphs(color, enableFlakes, width, DefaultGlyphs(), writer)
+}
+
+//NewWithGlyphs returns a stenographer that marks the specs with glyphs, see ParseGlyphs, and wraps failure messages at
+//width columns
+func NewWithGlyphs(color bool, enableFlakes bool, width uint, glyphs Glyphs, writer io.Writer) Stenographer {
 	return &consoleStenographer{
 		color:        color,
-		denoter:      denoter,
+		glyphs:       glyphs,
 		cursorState:  cursorStateTop,
 		enableFlakes: enableFlakes,
+		width:        width,
 		w:            writer,
 	}
 }
 
 type consoleStenographer struct {
 	color        bool
-	denoter      string
+	glyphs       Glyphs
 	cursorState  cursorStateType
 	enableFlakes bool
+	width        uint
 	w            io.Writer
 }
 
@@ -98,6 +111,12 @@ func (s *consoleStenographer) AnnounceSuite(description string, randomSeed int64
 	s.printNewLine()
 }
 
+func (s *consoleStenographer) AnnounceWarnings(warnings []string) {
+	for _, warning := range warnings {
+		s.println(0, s.colorize(yellowColor, "Warning: %s", warning))
+	}
+}
+
 func (s *consoleStenographer) AnnounceParallelRun(node int, nodes int, succinct bool) {
 	if succinct {
 		s.print(0, "- node #%d ", node)
@@ -162,7 +181,11 @@ func (s *consoleStenographer) AnnounceSpecRunCompletion(summary *types.SuiteSumm
 	if !summary.SuiteSucceeded {
 		color = redColor
 	}
-	s.println(0, s.colorize(boldStyle+color, "Ran %d of %d Specs in %.3f seconds", summary.NumberOfSpecsThatWillBeRun, summary.NumberOfTotalSpecs, summary.RunTime.Seconds()))
+	warmUp := ""
+	if summary.WarmUpRunTime > 0 {
+		warmUp = fmt.Sprintf(", after a %.3f second warm-up", summary.WarmUpRunTime.Seconds())
+	}
+	s.println(0, s.colorize(boldStyle+color, "Ran %d of %d Specs in %.3f seconds%s", summary.NumberOfSpecsThatWillBeRun, summary.NumberOfTotalSpecs, summary.RunTime.Seconds(), warmUp))
 
 	status := ""
 	if summary.SuiteSucceeded {
@@ -184,21 +207,39 @@ func (s *consoleStenographer) AnnounceSpecRunCompletion(summary *types.SuiteSumm
 		s.colorize(yellowColor+boldStyle, "%d Pending", summary.NumberOfPendingSpecs),
 		s.colorize(cyanColor+boldStyle, "%d Skipped", summary.NumberOfSkippedSpecs),
 	)
+
+	for _, reason := range summary.SpecialSuiteFailureReasons {
+		s.println(0, s.colorize(redColor, reason.Message))
+	}
+
+	if len(summary.RuntimeBudgetViolations) > 0 {
+		labels := []string{}
+		for label := range summary.RuntimeBudgetViolations {
+			labels = append(labels, label)
+		}
+		sort.Strings(labels)
+		counts := []string{}
+		for _, label := range labels {
+			counts = append(counts, fmt.Sprintf("%s: %d", label, summary.RuntimeBudgetViolations[label]))
+		}
+		s.println(0, s.colorize(yellowColor+boldStyle, "Over runtime budget -- %s", strings.Join(counts, " | ")))
+	}
 }
 
 func (s *consoleStenographer) AnnounceSpecWillRun(spec *types.SpecSummary) {
 	s.startBlock()
-	for i, text := range spec.ComponentTexts[1 : len(spec.ComponentTexts)-1] {
+	texts := spec.TextsToDisplay()
+	for i, text := range texts[1 : len(texts)-1] {
 		s.print(0, s.colorize(alternatingColors[i%2], text)+" ")
 	}
 
 	indentation := 0
-	if len(spec.ComponentTexts) > 2 {
+	if len(texts) > 2 {
 		indentation = 1
 		s.printNewLine()
 	}
-	index := len(spec.ComponentTexts) - 1
-	s.print(indentation, s.colorize(boldStyle, spec.ComponentTexts[index]))
+	index := len(texts) - 1
+	s.print(indentation, s.colorize(boldStyle, texts[index]))
 	s.printNewLine()
 	s.print(indentation, s.colorize(lightGrayColor, spec.ComponentCodeLocations[index].String()))
 	s.printNewLine()
@@ -206,11 +247,19 @@ func (s *consoleStenographer) AnnounceSpecWillRun(spec *types.SpecSummary) {
 }
 
 func (s *consoleStenographer) AnnounceBeforeSuiteFailure(summary *types.SetupSummary, succinct bool, fullTrace bool) {
+	if summary.ComponentType == types.SpecComponentTypeWarmUp {
+		s.announceSetupFailure("WarmUp", summary, succinct, fullTrace)
+		return
+	}
 	s.announceSetupFailure("BeforeSuite", summary, succinct, fullTrace)
 }
 
 func (s *consoleStenographer) AnnounceAfterSuiteFailure(summary *types.SetupSummary, succinct bool, fullTrace bool) {
-	s.announceSetupFailure("AfterSuite", summary, succinct, fullTrace)
+	name := "AfterSuite"
+	if summary.ComponentType == types.SpecComponentTypeReportAfterSuite {
+		name = summary.Text
+	}
+	s.announceSetupFailure(name, summary, succinct, fullTrace)
 }
 
 func (s *consoleStenographer) announceSetupFailure(name string, summary *types.SetupSummary, succinct bool, fullTrace bool) {
@@ -246,38 +295,65 @@ func (s *consoleStenographer) AnnounceCapturedOutput(output string) {
 }
 
 func (s *consoleStenographer) AnnounceSuccessfulSpec(spec *types.SpecSummary) {
-	s.print(0, s.colorize(greenColor, s.denoter))
+	s.print(0, s.colorize(greenColor, s.glyphs.Passed))
 	s.stream()
 }
 
 func (s *consoleStenographer) AnnounceSuccessfulSlowSpec(spec *types.SpecSummary, succinct bool) {
 	s.printBlockWithMessage(
-		s.colorize(greenColor, "%s [SLOW TEST:%.3f seconds]", s.denoter, spec.RunTime.Seconds()),
+		s.colorize(greenColor, "%s [SLOW TEST:%.3f seconds]", s.glyphs.Passed, spec.RunTime.Seconds()),
 		"",
 		spec,
 		succinct,
 	)
 }
 
+func (s *consoleStenographer) AnnounceSpecOverRuntimeBudget(spec *types.SpecSummary, succinct bool) {
+	violation := spec.RuntimeBudgetViolation
+	s.printBlockWithMessage(
+		s.colorize(yellowColor, "%s [OVER BUDGET:%.3f seconds]", s.glyphs.Passed, spec.RunTime.Seconds()),
+		s.colorize(yellowColor, "Ran longer than the %s runtime budget of the label %q", violation.Budget, violation.Label),
+		spec,
+		succinct,
+	)
+}
+
 func (s *consoleStenographer) AnnounceSuccessfulMeasurement(spec *types.SpecSummary, succinct bool) {
 	s.printBlockWithMessage(
-		s.colorize(greenColor, "%s [MEASUREMENT]", s.denoter),
+		s.colorize(greenColor, "%s [MEASUREMENT]", s.glyphs.Passed),
 		s.measurementReport(spec, succinct),
 		spec,
 		succinct,
 	)
 }
 
+func (s *consoleStenographer) AnnounceExpectedFailure(spec *types.SpecSummary, succinct bool) {
+	message := s.colorize(yellowColor, "Expected to fail: %s", spec.ExpectedFailureReason)
+	if spec.ExpectedFailure != nil {
+		message += "\n\n" + spec.ExpectedFailure.Message + "\n" + spec.ExpectedFailure.Location.String()
+	}
+	s.printBlockWithMessage(
+		s.colorize(yellowColor, "%s [EXPECTED FAILURE] [%.3f seconds]", s.glyphs.Passed, spec.RunTime.Seconds()),
+		message,
+		spec,
+		succinct,
+	)
+}
+
 func (s *consoleStenographer) AnnouncePendingSpec(spec *types.SpecSummary, noisy bool) {
 	if noisy {
+		message := ""
+		if spec.PendingReason != "" {
+			message = s.colorize(yellowColor, "Pending: %s", spec.PendingReason)
+		}
 		s.printBlockWithMessage(
-			s.colorize(yellowColor, "P [PENDING]"),
-			"",
+			s.colorize(yellowColor, "%s [PENDING]", s.glyphs.Pending),
+			message,
 			spec,
 			false,
 		)
 	} else {
-		s.print(0, s.colorize(yellowColor, "P"))
+		s.print(0, s.colorize(yellowColor, s.glyphs.Pending))
 		s.stream()
 	}
 }
@@ -285,13 +361,13 @@ func (s *consoleStenographer) AnnouncePendingSpec(spec *types.SpecSummary, noisy
 func (s *consoleStenographer) AnnounceSkippedSpec(spec *types.SpecSummary, succinct bool, fullTrace bool) {
 	// Skips at runtime will have a non-empty spec.Failure. All others should be succinct.
 	if succinct || spec.Failure == (types.SpecFailure{}) {
-		s.print(0, s.colorize(cyanColor, "S"))
+		s.print(0, s.colorize(cyanColor, s.glyphs.Skipped))
 		s.stream()
 	} else {
 		s.startBlock()
-		s.println(0, s.colorize(cyanColor+boldStyle, "S [SKIPPING]%s [%.3f seconds]", s.failureContext(spec.Failure.ComponentType), spec.RunTime.Seconds()))
+		s.println(0, s.colorize(cyanColor+boldStyle, "%s [SKIPPING]%s [%.3f seconds]", s.glyphs.Skipped, s.failureContext(spec.Failure.ComponentType), spec.RunTime.Seconds()))
 
-		indentation := s.printCodeLocationBlock(spec.ComponentTexts, spec.ComponentCodeLocations, spec.Failure.ComponentType, spec.Failure.ComponentIndex, spec.State, succinct)
+		indentation := s.printCodeLocationBlock(spec.TextsToDisplay(), spec.ComponentCodeLocations, spec.Failure.ComponentType, spec.Failure.ComponentIndex, spec.State, succinct)
 
 		s.printNewLine()
 		s.printSkip(indentation, spec.Failure)
@@ -300,18 +376,21 @@ func (s *consoleStenographer) AnnounceSkippedSpec(spec *types.SpecSummary, succi
 }
 
 func (s *consoleStenographer) AnnounceSpecTimedOut(spec *types.SpecSummary, succinct bool, fullTrace bool) {
-	s.printSpecFailure(fmt.Sprintf("%s... Timeout", s.denoter), spec, succinct, fullTrace)
+	s.printSpecFailure(fmt.Sprintf("%s Timeout", s.glyphs.TimedOut), spec, succinct, fullTrace)
 }
 
 func (s *consoleStenographer) AnnounceSpecPanicked(spec *types.SpecSummary, succinct bool, fullTrace bool) {
-	s.printSpecFailure(fmt.Sprintf("%s! Panic", s.denoter), spec, succinct, fullTrace)
+	s.printSpecFailure(fmt.Sprintf("%s Panic", s.glyphs.Panicked), spec, succinct, fullTrace)
 }
 
 func (s *consoleStenographer) AnnounceSpecFailed(spec *types.SpecSummary, succinct bool, fullTrace bool) {
-	s.printSpecFailure(fmt.Sprintf("%s Failure", s.denoter), spec, succinct, fullTrace)
+	s.printSpecFailure(fmt.Sprintf("%s Failure", s.glyphs.Failed), spec, succinct, fullTrace)
 }
 
-func (s *consoleStenographer) SummarizeFailures(summaries []*types.SpecSummary) {
+//SummarizeFailures lists the failing specs, telling identical failures once, followed by the specs they affected.  With
+//groupByFingerprint, every failure is clustered with the others that share its fingerprint, along with the fingerprint,
+//from the most frequent failure on.
+func (s *consoleStenographer) SummarizeFailures(summaries []*types.SpecSummary, groupByFingerprint bool) {
 	failingSpecs := []*types.SpecSummary{}
 
 	for _, summary := range summaries {
@@ -324,30 +403,153 @@ func (s *consoleStenographer) SummarizeFailures(summaries []*types.SpecSummary)
 		return
 	}
 
+	fingerprints, groups := groupFailuresByFingerprint(failingSpecs)
+	if groupByFingerprint {
+		s.summarizeFailureGroups(fingerprints, groups, len(failingSpecs))
+	} else {
+		s.summarizeIdenticalFailuresOnce(fingerprints, groups, len(failingSpecs))
+	}
+	s.summarizeFailuresByOwner(failingSpecs)
+	s.summarizeFailuresBySeverity(failingSpecs)
+}
+
+//summarizeIdenticalFailuresOnce lists the total failures in the order they occurred, telling identical failures once
+func (s *consoleStenographer) summarizeIdenticalFailuresOnce(fingerprints []string, groups map[string][]*types.SpecSummary, total int) {
 	s.printNewLine()
 	s.printNewLine()
-	plural := "s"
-	if len(failingSpecs) == 1 {
-		plural = ""
-	}
-	s.println(0, s.colorize(redColor+boldStyle, "Summarizing %d Failure%s:", len(failingSpecs), plural))
-	for _, summary := range failingSpecs {
+	s.println(0, s.colorize(redColor+boldStyle, "Summarizing %d Failure%s:", total, pluralSuffix(total)))
+	for _, fingerprint := range fingerprints {
+		group := groups[fingerprint]
+		summary := group[0]
 		s.printNewLine()
-		if summary.HasFailureState() {
-			if summary.TimedOut() {
-				s.print(0, s.colorize(redColor+boldStyle, "[Timeout...] "))
-			} else if summary.Panicked() {
-				s.print(0, s.colorize(redColor+boldStyle, "[Panic!] "))
-			} else if summary.Failed() {
-				s.print(0, s.colorize(redColor+boldStyle, "[Fail] "))
-			}
-			s.printSpecContext(summary.ComponentTexts, summary.ComponentCodeLocations, summary.Failure.ComponentType, summary.Failure.ComponentIndex, summary.State, true)
+		if summary.TimedOut() {
+			s.print(0, s.colorize(redColor+boldStyle, "[Timeout...] "))
+		} else if summary.Panicked() {
+			s.print(0, s.colorize(redColor+boldStyle, "[Panic!] "))
+		} else if summary.Failed() {
+			s.print(0, s.colorize(redColor+boldStyle, "[Fail] "))
+		}
+		if len(group) == 1 {
+			s.printSpecContext(summary.TextsToDisplay(), summary.ComponentCodeLocations, summary.Failure.ComponentType, summary.Failure.ComponentIndex, summary.State, true)
 			s.printNewLine()
 			s.println(0, s.colorize(lightGrayColor, summary.Failure.Location.String()))
+			continue
+		}
+
+		//identical failures are only told once, followed by the specs they affected
+		s.println(0, "%s %s", s.colorize(redColor+boldStyle, "[%dx]", len(group)), failureHeadline(summary.Failure))
+		s.println(0, s.colorize(lightGrayColor, summary.Failure.Location.String()))
+		for _, summary := range group {
+			s.println(1, "- %s", specText(summary))
+		}
+	}
+}
+
+//summarizeFailuresBySeverity counts the failing specs of each severity, from the most severe, when some have a severity
+func (s *consoleStenographer) summarizeFailuresBySeverity(failingSpecs []*types.SpecSummary) {
+	counts := types.FailuresBySeverity(failingSpecs)
+	if len(counts) == 0 {
+		return
+	}
+
+	breakdown := []string{}
+	for _, severity := range []types.Severity{types.SeverityCritical, types.SeverityHigh, types.SeverityMedium, types.SeverityLow, types.SeverityNone} {
+		if counts[severity] > 0 {
+			breakdown = append(breakdown, fmt.Sprintf("%d %s", counts[severity], severity))
+		}
+	}
+	s.printNewLine()
+	s.printNewLine()
+	s.println(0, "%s %s", s.colorize(redColor+boldStyle, "Failures by Severity:"), strings.Join(breakdown, " | "))
+}
+
+//summarizeFailuresByOwner lists the failing specs under each of their owners, when some have owners, to speed up triage
+func (s *consoleStenographer) summarizeFailuresByOwner(failingSpecs []*types.SpecSummary) {
+	owners, groups := types.FailuresByOwner(failingSpecs)
+	if len(owners) == 0 {
+		return
+	}
+
+	s.printNewLine()
+	s.printNewLine()
+	s.println(0, s.colorize(redColor+boldStyle, "Failures by Owner:"))
+	for _, owner := range owners {
+		s.printNewLine()
+		s.println(0, "%s %s", s.colorize(redColor+boldStyle, "[%dx]", len(groups[owner])), owner)
+		for _, summary := range groups[owner] {
+			s.println(1, "- %s", specText(summary))
 		}
 	}
 }
 
+//summarizeFailureGroups lists the total failures grouped by fingerprint, from the group with the most failures on
+func (s *consoleStenographer) summarizeFailureGroups(fingerprints []string, groups map[string][]*types.SpecSummary, total int) {
+	sort.SliceStable(fingerprints, func(i, j int) bool {
+		return len(groups[fingerprints[i]]) > len(groups[fingerprints[j]])
+	})
+
+	s.printNewLine()
+	s.printNewLine()
+	s.println(0, s.colorize(redColor+boldStyle, "Grouping %d Failure%s by Fingerprint into %d Group%s:", total, pluralSuffix(total), len(fingerprints), pluralSuffix(len(fingerprints))))
+	for _, fingerprint := range fingerprints {
+		group := groups[fingerprint]
+		failure := group[0].Failure
+
+		s.printNewLine()
+		s.println(0, "%s %s %s", s.colorize(redColor+boldStyle, "[%dx]", len(group)), s.colorize(grayColor, fingerprint), failureHeadline(failure))
+		s.println(1, s.colorize(lightGrayColor, failure.Location.String()))
+		for _, summary := range group {
+			s.println(2, "- %s", specText(summary))
+		}
+	}
+}
+
+//groupFailuresByFingerprint gathers the failing specs by failure fingerprint, listing the fingerprints in the order
+//of their first failure
+func groupFailuresByFingerprint(summaries []*types.SpecSummary) ([]string, map[string][]*types.SpecSummary) {
+	fingerprints := []string{}
+	groups := map[string][]*types.SpecSummary{}
+	for _, summary := range summaries {
+		if !summary.HasFailureState() {
+			continue
+		}
+		fingerprint := summary.Failure.Fingerprint
+		if fingerprint == "" {
+			fingerprint = types.FailureFingerprint(summary.Failure)
+		}
+		if _, ok := groups[fingerprint]; !ok {
+			fingerprints = append(fingerprints, fingerprint)
+		}
+		groups[fingerprint] = append(groups[fingerprint], summary)
+	}
+	return fingerprints, groups
+}
+
+//failureHeadline is the first line of the failure message, followed by the first line of the panic if any
+func failureHeadline(failure types.SpecFailure) string {
+	message := strings.SplitN(strings.TrimSpace(failure.Message), "\n", 2)[0]
+	if failure.ForwardedPanic != "" {
+		message = fmt.Sprintf("%s: %s", message, strings.SplitN(failure.ForwardedPanic, "\n", 2)[0])
+	}
+	return message
+}
+
+//specText is the full text of the spec, without the description of the suite
+func specText(summary *types.SpecSummary) string {
+	texts := summary.TextsToDisplay()
+	if len(texts) > 1 {
+		texts = texts[1:]
+	}
+	return strings.Join(texts, " ")
+}
+
+func pluralSuffix(count int) string {
+	if count == 1 {
+		return ""
+	}
+	return "s"
+}
+
 func (s *consoleStenographer) startBlock() {
 	if s.cursorState == cursorStateStreaming {
 		s.printNewLine()
@@ -374,11 +576,11 @@ func (s *consoleStenographer) printBlockWithMessage(header string, message strin
 	s.startBlock()
 	s.println(0, header)
 
-	indentation := s.printCodeLocationBlock(spec.ComponentTexts, spec.ComponentCodeLocations, types.SpecComponentTypeInvalid, 0, spec.State, succinct)
+	indentation := s.printCodeLocationBlock(spec.TextsToDisplay(), spec.ComponentCodeLocations, types.SpecComponentTypeInvalid, 0, spec.State, succinct)
 
 	if message != "" {
 		s.printNewLine()
-		s.println(indentation, message)
+		s.println(indentation, s.wrap(indentation, message))
 	}
 
 	s.endBlock()
@@ -388,46 +590,98 @@ func (s *consoleStenographer) printSpecFailure(message string, spec *types.SpecS
 	s.startBlock()
 	s.println(0, s.colorize(redColor+boldStyle, "%s%s [%.3f seconds]", message, s.failureContext(spec.Failure.ComponentType), spec.RunTime.Seconds()))
 
-	indentation := s.printCodeLocationBlock(spec.ComponentTexts, spec.ComponentCodeLocations, spec.Failure.ComponentType, spec.Failure.ComponentIndex, spec.State, succinct)
+	indentation := s.printCodeLocationBlock(spec.TextsToDisplay(), spec.ComponentCodeLocations, spec.Failure.ComponentType, spec.Failure.ComponentIndex, spec.State, succinct)
 
 	s.printNewLine()
 	s.printFailure(indentation, spec.State, spec.Failure, fullTrace)
+	s.printAdditionalFailures(indentation, spec.AdditionalFailures, fullTrace)
+	s.printReportEntries(indentation, spec.ReportEntries)
+	s.printNondeterminism(indentation, spec.Nondeterminism)
 	s.endBlock()
 }
 
+func (s *consoleStenographer) printReportEntries(indentation int, entries []types.ReportEntry) {
+	if len(entries) == 0 {
+		return
+	}
+
+	s.printNewLine()
+	s.println(indentation, s.colorize(boldStyle, "Report Entries:"))
+	for _, entry := range entries {
+		s.println(indentation+1, "%s %s", s.colorize(boldStyle, entry.Name), s.colorize(lightGrayColor, "- %s", entry.Location))
+		if entry.Representation != "" {
+			s.println(indentation+2, entry.Representation)
+		}
+	}
+}
+
+func (s *consoleStenographer) printAdditionalFailures(indentation int, failures []types.SpecFailure, fullTrace bool) {
+	for _, failure := range failures {
+		state := types.SpecStateFailed
+		if failure.ForwardedPanic != "" {
+			state = types.SpecStatePanicked
+		}
+		s.printNewLine()
+		s.println(indentation, s.colorize(redColor+boldStyle, "Additional failure reported while the spec ran:"))
+		s.printFailure(indentation, state, failure, fullTrace)
+	}
+}
+
+func (s *consoleStenographer) printNondeterminism(indentation int, nondeterminism []string) {
+	if len(nondeterminism) == 0 {
+		return
+	}
+
+	s.printNewLine()
+	s.println(indentation, s.colorize(yellowColor, "Sources of nondeterminism detected while the spec ran:"))
+	for _, source := range nondeterminism {
+		s.println(indentation+1, source)
+	}
+}
+
 func (s *consoleStenographer) failureContext(failedComponentType types.SpecComponentType) string {
 	switch failedComponentType {
 	case types.SpecComponentTypeBeforeSuite:
 		return " in Suite Setup (BeforeSuite)"
 	case types.SpecComponentTypeAfterSuite:
 		return " in Suite Teardown (AfterSuite)"
+	case types.SpecComponentTypeWarmUp:
+		return " in Suite Warm-Up (WarmUp)"
+	case types.SpecComponentTypeReportAfterSuite:
+		return " in Suite Reporting (ReportAfterSuite)"
 	case types.SpecComponentTypeBeforeEach:
 		return " in Spec Setup (BeforeEach)"
 	case types.SpecComponentTypeJustBeforeEach:
 		return " in Spec Setup (JustBeforeEach)"
 	case types.SpecComponentTypeAfterEach:
 		return " in Spec Teardown (AfterEach)"
+	case types.SpecComponentTypeAroundEach:
+		return " in Spec Interceptor (AroundEach)"
+	case types.SpecComponentTypeDeferCleanup:
+		return " in Spec Teardown (DeferCleanup)"
+	case types.SpecComponentTypeOnFailure:
+		return " in Failure Handler (OnFailure)"
 	}
 
 	return ""
 }
 
 func (s *consoleStenographer) printSkip(indentation int, spec types.SpecFailure) {
-	s.println(indentation, s.colorize(cyanColor, spec.Message))
+	s.println(indentation, s.colorize(cyanColor, s.wrap(indentation, spec.Message)))
 	s.printNewLine()
 	s.println(indentation, spec.Location.String())
 }
 
 func (s *consoleStenographer) printFailure(indentation int, state types.SpecState, failure types.SpecFailure, fullTrace bool) {
 	if state == types.SpecStatePanicked {
-		s.println(indentation, s.colorize(redColor+boldStyle, failure.Message))
-		s.println(indentation, s.colorize(redColor, failure.ForwardedPanic))
+		s.println(indentation, s.colorize(redColor+boldStyle, s.wrap(indentation, failure.Message)))
+		s.println(indentation, s.colorize(redColor, s.wrap(indentation, failure.ForwardedPanic)))
 		s.println(indentation, failure.Location.String())
 		s.printNewLine()
 		s.println(indentation, s.colorize(redColor, "Full Stack Trace"))
 		s.println(indentation, failure.Location.FullStackTrace)
 	} else {
-		s.println(indentation, s.colorize(redColor, failure.Message))
+		s.println(indentation, s.colorize(redColor, s.wrap(indentation, failure.Message)))
 		s.printNewLine()
 		s.println(indentation, failure.Location.String())
 		if fullTrace {
@@ -458,6 +712,10 @@ func (s *consoleStenographer) printSpecContext(componentTexts []string, componen
 				blockType = "BeforeSuite"
 			case types.SpecComponentTypeAfterSuite:
 				blockType = "AfterSuite"
+			case types.SpecComponentTypeWarmUp:
+				blockType = "WarmUp"
+			case types.SpecComponentTypeReportAfterSuite:
+				blockType = "ReportAfterSuite"
 			case types.SpecComponentTypeBeforeEach:
 				blockType = "BeforeEach"
 			case types.SpecComponentTypeJustBeforeEach:
@@ -468,6 +726,12 @@ func (s *consoleStenographer) printSpecContext(componentTexts []string, componen
 				blockType = "It"
 			case types.SpecComponentTypeMeasure:
 				blockType = "Measurement"
+			case types.SpecComponentTypeAroundEach:
+				blockType = "AroundEach"
+			case types.SpecComponentTypeDeferCleanup:
+				blockType = "DeferCleanup"
+			case types.SpecComponentTypeOnFailure:
+				blockType = "OnFailure"
 			}
 			if succinct {
 				s.print(0, s.colorize(color+boldStyle, "[%s] %s ", blockType, componentTexts[i]))
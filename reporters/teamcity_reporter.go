@@ -33,6 +33,10 @@ func NewTeamCityReporter(writer io.Writer) *TeamCityReporter {
 	}
 }
 
+func (reporter *TeamCityReporter) SetReporterConfig(reporterConfig config.DefaultReporterConfigType) {
+	reporter.ReporterConfig = reporterConfig
+}
+
 func (reporter *TeamCityReporter) SpecSuiteWillBegin(config config.GinkgoConfigType, summary *types.SuiteSummary) {
 	reporter.testSuiteName = escape(summary.SuiteDescription)
 	fmt.Fprintf(reporter.writer, "%s[testSuiteStarted name='%s']\n", messageId, reporter.testSuiteName)
@@ -59,12 +63,12 @@ func (reporter *TeamCityReporter) handleSetupSummary(name string, setupSummary *
 }
 
 func (reporter *TeamCityReporter) SpecWillRun(specSummary *types.SpecSummary) {
-	testName := escape(strings.Join(specSummary.ComponentTexts[1:], " "))
+	testName := escape(strings.Join(specSummary.TextsToDisplay()[1:], " "))
 	fmt.Fprintf(reporter.writer, "%s[testStarted name='%s']\n", messageId, testName)
 }
 
 func (reporter *TeamCityReporter) SpecDidComplete(specSummary *types.SpecSummary) {
-	testName := escape(strings.Join(specSummary.ComponentTexts[1:], " "))
+	testName := escape(strings.Join(specSummary.TextsToDisplay()[1:], " "))
 
 	if reporter.ReporterConfig.ReportPassed && specSummary.State == types.SpecStatePassed {
 		details := escape(specSummary.CapturedOutput)
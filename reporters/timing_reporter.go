@@ -0,0 +1,106 @@
+package reporters
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/onsi/ginkgo/config"
+	"github.com/onsi/ginkgo/formatter"
+	"github.com/onsi/ginkgo/types"
+)
+
+//TimingReporter summarizes the slow specs of a run against their historical run times and records the run in a TimingStore
+type TimingReporter struct {
+	store             TimingStore
+	writer            io.Writer
+	report            *JSONReporter
+	ReporterConfig    config.DefaultReporterConfigType
+	hasReporterConfig bool
+}
+
+//NewTimingReporter creates a new reporter backed by store.  The slow spec summary is printed to writer.
+func NewTimingReporter(writer io.Writer, store TimingStore) *TimingReporter {
+	return &TimingReporter{
+		store:  store,
+		writer: writer,
+		report: NewJSONReporter(""),
+	}
+}
+
+//SetReporterConfig gives the reporter its own configuration rather than the one of the flags
+func (reporter *TimingReporter) SetReporterConfig(reporterConfig config.DefaultReporterConfigType) {
+	reporter.ReporterConfig = reporterConfig
+	reporter.hasReporterConfig = true
+}
+
+func (reporter *TimingReporter) SpecSuiteWillBegin(ginkgoConfig config.GinkgoConfigType, summary *types.SuiteSummary) {
+	reporter.report.SpecSuiteWillBegin(ginkgoConfig, summary)
+	if !reporter.hasReporterConfig {
+		reporter.ReporterConfig = config.DefaultReporterConfig
+	}
+}
+
+func (reporter *TimingReporter) BeforeSuiteDidRun(setupSummary *types.SetupSummary) {
+	reporter.report.BeforeSuiteDidRun(setupSummary)
+}
+
+func (reporter *TimingReporter) SpecWillRun(specSummary *types.SpecSummary) {
+}
+
+func (reporter *TimingReporter) SpecDidComplete(specSummary *types.SpecSummary) {
+	reporter.report.SpecDidComplete(specSummary)
+}
+
+func (reporter *TimingReporter) AfterSuiteDidRun(setupSummary *types.SetupSummary) {
+	reporter.report.AfterSuiteDidRun(setupSummary)
+}
+
+func (reporter *TimingReporter) SpecSuiteDidEnd(summary *types.SuiteSummary) {
+	f := formatter.NewWithNoColorBool(reporter.ReporterConfig.NoColor)
+
+	report := reporter.report.report
+	report.SuiteSucceeded = summary.SuiteSucceeded
+	report.RunTime = summary.RunTime
+	report.SuiteSummary = summary
+
+	reporter.printSlowSpecs(f, report.SpecSummaries)
+
+	err := reporter.store.Put(report)
+	if err != nil {
+		fmt.Fprint(reporter.writer, f.F("\n{{orange}}Failed to record spec run times:{{/}}\n\t%s\n", err.Error()))
+	}
+}
+
+func (reporter *TimingReporter) printSlowSpecs(f formatter.Formatter, specSummaries []*types.SpecSummary) {
+	threshold := time.Duration(reporter.ReporterConfig.SlowSpecThreshold * float64(time.Second))
+	if threshold <= 0 {
+		return
+	}
+
+	slowSpecs := []*types.SpecSummary{}
+	for _, specSummary := range specSummaries {
+		if specSummary.Passed() && specSummary.RunTime >= threshold {
+			slowSpecs = append(slowSpecs, specSummary)
+		}
+	}
+	if len(slowSpecs) == 0 {
+		return
+	}
+	sort.SliceStable(slowSpecs, func(i, j int) bool {
+		return slowSpecs[i].RunTime > slowSpecs[j].RunTime
+	})
+
+	out := f.F("\n{{yellow}}{{bold}}%d Slow Specs:{{/}}\n", len(slowSpecs))
+	for _, specSummary := range slowSpecs {
+		specID := SpecFullText(specSummary)
+		historical, ok := reporter.store.Get(specID)
+		if ok {
+			out += f.Fi(1, "{{yellow}}%s{{/}} %.3fs (usually %.3fs)\n", specID, specSummary.RunTime.Seconds(), historical.Seconds())
+		} else {
+			out += f.Fi(1, "{{yellow}}%s{{/}} %.3fs (no history)\n", specID, specSummary.RunTime.Seconds())
+		}
+	}
+	fmt.Fprint(reporter.writer, out)
+}
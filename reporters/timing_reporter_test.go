@@ -0,0 +1,89 @@
+package reporters_test
+
+import (
+	"bytes"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/config"
+	"github.com/onsi/ginkgo/reporters"
+	"github.com/onsi/ginkgo/types"
+	. "github.com/onsi/gomega"
+)
+
+type fakeTimingStore struct {
+	timings reporters.Timings
+	reports []reporters.JSONReport
+	putErr  error
+}
+
+func (store *fakeTimingStore) Get(specID string) (time.Duration, bool) {
+	runTime, ok := store.timings[specID]
+	return runTime, ok
+}
+
+func (store *fakeTimingStore) Put(report reporters.JSONReport) error {
+	store.reports = append(store.reports, report)
+	return store.putErr
+}
+
+var _ = Describe("Timing Reporter", func() {
+	var (
+		store    *fakeTimingStore
+		buffer   *bytes.Buffer
+		reporter *reporters.TimingReporter
+	)
+
+	summary := func(text string, state types.SpecState, runTime time.Duration) *types.SpecSummary {
+		return &types.SpecSummary{
+			ComponentTexts: []string{"[Top Level]", "Suite", text},
+			State:          state,
+			RunTime:        runTime,
+		}
+	}
+
+	run := func() {
+		reporter.SpecSuiteWillBegin(config.GinkgoConfigType{RandomSeed: 3}, &types.SuiteSummary{SuiteDescription: "My test suite"})
+		reporter.ReporterConfig.NoColor = true
+		reporter.ReporterConfig.SlowSpecThreshold = 1
+		for _, specSummary := range []*types.SpecSummary{
+			summary("is fast", types.SpecStatePassed, 10*time.Millisecond),
+			summary("is slow", types.SpecStatePassed, 2*time.Second),
+			summary("is slower", types.SpecStatePassed, 3*time.Second),
+			summary("is slow but failed", types.SpecStateFailed, 5*time.Second),
+		} {
+			reporter.SpecWillRun(specSummary)
+			reporter.SpecDidComplete(specSummary)
+		}
+		reporter.SpecSuiteDidEnd(&types.SuiteSummary{SuiteSucceeded: false, RunTime: 10 * time.Second})
+	}
+
+	BeforeEach(func() {
+		store = &fakeTimingStore{timings: reporters.Timings{"Suite is slow": 500 * time.Millisecond}}
+		buffer = &bytes.Buffer{}
+		reporter = reporters.NewTimingReporter(buffer, store)
+	})
+
+	It("should summarize the slow passing specs, slowest first, against their history", func() {
+		run()
+		Ω(buffer.String()).Should(ContainSubstring("2 Slow Specs:\n  Suite is slower 3.000s (no history)\n  Suite is slow 2.000s (usually 0.500s)\n"))
+		Ω(buffer.String()).ShouldNot(ContainSubstring("is fast"))
+		Ω(buffer.String()).ShouldNot(ContainSubstring("failed"))
+	})
+
+	It("should record the run in the store", func() {
+		run()
+		Ω(store.reports).Should(HaveLen(1))
+		Ω(store.reports[0].SuiteDescription).Should(Equal("My test suite"))
+		Ω(store.reports[0].RandomSeed).Should(Equal(int64(3)))
+		Ω(store.reports[0].RunTime).Should(Equal(10 * time.Second))
+		Ω(store.reports[0].SpecSummaries).Should(HaveLen(4))
+	})
+
+	It("should warn when the run cannot be recorded", func() {
+		store.putErr = errors.New("boom")
+		run()
+		Ω(buffer.String()).Should(ContainSubstring("Failed to record spec run times:\n\tboom"))
+	})
+})
@@ -0,0 +1,184 @@
+/*
+
+Timing Stores for Ginkgo
+
+A TimingStore remembers how long each spec took in previous runs.  Ginkgo consults it to run the slowest specs first when
+running in parallel (so that a long spec does not end up starting last) and to put slow specs in perspective at the end of
+the run.  Once the suite ends the new timings are handed back to the store.
+
+Ginkgo ships with two stores:
+
+	ginkgo -timingStore=timings.json                          # a JSON file, typically cached between CI runs
+	ginkgo -timingStoreURL=https://timings.example.com/suite  # an HTTP endpoint shared across an organization
+
+The HTTP endpoint must answer GET requests with a JSON object mapping spec IDs (see SpecFullText) to durations in nanoseconds,
+and accept POST requests carrying the JSONReport of a run.  What it does with the report is up to the endpoint.
+
+*/
+
+package reporters
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+//TimingStore provides historical run times for specs, keyed by SpecFullText, and records the run times of new runs
+type TimingStore interface {
+	Get(specID string) (time.Duration, bool)
+	Put(report JSONReport) error
+}
+
+//Timings maps spec IDs to run times.  It is the document stored by the FileTimingStore and served by HTTP timing stores.
+type Timings map[string]time.Duration
+
+//Merge records the run time of every spec in the report that passed.  Failed specs are ignored as their run times are rarely representative.
+func (timings Timings) Merge(report JSONReport) {
+	for _, specSummary := range report.SpecSummaries {
+		if specSummary.Passed() {
+			timings[SpecFullText(specSummary)] = specSummary.RunTime
+		}
+	}
+}
+
+type FileTimingStore struct {
+	path string
+
+	lock    *sync.Mutex
+	timings Timings
+}
+
+//NewFileTimingStore creates a TimingStore backed by the JSON file at path.  A missing file is treated as an empty history.
+func NewFileTimingStore(path string) *FileTimingStore {
+	return &FileTimingStore{
+		path: path,
+		lock: &sync.Mutex{},
+	}
+}
+
+func (store *FileTimingStore) Get(specID string) (time.Duration, bool) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	if store.timings == nil {
+		store.timings, _ = store.load()
+	}
+	runTime, ok := store.timings[specID]
+	return runTime, ok
+}
+
+func (store *FileTimingStore) Put(report JSONReport) error {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	timings, err := store.load()
+	if err != nil {
+		return err
+	}
+	timings.Merge(report)
+	store.timings = timings
+
+	data, err := json.MarshalIndent(timings, "", "  ")
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(store.path), os.ModePerm)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(store.path, data, 0666)
+}
+
+func (store *FileTimingStore) load() (Timings, error) {
+	timings := Timings{}
+	data, err := ioutil.ReadFile(store.path)
+	if os.IsNotExist(err) {
+		return timings, nil
+	}
+	if err != nil {
+		return timings, err
+	}
+	err = json.Unmarshal(data, &timings)
+	return timings, err
+}
+
+type HTTPTimingStore struct {
+	url    string
+	client *http.Client
+
+	lock    *sync.Mutex
+	timings Timings
+}
+
+//NewHTTPTimingStore creates a TimingStore backed by the HTTP endpoint at url.  Timings are fetched once, on the first call to Get.
+func NewHTTPTimingStore(url string) *HTTPTimingStore {
+	return &HTTPTimingStore{
+		url:    url,
+		client: &http.Client{Timeout: 10 * time.Second},
+		lock:   &sync.Mutex{},
+	}
+}
+
+func (store *HTTPTimingStore) Get(specID string) (time.Duration, bool) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	if store.timings == nil {
+		var err error
+		store.timings, err = store.fetch()
+		if err != nil {
+			store.timings = Timings{}
+		}
+	}
+	runTime, ok := store.timings[specID]
+	return runTime, ok
+}
+
+//Timings fetches all the timings known to the endpoint
+func (store *HTTPTimingStore) Timings() (Timings, error) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	timings, err := store.fetch()
+	if err == nil {
+		store.timings = timings
+	}
+	return timings, err
+}
+
+func (store *HTTPTimingStore) Put(report JSONReport) error {
+	data, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+	resp, err := store.client.Post(store.url, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code %d from %s", resp.StatusCode, store.url)
+	}
+	return nil
+}
+
+func (store *HTTPTimingStore) fetch() (Timings, error) {
+	timings := Timings{}
+	resp, err := store.client.Get(store.url)
+	if err != nil {
+		return timings, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return timings, fmt.Errorf("unexpected status code %d from %s", resp.StatusCode, store.url)
+	}
+	err = json.NewDecoder(resp.Body).Decode(&timings)
+	return timings, err
+}
@@ -0,0 +1,153 @@
+package reporters_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/reporters"
+	"github.com/onsi/ginkgo/types"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Timing Stores", func() {
+	summary := func(text string, state types.SpecState, runTime time.Duration) *types.SpecSummary {
+		return &types.SpecSummary{
+			ComponentTexts: []string{"[Top Level]", "Suite", text},
+			State:          state,
+			RunTime:        runTime,
+		}
+	}
+
+	report := reporters.JSONReport{
+		SuiteDescription: "My test suite",
+		SpecSummaries: []*types.SpecSummary{
+			summary("passes", types.SpecStatePassed, 2*time.Second),
+			summary("fails", types.SpecStateFailed, 3*time.Second),
+		},
+	}
+
+	Describe("Timings", func() {
+		It("should only merge the run times of passing specs", func() {
+			timings := reporters.Timings{"Suite fails": time.Second}
+			timings.Merge(report)
+			Ω(timings).Should(Equal(reporters.Timings{
+				"Suite passes": 2 * time.Second,
+				"Suite fails":  time.Second,
+			}))
+		})
+	})
+
+	Describe("FileTimingStore", func() {
+		var dir, path string
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "timing-store")
+			Ω(err).ShouldNot(HaveOccurred())
+			path = filepath.Join(dir, "nested", "timings.json")
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		It("should treat a missing file as an empty history", func() {
+			_, ok := reporters.NewFileTimingStore(path).Get("Suite passes")
+			Ω(ok).Should(BeFalse())
+		})
+
+		It("should record run times and read them back", func() {
+			Ω(reporters.NewFileTimingStore(path).Put(report)).Should(Succeed())
+
+			store := reporters.NewFileTimingStore(path)
+			runTime, ok := store.Get("Suite passes")
+			Ω(ok).Should(BeTrue())
+			Ω(runTime).Should(Equal(2 * time.Second))
+			_, ok = store.Get("Suite fails")
+			Ω(ok).Should(BeFalse())
+		})
+
+		It("should keep the run times of specs that did not run", func() {
+			Ω(os.MkdirAll(filepath.Dir(path), os.ModePerm)).Should(Succeed())
+			Ω(ioutil.WriteFile(path, []byte(`{"Suite other": 1000}`), 0666)).Should(Succeed())
+			Ω(reporters.NewFileTimingStore(path).Put(report)).Should(Succeed())
+
+			runTime, ok := reporters.NewFileTimingStore(path).Get("Suite other")
+			Ω(ok).Should(BeTrue())
+			Ω(runTime).Should(Equal(time.Microsecond))
+		})
+
+		It("should fail to record run times when the file is corrupt", func() {
+			Ω(os.MkdirAll(filepath.Dir(path), os.ModePerm)).Should(Succeed())
+			Ω(ioutil.WriteFile(path, []byte("not json"), 0666)).Should(Succeed())
+			Ω(reporters.NewFileTimingStore(path).Put(report)).ShouldNot(Succeed())
+		})
+	})
+
+	Describe("HTTPTimingStore", func() {
+		var server *ghttp.Server
+		var store *reporters.HTTPTimingStore
+
+		BeforeEach(func() {
+			server = ghttp.NewServer()
+			store = reporters.NewHTTPTimingStore(server.URL() + "/timings")
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("should fetch the timings once", func() {
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/timings"),
+				ghttp.RespondWithJSONEncoded(http.StatusOK, reporters.Timings{"Suite passes": time.Second}),
+			))
+
+			runTime, ok := store.Get("Suite passes")
+			Ω(ok).Should(BeTrue())
+			Ω(runTime).Should(Equal(time.Second))
+			_, ok = store.Get("Suite fails")
+			Ω(ok).Should(BeFalse())
+			Ω(server.ReceivedRequests()).Should(HaveLen(1))
+		})
+
+		It("should treat an unreachable endpoint as an empty history", func() {
+			server.AppendHandlers(
+				ghttp.RespondWith(http.StatusInternalServerError, ""),
+				ghttp.RespondWith(http.StatusInternalServerError, ""),
+			)
+
+			_, ok := store.Get("Suite passes")
+			Ω(ok).Should(BeFalse())
+
+			_, err := store.Timings()
+			Ω(err).Should(HaveOccurred())
+		})
+
+		It("should post the report", func() {
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("POST", "/timings"),
+				ghttp.VerifyContentType("application/json"),
+				func(w http.ResponseWriter, req *http.Request) {
+					var received reporters.JSONReport
+					Ω(json.NewDecoder(req.Body).Decode(&received)).Should(Succeed())
+					Ω(received.SuiteDescription).Should(Equal("My test suite"))
+					Ω(received.SpecSummaries).Should(HaveLen(2))
+				},
+			))
+
+			Ω(store.Put(report)).Should(Succeed())
+		})
+
+		It("should fail when the endpoint rejects the report", func() {
+			server.AppendHandlers(ghttp.RespondWith(http.StatusBadRequest, ""))
+			Ω(store.Put(report)).ShouldNot(Succeed())
+		})
+	})
+})
@@ -0,0 +1,247 @@
+/*
+
+Webhook Reporter for Ginkgo
+
+The webhook reporter posts a compact summary of the suite run, including the list of failures, to a webhook once the suite ends.
+The default payload is understood by Slack (and Slack-compatible) incoming webhooks:
+
+	ginkgo -notifyWebhook=https://hooks.slack.com/services/... -notifyArtifactsURL=https://ci.example.com/builds/42/artifacts
+
+The payload can be customized with a Go text/template (see -notifyTemplate).  The template is executed against a
+WebhookNotification and can use the json function to embed values safely, for example:
+
+	{"content": {{json .Summary}}, "failures": {{json .Failures}}}
+
+*/
+
+package reporters
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/onsi/ginkgo/config"
+	"github.com/onsi/ginkgo/formatter"
+	"github.com/onsi/ginkgo/types"
+)
+
+//DefaultWebhookTemplate is the payload posted when no template is provided.  It is understood by Slack incoming webhooks.
+const DefaultWebhookTemplate = `{"text": {{json .Summary}}}`
+
+//WebhookNotification is the data the webhook payload template is executed against
+type WebhookNotification struct {
+	SuiteDescription string
+	SuiteSucceeded   bool
+	RunTime          time.Duration
+	SuiteLabels      []string
+	SuiteMetadata    map[string]string
+
+	NumberOfSpecs        int
+	NumberOfPassedSpecs  int
+	NumberOfFailedSpecs  int
+	NumberOfPendingSpecs int
+	NumberOfSkippedSpecs int
+	NumberOfFlakedSpecs  int
+
+	Failures     []WebhookFailure
+	ArtifactsURL string
+
+	//Summary is a human-readable rendition of the notification, used by the default template
+	Summary string
+}
+
+//WebhookFailure describes a spec (or BeforeSuite/AfterSuite) that failed
+type WebhookFailure struct {
+	Text         string
+	Location     string
+	Message      string
+	ArtifactsURL string
+}
+
+type WebhookReporter struct {
+	url          string
+	templateFile string
+	artifactsURL string
+	writer       io.Writer
+	client       *http.Client
+
+	suiteDescription string
+	failures         []WebhookFailure
+	failedSpecs      map[string]int
+	noColor          bool
+}
+
+/*
+NewWebhookReporter creates a new reporter that posts to webhookURL once the suite ends.
+
+If templateFile is empty DefaultWebhookTemplate is used.  artifactsURL, if set, is linked from the notification;
+occurrences of {spec} in it are replaced by the (query-escaped) text of each failed spec to build per-failure links,
+and dropped from the suite-level link.
+Problems are reported to writer.
+*/
+func NewWebhookReporter(writer io.Writer, webhookURL string, templateFile string, artifactsURL string) *WebhookReporter {
+	return &WebhookReporter{
+		url:          webhookURL,
+		templateFile: templateFile,
+		artifactsURL: artifactsURL,
+		writer:       writer,
+		client:       &http.Client{Timeout: 10 * time.Second},
+		failedSpecs:  map[string]int{},
+	}
+}
+
+func (reporter *WebhookReporter) SpecSuiteWillBegin(ginkgoConfig config.GinkgoConfigType, summary *types.SuiteSummary) {
+	reporter.suiteDescription = summary.SuiteDescription
+	reporter.noColor = config.DefaultReporterConfig.NoColor
+}
+
+func (reporter *WebhookReporter) BeforeSuiteDidRun(setupSummary *types.SetupSummary) {
+	reporter.handleSetupSummary("BeforeSuite", setupSummary)
+}
+
+func (reporter *WebhookReporter) SpecWillRun(specSummary *types.SpecSummary) {
+}
+
+func (reporter *WebhookReporter) SpecDidComplete(specSummary *types.SpecSummary) {
+	text := SpecFullText(specSummary)
+	index, failedBefore := reporter.failedSpecs[text]
+	if specSummary.HasFailureState() {
+		failure := reporter.failure(SpecDisplayText(specSummary), text, specSummary.Failure)
+		if failedBefore {
+			reporter.failures[index] = failure
+		} else {
+			reporter.failedSpecs[text] = len(reporter.failures)
+			reporter.failures = append(reporter.failures, failure)
+		}
+	} else if failedBefore && specSummary.Passed() {
+		//the spec flaked - it is no longer a failure
+		reporter.failures = append(reporter.failures[:index], reporter.failures[index+1:]...)
+		delete(reporter.failedSpecs, text)
+		for other, otherIndex := range reporter.failedSpecs {
+			if otherIndex > index {
+				reporter.failedSpecs[other] = otherIndex - 1
+			}
+		}
+	}
+}
+
+func (reporter *WebhookReporter) AfterSuiteDidRun(setupSummary *types.SetupSummary) {
+	reporter.handleSetupSummary("AfterSuite", setupSummary)
+}
+
+func (reporter *WebhookReporter) SpecSuiteDidEnd(summary *types.SuiteSummary) {
+	notification := reporter.Notification(summary)
+	err := reporter.post(notification)
+	if err != nil {
+		f := formatter.NewWithNoColorBool(reporter.noColor)
+		fmt.Fprint(reporter.writer, f.F("\n{{orange}}Failed to send webhook notification:{{/}}\n\t%s\n", err.Error()))
+	}
+}
+
+//Notification returns the notification for the suite run described by summary
+func (reporter *WebhookReporter) Notification(summary *types.SuiteSummary) WebhookNotification {
+	notification := WebhookNotification{
+		SuiteDescription:     reporter.suiteDescription,
+		SuiteSucceeded:       summary.SuiteSucceeded,
+		RunTime:              summary.RunTime,
+		SuiteLabels:          summary.SuiteLabels,
+		SuiteMetadata:        summary.SuiteMetadata,
+		NumberOfSpecs:        summary.NumberOfSpecsThatWillBeRun,
+		NumberOfPassedSpecs:  summary.NumberOfPassedSpecs,
+		NumberOfFailedSpecs:  summary.NumberOfFailedSpecs,
+		NumberOfPendingSpecs: summary.NumberOfPendingSpecs,
+		NumberOfSkippedSpecs: summary.NumberOfSkippedSpecs,
+		NumberOfFlakedSpecs:  summary.NumberOfFlakedSpecs,
+		Failures:             append([]WebhookFailure{}, reporter.failures...),
+		ArtifactsURL:         strings.Replace(reporter.artifactsURL, "{spec}", "", -1),
+	}
+	notification.Summary = webhookSummary(notification)
+	return notification
+}
+
+func (reporter *WebhookReporter) handleSetupSummary(name string, setupSummary *types.SetupSummary) {
+	if setupSummary.State.IsFailure() {
+		reporter.failures = append(reporter.failures, reporter.failure(name, name, setupSummary.Failure))
+	}
+}
+
+//failure describes failure to the readers by text, and links to the artifacts filed under spec, see SpecFullText
+func (reporter *WebhookReporter) failure(text string, spec string, failure types.SpecFailure) WebhookFailure {
+	result := WebhookFailure{
+		Text:     text,
+		Location: failure.Location.String(),
+		Message:  failure.Message,
+	}
+	if reporter.artifactsURL != "" {
+		result.ArtifactsURL = strings.Replace(reporter.artifactsURL, "{spec}", url.QueryEscape(spec), -1)
+	}
+	return result
+}
+
+func (reporter *WebhookReporter) post(notification WebhookNotification) error {
+	payload, err := reporter.render(notification)
+	if err != nil {
+		return err
+	}
+	resp, err := reporter.client.Post(reporter.url, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code %d from %s", resp.StatusCode, reporter.url)
+	}
+	return nil
+}
+
+func (reporter *WebhookReporter) render(notification WebhookNotification) ([]byte, error) {
+	text := DefaultWebhookTemplate
+	if reporter.templateFile != "" {
+		data, err := ioutil.ReadFile(reporter.templateFile)
+		if err != nil {
+			return nil, err
+		}
+		text = string(data)
+	}
+	tmpl, err := template.New("webhook").Funcs(template.FuncMap{
+		"json": func(value interface{}) (string, error) {
+			data, err := json.Marshal(value)
+			return string(data), err
+		},
+	}).Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	out := &bytes.Buffer{}
+	err = tmpl.Execute(out, notification)
+	return out.Bytes(), err
+}
+
+func webhookSummary(notification WebhookNotification) string {
+	outcome := "passed"
+	if !notification.SuiteSucceeded {
+		outcome = "FAILED"
+	}
+	out := fmt.Sprintf("%s %s in %.3fs: %d passed, %d failed, %d flaked, %d pending, %d skipped",
+		notification.SuiteDescription, outcome, notification.RunTime.Seconds(),
+		notification.NumberOfPassedSpecs, notification.NumberOfFailedSpecs, notification.NumberOfFlakedSpecs,
+		notification.NumberOfPendingSpecs, notification.NumberOfSkippedSpecs)
+	for _, failure := range notification.Failures {
+		out += fmt.Sprintf("\n- %s (%s)", failure.Text, failure.Location)
+		if failure.ArtifactsURL != "" {
+			out += fmt.Sprintf(" <%s|artifacts>", failure.ArtifactsURL)
+		}
+	}
+	if notification.ArtifactsURL != "" {
+		out += fmt.Sprintf("\nArtifacts: %s", notification.ArtifactsURL)
+	}
+	return out
+}
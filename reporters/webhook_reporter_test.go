@@ -0,0 +1,153 @@
+package reporters_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/config"
+	"github.com/onsi/ginkgo/reporters"
+	"github.com/onsi/ginkgo/types"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Webhook Reporter", func() {
+	var (
+		server   *ghttp.Server
+		buffer   *bytes.Buffer
+		reporter *reporters.WebhookReporter
+		payload  []byte
+	)
+
+	summary := func(text string, state types.SpecState, message string) *types.SpecSummary {
+		return &types.SpecSummary{
+			ComponentTexts: []string{"[Top Level]", "Suite", text},
+			State:          state,
+			Failure: types.SpecFailure{
+				Message:  message,
+				Location: types.CodeLocation{FileName: "suite_test.go", LineNumber: 12},
+			},
+		}
+	}
+
+	run := func(succeeded bool, specSummaries ...*types.SpecSummary) {
+		reporter.SpecSuiteWillBegin(config.GinkgoConfigType{}, &types.SuiteSummary{SuiteDescription: "My test suite"})
+		reporter.BeforeSuiteDidRun(&types.SetupSummary{State: types.SpecStatePassed})
+		for _, specSummary := range specSummaries {
+			reporter.SpecWillRun(specSummary)
+			reporter.SpecDidComplete(specSummary)
+		}
+		reporter.AfterSuiteDidRun(&types.SetupSummary{
+			State:   types.SpecStateFailed,
+			Failure: types.SpecFailure{Message: "cleanup failed", Location: types.CodeLocation{FileName: "suite_test.go", LineNumber: 30}},
+		})
+		reporter.SpecSuiteDidEnd(&types.SuiteSummary{
+			SuiteSucceeded:      succeeded,
+			RunTime:             1500 * time.Millisecond,
+			NumberOfPassedSpecs: 2,
+			NumberOfFailedSpecs: 1,
+			NumberOfFlakedSpecs: 1,
+			SuiteLabels:         []string{"nightly"},
+			SuiteMetadata:       map[string]string{"gitSHA": "abc123"},
+		})
+	}
+
+	BeforeEach(func() {
+		buffer = &bytes.Buffer{}
+		payload = nil
+		server = ghttp.NewServer()
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("POST", "/hook"),
+			ghttp.VerifyContentType("application/json"),
+			func(w http.ResponseWriter, req *http.Request) {
+				payload, _ = ioutil.ReadAll(req.Body)
+			},
+		))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should post a Slack-compatible summary listing the failures", func() {
+		reporter = reporters.NewWebhookReporter(buffer, server.URL()+"/hook", "", "https://ci.example.com/42?spec={spec}")
+		run(false,
+			summary("passes", types.SpecStatePassed, ""),
+			summary("flakes", types.SpecStateFailed, "flaked"),
+			summary("fails", types.SpecStateFailed, "boom"),
+			summary("flakes", types.SpecStatePassed, ""),
+			summary("is pending", types.SpecStatePending, ""),
+		)
+
+		Ω(server.ReceivedRequests()).Should(HaveLen(1))
+		var message struct{ Text string }
+		Ω(json.Unmarshal(payload, &message)).Should(Succeed())
+		Ω(message.Text).Should(Equal("My test suite FAILED in 1.500s: 2 passed, 1 failed, 1 flaked, 0 pending, 0 skipped\n" +
+			"- Suite fails (suite_test.go:12) <https://ci.example.com/42?spec=Suite+fails|artifacts>\n" +
+			"- AfterSuite (suite_test.go:30) <https://ci.example.com/42?spec=AfterSuite|artifacts>\n" +
+			"Artifacts: https://ci.example.com/42?spec="))
+		Ω(buffer.String()).Should(BeEmpty())
+	})
+
+	Context("with a template", func() {
+		var dir string
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "webhook")
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		It("should render the payload from the template", func() {
+			templateFile := filepath.Join(dir, "payload.tmpl")
+			Ω(ioutil.WriteFile(templateFile, []byte(`{"suite": {{json .SuiteDescription}}, "ok": {{.SuiteSucceeded}}, "failures": [{{range $i, $f := .Failures}}{{if $i}}, {{end}}{{json $f.Message}}{{end}}]}`), 0666)).Should(Succeed())
+
+			reporter = reporters.NewWebhookReporter(buffer, server.URL()+"/hook", templateFile, "")
+			run(false, summary("fails", types.SpecStateFailed, `a "quoted" failure`))
+
+			Ω(string(payload)).Should(MatchJSON(`{"suite": "My test suite", "ok": false, "failures": ["a \"quoted\" failure", "cleanup failed"]}`))
+		})
+
+		It("should make the suite labels and metadata available to the template", func() {
+			templateFile := filepath.Join(dir, "payload.tmpl")
+			Ω(ioutil.WriteFile(templateFile, []byte(`{"labels": {{json .SuiteLabels}}, "sha": {{json .SuiteMetadata.gitSHA}}}`), 0666)).Should(Succeed())
+
+			reporter = reporters.NewWebhookReporter(buffer, server.URL()+"/hook", templateFile, "")
+			run(true)
+
+			Ω(string(payload)).Should(MatchJSON(`{"labels": ["nightly"], "sha": "abc123"}`))
+		})
+
+		It("should report templates that cannot be rendered", func() {
+			templateFile := filepath.Join(dir, "payload.tmpl")
+			Ω(ioutil.WriteFile(templateFile, []byte(`{{.DoesNotExist}}`), 0666)).Should(Succeed())
+
+			reporter = reporters.NewWebhookReporter(buffer, server.URL()+"/hook", templateFile, "")
+			run(true)
+
+			Ω(server.ReceivedRequests()).Should(BeEmpty())
+			Ω(buffer.String()).Should(ContainSubstring("Failed to send webhook notification:"))
+			Ω(buffer.String()).Should(ContainSubstring("DoesNotExist"))
+		})
+	})
+
+	It("should report webhooks that reject the notification", func() {
+		server.SetHandler(0, ghttp.RespondWith(http.StatusForbidden, ""))
+
+		reporter = reporters.NewWebhookReporter(buffer, server.URL()+"/hook", "", "")
+		run(true)
+
+		Ω(buffer.String()).Should(ContainSubstring("Failed to send webhook notification:"))
+		Ω(buffer.String()).Should(ContainSubstring("unexpected status code 403"))
+	})
+})
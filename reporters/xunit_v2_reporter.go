@@ -0,0 +1,280 @@
+/*
+
+xUnit.net v2 XML Reporter for Ginkgo
+
+The xUnit.net v2 reporter writes the results of a suite run using the xUnit.net v2 XML schema, for tooling that
+does not understand JUnit XML:
+
+	ginkgo -xunitV2Report=xunit.xml
+
+Specs are grouped in collections named after their containers, and spec labels are reported as traits.  Suite labels
+are reported as suite-label traits of every test, and the suite metadata as the environment of the assembly.
+
+*/
+
+package reporters
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/onsi/ginkgo/config"
+	"github.com/onsi/ginkgo/internal/clock"
+	"github.com/onsi/ginkgo/types"
+)
+
+type XUnitV2Assemblies struct {
+	XMLName    xml.Name          `xml:"assemblies"`
+	Timestamp  string            `xml:"timestamp,attr"`
+	Assemblies []XUnitV2Assembly `xml:"assembly"`
+}
+
+type XUnitV2Assembly struct {
+	Name          string              `xml:"name,attr"`
+	Environment   string              `xml:"environment,attr,omitempty"`
+	TestFramework string              `xml:"test-framework,attr"`
+	RunDate       string              `xml:"run-date,attr"`
+	RunTime       string              `xml:"run-time,attr"`
+	Time          string              `xml:"time,attr"`
+	Total         int                 `xml:"total,attr"`
+	Passed        int                 `xml:"passed,attr"`
+	Failed        int                 `xml:"failed,attr"`
+	Skipped       int                 `xml:"skipped,attr"`
+	Errors        int                 `xml:"errors,attr"`
+	ErrorList     XUnitV2Errors       `xml:"errors"`
+	Collections   []XUnitV2Collection `xml:"collection"`
+}
+
+type XUnitV2Errors struct {
+	Errors []XUnitV2Error `xml:"error"`
+}
+
+type XUnitV2Error struct {
+	Type    string         `xml:"type,attr"`
+	Name    string         `xml:"name,attr"`
+	Failure XUnitV2Failure `xml:"failure"`
+}
+
+type XUnitV2Collection struct {
+	Name    string        `xml:"name,attr"`
+	Time    string        `xml:"time,attr"`
+	Total   int           `xml:"total,attr"`
+	Passed  int           `xml:"passed,attr"`
+	Failed  int           `xml:"failed,attr"`
+	Skipped int           `xml:"skipped,attr"`
+	Tests   []XUnitV2Test `xml:"test"`
+}
+
+type XUnitV2Test struct {
+	Name    string          `xml:"name,attr"`
+	Type    string          `xml:"type,attr"`
+	Method  string          `xml:"method,attr"`
+	Time    string          `xml:"time,attr"`
+	Result  string          `xml:"result,attr"`
+	Traits  *XUnitV2Traits  `xml:"traits,omitempty"`
+	Failure *XUnitV2Failure `xml:"failure,omitempty"`
+	Reason  string          `xml:"reason,omitempty"`
+	Output  string          `xml:"output,omitempty"`
+
+	runTime time.Duration
+}
+
+type XUnitV2Traits struct {
+	Traits []XUnitV2Trait `xml:"trait"`
+}
+
+type XUnitV2Trait struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type XUnitV2Failure struct {
+	ExceptionType string `xml:"exception-type,attr"`
+	Message       string `xml:"message"`
+	StackTrace    string `xml:"stack-trace"`
+}
+
+type XUnitV2Reporter struct {
+	filename string
+
+	assembly    XUnitV2Assembly
+	startTime   time.Time
+	collections []*xunitV2Collection
+	tests       map[string]*XUnitV2Test
+	suiteLabels []string
+}
+
+type xunitV2Collection struct {
+	name  string
+	tests []*XUnitV2Test
+}
+
+//NewXUnitV2Reporter creates a new xUnit.net v2 XML reporter.  The XML will be stored in the passed in filename.
+func NewXUnitV2Reporter(filename string) *XUnitV2Reporter {
+	return &XUnitV2Reporter{
+		filename: filename,
+	}
+}
+
+func (reporter *XUnitV2Reporter) SpecSuiteWillBegin(ginkgoConfig config.GinkgoConfigType, summary *types.SuiteSummary) {
+	reporter.startTime = clock.Now()
+	environment := []string{}
+	for _, key := range SortedSuiteMetadataKeys(summary) {
+		environment = append(environment, key+"="+summary.SuiteMetadata[key])
+	}
+	reporter.assembly = XUnitV2Assembly{
+		Name:          summary.SuiteDescription,
+		Environment:   strings.Join(environment, "; "),
+		TestFramework: "ginkgo",
+		RunDate:       reporter.startTime.Format("2006-01-02"),
+		RunTime:       reporter.startTime.Format("15:04:05"),
+	}
+	reporter.suiteLabels = summary.SuiteLabels
+	reporter.collections = []*xunitV2Collection{}
+	reporter.tests = map[string]*XUnitV2Test{}
+}
+
+func (reporter *XUnitV2Reporter) BeforeSuiteDidRun(setupSummary *types.SetupSummary) {
+	reporter.handleSetupSummary("BeforeSuite", "fatal", setupSummary)
+}
+
+func (reporter *XUnitV2Reporter) SpecWillRun(specSummary *types.SpecSummary) {
+}
+
+func (reporter *XUnitV2Reporter) SpecDidComplete(specSummary *types.SpecSummary) {
+	texts := specSummary.TextsToDisplay()
+	collection := "[Top Level]"
+	if len(texts) > 2 {
+		collection = strings.Join(texts[1:len(texts)-1], " ")
+	}
+	name := SpecDisplayText(specSummary)
+
+	test := &XUnitV2Test{
+		Name:    name,
+		Type:    collection,
+		Method:  texts[len(texts)-1],
+		Time:    xunitV2Seconds(specSummary.RunTime),
+		runTime: specSummary.RunTime,
+	}
+	switch {
+	case specSummary.Passed():
+		test.Result = "Pass"
+	case specSummary.Pending() || specSummary.Skipped():
+		test.Result = "Skip"
+		test.Reason = specSummary.State.String()
+		if specSummary.Failure.Message != "" {
+			test.Reason = specSummary.Failure.Message
+		}
+	default:
+		test.Result = "Fail"
+		test.Failure = xunitV2Failure(specSummary.State, specSummary.Failure)
+		test.Output = specSummary.CapturedOutput
+	}
+	if len(reporter.suiteLabels) > 0 || len(specSummary.Labels) > 0 {
+		test.Traits = &XUnitV2Traits{}
+		for _, label := range reporter.suiteLabels {
+			test.Traits.Traits = append(test.Traits.Traits, XUnitV2Trait{Name: "suite-label", Value: label})
+		}
+		for _, label := range specSummary.Labels {
+			test.Traits.Traits = append(test.Traits.Traits, XUnitV2Trait{Name: "label", Value: label})
+		}
+	}
+
+	//when a spec is retried (see -flakeAttempts) only its last attempt is kept
+	if existing, ok := reporter.tests[name]; ok {
+		*existing = *test
+		return
+	}
+	reporter.tests[name] = test
+	for _, c := range reporter.collections {
+		if c.name == collection {
+			c.tests = append(c.tests, test)
+			return
+		}
+	}
+	reporter.collections = append(reporter.collections, &xunitV2Collection{name: collection, tests: []*XUnitV2Test{test}})
+}
+
+func (reporter *XUnitV2Reporter) AfterSuiteDidRun(setupSummary *types.SetupSummary) {
+	reporter.handleSetupSummary("AfterSuite", "assembly-cleanup", setupSummary)
+}
+
+func (reporter *XUnitV2Reporter) SpecSuiteDidEnd(summary *types.SuiteSummary) {
+	assembly := reporter.assembly
+	assembly.Time = xunitV2Seconds(summary.RunTime)
+	assembly.Errors = len(assembly.ErrorList.Errors)
+	for _, c := range reporter.collections {
+		collection := XUnitV2Collection{Name: c.name}
+		runTime := time.Duration(0)
+		for _, test := range c.tests {
+			collection.Tests = append(collection.Tests, *test)
+			collection.Total++
+			runTime += test.runTime
+			switch test.Result {
+			case "Pass":
+				collection.Passed++
+			case "Skip":
+				collection.Skipped++
+			default:
+				collection.Failed++
+			}
+		}
+		collection.Time = xunitV2Seconds(runTime)
+		assembly.Total += collection.Total
+		assembly.Passed += collection.Passed
+		assembly.Failed += collection.Failed
+		assembly.Skipped += collection.Skipped
+		assembly.Collections = append(assembly.Collections, collection)
+	}
+
+	document := XUnitV2Assemblies{
+		Timestamp:  reporter.startTime.Format("01/02/2006 15:04:05"),
+		Assemblies: []XUnitV2Assembly{assembly},
+	}
+
+	filePath := reportFilePath(reporter.filename)
+	file, err := createReportFile(reporter.filename)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\nFailed to create xUnit.net report file: %s\n\t%s", filePath, err.Error())
+		return
+	}
+	defer file.Close()
+	io.WriteString(file, xml.Header)
+	encoder := xml.NewEncoder(file)
+	encoder.Indent("", "  ")
+	err = encoder.Encode(document)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\nFailed to generate xUnit.net report data:\n\t%s", err.Error())
+	}
+}
+
+func (reporter *XUnitV2Reporter) handleSetupSummary(name string, errorType string, setupSummary *types.SetupSummary) {
+	if setupSummary.State == types.SpecStatePassed {
+		return
+	}
+	reporter.assembly.ErrorList.Errors = append(reporter.assembly.ErrorList.Errors, XUnitV2Error{
+		Type:    errorType,
+		Name:    name,
+		Failure: *xunitV2Failure(setupSummary.State, setupSummary.Failure),
+	})
+}
+
+func xunitV2Failure(state types.SpecState, failure types.SpecFailure) *XUnitV2Failure {
+	result := &XUnitV2Failure{
+		ExceptionType: state.String(),
+		Message:       failure.Message,
+		StackTrace:    failureMessage(failure),
+	}
+	if state == types.SpecStatePanicked {
+		result.StackTrace += fmt.Sprintf("\n\nPanic: %s\n\nFull stack:\n%s", failure.ForwardedPanic, failure.Location.FullStackTrace)
+	}
+	return result
+}
+
+func xunitV2Seconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
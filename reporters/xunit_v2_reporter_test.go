@@ -0,0 +1,190 @@
+package reporters_test
+
+import (
+	"encoding/xml"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/config"
+	"github.com/onsi/ginkgo/reporters"
+	"github.com/onsi/ginkgo/types"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("xUnit.net v2 Reporter", func() {
+	var (
+		dir        string
+		outputFile string
+		reporter   *reporters.XUnitV2Reporter
+	)
+
+	summary := func(state types.SpecState, runTime time.Duration, texts ...string) *types.SpecSummary {
+		return &types.SpecSummary{
+			ComponentTexts: append([]string{"[Top Level]"}, texts...),
+			State:          state,
+			RunTime:        runTime,
+		}
+	}
+
+	readOutputFile := func() reporters.XUnitV2Assembly {
+		data, err := ioutil.ReadFile(outputFile)
+		Ω(err).ShouldNot(HaveOccurred())
+		var assemblies reporters.XUnitV2Assemblies
+		Ω(xml.Unmarshal(data, &assemblies)).Should(Succeed())
+		Ω(assemblies.Timestamp).ShouldNot(BeEmpty())
+		Ω(assemblies.Assemblies).Should(HaveLen(1))
+		return assemblies.Assemblies[0]
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "xunit")
+		Ω(err).ShouldNot(HaveOccurred())
+		outputFile = filepath.Join(dir, "nested", "xunit.xml")
+		reporter = reporters.NewXUnitV2Reporter(outputFile)
+		reporter.SpecSuiteWillBegin(config.GinkgoConfigType{}, &types.SuiteSummary{SuiteDescription: "My test suite"})
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	Context("with a mix of spec outcomes", func() {
+		BeforeEach(func() {
+			reporter.BeforeSuiteDidRun(&types.SetupSummary{State: types.SpecStatePassed})
+
+			passing := summary(types.SpecStatePassed, 1500*time.Millisecond, "Suite", "Context", "passes")
+			passing.Labels = []string{"fast", "storage"}
+			failing := summary(types.SpecStateFailed, 500*time.Millisecond, "Suite", "Context", "fails")
+			failing.Failure = types.SpecFailure{
+				Message:               "boom",
+				Location:              types.CodeLocation{FileName: "suite_test.go", LineNumber: 5},
+				ComponentCodeLocation: types.CodeLocation{FileName: "suite_test.go", LineNumber: 3},
+			}
+			failing.CapturedOutput = "some output"
+			panicking := summary(types.SpecStatePanicked, 0, "Suite", "panics")
+			panicking.Failure = types.SpecFailure{Message: "Test Panicked", ForwardedPanic: "oops"}
+
+			for _, specSummary := range []*types.SpecSummary{
+				passing,
+				failing,
+				panicking,
+				summary(types.SpecStatePending, 0, "Suite", "Context", "is pending"),
+				summary(types.SpecStatePassed, 0, "is top level"),
+			} {
+				reporter.SpecWillRun(specSummary)
+				reporter.SpecDidComplete(specSummary)
+			}
+
+			reporter.AfterSuiteDidRun(&types.SetupSummary{State: types.SpecStateFailed, Failure: types.SpecFailure{Message: "cleanup failed"}})
+			reporter.SpecSuiteDidEnd(&types.SuiteSummary{RunTime: 3 * time.Second})
+		})
+
+		It("should describe the suite as an assembly", func() {
+			assembly := readOutputFile()
+			Ω(assembly.Name).Should(Equal("My test suite"))
+			Ω(assembly.TestFramework).Should(Equal("ginkgo"))
+			Ω(assembly.RunDate).Should(MatchRegexp(`^\d{4}-\d{2}-\d{2}$`))
+			Ω(assembly.RunTime).Should(MatchRegexp(`^\d{2}:\d{2}:\d{2}$`))
+			Ω(assembly.Time).Should(Equal("3.000"))
+			Ω(assembly.Total).Should(Equal(5))
+			Ω(assembly.Passed).Should(Equal(2))
+			Ω(assembly.Failed).Should(Equal(2))
+			Ω(assembly.Skipped).Should(Equal(1))
+			Ω(assembly.Environment).Should(BeEmpty())
+		})
+
+		It("should report failed BeforeSuite and AfterSuite nodes as errors", func() {
+			assembly := readOutputFile()
+			Ω(assembly.Errors).Should(Equal(1))
+			Ω(assembly.ErrorList.Errors).Should(HaveLen(1))
+			Ω(assembly.ErrorList.Errors[0].Type).Should(Equal("assembly-cleanup"))
+			Ω(assembly.ErrorList.Errors[0].Name).Should(Equal("AfterSuite"))
+			Ω(assembly.ErrorList.Errors[0].Failure.Message).Should(Equal("cleanup failed"))
+		})
+
+		It("should group the specs in collections named after their containers", func() {
+			assembly := readOutputFile()
+			Ω(assembly.Collections).Should(HaveLen(3))
+
+			Ω(assembly.Collections[0].Name).Should(Equal("Suite Context"))
+			Ω(assembly.Collections[0].Total).Should(Equal(3))
+			Ω(assembly.Collections[0].Passed).Should(Equal(1))
+			Ω(assembly.Collections[0].Failed).Should(Equal(1))
+			Ω(assembly.Collections[0].Skipped).Should(Equal(1))
+			Ω(assembly.Collections[0].Time).Should(Equal("2.000"))
+
+			Ω(assembly.Collections[1].Name).Should(Equal("Suite"))
+			Ω(assembly.Collections[1].Tests).Should(HaveLen(1))
+
+			Ω(assembly.Collections[2].Name).Should(Equal("[Top Level]"))
+			Ω(assembly.Collections[2].Tests[0].Name).Should(Equal("is top level"))
+		})
+
+		It("should describe each spec as a test, with its labels as traits", func() {
+			tests := readOutputFile().Collections[0].Tests
+
+			Ω(tests[0].Name).Should(Equal("Suite Context passes"))
+			Ω(tests[0].Type).Should(Equal("Suite Context"))
+			Ω(tests[0].Method).Should(Equal("passes"))
+			Ω(tests[0].Time).Should(Equal("1.500"))
+			Ω(tests[0].Result).Should(Equal("Pass"))
+			Ω(tests[0].Failure).Should(BeNil())
+			Ω(tests[0].Traits.Traits).Should(Equal([]reporters.XUnitV2Trait{
+				{Name: "label", Value: "fast"},
+				{Name: "label", Value: "storage"},
+			}))
+
+			Ω(tests[1].Result).Should(Equal("Fail"))
+			Ω(tests[1].Traits).Should(BeNil())
+			Ω(tests[1].Failure.ExceptionType).Should(Equal("failed"))
+			Ω(tests[1].Failure.Message).Should(Equal("boom"))
+			Ω(tests[1].Failure.StackTrace).Should(Equal("suite_test.go:3\nboom\nsuite_test.go:5"))
+			Ω(tests[1].Output).Should(Equal("some output"))
+
+			Ω(tests[2].Result).Should(Equal("Skip"))
+			Ω(tests[2].Reason).Should(Equal("pending"))
+
+			panicking := readOutputFile().Collections[1].Tests[0]
+			Ω(panicking.Failure.ExceptionType).Should(Equal("panicked"))
+			Ω(panicking.Failure.StackTrace).Should(ContainSubstring("Panic: oops"))
+		})
+	})
+
+	Context("when the suite has labels and metadata", func() {
+		It("should report the labels as traits of every test and the metadata as the environment", func() {
+			reporter.SpecSuiteWillBegin(config.GinkgoConfigType{}, &types.SuiteSummary{
+				SuiteDescription: "My test suite",
+				SuiteLabels:      []string{"nightly"},
+				SuiteMetadata:    map[string]string{"gitSHA": "abc123", "environment": "staging"},
+			})
+			specSummary := summary(types.SpecStatePassed, time.Second, "Suite", "passes")
+			specSummary.Labels = []string{"fast"}
+			reporter.SpecDidComplete(specSummary)
+			reporter.SpecSuiteDidEnd(&types.SuiteSummary{})
+
+			assembly := readOutputFile()
+			Ω(assembly.Environment).Should(Equal("environment=staging; gitSHA=abc123"))
+			Ω(assembly.Collections[0].Tests[0].Traits.Traits).Should(Equal([]reporters.XUnitV2Trait{
+				{Name: "suite-label", Value: "nightly"},
+				{Name: "label", Value: "fast"},
+			}))
+		})
+	})
+
+	Context("when a spec is retried", func() {
+		It("should only keep its last attempt", func() {
+			reporter.SpecDidComplete(summary(types.SpecStateFailed, time.Second, "Suite", "flakes"))
+			reporter.SpecDidComplete(summary(types.SpecStatePassed, 2*time.Second, "Suite", "flakes"))
+			reporter.SpecSuiteDidEnd(&types.SuiteSummary{})
+
+			assembly := readOutputFile()
+			Ω(assembly.Total).Should(Equal(1))
+			Ω(assembly.Passed).Should(Equal(1))
+			Ω(assembly.Collections[0].Tests[0].Time).Should(Equal("2.000"))
+		})
+	})
+})
@@ -0,0 +1,43 @@
+package ginkgo
+
+import (
+	"github.com/onsi/ginkgo/internal/global"
+)
+
+//RequiredResources is the type of the RequiresResource decorator
+type RequiredResources []string
+
+//RequiresResource decorates a container or a spec that contends on an external resource (a GPU, a shared test account,
+//a fixed port...).  Specs requiring a resource hold it while they run, including their setup and teardown nodes:
+//
+//	Describe("the renderer", func() {
+//		It("renders on the GPU", func() {
+//			...
+//		})
+//	}, RequiresResource("gpu-0"))
+//
+//When running in parallel, specs requiring the same resource never run at the same time, even on different parallel nodes,
+//while the other specs keep running.  Unlike a Serial spec, a spec requiring a resource only waits for the specs requiring
+//the same resource.  Resources are acquired in alphabetical order, so that specs requiring several resources do not deadlock.
+func RequiresResource(resources ...string) RequiredResources {
+	return RequiredResources(resources)
+}
+
+//WithLock runs body while holding resource, blocking until no spec running on another parallel node holds it.  Use it to
+//serialize only part of a spec, or from setup nodes:
+//
+//	BeforeEach(func() {
+//		WithLock("shared-account", func() {
+//			account.Reset()
+//		})
+//	})
+//
+//Locks are reentrant: calling WithLock from a spec that already holds resource (e.g. through RequiresResource) runs body right away.
+func WithLock(resource string, body func()) {
+	err := global.Suite.LockResources(resource)
+	if err != nil {
+		Fail(err.Error(), 1)
+	}
+	defer global.Suite.UnlockResources(resource)
+	body()
+}
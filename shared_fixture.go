@@ -0,0 +1,58 @@
+package ginkgo
+
+import (
+	"github.com/onsi/ginkgo/internal/codelocation"
+	"github.com/onsi/ginkgo/internal/fixture"
+	"github.com/onsi/ginkgo/internal/global"
+	"github.com/onsi/ginkgo/reporters"
+)
+
+//Fixture is a SharedFixture.  Pass it as a decorator to the containers and specs that use it, and call Get from within them.
+type Fixture struct {
+	fixture *fixture.Fixture
+}
+
+//SharedFixture registers an expensive fixture that specs share rather than setting it up for themselves:
+//
+//	var postgres = SharedFixture("postgres", func() interface{} {
+//		return startPostgres()
+//	}, func(value interface{}) {
+//		value.(*Postgres).Stop()
+//	})
+//
+//	var _ = Describe("the repository", func() {
+//		It("stores books", func() {
+//			db := postgres.Get().(*Postgres)
+//			...
+//		})
+//	}, postgres)
+//
+//The first spec to call Get sets the fixture up; the specs that follow get the same value.  Specs declare that they use the
+//fixture by passing it as a decorator to themselves or to one of their containers: the fixture is torn down as soon as the
+//last of the declaring specs that are to run has completed.  A spec calling Get without declaring the fixture still shares it,
+//but may have it set up again if the specs declaring it have all completed.
+//
+//When running in parallel, each parallel node process sets up its own instance of the fixture, and tears it down at the end
+//of the suite, after AfterSuite.  Fixtures still set up when the suite ends (e.g. because of -failFast) are torn down then too.
+//
+//If the setup fails, the spec that called Get fails, and so do the specs that call Get until the fixture is torn down.  If the
+//teardown, which may be nil, fails, the suite fails.  Each lifetime of a fixture is described by SuiteSummary.SharedFixtures.
+func SharedFixture(name string, setup func() interface{}, teardown func(value interface{})) *Fixture {
+	return &Fixture{
+		fixture: global.Suite.RegisterSharedFixture(name, setup, teardown, codelocation.New(1)),
+	}
+}
+
+//Get returns the value of the fixture, setting it up if needed
+func (f *Fixture) Get() interface{} {
+	specText := ""
+	if summary, ok := global.Suite.CurrentRunningSpecSummary(); ok {
+		specText = reporters.SpecFullText(summary)
+	}
+
+	value, err := f.fixture.Get(specText)
+	if err != nil {
+		Fail(err.Error(), 1)
+	}
+	return value
+}
@@ -0,0 +1,64 @@
+package ginkgo
+
+import (
+	"fmt"
+
+	"github.com/onsi/ginkgo/config"
+	"github.com/onsi/ginkgo/internal/codelocation"
+	"github.com/onsi/ginkgo/internal/global"
+	"github.com/onsi/ginkgo/internal/snapshot"
+	"github.com/onsi/ginkgo/types"
+)
+
+//MatchSnapshot fails the current spec unless data matches the snapshot called name, stored in
+//testdata/__snapshots__/<name>.snap next to the suite:
+//
+//	It("renders the invoice", func() {
+//		MatchSnapshot("invoice/with-discount", invoice.Render())
+//	})
+//
+//Strings and byte slices are compared as is, other values are compared encoded as indented JSON.  The failure shows a
+//diff between the snapshot and data, and is recorded in the reports along with both.
+//
+//Run the suite with -updateSnapshots (-ginkgo.updateSnapshots with go test) to write the snapshots from data instead,
+//whether they exist or not, then review and commit them.  MatchSnapshot fails when a snapshot does not exist yet.
+func MatchSnapshot(name string, data interface{}) {
+	path, err := snapshot.Path(name)
+	if err != nil {
+		Fail(err.Error(), 1)
+	}
+	actual, err := snapshot.Serialize(data)
+	if err != nil {
+		Fail(err.Error(), 1)
+	}
+
+	if config.GinkgoConfig.UpdateSnapshots {
+		err := snapshot.Write(path, actual)
+		if err != nil {
+			Fail(fmt.Sprintf("Failed to update snapshot %q: %s", name, err), 1)
+		}
+		return
+	}
+
+	expected, exists, err := snapshot.Read(path)
+	if err != nil {
+		Fail(fmt.Sprintf("Failed to read snapshot %q: %s", name, err), 1)
+	}
+	if !exists {
+		Fail(fmt.Sprintf("Snapshot %q does not exist at %s.  Run with -updateSnapshots to create it.", name, path), 1)
+	}
+	if expected == actual {
+		return
+	}
+
+	mismatch := types.SnapshotMismatch{
+		Name:     name,
+		Path:     path,
+		Expected: expected,
+		Actual:   actual,
+		Diff:     snapshot.Diff(expected, actual),
+	}
+	message := fmt.Sprintf("Snapshot %q at %s does not match (- snapshot, + actual):\n%s\n\nRun with -updateSnapshots to update it.", name, path, mismatch.Diff)
+	global.Failer.FailSnapshotMismatch(message, codelocation.New(1), mismatch)
+	panic(GINKGO_PANIC)
+}
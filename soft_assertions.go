@@ -0,0 +1,104 @@
+package ginkgo
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/onsi/ginkgo/internal/codelocation"
+	"github.com/onsi/ginkgo/internal/global"
+	"github.com/onsi/ginkgo/types"
+)
+
+//SoftAssertions collect the failures raised within a Softly block, see Softly
+type SoftAssertions struct {
+	lock     *sync.Mutex
+	failures []types.SpecFailure
+}
+
+//Fail records a failure without stopping the Softly block.  Like Fail, it accepts an optional callerSkip.
+func (s *SoftAssertions) Fail(message string, callerSkip ...int) {
+	skip := 0
+	if len(callerSkip) > 0 {
+		skip = callerSkip[0]
+	}
+	s.record(message, codelocation.New(skip+1))
+}
+
+//Fatalf records a failure without stopping the Softly block.  It makes SoftAssertions a GomegaTestingT, so that Gomega
+//assertions made with NewWithT(s) are soft:
+//
+//	Softly(func(s *SoftAssertions) {
+//		g := NewWithT(s)
+//		g.Expect(user.Name).To(Equal("Jane"))
+//		g.Expect(user.Age).To(Equal(42))
+//	})
+func (s *SoftAssertions) Fatalf(format string, args ...interface{}) {
+	s.record(fmt.Sprintf(format, args...), callerOutsideGomega())
+}
+
+func (s *SoftAssertions) record(message string, location types.CodeLocation) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.failures = append(s.failures, types.SpecFailure{Message: message, Location: location})
+}
+
+//callerOutsideGomega returns the location of the first caller of its caller that is not part of Gomega, i.e. where the
+//failed assertion was made
+func callerOutsideGomega() types.CodeLocation {
+	for skip := 2; ; skip++ {
+		pc, _, _, ok := runtime.Caller(skip)
+		if !ok {
+			return codelocation.New(2)
+		}
+		if f := runtime.FuncForPC(pc); f == nil || !strings.HasPrefix(f.Name(), "github.com/onsi/gomega") {
+			return codelocation.New(skip)
+		}
+	}
+}
+
+//Softly runs body with soft assertions: the failures recorded through s do not stop body, so that a spec checking many
+//independent properties reports all those that do not hold rather than only the first one:
+//
+//	It("fills in the profile", func() {
+//		Softly(func(s *SoftAssertions) {
+//			if profile.Name == "" {
+//				s.Fail("the name is missing")
+//			}
+//			g := NewWithT(s)
+//			g.Expect(profile.Age).To(BeNumerically(">", 0))
+//		})
+//	})
+//
+//Once body returns, each failure is recorded as an additional failure of the running spec, and the spec fails once,
+//at the Softly call, listing where they occurred.  Failures raised through Fail or the global Gomega assertions
+//within body still stop it.
+func Softly(body func(s *SoftAssertions)) {
+	s := &SoftAssertions{lock: &sync.Mutex{}}
+
+	completed := false
+	defer func() {
+		if !completed {
+			//body failed for good: keep the soft failures recorded so far along with its failure
+			for _, failure := range s.failures {
+				global.Suite.RecordAdditionalFailure(failure)
+			}
+		}
+	}()
+	body(s)
+	completed = true
+
+	if len(s.failures) == 0 {
+		return
+	}
+	lines := []string{fmt.Sprintf("%d soft assertion(s) failed:", len(s.failures))}
+	for _, failure := range s.failures {
+		if global.Suite.RecordAdditionalFailure(failure) {
+			lines = append(lines, fmt.Sprintf("  %s", failure.Location))
+		} else {
+			lines = append(lines, fmt.Sprintf("  %s\n%s", failure.Location, failure.Message))
+		}
+	}
+	Fail(strings.Join(lines, "\n"), 1)
+}
@@ -0,0 +1,129 @@
+package ginkgo
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strings"
+
+	"github.com/onsi/ginkgo/internal/codelocation"
+	"github.com/onsi/ginkgo/internal/global"
+	"github.com/onsi/ginkgo/types"
+)
+
+//SpecReport identifies the running spec and tells how it has fared so far.  Libraries keeping per-spec state, such as
+//HTTP recorders keeping a cassette per spec, key it by FullText or FileName.
+type SpecReport struct {
+	ContainerHierarchyTexts []string
+	LeafNodeText            string
+	LeafNodeLocation        types.CodeLocation
+	Labels                  []string
+
+	State   types.SpecState
+	Failure types.SpecFailure
+}
+
+//maxFileNameLength bounds the length of the names returned by FileName, leaving room for a directory and an extension
+//within the file name limits of common file systems
+const maxFileNameLength = 128
+
+var unsafeFileNameCharacters = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+//FullText returns the texts of the containers and the subject of the spec, joined by spaces.  This is how reporters
+//identify a spec across runs.
+func (report SpecReport) FullText() string {
+	return strings.Join(append(append([]string{}, report.ContainerHierarchyTexts...), report.LeafNodeText), " ")
+}
+
+//FileName returns a name derived from FullText that is safe to use as a file name on all platforms and stable across
+//runs, e.g. "Books_can_be_borrowed-3f2a9c1e".  The suffix, taken from a hash of FullText, keeps apart specs whose texts
+//only differ by the characters the name leaves out.
+func (report SpecReport) FileName() string {
+	fullText := report.FullText()
+	hash := sha256.Sum256([]byte(fullText))
+	suffix := "-" + hex.EncodeToString(hash[:4])
+
+	name := strings.Trim(unsafeFileNameCharacters.ReplaceAllString(fullText, "_"), "._-")
+	if len(name) > maxFileNameLength-len(suffix) {
+		name = strings.TrimRight(name[:maxFileNameLength-len(suffix)], "._-")
+	}
+	if name == "" {
+		name = "spec"
+	}
+	return name + suffix
+}
+
+//Failed tells whether the spec has failed, panicked or timed out so far
+func (report SpecReport) Failed() bool {
+	return report.State.IsFailure()
+}
+
+//CurrentSpecReport returns the report of the running spec, or a zero SpecReport outside of a spec
+func CurrentSpecReport() SpecReport {
+	summary, ok := global.Suite.CurrentRunningSpecSummary()
+	if !ok {
+		return SpecReport{}
+	}
+	return newSpecReport(summary)
+}
+
+func newSpecReport(summary *types.SpecSummary) SpecReport {
+	texts := summary.ComponentTexts
+	if len(texts) > 1 {
+		texts = texts[1:]
+	}
+	return SpecReport{
+		ContainerHierarchyTexts: texts[:len(texts)-1],
+		LeafNodeText:            texts[len(texts)-1],
+		LeafNodeLocation:        summary.ComponentCodeLocations[len(summary.ComponentCodeLocations)-1],
+		Labels:                  summary.Labels,
+		State:                   summary.State,
+		Failure:                 summary.Failure,
+	}
+}
+
+//OnFailure registers a hook that runs only for the specs that fail, to gather diagnostics and attach them to their
+//reports:
+//
+//	var _ = OnFailure(func(report SpecReport) {
+//		output, _ := exec.Command("kubectl", "describe", "pods").CombinedOutput()
+//		AddReportEntry("pods", string(output))
+//	})
+//
+//Unlike an AfterEach block, the hook is handed the failure of the spec and runs once the spec has unwound, after its
+//AfterEach blocks, cleanups and AroundEach blocks, so that it sees the final state of the spec.  It runs with -failFast,
+//and for the running spec when the suite is interrupted, e.g. by SIGINT or as the go test deadline approaches.  Hooks run
+//in registration order; their failures are recorded as additional failures of the spec.  OnFailure hooks may only be
+//registered at the top level.
+func OnFailure(body func(report SpecReport)) bool {
+	global.Suite.PushOnFailureHook(func(summary *types.SpecSummary) {
+		body(newSpecReport(summary))
+	}, codelocation.New(1))
+	return true
+}
+
+//FixtureManager is implemented by libraries that manage fixtures of their own for each spec, such as HTTP recorders
+//loading and saving a cassette per spec
+type FixtureManager interface {
+	//SetUpSpec is called before the BeforeEach blocks of every spec
+	SetUpSpec(report SpecReport)
+	//TearDownSpec is called after the AfterEach blocks of every spec, with the final state of the spec, even if it failed
+	TearDownSpec(report SpecReport)
+}
+
+//RegisterFixtureManager hooks manager into every spec of the suite.  Like AroundEach, which it builds on, it may only be
+//called at the top level:
+//
+//	var _ = RegisterFixtureManager(recorder.NewManager("testdata/cassettes"))
+//
+//A manager that fails in SetUpSpec, e.g. through Fail, fails the spec without running it.
+func RegisterFixtureManager(manager FixtureManager) bool {
+	global.Suite.PushAroundEachNode(func(run func()) {
+		manager.SetUpSpec(CurrentSpecReport())
+		defer func() {
+			manager.TearDownSpec(CurrentSpecReport())
+		}()
+		run()
+	}, codelocation.New(1))
+	return true
+}
@@ -0,0 +1,41 @@
+package ginkgo
+
+import (
+	"os/exec"
+	"time"
+
+	"github.com/onsi/ginkgo/internal/global"
+)
+
+//StartSuiteProcess starts an external process (a database, a fake API...) once for the whole suite run, and returns its
+//address once it is ready.  Call it from BeforeSuite, or from any node: further calls with the same name return the same address.
+//
+//	var apiAddress string
+//
+//	var _ = BeforeSuite(func() {
+//		apiAddress = StartSuiteProcess("api", func() (*exec.Cmd, string) {
+//			port := GinkgoParallelPort()
+//			return exec.Command("./fake-api", "--port", strconv.Itoa(port)), fmt.Sprintf("127.0.0.1:%d", port)
+//		}, func(address string) error {
+//			conn, err := net.Dial("tcp", address)
+//			if err == nil {
+//				conn.Close()
+//			}
+//			return err
+//		})
+//	})
+//
+//command returns the command to run and the address the process will be reachable at.  readinessCheck, which may be nil,
+//is called with the address until it returns nil: the running node fails if the process exits before that, or if it is
+//not ready within the timeout (a time.Duration, or float64 or int seconds; one minute by default).
+//
+//When running in parallel, only node 1 starts the process, and the other nodes wait for its address.  The process is killed
+//at the end of the suite, after AfterSuite and once every node is done, or when the suite is interrupted.  What it wrote
+//to its stdout and stderr is made available to reporters through SuiteSummary.SuiteProcesses.
+func StartSuiteProcess(name string, command func() (*exec.Cmd, string), readinessCheck func(address string) error, timeout ...interface{}) string {
+	address, err := global.Suite.StartSuiteProcess(name, command, readinessCheck, parseWaitTimeout("StartSuiteProcess", time.Minute, timeout...))
+	if err != nil {
+		Fail(err.Error(), 1)
+	}
+	return address
+}
@@ -0,0 +1,44 @@
+package types
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var fingerprintNormalizers = []struct {
+	pattern     *regexp.Regexp
+	replacement string
+}{
+	{regexp.MustCompile(`0x[0-9a-fA-F]+`), "<pointer>"},
+	{regexp.MustCompile(`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`), "<uuid>"},
+	{regexp.MustCompile(`\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:?\d{2})?`), "<time>"},
+	{regexp.MustCompile(`\d+(\.\d+)?`), "N"},
+	{regexp.MustCompile(`\s+`), " "},
+}
+
+//NormalizeFailureMessage strips the parts of a failure message that typically vary from one run to the next
+//(pointers, UUIDs, timestamps, numbers and whitespace) so that the same failure produces the same text every time.
+func NormalizeFailureMessage(message string) string {
+	for _, normalizer := range fingerprintNormalizers {
+		message = normalizer.pattern.ReplaceAllString(message, normalizer.replacement)
+	}
+	return strings.TrimSpace(message)
+}
+
+//FailureFingerprint computes a stable identifier for a failure out of its normalized message and its location.
+//Only the last directory and the file name of the location are used, so the fingerprint does not depend on where the
+//suite was checked out.
+func FailureFingerprint(failure SpecFailure) string {
+	fileName := filepath.ToSlash(failure.Location.FileName)
+	if parts := strings.Split(fileName, "/"); len(parts) > 2 {
+		fileName = strings.Join(parts[len(parts)-2:], "/")
+	}
+
+	hash := sha1.New()
+	fmt.Fprintf(hash, "%s\x00%s\x00%s:%d", NormalizeFailureMessage(failure.Message), NormalizeFailureMessage(failure.ForwardedPanic), fileName, failure.Location.LineNumber)
+	return hex.EncodeToString(hash.Sum(nil))[:16]
+}
@@ -0,0 +1,64 @@
+package types_test
+
+import (
+	. "github.com/onsi/ginkgo/types"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Failure fingerprints", func() {
+	Describe("NormalizeFailureMessage", func() {
+		It("replaces the parts of a message that vary between runs", func() {
+			Ω(NormalizeFailureMessage("Expected\n    <*Thing | 0xc000123abc>: {Count: 17, Ratio: 0.25}\nto be nil")).Should(Equal("Expected <*Thing | <pointer>>: {Count: N, Ratio: N} to be nil"))
+			Ω(NormalizeFailureMessage("no such job 6ba7b810-9dad-11d1-80b4-00c04fd430c8")).Should(Equal("no such job <uuid>"))
+			Ω(NormalizeFailureMessage("  expired at 2021-03-04T05:06:07.123Z  ")).Should(Equal("expired at <time>"))
+		})
+	})
+
+	Describe("FailureFingerprint", func() {
+		var failure SpecFailure
+
+		BeforeEach(func() {
+			failure = SpecFailure{
+				Message:  "Expected <int>: 3 to equal <int>: 4",
+				Location: CodeLocation{FileName: "/home/alice/src/project/storage/store_test.go", LineNumber: 42},
+			}
+		})
+
+		It("is a short hex string", func() {
+			Ω(FailureFingerprint(failure)).Should(MatchRegexp(`^[0-9a-f]{16}$`))
+		})
+
+		It("is the same for failures that only differ by their volatile details or checkout location", func() {
+			other := failure
+			other.Message = "Expected <int>: 7 to equal <int>: 8"
+			other.Location.FileName = "/builds/ci/project/storage/store_test.go"
+			other.Location.FullStackTrace = "some stack trace"
+			other.ComponentIndex = 3
+			Ω(FailureFingerprint(other)).Should(Equal(FailureFingerprint(failure)))
+		})
+
+		It("differs when the message differs", func() {
+			other := failure
+			other.Message = "Expected <string>: a to equal <string>: b"
+			Ω(FailureFingerprint(other)).ShouldNot(Equal(FailureFingerprint(failure)))
+		})
+
+		It("differs when the location differs", func() {
+			other := failure
+			other.Location.LineNumber = 43
+			Ω(FailureFingerprint(other)).ShouldNot(Equal(FailureFingerprint(failure)))
+
+			other = failure
+			other.Location.FileName = "/home/alice/src/project/queue/store_test.go"
+			Ω(FailureFingerprint(other)).ShouldNot(Equal(FailureFingerprint(failure)))
+		})
+
+		It("takes forwarded panics into account", func() {
+			other := failure
+			other.ForwardedPanic = "runtime error: index out of range"
+			Ω(FailureFingerprint(other)).ShouldNot(Equal(FailureFingerprint(failure)))
+		})
+	})
+})
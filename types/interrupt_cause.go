@@ -0,0 +1,83 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+//InterruptCause tells why a suite was interrupted before all its specs ran
+type InterruptCause uint
+
+const (
+	InterruptCauseInvalid InterruptCause = iota
+
+	//InterruptCauseSignal: the process received SIGINT (e.g. Ctrl-C) or SIGTERM
+	InterruptCauseSignal
+	//InterruptCauseTimeout: a timeout enforced outside of the suite, e.g. by the program running it, expired
+	InterruptCauseTimeout
+	//InterruptCauseAbortByOtherProcess: when running in parallel with -failFast, another parallel process had a failing spec
+	InterruptCauseAbortByOtherProcess
+	//InterruptCauseSuiteTimeout: the suite was about to reach the go test deadline (see -test.timeout)
+	InterruptCauseSuiteTimeout
+	//InterruptCauseEmptySuite: no spec was left to run once the filters were applied, and the suite runs with -failOnEmpty
+	InterruptCauseEmptySuite
+	//InterruptCauseSlowContainers: container bodies took too long to build the spec tree, and the suite runs with -failOnSlowContainers
+	InterruptCauseSlowContainers
+	//InterruptCausePolicyViolation: a spec policy registered with RegisterSpecPolicy vetoed the run
+	InterruptCausePolicyViolation
+	//InterruptCauseReportAfterSuiteFailure: a ReportAfterSuite node failed, or could not run as the report of the suite could not be aggregated
+	InterruptCauseReportAfterSuiteFailure
+	//InterruptCauseCrash: a panic escaped the specs and crashed the test binary, once the reports were written
+	InterruptCauseCrash
+	//InterruptCauseAbort: a spec aborted the suite, see failer.Abort
+	InterruptCauseAbort
+)
+
+var interruptCauseNames = map[InterruptCause]string{
+	InterruptCauseSignal:                  "signal",
+	InterruptCauseTimeout:                 "timeout",
+	InterruptCauseAbortByOtherProcess:     "abort-by-other-process",
+	InterruptCauseSuiteTimeout:            "suite-timeout",
+	InterruptCauseEmptySuite:              "empty-suite",
+	InterruptCauseSlowContainers:          "slow-containers",
+	InterruptCausePolicyViolation:         "policy-violation",
+	InterruptCauseReportAfterSuiteFailure: "report-after-suite-failure",
+	InterruptCauseCrash:                   "crash",
+	InterruptCauseAbort:                   "abort",
+}
+
+func (cause InterruptCause) String() string {
+	if name, ok := interruptCauseNames[cause]; ok {
+		return name
+	}
+	return "invalid"
+}
+
+//MarshalJSON encodes the cause by name, so that reports remain readable as causes are added
+func (cause InterruptCause) MarshalJSON() ([]byte, error) {
+	return json.Marshal(cause.String())
+}
+
+func (cause *InterruptCause) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return err
+	}
+	for candidate, candidateName := range interruptCauseNames {
+		if candidateName == name {
+			*cause = candidate
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown interrupt cause %q", name)
+}
+
+//SpecialSuiteFailureReason describes why a suite failed other than because of a failing spec, such as an interrupt
+type SpecialSuiteFailureReason struct {
+	Cause   InterruptCause
+	Message string
+}
+
+func (reason SpecialSuiteFailureReason) String() string {
+	return fmt.Sprintf("%s: %s", reason.Cause, reason.Message)
+}
@@ -0,0 +1,34 @@
+package types_test
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/types"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("InterruptCause", func() {
+	It("has a readable name", func() {
+		Ω(InterruptCauseSignal.String()).Should(Equal("signal"))
+		Ω(InterruptCauseAbortByOtherProcess.String()).Should(Equal("abort-by-other-process"))
+		Ω(InterruptCauseInvalid.String()).Should(Equal("invalid"))
+	})
+
+	It("round-trips through JSON by name", func() {
+		reason := SpecialSuiteFailureReason{Cause: InterruptCauseSuiteTimeout, Message: "deadline"}
+		data, err := json.Marshal(reason)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(string(data)).Should(Equal(`{"Cause":"suite-timeout","Message":"deadline"}`))
+
+		var decoded SpecialSuiteFailureReason
+		Ω(json.Unmarshal(data, &decoded)).Should(Succeed())
+		Ω(decoded).Should(Equal(reason))
+	})
+
+	It("rejects unknown causes", func() {
+		var cause InterruptCause
+		Ω(json.Unmarshal([]byte(`"gremlins"`), &cause)).ShouldNot(Succeed())
+	})
+})
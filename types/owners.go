@@ -0,0 +1,42 @@
+package types
+
+import "sort"
+
+//NoOwner is the owner the failing specs that have no owner are grouped under
+const NoOwner = "(no owner)"
+
+//FailuresByOwner groups the failing specs by owner, a spec with several owners appearing under each of them.  It returns
+//the owners in alphabetical order, followed by NoOwner if some failing specs have no owner, or nothing when none of the
+//failing specs has an owner, as grouping them would not help.
+func FailuresByOwner(summaries []*SpecSummary) ([]string, map[string][]*SpecSummary) {
+	groups := map[string][]*SpecSummary{}
+	owned := false
+	for _, summary := range summaries {
+		if !summary.HasFailureState() {
+			continue
+		}
+		if len(summary.Owners) == 0 {
+			groups[NoOwner] = append(groups[NoOwner], summary)
+			continue
+		}
+		owned = true
+		for _, owner := range summary.Owners {
+			groups[owner] = append(groups[owner], summary)
+		}
+	}
+	if !owned {
+		return nil, nil
+	}
+
+	owners := []string{}
+	for owner := range groups {
+		if owner != NoOwner {
+			owners = append(owners, owner)
+		}
+	}
+	sort.Strings(owners)
+	if _, ok := groups[NoOwner]; ok {
+		owners = append(owners, NoOwner)
+	}
+	return owners, groups
+}
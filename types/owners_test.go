@@ -0,0 +1,33 @@
+package types_test
+
+import (
+	. "github.com/onsi/ginkgo/types"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FailuresByOwner", func() {
+	It("groups the failing specs by owner, leaving the specs without owners last", func() {
+		billing := &SpecSummary{State: SpecStateFailed, Owners: []string{"team-billing"}}
+		shared := &SpecSummary{State: SpecStatePanicked, Owners: []string{"team-search", "team-billing"}}
+		orphan := &SpecSummary{State: SpecStateTimedOut}
+		passed := &SpecSummary{State: SpecStatePassed, Owners: []string{"team-auth"}}
+
+		owners, groups := FailuresByOwner([]*SpecSummary{billing, shared, orphan, passed})
+		Ω(owners).Should(Equal([]string{"team-billing", "team-search", NoOwner}))
+		Ω(groups["team-billing"]).Should(Equal([]*SpecSummary{billing, shared}))
+		Ω(groups["team-search"]).Should(Equal([]*SpecSummary{shared}))
+		Ω(groups[NoOwner]).Should(Equal([]*SpecSummary{orphan}))
+		Ω(groups).ShouldNot(HaveKey("team-auth"))
+	})
+
+	It("groups nothing when no failing spec has an owner", func() {
+		owners, groups := FailuresByOwner([]*SpecSummary{
+			{State: SpecStateFailed},
+			{State: SpecStatePassed, Owners: []string{"team-auth"}},
+		})
+		Ω(owners).Should(BeEmpty())
+		Ω(groups).Should(BeEmpty())
+	})
+})
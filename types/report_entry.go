@@ -0,0 +1,59 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/onsi/ginkgo/internal/clock"
+)
+
+//ReportEntry is a value attached to the report of a spec with ginkgo.AddReportEntry
+type ReportEntry struct {
+	Name     string
+	Location CodeLocation
+	Time     time.Time
+
+	//Representation is how the value is printed on the console
+	Representation string
+	//Value is how the value is written to JSON reports
+	Value json.RawMessage
+}
+
+//ReportEntryRenderer is implemented by the values passed to ginkgo.AddReportEntry that render themselves differently on
+//the console and in JSON reports, such as rich diagnostic objects that read best as a colored table on the console but
+//as structured data in JSON
+type ReportEntryRenderer interface {
+	//ConsoleString returns how the value is printed on the console, using ANSI color codes only when color is true
+	ConsoleString(color bool) string
+	//JSONValue returns the value written to JSON reports in place of the value itself
+	JSONValue() interface{}
+}
+
+//NewReportEntry renders value once and for all, as the spec may change it after attaching it to its report.  Values
+//that do not implement ReportEntryRenderer are printed with their String method if they have one, with %+v otherwise,
+//and written to JSON reports as they marshal, or as their representation if they do not.
+func NewReportEntry(name string, location CodeLocation, value interface{}, color bool) ReportEntry {
+	entry := ReportEntry{Name: name, Location: location, Time: clock.Now()}
+	if value == nil {
+		return entry
+	}
+
+	jsonValue := value
+	switch value := value.(type) {
+	case ReportEntryRenderer:
+		entry.Representation = value.ConsoleString(color)
+		jsonValue = value.JSONValue()
+	case fmt.Stringer:
+		entry.Representation = value.String()
+	default:
+		entry.Representation = fmt.Sprintf("%+v", value)
+	}
+
+	data, err := json.Marshal(jsonValue)
+	if err != nil {
+		data, _ = json.Marshal(entry.Representation)
+	}
+	entry.Value = data
+	return entry
+}
@@ -0,0 +1,76 @@
+package types_test
+
+import (
+	"encoding/json"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/types"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type latency struct {
+	P50, P99 int
+}
+
+func (l latency) ConsoleString(color bool) string {
+	if color {
+		return fmt.Sprintf("\x1b[1mp50\x1b[0m %dms \x1b[1mp99\x1b[0m %dms", l.P50, l.P99)
+	}
+	return fmt.Sprintf("p50 %dms p99 %dms", l.P50, l.P99)
+}
+
+func (l latency) JSONValue() interface{} {
+	return map[string]int{"p50_ms": l.P50, "p99_ms": l.P99}
+}
+
+type version struct {
+	Major, Minor int
+}
+
+func (v version) String() string {
+	return fmt.Sprintf("v%d.%d", v.Major, v.Minor)
+}
+
+var _ = Describe("ReportEntry", func() {
+	location := CodeLocation{FileName: "report_test.go", LineNumber: 7}
+
+	It("lets values implementing ReportEntryRenderer render themselves on the console and in JSON", func() {
+		entry := NewReportEntry("latency", location, latency{P50: 12, P99: 80}, false)
+		Ω(entry.Name).Should(Equal("latency"))
+		Ω(entry.Location).Should(Equal(location))
+		Ω(entry.Time).ShouldNot(BeZero())
+		Ω(entry.Representation).Should(Equal("p50 12ms p99 80ms"))
+		Ω(entry.Value).Should(MatchJSON(`{"p50_ms": 12, "p99_ms": 80}`))
+
+		Ω(NewReportEntry("latency", location, latency{P50: 12, P99: 80}, true).Representation).Should(ContainSubstring("\x1b[1m"))
+	})
+
+	It("prints other values with String or %+v, and writes them to JSON as they marshal", func() {
+		entry := NewReportEntry("version", location, version{Major: 1, Minor: 16}, false)
+		Ω(entry.Representation).Should(Equal("v1.16"))
+		Ω(entry.Value).Should(MatchJSON(`{"Major": 1, "Minor": 16}`))
+
+		entry = NewReportEntry("retries", location, 3, false)
+		Ω(entry.Representation).Should(Equal("3"))
+		Ω(entry.Value).Should(MatchJSON(`3`))
+	})
+
+	It("writes values that do not marshal to JSON as their representation", func() {
+		entry := NewReportEntry("callback", location, struct{ F func() }{}, false)
+		Ω(entry.Representation).Should(Equal("{F:<nil>}"))
+		Ω(entry.Value).Should(MatchJSON(`"{F:<nil>}"`))
+	})
+
+	It("round-trips through JSON", func() {
+		entry := NewReportEntry("latency", location, latency{P50: 12, P99: 80}, false)
+		data, err := json.Marshal(entry)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		var decoded ReportEntry
+		Ω(json.Unmarshal(data, &decoded)).Should(Succeed())
+		Ω(decoded.Representation).Should(Equal(entry.Representation))
+		Ω(decoded.Value).Should(MatchJSON(entry.Value))
+	})
+})
@@ -0,0 +1,87 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+//Severity tells how much the failure of a spec matters, see the Critical, High, Medium and Low decorators
+type Severity uint
+
+const (
+	//SeverityNone: the spec is not decorated with a severity
+	SeverityNone Severity = iota
+
+	SeverityLow
+	SeverityMedium
+	SeverityHigh
+	SeverityCritical
+)
+
+var severityNames = map[Severity]string{
+	SeverityNone:     "none",
+	SeverityLow:      "low",
+	SeverityMedium:   "medium",
+	SeverityHigh:     "high",
+	SeverityCritical: "critical",
+}
+
+func (severity Severity) String() string {
+	if name, ok := severityNames[severity]; ok {
+		return name
+	}
+	return "invalid"
+}
+
+//ParseSeverity returns the severity called name, e.g. "high"
+func ParseSeverity(name string) (Severity, error) {
+	for candidate, candidateName := range severityNames {
+		if candidateName == name {
+			return candidate, nil
+		}
+	}
+	return SeverityNone, fmt.Errorf("unknown severity %q, expected critical, high, medium, low or none", name)
+}
+
+//FailsSuite tells whether the failure of a spec of this severity fails a suite that only fails on failures of at least
+//threshold (see -failOnSeverity).  Failures of specs without a severity always fail the suite, as do all failures when
+//there is no threshold.
+func (severity Severity) FailsSuite(threshold Severity) bool {
+	return threshold == SeverityNone || severity == SeverityNone || severity >= threshold
+}
+
+//MarshalJSON encodes the severity by name
+func (severity Severity) MarshalJSON() ([]byte, error) {
+	return json.Marshal(severity.String())
+}
+
+func (severity *Severity) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return err
+	}
+	parsed, err := ParseSeverity(name)
+	if err != nil {
+		return err
+	}
+	*severity = parsed
+	return nil
+}
+
+//FailuresBySeverity counts the failing specs of each severity.  It returns nothing when none of the failing specs has a
+//severity, as the breakdown would not help.
+func FailuresBySeverity(summaries []*SpecSummary) map[Severity]int {
+	counts := map[Severity]int{}
+	rated := false
+	for _, summary := range summaries {
+		if !summary.HasFailureState() {
+			continue
+		}
+		counts[summary.Severity]++
+		rated = rated || summary.Severity != SeverityNone
+	}
+	if !rated {
+		return nil
+	}
+	return counts
+}
@@ -0,0 +1,53 @@
+package types_test
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/types"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Severity", func() {
+	It("parses names", func() {
+		Ω(ParseSeverity("high")).Should(Equal(SeverityHigh))
+		Ω(ParseSeverity("none")).Should(Equal(SeverityNone))
+		_, err := ParseSeverity("urgent")
+		Ω(err).Should(MatchError(ContainSubstring(`unknown severity "urgent"`)))
+	})
+
+	It("round-trips through JSON by name", func() {
+		data, err := json.Marshal(SpecSummary{Severity: SeverityCritical})
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(string(data)).Should(ContainSubstring(`"Severity":"critical"`))
+
+		var decoded SpecSummary
+		Ω(json.Unmarshal(data, &decoded)).Should(Succeed())
+		Ω(decoded.Severity).Should(Equal(SeverityCritical))
+	})
+
+	It("fails the suite on failures of at least the threshold, or without a severity", func() {
+		Ω(SeverityLow.FailsSuite(SeverityNone)).Should(BeTrue())
+		Ω(SeverityLow.FailsSuite(SeverityHigh)).Should(BeFalse())
+		Ω(SeverityMedium.FailsSuite(SeverityHigh)).Should(BeFalse())
+		Ω(SeverityHigh.FailsSuite(SeverityHigh)).Should(BeTrue())
+		Ω(SeverityCritical.FailsSuite(SeverityHigh)).Should(BeTrue())
+		Ω(SeverityNone.FailsSuite(SeverityCritical)).Should(BeTrue())
+	})
+
+	Describe("FailuresBySeverity", func() {
+		It("counts the failing specs of each severity", func() {
+			Ω(FailuresBySeverity([]*SpecSummary{
+				{State: SpecStateFailed, Severity: SeverityLow},
+				{State: SpecStatePanicked, Severity: SeverityLow},
+				{State: SpecStateFailed},
+				{State: SpecStatePassed, Severity: SeverityCritical},
+			})).Should(Equal(map[Severity]int{SeverityLow: 2, SeverityNone: 1}))
+		})
+
+		It("counts nothing when no failing spec has a severity", func() {
+			Ω(FailuresBySeverity([]*SpecSummary{{State: SpecStateFailed}})).Should(BeEmpty())
+		})
+	})
+})
@@ -28,3 +28,95 @@ func (r RemoteBeforeSuiteData) ToJSON() []byte {
 type RemoteAfterSuiteData struct {
 	CanRun bool
 }
+
+//RemoteResourceLock is posted to /ResourceLock by a parallel node to acquire, or release, a resource shared across nodes
+type RemoteResourceLock struct {
+	Resource string
+	Node     int
+	Release  bool
+}
+
+func (r RemoteResourceLock) ToJSON() []byte {
+	data, _ := json.Marshal(r)
+	return data
+}
+
+type RemoteResourceLockState struct {
+	Acquired bool
+}
+
+//RemoteAllocation is returned by the /Port and /UniqueName endpoints, which hand out ports and names that do not conflict across parallel nodes
+type RemoteAllocation struct {
+	Port int
+	Name string
+}
+
+//RemoteKeyValue is posted to /KeyValue to set a key shared across parallel nodes, and returned when getting a key
+type RemoteKeyValue struct {
+	Key   string
+	Value []byte
+	Found bool
+}
+
+func (r RemoteKeyValue) ToJSON() []byte {
+	data, _ := json.Marshal(r)
+	return data
+}
+
+//RemoteAbort is posted to /Abort by a parallel node running with -failFast when one of its specs fails, so that the other
+//nodes stop running specs too.  Getting /Abort returns the node that aborted the suite, if any.
+//
+//A node also posts it, with the Message of the failure, when one of its specs aborts the suite with failer.Abort.  The
+//other nodes then stop running specs whether or not they run with -failFast.
+type RemoteAbort struct {
+	Node    int
+	Message string `json:",omitempty"`
+}
+
+func (r RemoteAbort) ToJSON() []byte {
+	data, _ := json.Marshal(r)
+	return data
+}
+
+//AggregatedReport is the report of a suite run so far, aggregated across its parallel nodes.  The server run by the
+//Ginkgo CLI returns it from /AggregatedReport.
+type AggregatedReport struct {
+	SuiteDescription string
+	//SpecSummaries lists the specs that completed, including the skipped and pending ones, in the order they completed
+	SpecSummaries []*SpecSummary
+
+	Passed  int
+	Failed  int
+	Pending int
+	Skipped int
+}
+
+//RemoteSpecLease is posted to /SpecLease by a parallel node to heartbeat the spec it claimed from /counter at Index, so
+//that its lease does not expire while it runs it
+type RemoteSpecLease struct {
+	Node  int
+	Index int
+	Text  string
+}
+
+func (r RemoteSpecLease) ToJSON() []byte {
+	data, _ := json.Marshal(r)
+	return data
+}
+
+type RemoteSpecLeaseState struct {
+	Held bool
+}
+
+//RemoteSpecOutcome is posted to /SpecOutcome by a parallel node when a spec other specs depend on completes or is skipped.
+//Getting /SpecOutcome?key=<key> returns the outcome of the spec identified by key, or 404 while it is not known.
+type RemoteSpecOutcome struct {
+	Key      string
+	FullText string
+	State    SpecState
+}
+
+func (r RemoteSpecOutcome) ToJSON() []byte {
+	data, _ := json.Marshal(r)
+	return data
+}
@@ -36,23 +36,163 @@ type SuiteSummary struct {
 	// subsequent try.
 	NumberOfFlakedSpecs int
 	RunTime             time.Duration
+	//WarmUpRunTime is how long the WarmUp node took, which RunTime leaves out.  For parallel runs, it is the longest
+	//warm-up of the parallel nodes.
+	WarmUpRunTime time.Duration
+
+	//SuiteLabels and SuiteMetadata describe the suite run as a whole (e.g. the git SHA or the environment
+	//the suite ran against) for the benefit of downstream systems consuming the reports
+	SuiteLabels   []string
+	SuiteMetadata map[string]string
+
+	//Warnings lists problems with the configuration of the suite run that did not prevent it from running, such as
+	//expired entries in the skip list passed to -skipFile
+	Warnings []string
+
+	//SpecialSuiteFailureReasons describes why the suite failed other than because of a failing spec, e.g. whether it was
+	//interrupted by a signal or by the go test deadline
+	SpecialSuiteFailureReasons []SpecialSuiteFailureReason
+
+	//SharedFixtures describes each time a SharedFixture was set up and torn down during the run
+	SharedFixtures []*SharedFixtureSummary
+
+	//SuiteProcesses describes the external processes started with StartSuiteProcess, including their output
+	SuiteProcesses []*SuiteProcessSummary
+
+	//AbandonedSpecAttempts lists the specs of parallel runs with -specLeaseTimeout that were handed to another node
+	//because the node that claimed them neither completed them nor heartbeated in time
+	AbandonedSpecAttempts []AbandonedSpecAttempt
+
+	//RuntimeBudgetViolations counts, for each label with a budget in -runtimeBudgets, the specs that ran longer than it
+	RuntimeBudgetViolations map[string]int
+}
+
+//RuntimeBudgetViolation describes a spec that ran longer than the runtime budget of its label, see -runtimeBudgets
+type RuntimeBudgetViolation struct {
+	Label   string
+	Budget  time.Duration
+	RunTime time.Duration
+}
+
+//AbandonedSpecAttempt describes an attempt at running a spec whose lease expired: the spec was handed to another node
+type AbandonedSpecAttempt struct {
+	Node int
+	//Text is the full text of the spec, empty if the node never heartbeated
+	Text         string
+	ClaimedAt    time.Time
+	AbandonedAt  time.Time
+	ReassignedTo int
+}
+
+//SharedFixtureSummary describes one lifetime of a SharedFixture on a parallel node: from the spec whose call to Get set it
+//up, to its teardown after the last spec that declared it, or at the end of the suite (TornDownAfter is then empty)
+type SharedFixtureSummary struct {
+	Name         string
+	CodeLocation CodeLocation
+
+	SetUpBy      string
+	SetUpTime    time.Time
+	SetUpRunTime time.Duration
+
+	TornDownAfter   string
+	TearDownTime    time.Time
+	TearDownRunTime time.Duration
+
+	//NumberOfReferences counts the specs that called Get during the lifetime of the fixture
+	NumberOfReferences int
+	Failure            string
+}
+
+//SuiteProcessSummary describes an external process started once for the whole suite run, on the parallel node that ran it
+type SuiteProcessSummary struct {
+	Name    string
+	Node    int
+	Command string
+	Address string
+
+	StartTime    time.Time
+	ReadyRunTime time.Duration
+	StopTime     time.Time
+
+	//Output holds what the process wrote to its stdout and stderr
+	Output  string
+	Failure string
 }
 
 type SpecSummary struct {
 	ComponentTexts         []string
 	ComponentCodeLocations []CodeLocation
+	Labels                 []string
+
+	//DisplayTexts are the ComponentTexts as the text transformers render them, see ginkgo.RegisterTextTransformer.  They
+	//are only set when transformers are registered, and only serve display: the ComponentTexts identify the spec.
+	DisplayTexts []string `json:",omitempty"`
+
+	//Owners lists who to turn to when the spec fails, see ginkgo.Owner and -codeOwners
+	Owners []string
+	//Severity tells how much the failure of the spec matters, see -failOnSeverity
+	Severity Severity
+	//RequiredFeatures lists the features the spec needs the environment to provide, see ginkgo.RequiresFeature
+	RequiredFeatures []string
+	//HermeticViolations lists the network connections and the files the spec opened without being allowed to, see -hermetic
+	HermeticViolations []string
+
+	//PendingReason explains why a pending spec is pending, see ginkgo.PendingReason
+	PendingReason string
+	//FilterReason explains which of the focus, skip and pending rules selected the spec to run or left it out, see
+	//ginkgo why.  It is empty when no rule applies to the spec.
+	FilterReason string
+
+	//ExpectedFailureReason explains why the spec is expected to fail, see ginkgo.ExpectFailure
+	ExpectedFailureReason string
+	//ExpectedFailure holds the failure of a spec that failed as expected, and was therefore reported as passed
+	ExpectedFailure *SpecFailure
 
 	State           SpecState
+	StartTime       time.Time
 	RunTime         time.Duration
 	Failure         SpecFailure
 	IsMeasurement   bool
 	NumberOfSamples int
 	Measurements    map[string]*SpecMeasurement
 
+	//AdditionalFailures lists the failures reported while the spec ran besides Failure, such as the failures of
+	//goroutines reported by GinkgoRecoverAndReport
+	AdditionalFailures []SpecFailure
+
+	//NodeSummaries describes each of the setup nodes and the subject node that ran as part of the spec, in the order they ran
+	NodeSummaries []*NodeSummary
+
+	//Steps lists the steps announced with By while the spec ran, in order
+	Steps []*StepSummary
+
+	//RandomSeed is the seed math/rand was seeded with before the spec ran, see -seedMathRand
+	RandomSeed int64
+	//Nondeterminism describes the sources of nondeterminism detected while the spec ran, such as time-based random seeds
+	Nondeterminism []string
+
+	//ReportEntries lists the values attached to the report of the spec with ginkgo.AddReportEntry, in order
+	ReportEntries []ReportEntry
+
+	//RuntimeBudgetViolation is set when the spec ran longer than the runtime budget of its label, see -runtimeBudgets
+	RuntimeBudgetViolation *RuntimeBudgetViolation
+
+	//CoveredFunctions lists the functions the spec covered, as file:function, see ginkgo coverage.  It is only set in
+	//the reports ginkgo coverage writes.
+	CoveredFunctions []string
+
 	CapturedOutput string
 	SuiteID        string
 }
 
+//TextsToDisplay returns the DisplayTexts of the spec when the text transformers set them, and its ComponentTexts otherwise
+func (s SpecSummary) TextsToDisplay() []string {
+	if s.DisplayTexts != nil {
+		return s.DisplayTexts
+	}
+	return s.ComponentTexts
+}
+
 func (s SpecSummary) HasFailureState() bool {
 	return s.State.IsFailure()
 }
@@ -84,15 +224,72 @@ func (s SpecSummary) Pending() bool {
 type SetupSummary struct {
 	ComponentType SpecComponentType
 	CodeLocation  CodeLocation
+	//Text is the text of a ReportAfterSuite node, see ginkgo.ReportAfterSuite
+	Text string
 
-	State   SpecState
-	RunTime time.Duration
-	Failure SpecFailure
+	State     SpecState
+	StartTime time.Time
+	RunTime   time.Duration
+	Failure   SpecFailure
 
 	CapturedOutput string
 	SuiteID        string
 }
 
+type NodeSummary struct {
+	ComponentType SpecComponentType
+	CodeLocation  CodeLocation
+
+	State     SpecState
+	StartTime time.Time
+	RunTime   time.Duration
+	Failure   SpecFailure
+}
+
+//NodeInfo describes the node about to run to the hooks registered with BeforeNode
+type NodeInfo struct {
+	ComponentType SpecComponentType
+	CodeLocation  CodeLocation
+	//ComponentTexts are the texts of the containers and of the subject of the spec the node runs for
+	ComponentTexts []string
+	Labels         []string
+}
+
+//HasLabel tells whether the spec the node runs for is labelled with label
+func (info NodeInfo) HasLabel(label string) bool {
+	for _, l := range info.Labels {
+		if l == label {
+			return true
+		}
+	}
+	return false
+}
+
+type StepSummary struct {
+	//Keyword is the Gherkin keyword of the step (Given, When or Then), empty for steps announced with By
+	Keyword      string
+	Text         string
+	CodeLocation CodeLocation
+	StartTime    time.Time
+
+	//RunTime and State are only known for the steps handed a callback, State is SpecStateInvalid for the others
+	RunTime time.Duration
+	State   SpecState
+}
+
+//ProgressReport is a snapshot of the spec that is currently running: the node it is running, the last step it
+//announced with By and the status lines contributed by the progress reporters attached to its SpecContext
+type ProgressReport struct {
+	ComponentTexts []string
+
+	CurrentNodeType         SpecComponentType
+	CurrentNodeCodeLocation CodeLocation
+	CurrentNodeStartTime    time.Time
+	CurrentStep             *StepSummary
+
+	AdditionalReports []string
+}
+
 type SpecFailure struct {
 	Message        string
 	Location       CodeLocation
@@ -101,6 +298,21 @@ type SpecFailure struct {
 	ComponentIndex        int
 	ComponentType         SpecComponentType
 	ComponentCodeLocation CodeLocation
+
+	//Fingerprint identifies the failure across runs, see FailureFingerprint
+	Fingerprint string
+
+	//SnapshotMismatch details the failure of a MatchSnapshot, if that is what failed
+	SnapshotMismatch *SnapshotMismatch
+}
+
+//SnapshotMismatch describes data that does not match the snapshot it is compared against
+type SnapshotMismatch struct {
+	Name     string
+	Path     string
+	Expected string
+	Actual   string
+	Diff     string
 }
 
 type SpecMeasurement struct {
@@ -145,6 +357,24 @@ const (
 	SpecStateTimedOut
 )
 
+func (state SpecState) String() string {
+	switch state {
+	case SpecStatePending:
+		return "pending"
+	case SpecStateSkipped:
+		return "skipped"
+	case SpecStatePassed:
+		return "passed"
+	case SpecStateFailed:
+		return "failed"
+	case SpecStatePanicked:
+		return "panicked"
+	case SpecStateTimedOut:
+		return "timedout"
+	}
+	return "invalid"
+}
+
 func (state SpecState) IsFailure() bool {
 	return state == SpecStateTimedOut || state == SpecStatePanicked || state == SpecStateFailed
 }
@@ -163,8 +393,47 @@ const (
 	SpecComponentTypeAfterEach
 	SpecComponentTypeIt
 	SpecComponentTypeMeasure
+	SpecComponentTypeAroundEach
+	SpecComponentTypeDeferCleanup
+	SpecComponentTypeOnFailure
+	SpecComponentTypeWarmUp
+	SpecComponentTypeReportAfterSuite
 )
 
+func (t SpecComponentType) String() string {
+	switch t {
+	case SpecComponentTypeContainer:
+		return "Container"
+	case SpecComponentTypeBeforeSuite:
+		return "BeforeSuite"
+	case SpecComponentTypeAfterSuite:
+		return "AfterSuite"
+	case SpecComponentTypeBeforeEach:
+		return "BeforeEach"
+	case SpecComponentTypeJustBeforeEach:
+		return "JustBeforeEach"
+	case SpecComponentTypeJustAfterEach:
+		return "JustAfterEach"
+	case SpecComponentTypeAfterEach:
+		return "AfterEach"
+	case SpecComponentTypeIt:
+		return "It"
+	case SpecComponentTypeMeasure:
+		return "Measure"
+	case SpecComponentTypeAroundEach:
+		return "AroundEach"
+	case SpecComponentTypeDeferCleanup:
+		return "DeferCleanup"
+	case SpecComponentTypeOnFailure:
+		return "OnFailure"
+	case SpecComponentTypeWarmUp:
+		return "WarmUp"
+	case SpecComponentTypeReportAfterSuite:
+		return "ReportAfterSuite"
+	}
+	return "Invalid"
+}
+
 type FlagType uint
 
 const (
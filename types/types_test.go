@@ -43,6 +43,28 @@ var _ = Describe("Types", func() {
 		})
 	})
 
+	Describe("SpecState", func() {
+		It("knows how to describe itself", func() {
+			Ω(SpecStatePassed.String()).Should(Equal("passed"))
+			Ω(SpecStateTimedOut.String()).Should(Equal("timedout"))
+			Ω(SpecStatePanicked.String()).Should(Equal("panicked"))
+			Ω(SpecStateFailed.String()).Should(Equal("failed"))
+			Ω(SpecStatePending.String()).Should(Equal("pending"))
+			Ω(SpecStateSkipped.String()).Should(Equal("skipped"))
+			Ω(SpecStateInvalid.String()).Should(Equal("invalid"))
+		})
+	})
+
+	Describe("SpecComponentType", func() {
+		It("knows how to describe itself", func() {
+			Ω(SpecComponentTypeBeforeSuite.String()).Should(Equal("BeforeSuite"))
+			Ω(SpecComponentTypeJustBeforeEach.String()).Should(Equal("JustBeforeEach"))
+			Ω(SpecComponentTypeIt.String()).Should(Equal("It"))
+			Ω(SpecComponentTypeMeasure.String()).Should(Equal("Measure"))
+			Ω(SpecComponentTypeInvalid.String()).Should(Equal("Invalid"))
+		})
+	})
+
 	Describe("SpecSummary", func() {
 		It("knows when it is in a failure-like state", func() {
 			verifySpecSummary(func(summary SpecSummary) bool {